@@ -0,0 +1,5 @@
+// Package api holds the gRPC service of the proxy, generated from proxy.proto. Regenerate it with
+// `make go-gen-protos`, which requires protoc, protoc-gen-go and protoc-gen-go-grpc.
+package api
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative api/proxy.proto
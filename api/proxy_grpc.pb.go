@@ -0,0 +1,296 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/proxy.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Proxy_Put_FullMethodName       = "/eigenda.proxy.v1.Proxy/Put"
+	Proxy_PutStream_FullMethodName = "/eigenda.proxy.v1.Proxy/PutStream"
+	Proxy_Get_FullMethodName       = "/eigenda.proxy.v1.Proxy/Get"
+	Proxy_GetStream_FullMethodName = "/eigenda.proxy.v1.Proxy/GetStream"
+)
+
+// ProxyClient is the client API for Proxy service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProxyClient interface {
+	// Put disperses a payload and returns its commitment. Payloads larger than the maximum message size are sent
+	// with PutStream.
+	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutReply, error)
+	// PutStream disperses a payload sent in chunks, which are concatenated in order. The commitment mode and
+	// commitment are read from the first message.
+	PutStream(ctx context.Context, opts ...grpc.CallOption) (Proxy_PutStreamClient, error)
+	// Get returns the payload of a commitment. Payloads larger than the maximum message size are read with
+	// GetStream.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetReply, error)
+	// GetStream returns the payload of a commitment in chunks.
+	GetStream(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (Proxy_GetStreamClient, error)
+}
+
+type proxyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProxyClient(cc grpc.ClientConnInterface) ProxyClient {
+	return &proxyClient{cc}
+}
+
+func (c *proxyClient) Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutReply, error) {
+	out := new(PutReply)
+	err := c.cc.Invoke(ctx, Proxy_Put_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) PutStream(ctx context.Context, opts ...grpc.CallOption) (Proxy_PutStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Proxy_ServiceDesc.Streams[0], Proxy_PutStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proxyPutStreamClient{stream}
+	return x, nil
+}
+
+type Proxy_PutStreamClient interface {
+	Send(*PutRequest) error
+	CloseAndRecv() (*PutReply, error)
+	grpc.ClientStream
+}
+
+type proxyPutStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *proxyPutStreamClient) Send(m *PutRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *proxyPutStreamClient) CloseAndRecv() (*PutReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PutReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *proxyClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetReply, error) {
+	out := new(GetReply)
+	err := c.cc.Invoke(ctx, Proxy_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *proxyClient) GetStream(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (Proxy_GetStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Proxy_ServiceDesc.Streams[1], Proxy_GetStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &proxyGetStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Proxy_GetStreamClient interface {
+	Recv() (*GetReply, error)
+	grpc.ClientStream
+}
+
+type proxyGetStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *proxyGetStreamClient) Recv() (*GetReply, error) {
+	m := new(GetReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ProxyServer is the server API for Proxy service.
+// All implementations must embed UnimplementedProxyServer
+// for forward compatibility
+type ProxyServer interface {
+	// Put disperses a payload and returns its commitment. Payloads larger than the maximum message size are sent
+	// with PutStream.
+	Put(context.Context, *PutRequest) (*PutReply, error)
+	// PutStream disperses a payload sent in chunks, which are concatenated in order. The commitment mode and
+	// commitment are read from the first message.
+	PutStream(Proxy_PutStreamServer) error
+	// Get returns the payload of a commitment. Payloads larger than the maximum message size are read with
+	// GetStream.
+	Get(context.Context, *GetRequest) (*GetReply, error)
+	// GetStream returns the payload of a commitment in chunks.
+	GetStream(*GetRequest, Proxy_GetStreamServer) error
+	mustEmbedUnimplementedProxyServer()
+}
+
+// UnimplementedProxyServer must be embedded to have forward compatible implementations.
+type UnimplementedProxyServer struct {
+}
+
+func (UnimplementedProxyServer) Put(context.Context, *PutRequest) (*PutReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Put not implemented")
+}
+func (UnimplementedProxyServer) PutStream(Proxy_PutStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutStream not implemented")
+}
+func (UnimplementedProxyServer) Get(context.Context, *GetRequest) (*GetReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedProxyServer) GetStream(*GetRequest, Proxy_GetStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetStream not implemented")
+}
+func (UnimplementedProxyServer) mustEmbedUnimplementedProxyServer() {}
+
+// UnsafeProxyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProxyServer will
+// result in compilation errors.
+type UnsafeProxyServer interface {
+	mustEmbedUnimplementedProxyServer()
+}
+
+func RegisterProxyServer(s grpc.ServiceRegistrar, srv ProxyServer) {
+	s.RegisterService(&Proxy_ServiceDesc, srv)
+}
+
+func _Proxy_Put_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).Put(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Proxy_Put_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).Put(ctx, req.(*PutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_PutStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProxyServer).PutStream(&proxyPutStreamServer{stream})
+}
+
+type Proxy_PutStreamServer interface {
+	SendAndClose(*PutReply) error
+	Recv() (*PutRequest, error)
+	grpc.ServerStream
+}
+
+type proxyPutStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *proxyPutStreamServer) SendAndClose(m *PutReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *proxyPutStreamServer) Recv() (*PutRequest, error) {
+	m := new(PutRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Proxy_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProxyServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Proxy_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProxyServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Proxy_GetStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProxyServer).GetStream(m, &proxyGetStreamServer{stream})
+}
+
+type Proxy_GetStreamServer interface {
+	Send(*GetReply) error
+	grpc.ServerStream
+}
+
+type proxyGetStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *proxyGetStreamServer) Send(m *GetReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Proxy_ServiceDesc is the grpc.ServiceDesc for Proxy service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Proxy_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eigenda.proxy.v1.Proxy",
+	HandlerType: (*ProxyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Put",
+			Handler:    _Proxy_Put_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Proxy_Get_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutStream",
+			Handler:       _Proxy_PutStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetStream",
+			Handler:       _Proxy_GetStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proxy.proto",
+}
@@ -0,0 +1,303 @@
+// Package audit implements the verification of a backup of blobs held by an S3 bucket, e.g. for disaster-recovery
+// audits: every object of the backup is read and checked against the KZG commitment of its DA cert or against its
+// keccak256 commitment, and the commitments expected to be backed up are cross-checked against the listed objects.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/envelope"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// problems of backed up blobs found by an audit
+const (
+	// ProblemMissing ... an expected commitment has no object in the backup
+	ProblemMissing = "missing"
+	// ProblemCorrupt ... an object doesn't match the commitment it is stored under
+	ProblemCorrupt = "corrupt"
+	// ProblemUnreadable ... an object couldn't be read from the backup
+	ProblemUnreadable = "unreadable"
+	// ProblemUnknown ... an object is neither a keccak256 commitment's preimage nor expected, so it can't be
+	// checked. Unknown objects don't fail the audit, e.g. since the cert list may only cover a time range.
+	ProblemUnknown = "unknown"
+)
+
+// DefaultConcurrency ... number of objects read and verified in parallel
+const DefaultConcurrency = 8
+
+// Backup ... the backed up key-value store, e.g. an S3 bucket holding the blobs of a proxy's fallback target
+type Backup interface {
+	store.Lister
+	Get(ctx context.Context, key []byte) ([]byte, error)
+}
+
+// CommitmentVerifier ... checks an encoded blob against the KZG commitment of its cert, e.g. *verify.Verifier
+type CommitmentVerifier interface {
+	VerifyCommitment(expectedCommit *common.G1Commitment, blob []byte) error
+}
+
+// Commitment ... a commitment the backup is expected to hold the blob of
+type Commitment struct {
+	// Key of the blob in the backup: the keccak256 hash of the cert, or the keccak256 commitment itself
+	Key []byte
+	// Cert is the DA cert of the blob, nil for keccak256 commitments
+	Cert []byte
+
+	kzgCommitment *common.G1Commitment
+}
+
+// CertCommitment ... returns the commitment of a DA cert, which is backed up under the keccak256 hash of the cert
+func CertCommitment(cert []byte) (Commitment, error) {
+	var c verify.Certificate
+	if err := rlp.DecodeBytes(cert, &c); err != nil {
+		return Commitment{}, fmt.Errorf("failed to decode DA cert: %w", err)
+	}
+	if c.BlobHeader == nil || c.BlobHeader.Commitment == nil {
+		return Commitment{}, errors.New("DA cert has no blob commitment")
+	}
+	return Commitment{Key: crypto.Keccak256(cert), Cert: cert, kzgCommitment: c.BlobHeader.Commitment}, nil
+}
+
+// KeccakCommitment ... returns a keccak256 commitment, which is backed up under the commitment itself
+func KeccakCommitment(key []byte) Commitment {
+	return Commitment{Key: key}
+}
+
+// ReadCerts ... reads a list of hex encoded DA certs, one per line. Empty lines and lines starting with # are
+// skipped.
+func ReadCerts(r io.Reader) ([]Commitment, error) {
+	var cms []Commitment
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cert, err := hex.DecodeString(strings.TrimPrefix(line, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid hex: %w", n, err)
+		}
+		cm, err := CertCommitment(cert)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		cms = append(cms, cm)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cert list: %w", err)
+	}
+	return cms, nil
+}
+
+// FromIndex ... returns the commitments of a dumped commitment index expected in the backup of backend: the DA
+// certs the index lists a copy of in the backend, and every keccak256 commitment, which are only written to S3
+func FromIndex(records []index.Record, backend string) ([]Commitment, error) {
+	var cms []Commitment
+	for _, r := range records {
+		if r.Mode == string(commitments.OptimismKeccak) {
+			cms = append(cms, KeccakCommitment(r.Key))
+			continue
+		}
+		if _, ok := r.Backends[backend]; !ok {
+			continue
+		}
+		cm, err := CertCommitment(r.Key)
+		if err != nil {
+			return nil, fmt.Errorf("index entry %s: %w", r.Key, err)
+		}
+		cms = append(cms, cm)
+	}
+	return cms, nil
+}
+
+// Finding ... a problem of a backed up blob
+type Finding struct {
+	Key     hexutil.Bytes `json:"key"`
+	Cert    hexutil.Bytes `json:"cert,omitempty"`
+	Problem string        `json:"problem"`
+	Detail  string        `json:"detail,omitempty"`
+}
+
+// Report ... outcome of an audit. The audit passed if no expected blob is missing, corrupt or unreadable.
+type Report struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Expected commitments were cross-checked against the Objects listed from the backup, of which Verified
+	// objects matched their commitment
+	Expected   int       `json:"expected"`
+	Objects    int       `json:"objects"`
+	Verified   int       `json:"verified"`
+	Missing    int       `json:"missing"`
+	Corrupt    int       `json:"corrupt"`
+	Unreadable int       `json:"unreadable"`
+	Unknown    int       `json:"unknown"`
+	Findings   []Finding `json:"findings,omitempty"`
+	Passed     bool      `json:"passed"`
+}
+
+func (r *Report) add(f Finding) {
+	switch f.Problem {
+	case ProblemMissing:
+		r.Missing++
+	case ProblemCorrupt:
+		r.Corrupt++
+	case ProblemUnreadable:
+		r.Unreadable++
+	case ProblemUnknown:
+		r.Unknown++
+	default:
+	}
+	r.Findings = append(r.Findings, f)
+}
+
+// Auditor ... audits a backup
+type Auditor struct {
+	backup      Backup
+	verifier    CommitmentVerifier
+	codec       codecs.BlobCodec
+	concurrency int
+	log         log.Logger
+}
+
+// New ... constructor. Blobs are encoded with codec before being checked against the KZG commitment of their
+// cert. concurrency <= 0 uses DefaultConcurrency.
+func New(backup Backup, verifier CommitmentVerifier, codec codecs.BlobCodec, concurrency int, l log.Logger) *Auditor {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	return &Auditor{
+		backup:      backup,
+		verifier:    verifier,
+		codec:       codec,
+		concurrency: concurrency,
+		log:         l,
+	}
+}
+
+// Run ... lists the objects of the backup, verifies each of them and reports the expected commitments missing
+// from the backup. An error is only returned if the backup couldn't be listed.
+func (a *Auditor) Run(ctx context.Context, expected []Commitment) (Report, error) {
+	rep := Report{Start: time.Now()}
+
+	byKey := make(map[string]Commitment, len(expected))
+	for _, cm := range expected {
+		byKey[string(cm.Key)] = cm
+	}
+	rep.Expected = len(byKey)
+
+	var keys [][]byte
+	err := a.backup.Keys(ctx, func(key []byte) error {
+		keys = append(keys, append([]byte(nil), key...))
+		return nil
+	})
+	if err != nil {
+		return rep, fmt.Errorf("failed to list backup: %w", err)
+	}
+	rep.Objects = len(keys)
+	a.log.Info("Listed backup", "objects", rep.Objects, "expected", rep.Expected)
+
+	listed := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		listed[string(k)] = struct{}{}
+	}
+	for k, cm := range byKey {
+		if _, ok := listed[k]; !ok {
+			rep.add(Finding{Key: cm.Key, Cert: cm.Cert, Problem: ProblemMissing})
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan []byte)
+	for i := 0; i < a.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range jobs {
+				cm, ok := byKey[string(key)]
+				f := a.check(ctx, key, cm, ok)
+
+				mu.Lock()
+				if f != nil {
+					rep.add(*f)
+				} else {
+					rep.Verified++
+				}
+				if checked := rep.Verified + rep.Corrupt + rep.Unreadable + rep.Unknown; checked%1000 == 0 {
+					a.log.Info("Audit progress", "checked", checked, "objects", rep.Objects)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.SliceStable(rep.Findings, func(i, j int) bool {
+		if rep.Findings[i].Problem != rep.Findings[j].Problem {
+			return rep.Findings[i].Problem < rep.Findings[j].Problem
+		}
+		return bytes.Compare(rep.Findings[i].Key, rep.Findings[j].Key) < 0
+	})
+	rep.End = time.Now()
+	rep.Passed = rep.Missing == 0 && rep.Corrupt == 0 && rep.Unreadable == 0
+	return rep, nil
+}
+
+// check ... reads and verifies the object stored under key, returning its problem if any. Objects of unexpected
+// keys are checked as precomputed-key commitments.
+func (a *Auditor) check(ctx context.Context, key []byte, cm Commitment, expected bool) *Finding {
+	data, err := a.backup.Get(ctx, key)
+	if err != nil {
+		return &Finding{Key: key, Cert: cm.Cert, Problem: ProblemUnreadable, Detail: err.Error()}
+	}
+
+	if cm.Cert == nil {
+		// precomputed-key commitments are written as is, never in an envelope
+		hash := store.HashAlgorithmOf(a.backup)
+		if bytes.Equal(hash.Sum(data), key) {
+			return nil
+		}
+		if !expected {
+			return &Finding{Key: key, Problem: ProblemUnknown}
+		}
+		detail := fmt.Sprintf("%s hash of blob does not match commitment", hash)
+		return &Finding{Key: key, Problem: ProblemCorrupt, Detail: detail}
+	}
+
+	if data, err = envelope.Open(data); err != nil {
+		return &Finding{Key: key, Cert: cm.Cert, Problem: ProblemCorrupt, Detail: err.Error()}
+	}
+	encoded, err := a.codec.EncodeBlob(data)
+	if err != nil {
+		return &Finding{Key: key, Cert: cm.Cert, Problem: ProblemCorrupt, Detail: fmt.Sprintf("failed to encode blob: %v", err)}
+	}
+	if err := a.verifier.VerifyCommitment(cm.kzgCommitment, encoded); err != nil {
+		return &Finding{Key: key, Cert: cm.Cert, Problem: ProblemCorrupt, Detail: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+// mapBackup ... in-memory backup, failing reads of the keys in unreadable
+type mapBackup struct {
+	objects    map[string][]byte
+	unreadable map[string]bool
+}
+
+func (b *mapBackup) Keys(_ context.Context, fn func(key []byte) error) error {
+	for k := range b.objects {
+		if err := fn([]byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *mapBackup) Get(_ context.Context, key []byte) ([]byte, error) {
+	if b.unreadable[string(key)] {
+		return nil, errors.New("connection reset")
+	}
+	return b.objects[string(key)], nil
+}
+
+// testCert ... returns an encoded DA cert of a blob with the given commitment
+func testCert(t *testing.T, commitment *common.G1Commitment) []byte {
+	cert, err := rlp.EncodeToBytes(&verify.Certificate{
+		BlobHeader: &disperser.BlobHeader{Commitment: commitment},
+		BlobVerificationProof: &disperser.BlobVerificationProof{
+			BatchMetadata: &disperser.BatchMetadata{BatchHeader: &disperser.BatchHeader{}},
+		},
+	})
+	require.NoError(t, err)
+	return cert
+}
+
+func TestAudit(t *testing.T) {
+	v, err := verify.NewVerifier(&verify.Config{
+		KzgConfig: &kzg.KzgConfig{
+			G1Path:          "../resources/g1.point",
+			G2PowerOf2Path:  "../resources/g2.point.powerOf2",
+			CacheDir:        "../resources/SRSTables",
+			SRSOrder:        3000,
+			SRSNumberToLoad: 3000,
+			NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		},
+	}, nil)
+	require.NoError(t, err)
+	codec := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec())
+
+	// certCommitment ... returns the commitment of a cert committing to blob
+	certCommitment := func(blob []byte) Commitment {
+		encoded, err := codec.EncodeBlob(blob)
+		require.NoError(t, err)
+		commit, err := v.Commit(encoded)
+		require.NoError(t, err)
+		x, y := commit.X.Bytes(), commit.Y.Bytes()
+		cm, err := CertCommitment(testCert(t, &common.G1Commitment{X: x[:], Y: y[:]}))
+		require.NoError(t, err)
+		return cm
+	}
+
+	intact, corrupt, missing, unreadable := certCommitment([]byte("intact")), certCommitment([]byte("corrupt")),
+		certCommitment([]byte("missing")), certCommitment([]byte("unreadable"))
+	preimage := []byte("keccak")
+	keccak := KeccakCommitment(crypto.Keccak256(preimage))
+
+	backup := &mapBackup{
+		objects: map[string][]byte{
+			string(intact.Key):     []byte("intact"),
+			string(corrupt.Key):    []byte("tampered"),
+			string(unreadable.Key): []byte("unreadable"),
+			string(keccak.Key):     preimage,
+			"orphan":               []byte("orphan"),
+		},
+		unreadable: map[string]bool{string(unreadable.Key): true},
+	}
+
+	rep, err := New(backup, v, codec, 2, log.New()).Run(context.Background(),
+		[]Commitment{intact, corrupt, missing, unreadable, keccak})
+	require.NoError(t, err)
+	require.False(t, rep.Passed)
+	require.Equal(t, 5, rep.Expected)
+	require.Equal(t, 5, rep.Objects)
+	require.Equal(t, 2, rep.Verified)
+	require.Equal(t, 1, rep.Missing)
+	require.Equal(t, 1, rep.Corrupt)
+	require.Equal(t, 1, rep.Unreadable)
+	require.Equal(t, 1, rep.Unknown)
+
+	problems := make(map[string]string)
+	for _, f := range rep.Findings {
+		problems[string(f.Key)] = f.Problem
+	}
+	require.Equal(t, map[string]string{
+		string(corrupt.Key):    ProblemCorrupt,
+		string(missing.Key):    ProblemMissing,
+		string(unreadable.Key): ProblemUnreadable,
+		"orphan":               ProblemUnknown,
+	}, problems)
+
+	// unknown objects alone don't fail the audit
+	delete(backup.objects, string(corrupt.Key))
+	delete(backup.objects, string(unreadable.Key))
+	rep, err = New(backup, v, codec, 0, log.New()).Run(context.Background(), []Commitment{intact})
+	require.NoError(t, err)
+	require.True(t, rep.Passed)
+	require.Equal(t, 2, rep.Verified)
+	require.Equal(t, 1, rep.Unknown)
+}
+
+func TestCommitmentSources(t *testing.T) {
+	cert := testCert(t, &common.G1Commitment{})
+	cms, err := ReadCerts(strings.NewReader(fmt.Sprintf("# certs\n\n0x%x\n%x\n", cert, cert)))
+	require.NoError(t, err)
+	require.Len(t, cms, 2)
+	require.Equal(t, crypto.Keccak256(cert), cms[0].Key)
+
+	_, err = ReadCerts(strings.NewReader("0xzz\n"))
+	require.Error(t, err)
+	_, err = ReadCerts(strings.NewReader("0x0102\n"))
+	require.Error(t, err)
+
+	cms, err = FromIndex([]index.Record{
+		{Entry: index.Entry{Key: cert, Backends: map[string]time.Time{"S3": {}}}},
+		{Entry: index.Entry{Key: cert, Backends: map[string]time.Time{"Redis": {}}}},
+		{Entry: index.Entry{Key: []byte("keccak"), Mode: string(commitments.OptimismKeccak)}},
+	}, "S3")
+	require.NoError(t, err)
+	require.Equal(t, []Commitment{
+		{Key: crypto.Keccak256(cert), Cert: cert, kzgCommitment: cms[0].kzgCommitment},
+		KeccakCommitment([]byte("keccak")),
+	}, cms)
+}
@@ -0,0 +1,196 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// altDADerivationVersion ... version byte prefixing batcher transactions that carry an alt-DA commitment
+	altDADerivationVersion = 0x01
+
+	// progressInterval ... number of blocks between progress logs
+	progressInterval = 1000
+)
+
+// Config ... configuration of the historical L1 backfill job
+type Config struct {
+	Enabled    bool
+	EthRPC     string
+	BatchInbox common.Address
+	// Batcher restricts the scan to transactions sent by the batcher, any sender is accepted if unset
+	Batcher    common.Address
+	StartBlock uint64
+	// EndBlock is inclusive, the L1 head at the time the job starts is used if unset
+	EndBlock       uint64
+	PopulateCaches bool
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.EthRPC == "" {
+		return fmt.Errorf("backfill enabled but eth rpc is not set")
+	}
+	if c.BatchInbox == (common.Address{}) {
+		return fmt.Errorf("backfill enabled but batch inbox address is not set")
+	}
+	if c.EndBlock != 0 && c.StartBlock > c.EndBlock {
+		return fmt.Errorf("backfill start block %d is after end block %d", c.StartBlock, c.EndBlock)
+	}
+	return nil
+}
+
+// L1Client ... subset of the ethclient API used by the job
+type L1Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// Backfiller ... indexes commitments found on L1, implemented by store.IRouter
+type Backfiller interface {
+	Backfill(ctx context.Context, cm commitments.CommitmentMode, commitment []byte, populateCaches bool) error
+}
+
+// Result ... summary of a backfill run
+type Result struct {
+	Blocks       uint64
+	Transactions uint64
+	Commitments  uint64
+	Failed       uint64
+}
+
+// Job ... scans an L1 block range for batcher inbox transactions and feeds the EigenDA commitments
+// they carry to the router, so that a freshly deployed proxy can serve historical derivation immediately.
+type Job struct {
+	cfg    Config
+	client L1Client
+	router Backfiller
+	log    log.Logger
+}
+
+// New ... constructor
+func New(cfg Config, client L1Client, router Backfiller, l log.Logger) *Job {
+	return &Job{
+		cfg:    cfg,
+		client: client,
+		router: router,
+		log:    l,
+	}
+}
+
+// Dial ... creates a job backed by an ethclient connected to the configured RPC
+func Dial(ctx context.Context, cfg Config, router Backfiller, l log.Logger) (*Job, error) {
+	client, err := ethclient.DialContext(ctx, cfg.EthRPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial backfill eth rpc: %w", err)
+	}
+	return New(cfg, client, router, l), nil
+}
+
+// Run ... scans the configured block range. Individual commitments that fail to be backfilled are logged
+// and counted, whereas failing to fetch a block aborts the run.
+func (j *Job) Run(ctx context.Context) (Result, error) {
+	var res Result
+
+	end := j.cfg.EndBlock
+	if end == 0 {
+		head, err := j.client.BlockNumber(ctx)
+		if err != nil {
+			return res, fmt.Errorf("failed to fetch L1 head: %w", err)
+		}
+		end = head
+	}
+	if j.cfg.StartBlock > end {
+		return res, fmt.Errorf("start block %d is after end block %d", j.cfg.StartBlock, end)
+	}
+
+	var signer types.Signer
+	if j.cfg.Batcher != (common.Address{}) {
+		chainID, err := j.client.ChainID(ctx)
+		if err != nil {
+			return res, fmt.Errorf("failed to fetch L1 chain id: %w", err)
+		}
+		signer = types.LatestSignerForChainID(chainID)
+	}
+
+	j.log.Info("Starting backfill", "start", j.cfg.StartBlock, "end", end, "inbox", j.cfg.BatchInbox)
+	for n := j.cfg.StartBlock; n <= end; n++ {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		block, err := j.client.BlockByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return res, fmt.Errorf("failed to fetch block %d: %w", n, err)
+		}
+		res.Blocks++
+
+		for _, tx := range block.Transactions() {
+			if !j.fromBatcher(tx, signer) {
+				continue
+			}
+			res.Transactions++
+
+			comm, ok := ExtractCommitment(tx.Data())
+			if !ok {
+				continue
+			}
+			if err := j.router.Backfill(store.WithL1Block(ctx, n), comm.Mode, comm.Cert, j.cfg.PopulateCaches); err != nil {
+				j.log.Warn("Failed to backfill commitment", "block", n, "tx", tx.Hash(), "err", err)
+				res.Failed++
+				continue
+			}
+			res.Commitments++
+		}
+
+		if res.Blocks%progressInterval == 0 {
+			j.log.Info("Backfill progress", "block", n, "end", end, "commitments", res.Commitments, "failed", res.Failed)
+		}
+	}
+
+	j.log.Info("Backfill done", "blocks", res.Blocks, "transactions", res.Transactions,
+		"commitments", res.Commitments, "failed", res.Failed)
+	return res, nil
+}
+
+// fromBatcher ... whether tx was sent to the batch inbox (by the batcher, if configured)
+func (j *Job) fromBatcher(tx *types.Transaction, signer types.Signer) bool {
+	if tx.To() == nil || *tx.To() != j.cfg.BatchInbox {
+		return false
+	}
+	if signer == nil {
+		return true
+	}
+	from, err := types.Sender(signer, tx)
+	return err == nil && from == j.cfg.Batcher
+}
+
+// ExtractCommitment ... parses the EigenDA commitment carried by the calldata of a batcher transaction, i.e.
+// [derivation_version, op_type (generic), da_layer (EigenDA), cert_version, ...]. Returns false for
+// transactions carrying frames or commitments of other DA layers.
+func ExtractCommitment(data []byte) (commitments.Commitment, bool) {
+	if len(data) < 4 ||
+		data[0] != altDADerivationVersion ||
+		data[1] != byte(commitments.GenericCommitmentType) ||
+		data[2] != byte(commitments.EigenDACommitmentType) {
+		return commitments.Commitment{}, false
+	}
+
+	c, err := commitments.ParseCommitment(data[1:], commitments.OptimismGeneric)
+	if err != nil {
+		return commitments.Commitment{}, false
+	}
+	return c, true
+}
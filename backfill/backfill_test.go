@@ -0,0 +1,156 @@
+package backfill
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testChainID = big.NewInt(17000)
+	testInbox   = common.HexToAddress("0xff00000000000000000000000000000000042069")
+)
+
+type fakeL1 struct {
+	blocks []*types.Block
+}
+
+func (f *fakeL1) BlockNumber(_ context.Context) (uint64, error) {
+	return uint64(len(f.blocks) - 1), nil
+}
+
+func (f *fakeL1) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	n := number.Uint64()
+	if n >= uint64(len(f.blocks)) {
+		return nil, fmt.Errorf("block %d not found", n)
+	}
+	return f.blocks[n], nil
+}
+
+func (f *fakeL1) ChainID(_ context.Context) (*big.Int, error) {
+	return testChainID, nil
+}
+
+type fakeRouter struct {
+	backfilled [][]byte
+	l1Blocks   []uint64
+}
+
+func (f *fakeRouter) Backfill(ctx context.Context, _ commitments.CommitmentMode, commitment []byte, _ bool) error {
+	f.backfilled = append(f.backfilled, commitment)
+	if n := store.RequestMetaFromContext(ctx).L1Block; n != nil {
+		f.l1Blocks = append(f.l1Blocks, *n)
+	}
+	return nil
+}
+
+func signedTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address, data []byte) *types.Transaction {
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(testChainID), &types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       100_000,
+		To:        &to,
+		Data:      data,
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func altDACalldata(cert []byte) []byte {
+	comm, _ := commitments.EncodeCommitment(cert, commitments.OptimismGeneric)
+	return append([]byte{altDADerivationVersion}, comm...)
+}
+
+func TestJobRun(t *testing.T) {
+	batcherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	batcher := crypto.PubkeyToAddress(batcherKey.PublicKey)
+
+	other := common.HexToAddress("0x1234")
+	l1 := &fakeL1{blocks: []*types.Block{
+		types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)}),
+		types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{
+			Transactions: []*types.Transaction{
+				signedTx(t, batcherKey, 0, testInbox, altDACalldata([]byte("cert-1"))),
+				// not sent to the inbox
+				signedTx(t, batcherKey, 1, other, altDACalldata([]byte("cert-2"))),
+				// regular frame data
+				signedTx(t, batcherKey, 2, testInbox, []byte{0x00, 0x01, 0x02, 0x03}),
+			},
+		}),
+		types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2)}).WithBody(types.Body{
+			Transactions: []*types.Transaction{
+				// not sent by the batcher
+				signedTx(t, otherKey, 0, testInbox, altDACalldata([]byte("cert-3"))),
+				signedTx(t, batcherKey, 3, testInbox, altDACalldata([]byte("cert-4"))),
+			},
+		}),
+	}}
+
+	t.Run("AnySender", func(t *testing.T) {
+		router := &fakeRouter{}
+		job := New(Config{Enabled: true, BatchInbox: testInbox}, l1, router, log.New())
+
+		res, err := job.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, Result{Blocks: 3, Transactions: 4, Commitments: 3}, res)
+		require.Equal(t, [][]byte{[]byte("cert-1"), []byte("cert-3"), []byte("cert-4")}, router.backfilled)
+		require.Equal(t, []uint64{1, 2, 2}, router.l1Blocks)
+	})
+
+	t.Run("BatcherOnly", func(t *testing.T) {
+		router := &fakeRouter{}
+		job := New(Config{Enabled: true, BatchInbox: testInbox, Batcher: batcher, StartBlock: 2, EndBlock: 2},
+			l1, router, log.New())
+
+		res, err := job.Run(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), res.Blocks)
+		require.Equal(t, [][]byte{[]byte("cert-4")}, router.backfilled)
+	})
+
+	t.Run("MissingBlock", func(t *testing.T) {
+		job := New(Config{Enabled: true, BatchInbox: testInbox, StartBlock: 2, EndBlock: 5}, l1, &fakeRouter{}, log.New())
+		_, err := job.Run(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestExtractCommitment(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		ok   bool
+	}{
+		{name: "EigenDA", data: altDACalldata([]byte("cert")), ok: true},
+		{name: "Frame", data: []byte{0x00, 0x01, 0x02, 0x03}},
+		{name: "Keccak", data: append([]byte{altDADerivationVersion, byte(commitments.Keccak256CommitmentType)}, make([]byte, 32)...)},
+		{name: "OtherDALayer", data: []byte{altDADerivationVersion, byte(commitments.GenericCommitmentType), 0x01, 0x00, 0x01}},
+		{name: "TooShort", data: []byte{altDADerivationVersion, byte(commitments.GenericCommitmentType), 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := ExtractCommitment(tt.data)
+			require.Equal(t, tt.ok, ok)
+			if tt.ok {
+				require.Equal(t, []byte("cert"), c.Cert)
+				require.Equal(t, commitments.OptimismGeneric, c.Mode)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package backfill
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	EnabledFlagName        = withFlagPrefix("enabled")
+	EthRPCFlagName         = withFlagPrefix("eth-rpc")
+	BatchInboxFlagName     = withFlagPrefix("batch-inbox")
+	BatcherFlagName        = withFlagPrefix("batcher")
+	StartBlockFlagName     = withFlagPrefix("start-block")
+	EndBlockFlagName       = withFlagPrefix("end-block")
+	PopulateCachesFlagName = withFlagPrefix("populate-caches")
+)
+
+func withFlagPrefix(s string) string {
+	return "backfill." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_BACKFILL_" + s}
+}
+
+func checkAddress(_ *cli.Context, s string) error {
+	if !common.IsHexAddress(s) {
+		return fmt.Errorf("invalid address %q", s)
+	}
+	return nil
+}
+
+// CLIFlags ... used for the historical L1 backfill job configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:     EnabledFlagName,
+			Usage:    "Whether to scan an L1 block range for batcher inbox transactions on startup and populate the commitment index with the EigenDA commitments found.",
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EthRPCFlagName,
+			Usage:    "JSON RPC node endpoint of the L1 chain to scan.",
+			EnvVars:  withEnvPrefix(envPrefix, "ETH_RPC"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BatchInboxFlagName,
+			Usage:    "Address of the rollup's batch inbox.",
+			EnvVars:  withEnvPrefix(envPrefix, "BATCH_INBOX"),
+			Action:   checkAddress,
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BatcherFlagName,
+			Usage:    "Address of the rollup's batcher. If set, only transactions sent by the batcher are considered.",
+			EnvVars:  withEnvPrefix(envPrefix, "BATCHER"),
+			Action:   checkAddress,
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     StartBlockFlagName,
+			Usage:    "First L1 block to scan.",
+			EnvVars:  withEnvPrefix(envPrefix, "START_BLOCK"),
+			Category: category,
+		},
+		&cli.Uint64Flag{
+			Name:     EndBlockFlagName,
+			Usage:    "Last L1 block to scan (inclusive). Defaults to the L1 head when the job starts.",
+			EnvVars:  withEnvPrefix(envPrefix, "END_BLOCK"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     PopulateCachesFlagName,
+			Usage:    "Whether to also fetch the blobs from EigenDA and write them to the cache and fallback targets.",
+			EnvVars:  withEnvPrefix(envPrefix, "POPULATE_CACHES"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	cfg := Config{
+		Enabled:        ctx.Bool(EnabledFlagName),
+		EthRPC:         ctx.String(EthRPCFlagName),
+		StartBlock:     ctx.Uint64(StartBlockFlagName),
+		EndBlock:       ctx.Uint64(EndBlockFlagName),
+		PopulateCaches: ctx.Bool(PopulateCachesFlagName),
+	}
+	// addresses have already been validated by the flags' actions
+	if s := ctx.String(BatchInboxFlagName); s != "" {
+		cfg.BatchInbox = common.HexToAddress(s)
+	}
+	if s := ctx.String(BatcherFlagName); s != "" {
+		cfg.Batcher = common.HexToAddress(s)
+	}
+	return cfg
+}
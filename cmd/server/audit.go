@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda-proxy/audit"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/snapshot"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	auditCertsFileFlagName   = "certs-file"
+	auditSnapshotFlagName    = "snapshot"
+	auditPrefixFlagName      = "prefix"
+	auditConcurrencyFlagName = "concurrency"
+	auditReportFileFlagName  = "report-file"
+)
+
+// AuditBackupCommand ... verifies the blobs backed up in the S3 bucket configured by the top-level s3 flags, e.g.
+// for disaster-recovery audits. Blobs are checked against the KZG commitments of their certs using the SRS
+// configured by the top-level eigenda flags.
+var AuditBackupCommand = &cli.Command{
+	Name:  "audit-backup",
+	Usage: "Verify the blobs of an S3 backup against their commitments and report missing or corrupt entries",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  auditCertsFileFlagName,
+			Usage: "File listing the hex encoded DA certs expected in the backup, one per line.",
+		},
+		&cli.StringFlag{
+			Name:  auditSnapshotFlagName,
+			Usage: "Snapshot archive whose commitment index lists the commitments expected in the backup.",
+		},
+		&cli.StringFlag{
+			Name:  auditPrefixFlagName,
+			Usage: "Path of the backup in the bucket. Defaults to the path of the top-level s3 flags.",
+		},
+		&cli.IntFlag{
+			Name:  auditConcurrencyFlagName,
+			Usage: "Number of objects read and verified in parallel.",
+			Value: audit.DefaultConcurrency,
+		},
+		&cli.StringFlag{
+			Name:  auditReportFileFlagName,
+			Usage: "File the JSON report is written to. Printed to stdout if unset.",
+		},
+	},
+	Action: AuditBackupAction,
+}
+
+func AuditBackupAction(cliCtx *cli.Context) error {
+	log := secrets.Logger(oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))).New("role", "audit")
+	cliCfg := server.ReadCLIConfig(cliCtx)
+	secrets.Register(cliCfg.Secrets()...)
+	cfg := cliCfg.EigenDAConfig
+
+	expected, err := readExpectedCommitments(cliCtx.String(auditCertsFileFlagName), cliCtx.String(auditSnapshotFlagName))
+	if err != nil {
+		return err
+	}
+
+	s3Cfg := cfg.S3Config
+	if s3Cfg.Bucket == "" || (s3Cfg.Endpoint == "" && s3Cfg.EndpointDiscovery == "") {
+		return errors.New("the backup's S3 bucket and endpoint must be set with the top-level s3 flags")
+	}
+	if prefix := cliCtx.String(auditPrefixFlagName); prefix != "" {
+		s3Cfg.Path = prefix
+	}
+	if s3Cfg.EndpointDiscovery != "" {
+		endpoints, err := utils.ResolveEndpoints(cliCtx.Context, s3Cfg.EndpointDiscovery)
+		if err != nil {
+			return fmt.Errorf("failed to discover S3 endpoint: %w", err)
+		}
+		s3Cfg.Endpoint = utils.SelectEndpoint("", endpoints)
+	}
+	backup, err := s3.NewS3(log, s3Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 store: %w", err)
+	}
+
+	// only the KZG commitments are checked, certs were verified against Ethereum when the blobs were written
+	verifierCfg := cfg.VerifierConfig
+	verifierCfg.VerifyCerts = false
+	verifierCfg.AllowMissingSRS = false
+	verifier, err := verify.NewVerifier(&verifierCfg, log)
+	if err != nil {
+		return fmt.Errorf("failed to create verifier: %w", err)
+	}
+	codec, err := blobCodec(cfg)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Starting backup audit", "bucket", s3Cfg.Bucket, "path", s3Cfg.Path, "expected", len(expected))
+	report, err := audit.New(backup, verifier, codec, cliCtx.Int(auditConcurrencyFlagName), log).
+		Run(cliCtx.Context, expected)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit report: %w", err)
+	}
+	if path := cliCtx.String(auditReportFileFlagName); path != "" {
+		if err := os.WriteFile(path, b, 0o600); err != nil {
+			return fmt.Errorf("failed to write audit report: %w", err)
+		}
+	} else {
+		out := cliCtx.App.Writer
+		if out == nil {
+			out = os.Stdout
+		}
+		fmt.Fprintln(out, string(b))
+	}
+
+	if !report.Passed {
+		return fmt.Errorf("backup audit failed: %d missing, %d corrupt and %d unreadable blobs",
+			report.Missing, report.Corrupt, report.Unreadable)
+	}
+	log.Info("Backup audit passed", "objects", report.Objects, "verified", report.Verified, "unknown", report.Unknown)
+	return nil
+}
+
+// readExpectedCommitments ... reads the commitments expected in the backup from a cert list and/or the index of
+// a snapshot archive
+func readExpectedCommitments(certsFile, snapshotFile string) ([]audit.Commitment, error) {
+	if certsFile == "" && snapshotFile == "" {
+		return nil, fmt.Errorf("at least one of --%s and --%s must be set", auditCertsFileFlagName, auditSnapshotFlagName)
+	}
+
+	var expected []audit.Commitment
+	if certsFile != "" {
+		f, err := os.Open(certsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open cert list: %w", err)
+		}
+		defer f.Close()
+		cms, err := audit.ReadCerts(f)
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, cms...)
+	}
+	if snapshotFile != "" {
+		f, err := os.Open(snapshotFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open snapshot: %w", err)
+		}
+		defer f.Close()
+		s, err := snapshot.Read(f)
+		if err != nil {
+			return nil, err
+		}
+		cms, err := audit.FromIndex(s.Index, store.S3BackendType.String())
+		if err != nil {
+			return nil, err
+		}
+		expected = append(expected, cms...)
+	}
+	return expected, nil
+}
+
+// blobCodec ... returns the codec blobs are encoded with before dispersal, as configured for the EigenDA client
+func blobCodec(cfg server.Config) (codecs.BlobCodec, error) {
+	codec, err := codecs.BlobEncodingVersionToCodec(cfg.EdaClientConfig.PutBlobEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob encoding version: %w", err)
+	}
+	if cfg.EdaClientConfig.DisablePointVerificationMode {
+		return codecs.NewNoIFFTCodec(codec), nil
+	}
+	return codecs.NewIFFTCodec(codec), nil
+}
@@ -3,11 +3,21 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/Layr-Labs/eigenda-proxy/backfill"
 	"github.com/Layr-Labs/eigenda-proxy/flags"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
 	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/snapshot"
+	"github.com/Layr-Labs/eigenda-proxy/standby"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
@@ -15,36 +25,125 @@ import (
 )
 
 func StartProxySvr(cliCtx *cli.Context) error {
-	log := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx)).New("role", "eigenda_proxy")
+	log := secrets.Logger(oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))).New("role", "eigenda_proxy")
 	oplog.SetGlobalLogHandler(log.Handler())
 	log.Info("Starting EigenDA Proxy Server", "version", Version, "date", Date, "commit", Commit)
 
 	cfg := server.ReadCLIConfig(cliCtx)
+	secrets.Register(cfg.Secrets()...)
 	if err := cfg.Check(); err != nil {
 		return err
 	}
 	ctx, ctxCancel := context.WithCancel(cliCtx.Context)
 	defer ctxCancel()
 
-	configJSON, err := json.MarshalIndent(cfg, "", "  ")
+	configJSON, err := json.Marshal(cfg.Redacted())
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
-	log.Info(fmt.Sprintf("Initializing EigenDA proxy server with config: %v", string(configJSON)))
+	log.Info("Initializing EigenDA proxy server", "config", string(configJSON))
 
-	daRouter, err := server.LoadStoreRouter(ctx, cfg, log)
+	versionInfo, err := server.NewVersionInfo(Version, Commit, Date, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create store: %w", err)
+		return err
+	}
+	cfg.ServerOptions.Version = versionInfo
+	log.Info("EigenDA proxy build", "version", versionInfo.Version, "commit", versionInfo.Commit,
+		"eigenda_client", versionInfo.EigenDAClientVersion, "go", versionInfo.GoVersion,
+		"features", strings.Join(versionInfo.Features, ","), "config_hash", versionInfo.ConfigHash)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingCfg, Version)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Error("failed to flush traces", "err", err)
+		}
+	}()
+	if cfg.TracingCfg.Enabled() {
+		log.Info("Exporting traces", "endpoint", cfg.TracingCfg.Endpoint, "protocol", cfg.TracingCfg.Protocol)
 	}
+
 	m := metrics.NewMetrics("default")
-	server := server.NewServer(cliCtx.String(flags.ListenAddrFlagName), cliCtx.Int(flags.PortFlagName), daRouter, log, m)
+	startup := server.NewStartupTracker(m, log)
+	daRouter, err := server.LoadStoreRouter(server.WithStartupTracker(ctx, startup), cfg, log, m)
+	if err != nil {
+		return fmt.Errorf("failed to create store: %w", err)
+	}
+	if cfg.EigenDAConfig.SnapshotRestoreFile != "" {
+		err := startup.Phase(server.StartupPhaseSnapshotRestore, func() error {
+			return restoreSnapshot(ctx, cfg.EigenDAConfig.SnapshotRestoreFile, daRouter, log)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	serverDone := startup.Begin(server.StartupPhaseServer)
+	server := server.NewServer(cliCtx.String(flags.ListenAddrFlagName), cliCtx.Int(flags.PortFlagName), daRouter, log, m,
+		cfg.ServerOptions)
+	server.SetConfig(cfg)
+	server.SetStartupTracker(startup)
 
 	if err := server.Start(); err != nil {
 		return fmt.Errorf("failed to start the DA server: %w", err)
 	}
+	serverDone()
+	startup.Ready()
 
 	log.Info("Started EigenDA proxy server")
 
+	if cfg.EigenDAConfig.BackfillConfig.Enabled {
+		job, err := backfill.Dial(ctx, cfg.EigenDAConfig.BackfillConfig, daRouter, log.With("subsystem", "backfill"))
+		if err != nil {
+			return err
+		}
+		go func() {
+			if _, err := job.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Error("Backfill failed", "err", err)
+			}
+		}()
+	}
+
+	if cfg.EigenDAConfig.StandbyConfig.Enabled() {
+		go standby.New(cfg.EigenDAConfig.StandbyConfig, daRouter, log.With("subsystem", "standby")).Run(ctx)
+	}
+
+	if interval := cfg.EigenDAConfig.DeadLetterRetryInterval; interval > 0 {
+		go store.RetryDeadLetters(ctx, daRouter, interval, cfg.EigenDAConfig.DeadLetterMaxBackoff, m,
+			log.With("subsystem", "dead-letters"))
+	}
+
+	if cfg.EigenDAConfig.ReconcileInterval > 0 {
+		go store.RunReconciliation(ctx, daRouter, cfg.EigenDAConfig.ReconcileInterval)
+	}
+
+	if cfg.EigenDAConfig.Tiering.Enabled() {
+		go store.RunTiering(ctx, daRouter, cfg.EigenDAConfig.Tiering.Interval)
+	}
+
+	if cfg.EigenDAConfig.S3Config.BucketNotifications {
+		go store.WatchRemovals(ctx, daRouter, log.With("subsystem", "removals"))
+	}
+
+	if cfg.EigenDAConfig.WarmPoolInterval > 0 {
+		go store.KeepWarm(ctx, daRouter, cfg.EigenDAConfig.WarmPoolInterval, m, log.With("subsystem", "warm-pool"))
+	}
+
+	if path := cfg.EigenDAConfig.QuotaUsagePath; path != "" {
+		quotas, err := cfg.EigenDAConfig.ParseQuotas()
+		if err != nil {
+			return err
+		}
+		go store.PersistQuotaUsage(ctx, daRouter.Index(), quotas, path, log.With("subsystem", "quotas"))
+		// deferred before stopping the server, so that it runs after the last write was served
+		defer func() {
+			if err := store.SaveQuotaUsage(daRouter.Index(), quotas, path); err != nil {
+				log.Error("Failed to save quota usage", "path", path, "err", err)
+			}
+		}()
+	}
+
 	defer func() {
 		if err := server.Stop(); err != nil {
 			log.Error("failed to stop DA server", "err", err)
@@ -70,3 +169,30 @@ func StartProxySvr(cliCtx *cli.Context) error {
 
 	return ctxinterrupt.Wait(cliCtx.Context)
 }
+
+// restoreSnapshot ... restores the disaster-recovery snapshot archive at path into the router's state
+func restoreSnapshot(ctx context.Context, path string, r store.IRouter, log log.Logger) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	s, err := snapshot.Read(f)
+	if err != nil {
+		return err
+	}
+	report, err := snapshot.Restore(ctx, r, s)
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+
+	log.Info("Restored snapshot", "path", path, "created_at", s.Meta.CreatedAt, "index_entries", report.IndexEntries,
+		"dead_letters", report.DeadLetters, "pending_dispersals", report.PendingDispersals)
+	for backend, n := range report.Missing {
+		if n > 0 {
+			log.Warn("Backend is missing keys listed in the snapshot", "backend", backend, "missing", n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/dataset"
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/snapshot"
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	exportSnapshotFlagName    = "snapshot"
+	exportURLFlagName         = "url"
+	exportFormatFlagName      = "format"
+	exportOutputFlagName      = "output"
+	exportSinceFlagName       = "since"
+	exportUntilFlagName       = "until"
+	exportPayloadsFlagName    = "payload-hashes"
+	exportConcurrencyFlagName = "concurrency"
+
+	// exportPayloadTimeout ... timeout of reading a single payload from the proxy
+	exportPayloadTimeout = 30 * time.Second
+)
+
+// ExportDatasetCommand ... exports the commitment index as a CSV or Parquet dataset, e.g. for DA cost and usage
+// analytics pipelines. The index is read from a snapshot archive, or from a running proxy's admin API if the proxy
+// is started with --admin.enabled.
+var ExportDatasetCommand = &cli.Command{
+	Name:  "export-dataset",
+	Usage: "Export the commitment metadata of the index over a time range as a CSV or Parquet dataset",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  exportSnapshotFlagName,
+			Usage: "Snapshot archive to read the commitment index from. The index of the proxy at --url is read if unset.",
+		},
+		&cli.StringFlag{
+			Name:  exportURLFlagName,
+			Usage: "Base URL of the proxy to read the commitment index and payloads from.",
+			Value: "http://127.0.0.1:3100",
+		},
+		&cli.StringFlag{
+			Name:  exportFormatFlagName,
+			Usage: fmt.Sprintf("Format of the dataset, options are %v.", dataset.Formats),
+			Value: dataset.FormatParquet,
+		},
+		&cli.StringFlag{
+			Name:     exportOutputFlagName,
+			Usage:    "File the dataset is written to.",
+			Required: true,
+		},
+		&cli.TimestampFlag{
+			Name:   exportSinceFlagName,
+			Usage:  "Only export commitments created at or after this RFC 3339 time.",
+			Layout: time.RFC3339,
+		},
+		&cli.TimestampFlag{
+			Name:   exportUntilFlagName,
+			Usage:  "Only export commitments created before this RFC 3339 time.",
+			Layout: time.RFC3339,
+		},
+		&cli.BoolFlag{
+			Name: exportPayloadsFlagName,
+			Usage: "Read the payload of every exported commitment from the proxy at --url to add its keccak256 hash " +
+				"and size. Reads count towards the access statistics of the blobs.",
+		},
+		&cli.IntFlag{
+			Name:  exportConcurrencyFlagName,
+			Usage: "Number of payloads read in parallel.",
+			Value: dataset.DefaultConcurrency,
+		},
+	},
+	Action: ExportDatasetAction,
+}
+
+func ExportDatasetAction(cliCtx *cli.Context) error {
+	log := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx)).New("role", "export")
+	format := cliCtx.String(exportFormatFlagName)
+	if format != dataset.FormatCSV && format != dataset.FormatParquet {
+		return fmt.Errorf("unknown dataset format %s, options are %v", format, dataset.Formats)
+	}
+	baseURL := strings.TrimSuffix(cliCtx.String(exportURLFlagName), "/")
+
+	opts := dataset.Options{Concurrency: cliCtx.Int(exportConcurrencyFlagName)}
+	if t := cliCtx.Timestamp(exportSinceFlagName); t != nil {
+		opts.Since = *t
+	}
+	if t := cliCtx.Timestamp(exportUntilFlagName); t != nil {
+		opts.Until = *t
+	}
+	if !opts.Since.IsZero() && !opts.Until.IsZero() && !opts.Since.Before(opts.Until) {
+		return fmt.Errorf("--%s must be before --%s", exportSinceFlagName, exportUntilFlagName)
+	}
+	if cliCtx.Bool(exportPayloadsFlagName) {
+		opts.Payloads = proxyPayloadReader(baseURL)
+	}
+
+	s, err := readSnapshot(cliCtx.Context, cliCtx.String(exportSnapshotFlagName), baseURL)
+	if err != nil {
+		return err
+	}
+
+	rows, failed := dataset.Build(cliCtx.Context, s.Index, opts, log)
+	if err := cliCtx.Context.Err(); err != nil {
+		return err
+	}
+
+	path := cliCtx.String(exportOutputFlagName)
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create dataset file: %w", err)
+	}
+	if err := dataset.Write(f, format, rows); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write dataset file: %w", err)
+	}
+
+	log.Info("Exported dataset", "file", path, "format", format, "rows", len(rows), "indexed", len(s.Index),
+		"unreadable_payloads", failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to read %d payloads, their hash and size are left empty", failed)
+	}
+	return nil
+}
+
+// readSnapshot ... reads the snapshot archive at path, or takes a snapshot of the proxy at baseURL if path is empty
+func readSnapshot(ctx context.Context, path, baseURL string) (*snapshot.Snapshot, error) {
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open snapshot: %w", err)
+		}
+		defer f.Close()
+		return snapshot.Read(f)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+server.AdminSnapshotRoute, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take snapshot of %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to take snapshot of %s: received bad status code %d: %s", baseURL,
+			resp.StatusCode, string(b))
+	}
+	return snapshot.Read(resp.Body)
+}
+
+// proxyPayloadReader ... reads payloads through the GET route of the proxy at baseURL
+func proxyPayloadReader(baseURL string) dataset.PayloadReader {
+	return func(ctx context.Context, key []byte, mode string) ([]byte, error) {
+		if mode == "" {
+			return nil, errors.New("commitment mode is unknown")
+		}
+		cm, err := commitments.StringToCommitmentMode(mode)
+		if err != nil {
+			return nil, err
+		}
+		comm, err := commitments.EncodeCommitment(key, cm)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, exportPayloadTimeout)
+		defer cancel()
+		url := fmt.Sprintf("%s/get/0x%x?%s=%s", baseURL, comm, server.CommitmentModeKey, cm)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("received bad status code %d: %s", resp.StatusCode, string(b))
+		}
+		return b, nil
+	}
+}
@@ -37,6 +37,10 @@ func main() {
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		StatusCommand,
+		SoakCommand,
+		AuditBackupCommand,
+		ExportDatasetCommand,
 	}
 
 	// load env file (if applicable)
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/soak"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const (
+	soakURLFlagName                 = "url"
+	soakDurationFlagName            = "duration"
+	soakIntervalFlagName            = "interval"
+	soakMaxBlobSizeFlagName         = "max-blob-size"
+	soakRereadsFlagName             = "rereads"
+	soakVerificationFlagName        = "verification"
+	soakMaxHeapGrowthFlagName       = "max-heap-growth"
+	soakMemoryCheckIntervalFlagName = "memory-check-interval"
+	soakReportFileFlagName          = "report-file"
+)
+
+// SoakCommand ... runs a long-running soak test, e.g. before mainnet upgrades. Unless --url is set, the proxy is
+// started in-process with the stack configured by the top-level flags, so that its memory can be checked too.
+var SoakCommand = &cli.Command{
+	Name:  "soak",
+	Usage: "Continuously write and read blobs through the configured stack while asserting invariants",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  soakURLFlagName,
+			Usage: "Base URL of a running proxy to soak instead of starting the configured stack in-process. Its memory isn't checked.",
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:  soakDurationFlagName,
+				Usage: "Duration of the soak test.",
+				Value: 6 * time.Hour,
+			},
+			Min: time.Second,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:  soakIntervalFlagName,
+				Usage: "Interval between blob writes.",
+				Value: time.Second,
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:  soakMaxBlobSizeFlagName,
+			Usage: "Upper bound of the random sizes of written blobs, in bytes.",
+			Value: 128 * 1024,
+		},
+		&cli.IntFlag{
+			Name:  soakRereadsFlagName,
+			Usage: "Number of previously written blobs read again after each write.",
+			Value: 5,
+		},
+		&cli.StringFlag{
+			Name:  soakVerificationFlagName,
+			Usage: "Verification mode every read must report (e.g. kzg+cert). If unset, the mode of the first read is expected.",
+		},
+		&cli.Uint64Flag{
+			Name:  soakMaxHeapGrowthFlagName,
+			Usage: "Maximum growth of the in-process proxy's heap over its baseline, in bytes. Set to 0 to disable.",
+			Value: 512 << 20,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:  soakMemoryCheckIntervalFlagName,
+				Usage: "Interval between heap checks.",
+				Value: time.Minute,
+			},
+			Min: time.Second,
+		},
+		&cli.StringFlag{
+			Name:  soakReportFileFlagName,
+			Usage: "File the JSON report is written to. Printed to stdout if unset.",
+		},
+	},
+	Action: SoakAction,
+}
+
+func SoakAction(cliCtx *cli.Context) error {
+	log := oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx)).New("role", "soak")
+
+	cfg := soak.Config{
+		URL:                 cliCtx.String(soakURLFlagName),
+		Duration:            cliCtx.Duration(soakDurationFlagName),
+		Interval:            cliCtx.Duration(soakIntervalFlagName),
+		MaxBlobSize:         cliCtx.Int(soakMaxBlobSizeFlagName),
+		Rereads:             cliCtx.Int(soakRereadsFlagName),
+		Verification:        cliCtx.String(soakVerificationFlagName),
+		MaxHeapGrowth:       cliCtx.Uint64(soakMaxHeapGrowthFlagName),
+		MemoryCheckInterval: cliCtx.Duration(soakMemoryCheckIntervalFlagName),
+	}
+
+	ctx, cancel := context.WithCancel(cliCtx.Context)
+	defer cancel()
+
+	if cfg.URL == "" {
+		svr, err := startSoakProxy(ctx, cliCtx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := svr.Stop(); err != nil {
+				log.Error("Failed to stop soaked proxy", "err", err)
+			}
+		}()
+		cfg.URL = "http://" + svr.Endpoint()
+	} else {
+		cfg.MaxHeapGrowth = 0
+	}
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+
+	log.Info("Starting soak test", "url", cfg.URL, "duration", cfg.Duration, "interval", cfg.Interval)
+	report := soak.New(cfg, log).Run(ctx)
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal soak report: %w", err)
+	}
+	if path := cliCtx.String(soakReportFileFlagName); path != "" {
+		if err := os.WriteFile(path, b, 0o600); err != nil {
+			return fmt.Errorf("failed to write soak report: %w", err)
+		}
+	} else {
+		out := cliCtx.App.Writer
+		if out == nil {
+			out = os.Stdout
+		}
+		fmt.Fprintln(out, string(b))
+	}
+
+	if !report.Passed {
+		return fmt.Errorf("soak test failed with %d invariant violations", report.ViolationCount)
+	}
+	log.Info("Soak test passed", "writes", report.Writes, "reads", report.Reads)
+	return nil
+}
+
+// startSoakProxy ... starts the proxy configured by the top-level flags on a random local port
+func startSoakProxy(ctx context.Context, cliCtx *cli.Context) (*server.Server, error) {
+	log := secrets.Logger(oplog.NewLogger(oplog.AppOut(cliCtx), oplog.ReadCLIConfig(cliCtx))).New("role", "eigenda_proxy")
+
+	cfg := server.ReadCLIConfig(cliCtx)
+	secrets.Register(cfg.Secrets()...)
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+
+	daRouter, err := server.LoadStoreRouter(ctx, cfg, log, metrics.NoopMetrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+	svr := server.NewServer("127.0.0.1", 0, daRouter, log, metrics.NoopMetrics, cfg.ServerOptions)
+	svr.SetConfig(cfg)
+	if err := svr.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the DA server: %w", err)
+	}
+	return svr, nil
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	statusURLFlagName      = "url"
+	statusIntervalFlagName = "interval"
+	statusOnceFlagName     = "once"
+
+	clearScreen = "\033[H\033[2J"
+)
+
+// StatusCommand ... renders a live terminal dashboard from a running proxy's admin API, for quick
+// on-call triage without Grafana access. The proxy must be started with --admin.enabled.
+var StatusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "Render a live status dashboard from a running proxy's admin API",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  statusURLFlagName,
+			Usage: "Base URL of the proxy to connect to.",
+			Value: "http://127.0.0.1:3100",
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:  statusIntervalFlagName,
+				Usage: "Refresh interval of the dashboard.",
+				Value: 2 * time.Second,
+			},
+			Min: time.Millisecond,
+		},
+		&cli.BoolFlag{
+			Name:  statusOnceFlagName,
+			Usage: "Print the status once and exit instead of refreshing.",
+		},
+	},
+	Action: StatusAction,
+}
+
+func StatusAction(cliCtx *cli.Context) error {
+	url := strings.TrimSuffix(cliCtx.String(statusURLFlagName), "/") + server.AdminStatusRoute
+	out := cliCtx.App.Writer
+	if out == nil {
+		out = os.Stdout
+	}
+
+	if cliCtx.Bool(statusOnceFlagName) {
+		rep, err := fetchStatus(cliCtx.Context, url)
+		if err != nil {
+			return err
+		}
+		renderStatus(out, url, rep)
+		return nil
+	}
+
+	ticker := time.NewTicker(cliCtx.Duration(statusIntervalFlagName))
+	defer ticker.Stop()
+
+	for {
+		rep, err := fetchStatus(cliCtx.Context, url)
+		fmt.Fprint(out, clearScreen)
+		if err != nil {
+			fmt.Fprintf(out, "failed to fetch status from %s: %v\n", url, err)
+		} else {
+			renderStatus(out, url, rep)
+		}
+
+		select {
+		case <-cliCtx.Context.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func fetchStatus(ctx context.Context, url string) (server.StatusReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return server.StatusReport{}, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return server.StatusReport{}, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return server.StatusReport{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return server.StatusReport{}, fmt.Errorf("received bad status code %d: %s", resp.StatusCode, string(b))
+	}
+
+	var rep server.StatusReport
+	if err := json.Unmarshal(b, &rep); err != nil {
+		return server.StatusReport{}, fmt.Errorf("failed to decode status report: %w", err)
+	}
+	return rep, nil
+}
+
+func renderStatus(out io.Writer, url string, rep server.StatusReport) {
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	fmt.Fprintf(tw, "EigenDA Proxy status (%s) - up %s\n\n", url, rep.Uptime.Truncate(time.Second))
+
+	fmt.Fprintln(tw, "BACKEND\tROLE\tENTRIES\tREADS")
+	for _, b := range rep.Backends {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\n", b.Backend, b.Role, b.Entries, b.Reads)
+	}
+
+	fmt.Fprintln(tw, "\nMETHOD\tIN FLIGHT\tSUCCESS\tERROR")
+	for _, method := range sortedKeys(rep.Requests) {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", method, rep.InFlight[method],
+			rep.Requests[method]["success"], rep.Requests[method]["error"])
+	}
+
+	fmt.Fprintln(tw, "\nREADS BY BACKEND\tCOUNT")
+	for _, backend := range sortedKeys(rep.Reads) {
+		fmt.Fprintf(tw, "%s\t%d\n", backend, rep.Reads[backend])
+	}
+	fmt.Fprintf(tw, "cache hit rate\t%.1f%%\n", rep.CacheHitRate*100)
+
+	fmt.Fprintf(tw, "\ndispersal throughput\t%d blobs/min\t%d bytes/min\n",
+		rep.Throughput.BlobsPerMinute, rep.Throughput.BytesPerMinute)
+
+	fmt.Fprintf(tw, "\nRECENT ERRORS (%d)\n", len(rep.RecentErrors))
+	for i := len(rep.RecentErrors) - 1; i >= 0; i-- {
+		e := rep.RecentErrors[i]
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", e.Time.Format(time.TimeOnly), e.Method, e.Error)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
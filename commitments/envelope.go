@@ -0,0 +1,211 @@
+package commitments
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// CertV1Envelope denotes a commitment whose payload is an Envelope rather than a bare DA cert.
+const CertV1Envelope CertEncodingCommitment = 1
+
+// EnvelopeVersion is the serialization version of an Envelope, allowing its layout to evolve
+// independently of the cert version byte.
+type EnvelopeVersion byte
+
+const (
+	EnvelopeV0 EnvelopeVersion = 0
+)
+
+// Compression describes how the payload was compressed before dispersal.
+type Compression uint8
+
+const (
+	CompressionNone Compression = 0
+)
+
+var (
+	ErrEnvelopeTooShort          = errors.New("envelope is too short")
+	ErrUnknownEnvelopeVersion    = errors.New("unknown envelope version")
+	ErrInvalidEnvelopeMetadata   = errors.New("invalid envelope metadata")
+	ErrEmptyEnvelopeCert         = errors.New("envelope contains no DA cert")
+	ErrUnsupportedCertVersion    = errors.New("unsupported cert version")
+	ErrUnsupportedCommitmentMode = errors.New("unsupported commitment mode")
+)
+
+// EnvelopeChunk describes a single chunk of a payload that was split across multiple blobs.
+type EnvelopeChunk struct {
+	Size uint64
+	Hash [32]byte
+}
+
+// EnvelopeMetadata describes how the payload behind the DA cert is encoded.
+type EnvelopeMetadata struct {
+	EncodingVersion uint8
+	Compression     Compression
+	Tenant          string
+	Chunks          []EnvelopeChunk
+}
+
+// Envelope is a multi-part commitment payload holding a metadata section alongside the DA cert.
+//
+// Serialized layout (EnvelopeV0):
+//
+//	[envelope_version (1 byte), metadata_length (uvarint), metadata (RLP), cert (remaining bytes)]
+type Envelope struct {
+	Version EnvelopeVersion
+	Meta    EnvelopeMetadata
+	Cert    []byte
+}
+
+// Encode serializes the envelope.
+func (e Envelope) Encode() ([]byte, error) {
+	if e.Version != EnvelopeV0 {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownEnvelopeVersion, e.Version)
+	}
+	if len(e.Cert) == 0 {
+		return nil, ErrEmptyEnvelopeCert
+	}
+
+	meta, err := rlp.EncodeToBytes(&e.Meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode envelope metadata: %w", err)
+	}
+
+	b := make([]byte, 0, 1+binary.MaxVarintLen64+len(meta)+len(e.Cert))
+	b = append(b, byte(e.Version))
+	b = binary.AppendUvarint(b, uint64(len(meta)))
+	b = append(b, meta...)
+	b = append(b, e.Cert...)
+	return b, nil
+}
+
+// DecodeEnvelope parses a serialized envelope.
+func DecodeEnvelope(b []byte) (Envelope, error) {
+	if len(b) < 2 {
+		return Envelope{}, ErrEnvelopeTooShort
+	}
+
+	version := EnvelopeVersion(b[0])
+	if version != EnvelopeV0 {
+		return Envelope{}, fmt.Errorf("%w: %d", ErrUnknownEnvelopeVersion, version)
+	}
+
+	metaLen, n := binary.Uvarint(b[1:])
+	if n <= 0 {
+		return Envelope{}, fmt.Errorf("%w: malformed metadata length", ErrInvalidEnvelopeMetadata)
+	}
+	rest := b[1+n:]
+	if metaLen > uint64(len(rest)) {
+		return Envelope{}, fmt.Errorf("%w: metadata length %d exceeds envelope size", ErrEnvelopeTooShort, metaLen)
+	}
+
+	var meta EnvelopeMetadata
+	if err := rlp.DecodeBytes(rest[:metaLen], &meta); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %w", ErrInvalidEnvelopeMetadata, err)
+	}
+
+	cert := rest[metaLen:]
+	if len(cert) == 0 {
+		return Envelope{}, ErrEmptyEnvelopeCert
+	}
+
+	return Envelope{
+		Version: version,
+		Meta:    meta,
+		Cert:    append([]byte(nil), cert...),
+	}, nil
+}
+
+// Commitment is the structured form of a commitment received by the server, decoupling handlers from
+// the byte layout of each commitment mode and cert version.
+type Commitment struct {
+	Mode        CommitmentMode
+	CertVersion CertEncodingCommitment
+	// Cert is the DA cert (or hash key in OptimismKeccak mode) used to look up the blob
+	Cert []byte
+	// Hash is the hash algorithm of OptimismKeccak commitments, recorded in their commitment type byte
+	Hash HashAlgorithm
+	// Envelope is set for CertV1Envelope commitments
+	Envelope *Envelope
+}
+
+// Meta returns the CommitmentMeta used for metrics and logging.
+func (c Commitment) Meta() CommitmentMeta {
+	return CommitmentMeta{Mode: c.Mode, CertVersion: byte(c.CertVersion)}
+}
+
+// ParseCommitment decodes a commitment without its hex encoding into its structured form.
+func ParseCommitment(b []byte, mode CommitmentMode) (Commitment, error) {
+	var versioned []byte // [cert_version, ...]
+
+	switch mode {
+	case OptimismKeccak: // [op_type, ...]
+		if len(b) < 2 {
+			return Commitment{}, ErrInvalidCommitment
+		}
+		hash, ok := HashAlgorithmFromCommitmentType(OPCommitmentType(b[0]))
+		if !ok {
+			return Commitment{}, fmt.Errorf("%w: unknown commitment type %d", ErrInvalidCommitment, b[0])
+		}
+		return Commitment{Mode: mode, Cert: b[1:], Hash: hash}, nil
+
+	case OptimismGeneric: // [op_type, da_provider, cert_version, ...]
+		if len(b) < 3 {
+			return Commitment{}, ErrInvalidCommitment
+		}
+		versioned = b[2:]
+
+	case SimpleCommitmentMode: // [cert_version, ...]
+		versioned = b
+
+	default:
+		return Commitment{}, fmt.Errorf("%w: %s", ErrUnsupportedCommitmentMode, mode)
+	}
+
+	if len(versioned) < 2 {
+		return Commitment{}, ErrInvalidCommitment
+	}
+
+	c := Commitment{Mode: mode, CertVersion: CertEncodingCommitment(versioned[0])}
+	switch c.CertVersion {
+	case CertV0:
+		c.Cert = versioned[1:]
+
+	case CertV1Envelope:
+		env, err := DecodeEnvelope(versioned[1:])
+		if err != nil {
+			return Commitment{}, err
+		}
+		c.Cert = env.Cert
+		c.Envelope = &env
+
+	default:
+		return Commitment{}, fmt.Errorf("%w: %d", ErrUnsupportedCertVersion, c.CertVersion)
+	}
+
+	return c, nil
+}
+
+// EncodeEnvelopeCommitment wraps an envelope into a commitment of the given mode.
+func EncodeEnvelopeCommitment(env Envelope, mode CommitmentMode) ([]byte, error) {
+	payload, err := env.Encode()
+	if err != nil {
+		return nil, err
+	}
+	certCommit := append([]byte{byte(CertV1Envelope)}, payload...)
+
+	switch mode {
+	case OptimismGeneric:
+		svcCommit := EigenDASvcCommitment(certCommit).Encode()
+		return NewGenericCommitment(svcCommit).Encode(), nil
+	case SimpleCommitmentMode:
+		return certCommit, nil
+	case OptimismKeccak:
+		return nil, fmt.Errorf("%w: envelopes are not supported in %s mode", ErrUnsupportedCommitmentMode, mode)
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedCommitmentMode, mode)
+}
@@ -0,0 +1,144 @@
+package commitments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testEnvelope() Envelope {
+	return Envelope{
+		Version: EnvelopeV0,
+		Meta: EnvelopeMetadata{
+			EncodingVersion: 1,
+			Compression:     CompressionNone,
+			Tenant:          "rollup-a",
+			Chunks: []EnvelopeChunk{
+				{Size: 1024, Hash: [32]byte{0x01}},
+				{Size: 512, Hash: [32]byte{0x02}},
+			},
+		},
+		Cert: []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	for _, env := range []Envelope{
+		testEnvelope(),
+		{Version: EnvelopeV0, Meta: EnvelopeMetadata{Chunks: []EnvelopeChunk{}}, Cert: []byte{0x01}},
+	} {
+		b, err := env.Encode()
+		require.NoError(t, err)
+
+		decoded, err := DecodeEnvelope(b)
+		require.NoError(t, err)
+		require.Equal(t, env, decoded)
+	}
+}
+
+func TestEnvelopeEncodeErrors(t *testing.T) {
+	env := testEnvelope()
+	env.Version = 1
+	_, err := env.Encode()
+	require.ErrorIs(t, err, ErrUnknownEnvelopeVersion)
+
+	env = testEnvelope()
+	env.Cert = nil
+	_, err = env.Encode()
+	require.ErrorIs(t, err, ErrEmptyEnvelopeCert)
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	valid, err := testEnvelope().Encode()
+	require.NoError(t, err)
+
+	// metadata section without the trailing cert
+	withoutCert := valid[:len(valid)-len(testEnvelope().Cert)]
+
+	tests := []struct {
+		name   string
+		input  []byte
+		expErr error
+	}{
+		{name: "Empty", input: nil, expErr: ErrEnvelopeTooShort},
+		{name: "VersionOnly", input: []byte{0x00}, expErr: ErrEnvelopeTooShort},
+		{name: "UnknownVersion", input: append([]byte{0x01}, valid[1:]...), expErr: ErrUnknownEnvelopeVersion},
+		{name: "MalformedLength", input: []byte{0x00, 0xff}, expErr: ErrInvalidEnvelopeMetadata},
+		{name: "LengthExceedsEnvelope", input: []byte{0x00, 0x7f, 0xc0}, expErr: ErrEnvelopeTooShort},
+		{name: "MetadataNotRLP", input: []byte{0x00, 0x01, 0xff, 0x01}, expErr: ErrInvalidEnvelopeMetadata},
+		{name: "MetadataWrongShape", input: []byte{0x00, 0x01, 0x80, 0x01}, expErr: ErrInvalidEnvelopeMetadata},
+		{name: "MissingCert", input: withoutCert, expErr: ErrEmptyEnvelopeCert},
+		{name: "Valid", input: valid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeEnvelope(tt.input)
+			if tt.expErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expErr)
+		})
+	}
+}
+
+func TestParseCommitment(t *testing.T) {
+	env := testEnvelope()
+	cert := []byte{0xca, 0xfe}
+
+	generic, err := EncodeCommitment(cert, OptimismGeneric)
+	require.NoError(t, err)
+	simple, err := EncodeCommitment(cert, SimpleCommitmentMode)
+	require.NoError(t, err)
+	keccak, err := EncodeCommitment(cert, OptimismKeccak)
+	require.NoError(t, err)
+	genericEnv, err := EncodeEnvelopeCommitment(env, OptimismGeneric)
+	require.NoError(t, err)
+	simpleEnv, err := EncodeEnvelopeCommitment(env, SimpleCommitmentMode)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		input       []byte
+		mode        CommitmentMode
+		expVersion  CertEncodingCommitment
+		expCert     []byte
+		expEnvelope bool
+		expErr      error
+	}{
+		{name: "GenericV0", input: generic, mode: OptimismGeneric, expVersion: CertV0, expCert: cert},
+		{name: "SimpleV0", input: simple, mode: SimpleCommitmentMode, expVersion: CertV0, expCert: cert},
+		{name: "Keccak", input: keccak, mode: OptimismKeccak, expCert: cert},
+		{name: "GenericEnvelope", input: genericEnv, mode: OptimismGeneric, expVersion: CertV1Envelope, expCert: env.Cert, expEnvelope: true},
+		{name: "SimpleEnvelope", input: simpleEnv, mode: SimpleCommitmentMode, expVersion: CertV1Envelope, expCert: env.Cert, expEnvelope: true},
+		{name: "UnknownCertVersion", input: []byte{0x07, 0x01}, mode: SimpleCommitmentMode, expErr: ErrUnsupportedCertVersion},
+		{name: "CorruptEnvelope", input: []byte{byte(CertV1Envelope), 0x05, 0x01}, mode: SimpleCommitmentMode, expErr: ErrUnknownEnvelopeVersion},
+		{name: "GenericTooShort", input: []byte{0x01, 0x00, 0x00}, mode: OptimismGeneric, expErr: ErrInvalidCommitment},
+		{name: "SimpleTooShort", input: []byte{0x00}, mode: SimpleCommitmentMode, expErr: ErrInvalidCommitment},
+		{name: "KeccakTooShort", input: []byte{0x00}, mode: OptimismKeccak, expErr: ErrInvalidCommitment},
+		{name: "Blake3", input: EncodeHashCommitment(Blake3Hash, cert), mode: OptimismKeccak, expCert: cert},
+		{name: "UnknownHash", input: []byte{0x05, 0x01}, mode: OptimismKeccak, expErr: ErrInvalidCommitment},
+		{name: "UnknownMode", input: simple, mode: "unknown", expErr: ErrUnsupportedCommitmentMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCommitment(tt.input, tt.mode)
+			if tt.expErr != nil {
+				require.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expVersion, c.CertVersion)
+			require.Equal(t, tt.expCert, c.Cert)
+			require.Equal(t, tt.expEnvelope, c.Envelope != nil)
+			if tt.expEnvelope {
+				require.Equal(t, env, *c.Envelope)
+			}
+		})
+	}
+
+	_, err = EncodeEnvelopeCommitment(env, OptimismKeccak)
+	require.ErrorIs(t, err, ErrUnsupportedCommitmentMode)
+}
@@ -0,0 +1,82 @@
+package commitments
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"lukechampine.com/blake3"
+)
+
+// HashAlgorithm is the hash function of precomputed-key (OptimismKeccak mode) commitments, which are the hash of
+// their preimage. The algorithm is recorded in the commitment type byte of the commitment.
+type HashAlgorithm string
+
+const (
+	Keccak256Hash HashAlgorithm = "keccak256"
+	Sha256Hash    HashAlgorithm = "sha256"
+	Blake3Hash    HashAlgorithm = "blake3"
+)
+
+// HashAlgorithms lists the supported hash algorithms.
+var HashAlgorithms = []HashAlgorithm{Keccak256Hash, Sha256Hash, Blake3Hash}
+
+// StringToHashAlgorithm parses a hash algorithm, the empty string being keccak256.
+func StringToHashAlgorithm(s string) (HashAlgorithm, error) {
+	switch HashAlgorithm(s) {
+	case "", Keccak256Hash:
+		return Keccak256Hash, nil
+	case Sha256Hash:
+		return Sha256Hash, nil
+	case Blake3Hash:
+		return Blake3Hash, nil
+	default:
+		return "", fmt.Errorf("unknown hash algorithm %s, options are %v", s, HashAlgorithms)
+	}
+}
+
+// HashAlgorithmFromCommitmentType returns the hash algorithm recorded by the commitment type byte of a
+// precomputed-key commitment.
+func HashAlgorithmFromCommitmentType(t OPCommitmentType) (HashAlgorithm, bool) {
+	switch t {
+	case Keccak256CommitmentType:
+		return Keccak256Hash, true
+	case Sha256CommitmentType:
+		return Sha256Hash, true
+	case Blake3CommitmentType:
+		return Blake3Hash, true
+	default:
+		return "", false
+	}
+}
+
+// CommitmentType returns the commitment type byte recording the hash algorithm. The empty algorithm is keccak256.
+func (h HashAlgorithm) CommitmentType() OPCommitmentType {
+	switch h {
+	case Sha256Hash:
+		return Sha256CommitmentType
+	case Blake3Hash:
+		return Blake3CommitmentType
+	default:
+		return Keccak256CommitmentType
+	}
+}
+
+// Sum returns the 32 byte hash of data. The empty algorithm is keccak256.
+func (h HashAlgorithm) Sum(data []byte) []byte {
+	switch h {
+	case Sha256Hash:
+		sum := sha256.Sum256(data)
+		return sum[:]
+	case Blake3Hash:
+		sum := blake3.Sum256(data)
+		return sum[:]
+	default:
+		return crypto.Keccak256(data)
+	}
+}
+
+// EncodeHashCommitment adds the commitment type prefix of the hash algorithm to a precomputed-key commitment.
+func EncodeHashCommitment(h HashAlgorithm, hash []byte) []byte {
+	return append([]byte{byte(h.CommitmentType())}, hash...)
+}
@@ -0,0 +1,39 @@
+package commitments
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashAlgorithm(t *testing.T) {
+	// hashes of the empty string
+	tests := map[HashAlgorithm]string{
+		Keccak256Hash: "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470",
+		Sha256Hash:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		Blake3Hash:    "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262",
+	}
+	for h, expected := range tests {
+		require.Equal(t, expected, hex.EncodeToString(h.Sum(nil)), h)
+
+		parsed, err := StringToHashAlgorithm(string(h))
+		require.NoError(t, err)
+		require.Equal(t, h, parsed)
+
+		// the algorithm is recorded in the commitment type byte
+		c, err := ParseCommitment(EncodeHashCommitment(h, h.Sum(nil)), OptimismKeccak)
+		require.NoError(t, err)
+		require.Equal(t, h, c.Hash)
+		require.Equal(t, h.Sum(nil), c.Cert)
+	}
+
+	// keccak256 is the default, so that OP commitments keep their type byte
+	h, err := StringToHashAlgorithm("")
+	require.NoError(t, err)
+	require.Equal(t, Keccak256Hash, h)
+	require.Equal(t, Keccak256CommitmentType, HashAlgorithm("").CommitmentType())
+
+	_, err = StringToHashAlgorithm("md5")
+	require.Error(t, err)
+}
@@ -3,6 +3,8 @@ package commitments
 import (
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 type CommitmentMeta struct {
@@ -19,6 +21,22 @@ const (
 	SimpleCommitmentMode CommitmentMode = "simple"
 )
 
+// modeAliases ... alternative names of the commitment modes accepted from clients
+var modeAliases = map[string]CommitmentMode{
+	"standard":        SimpleCommitmentMode,
+	"optimism_keccak": OptimismKeccak,
+}
+
+// ModeNames ... lists every name a commitment mode is accepted under by StringToCommitmentMode
+func ModeNames() []string {
+	names := []string{string(OptimismKeccak), string(OptimismGeneric), string(SimpleCommitmentMode)}
+	for alias := range modeAliases {
+		names = append(names, alias)
+	}
+	sort.Strings(names[3:])
+	return names
+}
+
 func StringToCommitmentMode(s string) (CommitmentMode, error) {
 	switch s {
 	case string(OptimismKeccak):
@@ -28,6 +46,9 @@ func StringToCommitmentMode(s string) (CommitmentMode, error) {
 	case string(SimpleCommitmentMode):
 		return SimpleCommitmentMode, nil
 	default:
+		if m, ok := modeAliases[s]; ok {
+			return m, nil
+		}
 		return "", fmt.Errorf("unknown commitment mode: %s", s)
 	}
 }
@@ -62,6 +83,15 @@ func StringToDecodedCommitment(key string, c CommitmentMode) ([]byte, error) {
 	}
 }
 
+// StringToCommitment decodes a hex encoded commitment into its structured form.
+func StringToCommitment(key string, c CommitmentMode) (Commitment, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return Commitment{}, err
+	}
+	return ParseCommitment(b, c)
+}
+
 func EncodeCommitment(b []byte, c CommitmentMode) ([]byte, error) {
 	switch c {
 	case OptimismKeccak:
@@ -31,9 +31,13 @@ func CommitmentTypeFromString(s string) (OPCommitmentType, error) {
 // CommitmentType describes the binary format of the commitment.
 // KeccakCommitmentStringType is the default commitment type for the centralized DA storage.
 // GenericCommitmentType indicates an opaque bytestring that the op-node never opens.
+// Sha256CommitmentType and Blake3CommitmentType are precomputed-key commitments of other hash algorithms, for
+// consumers other than the op-node. They are outside of the range of OP's commitment types.
 const (
 	Keccak256CommitmentType OPCommitmentType = 0
 	GenericCommitmentType   OPCommitmentType = 1
+	Sha256CommitmentType    OPCommitmentType = 0x10
+	Blake3CommitmentType    OPCommitmentType = 0x11
 	KeccakCommitmentString  string           = "KeccakCommitment"
 	GenericCommitmentString string           = "GenericCommitment"
 )
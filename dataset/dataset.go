@@ -0,0 +1,256 @@
+// Package dataset implements exports of the commitment index as tabular datasets, e.g. for DA cost and usage
+// analytics pipelines: one row per commitment created within a time range, with its metadata and optionally the
+// hash and size of its payload, written as CSV or Parquet.
+package dataset
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/parquet-go/parquet-go"
+)
+
+// formats of an exported dataset
+const (
+	FormatCSV     = "csv"
+	FormatParquet = "parquet"
+)
+
+// Formats ... lists the supported formats
+var Formats = []string{FormatCSV, FormatParquet}
+
+// DefaultConcurrency ... number of payloads read in parallel
+const DefaultConcurrency = 8
+
+// Row ... metadata of a single commitment. Times are UNIX milliseconds, optional columns are nil if unknown, except
+// for LastAccess which is 0, i.e. the UNIX epoch, if the commitment was never read.
+type Row struct {
+	Seq          int64   `parquet:"seq"`
+	Commitment   string  `parquet:"commitment"`
+	Mode         string  `parquet:"mode"`
+	SizeBytes    int64   `parquet:"size_bytes"`
+	CreatedAt    int64   `parquet:"created_at,timestamp(millisecond)"`
+	Tenant       string  `parquet:"tenant"`
+	RollupHeight *int64  `parquet:"rollup_height,optional"`
+	L1Block      *int64  `parquet:"l1_block,optional"`
+	Backends     string  `parquet:"backends"`
+	Archived     string  `parquet:"archived"`
+	RequestID    string  `parquet:"request_id"`
+	Reads        int64   `parquet:"reads"`
+	LastAccess   int64   `parquet:"last_access,timestamp(millisecond)"`
+	PayloadHash  *string `parquet:"payload_keccak256,optional"`
+	PayloadSize  *int64  `parquet:"payload_size,optional"`
+}
+
+// columns ... names of the columns of a dataset, in the order of the fields of Row
+var columns = []string{
+	"seq", "commitment", "mode", "size_bytes", "created_at", "tenant", "rollup_height", "l1_block", "backends",
+	"archived", "request_id", "reads", "last_access", "payload_keccak256", "payload_size",
+}
+
+// PayloadReader ... reads the payload of a commitment of the index, e.g. through the proxy's GET route
+type PayloadReader func(ctx context.Context, key []byte, mode string) ([]byte, error)
+
+// Options ... selects the commitments of a dataset and the columns filled
+type Options struct {
+	// Since and Until bound the creation time of the exported commitments, Since inclusive and Until exclusive.
+	// Zero times leave the range open.
+	Since, Until time.Time
+	// Payloads reads the payloads to fill the payload hash and size columns with, which are left empty if nil
+	Payloads PayloadReader
+	// Concurrency is the number of payloads read in parallel, <= 0 uses DefaultConcurrency
+	Concurrency int
+}
+
+// Build ... returns the rows of the records created within the range of opts, oldest first, and the number of
+// payloads which couldn't be read. The payload columns of those rows are left empty.
+func Build(ctx context.Context, records []index.Record, opts Options, l log.Logger) ([]Row, int) {
+	var selected []index.Record
+	for _, r := range records {
+		if !opts.Since.IsZero() && r.CreatedAt.Before(opts.Since) {
+			continue
+		}
+		if !opts.Until.IsZero() && !r.CreatedAt.Before(opts.Until) {
+			continue
+		}
+		selected = append(selected, r)
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Seq < selected[j].Seq })
+
+	rows := make([]Row, len(selected))
+	for i, r := range selected {
+		rows[i] = newRow(r.Entry)
+	}
+	if opts.Payloads == nil {
+		return rows, 0
+	}
+	return rows, readPayloads(ctx, selected, rows, opts, l)
+}
+
+// newRow ... returns the row of an index entry
+func newRow(e index.Entry) Row {
+	row := Row{
+		Seq:        int64(e.Seq),
+		Commitment: e.Key.String(),
+		Mode:       e.Mode,
+		SizeBytes:  int64(e.Size),
+		CreatedAt:  e.CreatedAt.UnixMilli(),
+		Tenant:     e.Tenant,
+		Backends:   joinBackends(e.Backends),
+		Archived:   joinBackends(e.Archived),
+		Reads:      int64(e.Reads),
+	}
+	if len(e.RequestID) > 0 {
+		row.RequestID = e.RequestID.String()
+	}
+	if e.RollupHeight != nil {
+		h := int64(*e.RollupHeight)
+		row.RollupHeight = &h
+	}
+	if e.L1Block != nil {
+		b := int64(*e.L1Block)
+		row.L1Block = &b
+	}
+	if !e.LastAccess.IsZero() {
+		row.LastAccess = e.LastAccess.UnixMilli()
+	}
+	return row
+}
+
+// joinBackends ... sorted, comma separated names of the backends of an entry
+func joinBackends(backends map[string]time.Time) string {
+	names := make([]string, 0, len(backends))
+	for b := range backends {
+		names = append(names, b)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// readPayloads ... fills the payload columns of rows with the payloads of records, and returns the number of
+// payloads which couldn't be read, including those skipped once ctx is done
+func readPayloads(ctx context.Context, records []index.Record, rows []Row, opts Options, l log.Logger) int {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	failed := 0
+	jobs := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				payload, err := opts.Payloads(ctx, records[i].Key, records[i].Mode)
+				if err != nil {
+					l.Warn("Failed to read payload", "commitment", rows[i].Commitment, "err", err)
+					mu.Lock()
+					failed++
+					mu.Unlock()
+					continue
+				}
+				hash := hexutil.Encode(crypto.Keccak256(payload))
+				size := int64(len(payload))
+				rows[i].PayloadHash, rows[i].PayloadSize = &hash, &size
+			}
+		}()
+	}
+	skipped := 0
+	for i := range records {
+		if ctx.Err() != nil {
+			skipped = len(records) - i
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return failed + skipped
+}
+
+// Write ... writes rows to w in format
+func Write(w io.Writer, format string, rows []Row) error {
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatParquet:
+		return writeParquet(w, rows)
+	default:
+		return fmt.Errorf("unknown dataset format %s, options are %v", format, Formats)
+	}
+}
+
+// writeCSV ... writes rows as CSV with a header, times being RFC 3339 timestamps in UTC
+func writeCSV(w io.Writer, rows []Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, r := range rows {
+		var lastAccess *int64
+		if r.LastAccess != 0 {
+			lastAccess = &r.LastAccess
+		}
+		record := []string{
+			strconv.FormatInt(r.Seq, 10),
+			r.Commitment,
+			r.Mode,
+			strconv.FormatInt(r.SizeBytes, 10),
+			formatMillis(&r.CreatedAt),
+			r.Tenant,
+			formatInt(r.RollupHeight),
+			formatInt(r.L1Block),
+			r.Backends,
+			r.Archived,
+			r.RequestID,
+			strconv.FormatInt(r.Reads, 10),
+			formatMillis(lastAccess),
+			"",
+			formatInt(r.PayloadSize),
+		}
+		if r.PayloadHash != nil {
+			record[13] = *r.PayloadHash
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatInt(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatInt(*n, 10)
+}
+
+func formatMillis(ms *int64) string {
+	if ms == nil {
+		return ""
+	}
+	return time.UnixMilli(*ms).UTC().Format(time.RFC3339Nano)
+}
+
+// writeParquet ... writes rows as a Snappy compressed Parquet file
+func writeParquet(w io.Writer, rows []Row) error {
+	if err := parquet.Write(w, rows, parquet.Compression(&parquet.Snappy)); err != nil {
+		return fmt.Errorf("failed to write Parquet file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package dataset
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecords(start time.Time) []index.Record {
+	height := uint64(42)
+	return []index.Record{
+		{Entry: index.Entry{Seq: 3, Key: []byte("c"), Size: 30, CreatedAt: start.Add(2 * time.Hour)}},
+		{Entry: index.Entry{Seq: 1, Key: []byte("a"), Size: 10, CreatedAt: start, Mode: "simple"}},
+		{Entry: index.Entry{
+			Seq: 2, Key: []byte("b"), Size: 20, CreatedAt: start.Add(time.Hour), Mode: "optimism_generic",
+			Tenant: "rollup", RollupHeight: &height, RequestID: []byte{1, 2},
+			Backends:   map[string]time.Time{"S3": start, "Redis": start},
+			LastAccess: start.Add(90 * time.Minute), Reads: 4,
+		}},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	start := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	records := testRecords(start)
+
+	// the range includes its start and excludes its end, rows are ordered by sequence number
+	rows, failed := Build(context.Background(), records, Options{Until: start.Add(2 * time.Hour)}, log.New())
+	require.Zero(t, failed)
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(1), rows[0].Seq)
+	require.Nil(t, rows[0].PayloadHash)
+	require.Equal(t, Row{
+		Seq: 2, Commitment: hexutil.Encode([]byte("b")), Mode: "optimism_generic", SizeBytes: 20,
+		CreatedAt: start.Add(time.Hour).UnixMilli(), Tenant: "rollup", RollupHeight: rows[1].RollupHeight,
+		Backends: "Redis,S3", RequestID: "0x0102", Reads: 4, LastAccess: rows[1].LastAccess,
+	}, rows[1])
+	require.Equal(t, int64(42), *rows[1].RollupHeight)
+	require.Equal(t, start.Add(90*time.Minute).UnixMilli(), rows[1].LastAccess)
+
+	rows, _ = Build(context.Background(), records, Options{Since: start.Add(time.Hour)}, log.New())
+	require.Len(t, rows, 2)
+	require.Equal(t, int64(2), rows[0].Seq)
+
+	// payloads which can't be read leave their columns empty
+	payloads := func(_ context.Context, key []byte, _ string) ([]byte, error) {
+		if string(key) == "c" {
+			return nil, errors.New("not found")
+		}
+		return append(key, key...), nil
+	}
+	rows, failed = Build(context.Background(), records, Options{Payloads: payloads}, log.New())
+	require.Equal(t, 1, failed)
+	require.Equal(t, hexutil.Encode(crypto.Keccak256([]byte("aa"))), *rows[0].PayloadHash)
+	require.Equal(t, int64(2), *rows[0].PayloadSize)
+	require.Nil(t, rows[2].PayloadHash)
+	require.Nil(t, rows[2].PayloadSize)
+
+	// payloads skipped once the context is done count as unreadable
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, failed = Build(ctx, records, Options{Payloads: payloads}, log.New())
+	require.Equal(t, 3, failed)
+}
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)
+	rows, _ := Build(context.Background(), testRecords(start), Options{}, log.New())
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, FormatCSV, rows))
+	table, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, table, 4)
+	require.Equal(t, columns, table[0])
+	require.Equal(t, []string{
+		"2", "0x62", "optimism_generic", "20", "2024-09-01T01:00:00Z", "rollup", "42", "", "Redis,S3", "", "0x0102",
+		"4", "2024-09-01T01:30:00Z", "", "",
+	}, table[2])
+
+	buf.Reset()
+	require.NoError(t, Write(&buf, FormatParquet, rows))
+	read, err := parquet.Read[Row](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, rows, read)
+
+	require.ErrorContains(t, Write(&buf, "json", rows), "unknown dataset format")
+}
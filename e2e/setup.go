@@ -77,7 +77,6 @@ func createS3Config(eigendaCfg server.Config) server.CLIConfig {
 	createS3Bucket(bucketName)
 
 	eigendaCfg.S3Config = s3.Config{
-		Profiling:       true,
 		Bucket:          bucketName,
 		Path:            "",
 		Endpoint:        "localhost:4566",
@@ -192,9 +191,10 @@ func CreateTestSuite(t *testing.T, testSuiteCfg server.CLIConfig) (TestSuite, fu
 		ctx,
 		testSuiteCfg,
 		log,
+		metrics.NoopMetrics,
 	)
 	require.NoError(t, err)
-	server := server.NewServer(host, 0, store, log, metrics.NoopMetrics)
+	server := server.NewServer(host, 0, store, log, metrics.NoopMetrics, testSuiteCfg.ServerOptions)
 
 	t.Log("Starting proxy server...")
 	err = server.Start()
@@ -243,12 +243,12 @@ func createS3Bucket(bucketName string) {
 		// Check to see if we already own this bucket (which happens if you run this twice)
 		exists, errBucketExists := minioClient.BucketExists(ctx, bucketName)
 		if errBucketExists == nil && exists {
-			log.Info(fmt.Sprintf("We already own %s\n", bucketName))
+			log.Info("Bucket already exists", "bucket", bucketName)
 		} else {
 			panic(err)
 		}
 	} else {
-		log.Info(fmt.Sprintf("Successfully created %s\n", bucketName))
+		log.Info("Created bucket", "bucket", bucketName)
 	}
 }
 
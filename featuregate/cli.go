@@ -0,0 +1,39 @@
+package featuregate
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	RulesFlagName = "feature-gates"
+)
+
+// Config ... the configured rules of the feature gates, as <gate>=<rule> entries
+type Config struct {
+	Rules []string
+}
+
+// Check ... validates the configured rules
+func (c Config) Check() error {
+	_, err := New(c)
+	return err
+}
+
+// CLIFlags ... used for the feature gate configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     RulesFlagName,
+			Usage:    "Rollout rules of feature gates, as <gate>=<rule> entries. A rule is on, off, or a ';' separated combination of tenants:<a>+<b> and <n>%, e.g. streaming=tenants:rollup-a;10%. Gates without a rule use their default. Rules can be changed at runtime via the admin API.",
+			EnvVars:  []string{envPrefix + "_FEATURE_GATES"},
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Rules: ctx.StringSlice(RulesFlagName),
+	}
+}
@@ -0,0 +1,226 @@
+package featuregate
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gates gating request behaviors, see Gate
+const (
+	// Streaming gates GET requests opting into streamed responses, on top of --streaming.enabled
+	Streaming = "streaming"
+	// TTLHints gates PUT requests limiting the retention of their blob, on top of --ttl-hints.enabled
+	TTLHints = "ttl-hints"
+	// RedundancyRequests gates PUT requests asking for extra secondary backends, on top of
+	// --redundancy-requests.enabled
+	RedundancyRequests = "redundancy-requests"
+)
+
+// Gate ... a behavior whose rollout is controlled by a Rule
+type Gate struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Default is whether the gate is open for requests when no rule is set
+	Default bool `json:"default"`
+}
+
+// Gates ... the known gates, rules for other names are rejected
+var Gates = []Gate{
+	{Name: Streaming, Description: "GET requests opting into streamed responses", Default: true},
+	{Name: TTLHints, Description: "PUT requests limiting how long secondary stores keep their blob", Default: true},
+	{
+		Name:        RedundancyRequests,
+		Description: "PUT requests asking for their blob to also be persisted to other secondary backends",
+		Default:     true,
+	},
+}
+
+// ErrUnknownGate ... returned for rules of gates that don't exist
+var ErrUnknownGate = errors.New("unknown feature gate")
+
+func lookup(name string) (Gate, bool) {
+	for _, g := range Gates {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return Gate{}, false
+}
+
+// Rule ... for which requests a gate is open. A gate is open for every request if Enabled is set, and otherwise
+// for requests of the listed Tenants and for Percentage of the remaining tenants.
+type Rule struct {
+	Enabled    bool     `json:"enabled"`
+	Tenants    []string `json:"tenants,omitempty"`
+	Percentage float64  `json:"percentage,omitempty"`
+}
+
+// ParseRule ... parses a rule: "on", "off", or a ';' separated combination of "tenants:<a>+<b>" and "<n>%",
+// e.g. "tenants:rollup-a+rollup-b;10%"
+func ParseRule(s string) (Rule, error) {
+	switch s {
+	case "on":
+		return Rule{Enabled: true}, nil
+	case "off":
+		return Rule{}, nil
+	}
+
+	var r Rule
+	for _, part := range strings.Split(s, ";") {
+		switch {
+		case strings.HasPrefix(part, "tenants:"):
+			for _, t := range strings.Split(strings.TrimPrefix(part, "tenants:"), "+") {
+				if t == "" {
+					return Rule{}, fmt.Errorf("empty tenant in feature gate rule %q", s)
+				}
+				r.Tenants = append(r.Tenants, t)
+			}
+		case strings.HasSuffix(part, "%"):
+			pct, err := strconv.ParseFloat(strings.TrimSuffix(part, "%"), 64)
+			if err != nil || pct < 0 || pct > 100 {
+				return Rule{}, fmt.Errorf("invalid percentage in feature gate rule %q", s)
+			}
+			r.Percentage = pct
+		default:
+			return Rule{}, fmt.Errorf("invalid feature gate rule %q: expected on, off, tenants:<a>+<b> or <n>%%", s)
+		}
+	}
+	return r, nil
+}
+
+// String ... formats the rule as parsed by ParseRule
+func (r Rule) String() string {
+	if r.Enabled {
+		return "on"
+	}
+	var parts []string
+	if len(r.Tenants) > 0 {
+		parts = append(parts, "tenants:"+strings.Join(r.Tenants, "+"))
+	}
+	if r.Percentage > 0 {
+		parts = append(parts, strconv.FormatFloat(r.Percentage, 'f', -1, 64)+"%")
+	}
+	if len(parts) == 0 {
+		return "off"
+	}
+	return strings.Join(parts, ";")
+}
+
+// open ... returns whether the rule opens the gate for a request of tenant. Tenants are sampled by a hash of
+// the gate name and tenant, so that a tenant consistently sees the same behavior while the percentage doesn't
+// change. Requests without a tenant are sampled individually.
+func (r Rule) open(name, tenant string) bool {
+	if r.Enabled {
+		return true
+	}
+	for _, t := range r.Tenants {
+		if t == tenant {
+			return true
+		}
+	}
+	if r.Percentage <= 0 {
+		return false
+	}
+	if tenant == "" {
+		return rand.Float64()*100 < r.Percentage // #nosec G404
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + "/" + tenant))
+	return float64(h.Sum32()%10000) < r.Percentage*100
+}
+
+// Status ... a gate and its rule, if one is set
+type Status struct {
+	Gate
+	Rule *Rule `json:"rule,omitempty"`
+}
+
+// Set ... the rules of the feature gates, which can be updated at runtime, e.g. via the admin API. A nil Set
+// applies the gates' defaults.
+type Set struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+}
+
+// New ... builds the set of feature gates from the configured rules
+func New(cfg Config) (*Set, error) {
+	s := &Set{rules: make(map[string]Rule)}
+	for _, entry := range cfg.Rules {
+		name, spec, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid feature gate %q: expected <gate>=<rule>", entry)
+		}
+		r, err := ParseRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.Set(name, r); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Enabled ... returns whether the gate name is open for a request of tenant
+func (s *Set) Enabled(name, tenant string) bool {
+	g, ok := lookup(name)
+	if !ok {
+		return false
+	}
+	if s == nil {
+		return g.Default
+	}
+
+	s.mu.RLock()
+	r, ok := s.rules[name]
+	s.mu.RUnlock()
+	if !ok {
+		return g.Default
+	}
+	return r.open(name, tenant)
+}
+
+// Set ... sets the rule of the gate name
+func (s *Set) Set(name string, r Rule) error {
+	if _, ok := lookup(name); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownGate, name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rules[name] = r
+	return nil
+}
+
+// Reset ... drops the rule of the gate name, so that its default applies again
+func (s *Set) Reset(name string) error {
+	if _, ok := lookup(name); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownGate, name)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.rules, name)
+	return nil
+}
+
+// List ... returns every gate with its rule, sorted by name
+func (s *Set) List() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(Gates))
+	for _, g := range Gates {
+		st := Status{Gate: g}
+		if r, ok := s.rules[g.Name]; ok {
+			st.Rule = &r
+		}
+		statuses = append(statuses, st)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
@@ -0,0 +1,92 @@
+package featuregate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		spec        string
+		expected    Rule
+		expectError bool
+	}{
+		{spec: "on", expected: Rule{Enabled: true}},
+		{spec: "off", expected: Rule{}},
+		{spec: "25%", expected: Rule{Percentage: 25}},
+		{spec: "tenants:a+b", expected: Rule{Tenants: []string{"a", "b"}}},
+		{spec: "tenants:a;0.5%", expected: Rule{Tenants: []string{"a"}, Percentage: 0.5}},
+		{spec: "101%", expectError: true},
+		{spec: "tenants:a+", expectError: true},
+		{spec: "yes", expectError: true},
+		{spec: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			r, err := ParseRule(tt.spec)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, r)
+			require.Equal(t, tt.spec, r.String())
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	s, err := New(Config{Rules: []string{"streaming=tenants:rollup-a"}})
+	require.NoError(t, err)
+
+	require.True(t, s.Enabled(Streaming, "rollup-a"))
+	require.False(t, s.Enabled(Streaming, "rollup-b"))
+	require.False(t, s.Enabled(Streaming, ""))
+	// gates without a rule use their default
+	require.True(t, s.Enabled(TTLHints, "rollup-b"))
+	require.False(t, s.Enabled("compression", "rollup-a"))
+
+	require.NoError(t, s.Set(TTLHints, Rule{}))
+	require.False(t, s.Enabled(TTLHints, "rollup-a"))
+	require.NoError(t, s.Reset(TTLHints))
+	require.True(t, s.Enabled(TTLHints, "rollup-a"))
+	require.True(t, errors.Is(s.Set("compression", Rule{Enabled: true}), ErrUnknownGate))
+
+	var unset *Set
+	require.True(t, unset.Enabled(Streaming, "rollup-a"))
+
+	list := s.List()
+	require.Len(t, list, len(Gates))
+	require.Equal(t, RedundancyRequests, list[0].Name)
+	require.Nil(t, list[0].Rule)
+	require.Equal(t, Streaming, list[1].Name)
+	require.Equal(t, &Rule{Tenants: []string{"rollup-a"}}, list[1].Rule)
+	require.Nil(t, list[2].Rule)
+}
+
+func TestNewInvalid(t *testing.T) {
+	for _, rules := range [][]string{{"streaming"}, {"streaming=maybe"}, {"compression=on"}} {
+		_, err := New(Config{Rules: rules})
+		require.Error(t, err, rules)
+	}
+}
+
+func TestPercentageIsStickyPerTenant(t *testing.T) {
+	s, err := New(Config{Rules: []string{"streaming=30%"}})
+	require.NoError(t, err)
+
+	open := 0
+	for i := 0; i < 1000; i++ {
+		tenant := fmt.Sprintf("tenant-%d", i)
+		enabled := s.Enabled(Streaming, tenant)
+		require.Equal(t, enabled, s.Enabled(Streaming, tenant))
+		if enabled {
+			open++
+		}
+	}
+	require.InDelta(t, 300, open, 60)
+}
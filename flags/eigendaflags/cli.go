@@ -1,8 +1,10 @@
 package eigendaflags
 
 import (
+	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/urfave/cli/v2"
@@ -11,47 +13,95 @@ import (
 // TODO: we should eventually move all of these flags into the eigenda repo
 
 var (
-	DisperserRPCFlagName                 = withFlagPrefix("disperser-rpc")
-	StatusQueryRetryIntervalFlagName     = withFlagPrefix("status-query-retry-interval")
-	StatusQueryTimeoutFlagName           = withFlagPrefix("status-query-timeout")
-	DisableTLSFlagName                   = withFlagPrefix("disable-tls")
-	ResponseTimeoutFlagName              = withFlagPrefix("response-timeout")
-	CustomQuorumIDsFlagName              = withFlagPrefix("custom-quorum-ids")
-	SignerPrivateKeyHexFlagName          = withFlagPrefix("signer-private-key-hex")
-	PutBlobEncodingVersionFlagName       = withFlagPrefix("put-blob-encoding-version")
-	DisablePointVerificationModeFlagName = withFlagPrefix("disable-point-verification-mode")
-	WaitForFinalizationFlagName          = withFlagPrefix("wait-for-finalization")
+	DisperserRPCFlagName                  = withFlagPrefix("disperser-rpc")
+	StatusQueryRetryIntervalFlagName      = withFlagPrefix("status-query-retry-interval")
+	StatusQueryTimeoutFlagName            = withFlagPrefix("status-query-timeout")
+	DisableTLSFlagName                    = withFlagPrefix("disable-tls")
+	ResponseTimeoutFlagName               = withFlagPrefix("response-timeout")
+	CustomQuorumIDsFlagName               = withFlagPrefix("custom-quorum-ids")
+	CustomQuorumFallbackAfterFlagName     = withFlagPrefix("custom-quorum-fallback-after")
+	SignerPrivateKeyHexFlagName           = withFlagPrefix("signer-private-key-hex")
+	SignerPrivateKeyFileFlagName          = withFlagPrefix("signer-private-key-file")
+	PutBlobEncodingVersionFlagName        = withFlagPrefix("put-blob-encoding-version")
+	DisablePointVerificationModeFlagName  = withFlagPrefix("disable-point-verification-mode")
+	WaitForFinalizationFlagName           = withFlagPrefix("wait-for-finalization")
+	PrevalidateCommitmentFlagName         = withFlagPrefix("prevalidate-commitment")
+	PendingDispersalsPathFlagName         = withFlagPrefix("pending-dispersals-path")
+	PendingDispersalsRetryFlagName        = withFlagPrefix("pending-dispersals-retry-interval")
+	PendingDispersalsMaxAgeFlagName       = withFlagPrefix("pending-dispersals-max-age")
+	PendingDispersalsRedisKeyFlagName     = withFlagPrefix("pending-dispersals-redis-key")
+	MaxBlobSizeNegotiationFlagName        = withFlagPrefix("max-blob-size-negotiation-interval")
+	StatusQueryQPSFlagName                = withFlagPrefix("status-query-qps")
+	StatusQueryParallelismFlagName        = withFlagPrefix("status-query-parallelism")
+	DisperserTLSFlagPrefix                = withFlagPrefix("disperser-tls")
+	DisperserBridgeURLFlagName            = withFlagPrefix("disperser-bridge-url")
+	DisperserBridgeProtocolFlagName       = withFlagPrefix("disperser-bridge-protocol")
+	RetrieverRPCsFlagName                 = withFlagPrefix("retriever-rpcs")
+	RetrieverDisableTLSFlagName           = withFlagPrefix("retriever-disable-tls")
+	PointVerificationModeFallbackFlagName = withFlagPrefix("point-verification-mode-fallback")
 )
 
 func withFlagPrefix(s string) string {
 	return "eigenda." + s
 }
 
+// ReadDisperserRPCs ... returns the disperser endpoints requests fail over between, if more than one is set
+func ReadDisperserRPCs(ctx *cli.Context) []string {
+	rpcs := strings.Split(ctx.String(DisperserRPCFlagName), ",")
+	if len(rpcs) < 2 {
+		return nil
+	}
+	for i, rpc := range rpcs {
+		rpcs[i] = strings.TrimSpace(rpc)
+	}
+	return rpcs
+}
+
 func withEnvPrefix(envPrefix, s string) []string {
 	return []string{envPrefix + "_EIGENDA_" + s}
 }
 
 // CLIFlags ... used for EigenDA client configuration
 func CLIFlags(envPrefix, category string) []cli.Flag {
-	return []cli.Flag{
+	flags := []cli.Flag{
 		&cli.StringFlag{
 			Name:     DisperserRPCFlagName,
-			Usage:    "RPC endpoint of the EigenDA disperser.",
+			Usage:    "RPC endpoint of the EigenDA disperser. If several comma separated endpoints of the same disperser are given, requests fail over to the next one when an endpoint is unavailable, and status queries and retrievals also when it times out.",
 			EnvVars:  withEnvPrefix(envPrefix, "DISPERSER_RPC"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     StatusQueryTimeoutFlagName,
-			Usage:    "Duration to wait for a blob to finalize after being sent for dispersal. Default is 30 minutes.",
-			Value:    30 * time.Minute,
-			EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_TIMEOUT"),
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     StatusQueryTimeoutFlagName,
+				Usage:    "Duration to wait for a blob to finalize after being sent for dispersal. Default is 30 minutes.",
+				Value:    30 * time.Minute,
+				EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Second,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     StatusQueryRetryIntervalFlagName,
+				Usage:    "Interval between retries when awaiting network blob finalization. Default is 5 seconds.",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_INTERVAL"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+		&cli.Float64Flag{
+			Name:     StatusQueryQPSFlagName,
+			Usage:    "Maximum number of blob status queries per second, shared by all in-flight dispersals. Set to 0 to disable the cap.",
+			Value:    10,
+			EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_QPS"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     StatusQueryRetryIntervalFlagName,
-			Usage:    "Interval between retries when awaiting network blob finalization. Default is 5 seconds.",
-			Value:    5 * time.Second,
-			EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_INTERVAL"),
+		&cli.IntFlag{
+			Name:     StatusQueryParallelismFlagName,
+			Usage:    "Number of concurrent blob status queries issued by the shared status poller.",
+			Value:    4,
+			EnvVars:  withEnvPrefix(envPrefix, "STATUS_QUERY_PARALLELISM"),
 			Category: category,
 		},
 		&cli.BoolFlag{
@@ -61,12 +111,15 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "GRPC_DISABLE_TLS"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     ResponseTimeoutFlagName,
-			Usage:    "Total time to wait for a response from the EigenDA disperser. Default is 60 seconds.",
-			Value:    60 * time.Second,
-			EnvVars:  withEnvPrefix(envPrefix, "RESPONSE_TIMEOUT"),
-			Category: category,
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     ResponseTimeoutFlagName,
+				Usage:    "Total time to wait for a response from the EigenDA disperser. Default is 60 seconds.",
+				Value:    60 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "RESPONSE_TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Second,
 		},
 		&cli.UintSliceFlag{
 			Name:     CustomQuorumIDsFlagName,
@@ -75,12 +128,25 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "CUSTOM_QUORUM_IDS"),
 			Category: category,
 		},
+		&cli.IntFlag{
+			Name:     CustomQuorumFallbackAfterFlagName,
+			Usage:    "Number of failed dispersals of a blob to the custom quorums after which it is dispersed to the default quorums only, keeping the chain live during an outage of a custom quorum. Set to 0 to never fall back.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "CUSTOM_QUORUM_FALLBACK_AFTER"),
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name:     SignerPrivateKeyHexFlagName,
 			Usage:    "Hex-encoded signer private key. This key should not be associated with an Ethereum address holding any funds.",
 			EnvVars:  withEnvPrefix(envPrefix, "SIGNER_PRIVATE_KEY_HEX"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     SignerPrivateKeyFileFlagName,
+			Usage:    "Path to a file containing the hex-encoded signer private key (e.g. a mounted Kubernetes secret). Changes to the file are picked up without a restart. Mutually exclusive with the hex flag.",
+			EnvVars:  withEnvPrefix(envPrefix, "SIGNER_PRIVATE_KEY_FILE"),
+			Category: category,
+		},
 		&cli.UintFlag{
 			Name:     PutBlobEncodingVersionFlagName,
 			Usage:    "Blob encoding version to use when writing blobs from the high-level interface.",
@@ -102,12 +168,94 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    false,
 			Category: category,
 		},
+		&cli.BoolFlag{
+			Name:     PrevalidateCommitmentFlagName,
+			Usage:    "Compute the KZG commitment of a blob before dispersing it, rejecting blobs it can't be computed for and certs returned by the disperser whose commitment doesn't match.",
+			EnvVars:  withEnvPrefix(envPrefix, "PREVALIDATE_COMMITMENT"),
+			Value:    false,
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     PendingDispersalsPathFlagName,
+			Usage:    "File journaling the request IDs of dispersals as soon as the disperser accepted them, ahead of status polling, so that dispersals in flight or whose status polling timed out are recovered after a restart. If unset, pending dispersals are kept in memory only.",
+			EnvVars:  withEnvPrefix(envPrefix, "PENDING_DISPERSALS_PATH"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     PendingDispersalsRetryFlagName,
+				Usage:    "Interval at which the status of pending dispersals is queried in the background. Set to 0 to only resume them via the admin API or when the same blob is put again.",
+				Value:    time.Minute,
+				EnvVars:  withEnvPrefix(envPrefix, "PENDING_DISPERSALS_RETRY_INTERVAL"),
+				Category: category,
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     PendingDispersalsMaxAgeFlagName,
+				Usage:    "Age after which pending and recovered dispersals are dropped from the journal, e.g. once the disperser no longer retains their blobs. Dropping happens before each background retry. Set to 0 to keep them until they are resolved.",
+				Value:    0,
+				EnvVars:  withEnvPrefix(envPrefix, "PENDING_DISPERSALS_MAX_AGE"),
+				Category: category,
+			},
+		},
+		&cli.StringFlag{
+			Name:     PendingDispersalsRedisKeyFlagName,
+			Usage:    "Key of a Redis hash journaling the pending dispersals instead of --eigenda.pending-dispersals-path, so that replicas sharing the Redis server survive the loss of their local disk. Requires a Redis endpoint.",
+			EnvVars:  withEnvPrefix(envPrefix, "PENDING_DISPERSALS_REDIS_KEY"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     MaxBlobSizeNegotiationFlagName,
+				Usage:    "Interval at which the disperser is probed for its max blob size. The proxy refuses to start if the disperser accepts less than --eigenda.max-blob-length, and shrinks its effective max blob length if the disperser lowers its limit later on. Set to 0 to disable the probes.",
+				Value:    time.Hour,
+				EnvVars:  withEnvPrefix(envPrefix, "MAX_BLOB_SIZE_NEGOTIATION_INTERVAL"),
+				Category: category,
+			},
+		},
+		&cli.StringFlag{
+			Name:     DisperserBridgeURLFlagName,
+			Usage:    "URL of a gRPC-web or Connect bridge through which the disperser is reached over HTTP(S), for environments only allowing outbound HTTPS. Replaces the native gRPC connection to --eigenda.disperser-rpc if set.",
+			EnvVars:  withEnvPrefix(envPrefix, "DISPERSER_BRIDGE_URL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     DisperserBridgeProtocolFlagName,
+			Usage:    "Protocol spoken by the disperser bridge, grpc-web or connect.",
+			Value:    "grpc-web",
+			EnvVars:  withEnvPrefix(envPrefix, "DISPERSER_BRIDGE_PROTOCOL"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     RetrieverRPCsFlagName,
+			Usage:    "RPC endpoints of EigenDA retriever services, which reconstruct blobs from the chunks held by the operator nodes. Tried in order when the disperser fails to serve a blob.",
+			EnvVars:  withEnvPrefix(envPrefix, "RETRIEVER_RPCS"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     RetrieverDisableTLSFlagName,
+			Usage:    "Connect to the EigenDA retriever services without TLS.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "RETRIEVER_DISABLE_TLS"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     PointVerificationModeFallbackFlagName,
+			Usage:    "Decode a blob retrieved from EigenDA that fails to decode in the configured point verification mode in the other mode, with the default blob encoding regardless of the version in its header, logging the mode that succeeded. Such blobs are verified as retrieved and never cached.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "POINT_VERIFICATION_MODE_FALLBACK"),
+			Category: category,
+		},
 	}
+	return append(flags, utils.TLSFlags(DisperserTLSFlagPrefix, envPrefix+"_EIGENDA_DISPERSER_TLS", "disperser gRPC", category)...)
 }
 
 func ReadConfig(ctx *cli.Context) clients.EigenDAClientConfig {
+	// the EigenDA client is configured with the first of the disperser endpoints, see ReadDisperserRPCs
+	rpc, _, _ := strings.Cut(ctx.String(DisperserRPCFlagName), ",")
 	return clients.EigenDAClientConfig{
-		RPC:                          ctx.String(DisperserRPCFlagName),
+		RPC:                          strings.TrimSpace(rpc),
 		StatusQueryRetryInterval:     ctx.Duration(StatusQueryRetryIntervalFlagName),
 		StatusQueryTimeout:           ctx.Duration(StatusQueryTimeoutFlagName),
 		DisableTLS:                   ctx.Bool(DisableTLSFlagName),
@@ -1,11 +1,30 @@
 package flags
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
 	"github.com/Layr-Labs/eigenda-proxy/flags/eigendaflags"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+	"github.com/Layr-Labs/eigenda-proxy/standby"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/azure"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/gcs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/localcache"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -18,16 +37,157 @@ const (
 	MemstoreFlagsCategory = "Memstore (replaces EigenDA when enabled)"
 	RedisCategory         = "Redis Cache/Fallback"
 	S3Category            = "S3 Cache/Fallback"
+	FSCategory            = "Filesystem Cache/Fallback"
+	GCSCategory           = "GCS Cache/Fallback"
+	AzureCategory         = "Azure Cache/Fallback"
+	LocalCacheCategory    = "Local Memory/Disk Cache"
 	VerifierCategory      = "KZG and Cert Verifier"
+	BackfillCategory      = "Historical L1 Backfill"
+	StandbyCategory       = "Warm Standby"
+	HooksCategory         = "Request Hooks"
+	FeatureGatesCategory  = "Feature Gates"
+	TracingCategory       = "Tracing"
+	TenancyCategory       = "Tenancy"
 )
 
 const (
 	ListenAddrFlagName = "addr"
 	PortFlagName       = "port"
 
+	ProfileFlagName = "profile"
+
+	// separate read API listener flags
+	ReadListenerAddrFlagName = "read-listener.addr"
+	ReadListenerPortFlagName = "read-listener.port"
+
+	// gRPC API flags
+	GRPCAddrFlagName = "grpc.addr"
+	GRPCPortFlagName = "grpc.port"
+
+	// cleartext HTTP/2 flags
+	HTTP2EnabledFlagName              = "http2.enabled"
+	HTTP2MaxConcurrentStreamsFlagName = "http2.max-concurrent-streams"
+	HTTP2MaxReadFrameSizeFlagName     = "http2.max-read-frame-size"
+
 	// routing flags
 	FallbackTargetsFlagName = "routing.fallback-targets"
 	CacheTargetsFlagName    = "routing.cache-targets"
+	KeccakBackendFlagName   = "routing.keccak-backend"
+	TargetRolesFlagName     = "routing.target-roles"
+	PrefixRoutesFlagName    = "routing.prefix-routes"
+	QuotasFlagName          = "routing.quotas"
+	QuotaPolicyFlagName     = "routing.quota-policy"
+	QuotaUsagePathFlagName  = "routing.quota-usage-path"
+	DeadLetterPathFlagName  = "routing.dead-letter-path"
+
+	DeadLetterRetryIntervalFlagName = "routing.dead-letter-retry-interval"
+	DeadLetterMaxBackoffFlagName    = "routing.dead-letter-max-backoff"
+
+	ReconcileIntervalFlagName   = "routing.reconcile-interval"
+	ReconcileSampleSizeFlagName = "routing.reconcile-sample-size"
+	ReconcileRepairFlagName     = "routing.reconcile-repair"
+	CoalesceReadsFlagName       = "routing.coalesce-reads"
+	RefreshStaleCertsFlagName   = "routing.refresh-stale-certs"
+	ReadSLOFlagName             = "routing.read-slo"
+	HedgeDelayFlagName          = "routing.hedge-delay"
+	EnvelopeFlagName            = "routing.envelope"
+
+	WarmPoolIntervalFlagName = "warm-pool.interval"
+
+	DegradationModeFlagName          = "degradation.mode"
+	DegradationErrorRateFlagName     = "degradation.error-rate"
+	DegradationMinDispersalsFlagName = "degradation.min-dispersals"
+	DegradationWindowFlagName        = "degradation.window"
+	DegradationProbeIntervalFlagName = "degradation.probe-interval"
+	DegradationHealthyProbesFlagName = "degradation.healthy-probes"
+
+	BreakerFailureThresholdFlagName  = "circuit-breaker.failure-threshold"
+	BreakerOpenDurationFlagName      = "circuit-breaker.open-duration"
+	BreakerHalfOpenSuccessesFlagName = "circuit-breaker.half-open-successes"
+	BreakerFlapWindowFlagName        = "circuit-breaker.flap-window"
+	BreakerFlapThresholdFlagName     = "circuit-breaker.flap-threshold"
+	BreakerFlapOpenDurationFlagName  = "circuit-breaker.flap-open-duration"
+
+	TieringIntervalFlagName     = "tiering.interval"
+	TieringDemoteAfterFlagName  = "tiering.demote-after"
+	TieringArchiveAfterFlagName = "tiering.archive-after"
+	TieringPromoteReadsFlagName = "tiering.promote-reads"
+	TieringBatchSizeFlagName    = "tiering.batch-size"
+
+	AccessStatsSampleRateFlagName = "access-stats.sample-rate"
+	AccessStatsWindowFlagName     = "access-stats.window"
+
+	ChunkingMaxSizeFlagName       = "chunking.max-size"
+	ChunkingMinSizeFlagName       = "chunking.min-size"
+	ChunkingTargetLatencyFlagName = "chunking.target-latency"
+	ChunkingWindowFlagName        = "chunking.window"
+
+	SnapshotRestoreFileFlagName = "snapshot.restore-file"
+
+	// admin API flags
+	AdminEnabledFlagName = "admin.enabled"
+
+	// crash report flags
+	CrashReportDSNFlagName         = "crash-report.dsn"
+	CrashReportEnvironmentFlagName = "crash-report.environment"
+	CrashReportTimeoutFlagName     = "crash-report.timeout"
+
+	// public route flags
+	ExplorerEnabledFlagName = "explorer.enabled"
+
+	// public read gateway flags
+	GatewayEnabledFlagName         = "gateway.enabled"
+	GatewayRateLimitFlagName       = "gateway.rate-limit"
+	GatewayBurstFlagName           = "gateway.burst"
+	GatewayAPIKeysFlagName         = "gateway.api-keys"
+	GatewayAllowedPrefixesFlagName = "gateway.allowed-commitment-prefixes"
+	GatewayCacheMaxAgeFlagName     = "gateway.cache-max-age"
+	GatewayTrustedProxiesFlagName  = "gateway.trusted-proxies"
+
+	// usage accounting flags
+	UsageTenantsFlagName = "usage.tenants"
+
+	CORSAllowedOriginsFlagName = "cors.allowed-origins"
+	CORSAllowedMethodsFlagName = "cors.allowed-methods"
+	CORSAllowedHeadersFlagName = "cors.allowed-headers"
+	SecurityHeadersFlagName    = "security-headers.enabled"
+
+	StreamingEnabledFlagName = "streaming.enabled"
+
+	TTLHintsEnabledFlagName = "ttl-hints.enabled"
+
+	RedundancyRequestsEnabledFlagName = "redundancy-requests.enabled"
+
+	CommitmentsAPIEnabledFlagName = "commitments-api.enabled"
+
+	EigenDAInfoRefreshIntervalFlagName = "eigenda-info.refresh-interval"
+
+	// shadow proxy mirroring flags
+	MirrorURLFlagName                = "mirror.url"
+	MirrorPercentageFlagName         = "mirror.percentage"
+	MirrorTimeoutFlagName            = "mirror.timeout"
+	MirrorMaxInFlightFlagName        = "mirror.max-in-flight"
+	MirrorForwardCredentialsFlagName = "mirror.forward-credentials"
+
+	// asynchronous put flags
+	AsyncEnabledFlagName     = "async.enabled"
+	AsyncMaxInFlightFlagName = "async.max-in-flight"
+	AsyncMaxQueuedFlagName   = "async.max-queued"
+	AsyncRetentionFlagName   = "async.retention"
+	AsyncMinAckFlagName      = "async.min-ack"
+	AsyncJournalDirFlagName  = "async.journal-dir"
+
+	// batch put flags
+	BatchMaxBlobsFlagName    = "batch.max-blobs"
+	BatchConcurrencyFlagName = "batch.concurrency"
+
+	// request class flags
+	PriorityEnabledFlagName                = "priority.enabled"
+	PriorityInteractiveConcurrencyFlagName = "priority.interactive-concurrency"
+	PriorityBatchConcurrencyFlagName       = "priority.batch-concurrency"
+	PriorityMaxQueuedFlagName              = "priority.max-queued"
+	PriorityQueueTimeoutFlagName           = "priority.queue-timeout"
+	PriorityBatchAPIKeysFlagName           = "priority.batch-api-keys"
 )
 
 const EnvVarPrefix = "EIGENDA_PROXY"
@@ -51,6 +211,53 @@ func CLIFlags() []cli.Flag {
 			Value:   3100,
 			EnvVars: prefixEnvVars("PORT"),
 		},
+		&cli.StringFlag{
+			Name:    ProfileFlagName,
+			Usage:   fmt.Sprintf("Preset of the disperser RPC, service manager address, custom quorums and SRS paths of an EigenDA network (%s). Flags set explicitly take precedence.", strings.Join(ProfileNames(), ", ")),
+			EnvVars: prefixEnvVars("PROFILE"),
+			Action:  ApplyProfile,
+		},
+		&cli.StringFlag{
+			Name:    ReadListenerAddrFlagName,
+			Usage:   "Listening address of the separate read API listener.",
+			Value:   "0.0.0.0",
+			EnvVars: prefixEnvVars("READ_LISTENER_ADDR"),
+		},
+		&cli.IntFlag{
+			Name:    ReadListenerPortFlagName,
+			Usage:   "Port of a separate listener serving only the read API (GET /get/, versioned hash lookups and /health), with the gateway profile applied when enabled. Writes and operator routes are then only served on the main listener. Set to 0 to disable.",
+			Value:   0,
+			EnvVars: prefixEnvVars("READ_LISTENER_PORT"),
+		},
+		&cli.StringFlag{
+			Name:    GRPCAddrFlagName,
+			Usage:   "Listening address of the gRPC API.",
+			Value:   "0.0.0.0",
+			EnvVars: prefixEnvVars("GRPC_ADDR"),
+		},
+		&cli.IntFlag{
+			Name:    GRPCPortFlagName,
+			Usage:   "Port of a listener serving the Put and Get operations as the gRPC service defined in api/proxy.proto, with server reflection enabled. Calls are served like the equivalent HTTP requests, with request metadata read as headers. Set to 0 to disable.",
+			Value:   0,
+			EnvVars: prefixEnvVars("GRPC_PORT"),
+		},
+		&cli.BoolFlag{
+			Name:    HTTP2EnabledFlagName,
+			Usage:   "Serve cleartext HTTP/2 (h2c) next to HTTP/1.1 on the proxy's listeners, so that clients can multiplex concurrent requests over a single connection. Clients connect with prior knowledge or upgrade an HTTP/1.1 connection.",
+			EnvVars: prefixEnvVars("HTTP2_ENABLED"),
+		},
+		&cli.UintFlag{
+			Name:    HTTP2MaxConcurrentStreamsFlagName,
+			Usage:   "Maximum number of concurrent requests (streams) of a single HTTP/2 connection.",
+			Value:   250,
+			EnvVars: prefixEnvVars("HTTP2_MAX_CONCURRENT_STREAMS"),
+		},
+		&cli.UintFlag{
+			Name:    HTTP2MaxReadFrameSizeFlagName,
+			Usage:   "Largest HTTP/2 frame accepted from clients in bytes, between 16384 and 16777215.",
+			Value:   1 << 20,
+			EnvVars: prefixEnvVars("HTTP2_MAX_READ_FRAME_SIZE"),
+		},
 		&cli.StringSliceFlag{
 			Name:    FallbackTargetsFlagName,
 			Usage:   "List of read fallback targets to rollover to if cert can't be read from EigenDA.",
@@ -63,6 +270,574 @@ func CLIFlags() []cli.Flag {
 			Value:   cli.NewStringSlice(),
 			EnvVars: prefixEnvVars("CACHE_TARGETS"),
 		},
+		&cli.StringFlag{
+			Name:    KeccakBackendFlagName,
+			Usage:   "Backend storing the payloads of OP keccak256 commitments, which are served by their hash (s3, redis, fs, gcs, azure or local).",
+			Value:   "s3",
+			EnvVars: prefixEnvVars("KECCAK_BACKEND"),
+		},
+		&cli.StringSliceFlag{
+			Name:    TargetRolesFlagName,
+			Usage:   "Roles of cache and fallback targets, as backend=role pairs (e.g. s3=write-only,redis=read-only). 'write-only' targets are never read from, e.g. backup sinks, and 'read-only' targets are never written to, e.g. shared caches. Targets without a role are read and written.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("TARGET_ROLES"),
+		},
+		&cli.StringSliceFlag{
+			Name:    PrefixRoutesFlagName,
+			Usage:   "Routes of GET requests by commitment prefix, as prefix=pipeline pairs (e.g. 0x010000=s3,0x010001=eigenda). A pipeline lists the backends the blob is read from in order, joined by '+' (eigenda, s3, redis, fs, local), or is 'default' to take the default read path. The route with the longest matching prefix applies.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("PREFIX_ROUTES"),
+		},
+		&cli.StringSliceFlag{
+			Name:    QuotasFlagName,
+			Usage:   "Byte quotas for secondary storage targets, as backend=size pairs (e.g. redis=512MiB,fs=10GiB). Only blobs written by the proxy count towards a quota.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("QUOTAS"),
+		},
+		&cli.StringFlag{
+			Name:    QuotaPolicyFlagName,
+			Usage:   "What to do when a write would exceed a backend's quota: 'reject' skips the write, 'evict' deletes the oldest entries written by the proxy.",
+			Value:   "reject",
+			EnvVars: prefixEnvVars("QUOTA_POLICY"),
+		},
+		&cli.StringFlag{
+			Name:    QuotaUsagePathFlagName,
+			Usage:   "File the blobs accounted to quotas are persisted to every minute and on shutdown, and restored from on startup, so that quota usage and eviction order survive restarts. If unset, usage accounting restarts from zero.",
+			EnvVars: prefixEnvVars("QUOTA_USAGE_PATH"),
+		},
+		&cli.StringFlag{
+			Name:    DeadLetterPathFlagName,
+			Usage:   "File used to persist failed redundant writes until they are re-driven. If unset, the dead-letter queue is kept in memory only.",
+			EnvVars: prefixEnvVars("DEAD_LETTER_PATH"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    DeadLetterRetryIntervalFlagName,
+				Usage:   "Interval at which dead-lettered writes are re-driven in the background. Writes whose re-drive fails again are retried with an exponential backoff starting at this interval. Set to 0 to only re-drive them via the admin API.",
+				Value:   time.Minute,
+				EnvVars: prefixEnvVars("DEAD_LETTER_RETRY_INTERVAL"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    DeadLetterMaxBackoffFlagName,
+				Usage:   "Maximum backoff between background re-drives of a dead-lettered write.",
+				Value:   time.Hour,
+				EnvVars: prefixEnvVars("DEAD_LETTER_MAX_BACKOFF"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    ReconcileIntervalFlagName,
+				Usage:   "Interval between reconciliations of the commitment index with the contents of the cache and fallback targets. Set to 0 to disable.",
+				Value:   0,
+				EnvVars: prefixEnvVars("RECONCILE_INTERVAL"),
+			},
+		},
+		&cli.IntFlag{
+			Name:    ReconcileSampleSizeFlagName,
+			Usage:   "Number of index entries checked for and number of objects listed per secondary target and reconciliation.",
+			Value:   1000,
+			EnvVars: prefixEnvVars("RECONCILE_SAMPLE_SIZE"),
+		},
+		&cli.StringFlag{
+			Name:    ReconcileRepairFlagName,
+			Usage:   "How reconciliation repairs inconsistencies: 'none' only reports them, 'index' drops missing blobs from the index, 'backend' rewrites missing blobs from EigenDA. Orphaned objects are only reported.",
+			Value:   "none",
+			EnvVars: prefixEnvVars("RECONCILE_REPAIR"),
+		},
+		&cli.BoolFlag{
+			Name:    CoalesceReadsFlagName,
+			Usage:   "Whether to serve concurrent GET requests for the same commitment with a single backend fetch.",
+			Value:   true,
+			EnvVars: prefixEnvVars("COALESCE_READS"),
+		},
+		&cli.BoolFlag{
+			Name:    EnvelopeFlagName,
+			Usage:   "Whether to wrap the blobs written to cache and fallback targets in an envelope carrying their checksum, size and creation time, so that corrupt or truncated blobs are detected when read. Blobs are read whether wrapped or not.",
+			Value:   false,
+			EnvVars: prefixEnvVars("ENVELOPE"),
+		},
+		&cli.BoolFlag{
+			Name:    RefreshStaleCertsFlagName,
+			Usage:   "Whether to resolve blobs whose cert fails to be read or verified via their current cert from the disperser, in case their batch was re-confirmed. Non-deterministic: only the proxy that dispersed a blob can refresh its cert, other proxies fail to read it. Prefer --eigenda.wait-for-finalization, whose certs are never re-batched.",
+			EnvVars: prefixEnvVars("REFRESH_STALE_CERTS"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    ReadSLOFlagName,
+				Usage:   "EigenDA read latency after which GET requests read from the fallback targets in parallel and serve the first verified blob, rather than waiting for the EigenDA read to time out. Set to 0 to disable.",
+				Value:   0,
+				EnvVars: prefixEnvVars("READ_SLO"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    HedgeDelayFlagName,
+				Usage:   "Delay after which GET requests read from EigenDA while the cache target reads are still in flight, serving the first verified blob. Cache targets are read in parallel rather than one after another. Set to 0 to disable.",
+				Value:   0,
+				EnvVars: prefixEnvVars("HEDGE_DELAY"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    WarmPoolIntervalFlagName,
+				Usage:   "Interval between health pings of the EigenDA disperser, S3 and Redis backends, which keep their pooled connections warm and re-establish them on failure. Set to 0 to disable.",
+				Value:   0,
+				EnvVars: prefixEnvVars("WARM_POOL_INTERVAL"),
+			},
+		},
+		&cli.StringFlag{
+			Name:    DegradationModeFlagName,
+			Usage:   "Mode writes are served in while the dispersal error rate exceeds --degradation.error-rate: 'fail-fast' rejects them with 503, 'keccak' writes blobs of OptimismGeneric requests to S3 and returns keccak256 commitments. Disabled if unset.",
+			EnvVars: prefixEnvVars("DEGRADATION_MODE"),
+		},
+		&cli.Float64Flag{
+			Name:    DegradationErrorRateFlagName,
+			Usage:   "Share of failed dispersals within --degradation.window, between 0 and 1, above which the write path is degraded.",
+			Value:   0.5,
+			EnvVars: prefixEnvVars("DEGRADATION_ERROR_RATE"),
+		},
+		&cli.IntFlag{
+			Name:    DegradationMinDispersalsFlagName,
+			Usage:   "Minimum number of dispersals within --degradation.window before the error rate is considered.",
+			Value:   10,
+			EnvVars: prefixEnvVars("DEGRADATION_MIN_DISPERSALS"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    DegradationWindowFlagName,
+				Usage:   "Window the dispersal error rate is measured over.",
+				Value:   5 * time.Minute,
+				EnvVars: prefixEnvVars("DEGRADATION_WINDOW"),
+			},
+			Min: time.Millisecond,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    DegradationProbeIntervalFlagName,
+				Usage:   "Interval between health probes of EigenDA while the write path is degraded.",
+				Value:   30 * time.Second,
+				EnvVars: prefixEnvVars("DEGRADATION_PROBE_INTERVAL"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:    DegradationHealthyProbesFlagName,
+			Usage:   "Number of consecutive healthy probes after which the write path returns to normal.",
+			Value:   3,
+			EnvVars: prefixEnvVars("DEGRADATION_HEALTHY_PROBES"),
+		},
+		&cli.IntFlag{
+			Name:    BreakerFailureThresholdFlagName,
+			Usage:   "Number of consecutive failed operations on a cache or fallback target after which its circuit breaker opens and the target is skipped. Missing keys don't count as failures. Disabled if 0.",
+			Value:   0,
+			EnvVars: prefixEnvVars("CIRCUIT_BREAKER_FAILURE_THRESHOLD"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    BreakerOpenDurationFlagName,
+				Usage:   "Time a target is skipped for once its circuit breaker opened, after which single operations probe whether it recovered.",
+				Value:   30 * time.Second,
+				EnvVars: prefixEnvVars("CIRCUIT_BREAKER_OPEN_DURATION"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:    BreakerHalfOpenSuccessesFlagName,
+			Usage:   "Number of consecutive successful probes after which the circuit breaker of a target closes again.",
+			Value:   1,
+			EnvVars: prefixEnvVars("CIRCUIT_BREAKER_HALF_OPEN_SUCCESSES"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    BreakerFlapWindowFlagName,
+				Usage:   "Window within which a circuit breaker reopening after it closed counts as a flap of its target, and within which flaps are counted.",
+				Value:   10 * time.Minute,
+				EnvVars: prefixEnvVars("CIRCUIT_BREAKER_FLAP_WINDOW"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:    BreakerFlapThresholdFlagName,
+			Usage:   "Number of flaps within the flap window after which a target is flapping, and its circuit breaker stays open for the flap open duration. Flap detection is disabled if 0.",
+			Value:   3,
+			EnvVars: prefixEnvVars("CIRCUIT_BREAKER_FLAP_THRESHOLD"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    BreakerFlapOpenDurationFlagName,
+				Usage:   "Time a flapping target is skipped for once its circuit breaker opened. Must be at least the open duration.",
+				Value:   5 * time.Minute,
+				EnvVars: prefixEnvVars("CIRCUIT_BREAKER_FLAP_OPEN_DURATION"),
+			},
+			Min: time.Millisecond,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    TieringIntervalFlagName,
+				Usage:   "Interval between runs of the policy moving blobs between the cache targets, the fallback targets and the archive storage class of S3 fallback targets, based on their age and reads. Set to 0 to disable.",
+				Value:   0,
+				EnvVars: prefixEnvVars("TIERING_INTERVAL"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    TieringDemoteAfterFlagName,
+				Usage:   "Time after which blobs that weren't read are moved from the cache targets to the fallback targets. Set to 0 to keep them cached.",
+				Value:   24 * time.Hour,
+				EnvVars: prefixEnvVars("TIERING_DEMOTE_AFTER"),
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    TieringArchiveAfterFlagName,
+				Usage:   "Time after which blobs that weren't read are moved to the archive storage class of S3 fallback targets, see --s3.archive-storage-class. Set to 0 to disable archiving.",
+				Value:   0,
+				EnvVars: prefixEnvVars("TIERING_ARCHIVE_AFTER"),
+			},
+		},
+		&cli.IntFlag{
+			Name:    TieringPromoteReadsFlagName,
+			Usage:   "Number of reads after which blobs held by fallback targets only are copied back to the cache targets. Set to 0 to disable promotion.",
+			Value:   3,
+			EnvVars: prefixEnvVars("TIERING_PROMOTE_READS"),
+		},
+		&cli.IntFlag{
+			Name:    TieringBatchSizeFlagName,
+			Usage:   "Maximum number of blobs moved per tier and run of the tiering policy.",
+			Value:   1000,
+			EnvVars: prefixEnvVars("TIERING_BATCH_SIZE"),
+		},
+		&cli.Float64Flag{
+			Name:    AccessStatsSampleRateFlagName,
+			Usage:   "Fraction of the reads recorded in the commitment index, each counting for 1/rate reads, to bound the overhead of recording the reads of hot blobs. Between 0 and 1, 0 records every read.",
+			Value:   1,
+			EnvVars: prefixEnvVars("ACCESS_STATS_SAMPLE_RATE"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    AccessStatsWindowFlagName,
+				Usage:   "Sliding window the recent reads of the hot commitments report are counted over.",
+				Value:   index.DefaultAccessWindow,
+				EnvVars: prefixEnvVars("ACCESS_STATS_WINDOW"),
+			},
+			Min: time.Second,
+		},
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:    ChunkingMaxSizeFlagName,
+				Usage:   "Size above which PUT payloads are split into several blobs, e.g. 8MiB, returning an envelope commitment to a manifest blob listing their certs. Chunks must fit into a blob once encoded. Set to 0 to disable.",
+				Value:   "0",
+				EnvVars: prefixEnvVars("CHUNKING_MAX_SIZE"),
+			},
+		},
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:    ChunkingMinSizeFlagName,
+				Usage:   "Smallest size payloads are split into while dispersals of larger chunks are congested.",
+				Value:   "256KiB",
+				EnvVars: prefixEnvVars("CHUNKING_MIN_SIZE"),
+			},
+			Min: 1,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    ChunkingTargetLatencyFlagName,
+				Usage:   "Average dispersal latency above which chunks of a size are considered congested, so that smaller chunks are used.",
+				Value:   5 * time.Minute,
+				EnvVars: prefixEnvVars("CHUNKING_TARGET_LATENCY"),
+			},
+			Min: time.Millisecond,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    ChunkingWindowFlagName,
+				Usage:   "How long the dispersal latency and error rate of a chunk size are remembered for, after which larger chunks are tried again.",
+				Value:   15 * time.Minute,
+				EnvVars: prefixEnvVars("CHUNKING_WINDOW"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.StringFlag{
+			Name:    SnapshotRestoreFileFlagName,
+			Usage:   "Snapshot archive, taken via GET /admin/snapshot, to restore the commitment index, dead-letter queue and pending dispersals from on startup.",
+			EnvVars: prefixEnvVars("SNAPSHOT_RESTORE_FILE"),
+		},
+		&cli.StringFlag{
+			Name:    CrashReportDSNFlagName,
+			Usage:   "DSN of a Sentry-compatible project recovered request handler panics are reported to. Panics are only logged if unset.",
+			EnvVars: prefixEnvVars("CRASH_REPORT_DSN"),
+		},
+		&cli.StringFlag{
+			Name:    CrashReportEnvironmentFlagName,
+			Usage:   "Environment crash reports are tagged with, e.g. mainnet.",
+			EnvVars: prefixEnvVars("CRASH_REPORT_ENVIRONMENT"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    CrashReportTimeoutFlagName,
+				Usage:   "Timeout of a crash report upload.",
+				Value:   10 * time.Second,
+				EnvVars: prefixEnvVars("CRASH_REPORT_TIMEOUT"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.BoolFlag{
+			Name:    AdminEnabledFlagName,
+			Usage:   "Whether to serve the operator-facing admin API under /admin/.",
+			Value:   false,
+			EnvVars: prefixEnvVars("ADMIN_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    ExplorerEnabledFlagName,
+			Usage:   "Whether to serve the blob explorer web UI under /explorer/, listing recently indexed commitments. Intended for devnet debugging.",
+			Value:   false,
+			EnvVars: prefixEnvVars("EXPLORER_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    GatewayEnabledFlagName,
+			Usage:   "Whether to run as a hardened public read gateway: only the GET routes are served, with per client rate limits, caching headers and no admin endpoints.",
+			Value:   false,
+			EnvVars: prefixEnvVars("GATEWAY_ENABLED"),
+		},
+		&cli.Float64Flag{
+			Name:    GatewayRateLimitFlagName,
+			Usage:   "Sustained number of requests per second allowed per client (API key, or remote IP if unauthenticated) in gateway mode.",
+			Value:   5,
+			EnvVars: prefixEnvVars("GATEWAY_RATE_LIMIT"),
+		},
+		&cli.IntFlag{
+			Name:    GatewayBurstFlagName,
+			Usage:   "Number of requests a client can burst above the rate limit in gateway mode.",
+			Value:   10,
+			EnvVars: prefixEnvVars("GATEWAY_BURST"),
+		},
+		&cli.StringSliceFlag{
+			Name:    GatewayAPIKeysFlagName,
+			Usage:   "API keys accepted as bearer tokens in gateway mode. If set, unauthenticated requests are rejected.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("GATEWAY_API_KEYS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    GatewayAllowedPrefixesFlagName,
+			Usage:   "Hex encoded commitment prefixes allowed to be read in gateway mode, e.g. 0x010000 for generic EigenDA V0 commitments. All commitments are allowed if empty.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("GATEWAY_ALLOWED_COMMITMENT_PREFIXES"),
+			Action: func(_ *cli.Context, prefixes []string) error {
+				for _, p := range prefixes {
+					if _, err := hexutil.Decode(p); err != nil {
+						return fmt.Errorf("invalid commitment prefix %q: %w", p, err)
+					}
+				}
+				return nil
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    GatewayCacheMaxAgeFlagName,
+				Usage:   "Max age advertised to HTTP caches for successful reads in gateway mode.",
+				Value:   24 * time.Hour,
+				EnvVars: prefixEnvVars("GATEWAY_CACHE_MAX_AGE"),
+			},
+		},
+		&cli.StringSliceFlag{
+			Name:    GatewayTrustedProxiesFlagName,
+			Usage:   "IPs or CIDR ranges of proxies, e.g. a load balancer, whose X-Forwarded-For header identifies unauthenticated clients in gateway mode. If empty, clients are identified by the connection's remote address.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("GATEWAY_TRUSTED_PROXIES"),
+			Action: func(_ *cli.Context, proxies []string) error {
+				for _, p := range proxies {
+					if _, _, err := net.ParseCIDR(p); err != nil && net.ParseIP(p) == nil {
+						return fmt.Errorf("invalid trusted proxy %q: expected an IP or a CIDR range", p)
+					}
+				}
+				return nil
+			},
+		},
+		&cli.StringSliceFlag{
+			Name:    UsageTenantsFlagName,
+			Usage:   "Tenants dispersal usage is tracked for, identified by the X-EigenDA-Proxy-Tenant header of PUT requests. Requests naming another tenant are accounted to 'other'. If empty, all usage is accounted to 'default'.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("USAGE_TENANTS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    CORSAllowedOriginsFlagName,
+			Usage:   "Origins allowed to fetch blobs from the GET routes via CORS (e.g. https://explorer.example.com, or * for any). CORS is disabled if empty.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("CORS_ALLOWED_ORIGINS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    CORSAllowedMethodsFlagName,
+			Usage:   "Methods allowed in CORS preflight responses.",
+			Value:   cli.NewStringSlice(http.MethodGet, http.MethodHead, http.MethodOptions),
+			EnvVars: prefixEnvVars("CORS_ALLOWED_METHODS"),
+		},
+		&cli.StringSliceFlag{
+			Name:    CORSAllowedHeadersFlagName,
+			Usage:   "Request headers allowed in CORS preflight responses.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("CORS_ALLOWED_HEADERS"),
+		},
+		&cli.BoolFlag{
+			Name:    SecurityHeadersFlagName,
+			Usage:   "Whether to set standard security headers (nosniff, frame denial, restrictive CSP) on the GET routes.",
+			Value:   true,
+			EnvVars: prefixEnvVars("SECURITY_HEADERS_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    StreamingEnabledFlagName,
+			Usage:   "Whether GET requests setting the X-EigenDA-Proxy-Stream header are served while the blob is verified, with the verification result sent in a trailer.",
+			Value:   false,
+			EnvVars: prefixEnvVars("STREAMING_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    TTLHintsEnabledFlagName,
+			Usage:   "Whether PUT requests setting the X-EigenDA-Proxy-TTL header limit how long Redis, S3 and the filesystem backend keep their blob.",
+			Value:   false,
+			EnvVars: prefixEnvVars("TTL_HINTS_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    RedundancyRequestsEnabledFlagName,
+			Usage:   "Whether PUT requests setting the X-EigenDA-Proxy-Redundancy header are also persisted to the listed secondary backends, whether they are cache or fallback targets or not.",
+			Value:   false,
+			EnvVars: prefixEnvVars("REDUNDANCY_REQUESTS_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    CommitmentsAPIEnabledFlagName,
+			Usage:   "Whether to serve the /commitments route, listing the indexed commitments filtered by creation, tenant and backend.",
+			Value:   false,
+			EnvVars: prefixEnvVars("COMMITMENTS_API_ENABLED"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    EigenDAInfoRefreshIntervalFlagName,
+				Usage:   "How long the EigenDA network parameters served on /eigenda-info are cached for before being fetched again.",
+				Value:   10 * time.Minute,
+				EnvVars: prefixEnvVars("EIGENDA_INFO_REFRESH_INTERVAL"),
+			},
+		},
+		&cli.StringFlag{
+			Name:    MirrorURLFlagName,
+			Usage:   "URL of a shadow proxy, e.g. a new version under test, to which a share of the PUT and GET requests is mirrored and whose responses are compared to the proxy's. Mirroring is disabled if unset.",
+			EnvVars: prefixEnvVars("MIRROR_URL"),
+		},
+		&cli.Float64Flag{
+			Name:    MirrorPercentageFlagName,
+			Usage:   "Percentage of the PUT and GET requests mirrored to the shadow proxy.",
+			Value:   1,
+			EnvVars: prefixEnvVars("MIRROR_PERCENTAGE"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    MirrorTimeoutFlagName,
+				Usage:   "Timeout of a request mirrored to the shadow proxy, including the dispersal of mirrored PUT requests.",
+				Value:   30 * time.Minute,
+				EnvVars: prefixEnvVars("MIRROR_TIMEOUT"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:    MirrorMaxInFlightFlagName,
+			Usage:   "Maximum number of mirrored requests in flight. Further requests aren't mirrored until one completes.",
+			Value:   16,
+			EnvVars: prefixEnvVars("MIRROR_MAX_IN_FLIGHT"),
+		},
+		&cli.BoolFlag{
+			Name:    MirrorForwardCredentialsFlagName,
+			Usage:   "Whether the Authorization header of mirrored requests is forwarded to the shadow proxy, e.g. one authenticating namespaces. Only the proxy's own headers are forwarded otherwise.",
+			Value:   false,
+			EnvVars: prefixEnvVars("MIRROR_FORWARD_CREDENTIALS"),
+		},
+		&cli.BoolFlag{
+			Name:    AsyncEnabledFlagName,
+			Usage:   "Whether PUT requests setting the X-EigenDA-Proxy-Async header are dispersed in the background, answered at once with a job ID whose status is served on /status/{job-id}.",
+			Value:   false,
+			EnvVars: prefixEnvVars("ASYNC_ENABLED"),
+		},
+		&cli.IntFlag{
+			Name:    AsyncMaxInFlightFlagName,
+			Usage:   "Maximum number of asynchronous PUTs dispersed concurrently. Further jobs are queued until one completes.",
+			Value:   16,
+			EnvVars: prefixEnvVars("ASYNC_MAX_IN_FLIGHT"),
+		},
+		&cli.IntFlag{
+			Name:    AsyncMaxQueuedFlagName,
+			Usage:   "Maximum number of queued asynchronous PUTs. Further asynchronous PUTs are rejected with 503.",
+			Value:   256,
+			EnvVars: prefixEnvVars("ASYNC_MAX_QUEUED"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    AsyncRetentionFlagName,
+				Usage:   "How long the status of completed asynchronous PUTs is served for.",
+				Value:   time.Hour,
+				EnvVars: prefixEnvVars("ASYNC_RETENTION"),
+			},
+		},
+		&cli.StringFlag{
+			Name:    AsyncMinAckFlagName,
+			Usage:   "Earliest point at which PUT requests are answered: cache, dispersed or confirmed. PUTs asking for an earlier one via the X-EigenDA-Proxy-Ack or X-EigenDA-Proxy-Async header are answered at this level.",
+			Value:   "cache",
+			EnvVars: prefixEnvVars("ASYNC_MIN_ACK"),
+		},
+		&cli.StringFlag{
+			Name:    AsyncJournalDirFlagName,
+			Usage:   "Directory PUTs answered at the cache ack level are journaled to until dispersed, and replayed from on restart. Required unless --async.min-ack is dispersed or confirmed.",
+			Value:   "",
+			EnvVars: prefixEnvVars("ASYNC_JOURNAL_DIR"),
+		},
+		&cli.IntFlag{
+			Name:    BatchMaxBlobsFlagName,
+			Usage:   "Maximum number of blobs of a PUT request on /batch/put. Set to 0 to disable batch PUTs.",
+			Value:   64,
+			EnvVars: prefixEnvVars("BATCH_MAX_BLOBS"),
+		},
+		&cli.IntFlag{
+			Name:    BatchConcurrencyFlagName,
+			Usage:   "Number of blobs of a batch PUT dispersed concurrently.",
+			Value:   8,
+			EnvVars: prefixEnvVars("BATCH_CONCURRENCY"),
+		},
+		&cli.BoolFlag{
+			Name:    PriorityEnabledFlagName,
+			Usage:   "Whether reads are served within separate concurrency pools per request class, interactive (the default) or batch as selected by the X-EigenDA-Proxy-Request-Class header, so that archival scans can't add latency to derivation reads.",
+			Value:   false,
+			EnvVars: prefixEnvVars("PRIORITY_ENABLED"),
+		},
+		&cli.IntFlag{
+			Name:    PriorityInteractiveConcurrencyFlagName,
+			Usage:   "Maximum number of interactive reads served concurrently.",
+			Value:   64,
+			EnvVars: prefixEnvVars("PRIORITY_INTERACTIVE_CONCURRENCY"),
+		},
+		&cli.IntFlag{
+			Name:    PriorityBatchConcurrencyFlagName,
+			Usage:   "Maximum number of batch reads served concurrently.",
+			Value:   4,
+			EnvVars: prefixEnvVars("PRIORITY_BATCH_CONCURRENCY"),
+		},
+		&cli.IntFlag{
+			Name:    PriorityMaxQueuedFlagName,
+			Usage:   "Maximum number of reads of a request class waiting for a slot of its pool. Further reads are rejected with 503.",
+			Value:   256,
+			EnvVars: prefixEnvVars("PRIORITY_MAX_QUEUED"),
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:    PriorityQueueTimeoutFlagName,
+				Usage:   "How long a read waits for a slot of the pool of its request class before being rejected with 503.",
+				Value:   5 * time.Second,
+				EnvVars: prefixEnvVars("PRIORITY_QUEUE_TIMEOUT"),
+			},
+			Min: time.Millisecond,
+		},
+		&cli.StringSliceFlag{
+			Name:    PriorityBatchAPIKeysFlagName,
+			Usage:   "API keys of batch clients, e.g. archival indexers. Reads presenting one as a bearer token are batch reads regardless of their request class header.",
+			Value:   cli.NewStringSlice(),
+			EnvVars: prefixEnvVars("PRIORITY_BATCH_API_KEYS"),
+		},
 	}
 
 	return flags
@@ -71,13 +846,30 @@ func CLIFlags() []cli.Flag {
 // Flags contains the list of configuration options available to the binary.
 var Flags = []cli.Flag{}
 
+// AllFlags ... returns new instances of every configuration option available to the binary, e.g. to parse a
+// configuration other than the one the binary was started with
+func AllFlags() []cli.Flag {
+	flags := CLIFlags()
+	flags = append(flags, oplog.CLIFlags(EnvVarPrefix)...)
+	flags = append(flags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	flags = append(flags, eigendaflags.CLIFlags(EnvVarPrefix, EigenDAClientCategory)...)
+	flags = append(flags, redis.CLIFlags(EnvVarPrefix, RedisCategory)...)
+	flags = append(flags, s3.CLIFlags(EnvVarPrefix, S3Category)...)
+	flags = append(flags, fs.CLIFlags(EnvVarPrefix, FSCategory)...)
+	flags = append(flags, gcs.CLIFlags(EnvVarPrefix, GCSCategory)...)
+	flags = append(flags, azure.CLIFlags(EnvVarPrefix, AzureCategory)...)
+	flags = append(flags, localcache.CLIFlags(EnvVarPrefix, LocalCacheCategory)...)
+	flags = append(flags, memstore.CLIFlags(EnvVarPrefix, MemstoreFlagsCategory)...)
+	flags = append(flags, verify.CLIFlags(EnvVarPrefix, VerifierCategory)...)
+	flags = append(flags, backfill.CLIFlags(EnvVarPrefix, BackfillCategory)...)
+	flags = append(flags, standby.CLIFlags(EnvVarPrefix, StandbyCategory)...)
+	flags = append(flags, hooks.CLIFlags(EnvVarPrefix, HooksCategory)...)
+	flags = append(flags, featuregate.CLIFlags(EnvVarPrefix, FeatureGatesCategory)...)
+	flags = append(flags, tenancy.CLIFlags(EnvVarPrefix, TenancyCategory)...)
+	flags = append(flags, tracing.CLIFlags(EnvVarPrefix, TracingCategory)...)
+	return flags
+}
+
 func init() {
-	Flags = CLIFlags()
-	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
-	Flags = append(Flags, opmetrics.CLIFlags(EnvVarPrefix)...)
-	Flags = append(Flags, eigendaflags.CLIFlags(EnvVarPrefix, EigenDAClientCategory)...)
-	Flags = append(Flags, redis.CLIFlags(EnvVarPrefix, RedisCategory)...)
-	Flags = append(Flags, s3.CLIFlags(EnvVarPrefix, S3Category)...)
-	Flags = append(Flags, memstore.CLIFlags(EnvVarPrefix, MemstoreFlagsCategory)...)
-	Flags = append(Flags, verify.CLIFlags(EnvVarPrefix, VerifierCategory)...)
+	Flags = AllFlags()
 }
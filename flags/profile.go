@@ -0,0 +1,101 @@
+package flags
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/flags/eigendaflags"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/urfave/cli/v2"
+)
+
+// Profile ... network-correct values of the flags that have to match the EigenDA network the proxy disperses to
+type Profile struct {
+	DisperserRPC   string
+	SvcManagerAddr string
+	// CustomQuorumIDs are written to in addition to the default quorums 0 and 1
+	CustomQuorumIDs []uint
+	G1Path          string
+	G2PowerOf2Path  string
+	CachePath       string
+}
+
+// Profiles ... presets selected with --profile
+var Profiles = map[string]Profile{
+	"mainnet": {
+		DisperserRPC:   "disperser.eigenda.xyz:443",
+		SvcManagerAddr: "0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0",
+		G1Path:         "resources/g1.point",
+		G2PowerOf2Path: "resources/g2.point.powerOf2",
+		CachePath:      "resources/SRSTables/",
+	},
+	"holesky": {
+		DisperserRPC:   "disperser-holesky.eigenda.xyz:443",
+		SvcManagerAddr: "0xD4A7E1Bd8015057293f0D0A557088c286942e84b",
+		G1Path:         "resources/g1.point",
+		G2PowerOf2Path: "resources/g2.point.powerOf2",
+		CachePath:      "resources/SRSTables/",
+	},
+	// the preprod network on Holesky
+	"devnet": {
+		DisperserRPC:   "disperser-preprod-holesky.eigenda.xyz:443",
+		SvcManagerAddr: "0x54A03db2784E3D0aCC08344D05385d0b62d4F432",
+		G1Path:         "resources/g1.point",
+		G2PowerOf2Path: "resources/g2.point.powerOf2",
+		CachePath:      "resources/SRSTables/",
+	},
+}
+
+// ProfileNames ... returns the names of the profiles in alphabetical order
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// values ... returns the profile's value of each flag it presets, empty fields aren't preset
+func (p Profile) values() map[string]string {
+	values := make(map[string]string)
+	for flag, value := range map[string]string{
+		eigendaflags.DisperserRPCFlagName: p.DisperserRPC,
+		verify.SvcManagerAddrFlagName:     p.SvcManagerAddr,
+		verify.G1PathFlagName:             p.G1Path,
+		verify.G2TauFlagName:              p.G2PowerOf2Path,
+		verify.CachePathFlagName:          p.CachePath,
+	} {
+		if value != "" {
+			values[flag] = value
+		}
+	}
+	if len(p.CustomQuorumIDs) > 0 {
+		ids := make([]string, len(p.CustomQuorumIDs))
+		for i, id := range p.CustomQuorumIDs {
+			ids[i] = strconv.FormatUint(uint64(id), 10)
+		}
+		values[eigendaflags.CustomQuorumIDsFlagName] = strings.Join(ids, ",")
+	}
+	return values
+}
+
+// ApplyProfile ... sets the flags preset by the profile name, unless they were set explicitly via the command line
+// or the environment
+func ApplyProfile(ctx *cli.Context, name string) error {
+	p, ok := Profiles[name]
+	if !ok {
+		return fmt.Errorf("unknown profile %q, expected one of %s", name, strings.Join(ProfileNames(), ", "))
+	}
+	for flag, value := range p.values() {
+		if ctx.IsSet(flag) {
+			continue
+		}
+		if err := ctx.Set(flag, value); err != nil {
+			return fmt.Errorf("failed to apply profile %s to --%s: %w", name, flag, err)
+		}
+	}
+	return nil
+}
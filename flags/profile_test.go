@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"io"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/flags/eigendaflags"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestProfile(t *testing.T) {
+	run := func(args ...string) (*cli.Context, error) {
+		var cliCtx *cli.Context
+		app := &cli.App{
+			Flags:     AllFlags(),
+			Writer:    io.Discard,
+			ErrWriter: io.Discard,
+			Action: func(ctx *cli.Context) error {
+				cliCtx = ctx
+				return nil
+			},
+		}
+		err := app.Run(append([]string{"eigenda-proxy"}, args...))
+		return cliCtx, err
+	}
+
+	ctx, err := run("--profile=holesky", "--"+verify.G1PathFlagName+"=/srs/g1.point")
+	require.NoError(t, err)
+	require.Equal(t, "disperser-holesky.eigenda.xyz:443", ctx.String(eigendaflags.DisperserRPCFlagName))
+	require.Equal(t, Profiles["holesky"].SvcManagerAddr, ctx.String(verify.SvcManagerAddrFlagName))
+	require.Equal(t, "resources/g2.point.powerOf2", ctx.String(verify.G2TauFlagName))
+	require.Empty(t, ctx.UintSlice(eigendaflags.CustomQuorumIDsFlagName))
+	// explicit flags take precedence
+	require.Equal(t, "/srs/g1.point", ctx.String(verify.G1PathFlagName))
+
+	t.Setenv(EnvVarPrefix+"_EIGENDA_DISPERSER_RPC", "localhost:32001")
+	ctx, err = run("--profile=mainnet")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:32001", ctx.String(eigendaflags.DisperserRPCFlagName))
+	require.Equal(t, Profiles["mainnet"].SvcManagerAddr, ctx.String(verify.SvcManagerAddrFlagName))
+
+	_, err = run("--profile=sepolia")
+	require.ErrorContains(t, err, "unknown profile")
+}
+
+func TestProfileQuorums(t *testing.T) {
+	Profiles["test"] = Profile{CustomQuorumIDs: []uint{2, 3}}
+	defer delete(Profiles, "test")
+
+	var quorums []uint
+	app := &cli.App{
+		Flags:  AllFlags(),
+		Writer: io.Discard,
+		Action: func(ctx *cli.Context) error {
+			quorums = ctx.UintSlice(eigendaflags.CustomQuorumIDsFlagName)
+			return nil
+		},
+	}
+	require.NoError(t, app.Run([]string{"eigenda-proxy", "--profile=test"}))
+	require.Equal(t, []uint{2, 3}, quorums)
+}
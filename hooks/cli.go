@@ -0,0 +1,110 @@
+package hooks
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	NamesFlagName       = withFlagPrefix("names")
+	SchemaFlagName      = withFlagPrefix("schema")
+	HTTPURLFlagName     = withFlagPrefix("http.url")
+	HTTPStagesFlagName  = withFlagPrefix("http.stages")
+	HTTPTimeoutFlagName = withFlagPrefix("http.timeout")
+)
+
+func withFlagPrefix(s string) string {
+	return "hooks." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_HOOKS_" + s}
+}
+
+// Config ... hooks run on the PUT and GET paths
+type Config struct {
+	// Schema PUT payloads are validated against before any other hook runs, disabled if empty
+	Schema string
+	// Names of the compiled-in hooks, in the order they run on PUTs
+	Names []string
+	// HTTP hook, runs after the compiled-in hooks on PUTs if its URL is set
+	HTTP HTTPConfig
+}
+
+// Enabled ... returns whether any hook is configured
+func (c Config) Enabled() bool {
+	return c.Schema != "" || len(c.Names) > 0 || c.HTTP.URL != ""
+}
+
+// CLIFlags ... used for request hook configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     SchemaFlagName,
+			Usage:    "Schema PUT payloads must match before being dispersed, e.g. 'op-frames' for OP Stack channel frames. Malformed payloads are rejected with 400 Bad Request. Disabled if unset.",
+			EnvVars:  withEnvPrefix(envPrefix, "SCHEMA"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     NamesFlagName,
+			Usage:    "Compiled-in hooks to run on PUTs and GETs, in the order they run on PUTs. GETs run them in reverse order.",
+			Value:    cli.NewStringSlice(),
+			EnvVars:  withEnvPrefix(envPrefix, "NAMES"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     HTTPURLFlagName,
+			Usage:    "URL of an external hook endpoint each request stage is POSTed to. Runs after the compiled-in hooks on PUTs.",
+			EnvVars:  withEnvPrefix(envPrefix, "HTTP_URL"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     HTTPStagesFlagName,
+			Usage:    "Stages the external hook endpoint is called on (pre-put, post-put, pre-get, post-get).",
+			Value:    cli.NewStringSlice(string(PrePut), string(PostPut), string(PreGet), string(PostGet)),
+			EnvVars:  withEnvPrefix(envPrefix, "HTTP_STAGES"),
+			Category: category,
+			Action: func(_ *cli.Context, stages []string) error {
+				for _, s := range stages {
+					if _, err := ParseStage(s); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     HTTPTimeoutFlagName,
+				Usage:    "Timeout of a single call to the external hook endpoint.",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "HTTP_TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+	}
+}
+
+// ReadConfig ... parses the hook Config from the provided flags or environment variables
+func ReadConfig(ctx *cli.Context) Config {
+	// stages have already been validated by the flag's action
+	var stages []Stage
+	for _, s := range ctx.StringSlice(HTTPStagesFlagName) {
+		stage, _ := ParseStage(s)
+		stages = append(stages, stage)
+	}
+
+	return Config{
+		Schema: ctx.String(SchemaFlagName),
+		Names:  ctx.StringSlice(NamesFlagName),
+		HTTP: HTTPConfig{
+			URL:     ctx.String(HTTPURLFlagName),
+			Stages:  stages,
+			Timeout: ctx.Duration(HTTPTimeoutFlagName),
+		},
+	}
+}
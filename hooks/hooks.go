@@ -0,0 +1,156 @@
+// Package hooks provides an extension point to inspect and transform payloads and commitments on the PUT
+// and GET paths, so that org-specific policies (redaction, watermarking, custom framing) can be enforced
+// without forking the handler code. Hooks are either compiled into the binary and registered by name, or
+// served by an external HTTP endpoint.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrRejected ... returned by hooks to reject a request, e.g. because it violates a policy. Rejected
+// requests are answered with 403 Forbidden instead of an internal error.
+var ErrRejected = errors.New("request rejected by hook")
+
+// Stage ... point of the request path a hook runs at
+type Stage string
+
+const (
+	// PrePut ... before a payload is stored, the hook may replace the payload
+	PrePut Stage = "pre-put"
+	// PostPut ... after a payload is stored, the hook may replace the commitment returned to the caller
+	PostPut Stage = "post-put"
+	// PreGet ... before a blob is read, the hook may replace the commitment to read
+	PreGet Stage = "pre-get"
+	// PostGet ... after a blob is read, the hook may replace the payload returned to the caller
+	PostGet Stage = "post-get"
+)
+
+// Stages ... every stage, in request order
+var Stages = []Stage{PrePut, PostPut, PreGet, PostGet}
+
+// ParseStage ... parses the name of a stage
+func ParseStage(s string) (Stage, error) {
+	for _, stage := range Stages {
+		if string(stage) == s {
+			return stage, nil
+		}
+	}
+	return "", fmt.Errorf("unknown hook stage %q", s)
+}
+
+// Call ... request state passed through the hooks of a stage. Commitment is the raw commitment without
+// mode specific prefixes, it is empty on PrePut unless the caller provided the key (keccak256 mode).
+type Call struct {
+	Stage      Stage                      `json:"stage"`
+	Mode       commitments.CommitmentMode `json:"mode"`
+	Commitment hexutil.Bytes              `json:"commitment,omitempty"`
+	Payload    hexutil.Bytes              `json:"payload,omitempty"`
+}
+
+// Hook ... inspects and transforms a request. Run is called at every stage and returns the call with the
+// commitment or payload replaced, or unchanged for stages the hook doesn't act on. Stage and Mode of the
+// returned call are ignored. Hooks must be safe for concurrent use.
+type Hook interface {
+	Run(ctx context.Context, call Call) (Call, error)
+}
+
+// HookFunc ... adapter to use an ordinary function as a Hook
+type HookFunc func(ctx context.Context, call Call) (Call, error)
+
+func (f HookFunc) Run(ctx context.Context, call Call) (Call, error) {
+	return f(ctx, call)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Hook)
+)
+
+// Register ... makes a compiled-in hook available by name, typically from the init function of the package
+// providing it. Panics if the name is already taken.
+func Register(name string, h Hook) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("hook %q registered twice", name))
+	}
+	registry[name] = h
+}
+
+// Registered ... returns the sorted names of the compiled-in hooks
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup ... returns the compiled-in hook registered under name
+func lookup(name string) (Hook, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	h, ok := registry[name]
+	return h, ok
+}
+
+// Chain ... hooks run in order on the PUT stages and in reverse order on the GET stages, so that the
+// transformations of the write path are undone in the opposite order on the read path. The zero value
+// is a no-op.
+type Chain []Hook
+
+// Load ... builds the chain of the compiled-in hooks named in the config, preceded by the schema validation
+// hook and followed by the HTTP hook if set
+func Load(cfg Config) (Chain, error) {
+	var chain Chain
+	if cfg.Schema != "" {
+		h, err := loadSchema(cfg.Schema)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, h)
+	}
+	for _, name := range cfg.Names {
+		h, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown hook %q, registered hooks: %v", name, Registered())
+		}
+		chain = append(chain, h)
+	}
+	if cfg.HTTP.URL != "" {
+		chain = append(chain, NewHTTPHook(cfg.HTTP))
+	}
+	return chain, nil
+}
+
+// Run ... passes the call through every hook of the chain
+func (c Chain) Run(ctx context.Context, call Call) (Call, error) {
+	for i := range c {
+		h := c[i]
+		if call.Stage == PreGet || call.Stage == PostGet {
+			h = c[len(c)-1-i]
+		}
+
+		out, err := h.Run(ctx, call)
+		if err != nil {
+			return Call{}, fmt.Errorf("%s hook failed: %w", call.Stage, err)
+		}
+		call.Commitment = out.Commitment
+		call.Payload = out.Payload
+	}
+	return call, nil
+}
@@ -0,0 +1,129 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/stretchr/testify/require"
+)
+
+// appendHook ... appends a marker to the payload on PUTs and strips it on GETs
+func appendHook(marker string) Hook {
+	return HookFunc(func(_ context.Context, call Call) (Call, error) {
+		switch call.Stage {
+		case PrePut:
+			call.Payload = append(append([]byte(nil), call.Payload...), marker...)
+		case PostGet:
+			trimmed, ok := bytes.CutSuffix(call.Payload, []byte(marker))
+			if !ok {
+				return Call{}, ErrRejected
+			}
+			call.Payload = trimmed
+		}
+		return call, nil
+	})
+}
+
+func TestChainOrder(t *testing.T) {
+	chain := Chain{appendHook("a"), appendHook("b")}
+
+	put, err := chain.Run(context.Background(), Call{Stage: PrePut, Payload: []byte("x")})
+	require.NoError(t, err)
+	require.Equal(t, "xab", string(put.Payload))
+
+	// GETs undo the PUT transformations in reverse order
+	get, err := chain.Run(context.Background(), Call{Stage: PostGet, Payload: put.Payload})
+	require.NoError(t, err)
+	require.Equal(t, "x", string(get.Payload))
+
+	_, err = chain.Run(context.Background(), Call{Stage: PostGet, Payload: []byte("xba")})
+	require.ErrorIs(t, err, ErrRejected)
+
+	var empty Chain
+	call, err := empty.Run(context.Background(), Call{Stage: PrePut, Payload: []byte("x")})
+	require.NoError(t, err)
+	require.Equal(t, "x", string(call.Payload))
+}
+
+func TestLoad(t *testing.T) {
+	Register("test-load", appendHook("l"))
+	require.Panics(t, func() { Register("test-load", appendHook("l")) })
+	require.Contains(t, Registered(), "test-load")
+
+	chain, err := Load(Config{Names: []string{"test-load"}, HTTP: HTTPConfig{URL: "http://127.0.0.1:1"}})
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+
+	_, err = Load(Config{Names: []string{"no-such-hook"}})
+	require.ErrorContains(t, err, "unknown hook")
+}
+
+func TestHTTPHook(t *testing.T) {
+	var status int
+	var calls []Call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var call Call
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&call))
+		calls = append(calls, call)
+
+		switch status {
+		case http.StatusOK:
+			_ = json.NewEncoder(w).Encode(Call{Payload: append(call.Payload, '!')})
+		case http.StatusForbidden:
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("payload contains pii"))
+		default:
+			w.WriteHeader(status)
+		}
+	}))
+	defer srv.Close()
+
+	h := NewHTTPHook(HTTPConfig{URL: srv.URL, Stages: []Stage{PrePut}, Timeout: time.Second})
+	in := Call{Stage: PrePut, Mode: commitments.OptimismGeneric, Commitment: []byte{0x01}, Payload: []byte("blob")}
+
+	t.Run("Transform", func(t *testing.T) {
+		status = http.StatusOK
+		out, err := h.Run(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, "blob!", string(out.Payload))
+		// unset fields of the response are left unchanged
+		require.Equal(t, []byte{0x01}, []byte(out.Commitment))
+		require.Equal(t, in, calls[len(calls)-1])
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		status = http.StatusNoContent
+		out, err := h.Run(context.Background(), in)
+		require.NoError(t, err)
+		require.Equal(t, in, out)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		status = http.StatusForbidden
+		_, err := h.Run(context.Background(), in)
+		require.ErrorIs(t, err, ErrRejected)
+		require.ErrorContains(t, err, "payload contains pii")
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		status = http.StatusBadGateway
+		_, err := h.Run(context.Background(), in)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("OtherStagesSkipped", func(t *testing.T) {
+		n := len(calls)
+		get := Call{Stage: PostGet, Payload: []byte("blob")}
+		out, err := h.Run(context.Background(), get)
+		require.NoError(t, err)
+		require.Equal(t, get, out)
+		require.Len(t, calls, n)
+	})
+}
@@ -0,0 +1,98 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxHTTPHookResponseSize ... upper bound of a response of the HTTP hook, blobs are at most 16MiB
+const maxHTTPHookResponseSize = 64 << 20
+
+// HTTPConfig ... external hook served over HTTP
+type HTTPConfig struct {
+	URL     string
+	Stages  []Stage
+	Timeout time.Duration
+}
+
+// HTTPHook ... hook delegating to an external HTTP endpoint. Each call of a configured stage is POSTed as a
+// JSON encoded Call. The endpoint responds with:
+//   - 200 and a JSON encoded Call, whose commitment and payload replace those of the request if set
+//   - 204 to leave the request unchanged
+//   - 403 to reject the request, with the reason as body
+//
+// Any other response fails the request, so that policies can't be bypassed by an unavailable endpoint.
+type HTTPHook struct {
+	url    string
+	stages []Stage
+	client *http.Client
+}
+
+// NewHTTPHook ... constructor
+func NewHTTPHook(cfg HTTPConfig) *HTTPHook {
+	stages := cfg.Stages
+	if len(stages) == 0 {
+		stages = Stages
+	}
+	return &HTTPHook{
+		url:    cfg.URL,
+		stages: stages,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (h *HTTPHook) Run(ctx context.Context, call Call) (Call, error) {
+	if !slices.Contains(h.stages, call.Stage) {
+		return call, nil
+	}
+
+	b, err := json.Marshal(call)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to marshal hook call: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(b))
+	if err != nil {
+		return Call{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to call hook endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPHookResponseSize))
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to read hook response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var out Call
+		if err := json.Unmarshal(body, &out); err != nil {
+			return Call{}, fmt.Errorf("failed to unmarshal hook response: %w", err)
+		}
+		if out.Commitment != nil {
+			call.Commitment = out.Commitment
+		}
+		if out.Payload != nil {
+			call.Payload = out.Payload
+		}
+		return call, nil
+	case http.StatusNoContent:
+		return call, nil
+	case http.StatusForbidden:
+		return Call{}, fmt.Errorf("%w: %s", ErrRejected, strings.TrimSpace(string(body)))
+	default:
+		return Call{}, fmt.Errorf("hook endpoint responded with status %d: %s", resp.StatusCode,
+			strings.TrimSpace(string(body)))
+	}
+}
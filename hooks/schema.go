@@ -0,0 +1,120 @@
+package hooks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrInvalidPayload ... returned by schema validation when a PUT payload doesn't match the configured
+// schema. Invalid payloads are answered with 400 Bad Request before anything is dispersed.
+var ErrInvalidPayload = errors.New("invalid payload")
+
+// OPFramesSchema ... name of the schema of OP Stack batcher data: a derivation version byte followed by
+// one or more channel frames
+const OPFramesSchema = "op-frames"
+
+// Schema ... validates a PUT payload, returning why it is malformed
+type Schema func(payload []byte) error
+
+var schemas = map[string]Schema{
+	OPFramesSchema: ValidateOPFrames,
+}
+
+// RegisterSchema ... makes a compiled-in payload schema available by name, typically from the init function
+// of the package providing it. Panics if the name is already taken.
+func RegisterSchema(name string, s Schema) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := schemas[name]; ok {
+		panic(fmt.Sprintf("schema %q registered twice", name))
+	}
+	schemas[name] = s
+}
+
+// RegisteredSchemas ... returns the sorted names of the compiled-in payload schemas
+func RegisteredSchemas() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaHook ... rejects PUT payloads that don't match the schema with ErrInvalidPayload
+func SchemaHook(name string, s Schema) Hook {
+	return HookFunc(func(_ context.Context, call Call) (Call, error) {
+		if call.Stage != PrePut {
+			return call, nil
+		}
+		if err := s(call.Payload); err != nil {
+			return Call{}, fmt.Errorf("%w: payload doesn't match the %s schema: %w", ErrInvalidPayload, name, err)
+		}
+		return call, nil
+	})
+}
+
+// loadSchema ... builds the validation hook of the schema registered under name
+func loadSchema(name string) (Hook, error) {
+	registryMu.RLock()
+	s, ok := schemas[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown payload schema %q, registered schemas: %v", name, RegisteredSchemas())
+	}
+	return SchemaHook(name, s), nil
+}
+
+const (
+	// opDerivationVersion0 ... version byte prefixing the frames of batcher data
+	opDerivationVersion0 = 0
+	// opFrameHeaderLen ... channel id (16 bytes), frame number (uint16) and frame data length (uint32)
+	opFrameHeaderLen = 16 + 2 + 4
+	// opMaxFrameLen ... upper bound of the frame data length enforced by the derivation pipeline
+	opMaxFrameLen = 1_000_000
+)
+
+// ValidateOPFrames ... checks that the payload is OP Stack batcher data, i.e. the derivation version byte
+// followed by one or more frames of the form
+//
+//	channel_id (16 bytes) ++ frame_number (uint16) ++ frame_data_length (uint32) ++ frame_data ++ is_last (1 byte)
+//
+// filling the whole payload. The frame data itself, i.e. the compressed channel, isn't inspected.
+func ValidateOPFrames(payload []byte) error {
+	if len(payload) == 0 {
+		return errors.New("empty payload")
+	}
+	if payload[0] != opDerivationVersion0 {
+		return fmt.Errorf("unknown derivation version %d", payload[0])
+	}
+
+	data := payload[1:]
+	if len(data) == 0 {
+		return errors.New("no frames")
+	}
+	for frame := 0; len(data) > 0; frame++ {
+		if len(data) < opFrameHeaderLen+1 {
+			return fmt.Errorf("frame %d: truncated header", frame)
+		}
+		length := binary.BigEndian.Uint32(data[opFrameHeaderLen-4 : opFrameHeaderLen])
+		if length > opMaxFrameLen {
+			return fmt.Errorf("frame %d: data length %d exceeds maximum of %d", frame, length, opMaxFrameLen)
+		}
+		end := opFrameHeaderLen + int(length)
+		if len(data) < end+1 {
+			return fmt.Errorf("frame %d: truncated data, expected %d bytes", frame, length)
+		}
+		if isLast := data[end]; isLast > 1 {
+			return fmt.Errorf("frame %d: invalid is_last byte %d", frame, isLast)
+		}
+		data = data[end+1:]
+	}
+	return nil
+}
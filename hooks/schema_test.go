@@ -0,0 +1,75 @@
+package hooks
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// opFrame ... encodes an OP Stack channel frame
+func opFrame(number uint16, data []byte, isLast bool) []byte {
+	frame := make([]byte, 16, 16+2+4+len(data)+1)
+	frame = binary.BigEndian.AppendUint16(frame, number)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(data))) // #nosec G115
+	frame = append(frame, data...)
+	if isLast {
+		return append(frame, 1)
+	}
+	return append(frame, 0)
+}
+
+func TestValidateOPFrames(t *testing.T) {
+	frame := opFrame(0, []byte("compressed channel"), false)
+	last := opFrame(1, []byte("rest"), true)
+
+	tests := []struct {
+		name    string
+		payload []byte
+		err     string
+	}{
+		{name: "SingleFrame", payload: append([]byte{0}, last...)},
+		{name: "MultipleFrames", payload: append(append([]byte{0}, frame...), last...)},
+		{name: "Empty", payload: nil, err: "empty payload"},
+		{name: "NoFrames", payload: []byte{0}, err: "no frames"},
+		{name: "UnknownVersion", payload: append([]byte{1}, last...), err: "unknown derivation version"},
+		{name: "TruncatedHeader", payload: append([]byte{0}, last[:10]...), err: "frame 0: truncated header"},
+		{name: "TruncatedData", payload: append(append([]byte{0}, frame...), last[:len(last)-2]...),
+			err: "frame 1: truncated data"},
+		{name: "TrailingGarbage", payload: append(append([]byte{0}, last...), 0xff), err: "frame 1: truncated header"},
+		{name: "InvalidIsLast", payload: append(append([]byte{0}, last[:len(last)-1]...), 2), err: "invalid is_last"},
+		{name: "Text", payload: []byte("hello world"), err: "unknown derivation version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOPFrames(tt.payload)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestSchemaHook(t *testing.T) {
+	chain, err := Load(Config{Schema: OPFramesSchema})
+	require.NoError(t, err)
+
+	_, err = chain.Run(context.Background(), Call{Stage: PrePut, Payload: []byte("garbage")})
+	require.ErrorIs(t, err, ErrInvalidPayload)
+	require.ErrorContains(t, err, "op-frames schema")
+
+	valid := append([]byte{0}, opFrame(0, []byte("data"), true)...)
+	_, err = chain.Run(context.Background(), Call{Stage: PrePut, Payload: valid})
+	require.NoError(t, err)
+
+	// only PUT payloads are validated
+	_, err = chain.Run(context.Background(), Call{Stage: PostGet, Payload: []byte("garbage")})
+	require.NoError(t, err)
+
+	_, err = Load(Config{Schema: "no-such-schema"})
+	require.ErrorContains(t, err, "unknown payload schema")
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"net"
 	"strconv"
+	"time"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 
@@ -30,6 +31,33 @@ type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
 	RecordRPCServerRequest(method string) func(status string, commitmentMode string, version string)
+	RecordQuotaUtilization(backend string, usedBytes, maxBytes int64)
+	RecordReconciliation(backend string, missing, orphaned int)
+	RecordDispersalUsage(tenant string, payloadBytes, encodedSymbols int)
+	RecordBackendPing(backend string, up bool)
+	RecordCoalescedRead(commitmentMode string)
+	RecordFallbackRead(backend string, verified bool)
+	RecordReadSLOExceeded(servedBy string)
+	RecordHedgedRead(servedBy string, hedged bool)
+	RecordMirroredRequest(method string, result string)
+	RecordClassifiedRequest(class string, result string, queued time.Duration)
+	RecordWritePathDegraded(mode string, degraded bool)
+	RecordCircuitBreakerState(backend string, state float64)
+	RecordCircuitBreakerFlap(backend string)
+	RecordCircuitBreakerFlapping(backend string, flapping bool)
+	RecordDeadLetters(backend string, outstanding int)
+	RecordDeadLetterRetry(backend string, success bool)
+	RecordTierBlobs(backend string, archived bool, blobs int)
+	RecordTierMove(from, to string)
+	RecordPanic(method string)
+	RecordStartupPhase(phase string, duration time.Duration, heapGrowthBytes int64)
+	RecordStartupDone(duration time.Duration)
+	RecordBlobSize(method string, size int)
+	RecordBackendOperation(backend string, operation string, success bool, duration time.Duration)
+	RecordDispersalError(reason string)
+	RecordDisperserEndpointRequest(endpoint string, success bool)
+	RecordDisperserEndpointHealth(endpoint string, healthy bool)
+	RecordVerificationFailure(backend string)
 
 	Document() []metrics.DocumentedMetric
 }
@@ -42,6 +70,54 @@ type Metrics struct {
 	HTTPServerRequestsTotal          *prometheus.CounterVec
 	HTTPServerBadRequestHeader       *prometheus.CounterVec
 	HTTPServerRequestDurationSeconds *prometheus.HistogramVec
+	HTTPServerPanics                 *prometheus.CounterVec
+	HTTPServerBlobSizeBytes          *prometheus.HistogramVec
+
+	BackendOperations               *prometheus.CounterVec
+	BackendOperationDurationSeconds *prometheus.HistogramVec
+	DispersalErrors                 *prometheus.CounterVec
+	DisperserEndpointRequests       *prometheus.CounterVec
+	DisperserEndpointHealthy        *prometheus.GaugeVec
+	VerificationFailures            *prometheus.CounterVec
+
+	QuotaUsedBytes *prometheus.GaugeVec
+	QuotaMaxBytes  *prometheus.GaugeVec
+
+	ReconcileMissing  *prometheus.GaugeVec
+	ReconcileOrphaned *prometheus.GaugeVec
+
+	UsageDispersals     *prometheus.CounterVec
+	UsagePayloadBytes   *prometheus.CounterVec
+	UsageEncodedSymbols *prometheus.CounterVec
+
+	BackendUp *prometheus.GaugeVec
+
+	CoalescedReads *prometheus.CounterVec
+	FallbackReads  *prometheus.CounterVec
+
+	ReadSLOExceeded *prometheus.CounterVec
+	HedgedReads     *prometheus.CounterVec
+
+	MirroredRequests *prometheus.CounterVec
+
+	ClassifiedRequests    *prometheus.CounterVec
+	ClassQueueWaitSeconds *prometheus.HistogramVec
+
+	WritePathDegraded *prometheus.GaugeVec
+
+	CircuitBreakerState    *prometheus.GaugeVec
+	CircuitBreakerFlaps    *prometheus.CounterVec
+	CircuitBreakerFlapping *prometheus.GaugeVec
+
+	DeadLetters       *prometheus.GaugeVec
+	DeadLetterRetries *prometheus.CounterVec
+
+	TierBlobs *prometheus.GaugeVec
+	TierMoves *prometheus.CounterVec
+
+	StartupPhaseDuration   *prometheus.GaugeVec
+	StartupPhaseHeapGrowth *prometheus.GaugeVec
+	StartupDuration        prometheus.Gauge
 
 	registry *prometheus.Registry
 	factory  metrics.Factory
@@ -101,6 +177,280 @@ func NewMetrics(subsystem string) *Metrics {
 		}, []string{
 			"method", // no status on histograms because those are very expensive
 		}),
+		HTTPServerPanics: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "panics_total",
+			Help:      "Total panics of request handlers recovered by the HTTP server",
+		}, []string{
+			"method",
+		}),
+		HTTPServerBlobSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "blob_size_bytes",
+			// 1KiB to 32MiB
+			Buckets: prometheus.ExponentialBuckets(1024, 2, 16),
+			Help:    "Histogram of the sizes of the payloads written by PUT requests and served by GET requests",
+		}, []string{
+			"method",
+		}),
+		BackendOperations: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "backend_operations_total",
+			Help:      "Total reads and writes of a backend, by whether they succeeded",
+		}, []string{
+			"backend", "operation", "result",
+		}),
+		BackendOperationDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "backend_operation_duration_seconds",
+			Buckets:   prometheus.ExponentialBucketsRange(0.001, 1200, 20),
+			Help:      "Histogram of the durations of the reads and writes of a backend",
+		}, []string{
+			"backend", "operation",
+		}),
+		DispersalErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "eigenda",
+			Name:      "dispersal_errors_total",
+			Help:      "Total failed dispersals, by reason, e.g. the gRPC status code returned by the disperser",
+		}, []string{
+			"reason",
+		}),
+		DisperserEndpointRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "eigenda",
+			Name:      "disperser_endpoint_requests_total",
+			Help:      "Total requests sent to each of several disperser endpoints, by result",
+		}, []string{
+			"endpoint", "result",
+		}),
+		DisperserEndpointHealthy: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "eigenda",
+			Name:      "disperser_endpoint_healthy",
+			Help:      "1 if the last request to a disperser endpoint didn't fail with an error of the endpoint, 0 otherwise",
+		}, []string{
+			"endpoint",
+		}),
+		VerificationFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "verification_failures_total",
+			Help:      "Total blobs read from a backend that failed verification against their cert or key",
+		}, []string{
+			"backend",
+		}),
+		QuotaUsedBytes: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "quota_used_bytes",
+			Help:      "Bytes stored in a secondary backend by the proxy, as tracked by the commitment index",
+		}, []string{
+			"backend",
+		}),
+		QuotaMaxBytes: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "quota_max_bytes",
+			Help:      "Configured byte quota of a secondary backend",
+		}, []string{
+			"backend",
+		}),
+		ReconcileMissing: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "reconcile_missing_entries",
+			Help:      "Sampled commitment index entries missing from a secondary backend in the last reconciliation",
+		}, []string{
+			"backend",
+		}),
+		ReconcileOrphaned: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "reconcile_orphaned_objects",
+			Help:      "Listed objects of a secondary backend unknown to the commitment index in the last reconciliation",
+		}, []string{
+			"backend",
+		}),
+		UsageDispersals: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usage",
+			Name:      "dispersals_total",
+			Help:      "Total successful dispersals of a tenant",
+		}, []string{
+			"tenant",
+		}),
+		UsagePayloadBytes: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usage",
+			Name:      "payload_bytes_total",
+			Help:      "Total payload bytes dispersed by a tenant, before encoding",
+		}, []string{
+			"tenant",
+		}),
+		UsageEncodedSymbols: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "usage",
+			Name:      "encoded_symbols_total",
+			Help:      "Total 32 byte symbols (field elements) dispersed by a tenant after encoding, including padding",
+		}, []string{
+			"tenant",
+		}),
+		BackendUp: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "backend_up",
+			Help:      "1 if the last health ping of a backend succeeded, 0 otherwise",
+		}, []string{
+			"backend",
+		}),
+		CoalescedReads: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "coalesced_reads_total",
+			Help:      "Total reads served by the backend fetch of a concurrent read of the same commitment",
+		}, []string{
+			"commitment_mode",
+		}),
+		FallbackReads: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "fallback_reads_total",
+			Help:      "Total blobs read from a fallback target after EigenDA failed, by whether they passed verification against the cert",
+		}, []string{
+			"backend", "result",
+		}),
+		ReadSLOExceeded: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "read_slo_exceeded_total",
+			Help:      "Total EigenDA reads exceeding the read latency SLO, which started an early fallback read, by the backend that served the blob",
+		}, []string{
+			"served_by",
+		}),
+		HedgedReads: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "hedged_reads_total",
+			Help:      "Total reads racing the cache targets against EigenDA, by the backend that served the blob and whether the EigenDA read was started",
+		}, []string{
+			"served_by", "hedged",
+		}),
+		MirroredRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "mirror",
+			Name:      "requests_total",
+			Help:      "Total requests mirrored to the shadow proxy, by whether its response matched the proxy's",
+		}, []string{
+			"method", "result",
+		}),
+		ClassifiedRequests: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "classified_requests_total",
+			Help:      "Total reads by request class, by whether they got a slot of the class' pool or were rejected",
+		}, []string{
+			"class", "result",
+		}),
+		ClassQueueWaitSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: httpServerSubsystem,
+			Name:      "class_queue_wait_seconds",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 8),
+			Help:      "Histogram of the time reads waited for a slot of the pool of their request class",
+		}, []string{
+			"class",
+		}),
+		WritePathDegraded: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "write_path_degraded",
+			Help:      "1 if the dispersal error rate exceeded its threshold and writes are served in the degraded mode, 0 otherwise",
+		}, []string{
+			"mode",
+		}),
+		CircuitBreakerState: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "circuit_breaker_state",
+			Help:      "State of the circuit breaker of a cache or fallback target: 0 closed, 1 half-open, 2 open",
+		}, []string{
+			"backend",
+		}),
+		CircuitBreakerFlaps: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "circuit_breaker_flaps_total",
+			Help:      "Number of times the circuit breaker of a cache or fallback target reopened shortly after closing",
+		}, []string{
+			"backend",
+		}),
+		CircuitBreakerFlapping: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "circuit_breaker_flapping",
+			Help:      "1 if a cache or fallback target is flapping and its circuit breaker stays open for longer, 0 otherwise",
+		}, []string{
+			"backend",
+		}),
+		DeadLetters: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "dead_letters",
+			Help:      "Outstanding writes to secondary backends in the dead-letter queue, by backend",
+		}, []string{
+			"backend",
+		}),
+		DeadLetterRetries: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "dead_letter_retries_total",
+			Help:      "Total background re-drives of dead-lettered writes, by backend and whether they succeeded",
+		}, []string{
+			"backend", "result",
+		}),
+		TierBlobs: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "tier_blobs",
+			Help:      "Indexed blobs held by each storage tier, by backend and whether they are archived",
+		}, []string{
+			"backend", "archived",
+		}),
+		TierMoves: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "store",
+			Name:      "tier_moves_total",
+			Help:      "Total blobs moved between storage tiers by the tiering policy, by source and destination tier",
+		}, []string{
+			"from", "to",
+		}),
+		StartupPhaseDuration: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "startup",
+			Name:      "phase_duration_seconds",
+			Help:      "Duration of a phase of the proxy's startup, e.g. loading the KZG SRS",
+		}, []string{
+			"phase",
+		}),
+		StartupPhaseHeapGrowth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "startup",
+			Name:      "phase_heap_growth_bytes",
+			Help:      "Growth of the heap during a phase of the proxy's startup",
+		}, []string{
+			"phase",
+		}),
+		StartupDuration: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "startup",
+			Name:      "duration_seconds",
+			Help:      "Duration of the proxy's startup until it served requests",
+		}),
 		registry: registry,
 		factory:  factory,
 	}
@@ -131,6 +481,188 @@ func (m *Metrics) RecordRPCServerRequest(method string) func(status string, mode
 	}
 }
 
+// RecordQuotaUtilization records the bytes used and the quota of a secondary backend.
+func (m *Metrics) RecordQuotaUtilization(backend string, usedBytes, maxBytes int64) {
+	m.QuotaUsedBytes.WithLabelValues(backend).Set(float64(usedBytes))
+	m.QuotaMaxBytes.WithLabelValues(backend).Set(float64(maxBytes))
+}
+
+// RecordReconciliation records the inconsistencies found by the last reconciliation of a secondary backend.
+func (m *Metrics) RecordReconciliation(backend string, missing, orphaned int) {
+	m.ReconcileMissing.WithLabelValues(backend).Set(float64(missing))
+	m.ReconcileOrphaned.WithLabelValues(backend).Set(float64(orphaned))
+}
+
+// RecordDispersalUsage records a successful dispersal of a tenant, with its payload size and the number of
+// symbols the payload was encoded into.
+func (m *Metrics) RecordDispersalUsage(tenant string, payloadBytes, encodedSymbols int) {
+	m.UsageDispersals.WithLabelValues(tenant).Inc()
+	m.UsagePayloadBytes.WithLabelValues(tenant).Add(float64(payloadBytes))
+	m.UsageEncodedSymbols.WithLabelValues(tenant).Add(float64(encodedSymbols))
+}
+
+// RecordBackendPing records the outcome of the last health ping of a backend.
+func (m *Metrics) RecordBackendPing(backend string, up bool) {
+	v := 0.0
+	if up {
+		v = 1
+	}
+	m.BackendUp.WithLabelValues(backend).Set(v)
+}
+
+// RecordCoalescedRead records a read that was served by the backend fetch of a concurrent read.
+func (m *Metrics) RecordCoalescedRead(commitmentMode string) {
+	m.CoalescedReads.WithLabelValues(commitmentMode).Inc()
+}
+
+// RecordFallbackRead records a blob read from a fallback target, and whether it passed verification against
+// the cert and was served.
+func (m *Metrics) RecordFallbackRead(backend string, verified bool) {
+	result := "served"
+	if !verified {
+		result = "verification_failed"
+	}
+	m.FallbackReads.WithLabelValues(backend, result).Inc()
+}
+
+// RecordReadSLOExceeded records an EigenDA read exceeding the read latency SLO, and the backend that served the
+// blob of the raced reads, or "none" if no read succeeded.
+func (m *Metrics) RecordReadSLOExceeded(servedBy string) {
+	m.ReadSLOExceeded.WithLabelValues(servedBy).Inc()
+}
+
+// RecordHedgedRead records a read racing the cache targets against EigenDA, the backend that served the blob or
+// "none" if no read succeeded, and whether the EigenDA read was started.
+func (m *Metrics) RecordHedgedRead(servedBy string, hedged bool) {
+	m.HedgedReads.WithLabelValues(servedBy, strconv.FormatBool(hedged)).Inc()
+}
+
+// RecordMirroredRequest records a request mirrored to the shadow proxy, and how its response compared to the
+// proxy's.
+func (m *Metrics) RecordMirroredRequest(method string, result string) {
+	m.MirroredRequests.WithLabelValues(method, result).Inc()
+}
+
+// RecordClassifiedRequest records a read of a request class, whether it was served or rejected, and how long it
+// waited for a slot of the class' pool.
+func (m *Metrics) RecordClassifiedRequest(class string, result string, queued time.Duration) {
+	m.ClassifiedRequests.WithLabelValues(class, result).Inc()
+	m.ClassQueueWaitSeconds.WithLabelValues(class).Observe(queued.Seconds())
+}
+
+// RecordWritePathDegraded records whether the write path is degraded, and the degraded mode writes are
+// then served in.
+func (m *Metrics) RecordWritePathDegraded(mode string, degraded bool) {
+	v := 0.0
+	if degraded {
+		v = 1
+	}
+	m.WritePathDegraded.WithLabelValues(mode).Set(v)
+}
+
+// RecordCircuitBreakerState records the state of the circuit breaker of a cache or fallback target: 0 closed,
+// 1 half-open, 2 open.
+func (m *Metrics) RecordCircuitBreakerState(backend string, state float64) {
+	m.CircuitBreakerState.WithLabelValues(backend).Set(state)
+}
+
+// RecordCircuitBreakerFlap records the circuit breaker of a cache or fallback target reopening shortly after closing.
+func (m *Metrics) RecordCircuitBreakerFlap(backend string) {
+	m.CircuitBreakerFlaps.WithLabelValues(backend).Inc()
+}
+
+// RecordCircuitBreakerFlapping records whether a cache or fallback target is flapping.
+func (m *Metrics) RecordCircuitBreakerFlapping(backend string, flapping bool) {
+	v := 0.0
+	if flapping {
+		v = 1
+	}
+	m.CircuitBreakerFlapping.WithLabelValues(backend).Set(v)
+}
+
+// RecordDeadLetters records the number of outstanding dead-lettered writes to a backend.
+func (m *Metrics) RecordDeadLetters(backend string, outstanding int) {
+	m.DeadLetters.WithLabelValues(backend).Set(float64(outstanding))
+}
+
+// RecordDeadLetterRetry records a background re-drive of a dead-lettered write to a backend.
+func (m *Metrics) RecordDeadLetterRetry(backend string, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	m.DeadLetterRetries.WithLabelValues(backend, result).Inc()
+}
+
+// RecordTierBlobs records the number of indexed blobs held by a storage tier.
+func (m *Metrics) RecordTierBlobs(backend string, archived bool, blobs int) {
+	m.TierBlobs.WithLabelValues(backend, strconv.FormatBool(archived)).Set(float64(blobs))
+}
+
+// RecordTierMove records a blob moved between storage tiers, where archiving a blob moves it to the "archive" tier.
+func (m *Metrics) RecordTierMove(from, to string) {
+	m.TierMoves.WithLabelValues(from, to).Inc()
+}
+
+// RecordPanic records a recovered panic of a request handler.
+func (m *Metrics) RecordPanic(method string) {
+	m.HTTPServerPanics.WithLabelValues(method).Inc()
+}
+
+// RecordStartupPhase records the duration of a startup phase and the growth of the heap during it.
+func (m *Metrics) RecordStartupPhase(phase string, duration time.Duration, heapGrowthBytes int64) {
+	m.StartupPhaseDuration.WithLabelValues(phase).Set(duration.Seconds())
+	m.StartupPhaseHeapGrowth.WithLabelValues(phase).Set(float64(heapGrowthBytes))
+}
+
+// RecordStartupDone records the duration of the startup once the proxy serves requests.
+func (m *Metrics) RecordStartupDone(duration time.Duration) {
+	m.StartupDuration.Set(duration.Seconds())
+}
+
+// RecordBlobSize records the size of a payload written by a PUT request or served by a GET request.
+func (m *Metrics) RecordBlobSize(method string, size int) {
+	m.HTTPServerBlobSizeBytes.WithLabelValues(method).Observe(float64(size))
+}
+
+// RecordBackendOperation records a read or write of a backend, whether it succeeded and how long it took.
+func (m *Metrics) RecordBackendOperation(backend string, operation string, success bool, duration time.Duration) {
+	result := "success"
+	if !success {
+		result = "error"
+	}
+	m.BackendOperations.WithLabelValues(backend, operation, result).Inc()
+	m.BackendOperationDurationSeconds.WithLabelValues(backend, operation).Observe(duration.Seconds())
+}
+
+// RecordDispersalError records a failed dispersal and the reason it failed for.
+func (m *Metrics) RecordDispersalError(reason string) {
+	m.DispersalErrors.WithLabelValues(reason).Inc()
+}
+
+// RecordDisperserEndpointRequest records a request sent to one of several disperser endpoints.
+func (m *Metrics) RecordDisperserEndpointRequest(endpoint string, success bool) {
+	result := "success"
+	if !success {
+		result = "error"
+	}
+	m.DisperserEndpointRequests.WithLabelValues(endpoint, result).Inc()
+}
+
+// RecordDisperserEndpointHealth records whether one of several disperser endpoints is healthy.
+func (m *Metrics) RecordDisperserEndpointHealth(endpoint string, healthy bool) {
+	v := 0.0
+	if healthy {
+		v = 1
+	}
+	m.DisperserEndpointHealthy.WithLabelValues(endpoint).Set(v)
+}
+
+// RecordVerificationFailure records a blob read from a backend that failed verification.
+func (m *Metrics) RecordVerificationFailure(backend string) {
+	m.VerificationFailures.WithLabelValues(backend).Inc()
+}
+
 // StartServer starts the metrics server on the given hostname and port.
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
@@ -162,3 +694,84 @@ func (n *noopMetricer) RecordUp() {
 func (n *noopMetricer) RecordRPCServerRequest(string) func(status, mode, ver string) {
 	return func(string, string, string) {}
 }
+
+func (n *noopMetricer) RecordQuotaUtilization(string, int64, int64) {
+}
+
+func (n *noopMetricer) RecordReconciliation(string, int, int) {
+}
+
+func (n *noopMetricer) RecordDispersalUsage(string, int, int) {
+}
+
+func (n *noopMetricer) RecordBackendPing(string, bool) {
+}
+
+func (n *noopMetricer) RecordCoalescedRead(string) {
+}
+
+func (n *noopMetricer) RecordFallbackRead(string, bool) {
+}
+
+func (n *noopMetricer) RecordReadSLOExceeded(string) {
+}
+
+func (n *noopMetricer) RecordHedgedRead(string, bool) {
+}
+
+func (n *noopMetricer) RecordMirroredRequest(string, string) {
+}
+
+func (n *noopMetricer) RecordClassifiedRequest(string, string, time.Duration) {
+}
+
+func (n *noopMetricer) RecordWritePathDegraded(string, bool) {
+}
+
+func (n *noopMetricer) RecordCircuitBreakerState(string, float64) {
+}
+
+func (n *noopMetricer) RecordCircuitBreakerFlap(string) {
+}
+
+func (n *noopMetricer) RecordCircuitBreakerFlapping(string, bool) {
+}
+
+func (n *noopMetricer) RecordDeadLetters(string, int) {
+}
+
+func (n *noopMetricer) RecordDeadLetterRetry(string, bool) {
+}
+
+func (n *noopMetricer) RecordTierBlobs(string, bool, int) {
+}
+
+func (n *noopMetricer) RecordTierMove(string, string) {
+}
+
+func (n *noopMetricer) RecordPanic(string) {
+}
+
+func (n *noopMetricer) RecordStartupPhase(string, time.Duration, int64) {
+}
+
+func (n *noopMetricer) RecordStartupDone(time.Duration) {
+}
+
+func (n *noopMetricer) RecordBlobSize(string, int) {
+}
+
+func (n *noopMetricer) RecordBackendOperation(string, string, bool, time.Duration) {
+}
+
+func (n *noopMetricer) RecordDispersalError(string) {
+}
+
+func (n *noopMetricer) RecordDisperserEndpointRequest(string, bool) {
+}
+
+func (n *noopMetricer) RecordDisperserEndpointHealth(string, bool) {
+}
+
+func (n *noopMetricer) RecordVerificationFailure(string) {
+}
@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda (interfaces: EigenDABackend)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	disperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	disperser0 "github.com/Layr-Labs/eigenda/disperser"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockEigenDABackend is a mock of EigenDABackend interface.
+type MockEigenDABackend struct {
+	ctrl     *gomock.Controller
+	recorder *MockEigenDABackendMockRecorder
+}
+
+// MockEigenDABackendMockRecorder is the mock recorder for MockEigenDABackend.
+type MockEigenDABackendMockRecorder struct {
+	mock *MockEigenDABackend
+}
+
+// NewMockEigenDABackend creates a new mock instance.
+func NewMockEigenDABackend(ctrl *gomock.Controller) *MockEigenDABackend {
+	mock := &MockEigenDABackend{ctrl: ctrl}
+	mock.recorder = &MockEigenDABackendMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEigenDABackend) EXPECT() *MockEigenDABackendMockRecorder {
+	return m.recorder
+}
+
+// Disperse mocks base method.
+func (m *MockEigenDABackend) Disperse(arg0 context.Context, arg1, arg2 []byte) (*disperser0.BlobStatus, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disperse", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*disperser0.BlobStatus)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Disperse indicates an expected call of Disperse.
+func (mr *MockEigenDABackendMockRecorder) Disperse(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disperse", reflect.TypeOf((*MockEigenDABackend)(nil).Disperse), arg0, arg1, arg2)
+}
+
+// GetStatus mocks base method.
+func (m *MockEigenDABackend) GetStatus(arg0 context.Context, arg1 []byte) (*disperser.BlobStatusReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatus", arg0, arg1)
+	ret0, _ := ret[0].(*disperser.BlobStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatus indicates an expected call of GetStatus.
+func (mr *MockEigenDABackendMockRecorder) GetStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockEigenDABackend)(nil).GetStatus), arg0, arg1)
+}
+
+// Retrieve mocks base method.
+func (m *MockEigenDABackend) Retrieve(arg0 context.Context, arg1 []byte, arg2 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retrieve", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retrieve indicates an expected call of Retrieve.
+func (mr *MockEigenDABackendMockRecorder) Retrieve(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retrieve", reflect.TypeOf((*MockEigenDABackend)(nil).Retrieve), arg0, arg1, arg2)
+}
@@ -10,6 +10,8 @@ import (
 
 	commitments "github.com/Layr-Labs/eigenda-proxy/commitments"
 	store "github.com/Layr-Labs/eigenda-proxy/store"
+	deadletter "github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	index "github.com/Layr-Labs/eigenda-proxy/store/index"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,6 +38,34 @@ func (m *MockIRouter) EXPECT() *MockIRouterMockRecorder {
 	return m.recorder
 }
 
+// Backfill mocks base method.
+func (m *MockIRouter) Backfill(arg0 context.Context, arg1 commitments.CommitmentMode, arg2 []byte, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockIRouterMockRecorder) Backfill(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockIRouter)(nil).Backfill), arg0, arg1, arg2, arg3)
+}
+
+// BackendHealth mocks base method.
+func (m *MockIRouter) BackendHealth() []store.TargetHealth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackendHealth")
+	ret0, _ := ret[0].([]store.TargetHealth)
+	return ret0
+}
+
+// BackendHealth indicates an expected call of BackendHealth.
+func (mr *MockIRouterMockRecorder) BackendHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackendHealth", reflect.TypeOf((*MockIRouter)(nil).BackendHealth))
+}
+
 // Caches mocks base method.
 func (m *MockIRouter) Caches() []store.PrecomputedKeyStore {
 	m.ctrl.T.Helper()
@@ -50,6 +80,20 @@ func (mr *MockIRouterMockRecorder) Caches() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Caches", reflect.TypeOf((*MockIRouter)(nil).Caches))
 }
 
+// DeadLetters mocks base method.
+func (m *MockIRouter) DeadLetters() *deadletter.Queue {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetters")
+	ret0, _ := ret[0].(*deadletter.Queue)
+	return ret0
+}
+
+// DeadLetters indicates an expected call of DeadLetters.
+func (mr *MockIRouterMockRecorder) DeadLetters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetters", reflect.TypeOf((*MockIRouter)(nil).DeadLetters))
+}
+
 // Fallbacks mocks base method.
 func (m *MockIRouter) Fallbacks() []store.PrecomputedKeyStore {
 	m.ctrl.T.Helper()
@@ -93,18 +137,46 @@ func (mr *MockIRouterMockRecorder) GetEigenDAStore() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEigenDAStore", reflect.TypeOf((*MockIRouter)(nil).GetEigenDAStore))
 }
 
-// GetS3Store mocks base method.
-func (m *MockIRouter) GetS3Store() store.PrecomputedKeyStore {
+// GetKeccakStore mocks base method.
+func (m *MockIRouter) GetKeccakStore() store.PrecomputedKeyStore {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetS3Store")
+	ret := m.ctrl.Call(m, "GetKeccakStore")
 	ret0, _ := ret[0].(store.PrecomputedKeyStore)
 	return ret0
 }
 
-// GetS3Store indicates an expected call of GetS3Store.
-func (mr *MockIRouterMockRecorder) GetS3Store() *gomock.Call {
+// GetKeccakStore indicates an expected call of GetKeccakStore.
+func (mr *MockIRouterMockRecorder) GetKeccakStore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeccakStore", reflect.TypeOf((*MockIRouter)(nil).GetKeccakStore))
+}
+
+// Import mocks base method.
+func (m *MockIRouter) Import(arg0 context.Context, arg1 commitments.CommitmentMode, arg2 []byte, arg3 int, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockIRouterMockRecorder) Import(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockIRouter)(nil).Import), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Index mocks base method.
+func (m *MockIRouter) Index() *index.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Index")
+	ret0, _ := ret[0].(*index.Index)
+	return ret0
+}
+
+// Index indicates an expected call of Index.
+func (mr *MockIRouterMockRecorder) Index() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetS3Store", reflect.TypeOf((*MockIRouter)(nil).GetS3Store))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Index", reflect.TypeOf((*MockIRouter)(nil).Index))
 }
 
 // Put mocks base method.
@@ -121,3 +193,73 @@ func (mr *MockIRouterMockRecorder) Put(arg0, arg1, arg2, arg3 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIRouter)(nil).Put), arg0, arg1, arg2, arg3)
 }
+
+// Reconcile mocks base method.
+func (m *MockIRouter) Reconcile(arg0 context.Context) []store.ReconcileReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0)
+	ret0, _ := ret[0].([]store.ReconcileReport)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockIRouterMockRecorder) Reconcile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockIRouter)(nil).Reconcile), arg0)
+}
+
+// ReconcileReports mocks base method.
+func (m *MockIRouter) ReconcileReports() []store.ReconcileReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileReports")
+	ret0, _ := ret[0].([]store.ReconcileReport)
+	return ret0
+}
+
+// ReconcileReports indicates an expected call of ReconcileReports.
+func (mr *MockIRouterMockRecorder) ReconcileReports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileReports", reflect.TypeOf((*MockIRouter)(nil).ReconcileReports))
+}
+
+// Redrive mocks base method.
+func (m *MockIRouter) Redrive(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redrive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Redrive indicates an expected call of Redrive.
+func (mr *MockIRouterMockRecorder) Redrive(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redrive", reflect.TypeOf((*MockIRouter)(nil).Redrive), arg0, arg1)
+}
+
+// Tier mocks base method.
+func (m *MockIRouter) Tier(arg0 context.Context) store.TieringReport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tier", arg0)
+	ret0, _ := ret[0].(store.TieringReport)
+	return ret0
+}
+
+// Tier indicates an expected call of Tier.
+func (mr *MockIRouterMockRecorder) Tier(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tier", reflect.TypeOf((*MockIRouter)(nil).Tier), arg0)
+}
+
+// WriteSummaries mocks base method.
+func (m *MockIRouter) WriteSummaries() []store.TargetWriteSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSummaries")
+	ret0, _ := ret[0].([]store.TargetWriteSummary)
+	return ret0
+}
+
+// WriteSummaries indicates an expected call of WriteSummaries.
+func (mr *MockIRouterMockRecorder) WriteSummaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSummaries", reflect.TypeOf((*MockIRouter)(nil).WriteSummaries))
+}
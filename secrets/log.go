@@ -0,0 +1,85 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Logger ... returns l, whose records are scrubbed of registered secrets before they are written
+func Logger(l log.Logger) log.Logger {
+	return log.NewLogger(Handler(l.Handler()))
+}
+
+// Handler ... wraps h so that the message and the string, error and fmt.Stringer attribute values of records are
+// scrubbed of registered secrets
+func Handler(h slog.Handler) slog.Handler {
+	if sh, ok := h.(*scrubHandler); ok {
+		return sh
+	}
+	return &scrubHandler{next: h}
+}
+
+type scrubHandler struct {
+	next slog.Handler
+}
+
+func (h *scrubHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *scrubHandler) Handle(ctx context.Context, r slog.Record) error {
+	scrubbed := slog.NewRecord(r.Time, r.Level, Scrub(r.Message), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		scrubbed.AddAttrs(scrubAttr(a))
+		return true
+	})
+	return h.next.Handle(ctx, scrubbed)
+}
+
+func (h *scrubHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	scrubbed := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		scrubbed[i] = scrubAttr(a)
+	}
+	return &scrubHandler{next: h.next.WithAttrs(scrubbed)}
+}
+
+func (h *scrubHandler) WithGroup(name string) slog.Handler {
+	return &scrubHandler{next: h.next.WithGroup(name)}
+}
+
+// scrubAttr ... returns a with its value scrubbed of registered secrets
+func scrubAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return scrubText(a.Key, v, v.String())
+	case slog.KindGroup:
+		attrs := v.Group()
+		scrubbed := make([]slog.Attr, len(attrs))
+		for i, ga := range attrs {
+			scrubbed[i] = scrubAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(scrubbed...)}
+	case slog.KindAny:
+		switch x := v.Any().(type) {
+		case error:
+			return scrubText(a.Key, v, x.Error())
+		case fmt.Stringer:
+			return scrubText(a.Key, v, x.String())
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+// scrubText ... returns the attribute of value v whose text is s, replaced by the scrubbed text if s contains a
+// registered secret. Values are kept otherwise, so that handlers still format them by type.
+func scrubText(key string, v slog.Value, s string) slog.Attr {
+	if scrubbed := Scrub(s); scrubbed != s {
+		return slog.String(key, scrubbed)
+	}
+	return slog.Attr{Key: key, Value: v}
+}
@@ -0,0 +1,127 @@
+// Package secrets keeps the proxy's credentials (signer key, storage secrets, API keys) out of its logs, error
+// messages and served config. Secrets are registered once they are read, from flags or mounted files, and are then
+// replaced wherever they would be printed: by the log handler wrapping the proxy's logger, and by Scrub for text
+// leaving the proxy otherwise, e.g. error responses.
+package secrets
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted ... replaces secrets
+const Redacted = "[redacted]"
+
+// minLength ... secrets shorter than this aren't scrubbed from text, since they'd match unrelated substrings.
+// They are still redacted from the served config.
+const minLength = 4
+
+// Equal ... compares a presented credential to the expected one in constant time. Both are hashed first, so
+// that the comparison doesn't leak the length of the expected credential either.
+func Equal(expected, presented string) bool {
+	e, p := sha256.Sum256([]byte(expected)), sha256.Sum256([]byte(presented))
+	return subtle.ConstantTimeCompare(e[:], p[:]) == 1
+}
+
+// EqualAny ... compares a presented credential to each of the expected ones, see Equal. Every one is compared, so
+// that the response time doesn't tell which one matched.
+func EqualAny(expected []string, presented string) bool {
+	match := false
+	for _, e := range expected {
+		if Equal(e, presented) {
+			match = true
+		}
+	}
+	return match
+}
+
+// Redact ... returns Redacted for a set secret, and an empty string for an unset one so that it still shows as
+// unset
+func Redact(s string) string {
+	if s == "" {
+		return ""
+	}
+	return Redacted
+}
+
+// RedactURL ... returns u with the password of its user info redacted. URLs which fail to parse are redacted
+// entirely, since they may embed credentials anyway.
+func RedactURL(u string) string {
+	if u == "" {
+		return ""
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return Redacted
+	}
+	if _, ok := parsed.User.Password(); ok {
+		parsed.User = url.UserPassword(parsed.User.Username(), Redacted)
+	}
+	return parsed.String()
+}
+
+// registry ... secrets scrubbed from text
+var registry struct {
+	mu       sync.RWMutex
+	secrets  map[string]struct{}
+	replacer *strings.Replacer
+}
+
+// Register ... adds secrets to be scrubbed from logs and error messages from now on. Rotated secrets are
+// registered on top of the previous ones, which are still scrubbed. Empty and short secrets are ignored.
+func Register(secrets ...string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if registry.secrets == nil {
+		registry.secrets = make(map[string]struct{})
+	}
+	added := false
+	for _, s := range secrets {
+		if len(s) < minLength {
+			continue
+		}
+		if _, ok := registry.secrets[s]; !ok {
+			registry.secrets[s] = struct{}{}
+			added = true
+		}
+	}
+	if !added {
+		return
+	}
+
+	// longest first, so that a secret containing another one is replaced as a whole
+	all := make([]string, 0, len(registry.secrets))
+	for s := range registry.secrets {
+		all = append(all, s)
+	}
+	sort.Slice(all, func(i, j int) bool { return len(all[i]) > len(all[j]) })
+	pairs := make([]string, 0, 2*len(all))
+	for _, s := range all {
+		pairs = append(pairs, s, Redacted)
+	}
+	registry.replacer = strings.NewReplacer(pairs...)
+}
+
+// Scrub ... returns s with every registered secret replaced by Redacted
+func Scrub(s string) string {
+	registry.mu.RLock()
+	r := registry.replacer
+	registry.mu.RUnlock()
+
+	if r == nil {
+		return s
+	}
+	return r.Replace(s)
+}
+
+// reset ... forgets every registered secret, for tests
+func reset() {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.secrets, registry.replacer = nil, nil
+}
@@ -0,0 +1,169 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AckHeader ... PUT request header choosing when the request is answered, see AckLevel. The level the request
+// was acknowledged at is echoed in the response header of the same name.
+const AckHeader = "X-EigenDA-Proxy-Ack"
+
+// AckLevel ... point of the write path at which a PUT is answered
+type AckLevel string
+
+const (
+	// AckCache ... the payload is held by the proxy, journaled to disk if a journal dir is configured, and
+	// dispersed in the background. The request is answered with 202 Accepted and a job, as with AsyncHeader.
+	AckCache AckLevel = "cache"
+	// AckDispersed ... the disperser accepted the blob. The request is answered with 202 Accepted and a job whose
+	// commitment is set once the blob is confirmed.
+	AckDispersed AckLevel = "dispersed"
+	// AckConfirmed ... the batch of the blob was confirmed on chain, or finalized with wait-for-finalization. The
+	// request is answered with the commitment, as without AckHeader.
+	AckConfirmed AckLevel = "confirmed"
+)
+
+// ackLevelRanks ... order of the ack levels, from the earliest to the most durable
+var ackLevelRanks = map[AckLevel]int{AckCache: 0, AckDispersed: 1, AckConfirmed: 2}
+
+// ParseAckLevel ... parses an ack level
+func ParseAckLevel(s string) (AckLevel, error) {
+	l := AckLevel(strings.ToLower(s))
+	if _, ok := ackLevelRanks[l]; !ok {
+		return "", fmt.Errorf("unknown ack level %q, must be one of cache, dispersed or confirmed", s)
+	}
+	return l, nil
+}
+
+// readAckLevel ... returns the ack level requested by a PUT via AckHeader, AckCache if it sets AsyncHeader
+// instead, and AckConfirmed otherwise. Requests are acknowledged at min at the earliest.
+func readAckLevel(r *http.Request, min AckLevel) (AckLevel, error) {
+	level := AckConfirmed
+	if v := r.Header.Get(AckHeader); v != "" {
+		l, err := ParseAckLevel(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s header: %w", AckHeader, err)
+		}
+		level = l
+	} else if strings.EqualFold(r.Header.Get(AsyncHeader), "true") {
+		level = AckCache
+	}
+	if min != "" && ackLevelRanks[level] < ackLevelRanks[min] {
+		level = min
+	}
+	return level, nil
+}
+
+// journaledHeaders ... headers of a PUT that are journaled, i.e. those the PUT handler reads. Other headers, e.g.
+// Authorization or API keys, are dropped so that credentials aren't persisted.
+var journaledHeaders = []string{
+	"Content-Type", CommitmentModeHeader, TenantHeader, TTLHeader, RedundancyHeader, CachePolicyHeader,
+	RequestClassHeader, RollupHeightHeader, RequestIDHeader,
+}
+
+// journaledHeader ... the journaledHeaders of h
+func journaledHeader(h http.Header) http.Header {
+	journaled := make(http.Header, len(journaledHeaders))
+	for _, name := range journaledHeaders {
+		if v := h.Values(name); len(v) > 0 {
+			journaled[http.CanonicalHeaderKey(name)] = append([]string(nil), v...)
+		}
+	}
+	return journaled
+}
+
+// journaledPut ... PUT acknowledged at AckCache whose payload hasn't been dispersed yet
+type journaledPut struct {
+	JobID string `json:"job_id"`
+	// URL is the request URI of the PUT, i.e. its path and query
+	URL string `json:"url"`
+	// Header holds the journaledHeaders of the PUT
+	Header    http.Header `json:"header"`
+	Body      []byte      `json:"body"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// putJournal ... journals the PUTs acknowledged at AckCache to a directory, one file per job, until their
+// dispersal completed, so that they are dispersed after a restart
+type putJournal struct {
+	dir string
+}
+
+func (j *putJournal) path(id string) string {
+	return filepath.Join(j.dir, id+".json")
+}
+
+// add ... durably journals a PUT, through a temporary file synced to disk so that a partially written entry is
+// never replayed
+func (j *putJournal) add(p journaledPut) error {
+	if err := os.MkdirAll(j.dir, 0o750); err != nil {
+		return err
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	tmp := j.path(p.JobID) + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path(p.JobID))
+}
+
+// remove ... drops the PUT of a job from the journal
+func (j *putJournal) remove(id string) error {
+	err := os.Remove(j.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// list ... returns the journaled PUTs, oldest first
+func (j *putJournal) list() ([]journaledPut, error) {
+	entries, err := os.ReadDir(j.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read put journal %s: %w", j.dir, err)
+	}
+
+	var puts []journaledPut
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(j.dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read journaled put %s: %w", e.Name(), err)
+		}
+		var p journaledPut
+		if err := json.Unmarshal(b, &p); err != nil {
+			return nil, fmt.Errorf("failed to decode journaled put %s: %w", e.Name(), err)
+		}
+		puts = append(puts, p)
+	}
+	sort.Slice(puts, func(a, b int) bool { return puts[a].CreatedAt.Before(puts[b].CreatedAt) })
+	return puts, nil
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/standby"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const (
+	AdminRoute       = "/admin/"
+	AdminStatusRoute = AdminRoute + "status"
+)
+
+// registerAdminRoutes ... mounts the admin API onto the provided mux
+func (svr *Server) registerAdminRoutes(mux *http.ServeMux) {
+	mux.HandleFunc(AdminStatusRoute, WithLogging(svr.HandleAdminStatus, svr.log))
+	mux.HandleFunc(AdminDeadLettersRoute, WithLogging(svr.HandleDeadLetters, svr.log))
+	mux.HandleFunc(AdminDeadLettersRedriveRoute, WithLogging(svr.HandleDeadLettersRedrive, svr.log))
+	mux.HandleFunc(AdminWritesRoute, WithLogging(svr.HandleWrites, svr.log))
+	mux.HandleFunc(AdminHealthRoute, WithLogging(svr.HandleBackendHealth, svr.log))
+	mux.HandleFunc(AdminDispersalsRoute, WithLogging(svr.HandleDispersals, svr.log))
+	mux.HandleFunc(AdminDispersalsResumeRoute, WithLogging(svr.HandleDispersalsResume, svr.log))
+	mux.HandleFunc(AdminConfigDiffRoute, WithLogging(svr.HandleConfigDiff, svr.log))
+	mux.HandleFunc(standby.SyncRoute, WithLogging(svr.HandleSync, svr.log))
+	mux.HandleFunc(AdminReconcileRoute, WithLogging(svr.HandleReconcile, svr.log))
+	mux.HandleFunc(AdminUsageRoute, WithLogging(svr.HandleUsage, svr.log))
+	mux.HandleFunc(AdminProfilingRoute, WithLogging(svr.HandleProfilingReport, svr.log))
+	mux.HandleFunc(AdminProfilingStartRoute, WithLogging(svr.HandleProfilingStart, svr.log))
+	mux.HandleFunc(AdminProfilingStopRoute, WithLogging(svr.HandleProfilingStop, svr.log))
+	mux.HandleFunc(AdminSnapshotRoute, WithLogging(svr.HandleSnapshot, svr.log))
+	mux.HandleFunc(AdminRestoreRoute, WithLogging(svr.HandleRestore, svr.log))
+	mux.HandleFunc(AdminFeatureGatesRoute, WithLogging(svr.HandleFeatureGates, svr.log))
+	mux.HandleFunc(AdminMaintenanceRoute, WithLogging(svr.HandleMaintenance, svr.log))
+	mux.HandleFunc(AdminRoutingRoute, WithLogging(svr.HandleRoutingPlan, svr.log))
+	mux.HandleFunc(AdminHotKeysRoute, WithLogging(svr.HandleHotKeys, svr.log))
+}
+
+// HandleAdminStatus ... returns a JSON StatusReport describing dependency health, in-flight requests,
+// recent errors, cache hit rates and dispersal throughput.
+func (svr *Server) HandleAdminStatus(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	var backends []BackendStatus
+	var cacheBackends []string
+
+	if eigenDA := svr.router.GetEigenDAStore(); eigenDA != nil {
+		backends = append(backends, backendStatus(eigenDA, "primary"))
+	}
+	if keccak := svr.router.GetKeccakStore(); keccak != nil {
+		backends = append(backends, backendStatus(keccak, "keccak256"))
+	}
+	for _, c := range svr.router.Caches() {
+		backends = append(backends, backendStatus(c, "cache"))
+		cacheBackends = append(cacheBackends, c.BackendType().String())
+	}
+	for _, f := range svr.router.Fallbacks() {
+		backends = append(backends, backendStatus(f, "fallback"))
+	}
+
+	return svr.writeJSON(w, svr.status.report(backends, cacheBackends))
+}
+
+func backendStatus(s store.Store, role string) BackendStatus {
+	bs := BackendStatus{Backend: s.BackendType().String(), Role: role}
+	if stats := s.Stats(); stats != nil {
+		bs.Entries = stats.Entries
+		bs.Reads = stats.Reads
+	}
+	return bs
+}
+
+// writeJSON ... writes v as a JSON response body
+func (svr *Server) writeJSON(w http.ResponseWriter, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		svr.WriteInternalError(w, err)
+		return fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	svr.WriteResponse(w, b)
+	return nil
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{Admin: AdminConfig{Enabled: true}})
+
+	mockRouter.EXPECT().GetEigenDAStore().Return(nil).AnyTimes()
+	mockRouter.EXPECT().GetKeccakStore().Return(nil).AnyTimes()
+	mockRouter.EXPECT().Caches().Return([]store.PrecomputedKeyStore{}).AnyTimes()
+	mockRouter.EXPECT().Fallbacks().Return([]store.PrecomputedKeyStore{}).AnyTimes()
+
+	body := []byte("some data that will successfully be written to EigenDA")
+	mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(testCommitStr), nil)
+	mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("internal error"))
+
+	put := WithStatus(server.HandlePut, server.status)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader(body))
+		_, _ = put(httptest.NewRecorder(), req)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, AdminStatusRoute, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, server.HandleAdminStatus(rec, req))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var rep StatusReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+	require.Equal(t, uint64(1), rep.Requests[http.MethodPost]["success"])
+	require.Equal(t, uint64(1), rep.Requests[http.MethodPost]["error"])
+	require.Equal(t, 0, rep.InFlight[http.MethodPost])
+	require.Equal(t, 1, rep.Throughput.BlobsPerMinute)
+	require.Equal(t, len(body), rep.Throughput.BytesPerMinute)
+	require.Len(t, rep.RecentErrors, 1)
+
+	req = httptest.NewRequest(http.MethodPost, AdminStatusRoute, nil)
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleAdminStatus(rec, req))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdminWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{Admin: AdminConfig{Enabled: true}})
+
+	summaries := []store.TargetWriteSummary{{
+		Backend: "S3",
+		Counts:  map[store.WriteStatus]int{store.WriteSucceeded: 3, store.WriteDeadLettered: 1},
+		Recent: []store.WriteFailure{{
+			Commitment: []byte{0x01},
+			Status:     store.WriteDeadLettered,
+			Attempts:   3,
+			Error:      "connection refused",
+		}},
+	}}
+	mockRouter.EXPECT().WriteSummaries().Return(summaries)
+
+	req := httptest.NewRequest(http.MethodGet, AdminWritesRoute, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, server.HandleWrites(rec, req))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []store.TargetWriteSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, 1, got[0].Counts[store.WriteDeadLettered])
+	require.Equal(t, "connection refused", got[0].Recent[0].Error)
+
+	req = httptest.NewRequest(http.MethodPost, AdminWritesRoute, nil)
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleWrites(rec, req))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdminBackendHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{Admin: AdminConfig{Enabled: true}})
+
+	health := []store.TargetHealth{{
+		Backend:     "Redis",
+		State:       store.BreakerOpen,
+		Flapping:    true,
+		Flaps:       4,
+		RecentFlaps: 3,
+		Transitions: []store.HealthTransition{{From: store.BreakerClosed, To: store.BreakerOpen}},
+	}}
+	mockRouter.EXPECT().BackendHealth().Return(health)
+
+	req := httptest.NewRequest(http.MethodGet, AdminHealthRoute, nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, server.HandleBackendHealth(rec, req))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []store.TargetHealth
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, health[0].Backend, got[0].Backend)
+	require.True(t, got[0].Flapping)
+	require.Equal(t, 3, got[0].RecentFlaps)
+	require.Equal(t, store.BreakerOpen, got[0].Transitions[0].To)
+}
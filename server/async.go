@@ -0,0 +1,466 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// StatusRoute ... serves the status of the asynchronous PUT whose job ID follows the route
+	StatusRoute = "/status/"
+
+	// AsyncHeader ... PUT request header asking for the blob to be dispersed in the background. The request is
+	// answered with 202 Accepted and the ID of a job whose status is served on StatusRoute. Equivalent to an
+	// AckHeader of AckCache.
+	AsyncHeader = "X-EigenDA-Proxy-Async"
+)
+
+// ErrTooManyJobs ... returned when an asynchronous PUT is rejected because MaxQueued jobs are already queued
+var ErrTooManyJobs = errors.New("too many queued asynchronous puts")
+
+// JobState ... progress of an asynchronous PUT
+type JobState string
+
+const (
+	// JobQueued ... the job waits for one of the MaxInFlight dispersal slots
+	JobQueued JobState = "queued"
+	// JobDispersing ... the blob is being dispersed
+	JobDispersing JobState = "dispersing"
+	// JobConfirmed ... the blob was dispersed and its batch confirmed, the commitment is available
+	JobConfirmed JobState = "confirmed"
+	// JobFinalized ... the blob was dispersed and its batch finalized, the commitment is available
+	JobFinalized JobState = "finalized"
+	// JobFailed ... the PUT failed, see Error
+	JobFailed JobState = "failed"
+)
+
+// jobStateRanks ... order of the states a job goes through, progress reports never move a job backwards
+var jobStateRanks = map[JobState]int{JobQueued: 0, JobDispersing: 1, JobConfirmed: 2, JobFinalized: 3, JobFailed: 4}
+
+// done ... whether the job completed, successfully or not
+func (s JobState) done() bool {
+	return s == JobConfirmed || s == JobFinalized || s == JobFailed
+}
+
+// AsyncConfig ... asynchronous PUTs, see AsyncHeader
+type AsyncConfig struct {
+	Enabled bool
+	// MaxInFlight caps the asynchronous PUTs dispersed concurrently, further jobs are queued
+	MaxInFlight int
+	// MaxQueued caps the jobs waiting for a dispersal slot, further asynchronous PUTs are rejected
+	MaxQueued int
+	// Retention is how long the status of completed jobs is kept for
+	Retention time.Duration
+	// MinAck is the earliest ack level PUTs are answered at, requests asking for an earlier one are answered at
+	// MinAck
+	MinAck AckLevel
+	// JournalDir is the directory PUTs answered at AckCache are journaled to until dispersed, required if MinAck
+	// is AckCache
+	JournalDir string
+}
+
+// Check ... validates the asynchronous PUT config
+func (c AsyncConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxInFlight < 1 || c.MaxQueued < 0 {
+		return fmt.Errorf("async max in flight must be at least 1 and max queued must not be negative")
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("async job retention must be positive")
+	}
+	minAck := AckCache
+	if c.MinAck != "" {
+		var err error
+		minAck, err = ParseAckLevel(string(c.MinAck))
+		if err != nil {
+			return fmt.Errorf("async min ack: %w", err)
+		}
+	}
+	// puts acknowledged from the cache would be lost on restart
+	if minAck == AckCache && c.JournalDir == "" {
+		return fmt.Errorf("async min ack %s requires a journal dir, or a min ack of %s", AckCache, AckDispersed)
+	}
+	return nil
+}
+
+// Job ... status of an asynchronous PUT, as served on StatusRoute
+type Job struct {
+	ID    string   `json:"id"`
+	State JobState `json:"state"`
+	// Commitment is the response body of the PUT once confirmed, empty for OptimismKeccak commitments provided by
+	// the client
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	// Error of the PUT if it failed
+	Error string `json:"error,omitempty"`
+	// Cert is the RLP encoded cert of a dispersal recovered from the journal of pending dispersals, whose
+	// commitment is returned by the next PUT of the same payload
+	Cert      hexutil.Bytes `json:"cert,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// jobTracker ... runs asynchronous PUTs in the background and keeps their status. Jobs are kept in memory only,
+// the status of jobs is lost on restart. PUTs answered at AckCache are journaled if a journal dir is configured.
+type jobTracker struct {
+	cfg      AsyncConfig
+	inFlight chan struct{}
+	journal  *putJournal
+	log      log.Logger
+	now      func() time.Time
+
+	mu     sync.Mutex
+	jobs   map[string]*Job
+	queued int
+}
+
+func newJobTracker(cfg AsyncConfig, log log.Logger) *jobTracker {
+	if !cfg.Enabled {
+		return nil
+	}
+	t := &jobTracker{
+		cfg:      cfg,
+		inFlight: make(chan struct{}, cfg.MaxInFlight),
+		log:      log.With("subsystem", "async"),
+		now:      time.Now,
+		jobs:     make(map[string]*Job),
+	}
+	if cfg.JournalDir != "" {
+		t.journal = &putJournal{dir: cfg.JournalDir}
+	}
+	return t
+}
+
+// add ... creates a queued job, unless MaxQueued jobs are already queued
+func (t *jobTracker) add() (Job, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return Job{}, fmt.Errorf("failed to generate job id: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune()
+	if t.queued >= t.cfg.MaxQueued {
+		return Job{}, fmt.Errorf("%w: %d jobs queued", ErrTooManyJobs, t.queued)
+	}
+	now := t.now()
+	job := &Job{ID: hex.EncodeToString(id), State: JobQueued, CreatedAt: now, UpdatedAt: now}
+	t.jobs[job.ID] = job
+	t.queued++
+	return *job, nil
+}
+
+// restore ... creates a queued job with the given id, e.g. for a PUT replayed from the journal, regardless of
+// MaxQueued
+func (t *jobTracker) restore(id string, createdAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.jobs[id] = &Job{ID: id, State: JobQueued, CreatedAt: createdAt, UpdatedAt: t.now()}
+	t.queued++
+}
+
+// prune ... forgets the jobs completed more than Retention ago. Caller must hold the lock.
+func (t *jobTracker) prune() {
+	cutoff := t.now().Add(-t.cfg.Retention)
+	for id, job := range t.jobs {
+		if job.State.done() && job.UpdatedAt.Before(cutoff) {
+			delete(t.jobs, id)
+		}
+	}
+}
+
+// get ... returns the job with the given id
+func (t *jobTracker) get(id string) (Job, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.prune()
+	job, ok := t.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// advance ... moves the job to state, unless it already progressed further
+func (t *jobTracker) advance(id string, state JobState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+	if !ok || jobStateRanks[state] <= jobStateRanks[job.State] {
+		return
+	}
+	if job.State == JobQueued {
+		t.queued--
+	}
+	job.State = state
+	job.UpdatedAt = t.now()
+}
+
+// complete ... records the outcome of the PUT of a job, and drops it from the journal
+func (t *jobTracker) complete(id string, commitment []byte, err error) {
+	if t.journal != nil {
+		if jerr := t.journal.remove(id); jerr != nil {
+			t.log.Error("Failed to remove put from the journal", "job", id, "err", jerr)
+		}
+	}
+	if err == nil {
+		// backends which don't report the progress of their dispersals, e.g. the memstore, are confirmed at once
+		t.advance(id, JobConfirmed)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	job, ok := t.jobs[id]
+	if !ok {
+		return
+	}
+	if err != nil {
+		job.State = JobFailed
+		job.Error = err.Error()
+	} else {
+		job.Commitment = commitment
+	}
+	job.UpdatedAt = t.now()
+}
+
+// run ... serves the PUT of a job once a dispersal slot is available
+func (t *jobTracker) run(
+	id string,
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	r *http.Request,
+) {
+	t.inFlight <- struct{}{}
+	defer func() { <-t.inFlight }()
+	t.advance(id, JobDispersing)
+
+	rec := &responseRecorder{header: make(http.Header)}
+	defer func() {
+		if p := recover(); p != nil {
+			t.log.Error("Asynchronous put panicked", "job", id, "panic", p)
+			t.complete(id, nil, fmt.Errorf("internal error: %v", p))
+		}
+	}()
+
+	_, err := handleFn(rec, r)
+	if err == nil && rec.status >= http.StatusMultipleChoices {
+		err = fmt.Errorf("put failed with status %d", rec.status)
+	}
+	if err != nil {
+		store.RequestLogger(r.Context(), t.log).Warn("Asynchronous put failed", "job", id, "err", err)
+	}
+	t.complete(id, rec.body.Bytes(), err)
+}
+
+// responseRecorder ... response of a request served on behalf of another one, i.e. a job, a blob of a batch or a
+// gRPC call
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseRecorder) Header() http.Header {
+	return w.header
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(b)
+}
+
+// WithAsync is a middleware that serves PUT requests at the ack level they ask for via AckHeader or AsyncHeader,
+// raised to the configured minimum, and echoes the level in the response's AckHeader. Requests acknowledged
+// before their blob is confirmed are served by handleFn in the background once a dispersal slot is available,
+// detached from the client's connection and deadline, and answered with 202 Accepted and the job whose status
+// is served on StatusRoute. Requests are served synchronously if jobs is nil. Writes rejected by maintenance, as
+// HandlePut rejects them, aren't queued.
+func WithAsync(
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	jobs *jobTracker,
+	maintenance func(http.ResponseWriter) bool,
+) func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error) {
+	if jobs == nil {
+		return func(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+			w.Header().Set(AckHeader, string(AckConfirmed))
+			return handleFn(w, r)
+		}
+	}
+	return func(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+		meta, _ := ReadCommitmentMeta(r)
+		level, err := readAckLevel(r, jobs.cfg.MinAck)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return meta, err
+		}
+		w.Header().Set(AckHeader, string(level))
+		if level == AckConfirmed {
+			return handleFn(w, r)
+		}
+		if maintenance(w) {
+			return meta, ErrMaintenance
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return meta, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		job, err := jobs.add()
+		if err != nil {
+			if errors.Is(err, ErrTooManyJobs) {
+				w.Header().Set("Retry-After", "60")
+				w.WriteHeader(http.StatusServiceUnavailable)
+			} else {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+			return meta, err
+		}
+
+		req := r.Clone(r.Context())
+		req.Header.Del(AsyncHeader)
+		req.Header.Del(AckHeader)
+		if level == AckCache && jobs.journal != nil {
+			err := jobs.journal.add(journaledPut{JobID: job.ID, URL: r.URL.RequestURI(), Header: journaledHeader(req.Header),
+				Body: body, CreatedAt: job.CreatedAt})
+			if err != nil {
+				err = fmt.Errorf("failed to journal put: %w", err)
+				jobs.complete(job.ID, nil, err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return meta, err
+			}
+		}
+		accepted := jobs.start(job.ID, handleFn, req, body)
+
+		if level == AckDispersed {
+			select {
+			case <-accepted:
+			case <-r.Context().Done():
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return meta, fmt.Errorf("request ended before the disperser accepted the blob of job %s: %w", job.ID,
+					r.Context().Err())
+			}
+		}
+
+		job, _ = jobs.get(job.ID)
+		status := http.StatusAccepted
+		if job.State == JobFailed {
+			status = http.StatusInternalServerError
+		}
+		w.Header().Set("Location", StatusRoute+job.ID)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(job)
+		if job.State == JobFailed {
+			return meta, fmt.Errorf("put of job %s failed: %s", job.ID, job.Error)
+		}
+		return meta, nil
+	}
+}
+
+// start ... serves the PUT r of a job with body in the background, detached from the client's connection and
+// deadline. The returned channel is closed once the disperser accepted the blob, or the PUT completed.
+func (t *jobTracker) start(
+	id string,
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	r *http.Request,
+	body []byte,
+) <-chan struct{} {
+	accepted := make(chan struct{})
+	var once sync.Once
+	accept := func() { once.Do(func() { close(accepted) }) }
+
+	ctx := store.WithCallerDeadline(context.WithoutCancel(r.Context()), time.Time{})
+	ctx = store.WithDispersalProgress(ctx, func(s store.DispersalState) {
+		t.advance(id, JobState(s))
+		accept()
+	})
+	req := r.Clone(ctx)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	go func() {
+		defer accept()
+		t.run(id, handleFn, req)
+	}()
+	return accepted
+}
+
+// replay ... serves the PUTs left in the journal by a previous run in the background, under their job ids
+func (t *jobTracker) replay(
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+) error {
+	if t == nil || t.journal == nil {
+		return nil
+	}
+	puts, err := t.journal.list()
+	if err != nil {
+		return err
+	}
+	for _, p := range puts {
+		r, err := http.NewRequestWithContext(context.Background(), http.MethodPost, p.URL, nil)
+		if err != nil {
+			t.log.Error("Failed to replay journaled put", "job", p.JobID, "err", err)
+			continue
+		}
+		// entries journaled by earlier versions may hold any header
+		r.Header = journaledHeader(p.Header)
+		t.log.Info("Replaying journaled put", "job", p.JobID, "size", len(p.Body), "created_at", p.CreatedAt)
+		t.restore(p.JobID, p.CreatedAt)
+		t.start(p.JobID, handleFn, r, p.Body)
+	}
+	return nil
+}
+
+// HandleJobStatus ... serves the status of an asynchronous PUT, or of a journaled dispersal identified by the
+// keccak256 hash of its payload.
+// Example: GET /status/<job id>
+func (svr *Server) HandleJobStatus(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, StatusRoute)
+	job, ok := Job{}, false
+	if svr.jobs != nil {
+		job, ok = svr.jobs.get(id)
+	}
+	if !ok {
+		job, ok = svr.dispersalJob(id)
+	}
+	if !ok {
+		err := fmt.Errorf("unknown job %q", id)
+		svr.WriteNotFound(w, err)
+		return err
+	}
+	return svr.writeJSON(w, job)
+}
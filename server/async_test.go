@@ -0,0 +1,287 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncPut(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Async: AsyncConfig{Enabled: true, MaxInFlight: 1, MaxQueued: 4, Retention: time.Minute},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint()
+
+	payload := []byte("dispersed in the background")
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		url+"/put/?commitment_mode=simple", bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set(AsyncHeader, "true")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var job Job
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+	require.Equal(t, StatusRoute+job.ID, resp.Header.Get("Location"))
+
+	require.Eventually(t, func() bool {
+		job, err = getJob(url + StatusRoute + job.ID)
+		return err == nil && job.State.done()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, JobConfirmed, job.State, job.Error)
+
+	data, err := doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x?commitment_mode=simple", url, []byte(job.Commitment)), nil)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	_, err = getJob(url + StatusRoute + "unknown")
+	require.ErrorContains(t, err, "404")
+}
+
+func TestAsyncPutMaintenance(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Async: AsyncConfig{Enabled: true, MaxInFlight: 1, MaxQueued: 4, Retention: time.Minute},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	_, err := svr.maintenance.enter("upgrade", 90*time.Second)
+	require.NoError(t, err)
+
+	// writes are rejected like synchronous ones rather than queued
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		"http://"+svr.Endpoint()+"/put/?commitment_mode=simple", bytes.NewReader([]byte("frozen")))
+	require.NoError(t, err)
+	req.Header.Set(AsyncHeader, "true")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, "90", resp.Header.Get("Retry-After"))
+	require.Empty(t, svr.jobs.jobs)
+}
+
+// getJob ... returns the job served on url
+func getJob(url string) (Job, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		return Job{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return Job{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Job{}, fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	var job Job
+	err = json.NewDecoder(resp.Body).Decode(&job)
+	return job, err
+}
+
+func TestAsyncConfigMinAck(t *testing.T) {
+	cfg := AsyncConfig{Enabled: true, MaxInFlight: 1, Retention: time.Minute}
+	// puts acknowledged from the cache must be journaled
+	require.Error(t, cfg.Check())
+
+	cfg.MinAck = AckDispersed
+	require.NoError(t, cfg.Check())
+
+	cfg.MinAck = AckCache
+	cfg.JournalDir = t.TempDir()
+	require.NoError(t, cfg.Check())
+}
+
+func TestJobTracker(t *testing.T) {
+	now := time.Now()
+	jobs := newJobTracker(AsyncConfig{Enabled: true, MaxInFlight: 1, MaxQueued: 1, Retention: time.Minute}, log.New())
+	jobs.now = func() time.Time { return now }
+
+	job, err := jobs.add()
+	require.NoError(t, err)
+	_, err = jobs.add()
+	require.ErrorIs(t, err, ErrTooManyJobs)
+
+	// progress reports of the chunks of a payload never move the job backwards
+	jobs.advance(job.ID, JobConfirmed)
+	jobs.advance(job.ID, JobDispersing)
+	job, _ = jobs.get(job.ID)
+	require.Equal(t, JobConfirmed, job.State)
+
+	// once dispersing, the job no longer counts towards the queue
+	other, err := jobs.add()
+	require.NoError(t, err)
+	jobs.complete(other.ID, nil, errors.New("disperser unavailable"))
+	other, _ = jobs.get(other.ID)
+	require.Equal(t, JobFailed, other.State)
+	require.Equal(t, "disperser unavailable", other.Error)
+
+	now = now.Add(2 * time.Minute)
+	_, ok := jobs.get(job.ID)
+	require.False(t, ok)
+}
+
+func TestJournaledHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer secret")
+	h.Set("X-Api-Key", "secret")
+	h.Set(TenantHeader, "rollup-a")
+	h.Set(CommitmentModeHeader, "simple")
+	h.Add(RedundancyHeader, "s3")
+
+	// credentials aren't persisted
+	journaled := journaledHeader(h)
+	require.Len(t, journaled, 3)
+	require.Equal(t, "rollup-a", journaled.Get(TenantHeader))
+	require.Equal(t, "simple", journaled.Get(CommitmentModeHeader))
+	require.Equal(t, "s3", journaled.Get(RedundancyHeader))
+}
+
+func TestAckLevels(t *testing.T) {
+	journal := &putJournal{dir: t.TempDir()}
+	payload := []byte("journaled before a restart")
+	require.NoError(t, journal.add(journaledPut{JobID: "replayed", URL: "/put/?commitment_mode=simple",
+		Header: http.Header{}, Body: payload, CreatedAt: time.Now()}))
+
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Async: AsyncConfig{Enabled: true, MaxInFlight: 1, MaxQueued: 4, Retention: time.Minute,
+			MinAck: AckDispersed, JournalDir: journal.dir},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint()
+
+	// journaled puts are replayed on startup under their job id
+	require.Eventually(t, func() bool {
+		job, err := getJob(url + StatusRoute + "replayed")
+		return err == nil && job.State == JobConfirmed
+	}, 5*time.Second, 10*time.Millisecond)
+	puts, err := journal.list()
+	require.NoError(t, err)
+	require.Empty(t, puts)
+
+	put := func(header, value string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			url+"/put/?commitment_mode=simple", bytes.NewReader([]byte("acknowledged")))
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		status int
+		ack    AckLevel
+	}{
+		{name: "Confirmed", status: http.StatusOK, ack: AckConfirmed},
+		{name: "Dispersed", header: AckHeader, value: "dispersed", status: http.StatusAccepted, ack: AckDispersed},
+		{name: "CacheRaisedToMin", header: AckHeader, value: "cache", status: http.StatusAccepted, ack: AckDispersed},
+		{name: "AsyncRaisedToMin", header: AsyncHeader, value: "true", status: http.StatusAccepted, ack: AckDispersed},
+		{name: "Invalid", header: AckHeader, value: "mempool", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := put(tt.header, tt.value)
+			require.Equal(t, tt.status, resp.StatusCode)
+			require.Equal(t, string(tt.ack), resp.Header.Get(AckHeader))
+			if tt.status == http.StatusAccepted {
+				// the memstore confirms blobs at once
+				var job Job
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&job))
+				require.Equal(t, JobConfirmed, job.State)
+			}
+		})
+	}
+}
+
+func TestReadAckLevel(t *testing.T) {
+	req := func(header, value string) *http.Request {
+		r, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/put", nil)
+		if header != "" {
+			r.Header.Set(header, value)
+		}
+		return r
+	}
+
+	level, err := readAckLevel(req("", ""), "")
+	require.NoError(t, err)
+	require.Equal(t, AckConfirmed, level)
+	level, err = readAckLevel(req(AsyncHeader, "true"), AckCache)
+	require.NoError(t, err)
+	require.Equal(t, AckCache, level)
+	level, err = readAckLevel(req(AckHeader, "Dispersed"), AckCache)
+	require.NoError(t, err)
+	require.Equal(t, AckDispersed, level)
+	level, err = readAckLevel(req(AckHeader, "cache"), AckConfirmed)
+	require.NoError(t, err)
+	require.Equal(t, AckConfirmed, level)
+	_, err = readAckLevel(req(AckHeader, "mempool"), AckCache)
+	require.Error(t, err)
+}
+
+// journaledDispersalStore ... GeneratedKeyStore tracking fixed pending dispersals
+type journaledDispersalStore struct {
+	store.GeneratedKeyStore
+	dispersals []eigenda.PendingDispersal
+}
+
+func (s *journaledDispersalStore) PendingDispersals() []eigenda.PendingDispersal { return s.dispersals }
+
+func (s *journaledDispersalStore) ResumeDispersal(context.Context, string) error {
+	return eigenda.ErrDispersalPending
+}
+
+func TestJournaledDispersalStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	da := &journaledDispersalStore{dispersals: []eigenda.PendingDispersal{
+		{ID: "0x01", Status: eigenda.DispersalStatusPending, LastError: "dispersal is still pending"},
+		{ID: "0x02", Status: eigenda.DispersalStatusRecovered, Cert: []byte{0xc0}},
+	}}
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().GetEigenDAStore().Return(da).AnyTimes()
+	svr := NewServer("127.0.0.1", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint() + StatusRoute
+
+	// the status route is served without async puts, for the dispersals recovered from the journal
+	job, err := getJob(url + "0x01")
+	require.NoError(t, err)
+	require.Equal(t, JobDispersing, job.State)
+	require.Equal(t, "dispersal is still pending", job.Error)
+
+	job, err = getJob(url + "0x02")
+	require.NoError(t, err)
+	require.Equal(t, JobConfirmed, job.State)
+	require.Equal(t, []byte{0xc0}, []byte(job.Cert))
+
+	_, err = getJob(url + "0x03")
+	require.ErrorContains(t, err, "404")
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const AdminHealthRoute = AdminRoute + "health"
+
+// HandleBackendHealth ... returns the recent circuit breaker transitions and flap counts of each cache and fallback
+// target, so that transient blips can be told apart from outages
+func (svr *Server) HandleBackendHealth(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	return svr.writeJSON(w, svr.router.BackendHealth())
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BatchPutRoute ... disperses several blobs in a single request, see HandleBatchPut
+const BatchPutRoute = "/batch/put"
+
+// BatchConfig ... PUT requests of several blobs, see HandleBatchPut. Disabled if MaxBlobs is 0.
+type BatchConfig struct {
+	// MaxBlobs is the number of blobs a batch may hold
+	MaxBlobs int
+	// Concurrency is the number of blobs of a batch dispersed concurrently
+	Concurrency int
+}
+
+// Enabled ... whether batch PUTs are served
+func (c BatchConfig) Enabled() bool {
+	return c.MaxBlobs > 0
+}
+
+// Check ... validates the batch PUT config
+func (c BatchConfig) Check() error {
+	if c.Enabled() && c.Concurrency < 1 {
+		return fmt.Errorf("batch concurrency must be at least 1")
+	}
+	return nil
+}
+
+// BatchPutRequest ... JSON body of a batch PUT
+type BatchPutRequest struct {
+	Blobs []hexutil.Bytes `json:"blobs"`
+}
+
+// BatchPutResult ... outcome of the PUT of a blob of a batch, in the order of the request's blobs
+type BatchPutResult struct {
+	// Status is the HTTP status the blob would have been answered with by a single PUT
+	Status int `json:"status"`
+	// Commitment is the response body of the PUT if it succeeded
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// BatchPutResponse ... response of a batch PUT
+type BatchPutResponse struct {
+	Results []BatchPutResult `json:"results"`
+}
+
+// readBatch ... returns the blobs of a batch PUT, sent either as a JSON BatchPutRequest or as the parts of a
+// multipart body
+func readBatch(r *http.Request, maxBlobs int) ([][]byte, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type: %w", err)
+	}
+
+	var blobs [][]byte
+	switch {
+	case mediaType == "application/json":
+		var req BatchPutRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("failed to decode batch: %w", err)
+		}
+		for _, b := range req.Blobs {
+			blobs = append(blobs, b)
+		}
+	case strings.HasPrefix(mediaType, "multipart/"):
+		mr, err := r.MultipartReader()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart batch: %w", err)
+		}
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read multipart batch: %w", err)
+			}
+			if len(blobs) == maxBlobs {
+				return nil, fmt.Errorf("batch holds more than %d blobs", maxBlobs)
+			}
+			b, err := io.ReadAll(part)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read blob %d of multipart batch: %w", len(blobs), err)
+			}
+			blobs = append(blobs, b)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content type %s, expected application/json or multipart", mediaType)
+	}
+
+	if len(blobs) == 0 {
+		return nil, errors.New("batch holds no blobs")
+	}
+	if len(blobs) > maxBlobs {
+		return nil, fmt.Errorf("batch holds %d blobs, more than %d", len(blobs), maxBlobs)
+	}
+	return blobs, nil
+}
+
+// HandleBatchPut ... disperses the blobs of a batch concurrently, each served like a single PUT with the query
+// and headers of the batch, and returns the result of each blob. The batch succeeds even if some of its blobs
+// failed, clients retry those only.
+// Example: POST /batch/put?commitment_mode=simple with {"blobs": ["0x...", "0x..."]}
+func (svr *Server) HandleBatchPut(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	mode, err := ReadCommitmentMode(r)
+	if err != nil {
+		err = fmt.Errorf("invalid commitment mode: %w", err)
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	// keccak256 commitments are only written by single PUTs
+	if mode == commitments.OptimismKeccak {
+		err = fmt.Errorf("batch puts require a generic or simple commitment mode")
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	blobs, err := readBatch(r, svr.opts.Batch.MaxBlobs)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	put := WithStatus(svr.HandlePut, svr.status)
+	results := make([]BatchPutResult, len(blobs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < svr.opts.Batch.Concurrency && i < len(blobs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = svr.putBatchBlob(put, r, blobs[j])
+			}
+		}()
+	}
+	for i := range blobs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return svr.writeJSON(w, BatchPutResponse{Results: results})
+}
+
+// putBatchBlob ... serves the PUT of a blob of a batch
+func (svr *Server) putBatchBlob(
+	put func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	r *http.Request,
+	blob []byte,
+) BatchPutResult {
+	req := r.Clone(r.Context())
+	req.URL.Path = PutRoute
+	req.Header.Del("Content-Type")
+	req.Header.Del(AsyncHeader)
+	req.Header.Del(AckHeader)
+	req.Body = io.NopCloser(bytes.NewReader(blob))
+	req.ContentLength = int64(len(blob))
+
+	rec := &responseRecorder{header: make(http.Header)}
+	_, err := put(rec, req)
+	res := BatchPutResult{Status: rec.status}
+	if res.Status == 0 {
+		res.Status = http.StatusOK
+	}
+	if err != nil {
+		if res.Status == http.StatusOK {
+			res.Status = http.StatusInternalServerError
+		}
+		res.Error = secrets.Scrub(err.Error())
+		return res
+	}
+	res.Commitment = rec.body.Bytes()
+	return res
+}
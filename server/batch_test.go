@@ -0,0 +1,97 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchPut(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Batch: BatchConfig{MaxBlobs: 3, Concurrency: 2},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint() + BatchPutRoute
+
+	batchPut := func(query, contentType string, body []byte) (int, BatchPutResponse) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url+query, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var batch BatchPutResponse
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&batch))
+		} else {
+			_, _ = io.Copy(io.Discard, resp.Body)
+		}
+		return resp.StatusCode, batch
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		blobs := []hexutil.Bytes{[]byte("first"), make([]byte, 2<<20), []byte("third")}
+		body, err := json.Marshal(BatchPutRequest{Blobs: blobs})
+		require.NoError(t, err)
+
+		status, batch := batchPut("?commitment_mode=simple", "application/json", body)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, batch.Results, 3)
+
+		// the oversized blob fails on its own
+		require.Equal(t, http.StatusBadRequest, batch.Results[1].Status)
+		require.NotEmpty(t, batch.Results[1].Error)
+		for _, i := range []int{0, 2} {
+			require.Equal(t, http.StatusOK, batch.Results[i].Status, batch.Results[i].Error)
+			data, err := doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x?commitment_mode=simple",
+				"http://"+svr.Endpoint(), []byte(batch.Results[i].Commitment)), nil)
+			require.NoError(t, err)
+			require.Equal(t, []byte(blobs[i]), data)
+		}
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		for _, blob := range []string{"first", "second"} {
+			part, err := mw.CreateFormFile("blob", "blob")
+			require.NoError(t, err)
+			_, err = part.Write([]byte(blob))
+			require.NoError(t, err)
+		}
+		require.NoError(t, mw.Close())
+
+		status, batch := batchPut("", mw.FormDataContentType(), body.Bytes())
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, batch.Results, 2)
+		for _, res := range batch.Results {
+			require.Equal(t, http.StatusOK, res.Status, res.Error)
+			require.NotEmpty(t, res.Commitment)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		body, err := json.Marshal(BatchPutRequest{Blobs: []hexutil.Bytes{{1}, {2}, {3}, {4}}})
+		require.NoError(t, err)
+		status, _ := batchPut("", "application/json", body)
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = batchPut("?commitment_mode=optimism_keccak256", "application/json", []byte(`{"blobs":["0x01"]}`))
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = batchPut("", "text/plain", []byte("blob"))
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
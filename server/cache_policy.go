@@ -0,0 +1,34 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+// CachePolicyHeader ... GET request header forcing a read from EigenDA, skipping the cache and fallback targets:
+// "bypass" only skips them, "refresh" also overwrites the cache targets with the blob read from EigenDA
+const CachePolicyHeader = "X-EigenDA-Proxy-Cache-Policy"
+
+// readCachePolicy ... returns the cache policy requested by a GET request via CachePolicyHeader, or via a
+// Cache-Control no-cache directive which is treated as "bypass"
+func readCachePolicy(r *http.Request) (store.CachePolicy, error) {
+	if v := r.Header.Get(CachePolicyHeader); v != "" {
+		p, err := store.ParseCachePolicy(v)
+		if err != nil {
+			return store.CachePolicyDefault, fmt.Errorf("invalid %s header: %w", CachePolicyHeader, err)
+		}
+		return p, nil
+	}
+
+	for _, v := range r.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+				return store.CachePolicyBypass, nil
+			}
+		}
+	}
+	return store.CachePolicyDefault, nil
+}
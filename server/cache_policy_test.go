@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCachePolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		expected    store.CachePolicy
+		expectError bool
+	}{
+		{name: "None", expected: store.CachePolicyDefault},
+		{name: "NoCache", headers: map[string]string{"Cache-Control": "max-age=0, No-Cache"}, expected: store.CachePolicyBypass},
+		{name: "OtherDirective", headers: map[string]string{"Cache-Control": "no-store"}, expected: store.CachePolicyDefault},
+		{name: "Refresh", headers: map[string]string{CachePolicyHeader: "refresh"}, expected: store.CachePolicyRefresh},
+		{name: "HeaderPrecedence", headers: map[string]string{CachePolicyHeader: "refresh", "Cache-Control": "no-cache"},
+			expected: store.CachePolicyRefresh},
+		{name: "Invalid", headers: map[string]string{CachePolicyHeader: "purge"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/get/0x00", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			policy, err := readCachePolicy(req)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, policy)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedRoundTrip(t *testing.T) {
+	fsStore, err := fs.NewStore(log.New(), fs.Config{Path: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fsStore.Close() })
+
+	router, err := store.NewRouter(newMemstore(t), nil, log.New(), []store.PrecomputedKeyStore{fsStore}, nil,
+		store.RouterOptions{Chunking: store.ChunkingOptions{
+			MinSize: 512, MaxSize: 2048, TargetLatency: time.Minute, Window: time.Minute,
+		}})
+	require.NoError(t, err)
+	svr := NewServer("127.0.0.1", 0, router, log.New(), metrics.NoopMetrics, Options{})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint()
+
+	payload := make([]byte, 5000)
+	_, err = rand.Read(payload)
+	require.NoError(t, err)
+
+	comm, err := doRequest(http.MethodPost, url+"/put/?commitment_mode=simple", payload)
+	require.NoError(t, err)
+	parsed, err := commitments.ParseCommitment(comm, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, commitments.CertV1Envelope, parsed.CertVersion)
+	require.Len(t, parsed.Envelope.Meta.Chunks, 3)
+
+	data, err := doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x?commitment_mode=simple", url, comm), nil)
+	require.NoError(t, err)
+	require.Equal(t, payload, data)
+
+	// payloads below the max chunk size are dispersed as a single blob
+	comm, err = doRequest(http.MethodPost, url+"/put/?commitment_mode=simple", payload[:2048])
+	require.NoError(t, err)
+	require.Equal(t, byte(commitments.CertV0), comm[0])
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+)
+
+// CommitmentModeHeader ... request header selecting the commitment mode of a request, as an alternative to the
+// commitment_mode query parameter for clients that can't change the URL they are configured with
+const CommitmentModeHeader = "X-EigenDA-Proxy-Commitment-Mode"
+
+// CommitmentModeRoute ... prefix of the GET and PUT routes of a commitment mode, e.g. /simple/put/ or
+// /optimism_generic/get/0x01..., so that clients of several modes can share a deployment by base URL only
+func CommitmentModeRoute(mode string) string {
+	return "/" + mode
+}
+
+// requestedCommitmentMode ... returns the commitment mode requested by the query parameter or the header, and
+// whether one was requested. Both must select the same mode if set.
+func requestedCommitmentMode(r *http.Request) (commitments.CommitmentMode, bool, error) {
+	var requested commitments.CommitmentMode
+	for _, raw := range []string{r.URL.Query().Get(CommitmentModeKey), r.Header.Get(CommitmentModeHeader)} {
+		if raw == "" {
+			continue
+		}
+		mode, err := commitments.StringToCommitmentMode(strings.ToLower(raw))
+		if err != nil {
+			return "", false, err
+		}
+		if requested != "" && mode != requested {
+			return "", false, fmt.Errorf("conflicting commitment modes %s and %s requested", requested, mode)
+		}
+		requested = mode
+	}
+	return requested, requested != "", nil
+}
+
+// registerCommitmentModeRoutes ... mounts handler under the mode routes of route, for every commitment mode name
+func registerCommitmentModeRoutes(mux *http.ServeMux, route string, handler http.HandlerFunc) {
+	for _, name := range commitments.ModeNames() {
+		mux.HandleFunc(CommitmentModeRoute(name)+route, WithCommitmentModeRoute(name, handler))
+	}
+}
+
+// WithCommitmentModeRoute ... serves requests of the mode route of name with the handler of the plain route, by
+// stripping the mode prefix from the path and setting the commitment_mode query parameter. Requests selecting
+// another mode by query parameter are rejected, as are those selecting another mode by header once handled.
+func WithCommitmentModeRoute(name string, handler http.HandlerFunc) http.HandlerFunc {
+	prefix := CommitmentModeRoute(name)
+	mode, _ := commitments.StringToCommitmentMode(name)
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if raw := query.Get(CommitmentModeKey); raw != "" {
+			if m, err := commitments.StringToCommitmentMode(strings.ToLower(raw)); err != nil || m != mode {
+				http.Error(w, fmt.Sprintf("commitment mode %s conflicts with route %s", raw, prefix),
+					http.StatusBadRequest)
+				return
+			}
+		}
+		query.Set(CommitmentModeKey, string(mode))
+
+		r2 := r.Clone(r.Context())
+		r2.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+		r2.URL.RawPath = ""
+		r2.URL.RawQuery = query.Encode()
+		r2.RequestURI = r2.URL.RequestURI()
+		handler(w, r2)
+	}
+}
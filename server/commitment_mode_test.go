@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCommitmentModeRequested(t *testing.T) {
+	tests := []struct {
+		name         string
+		target       string
+		header       string
+		expectedMode commitments.CommitmentMode
+		expectError  bool
+	}{
+		{name: "Query", target: "/put/?commitment_mode=simple", expectedMode: commitments.SimpleCommitmentMode},
+		{name: "Header", target: "/put/", header: "optimism_keccak256", expectedMode: commitments.OptimismKeccak},
+		{name: "Alias", target: "/put/?commitment_mode=standard", expectedMode: commitments.SimpleCommitmentMode},
+		{name: "HeaderAlias", target: "/put/", header: "Optimism_Keccak", expectedMode: commitments.OptimismKeccak},
+		{name: "Agreeing", target: "/put/?commitment_mode=simple", header: "standard", expectedMode: commitments.SimpleCommitmentMode},
+		{name: "Conflicting", target: "/put/?commitment_mode=simple", header: "optimism_generic", expectError: true},
+		{name: "Unknown", target: "/put/", header: "arbitrum", expectError: true},
+		{name: "Inferred", target: "/get/0x010000abcd", expectedMode: commitments.OptimismGeneric},
+		// the requested mode takes precedence over the commit type byte
+		{name: "HeaderOverPath", target: "/get/0x010000abcd", header: "simple", expectedMode: commitments.SimpleCommitmentMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set(CommitmentModeHeader, tt.header)
+			}
+			mode, err := ReadCommitmentMode(r)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedMode, mode)
+		})
+	}
+}
+
+func TestWithCommitmentModeRoute(t *testing.T) {
+	var served *http.Request
+	handler := WithCommitmentModeRoute("standard", func(_ http.ResponseWriter, r *http.Request) { served = r })
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/standard/put/?tenant=a", nil))
+	require.Equal(t, "/put/", served.URL.Path)
+	require.Equal(t, "simple", served.URL.Query().Get(CommitmentModeKey))
+	require.Equal(t, "a", served.URL.Query().Get("tenant"))
+
+	served = nil
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/standard/put/?commitment_mode=optimism_generic", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Nil(t, served)
+}
+
+// TestCommitmentModeRoutes ... blobs of every commitment mode are written and read through the mode routes and
+// the mode header of a single proxy
+func TestCommitmentModeRoutes(t *testing.T) {
+	url := startRoundTripServer(t)
+
+	for i, name := range commitments.ModeNames() {
+		t.Run(name, func(t *testing.T) {
+			payload := []byte(fmt.Sprintf("payload of mode %s", name))
+			comm, err := doRequest(http.MethodPost, url+CommitmentModeRoute(name)+PutRoute, payload)
+			require.NoError(t, err)
+
+			got, err := doRequest(http.MethodGet, fmt.Sprintf("%s%s%s0x%x", url, CommitmentModeRoute(name), GetRoute, comm), nil)
+			require.NoError(t, err)
+			require.Equal(t, payload, got)
+
+			got, err = doModeRequest(http.MethodGet, fmt.Sprintf("%s%s0x%x", url, GetRoute, comm), name, nil)
+			require.NoError(t, err)
+			require.Equal(t, payload, got)
+
+			// a second payload is written through the plain route and the header
+			payload = append(payload, byte(i))
+			comm, err = doModeRequest(http.MethodPost, url+PutRoute, name, payload)
+			require.NoError(t, err)
+			got, err = doRequest(http.MethodGet, fmt.Sprintf("%s%s%s0x%x", url, CommitmentModeRoute(name), GetRoute, comm), nil)
+			require.NoError(t, err)
+			require.Equal(t, payload, got)
+		})
+	}
+
+	_, err := doRequest(http.MethodPost, url+CommitmentModeRoute("simple")+PutRoute+"?commitment_mode=optimism_generic",
+		[]byte("payload"))
+	require.ErrorContains(t, err, "returned status 400")
+}
+
+// doModeRequest ... returns the response body of a successful request selecting mode by CommitmentModeHeader
+func doModeRequest(method, url, mode string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(CommitmentModeHeader, mode)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, b)
+	}
+	return b, nil
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	CommitmentsRoute = "/commitments"
+
+	commitmentsSinceParam   = "since"
+	commitmentsTenantParam  = "tenant"
+	commitmentsBackendParam = "backend"
+	commitmentsLimitParam   = "limit"
+
+	defaultCommitmentsLimit = 100
+	maxCommitmentsLimit     = 1000
+)
+
+// CommitmentInfo ... metadata of an indexed commitment listed on CommitmentsRoute
+type CommitmentInfo struct {
+	// Seq increases with every indexed commitment and is used as the since cursor of the next page
+	Seq uint64 `json:"seq"`
+	// Key is the commitment as used by the storage backends, i.e. the DA cert or keccak256 hash
+	Key hexutil.Bytes `json:"key"`
+	// Commitment is the commitment as returned to clients, i.e. the key of the /get/ route
+	Commitment hexutil.Bytes `json:"commitment,omitempty"`
+	Mode       string        `json:"mode,omitempty"`
+	Size       int           `json:"size"`
+	Tenant     string        `json:"tenant,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	// RollupHeight is the rollup block or batch number supplied with the PUT request, see RollupHeightHeader
+	RollupHeight *uint64 `json:"rollup_height,omitempty"`
+	// L1Block is the L1 block the commitment was included in, as found by the L1 backfill scanner
+	L1Block *uint64 `json:"l1_block,omitempty"`
+	// Backends are the secondary backends holding a copy of the blob, mapped to the time it was written
+	Backends map[string]time.Time `json:"backends"`
+}
+
+// CommitmentsPage ... a page of commitments listed on CommitmentsRoute, oldest first
+type CommitmentsPage struct {
+	Commitments []CommitmentInfo `json:"commitments"`
+	// Next is the since parameter of the next page, unset once the last page has been listed
+	Next uint64 `json:"next,omitempty"`
+}
+
+// HandleCommitments ... lists the indexed commitments matching the query parameters, oldest first.
+// since is either the seq of the last listed commitment, or an RFC 3339 timestamp the commitments must be
+// created after. tenant matches the tenant of the PUT request (see TenantHeader) and backend a secondary
+// backend holding a copy of the blob.
+// Example: GET /commitments?since=2024-10-01T00:00:00Z&tenant=rollup-a&backend=s3&limit=100
+func (svr *Server) HandleCommitments(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	query := r.URL.Query()
+	filter := index.Filter{Tenant: query.Get(commitmentsTenantParam)}
+	if raw := query.Get(commitmentsSinceParam); raw != "" {
+		if seq, err := strconv.ParseUint(raw, 10, 64); err == nil {
+			filter.Since = seq
+		} else if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			filter.CreatedAfter = t
+		} else {
+			err = fmt.Errorf("invalid %s %q, expected a seq or an RFC 3339 timestamp", commitmentsSinceParam, raw)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+	}
+	if raw := query.Get(commitmentsBackendParam); raw != "" {
+		b := store.StringToBackendType(raw)
+		if b == store.Unknown {
+			err := fmt.Errorf("invalid %s %q", commitmentsBackendParam, raw)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+		filter.Backend = b.String()
+	}
+
+	limit := defaultCommitmentsLimit
+	if raw := query.Get(commitmentsLimitParam); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 {
+			err = fmt.Errorf("invalid %s %q", commitmentsLimitParam, raw)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+		limit = min(l, maxCommitmentsLimit)
+	}
+
+	entries := svr.router.Index().List(filter, limit)
+	page := CommitmentsPage{Commitments: make([]CommitmentInfo, 0, len(entries))}
+	for _, e := range entries {
+		page.Commitments = append(page.Commitments, svr.commitmentInfo(e))
+	}
+	// a full page may be followed by more matching commitments
+	if len(entries) == limit {
+		page.Next = entries[len(entries)-1].Seq
+	}
+
+	return svr.writeJSON(w, page)
+}
+
+// commitmentInfo ... returns the listed metadata of an index entry
+func (svr *Server) commitmentInfo(e index.Entry) CommitmentInfo {
+	info := CommitmentInfo{
+		Seq:          e.Seq,
+		Key:          e.Key,
+		Mode:         e.Mode,
+		Size:         e.Size,
+		Tenant:       e.Tenant,
+		CreatedAt:    e.CreatedAt,
+		RollupHeight: e.RollupHeight,
+		L1Block:      e.L1Block,
+		Backends:     e.Backends,
+	}
+	if e.Mode != "" {
+		if comm, err := svr.encodeCommitment(e.Key, commitments.CommitmentMode(e.Mode)); err == nil {
+			info.Commitment = comm
+		}
+	}
+	return info
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCommitments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := index.New(10)
+	for _, k := range []string{"cert-a", "cert-b", "cert-c"} {
+		idx.Put([]byte(k), 5, string(commitments.OptimismGeneric))
+		idx.SetTenant([]byte(k), "rollup-a")
+	}
+	idx.SetTenant([]byte("cert-b"), "rollup-b")
+	idx.AddBackend([]byte("cert-c"), 5, "S3")
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+		expectedKeys []string
+		expectedNext uint64
+	}{
+		{name: "All", query: "", expectedCode: http.StatusOK, expectedKeys: []string{"cert-a", "cert-b", "cert-c"}},
+		{name: "Tenant", query: "?tenant=rollup-a", expectedCode: http.StatusOK, expectedKeys: []string{"cert-a", "cert-c"}},
+		{name: "Backend", query: "?backend=s3", expectedCode: http.StatusOK, expectedKeys: []string{"cert-c"}},
+		{name: "FirstPage", query: "?limit=2", expectedCode: http.StatusOK, expectedKeys: []string{"cert-a", "cert-b"}, expectedNext: 2},
+		{name: "NextPage", query: "?since=2&limit=2", expectedCode: http.StatusOK, expectedKeys: []string{"cert-c"}},
+		{name: "SinceTime", query: "?since=2006-01-02T15:04:05Z", expectedCode: http.StatusOK, expectedKeys: []string{"cert-a", "cert-b", "cert-c"}},
+		{name: "InvalidSince", query: "?since=yesterday", expectedCode: http.StatusBadRequest},
+		{name: "InvalidBackend", query: "?backend=tape", expectedCode: http.StatusBadRequest},
+		{name: "InvalidLimit", query: "?limit=0", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			err := server.HandleCommitments(rec, httptest.NewRequest(http.MethodGet, CommitmentsRoute+tt.query, nil))
+			require.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode != http.StatusOK {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var page CommitmentsPage
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+			keys := make([]string, len(page.Commitments))
+			for i, c := range page.Commitments {
+				keys[i] = string(c.Key)
+			}
+			require.Equal(t, tt.expectedKeys, keys)
+			require.Equal(t, tt.expectedNext, page.Next)
+		})
+	}
+}
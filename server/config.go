@@ -1,16 +1,30 @@
 package server
 
 import (
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/Layr-Labs/eigenda-proxy/backfill"
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
 	"github.com/Layr-Labs/eigenda-proxy/flags"
 	"github.com/Layr-Labs/eigenda-proxy/flags/eigendaflags"
+	"github.com/Layr-Labs/eigenda-proxy/standby"
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/azure"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/gcs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/localcache"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/api/clients"
@@ -22,29 +36,193 @@ type Config struct {
 	EdaClientConfig clients.EigenDAClientConfig
 	VerifierConfig  verify.Config
 
+	// file containing the signer private key, watched for changes to support secret rotation
+	SignerPrivateKeyFile string
+	// compute the kzg commitment of blobs before dispersing them
+	PrevalidateCommitment bool
+	// dispersals whose status polling timed out
+	PendingDispersalsPath          string
+	PendingDispersalsRetryInterval time.Duration
+	PendingDispersalsMaxAge        time.Duration
+	// CustomQuorumFallbackAfter is the number of failed dispersals to the custom quorums after which blobs are
+	// dispersed to the default quorums only, never if 0
+	CustomQuorumFallbackAfter int
+	// PendingDispersalsRedisKey journals the pending dispersals to a Redis hash instead of PendingDispersalsPath
+	PendingDispersalsRedisKey string
+	// interval of the probes of the disperser's max blob size
+	MaxBlobSizeNegotiationInterval time.Duration
+	// shared status poller
+	StatusQueryQPS         float64
+	StatusQueryParallelism int
+	// TLS config of the disperser connection, replaces the default one of the EigenDA client if set
+	DisperserTLS utils.TLSConfig
+	// HTTP bridge replacing the native gRPC connection to the disperser if set
+	DisperserBridge eigenda.BridgeConfig
+	// endpoints of the disperser requests fail over between, if more than one is configured
+	DisperserRPCs []string
+	// retriever services blobs are read from when the disperser fails to serve them
+	RetrieverRPCs       []string
+	RetrieverDisableTLS bool
+	// decode retrieved blobs failing to decode in the other point verification mode
+	PointVerificationModeFallback bool
+
 	MemstoreEnabled bool
 	MemstoreConfig  memstore.Config
 
 	// routing
 	FallbackTargets []string
 	CacheTargets    []string
+	KeccakBackend   string
+	TargetRoles     []string
+	PrefixRoutes    []string
+	Quotas          []string
+	QuotaPolicy     string
+	QuotaUsagePath  string
+	DeadLetterPath  string
+	// background re-drives of the dead-lettered writes, disabled if the interval is 0
+	DeadLetterRetryInterval time.Duration
+	DeadLetterMaxBackoff    time.Duration
+	// reconciliation of the commitment index with the secondary targets
+	ReconcileInterval   time.Duration
+	ReconcileSampleSize int
+	ReconcileRepair     string
+	// serve concurrent reads of a commitment with a single backend fetch
+	CoalesceReads bool
+	// resolve blobs of stale certs via their current cert from the disperser
+	RefreshStaleCerts bool
+	// EigenDA read latency after which fallbacks are read in parallel
+	ReadSLO time.Duration
+	// delay after which EigenDA is read while cache reads are in flight
+	HedgeDelay time.Duration
+	// wrap blobs written to cache and fallback targets with integrity metadata
+	Envelope bool
+	// interval of health pings keeping backend connections warm
+	WarmPoolInterval time.Duration
+	// automatic degradation of the write path while dispersals keep failing
+	Degradation store.DegradationOptions
+	// circuit breakers of the cache and fallback targets
+	Breakers store.BreakerOptions
+	// moves blobs between the cache targets, fallback targets and archive storage, disabled if the interval is 0
+	Tiering store.TieringOptions
+	// recording of the reads of the indexed blobs
+	AccessStats index.AccessOptions
+	// splitting of payloads too large for a single blob
+	Chunking store.ChunkingOptions
+
+	// snapshot archive restored on startup
+	SnapshotRestoreFile string
 
 	// secondary storage
 	RedisConfig redis.Config
 	S3Config    s3.Config
+	FSConfig    fs.Config
+	GCSConfig   gcs.Config
+	AzureConfig azure.Config
+	// two-tier memory and disk cache, usable as cache target only
+	LocalCacheConfig localcache.Config
+
+	BackfillConfig backfill.Config
+	StandbyConfig  standby.Config
+	// namespaces dispersing with their own quorums and signer key, and storing their blobs under their own S3 path
+	Tenancy tenancy.Config
 }
 
 // ReadConfig ... parses the Config from the provided flags or environment variables.
 func ReadConfig(ctx *cli.Context) Config {
 	return Config{
-		RedisConfig:     redis.ReadConfig(ctx),
-		S3Config:        s3.ReadConfig(ctx),
-		EdaClientConfig: eigendaflags.ReadConfig(ctx),
-		VerifierConfig:  verify.ReadConfig(ctx),
-		MemstoreEnabled: ctx.Bool(memstore.EnabledFlagName),
-		MemstoreConfig:  memstore.ReadConfig(ctx),
-		FallbackTargets: ctx.StringSlice(flags.FallbackTargetsFlagName),
-		CacheTargets:    ctx.StringSlice(flags.CacheTargetsFlagName),
+		RedisConfig:                    redis.ReadConfig(ctx),
+		S3Config:                       s3.ReadConfig(ctx),
+		FSConfig:                       fs.ReadConfig(ctx),
+		GCSConfig:                      gcs.ReadConfig(ctx),
+		AzureConfig:                    azure.ReadConfig(ctx),
+		LocalCacheConfig:               localcache.ReadConfig(ctx),
+		EdaClientConfig:                eigendaflags.ReadConfig(ctx),
+		VerifierConfig:                 verify.ReadConfig(ctx),
+		SignerPrivateKeyFile:           ctx.String(eigendaflags.SignerPrivateKeyFileFlagName),
+		PrevalidateCommitment:          ctx.Bool(eigendaflags.PrevalidateCommitmentFlagName),
+		PendingDispersalsPath:          ctx.String(eigendaflags.PendingDispersalsPathFlagName),
+		PendingDispersalsRetryInterval: ctx.Duration(eigendaflags.PendingDispersalsRetryFlagName),
+		PendingDispersalsMaxAge:        ctx.Duration(eigendaflags.PendingDispersalsMaxAgeFlagName),
+		PendingDispersalsRedisKey:      ctx.String(eigendaflags.PendingDispersalsRedisKeyFlagName),
+		CustomQuorumFallbackAfter:      ctx.Int(eigendaflags.CustomQuorumFallbackAfterFlagName),
+		MaxBlobSizeNegotiationInterval: ctx.Duration(eigendaflags.MaxBlobSizeNegotiationFlagName),
+		StatusQueryQPS:                 ctx.Float64(eigendaflags.StatusQueryQPSFlagName),
+		StatusQueryParallelism:         ctx.Int(eigendaflags.StatusQueryParallelismFlagName),
+		DisperserTLS:                   utils.ReadTLSConfig(ctx, eigendaflags.DisperserTLSFlagPrefix),
+		DisperserBridge: eigenda.BridgeConfig{
+			URL:      ctx.String(eigendaflags.DisperserBridgeURLFlagName),
+			Protocol: eigenda.BridgeProtocol(ctx.String(eigendaflags.DisperserBridgeProtocolFlagName)),
+		},
+		DisperserRPCs:                 eigendaflags.ReadDisperserRPCs(ctx),
+		RetrieverRPCs:                 ctx.StringSlice(eigendaflags.RetrieverRPCsFlagName),
+		RetrieverDisableTLS:           ctx.Bool(eigendaflags.RetrieverDisableTLSFlagName),
+		PointVerificationModeFallback: ctx.Bool(eigendaflags.PointVerificationModeFallbackFlagName),
+		MemstoreEnabled:               ctx.Bool(memstore.EnabledFlagName),
+		MemstoreConfig:                memstore.ReadConfig(ctx),
+		FallbackTargets:               ctx.StringSlice(flags.FallbackTargetsFlagName),
+		CacheTargets:                  ctx.StringSlice(flags.CacheTargetsFlagName),
+		KeccakBackend:                 ctx.String(flags.KeccakBackendFlagName),
+		TargetRoles:                   ctx.StringSlice(flags.TargetRolesFlagName),
+		PrefixRoutes:                  ctx.StringSlice(flags.PrefixRoutesFlagName),
+		Quotas:                        ctx.StringSlice(flags.QuotasFlagName),
+		QuotaPolicy:                   ctx.String(flags.QuotaPolicyFlagName),
+		QuotaUsagePath:                ctx.String(flags.QuotaUsagePathFlagName),
+		DeadLetterPath:                ctx.String(flags.DeadLetterPathFlagName),
+		DeadLetterRetryInterval:       ctx.Duration(flags.DeadLetterRetryIntervalFlagName),
+		DeadLetterMaxBackoff:          ctx.Duration(flags.DeadLetterMaxBackoffFlagName),
+		ReconcileInterval:             ctx.Duration(flags.ReconcileIntervalFlagName),
+		ReconcileSampleSize:           ctx.Int(flags.ReconcileSampleSizeFlagName),
+		ReconcileRepair:               ctx.String(flags.ReconcileRepairFlagName),
+		CoalesceReads:                 ctx.Bool(flags.CoalesceReadsFlagName),
+		RefreshStaleCerts:             ctx.Bool(flags.RefreshStaleCertsFlagName),
+		ReadSLO:                       ctx.Duration(flags.ReadSLOFlagName),
+		HedgeDelay:                    ctx.Duration(flags.HedgeDelayFlagName),
+		Envelope:                      ctx.Bool(flags.EnvelopeFlagName),
+		WarmPoolInterval:              ctx.Duration(flags.WarmPoolIntervalFlagName),
+		Degradation: store.DegradationOptions{
+			Mode:          store.DegradedMode(ctx.String(flags.DegradationModeFlagName)),
+			ErrorRate:     ctx.Float64(flags.DegradationErrorRateFlagName),
+			MinDispersals: ctx.Int(flags.DegradationMinDispersalsFlagName),
+			Window:        ctx.Duration(flags.DegradationWindowFlagName),
+			ProbeInterval: ctx.Duration(flags.DegradationProbeIntervalFlagName),
+			HealthyProbes: ctx.Int(flags.DegradationHealthyProbesFlagName),
+		},
+		Tiering: store.TieringOptions{
+			Interval:     ctx.Duration(flags.TieringIntervalFlagName),
+			DemoteAfter:  ctx.Duration(flags.TieringDemoteAfterFlagName),
+			ArchiveAfter: ctx.Duration(flags.TieringArchiveAfterFlagName),
+			PromoteReads: ctx.Int(flags.TieringPromoteReadsFlagName),
+			BatchSize:    ctx.Int(flags.TieringBatchSizeFlagName),
+		},
+		AccessStats: index.AccessOptions{
+			SampleRate: ctx.Float64(flags.AccessStatsSampleRateFlagName),
+			Window:     ctx.Duration(flags.AccessStatsWindowFlagName),
+		},
+		Breakers: store.BreakerOptions{
+			FailureThreshold:  ctx.Int(flags.BreakerFailureThresholdFlagName),
+			OpenDuration:      ctx.Duration(flags.BreakerOpenDurationFlagName),
+			HalfOpenSuccesses: ctx.Int(flags.BreakerHalfOpenSuccessesFlagName),
+			FlapWindow:        ctx.Duration(flags.BreakerFlapWindowFlagName),
+			FlapThreshold:     ctx.Int(flags.BreakerFlapThresholdFlagName),
+			FlapOpenDuration:  ctx.Duration(flags.BreakerFlapOpenDurationFlagName),
+		},
+		Chunking:            readChunkingOptions(ctx),
+		SnapshotRestoreFile: ctx.String(flags.SnapshotRestoreFileFlagName),
+		BackfillConfig:      backfill.ReadConfig(ctx),
+		StandbyConfig:       standby.ReadConfig(ctx),
+		Tenancy:             tenancy.ReadConfig(ctx),
+	}
+}
+
+// readChunkingOptions ... parses the chunking options, whose sizes have already been validated by their flags' action
+func readChunkingOptions(ctx *cli.Context) store.ChunkingOptions {
+	maxSize, _ := utils.ParseBytesAmount(ctx.String(flags.ChunkingMaxSizeFlagName))
+	minSize, _ := utils.ParseBytesAmount(ctx.String(flags.ChunkingMinSizeFlagName))
+	return store.ChunkingOptions{
+		MinSize:       int(minSize), // #nosec G115
+		MaxSize:       int(maxSize), // #nosec G115
+		TargetLatency: ctx.Duration(flags.ChunkingTargetLatencyFlagName),
+		Window:        ctx.Duration(flags.ChunkingWindowFlagName),
 	}
 }
 
@@ -67,12 +245,258 @@ func (cfg *Config) checkTargets(targets []string) error {
 	return nil
 }
 
+// keccakBackendType ... backend type of the precomputed-key backend of keccak256 commitments, S3 if unset
+func (cfg *Config) keccakBackendType() store.BackendType {
+	if cfg.KeccakBackend == "" {
+		return store.S3BackendType
+	}
+	return store.StringToBackendType(cfg.KeccakBackend)
+}
+
+// checkKeccakBackend ... checks that keccak256 commitments are stored by a precomputed-key backend whose keys are
+// the hash algorithm of the commitments
+func (cfg *Config) checkKeccakBackend() error {
+	switch b := cfg.keccakBackendType(); b {
+	case store.S3BackendType:
+		return nil
+	case store.RedisBackendType, store.FSBackendType, store.GCSBackendType, store.AzureBackendType,
+		store.LocalBackendType:
+		// only S3 verifies hash algorithms other than keccak256
+		h, err := commitments.StringToHashAlgorithm(string(cfg.S3Config.HashAlgorithm))
+		if err == nil && h != commitments.Keccak256Hash {
+			return fmt.Errorf("s3 hash algorithm %s requires keccak256 commitments to be stored in S3, not %s", h, b)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown keccak256 backend %s", cfg.KeccakBackend)
+	}
+}
+
+// ParseTargetRoles ... parses the backend=role pairs into per backend target roles
+func (cfg *Config) ParseTargetRoles() (map[store.BackendType]store.TargetRole, error) {
+	roles := make(map[store.BackendType]store.TargetRole, len(cfg.TargetRoles))
+	for _, tr := range cfg.TargetRoles {
+		name, raw, ok := strings.Cut(tr, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid target role %s, expected backend=role", tr)
+		}
+		if !utils.Contains(cfg.CacheTargets, name) && !utils.Contains(cfg.FallbackTargets, name) {
+			return nil, fmt.Errorf("role provided for %s, but it is not a cache or fallback target", name)
+		}
+
+		role, err := store.ParseTargetRole(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role for %s: %w", name, err)
+		}
+		roles[store.StringToBackendType(name)] = role
+	}
+
+	return roles, nil
+}
+
+// ParseQuotas ... parses the backend=size quota pairs into per backend quotas
+func (cfg *Config) ParseQuotas() (map[store.BackendType]store.Quota, error) {
+	if len(cfg.Quotas) == 0 {
+		return map[store.BackendType]store.Quota{}, nil
+	}
+
+	policy := store.QuotaPolicy(cfg.QuotaPolicy)
+	if policy != store.QuotaPolicyReject && policy != store.QuotaPolicyEvict {
+		return nil, fmt.Errorf("unknown quota policy provided: %s", cfg.QuotaPolicy)
+	}
+
+	quotas := make(map[store.BackendType]store.Quota, len(cfg.Quotas))
+	for _, q := range cfg.Quotas {
+		name, size, ok := strings.Cut(q, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid quota %s, expected backend=size", q)
+		}
+
+		b := store.StringToBackendType(name)
+		if b != store.RedisBackendType && b != store.S3BackendType && b != store.FSBackendType &&
+			b != store.GCSBackendType && b != store.AzureBackendType {
+			return nil, fmt.Errorf("quotas are not supported for backend %s", name)
+		}
+		if !utils.Contains(cfg.CacheTargets, name) && !utils.Contains(cfg.FallbackTargets, name) {
+			return nil, fmt.Errorf("quota provided for %s, but it is not a cache or fallback target", name)
+		}
+
+		maxBytes, err := utils.ParseBytesAmount(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quota size for %s: %w", name, err)
+		}
+		quotas[b] = store.Quota{MaxBytes: int64(maxBytes), Policy: policy} // #nosec G115
+	}
+
+	return quotas, nil
+}
+
+// PrefixRouteConfig ... a prefix route, whose pipeline lists the names of the backends it reads from
+type PrefixRouteConfig struct {
+	Prefix   []byte
+	Pipeline []string
+}
+
+// ParsePrefixRoutes ... parses the prefix=pipeline routes, where pipeline is 'default' or backend names joined by
+// '+', e.g. 0x010000=s3 or 0x01=eigenda+s3
+func (cfg *Config) ParsePrefixRoutes() ([]PrefixRouteConfig, error) {
+	routes := make([]PrefixRouteConfig, 0, len(cfg.PrefixRoutes))
+	seen := make(map[string]bool, len(cfg.PrefixRoutes))
+	for _, pr := range cfg.PrefixRoutes {
+		rawPrefix, rawPipeline, ok := strings.Cut(pr, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid prefix route %s, expected prefix=pipeline", pr)
+		}
+		prefix, err := hex.DecodeString(strings.TrimPrefix(rawPrefix, "0x"))
+		if err != nil || len(prefix) == 0 {
+			return nil, fmt.Errorf("invalid prefix route %s: prefix must be a non-empty hex string", pr)
+		}
+		if seen[string(prefix)] {
+			return nil, fmt.Errorf("duplicate prefix route provided: %s", rawPrefix)
+		}
+		seen[string(prefix)] = true
+
+		route := PrefixRouteConfig{Prefix: prefix}
+		if rawPipeline != "default" {
+			route.Pipeline = strings.Split(rawPipeline, "+")
+			if utils.ContainsDuplicates(route.Pipeline) {
+				return nil, fmt.Errorf("invalid prefix route %s: duplicate backends in pipeline", pr)
+			}
+		}
+		for _, name := range route.Pipeline {
+			switch store.StringToBackendType(name) {
+			case store.EigenDABackendType:
+			case store.S3BackendType, store.RedisBackendType, store.FSBackendType, store.GCSBackendType,
+				store.AzureBackendType:
+			case store.LocalBackendType:
+				if !utils.Contains(cfg.CacheTargets, name) {
+					return nil, fmt.Errorf("invalid prefix route %s: local cache is not a cache target", pr)
+				}
+			default:
+				return nil, fmt.Errorf("invalid prefix route %s: unknown backend %s", pr, name)
+			}
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// checkGCS ... validates the GCS backend's credentials
+func (cfg *Config) checkGCS() error {
+	gcsCfg := cfg.GCSConfig
+	if gcsCfg.Bucket == "" {
+		if gcsCfg.CredentialsFile != "" {
+			return fmt.Errorf("gcs credentials file is set, but bucket is not")
+		}
+		return nil
+	}
+
+	switch gcsCfg.CredentialType {
+	case gcs.CredentialTypeServiceAccount:
+		if gcsCfg.CredentialsFile == "" {
+			return fmt.Errorf("gcs credential type is service-account, but credentials file is not set")
+		}
+	case gcs.CredentialTypeWorkloadIdentity, gcs.CredentialTypeAnonymous:
+		if gcsCfg.CredentialsFile != "" {
+			return fmt.Errorf("gcs credentials file is only used with the service-account credential type")
+		}
+	case gcs.CredentialTypeUnknown:
+		fallthrough
+	default:
+		return fmt.Errorf("gcs credential type must be one of service-account, workload-identity or anonymous")
+	}
+	return nil
+}
+
+// checkAzure ... validates the Azure backend's credentials
+func (cfg *Config) checkAzure() error {
+	azureCfg := cfg.AzureConfig
+	if azureCfg.Container == "" {
+		if azureCfg.ConnectionString != "" || azureCfg.AccountURL != "" {
+			return fmt.Errorf("azure credentials are set, but container is not")
+		}
+		return nil
+	}
+
+	switch azureCfg.CredentialType {
+	case azure.CredentialTypeConnectionString:
+		if azureCfg.ConnectionString == "" {
+			return fmt.Errorf("azure credential type is connection-string, but connection string is not set")
+		}
+		if azureCfg.AccountURL != "" || azureCfg.ManagedIdentityClientID != "" {
+			return fmt.Errorf("azure account url and managed identity client id are only used with the " +
+				"managed-identity credential type")
+		}
+	case azure.CredentialTypeManagedIdentity:
+		if azureCfg.AccountURL == "" {
+			return fmt.Errorf("azure credential type is managed-identity, but account url is not set")
+		}
+		if azureCfg.ConnectionString != "" {
+			return fmt.Errorf("azure connection string is only used with the connection-string credential type")
+		}
+	case azure.CredentialTypeUnknown:
+		fallthrough
+	default:
+		return fmt.Errorf("azure credential type must be one of connection-string or managed-identity")
+	}
+	return nil
+}
+
+// checkTLS ... validates the TLS config of each backend connection
+func (cfg *Config) checkTLS() error {
+	tlsCfgs := []struct {
+		name    string
+		tls     utils.TLSConfig
+		enabled bool
+	}{
+		{"disperser", cfg.DisperserTLS, !cfg.EdaClientConfig.DisableTLS},
+		{"eth rpc", cfg.VerifierConfig.RPCTLS, true},
+		{"s3", cfg.S3Config.TLS, cfg.S3Config.EnableTLS},
+		{"redis", cfg.RedisConfig.TLS, cfg.RedisConfig.EnableTLS},
+	}
+	for _, c := range tlsCfgs {
+		if !c.tls.IsSet() {
+			continue
+		}
+		if !c.enabled {
+			return fmt.Errorf("%s tls settings are set, but tls is disabled", c.name)
+		}
+		if err := c.tls.Check(); err != nil {
+			return fmt.Errorf("invalid %s tls config: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
 // Check ... verifies that configuration values are adequately set
 func (cfg *Config) Check() error {
 	if !cfg.MemstoreEnabled {
-		if cfg.EdaClientConfig.RPC == "" {
+		if cfg.EdaClientConfig.RPC == "" && cfg.DisperserBridge.URL == "" {
 			return fmt.Errorf("using eigenda backend (memstore.enabled=false) but eigenda disperser rpc url is not set")
 		}
+		if cfg.StatusQueryQPS < 0 {
+			return fmt.Errorf("status query qps must not be negative")
+		}
+		if cfg.StatusQueryParallelism < 1 {
+			return fmt.Errorf("status query parallelism must be at least 1")
+		}
+	}
+
+	if err := cfg.checkTLS(); err != nil {
+		return err
+	}
+
+	if cfg.MemstoreConfig.Replication.Role != memstore.RoleStandalone {
+		if !cfg.MemstoreEnabled {
+			return fmt.Errorf("memstore replication role is set, but memstore is not enabled")
+		}
+		if cfg.MemstoreConfig.Replication.Role == memstore.RoleReplica && cfg.MemstoreConfig.Replication.PrimaryURL == "" {
+			return fmt.Errorf("memstore replication role is replica, but primary url is not set")
+		}
+	}
+
+	if cfg.EdaClientConfig.SignerPrivateKeyHex != "" && cfg.SignerPrivateKeyFile != "" {
+		return fmt.Errorf("only one of signer private key hex and signer private key file can be set")
 	}
 
 	// cert verification is enabled
@@ -87,20 +511,83 @@ func (cfg *Config) Check() error {
 		if cfg.VerifierConfig.SvcManagerAddr == "" {
 			return fmt.Errorf("cert verification enabled but svc manager address is not set")
 		}
+		switch cfg.VerifierConfig.SvcManagerCheck {
+		// warn if unset
+		case "", verify.SvcManagerCheckWarn, verify.SvcManagerCheckFail, verify.SvcManagerCheckOff:
+		default:
+			return fmt.Errorf("unknown svc manager check provided: %s", cfg.VerifierConfig.SvcManagerCheck)
+		}
+	}
+
+	if cfg.VerifierConfig.AllowMissingSRS && !cfg.VerifierConfig.VerifyCerts {
+		return fmt.Errorf("allowing missing srs requires cert verification to be enabled")
+	}
+
+	if len(cfg.VerifierConfig.ReadQuorums) > 0 {
+		if !cfg.VerifierConfig.VerifyCerts {
+			return fmt.Errorf("read quorum policy requires cert verification to be enabled")
+		}
+		if _, err := verify.NewQuorumPolicy(cfg.VerifierConfig.ReadQuorums); err != nil {
+			return fmt.Errorf("invalid read quorum policy: %w", err)
+		}
 	}
 
-	if cfg.S3Config.CredentialType == s3.CredentialTypeUnknown && cfg.S3Config.Endpoint != "" {
+	if cfg.S3Config.Endpoint != "" && cfg.S3Config.EndpointDiscovery != "" {
+		return fmt.Errorf("only one of s3 endpoint and s3 endpoint discovery can be set")
+	}
+	if cfg.S3Config.EndpointDiscovery != "" {
+		if err := utils.CheckDiscoverySource(cfg.S3Config.EndpointDiscovery); err != nil {
+			return err
+		}
+	}
+	s3Endpoint := cfg.S3Config.Endpoint != "" || cfg.S3Config.EndpointDiscovery != ""
+	if cfg.S3Config.CredentialType == s3.CredentialTypeUnknown && s3Endpoint {
 		return fmt.Errorf("s3 credential type must be set")
 	}
 	if cfg.S3Config.CredentialType == s3.CredentialTypeStatic {
-		if cfg.S3Config.Endpoint != "" && (cfg.S3Config.AccessKeyID == "" || cfg.S3Config.AccessKeySecret == "") {
+		if cfg.S3Config.AccessKeySecret != "" && cfg.S3Config.AccessKeySecretFile != "" {
+			return fmt.Errorf("only one of s3 access key secret and access key secret file can be set")
+		}
+		noSecret := cfg.S3Config.AccessKeySecret == "" && cfg.S3Config.AccessKeySecretFile == ""
+		if s3Endpoint && (cfg.S3Config.AccessKeyID == "" || noSecret) {
 			return fmt.Errorf("s3 endpoint is set, but access key id or access key secret is not set")
 		}
 	}
+	if cfg.S3Config.HealthCheckInterval != 0 && cfg.S3Config.HealthCheckInterval < time.Second {
+		return fmt.Errorf("s3 health check interval must be at least 1s, got %s", cfg.S3Config.HealthCheckInterval)
+	}
+	if _, err := commitments.StringToHashAlgorithm(string(cfg.S3Config.HashAlgorithm)); err != nil {
+		return fmt.Errorf("invalid s3 hash algorithm: %w", err)
+	}
+	if cfg.S3Config.RestoreDays < 0 {
+		return fmt.Errorf("s3 restore days must not be negative")
+	}
 
-	if cfg.RedisConfig.Endpoint == "" && cfg.RedisConfig.Password != "" {
+	if cfg.RedisConfig.Password != "" && cfg.RedisConfig.PasswordFile != "" {
+		return fmt.Errorf("only one of redis password and redis password file can be set")
+	}
+	if cfg.RedisConfig.Endpoint != "" && cfg.RedisConfig.EndpointDiscovery != "" {
+		return fmt.Errorf("only one of redis endpoint and redis endpoint discovery can be set")
+	}
+	if cfg.RedisConfig.EndpointDiscovery != "" {
+		if err := utils.CheckDiscoverySource(cfg.RedisConfig.EndpointDiscovery); err != nil {
+			return err
+		}
+	}
+	redisEndpoint := cfg.RedisConfig.Endpoint != "" || cfg.RedisConfig.EndpointDiscovery != ""
+	if !redisEndpoint && (cfg.RedisConfig.Password != "" || cfg.RedisConfig.PasswordFile != "") {
 		return fmt.Errorf("redis password is set, but endpoint is not")
 	}
+	if !redisEndpoint && cfg.PendingDispersalsRedisKey != "" {
+		return fmt.Errorf("pending dispersals redis key is set, but redis endpoint is not")
+	}
+
+	if err := cfg.checkGCS(); err != nil {
+		return err
+	}
+	if err := cfg.checkAzure(); err != nil {
+		return err
+	}
 
 	err := cfg.checkTargets(cfg.FallbackTargets)
 	if err != nil {
@@ -119,12 +606,139 @@ func (cfg *Config) Check() error {
 		}
 	}
 
+	if utils.Contains(cfg.FallbackTargets, "local") {
+		return fmt.Errorf("local cache can only be used as a cache target")
+	}
+	if err := cfg.checkKeccakBackend(); err != nil {
+		return err
+	}
+	if cfg.ReadSLO < 0 {
+		return fmt.Errorf("read slo must not be negative")
+	}
+	if cfg.ReadSLO > 0 && len(cfg.FallbackTargets) == 0 {
+		return fmt.Errorf("read slo requires fallback targets")
+	}
+	if cfg.HedgeDelay < 0 {
+		return fmt.Errorf("hedge delay must not be negative")
+	}
+	if utils.Contains(cfg.CacheTargets, "local") && cfg.LocalCacheConfig.DiskPath != "" &&
+		cfg.LocalCacheConfig.DiskPath == cfg.FSConfig.Path {
+		return fmt.Errorf("local cache disk path must differ from the filesystem backend path")
+	}
+
+	if cfg.FSConfig.CompactionInterval < 0 {
+		return fmt.Errorf("fs compaction interval must not be negative")
+	}
+	if cfg.FSConfig.MaxBytes > 0 && cfg.FSConfig.CompactionInterval == 0 {
+		return fmt.Errorf("fs max bytes is set, but compaction is disabled")
+	}
+	if cfg.CustomQuorumFallbackAfter < 0 {
+		return fmt.Errorf("custom quorum fallback after must not be negative")
+	}
+	if cfg.CustomQuorumFallbackAfter > 0 && len(cfg.EdaClientConfig.CustomQuorumIDs) == 0 {
+		return fmt.Errorf("custom quorum fallback is set, but no custom quorums are configured")
+	}
+	if cfg.PendingDispersalsMaxAge < 0 {
+		return fmt.Errorf("pending dispersals max age must not be negative")
+	}
+	if cfg.PendingDispersalsMaxAge > 0 && cfg.PendingDispersalsRetryInterval == 0 {
+		return fmt.Errorf("pending dispersals max age is set, but background retries are disabled")
+	}
+	if cfg.DisperserBridge.URL != "" {
+		if _, err := eigenda.ParseBridgeProtocol(string(cfg.DisperserBridge.Protocol)); err != nil {
+			return err
+		}
+		if len(cfg.DisperserRPCs) > 0 {
+			return fmt.Errorf("disperser endpoints can't be failed over between when reaching the disperser through a bridge")
+		}
+	}
+	if utils.ContainsDuplicates(cfg.DisperserRPCs) || utils.Contains(cfg.DisperserRPCs, "") {
+		return fmt.Errorf("duplicate or empty disperser endpoints provided: %+v", cfg.DisperserRPCs)
+	}
+	if cfg.MaxBlobSizeNegotiationInterval < 0 {
+		return fmt.Errorf("max blob size negotiation interval must not be negative")
+	}
+
+	if _, err := cfg.ParseTargetRoles(); err != nil {
+		return err
+	}
+	if _, err := cfg.ParseQuotas(); err != nil {
+		return err
+	}
+	if cfg.QuotaUsagePath != "" && len(cfg.Quotas) == 0 {
+		return fmt.Errorf("quota usage path provided, but no quotas are configured")
+	}
+	if _, err := cfg.ParsePrefixRoutes(); err != nil {
+		return err
+	}
+
+	if cfg.DeadLetterRetryInterval < 0 {
+		return fmt.Errorf("dead-letter retry interval must not be negative")
+	}
+	if cfg.DeadLetterRetryInterval > 0 && cfg.DeadLetterMaxBackoff < cfg.DeadLetterRetryInterval {
+		return fmt.Errorf("dead-letter max backoff must be at least the retry interval")
+	}
+	if cfg.ReconcileInterval < 0 {
+		return fmt.Errorf("reconcile interval must not be negative")
+	}
+	if cfg.ReconcileInterval > 0 && cfg.ReconcileSampleSize < 1 {
+		return fmt.Errorf("reconcile sample size must be at least 1")
+	}
+	switch store.ReconcileRepair(cfg.ReconcileRepair) {
+	case "", store.ReconcileRepairNone, store.ReconcileRepairIndex, store.ReconcileRepairBackend:
+	default:
+		return fmt.Errorf("unknown reconcile repair provided: %s", cfg.ReconcileRepair)
+	}
+
+	if cfg.WarmPoolInterval < 0 {
+		return fmt.Errorf("warm pool interval must not be negative")
+	}
+
+	if err := cfg.Degradation.Check(); err != nil {
+		return err
+	}
+	if cfg.Degradation.Mode == store.DegradedModeKeccak && cfg.keccakBackendType() == store.S3BackendType &&
+		(cfg.S3Config.Bucket == "" || !s3Endpoint) {
+		return fmt.Errorf("keccak degraded mode requires an S3 backend")
+	}
+
+	if err := cfg.Breakers.Check(); err != nil {
+		return err
+	}
+	if err := cfg.Tiering.Check(); err != nil {
+		return err
+	}
+	if err := cfg.AccessStats.Check(); err != nil {
+		return err
+	}
+
+	if err := cfg.Chunking.Check(); err != nil {
+		return err
+	}
+
+	if err := cfg.BackfillConfig.Check(); err != nil {
+		return err
+	}
+
+	if err := cfg.StandbyConfig.Check(); err != nil {
+		return err
+	}
+
+	if err := cfg.Tenancy.Check(); err != nil {
+		return err
+	}
+	if len(cfg.Tenancy.S3Paths) > 0 && cfg.S3Config.Bucket == "" {
+		return fmt.Errorf("namespace S3 paths are set but the S3 bucket is not configured")
+	}
+
 	return nil
 }
 
 type CLIConfig struct {
 	EigenDAConfig Config
 	MetricsCfg    opmetrics.CLIConfig
+	TracingCfg    tracing.Config
+	ServerOptions Options
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
@@ -132,6 +746,8 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		EigenDAConfig: config,
 		MetricsCfg:    opmetrics.ReadCLIConfig(ctx),
+		TracingCfg:    tracing.ReadConfig(ctx),
+		ServerOptions: ReadOptions(ctx),
 	}
 }
 
@@ -140,5 +756,26 @@ func (c CLIConfig) Check() error {
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := c.ServerOptions.HTTP2.Check(); err != nil {
+		return err
+	}
+	if err := c.ServerOptions.Mirror.Check(); err != nil {
+		return err
+	}
+	if err := c.ServerOptions.Async.Check(); err != nil {
+		return err
+	}
+	if err := c.ServerOptions.Batch.Check(); err != nil {
+		return err
+	}
+	if err := c.ServerOptions.Priority.Check(); err != nil {
+		return err
+	}
+	if err := c.ServerOptions.CrashReports.Check(); err != nil {
+		return err
+	}
+	if err := c.TracingCfg.Check(); err != nil {
+		return err
+	}
+	return c.ServerOptions.FeatureGates.Check()
 }
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/flags"
+	"github.com/joho/godotenv"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	AdminConfigDiffRoute = AdminRoute + "config/diff"
+
+	// configFormField ... multipart form field of an uploaded candidate config
+	configFormField = "config"
+	// maxConfigSize ... upper bound of a candidate config upload
+	maxConfigSize = 1 << 20
+)
+
+// ConfigChange ... a config field whose value differs between the running and the candidate config. Fields
+// are dotted paths into the redacted CLIConfig.
+type ConfigChange struct {
+	Field     string `json:"field"`
+	Running   any    `json:"running"`
+	Candidate any    `json:"candidate"`
+}
+
+// ConfigDiff ... result of comparing a candidate config against the running one
+type ConfigDiff struct {
+	Valid               bool           `json:"valid"`
+	Errors              []string       `json:"errors,omitempty"`
+	Changes             []ConfigChange `json:"changes"`
+	RunningConfigHash   string         `json:"running_config_hash"`
+	CandidateConfigHash string         `json:"candidate_config_hash,omitempty"`
+}
+
+// SetConfig ... sets the config the proxy is running with, which candidate configs are compared against
+func (svr *Server) SetConfig(cfg CLIConfig) {
+	svr.config = &cfg
+}
+
+// ParseConfig ... reads a config from flag values as if the proxy was started with them. Values are keyed by
+// flag name (e.g. memstore.enabled) or environment variable (e.g. EIGENDA_PROXY_MEMSTORE_ENABLED). Flags
+// that aren't set take their default value, the environment of the running proxy is ignored. The returned
+// config isn't checked.
+func ParseConfig(values map[string]string) (CLIConfig, error) {
+	cliFlags := flags.AllFlags()
+
+	names := make(map[string]string)
+	for _, f := range cliFlags {
+		for _, name := range f.Names() {
+			names[name] = f.Names()[0]
+		}
+		if ef, ok := f.(interface{ GetEnvVars() []string }); ok {
+			for _, env := range ef.GetEnvVars() {
+				names[env] = f.Names()[0]
+			}
+		}
+		clearEnvVars(f)
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	args := []string{"eigenda-proxy"}
+	for _, k := range keys {
+		name, ok := names[strings.TrimLeft(k, "-")]
+		if !ok {
+			return CLIConfig{}, fmt.Errorf("unknown config option %q", k)
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", name, values[k]))
+	}
+
+	var cfg CLIConfig
+	app := &cli.App{
+		Name:      "eigenda-proxy",
+		Flags:     cliFlags,
+		HideHelp:  true,
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+		Action: func(ctx *cli.Context) error {
+			cfg = ReadCLIConfig(ctx)
+			return nil
+		},
+	}
+	if err := app.Run(args); err != nil {
+		return CLIConfig{}, err
+	}
+	return cfg, nil
+}
+
+// clearEnvVars ... unsets the environment variables of a flag, so that parsing a candidate config doesn't
+// pick up the environment of the running proxy
+func clearEnvVars(f cli.Flag) {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	if envVars := v.Elem().FieldByName("EnvVars"); envVars.IsValid() && envVars.CanSet() {
+		envVars.Set(reflect.Zero(envVars.Type()))
+	}
+}
+
+// readConfigValues ... reads the flag values of a candidate config, either a JSON object or an env file
+func readConfigValues(body []byte) (map[string]string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		values, err := godotenv.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse env file: %w", err)
+		}
+		return values, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON config: %w", err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for k, v := range raw {
+		s, err := flagValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %q: %w", k, err)
+		}
+		values[k] = s
+	}
+	return values, nil
+}
+
+// flagValue ... formats a JSON value as a flag value. Lists are joined by commas, like slice flags expect.
+func flagValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return fmt.Sprint(v), nil
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			s, err := flagValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// DiffConfig ... returns the fields of the redacted configs whose values differ, sorted by field. Secrets are
+// compared after redaction, so only setting or unsetting a secret is reported.
+func DiffConfig(running, candidate CLIConfig) ([]ConfigChange, error) {
+	r, err := flattenConfig(running)
+	if err != nil {
+		return nil, err
+	}
+	c, err := flattenConfig(candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]struct{}, len(r))
+	for f := range r {
+		fields[f] = struct{}{}
+	}
+	for f := range c {
+		fields[f] = struct{}{}
+	}
+
+	changes := []ConfigChange{}
+	for f := range fields {
+		if !reflect.DeepEqual(r[f], c[f]) {
+			changes = append(changes, ConfigChange{Field: f, Running: r[f], Candidate: c[f]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes, nil
+}
+
+// flattenConfig ... maps the dotted path of every leaf of the redacted config to its JSON value
+func flattenConfig(cfg CLIConfig) (map[string]any, error) {
+	b, err := json.Marshal(cfg.Redacted())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	leaves := make(map[string]any)
+	var flatten func(path string, v any)
+	flatten = func(path string, v any) {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			leaves[path] = v
+			return
+		}
+		for k, child := range obj {
+			if path != "" {
+				k = path + "." + k
+			}
+			flatten(k, child)
+		}
+	}
+	flatten("", v)
+	return leaves, nil
+}
+
+// HandleConfigDiff ... compares a candidate config against the running one and validates it, without
+// applying it. The candidate is either a JSON object of flag values or an env file, sent as the request
+// body or uploaded as the "config" field of a multipart form.
+// Example: curl -X POST --data-binary @.env http://127.0.0.1:3100/admin/config/diff
+func (svr *Server) HandleConfigDiff(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	if svr.config == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return fmt.Errorf("running config is not available")
+	}
+
+	body, err := readConfigUpload(w, r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return err
+	}
+	values, err := readConfigValues(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return err
+	}
+
+	runningHash, err := svr.config.Fingerprint()
+	if err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+	diff := ConfigDiff{Changes: []ConfigChange{}, RunningConfigHash: runningHash}
+
+	candidate, err := ParseConfig(values)
+	if err != nil {
+		diff.Errors = append(diff.Errors, err.Error())
+		return svr.writeJSON(w, diff)
+	}
+
+	if diff.Changes, err = DiffConfig(*svr.config, candidate); err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+	if diff.CandidateConfigHash, err = candidate.Fingerprint(); err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+	if err := candidate.Check(); err != nil {
+		diff.Errors = append(diff.Errors, err.Error())
+	}
+	diff.Valid = len(diff.Errors) == 0
+	return svr.writeJSON(w, diff)
+}
+
+// readConfigUpload ... returns the candidate config sent as request body or multipart form upload
+func readConfigUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxConfigSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+		return body, nil
+	}
+
+	f, _, err := r.FormFile(configFormField)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded config: %w", err)
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Run("FlagNamesAndEnvVars", func(t *testing.T) {
+		cfg, err := ParseConfig(map[string]string{
+			"memstore.enabled":                  "true",
+			"EIGENDA_PROXY_MEMSTORE_EXPIRATION": "30m",
+		})
+		require.NoError(t, err)
+		require.True(t, cfg.EigenDAConfig.MemstoreEnabled)
+		require.Equal(t, 30*time.Minute, cfg.EigenDAConfig.MemstoreConfig.BlobExpiration)
+	})
+
+	t.Run("IgnoresProcessEnv", func(t *testing.T) {
+		t.Setenv("EIGENDA_PROXY_MEMSTORE_ENABLED", "true")
+
+		cfg, err := ParseConfig(map[string]string{})
+		require.NoError(t, err)
+		require.False(t, cfg.EigenDAConfig.MemstoreEnabled)
+	})
+
+	t.Run("UnknownOption", func(t *testing.T) {
+		_, err := ParseConfig(map[string]string{"memstore.enabledd": "true"})
+		require.ErrorContains(t, err, "unknown config option")
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		_, err := ParseConfig(map[string]string{"memstore.expiration": "forever"})
+		require.Error(t, err)
+	})
+
+	t.Run("OutOfBounds", func(t *testing.T) {
+		_, err := ParseConfig(map[string]string{"memstore.expiration": "30s"})
+		require.EqualError(t, err, "memstore.expiration must be 0 or ≥ 1m, got 30s")
+
+		_, err = ParseConfig(map[string]string{"local-cache.memory-max-bytes": "256XiB"})
+		require.ErrorContains(t, err, "invalid value \"256XiB\" for local-cache.memory-max-bytes")
+
+		_, err = ParseConfig(map[string]string{"eigenda.max-blob-length": "2GiB"})
+		require.ErrorContains(t, err, "eigenda.max-blob-length must be between 1B and")
+	})
+}
+
+func TestHandleConfigDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	running, err := ParseConfig(map[string]string{"memstore.enabled": "true"})
+	require.NoError(t, err)
+
+	server := NewServer("localhost", 0, mocks.NewMockIRouter(ctrl), log.New(), metrics.NoopMetrics, Options{})
+	server.SetConfig(running)
+
+	diff := func(t *testing.T, contentType string, body []byte) ConfigDiff {
+		req := httptest.NewRequest(http.MethodPost, AdminConfigDiffRoute, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		require.NoError(t, server.HandleConfigDiff(rec, req))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var d ConfigDiff
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &d))
+		return d
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		d := diff(t, "application/json", []byte(`{"memstore.enabled": true, "memstore.expiration": "30m"}`))
+		require.True(t, d.Valid)
+		require.Empty(t, d.Errors)
+		require.Len(t, d.Changes, 1)
+		require.Equal(t, "EigenDAConfig.MemstoreConfig.BlobExpiration", d.Changes[0].Field)
+		require.NotEqual(t, d.RunningConfigHash, d.CandidateConfigHash)
+	})
+
+	t.Run("EnvFileUpload", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, err := mw.CreateFormFile(configFormField, ".env")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte("# same as running\nEIGENDA_PROXY_MEMSTORE_ENABLED=true\n"))
+		require.NoError(t, err)
+		require.NoError(t, mw.Close())
+
+		d := diff(t, mw.FormDataContentType(), body.Bytes())
+		require.True(t, d.Valid)
+		require.Empty(t, d.Changes)
+		require.Equal(t, d.RunningConfigHash, d.CandidateConfigHash)
+	})
+
+	t.Run("InvalidCandidate", func(t *testing.T) {
+		d := diff(t, "text/plain", []byte("EIGENDA_PROXY_MEMSTORE_ENABLED=false\n"))
+		require.False(t, d.Valid)
+		require.Len(t, d.Errors, 1)
+		require.Contains(t, d.Errors[0], "eigenda disperser rpc url is not set")
+		require.NotEmpty(t, d.Changes)
+	})
+
+	t.Run("UnknownOption", func(t *testing.T) {
+		d := diff(t, "application/json", []byte(`{"no-such-flag": 1}`))
+		require.False(t, d.Valid)
+		require.Empty(t, d.Changes)
+		require.Empty(t, d.CandidateConfigHash)
+	})
+
+	t.Run("MalformedBody", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, AdminConfigDiffRoute, bytes.NewReader([]byte(`{"memstore.enabled":`)))
+		rec := httptest.NewRecorder()
+		require.Error(t, server.HandleConfigDiff(rec, req))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -4,13 +4,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/azure"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/gcs"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
 	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,7 +64,9 @@ func validCfg() *Config {
 			RPCURL:               "http://localhost:8545",
 			EthConfirmationDepth: 12,
 		},
-		MemstoreEnabled: true,
+		StatusQueryQPS:         10,
+		StatusQueryParallelism: 4,
+		MemstoreEnabled:        true,
 		MemstoreConfig: memstore.Config{
 			BlobExpiration: 25 * time.Minute,
 		},
@@ -97,6 +106,17 @@ func TestConfigVerification(t *testing.T) {
 			require.Error(t, err)
 		})
 
+		t.Run("UnknownSvcManagerCheck", func(t *testing.T) {
+			cfg := validCfg()
+			cfg.MemstoreEnabled = false
+			cfg.VerifierConfig.VerifyCerts = true
+			cfg.VerifierConfig.SvcManagerCheck = verify.SvcManagerCheckFail
+			require.NoError(t, cfg.Check())
+
+			cfg.VerifierConfig.SvcManagerCheck = "strict"
+			require.Error(t, cfg.Check())
+		})
+
 		t.Run("CantDoCertVerificationWhenMemstoreEnabled", func(t *testing.T) {
 			cfg := validCfg()
 			cfg.MemstoreEnabled = true
@@ -107,6 +127,44 @@ func TestConfigVerification(t *testing.T) {
 		})
 	})
 
+	t.Run("AllowMissingSRS", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.VerifierConfig.AllowMissingSRS = true
+		require.Error(t, cfg.Check())
+
+		cfg.MemstoreEnabled = false
+		cfg.VerifierConfig.VerifyCerts = true
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("ReadQuorums", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.VerifierConfig.ReadQuorums = []uint{0}
+		require.Error(t, cfg.Check())
+
+		cfg.MemstoreEnabled = false
+		cfg.VerifierConfig.VerifyCerts = true
+		require.NoError(t, cfg.Check())
+
+		cfg.VerifierConfig.ReadQuorums = []uint{256}
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("StatusPoller", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.MemstoreEnabled = false
+		cfg.StatusQueryQPS = 0
+		require.NoError(t, cfg.Check())
+
+		cfg.StatusQueryQPS = -1
+		require.Error(t, cfg.Check())
+
+		cfg = validCfg()
+		cfg.MemstoreEnabled = false
+		cfg.StatusQueryParallelism = 0
+		require.Error(t, cfg.Check())
+	})
+
 	t.Run("MissingS3AccessKeys", func(t *testing.T) {
 		cfg := validCfg()
 
@@ -184,6 +242,20 @@ func TestConfigVerification(t *testing.T) {
 		require.Error(t, err)
 	})
 
+	t.Run("LocalCache", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.CacheTargets = []string{"local"}
+		cfg.LocalCacheConfig.DiskPath = "/tmp/local-cache"
+		require.NoError(t, cfg.Check())
+
+		cfg.FSConfig.Path = cfg.LocalCacheConfig.DiskPath
+		require.Error(t, cfg.Check())
+
+		cfg = validCfg()
+		cfg.FallbackTargets = []string{"local"}
+		require.Error(t, cfg.Check())
+	})
+
 	t.Run("BadRedisConfiguration", func(t *testing.T) {
 		cfg := validCfg()
 		cfg.RedisConfig.Endpoint = ""
@@ -191,4 +263,357 @@ func TestConfigVerification(t *testing.T) {
 		err := cfg.Check()
 		require.Error(t, err)
 	})
+
+	t.Run("SignerPrivateKeyHexAndFile", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.SignerPrivateKeyFile = "/var/run/secrets/signer-key"
+
+		err := cfg.Check()
+		require.Error(t, err)
+	})
+
+	t.Run("S3AccessKeySecretFile", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.S3Config.CredentialType = s3.CredentialTypeStatic
+		cfg.S3Config.AccessKeySecret = ""
+		cfg.S3Config.AccessKeySecretFile = "/var/run/secrets/s3-secret"
+
+		err := cfg.Check()
+		require.NoError(t, err)
+
+		cfg.S3Config.AccessKeySecret = "access-key-secret"
+		err = cfg.Check()
+		require.Error(t, err)
+	})
+
+	t.Run("S3HealthCheckInterval", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.S3Config.HealthCheckInterval = 500 * time.Millisecond
+		require.Error(t, cfg.Check())
+
+		cfg.S3Config.HealthCheckInterval = 5 * time.Second
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Chunking", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.Chunking = store.ChunkingOptions{MinSize: 4096, MaxSize: 1024, TargetLatency: time.Minute, Window: time.Minute}
+		require.Error(t, cfg.Check())
+
+		cfg.Chunking.MaxSize = 8192
+		require.NoError(t, cfg.Check())
+
+		cfg.Chunking.Window = 0
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("RedisPasswordAndPasswordFile", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.RedisConfig.PasswordFile = "/var/run/secrets/redis-password"
+
+		err := cfg.Check()
+		require.Error(t, err)
+	})
+
+	t.Run("GCS", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.GCSConfig = gcs.Config{Bucket: "blobs", CredentialType: gcs.CredentialTypeWorkloadIdentity}
+		cfg.FallbackTargets = []string{"gcs"}
+		cfg.Quotas, cfg.QuotaPolicy = []string{"gcs=1GiB"}, "evict"
+		cfg.PrefixRoutes = []string{"0x01=gcs"}
+		require.NoError(t, cfg.Check())
+
+		cfg.GCSConfig.CredentialsFile = "/var/run/secrets/gcs.json"
+		require.Error(t, cfg.Check(), "credentials file is only used with service accounts")
+		cfg.GCSConfig.CredentialType = gcs.CredentialTypeServiceAccount
+		require.NoError(t, cfg.Check())
+
+		cfg.GCSConfig.CredentialsFile = ""
+		require.Error(t, cfg.Check(), "service account requires a credentials file")
+		cfg.GCSConfig.CredentialType = gcs.CredentialTypeUnknown
+		require.Error(t, cfg.Check())
+
+		cfg.GCSConfig = gcs.Config{CredentialsFile: "/var/run/secrets/gcs.json"}
+		require.Error(t, cfg.Check(), "credentials file requires a bucket")
+	})
+
+	t.Run("Azure", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.AzureConfig = azure.Config{
+			Container:      "blobs",
+			CredentialType: azure.CredentialTypeManagedIdentity,
+			AccountURL:     "https://account.blob.core.windows.net",
+		}
+		cfg.CacheTargets = []string{"azure"}
+		cfg.Quotas, cfg.QuotaPolicy = []string{"azure=1GiB"}, "evict"
+		cfg.PrefixRoutes = []string{"0x01=eigenda+azure"}
+		require.NoError(t, cfg.Check())
+
+		cfg.AzureConfig.ConnectionString = "AccountName=account;AccountKey=key"
+		require.Error(t, cfg.Check(), "connection string is only used with the connection-string credential type")
+		cfg.AzureConfig.CredentialType = azure.CredentialTypeConnectionString
+		require.Error(t, cfg.Check(), "account url is only used with managed identities")
+		cfg.AzureConfig.AccountURL = ""
+		require.NoError(t, cfg.Check())
+
+		cfg.AzureConfig.ConnectionString = ""
+		require.Error(t, cfg.Check(), "connection-string requires a connection string")
+		cfg.AzureConfig.CredentialType = azure.CredentialTypeManagedIdentity
+		require.Error(t, cfg.Check(), "managed-identity requires an account url")
+		cfg.AzureConfig.CredentialType = azure.CredentialTypeUnknown
+		require.Error(t, cfg.Check())
+
+		cfg.AzureConfig = azure.Config{ConnectionString: "AccountName=account;AccountKey=key"}
+		require.Error(t, cfg.Check(), "connection string requires a container")
+	})
+
+	t.Run("PrefixRoutes", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.PrefixRoutes = []string{"0x010000=s3", "0x01=eigenda+redis", "0x010001=default"}
+		require.NoError(t, cfg.Check())
+
+		routes, err := cfg.ParsePrefixRoutes()
+		require.NoError(t, err)
+		require.Equal(t, []PrefixRouteConfig{
+			{Prefix: []byte{0x01, 0x00, 0x00}, Pipeline: []string{"s3"}},
+			{Prefix: []byte{0x01}, Pipeline: []string{"eigenda", "redis"}},
+			{Prefix: []byte{0x01, 0x00, 0x01}},
+		}, routes)
+
+		for _, invalid := range [][]string{
+			{"0x010000"},
+			{"=s3"},
+			{"0xzz=s3"},
+			{"0x01=s3", "01=redis"},
+			{"0x01=s3+s3"},
+			{"0x01=postgres"},
+			{"0x01=local"},
+		} {
+			cfg.PrefixRoutes = invalid
+			require.Error(t, cfg.Check(), invalid)
+		}
+	})
+
+	t.Run("ReadSLO", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.ReadSLO = 2 * time.Second
+		require.Error(t, cfg.Check(), "read slo requires fallback targets")
+
+		cfg.FallbackTargets = []string{"s3"}
+		require.NoError(t, cfg.Check())
+
+		cfg.ReadSLO = -time.Second
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("EndpointDiscovery", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.RedisConfig.EndpointDiscovery = "srv://_redis._tcp.cache"
+		require.Error(t, cfg.Check(), "endpoint and endpoint discovery are exclusive")
+
+		cfg.RedisConfig.Endpoint = ""
+		require.NoError(t, cfg.Check())
+
+		cfg.S3Config.Endpoint = ""
+		cfg.S3Config.EndpointDiscovery = "s3.internal:9000"
+		require.Error(t, cfg.Check(), "invalid discovery source")
+
+		cfg.S3Config.EndpointDiscovery = "https://discovery.internal/s3"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("TLS", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.RedisConfig.TLS = utils.TLSConfig{CAFile: "/etc/ssl/private-ca.pem"}
+		require.Error(t, cfg.Check(), "tls settings require tls to be enabled")
+
+		cfg.RedisConfig.EnableTLS = true
+		require.NoError(t, cfg.Check())
+
+		cfg.S3Config.EnableTLS = true
+		cfg.S3Config.TLS = utils.TLSConfig{CertFile: "/etc/ssl/client.pem"}
+		require.Error(t, cfg.Check(), "client certificate requires a key")
+
+		cfg.S3Config.TLS.KeyFile = "/etc/ssl/client-key.pem"
+		require.NoError(t, cfg.Check())
+
+		cfg.EdaClientConfig.DisableTLS = true
+		cfg.DisperserTLS = utils.TLSConfig{ServerName: "disperser.internal"}
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("MemstoreReplication", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.MemstoreConfig.Replication.Role = memstore.RoleReplica
+
+		cfg.MemstoreEnabled = false
+		err := cfg.Check()
+		require.Error(t, err)
+
+		cfg.MemstoreEnabled = true
+		err = cfg.Check()
+		require.Error(t, err)
+
+		cfg.MemstoreConfig.Replication.PrimaryURL = "http://proxy-0:3100"
+		err = cfg.Check()
+		require.NoError(t, err)
+	})
+
+	t.Run("Backfill", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.BackfillConfig.Enabled = true
+
+		err := cfg.Check()
+		require.Error(t, err)
+
+		cfg.BackfillConfig.EthRPC = "http://localhost:8545"
+		cfg.BackfillConfig.BatchInbox = common.HexToAddress("0xff00000000000000000000000000000000042069")
+		err = cfg.Check()
+		require.NoError(t, err)
+
+		cfg.BackfillConfig.StartBlock = 100
+		cfg.BackfillConfig.EndBlock = 10
+		err = cfg.Check()
+		require.Error(t, err)
+	})
+
+	t.Run("Quotas", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.CacheTargets = []string{"redis"}
+		cfg.Quotas = []string{"redis=512MiB"}
+		cfg.QuotaPolicy = "evict"
+
+		quotas, err := cfg.ParseQuotas()
+		require.NoError(t, err)
+		require.Equal(t, int64(512*1024*1024), quotas[store.RedisBackendType].MaxBytes)
+		require.NoError(t, cfg.Check())
+
+		cfg.QuotaUsagePath = "/var/lib/eigenda-proxy/quota-usage.json"
+		require.NoError(t, cfg.Check())
+
+		cfg.QuotaPolicy = "drop"
+		require.Error(t, cfg.Check())
+
+		cfg.QuotaPolicy = "reject"
+		cfg.Quotas = []string{"s3=1GiB"}
+		require.Error(t, cfg.Check())
+
+		cfg.Quotas = []string{"redis"}
+		require.Error(t, cfg.Check())
+
+		cfg.Quotas = nil
+		require.ErrorContains(t, cfg.Check(), "no quotas are configured")
+	})
+
+	t.Run("Compaction", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.FSConfig.MaxBytes = 1024
+		require.Error(t, cfg.Check())
+
+		cfg.FSConfig.CompactionInterval = time.Hour
+		require.NoError(t, cfg.Check())
+
+		cfg.PendingDispersalsMaxAge = 24 * time.Hour
+		cfg.PendingDispersalsRetryInterval = 0
+		require.Error(t, cfg.Check())
+
+		cfg.PendingDispersalsRetryInterval = time.Minute
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("DisperserBridge", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.EdaClientConfig.RPC = ""
+		cfg.MemstoreEnabled = false
+		require.Error(t, cfg.Check())
+
+		cfg.DisperserBridge = eigenda.BridgeConfig{URL: "https://disperser-bridge.example.com", Protocol: eigenda.BridgeProtocolConnect}
+		require.NoError(t, cfg.Check())
+
+		cfg.DisperserBridge.Protocol = "websocket"
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("DisperserFailover", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.DisperserRPCs = []string{"disperser-a.example.com:443", "disperser-b.example.com:443"}
+		require.NoError(t, cfg.Check())
+
+		cfg.DisperserRPCs = []string{"disperser-a.example.com:443", ""}
+		require.Error(t, cfg.Check())
+
+		cfg.DisperserRPCs = []string{"disperser-a.example.com:443", "disperser-b.example.com:443"}
+		cfg.DisperserBridge = eigenda.BridgeConfig{URL: "https://disperser-bridge.example.com", Protocol: eigenda.BridgeProtocolConnect}
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("KeccakBackend", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.KeccakBackend = "redis"
+		require.NoError(t, cfg.Check())
+
+		cfg.KeccakBackend = "eigenda"
+		require.ErrorContains(t, cfg.Check(), "unknown keccak256 backend")
+
+		// only S3 verifies other hash algorithms
+		cfg.KeccakBackend = "fs"
+		cfg.S3Config.HashAlgorithm = commitments.Sha256Hash
+		require.ErrorContains(t, cfg.Check(), "requires keccak256 commitments to be stored in S3")
+	})
+
+	t.Run("TargetRoles", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.CacheTargets = []string{"redis"}
+		cfg.FallbackTargets = []string{"s3"}
+		cfg.TargetRoles = []string{"redis=read-only", "s3=write-only"}
+
+		roles, err := cfg.ParseTargetRoles()
+		require.NoError(t, err)
+		require.Equal(t, store.TargetRoleReadOnly, roles[store.RedisBackendType])
+		require.Equal(t, store.TargetRoleWriteOnly, roles[store.S3BackendType])
+		require.NoError(t, cfg.Check())
+
+		cfg.TargetRoles = []string{"redis=backup"}
+		require.Error(t, cfg.Check())
+
+		cfg.TargetRoles = []string{"fs=read-only"}
+		require.Error(t, cfg.Check())
+
+		cfg.TargetRoles = []string{"redis"}
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("Reconciliation", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.ReconcileInterval = time.Hour
+		cfg.ReconcileSampleSize = 100
+		cfg.ReconcileRepair = "index"
+		require.NoError(t, cfg.Check())
+
+		cfg.ReconcileRepair = "both"
+		require.Error(t, cfg.Check())
+
+		cfg.ReconcileRepair = "backend"
+		cfg.ReconcileSampleSize = 0
+		require.Error(t, cfg.Check())
+	})
+
+	t.Run("Tenancy", func(t *testing.T) {
+		cfg := validCfg()
+		cfg.Tenancy = tenancy.Config{
+			Namespaces: []string{"rollup-a"},
+			Quorums:    []string{"rollup-a=2"},
+			S3Paths:    []string{"rollup-a=rollups/a"},
+			APIKeys:    []string{"rollup-a=key-a"},
+			Burst:      1,
+		}
+		require.NoError(t, cfg.Check())
+
+		cfg.Tenancy.Quorums = []string{"rollup-b=2"}
+		require.ErrorContains(t, cfg.Check(), "not a namespace")
+
+		cfg.Tenancy.Quorums = nil
+		cfg.S3Config.Bucket = ""
+		require.ErrorContains(t, cfg.Check(), "S3 bucket is not configured")
+	})
 }
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CORSConfig ... cross-origin resource sharing configuration for the public GET routes, so that
+// browser-based explorers can fetch blobs directly from the proxy. CORS is disabled when no origins are set.
+type CORSConfig struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+}
+
+// Enabled ... whether any origin is allowed
+func (c CORSConfig) Enabled() bool {
+	return len(c.AllowedOrigins) > 0
+}
+
+func (c CORSConfig) allowOrigin(origin string) (string, bool) {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			return "*", true
+		}
+		if strings.EqualFold(o, origin) {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// exposedHeaders ... response headers readable by browser clients
+var exposedHeaders = strings.Join([]string{
+	BackendHeader,
+	VerificationHeader,
+	BlobSizeHeader,
+	CertReferenceBlockHeader,
+}, ", ")
+
+// securityHeaders ... standard security headers set on public routes. Responses are raw blob bytes
+// and never meant to be rendered or framed by a browser.
+var securityHeaders = map[string]string{
+	"X-Content-Type-Options":  "nosniff",
+	"X-Frame-Options":         "DENY",
+	"Referrer-Policy":         "no-referrer",
+	"Content-Security-Policy": "default-src 'none'; frame-ancestors 'none'",
+}
+
+// explorerSecurityHeaders ... security headers set on the blob explorer, whose page needs to load its own
+// scripts and styles and call the explorer API
+var explorerSecurityHeaders = map[string]string{
+	"X-Content-Type-Options": "nosniff",
+	"X-Frame-Options":        "DENY",
+	"Referrer-Policy":        "no-referrer",
+	"Content-Security-Policy": "default-src 'none'; script-src 'self'; style-src 'self'; connect-src 'self'; " +
+		"frame-ancestors 'none'; base-uri 'none'; form-action 'none'",
+}
+
+// WithSecurityHeaders is a middleware that sets standard security headers on every response.
+func WithSecurityHeaders(handleFn http.HandlerFunc) http.HandlerFunc {
+	return withHeaders(handleFn, securityHeaders)
+}
+
+func withHeaders(handleFn http.HandlerFunc, headers map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range headers {
+			w.Header().Set(k, v)
+		}
+		handleFn(w, r)
+	}
+}
+
+// WithCORS is a middleware that sets CORS headers for allowed origins and answers preflight requests.
+func WithCORS(handleFn http.HandlerFunc, cfg CORSConfig) http.HandlerFunc {
+	if !cfg.Enabled() {
+		return handleFn
+	}
+
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		allowed, ok := cfg.allowOrigin(origin)
+		if origin == "" || !ok {
+			handleFn(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Access-Control-Allow-Origin", allowed)
+		h.Add("Vary", "Origin")
+		h.Set("Access-Control-Expose-Headers", exposedHeaders)
+
+		// preflight request
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Set("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				h.Set("Access-Control-Allow-Headers", headers)
+			}
+			h.Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		handleFn(w, r)
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCORS(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins: []string{"https://explorer.example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodOptions},
+		AllowedHeaders: []string{"Content-Type"},
+	}
+
+	called := false
+	handler := WithCORS(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}, cfg)
+
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		preflight     bool
+		expStatus     int
+		expAllowed    string
+		expHandlerRun bool
+	}{
+		{name: "AllowedOrigin", method: http.MethodGet, origin: "https://explorer.example.com",
+			expStatus: http.StatusOK, expAllowed: "https://explorer.example.com", expHandlerRun: true},
+		{name: "DisallowedOrigin", method: http.MethodGet, origin: "https://evil.example.com",
+			expStatus: http.StatusOK, expHandlerRun: true},
+		{name: "NoOrigin", method: http.MethodGet, expStatus: http.StatusOK, expHandlerRun: true},
+		{name: "Preflight", method: http.MethodOptions, origin: "https://explorer.example.com", preflight: true,
+			expStatus: http.StatusNoContent, expAllowed: "https://explorer.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			req := httptest.NewRequest(tt.method, "/get/0x00", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			require.Equal(t, tt.expStatus, rec.Code)
+			require.Equal(t, tt.expAllowed, rec.Header().Get("Access-Control-Allow-Origin"))
+			require.Equal(t, tt.expHandlerRun, called)
+			if tt.preflight {
+				require.Equal(t, "GET, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+				require.Equal(t, "Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+			}
+			if tt.expAllowed != "" {
+				require.Contains(t, rec.Header().Get("Access-Control-Expose-Headers"), BackendHeader)
+			}
+		})
+	}
+}
+
+func TestWithSecurityHeaders(t *testing.T) {
+	handler := WithSecurityHeaders(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/get/0x00", nil))
+
+	require.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+}
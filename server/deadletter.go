@@ -0,0 +1,65 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+)
+
+const (
+	AdminDeadLettersRoute        = AdminRoute + "dead-letters"
+	AdminDeadLettersRedriveRoute = AdminDeadLettersRoute + "/redrive"
+
+	deadLetterIDParam = "id"
+)
+
+// RedriveResult ... outcome of re-driving a single dead-letter task
+type RedriveResult struct {
+	ID    string `json:"id"`
+	Error string `json:"error,omitempty"`
+}
+
+// HandleDeadLetters ... lists the tasks in the dead-letter queue, oldest first
+func (svr *Server) HandleDeadLetters(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	return svr.writeJSON(w, svr.router.DeadLetters().List())
+}
+
+// HandleDeadLettersRedrive ... re-drives the task with the given id, or every task if no id is provided.
+// Example: POST /admin/dead-letters/redrive?id=0a1b2c3d4e5f6789
+func (svr *Server) HandleDeadLettersRedrive(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	var ids []string
+	if id := r.URL.Query().Get(deadLetterIDParam); id != "" {
+		ids = append(ids, id)
+	} else {
+		for _, t := range svr.router.DeadLetters().List() {
+			ids = append(ids, t.ID)
+		}
+	}
+
+	results := make([]RedriveResult, 0, len(ids))
+	for _, id := range ids {
+		res := RedriveResult{ID: id}
+		if err := svr.router.Redrive(r.Context(), id); err != nil {
+			if len(ids) == 1 && errors.Is(err, deadletter.ErrTaskNotFound) {
+				svr.WriteNotFound(w, err)
+				return err
+			}
+			res.Error = err.Error()
+		}
+		results = append(results, res)
+	}
+
+	return svr.writeJSON(w, results)
+}
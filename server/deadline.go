@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const (
+	// DeadlineHeader ... absolute deadline of a request, as unix seconds or RFC 3339 timestamp
+	DeadlineHeader = "X-Deadline"
+	// TimeoutHeader ... latency budget of a request relative to its arrival, as a duration (e.g. 30s) or seconds
+	TimeoutHeader = "X-Timeout"
+	// ErrorCodeHeader ... machine readable reason of a failed request
+	ErrorCodeHeader = "X-EigenDA-Proxy-Error-Code"
+
+	// ErrCodeDeadlineExceeded ... the deadline set by the caller passed before the request could be served
+	ErrCodeDeadlineExceeded = "deadline_exceeded_upstream"
+)
+
+// ReadDeadline ... returns the deadline of a request set via DeadlineHeader or TimeoutHeader. If both are set,
+// the earlier one applies.
+func ReadDeadline(r *http.Request, now time.Time) (time.Time, bool, error) {
+	var deadline time.Time
+
+	if v := r.Header.Get(DeadlineHeader); v != "" {
+		d, err := parseDeadline(v)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s header %q: %w", DeadlineHeader, v, err)
+		}
+		deadline = d
+	}
+
+	if v := r.Header.Get(TimeoutHeader); v != "" {
+		timeout, err := parseTimeout(v)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s header %q: %w", TimeoutHeader, v, err)
+		}
+		if d := now.Add(timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+
+	return deadline, !deadline.IsZero(), nil
+}
+
+func parseDeadline(v string) (time.Time, error) {
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Parse(time.RFC3339Nano, v)
+}
+
+func parseTimeout(v string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		secs, serr := strconv.ParseFloat(v, 64)
+		if serr != nil {
+			return 0, err
+		}
+		timeout = time.Duration(secs * float64(time.Second))
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("timeout must be positive")
+	}
+	return timeout, nil
+}
+
+// WithDeadline is a middleware that applies the deadline set by the caller to the request context, so that
+// dispersal, verification and storage are abandoned once the caller has given up on the request. Requests
+// whose deadline already passed are rejected upfront.
+func WithDeadline(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		now := time.Now()
+		deadline, ok, err := ReadDeadline(r, now)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return err
+		}
+		if !ok {
+			return handleFn(w, r)
+		}
+		if !now.Before(deadline) {
+			writeDeadlineExceeded(w)
+			return fmt.Errorf("%s: deadline %s passed before the request arrived", ErrCodeDeadlineExceeded,
+				deadline.Format(time.RFC3339Nano))
+		}
+
+		ctx, cancel := context.WithDeadline(r.Context(), deadline)
+		defer cancel()
+		ctx = store.WithCallerDeadline(ctx, deadline)
+		return handleFn(w, r.WithContext(ctx))
+	}
+}
+
+// upstreamDeadlineExceeded ... returns whether the request failed because the deadline set by the caller passed
+func upstreamDeadlineExceeded(ctx context.Context) bool {
+	return !store.RequestMetaFromContext(ctx).Deadline.IsZero() && errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// writeDeadlineExceeded ... responds with a gateway timeout carrying ErrCodeDeadlineExceeded, so that callers can
+// tell their own deadline apart from failures of the proxy and its backends
+func writeDeadlineExceeded(w http.ResponseWriter) {
+	w.Header().Set(ErrorCodeHeader, ErrCodeDeadlineExceeded)
+	w.WriteHeader(http.StatusGatewayTimeout)
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDeadline(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected time.Time
+		ok       bool
+		err      bool
+	}{
+		{name: "None", headers: map[string]string{}},
+		{name: "UnixDeadline", headers: map[string]string{DeadlineHeader: "1700000030"}, expected: now.Add(30 * time.Second), ok: true},
+		{name: "RFC3339Deadline", headers: map[string]string{DeadlineHeader: now.Add(time.Minute).UTC().Format(time.RFC3339)}, expected: now.Add(time.Minute), ok: true},
+		{name: "DurationTimeout", headers: map[string]string{TimeoutHeader: "1m30s"}, expected: now.Add(90 * time.Second), ok: true},
+		{name: "SecondsTimeout", headers: map[string]string{TimeoutHeader: "2.5"}, expected: now.Add(2500 * time.Millisecond), ok: true},
+		{name: "EarliestWins", headers: map[string]string{DeadlineHeader: "1700000030", TimeoutHeader: "10s"}, expected: now.Add(10 * time.Second), ok: true},
+		{name: "InvalidDeadline", headers: map[string]string{DeadlineHeader: "tomorrow"}, err: true},
+		{name: "InvalidTimeout", headers: map[string]string{TimeoutHeader: "soon"}, err: true},
+		{name: "NegativeTimeout", headers: map[string]string{TimeoutHeader: "-5s"}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/put/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+
+			deadline, ok, err := ReadDeadline(req, now)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.ok, ok)
+			require.True(t, tt.expected.Equal(deadline), "expected %s, got %s", tt.expected, deadline)
+		})
+	}
+}
+
+func TestDeadlinePropagation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+	put := WithDeadline(WithMetrics(WithStatus(server.HandlePut, server.status), server.m))
+
+	t.Run("DeadlineExceededDuringDispersal", func(t *testing.T) {
+		mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ commitments.CommitmentMode, _, _ []byte) ([]byte, error) {
+				_, ok := ctx.Deadline()
+				require.True(t, ok)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader([]byte("data")))
+		req.Header.Set(TimeoutHeader, "50ms")
+		rec := httptest.NewRecorder()
+
+		require.Error(t, put(rec, req))
+		require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		require.Equal(t, ErrCodeDeadlineExceeded, rec.Header().Get(ErrorCodeHeader))
+	})
+
+	t.Run("DeadlineAlreadyPassed", func(t *testing.T) {
+		// rejected before reaching the router
+		req := httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader([]byte("data")))
+		req.Header.Set(DeadlineHeader, time.Now().Add(-time.Second).Format(time.RFC3339Nano))
+		rec := httptest.NewRecorder()
+
+		require.Error(t, put(rec, req))
+		require.Equal(t, http.StatusGatewayTimeout, rec.Code)
+		require.Equal(t, ErrCodeDeadlineExceeded, rec.Header().Get(ErrorCodeHeader))
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader([]byte("data")))
+		req.Header.Set(TimeoutHeader, "soon")
+		rec := httptest.NewRecorder()
+
+		require.Error(t, put(rec, req))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("OtherErrorsWithinDeadline", func(t *testing.T) {
+		mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, context.Canceled)
+
+		req := httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader([]byte("data")))
+		req.Header.Set(TimeoutHeader, "1m")
+		rec := httptest.NewRecorder()
+
+		require.Error(t, put(rec, req))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Empty(t, rec.Header().Get(ErrorCodeHeader))
+	})
+}
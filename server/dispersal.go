@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
+)
+
+const (
+	AdminDispersalsRoute       = AdminRoute + "dispersals"
+	AdminDispersalsResumeRoute = AdminDispersalsRoute + "/resume"
+
+	dispersalIDParam = "id"
+)
+
+// pendingDispersalStore ... implemented by EigenDA backends that keep track of dispersals in flight or whose
+// status polling timed out
+type pendingDispersalStore interface {
+	PendingDispersals() []eigenda.PendingDispersal
+	ResumeDispersal(ctx context.Context, id string) error
+}
+
+// ResumeResult ... outcome of resuming a single pending dispersal
+type ResumeResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// pendingDispersals ... returns the EigenDA backend if it tracks pending dispersals
+func (svr *Server) pendingDispersals() (pendingDispersalStore, bool) {
+	s, ok := svr.router.GetEigenDAStore().(pendingDispersalStore)
+	return s, ok
+}
+
+// dispersalJob ... returns the pending dispersal with the given id as a job, e.g. to serve the status of a
+// dispersal a proxy stopped in the middle of after it was recovered on restart
+func (svr *Server) dispersalJob(id string) (Job, bool) {
+	s, ok := svr.pendingDispersals()
+	if !ok {
+		return Job{}, false
+	}
+	for _, p := range s.PendingDispersals() {
+		if p.ID != id {
+			continue
+		}
+		job := Job{ID: p.ID, State: JobDispersing, Error: p.LastError, CreatedAt: p.CreatedAt, UpdatedAt: p.CreatedAt}
+		if p.Status == eigenda.DispersalStatusRecovered {
+			job.State, job.Cert = JobConfirmed, p.Cert
+		}
+		return job, true
+	}
+	return Job{}, false
+}
+
+// HandleDispersals ... lists the dispersals in flight or whose status polling timed out, oldest first
+func (svr *Server) HandleDispersals(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	s, ok := svr.pendingDispersals()
+	if !ok {
+		return svr.writeJSON(w, []eigenda.PendingDispersal{})
+	}
+	return svr.writeJSON(w, s.PendingDispersals())
+}
+
+// HandleDispersalsResume ... queries the disperser for the status of the pending dispersal with the given id,
+// or of every pending dispersal if no id is provided.
+// Example: POST /admin/dispersals/resume?id=0x<keccak256 of the payload>
+func (svr *Server) HandleDispersalsResume(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	s, ok := svr.pendingDispersals()
+	if !ok {
+		err := fmt.Errorf("pending dispersals are not supported by the EigenDA backend")
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	var ids []string
+	if id := r.URL.Query().Get(dispersalIDParam); id != "" {
+		ids = append(ids, id)
+	} else {
+		for _, p := range s.PendingDispersals() {
+			if p.Status == eigenda.DispersalStatusPending {
+				ids = append(ids, p.ID)
+			}
+		}
+	}
+
+	results := make([]ResumeResult, 0, len(ids))
+	for _, id := range ids {
+		res := ResumeResult{ID: id, Status: eigenda.DispersalStatusRecovered}
+		if err := s.ResumeDispersal(r.Context(), id); err != nil {
+			if len(ids) == 1 && errors.Is(err, eigenda.ErrDispersalNotFound) {
+				svr.WriteNotFound(w, err)
+				return err
+			}
+			res.Status = eigenda.DispersalStatusPending
+			if errors.Is(err, eigenda.ErrDispersalFailed) {
+				res.Status = "failed"
+			}
+			if !errors.Is(err, eigenda.ErrDispersalPending) {
+				res.Error = err.Error()
+			}
+		}
+		results = append(results, res)
+	}
+
+	return svr.writeJSON(w, results)
+}
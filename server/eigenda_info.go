@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const EigenDAInfoRoute = "/eigenda-info"
+
+// EigenDAInfo ... limits and parameters of the EigenDA network served on EigenDAInfoRoute, so that clients can
+// adapt their payload sizes instead of hardcoding them
+type EigenDAInfo struct {
+	Backend string `json:"backend"`
+	// MaxBlobSizeBytes is the largest payload accepted by PUT requests
+	MaxBlobSizeBytes uint64 `json:"max_blob_size_bytes"`
+	// RequiredQuorums, Quorums and RetentionBlocks are read from the EigenDA service manager, and only set with
+	// cert verification enabled
+	RequiredQuorums []uint       `json:"required_quorums,omitempty"`
+	Quorums         []QuorumInfo `json:"quorums,omitempty"`
+	RetentionBlocks uint32       `json:"retention_blocks,omitempty"`
+	// RetentionSeconds is set by backends measuring retention in time rather than blocks, i.e. the memstore
+	RetentionSeconds int64     `json:"retention_seconds,omitempty"`
+	FetchedAt        time.Time `json:"fetched_at"`
+}
+
+// QuorumInfo ... security thresholds of a quorum
+type QuorumInfo struct {
+	Quorum                          uint8 `json:"quorum"`
+	AdversaryThresholdPercentage    uint8 `json:"adversary_threshold_percentage"`
+	ConfirmationThresholdPercentage uint8 `json:"confirmation_threshold_percentage"`
+}
+
+// newEigenDAInfo ... converts the NetworkInfo reported by backend
+func newEigenDAInfo(backend store.BackendType, ni store.NetworkInfo) EigenDAInfo {
+	info := EigenDAInfo{
+		Backend:          backend.String(),
+		MaxBlobSizeBytes: ni.MaxBlobSizeBytes,
+		RetentionBlocks:  ni.RetentionBlocks,
+		RetentionSeconds: int64(ni.Retention.Seconds()),
+		FetchedAt:        time.Now(),
+	}
+	for _, q := range ni.RequiredQuorums {
+		info.RequiredQuorums = append(info.RequiredQuorums, uint(q))
+	}
+	for i := 0; i < max(len(ni.AdversaryThresholds), len(ni.ConfirmationThresholds)); i++ {
+		q := QuorumInfo{Quorum: uint8(i)} // #nosec G115
+		if i < len(ni.AdversaryThresholds) {
+			q.AdversaryThresholdPercentage = ni.AdversaryThresholds[i]
+		}
+		if i < len(ni.ConfirmationThresholds) {
+			q.ConfirmationThresholdPercentage = ni.ConfirmationThresholds[i]
+		}
+		info.Quorums = append(info.Quorums, q)
+	}
+	return info
+}
+
+// eigenDAInfoCache ... caches the EigenDAInfo for refreshInterval, so that requests don't query the Ethereum RPC
+type eigenDAInfoCache struct {
+	mu              sync.Mutex
+	refreshInterval time.Duration
+	info            *EigenDAInfo
+}
+
+// get ... returns the cached info, refreshing it from s if it is older than the refresh interval. If the refresh
+// fails, the stale info is returned if there is any.
+func (c *eigenDAInfoCache) get(ctx context.Context, s store.GeneratedKeyStore) (EigenDAInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.info != nil && time.Since(c.info.FetchedAt) < c.refreshInterval {
+		return *c.info, nil
+	}
+
+	reporter, ok := s.(store.NetworkInfoReporter)
+	if !ok {
+		return EigenDAInfo{}, fmt.Errorf("EigenDA backend doesn't report network info")
+	}
+	ni, err := reporter.NetworkInfo(ctx)
+	if err != nil {
+		if c.info != nil {
+			return *c.info, nil
+		}
+		return EigenDAInfo{}, fmt.Errorf("failed to fetch network info: %w", err)
+	}
+
+	info := newEigenDAInfo(s.BackendType(), ni)
+	c.info = &info
+	return info, nil
+}
+
+// HandleEigenDAInfo ... returns the limits and parameters of the EigenDA network, refreshed every
+// Options.EigenDAInfoRefreshInterval.
+// Example: GET /eigenda-info
+func (svr *Server) HandleEigenDAInfo(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	info, err := svr.eigenDAInfo.get(r.Context(), svr.router.GetEigenDAStore())
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return err
+	}
+	return svr.writeJSON(w, info)
+}
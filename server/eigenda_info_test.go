@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleEigenDAInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	da := &storetest.DAStore{Info: store.NetworkInfo{
+		MaxBlobSizeBytes:       16 * 1024 * 1024,
+		RequiredQuorums:        []uint8{0, 1},
+		AdversaryThresholds:    []uint8{33, 33},
+		ConfirmationThresholds: []uint8{55, 55},
+		RetentionBlocks:        100800,
+	}}
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().GetEigenDAStore().Return(da).AnyTimes()
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics,
+		Options{EigenDAInfoRefreshInterval: time.Hour})
+
+	get := func() (*httptest.ResponseRecorder, EigenDAInfo) {
+		rec := httptest.NewRecorder()
+		_ = server.HandleEigenDAInfo(rec, httptest.NewRequest(http.MethodGet, EigenDAInfoRoute, nil))
+		var info EigenDAInfo
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+		}
+		return rec, info
+	}
+
+	rec, info := get()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "EigenDA", info.Backend)
+	require.Equal(t, uint64(16*1024*1024), info.MaxBlobSizeBytes)
+	require.Equal(t, []uint{0, 1}, info.RequiredQuorums)
+	require.Equal(t, []QuorumInfo{
+		{Quorum: 0, AdversaryThresholdPercentage: 33, ConfirmationThresholdPercentage: 55},
+		{Quorum: 1, AdversaryThresholdPercentage: 33, ConfirmationThresholdPercentage: 55},
+	}, info.Quorums)
+	require.Equal(t, uint32(100800), info.RetentionBlocks)
+
+	// served from cache within the refresh interval
+	_, _ = get()
+	require.Equal(t, 1, da.InfoFetches())
+
+	// stale info is served if a refresh fails
+	server.eigenDAInfo.info.FetchedAt = time.Now().Add(-2 * time.Hour)
+	da.InfoErr = errors.New("rpc unavailable")
+	rec, info = get()
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, uint32(100800), info.RetentionBlocks)
+	require.Equal(t, 2, da.InfoFetches())
+
+	// without any cached info, the error is returned
+	server.eigenDAInfo.info = nil
+	rec, _ = get()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleEigenDAInfo(rec, httptest.NewRequest(http.MethodPost, EigenDAInfoRoute, nil)))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
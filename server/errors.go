@@ -19,6 +19,10 @@ func (me MetaError) Error() string {
 		me.Meta.CertVersion)
 }
 
+func (me MetaError) Unwrap() error {
+	return me.Err
+}
+
 // NewMetaError creates a new MetaError
 func NewMetaError(err error, meta commitments.CommitmentMeta) MetaError {
 	return MetaError{Err: err, Meta: meta}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	ExplorerRoute            = "/explorer/"
+	ExplorerCommitmentsRoute = ExplorerRoute + "api/commitments"
+	ExplorerBlobRoute        = ExplorerRoute + "api/blob"
+
+	explorerLimitParam = "limit"
+	explorerKeyParam   = "key"
+	explorerModeParam  = "mode"
+
+	defaultExplorerLimit = 50
+	maxExplorerLimit     = 500
+	// maxPreviewBytes ... number of payload bytes rendered in the hex/ASCII preview
+	maxPreviewBytes = 4096
+)
+
+//go:embed explorer
+var explorerAssets embed.FS
+
+// ExplorerConfig ... configuration for the blob explorer web UI
+type ExplorerConfig struct {
+	Enabled bool
+}
+
+// ExplorerEntry ... a commitment listed by the blob explorer
+type ExplorerEntry struct {
+	// Key is the commitment as used by the storage backends, i.e. the DA cert or keccak256 hash
+	Key hexutil.Bytes `json:"key"`
+	// Commitment is the commitment as returned to clients, i.e. the key of the /get/ route
+	Commitment hexutil.Bytes        `json:"commitment,omitempty"`
+	Mode       string               `json:"mode,omitempty"`
+	Size       int                  `json:"size"`
+	CreatedAt  time.Time            `json:"created_at"`
+	Backends   map[string]time.Time `json:"backends"`
+}
+
+// BlobDetails ... metadata and preview of a blob fetched by the blob explorer
+type BlobDetails struct {
+	Key                hexutil.Bytes `json:"key"`
+	Mode               string        `json:"mode"`
+	Size               int           `json:"size"`
+	Backend            string        `json:"backend"`
+	Verification       string        `json:"verification"`
+	CertReferenceBlock *uint32       `json:"cert_reference_block,omitempty"`
+	// Preview is a hex/ASCII dump of the first maxPreviewBytes of the decoded payload
+	Preview   string `json:"preview"`
+	Truncated bool   `json:"truncated"`
+}
+
+// registerExplorerRoutes ... mounts the blob explorer UI and its API onto the provided mux
+func (svr *Server) registerExplorerRoutes(mux *http.ServeMux) error {
+	assets, err := fs.Sub(explorerAssets, "explorer")
+	if err != nil {
+		return fmt.Errorf("failed to load explorer assets: %w", err)
+	}
+	static := http.StripPrefix(ExplorerRoute, http.FileServer(http.FS(assets)))
+
+	mux.HandleFunc(ExplorerRoute, withHeaders(static.ServeHTTP, explorerSecurityHeaders))
+	mux.HandleFunc(ExplorerCommitmentsRoute,
+		withHeaders(WithLogging(svr.HandleExplorerCommitments, svr.log), explorerSecurityHeaders))
+	mux.HandleFunc(ExplorerBlobRoute,
+		withHeaders(WithLogging(svr.HandleExplorerBlob, svr.log), explorerSecurityHeaders))
+	return nil
+}
+
+// HandleExplorerCommitments ... lists the most recently indexed commitments, newest first.
+// Example: GET /explorer/api/commitments?limit=20
+func (svr *Server) HandleExplorerCommitments(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	limit := defaultExplorerLimit
+	if raw := r.URL.Query().Get(explorerLimitParam); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 {
+			err = fmt.Errorf("invalid %s %q", explorerLimitParam, raw)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+		limit = min(l, maxExplorerLimit)
+	}
+
+	recent := svr.router.Index().Recent(limit)
+	entries := make([]ExplorerEntry, 0, len(recent))
+	for _, e := range recent {
+		entry := ExplorerEntry{
+			Key:       e.Key,
+			Mode:      e.Mode,
+			Size:      e.Size,
+			CreatedAt: e.CreatedAt,
+			Backends:  e.Backends,
+		}
+		if e.Mode != "" {
+			if comm, err := svr.encodeCommitment(e.Key, commitments.CommitmentMode(e.Mode)); err == nil {
+				entry.Commitment = comm
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return svr.writeJSON(w, entries)
+}
+
+// HandleExplorerBlob ... fetches the blob for a backend key through the router and returns its metadata,
+// verification status and a hex/ASCII preview of the decoded payload.
+// Example: GET /explorer/api/blob?key=0x...&mode=optimism_generic
+func (svr *Server) HandleExplorerBlob(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	query := r.URL.Query()
+	key, err := hexutil.Decode(query.Get(explorerKeyParam))
+	if err != nil {
+		err = fmt.Errorf("invalid %s: %w", explorerKeyParam, err)
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	mode := commitments.OptimismGeneric
+	if raw := query.Get(explorerModeParam); raw != "" {
+		mode, err = commitments.StringToCommitmentMode(raw)
+		if err != nil {
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+	}
+
+	ctx, rm := store.WithResponseMeta(r.Context())
+	data, err := svr.router.Get(ctx, key, mode)
+	if err != nil {
+		err = fmt.Errorf("failed to fetch blob %s (commitment mode %v): %w", hexutil.Encode(key), mode, err)
+		if errors.Is(err, ErrNotFound) {
+			svr.WriteNotFound(w, err)
+		} else {
+			svr.WriteInternalError(w, err)
+		}
+		return err
+	}
+
+	details := BlobDetails{
+		Key:          key,
+		Mode:         string(mode),
+		Size:         len(data),
+		Backend:      rm.Backend().String(),
+		Verification: rm.Verification(),
+		Preview:      hex.Dump(data[:min(len(data), maxPreviewBytes)]),
+		Truncated:    len(data) > maxPreviewBytes,
+	}
+	if refBlock, ok := certReferenceBlock(mode, key); ok {
+		details.CertReferenceBlock = &refBlock
+	}
+
+	return svr.writeJSON(w, details)
+}
@@ -0,0 +1,108 @@
+"use strict";
+
+const api = {
+  commitments: "api/commitments",
+  blob: "api/blob",
+};
+
+function el(tag, text, className) {
+  const e = document.createElement(tag);
+  if (text !== undefined) {
+    e.textContent = text;
+  }
+  if (className) {
+    e.className = className;
+  }
+  return e;
+}
+
+function showError(msg) {
+  const e = document.getElementById("error");
+  e.textContent = msg;
+  e.hidden = !msg;
+}
+
+async function fetchJSON(url) {
+  const resp = await fetch(url);
+  if (!resp.ok) {
+    throw new Error(`${url}: ${resp.status} ${(await resp.text()) || resp.statusText}`);
+  }
+  return resp.json();
+}
+
+async function loadCommitments() {
+  showError("");
+  let entries;
+  try {
+    entries = await fetchJSON(api.commitments);
+  } catch (err) {
+    showError(err.message);
+    return;
+  }
+
+  const body = document.getElementById("commitments");
+  body.replaceChildren();
+  document.getElementById("empty").hidden = entries.length > 0;
+
+  for (const entry of entries) {
+    const row = el("tr");
+    row.append(
+      el("td", new Date(entry.created_at).toLocaleString()),
+      el("td", entry.commitment || entry.key, "mono"),
+      el("td", entry.mode || "unknown"),
+      el("td", `${entry.size} B`),
+      el("td", Object.keys(entry.backends || {}).join(", ") || "-"),
+    );
+    row.addEventListener("click", () => {
+      for (const r of body.querySelectorAll("tr.selected")) {
+        r.classList.remove("selected");
+      }
+      row.classList.add("selected");
+      loadBlob(entry);
+    });
+    body.append(row);
+  }
+}
+
+async function loadBlob(entry) {
+  showError("");
+  const params = new URLSearchParams({ key: entry.key });
+  if (entry.mode) {
+    params.set("mode", entry.mode);
+  }
+
+  let blob;
+  try {
+    blob = await fetchJSON(`${api.blob}?${params}`);
+  } catch (err) {
+    showError(err.message);
+    return;
+  }
+
+  const fields = [
+    ["Commitment", entry.commitment || "-"],
+    ["Backend key", blob.key],
+    ["Mode", blob.mode],
+    ["Size", `${blob.size} B`],
+    ["Served by", blob.backend],
+    ["Verification", blob.verification],
+    ["Cert reference block", blob.cert_reference_block ?? "-"],
+  ];
+  const meta = document.getElementById("meta");
+  meta.replaceChildren();
+  for (const [name, value] of fields) {
+    meta.append(el("dt", name), el("dd", String(value)));
+  }
+
+  let preview = blob.preview;
+  if (blob.truncated) {
+    preview += "... (truncated)\n";
+  }
+  document.getElementById("preview").textContent = preview;
+  document.getElementById("details").hidden = false;
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  document.getElementById("refresh").addEventListener("click", loadCommitments);
+  loadCommitments();
+});
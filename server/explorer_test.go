@@ -0,0 +1,83 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplorer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := index.New(10)
+	idx.Put([]byte("cert-a"), 5, string(commitments.OptimismGeneric))
+	idx.Put([]byte("cert-b"), 5, string(commitments.SimpleCommitmentMode))
+	idx.AddBackend([]byte("cert-b"), 5, "Redis")
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	mux := http.NewServeMux()
+	require.NoError(t, server.registerExplorerRoutes(mux))
+
+	t.Run("UI", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ExplorerRoute, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), "Blob Explorer")
+		require.Contains(t, rec.Header().Get("Content-Security-Policy"), "script-src 'self'")
+	})
+
+	t.Run("Commitments", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ExplorerCommitmentsRoute+"?limit=1", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var entries []ExplorerEntry
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+		require.Len(t, entries, 1)
+		require.Equal(t, []byte("cert-b"), []byte(entries[0].Key))
+		require.Contains(t, entries[0].Backends, "Redis")
+
+		expected, err := commitments.EncodeCommitment([]byte("cert-b"), commitments.SimpleCommitmentMode)
+		require.NoError(t, err)
+		require.Equal(t, expected, []byte(entries[0].Commitment))
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ExplorerCommitmentsRoute+"?limit=-1", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("Blob", func(t *testing.T) {
+		payload := []byte(strings.Repeat("a", maxPreviewBytes+1))
+		mockRouter.EXPECT().Get(gomock.Any(), []byte("cert-a"), commitments.OptimismGeneric).Return(payload, nil)
+
+		url := ExplorerBlobRoute + "?key=" + hexutil.Encode([]byte("cert-a")) + "&mode=optimism_generic"
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var details BlobDetails
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &details))
+		require.Equal(t, len(payload), details.Size)
+		require.True(t, details.Truncated)
+		require.Contains(t, details.Preview, "|aaaaaaaaaaaaaaaa|")
+
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ExplorerBlobRoute+"?key=zz", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
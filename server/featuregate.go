@@ -0,0 +1,58 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+)
+
+const (
+	// AdminFeatureGatesRoute ... lists the feature gates on GET, sets the rule of the gate given by the name
+	// query param to the rule param on POST, and resets it to its default on DELETE
+	AdminFeatureGatesRoute = AdminRoute + "feature-gates"
+)
+
+// HandleFeatureGates ... lists and updates the rules of the feature gates, see featuregate.Set. Updates aren't
+// persisted, on restart the configured rules apply again.
+func (svr *Server) HandleFeatureGates(w http.ResponseWriter, r *http.Request) error {
+	if svr.gates == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return fmt.Errorf("feature gates not loaded")
+	}
+
+	name := r.URL.Query().Get("name")
+	switch r.Method {
+	case http.MethodGet:
+		return svr.writeJSON(w, svr.gates.List())
+	case http.MethodPost:
+		rule, err := featuregate.ParseRule(r.URL.Query().Get("rule"))
+		if err != nil {
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+		if err := svr.gates.Set(name, rule); err != nil {
+			return svr.writeGateError(w, err)
+		}
+		svr.log.Info("Feature gate updated", "gate", name, "rule", rule.String())
+	case http.MethodDelete:
+		if err := svr.gates.Reset(name); err != nil {
+			return svr.writeGateError(w, err)
+		}
+		svr.log.Info("Feature gate reset to its default", "gate", name)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	return svr.writeJSON(w, svr.gates.List())
+}
+
+func (svr *Server) writeGateError(w http.ResponseWriter, err error) error {
+	if errors.Is(err, featuregate.ErrUnknownGate) {
+		svr.WriteNotFound(w, err)
+		return err
+	}
+	svr.WriteInternalError(w, err)
+	return err
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleFeatureGates(t *testing.T) {
+	gates, err := featuregate.New(featuregate.Config{})
+	require.NoError(t, err)
+	svr := &Server{log: log.New(), gates: gates, opts: Options{TTLHints: true}}
+
+	ttlOf := func(tenant string) time.Duration {
+		req := httptest.NewRequest(http.MethodPut, "/put", nil)
+		req.Header.Set(TTLHeader, "1h")
+		req.Header.Set(TenantHeader, tenant)
+		ttl, err := svr.readTTL(req)
+		require.NoError(t, err)
+		return ttl
+	}
+	require.Equal(t, time.Hour, ttlOf("rollup-b"))
+
+	tests := []struct {
+		name           string
+		method         string
+		query          string
+		expectedStatus int
+	}{
+		{name: "Set", method: http.MethodPost, query: "?name=ttl-hints&rule=tenants:rollup-a", expectedStatus: http.StatusOK},
+		{name: "InvalidRule", method: http.MethodPost, query: "?name=ttl-hints&rule=maybe", expectedStatus: http.StatusBadRequest},
+		{name: "UnknownGate", method: http.MethodPost, query: "?name=compression&rule=on", expectedStatus: http.StatusNotFound},
+		{name: "List", method: http.MethodGet, expectedStatus: http.StatusOK},
+		{name: "MethodNotAllowed", method: http.MethodPut, expectedStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			_ = svr.HandleFeatureGates(rec, httptest.NewRequest(tt.method, AdminFeatureGatesRoute+tt.query, nil))
+			require.Equal(t, tt.expectedStatus, rec.Code)
+		})
+	}
+
+	require.Equal(t, time.Hour, ttlOf("rollup-a"))
+	require.Zero(t, ttlOf("rollup-b"))
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, svr.HandleFeatureGates(rec,
+		httptest.NewRequest(http.MethodDelete, AdminFeatureGatesRoute+"?name=ttl-hints", nil)))
+	var list []featuregate.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	for _, st := range list {
+		require.Nil(t, st.Rule)
+	}
+	require.Equal(t, time.Hour, ttlOf("rollup-b"))
+}
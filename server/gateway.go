@@ -0,0 +1,287 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// limiterIdleTimeout ... per client rate limiters unused for this long are dropped
+	limiterIdleTimeout = 10 * time.Minute
+)
+
+// GatewayConfig ... configuration of the public read gateway profile, which hardens the proxy for exposing
+// blob reads to the public internet: only the GET route is served, clients are rate limited, responses
+// carry caching headers and commitments can be restricted to an allowlist of prefixes.
+type GatewayConfig struct {
+	Enabled bool
+	// RateLimit is the sustained number of requests per second allowed per client
+	RateLimit float64
+	Burst     int
+	// APIKeys, if set, are required as a bearer token and are used to identify clients for rate limiting
+	APIKeys []string
+	// AllowedPrefixes restricts reads to commitments starting with one of the prefixes, all commitments
+	// are allowed if empty
+	AllowedPrefixes []hexutil.Bytes
+	// CacheMaxAge is the max-age advertised to caches for successful reads
+	CacheMaxAge time.Duration
+	// TrustedProxies are the IPs or CIDR ranges of proxies whose X-Forwarded-For header identifies unauthenticated
+	// clients, rather than the proxy's own address
+	TrustedProxies []string
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// gateway ... enforces the GatewayConfig on incoming requests
+type gateway struct {
+	cfg     GatewayConfig
+	proxies []*net.IPNet
+	// commitment ... returns the commitment a request reads, and false if it can't be determined
+	commitment func(r *http.Request) ([]byte, bool)
+
+	mu        sync.Mutex
+	limiters  map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+func newGateway(cfg GatewayConfig, commitment func(r *http.Request) ([]byte, bool)) *gateway {
+	return &gateway{
+		cfg:        cfg,
+		proxies:    parseTrustedProxies(cfg.TrustedProxies),
+		commitment: commitment,
+		limiters:   make(map[string]*clientLimiter),
+		lastPrune:  time.Now(),
+	}
+}
+
+// parseTrustedProxies ... parses IPs and CIDR ranges, single IPs are turned into a range only containing them.
+// Invalid entries are skipped, they have already been rejected by the flag's action.
+func parseTrustedProxies(proxies []string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, p := range proxies {
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			nets = append(nets, n)
+			continue
+		}
+		ip := net.ParseIP(p)
+		if ip == nil {
+			continue
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return nets
+}
+
+// allow ... whether the client is within its rate limit
+func (g *gateway) allow(client string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(g.lastPrune) > limiterIdleTimeout {
+		for c, l := range g.limiters {
+			if now.Sub(l.lastSeen) > limiterIdleTimeout {
+				delete(g.limiters, c)
+			}
+		}
+		g.lastPrune = now
+	}
+
+	l, ok := g.limiters[client]
+	if !ok {
+		l = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(g.cfg.RateLimit), g.cfg.Burst)}
+		g.limiters[client] = l
+	}
+	l.lastSeen = now
+	return l.limiter.Allow()
+}
+
+// authenticate ... returns the API key presented by the request, and whether it is valid.
+// Requests are always valid when no API keys are configured.
+func (g *gateway) authenticate(r *http.Request) (string, bool) {
+	if len(g.cfg.APIKeys) == 0 {
+		return "", true
+	}
+
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || key == "" {
+		return "", false
+	}
+	if !secrets.EqualAny(g.cfg.APIKeys, key) {
+		return "", false
+	}
+	return key, true
+}
+
+// allowedCommitment ... whether the commitment read by the request starts with an allowed prefix
+func (g *gateway) allowedCommitment(r *http.Request) bool {
+	if len(g.cfg.AllowedPrefixes) == 0 {
+		return true
+	}
+
+	comm, ok := g.commitment(r)
+	if !ok {
+		return false
+	}
+	for _, p := range g.cfg.AllowedPrefixes {
+		if bytes.HasPrefix(comm, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathCommitment ... decodes the commitment in the request path of the GET route
+func pathCommitment(r *http.Request) ([]byte, bool) {
+	key := path.Base(r.URL.Path)
+	if !strings.HasPrefix(key, "0x") {
+		key = "0x" + key
+	}
+	comm, err := hexutil.Decode(key)
+	if err != nil {
+		return nil, false
+	}
+	return comm, true
+}
+
+// trusted ... whether the IP belongs to one of the trusted proxies
+func (g *gateway) trusted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range g.proxies {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP ... the IP of the client. Requests relayed by a trusted proxy are attributed to the closest untrusted
+// address of their X-Forwarded-For header: entries are walked from the right, since every proxy appends the
+// address it received the request from, while the leftmost entries can be forged by the client.
+func (g *gateway) clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !g.trusted(ip) {
+		return ip
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !g.trusted(hop) {
+			return hop
+		}
+		ip = hop
+	}
+	// every hop is trusted, the leftmost one is the closest to the client
+	return ip
+}
+
+// clientID ... identifies the client for rate limiting, by the hash of its API key if present or else by IP, so
+// that API keys aren't kept around in the limiters
+func (g *gateway) clientID(r *http.Request, apiKey string) string {
+	if apiKey != "" {
+		h := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(h[:8])
+	}
+	return "ip:" + g.clientIP(r)
+}
+
+// cacheControlWriter ... sets Cache-Control once the response status is known, so that only successful
+// reads are cached
+type cacheControlWriter struct {
+	http.ResponseWriter
+	maxAge      string
+	wroteHeader bool
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if code == http.StatusOK {
+			// a commitment always resolves to the same blob, so successful reads never go stale
+			w.Header().Set("Cache-Control", "public, max-age="+w.maxAge+", immutable")
+		} else {
+			w.Header().Set("Cache-Control", "no-store")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// WithGateway is a middleware that enforces the public read gateway profile: read-only methods, API key
+// authentication, per client rate limits, the commitment prefix allowlist and response caching headers. Cache
+// policy headers are dropped, so that public clients can't force reads from EigenDA.
+func WithGateway(handleFn http.HandlerFunc, cfg GatewayConfig) http.HandlerFunc {
+	return withGateway(handleFn, cfg, pathCommitment)
+}
+
+// withGateway ... WithGateway for routes that don't carry the commitment in their path, commitment resolves the
+// commitment read by a request for the prefix allowlist
+func withGateway(handleFn http.HandlerFunc, cfg GatewayConfig, commitment func(r *http.Request) ([]byte, bool)) http.HandlerFunc {
+	g := newGateway(cfg, commitment)
+	maxAge := strconv.FormatInt(int64(cfg.CacheMaxAge.Seconds()), 10)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		apiKey, ok := g.authenticate(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if !g.allow(g.clientID(r, apiKey)) {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		if !g.allowedCommitment(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		r.Header.Del(CachePolicyHeader)
+		r.Header.Del("Cache-Control")
+
+		handleFn(&cacheControlWriter{ResponseWriter: w, maxAge: maxAge}, r)
+	}
+}
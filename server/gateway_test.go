@@ -0,0 +1,100 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithGateway(t *testing.T) {
+	cfg := GatewayConfig{
+		Enabled:         true,
+		RateLimit:       1,
+		Burst:           2,
+		APIKeys:         []string{"secret"},
+		AllowedPrefixes: []hexutil.Bytes{{0x01, 0x00}},
+		CacheMaxAge:     time.Hour,
+	}
+
+	status := http.StatusOK
+	handler := WithGateway(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}, cfg)
+
+	serve := func(method, commitment, apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, GetRoute+commitment, nil)
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		require.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPost, "0x010000", "secret").Code)
+	})
+
+	t.Run("Authentication", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "0x010000", "").Code)
+		require.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "0x010000", "wrong").Code)
+	})
+
+	t.Run("AllowedPrefixes", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, serve(http.MethodGet, "0x000000", "secret").Code)
+	})
+
+	t.Run("CacheHeadersAndRateLimit", func(t *testing.T) {
+		// the previous request used up one token of the burst
+		rec := serve(http.MethodGet, "0x010000", "secret")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "public, max-age=3600, immutable", rec.Header().Get("Cache-Control"))
+
+		rec = serve(http.MethodGet, "0x010000", "secret")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, "1", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("ErrorsAreNotCached", func(t *testing.T) {
+		status = http.StatusNotFound
+		g := WithGateway(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(status)
+		}, GatewayConfig{Enabled: true, RateLimit: 1, Burst: 1})
+
+		rec := httptest.NewRecorder()
+		g(rec, httptest.NewRequest(http.MethodGet, GetRoute+"0x010000", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	})
+
+	t.Run("TrustedProxies", func(t *testing.T) {
+		g := newGateway(GatewayConfig{TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1"}}, pathCommitment)
+		id := func(remote string, forwarded ...string) string {
+			req := httptest.NewRequest(http.MethodGet, GetRoute+"0x010000", nil)
+			req.RemoteAddr = remote
+			for _, f := range forwarded {
+				req.Header.Add("X-Forwarded-For", f)
+			}
+			return g.clientID(req, "")
+		}
+
+		// untrusted peers can't pick their identity
+		require.Equal(t, "ip:1.2.3.4", id("1.2.3.4:1234", "5.6.7.8"))
+		// the rightmost untrusted hop is the client, entries to its left may be forged
+		require.Equal(t, "ip:5.6.7.8", id("10.0.0.1:1234", "9.9.9.9, 5.6.7.8"))
+		require.Equal(t, "ip:5.6.7.8", id("10.0.0.1:1234", "9.9.9.9, 5.6.7.8", "192.168.1.1"))
+		// without the header, or with only trusted hops, the closest address to the client is used
+		require.Equal(t, "ip:10.0.0.1", id("10.0.0.1:1234"))
+		require.Equal(t, "ip:10.1.1.1", id("10.0.0.1:1234", "10.1.1.1"))
+		// API keys identify clients regardless of the proxies
+		req := httptest.NewRequest(http.MethodGet, GetRoute+"0x010000", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", "5.6.7.8")
+		require.True(t, strings.HasPrefix(g.clientID(req, "secret"), "key:"))
+	})
+}
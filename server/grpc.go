@@ -0,0 +1,220 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/api"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// grpcChunkSize ... size of the payload chunks sent by GetStream, well below gRPC's default 4MiB message limit
+const grpcChunkSize = 1 << 20
+
+// GRPCConfig ... listener serving the Put and Get operations as the gRPC service defined in api/proxy.proto
+type GRPCConfig struct {
+	Enabled bool
+	Addr    string
+	Port    int
+}
+
+// grpcService ... implements api.ProxyServer by serving each call as the equivalent request to the HTTP API, so
+// that gRPC clients go through the same middlewares, e.g. authentication, quotas, hooks and metrics
+type grpcService struct {
+	api.UnimplementedProxyServer
+	handler http.Handler
+	log     log.Logger
+}
+
+func (s *grpcService) Put(ctx context.Context, req *api.PutRequest) (*api.PutReply, error) {
+	commitment, err := s.put(ctx, req.GetCommitmentMode(), req.GetCommitment(), req.GetPayload())
+	if err != nil {
+		return nil, err
+	}
+	return &api.PutReply{Commitment: commitment}, nil
+}
+
+func (s *grpcService) PutStream(stream api.Proxy_PutStreamServer) error {
+	var mode string
+	var commitment []byte
+	var payload bytes.Buffer
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if first {
+			mode, commitment = req.GetCommitmentMode(), req.GetCommitment()
+		}
+		payload.Write(req.GetPayload())
+	}
+
+	commitment, err := s.put(stream.Context(), mode, commitment, payload.Bytes())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&api.PutReply{Commitment: commitment})
+}
+
+func (s *grpcService) Get(ctx context.Context, req *api.GetRequest) (*api.GetReply, error) {
+	payload, err := s.get(ctx, req.GetCommitmentMode(), req.GetCommitment())
+	if err != nil {
+		return nil, err
+	}
+	return &api.GetReply{Payload: payload}, nil
+}
+
+func (s *grpcService) GetStream(req *api.GetRequest, stream api.Proxy_GetStreamServer) error {
+	payload, err := s.get(stream.Context(), req.GetCommitmentMode(), req.GetCommitment())
+	if err != nil {
+		return err
+	}
+	for len(payload) > 0 {
+		n := min(len(payload), grpcChunkSize)
+		if err := stream.Send(&api.GetReply{Payload: payload[:n]}); err != nil {
+			return err
+		}
+		payload = payload[n:]
+	}
+	return nil
+}
+
+// put ... serves the PUT of payload, returning the commitment
+func (s *grpcService) put(ctx context.Context, mode string, commitment, payload []byte) ([]byte, error) {
+	target := PutRoute
+	if len(commitment) > 0 {
+		target += hexutil.Encode(commitment)
+	}
+	return s.serve(ctx, http.MethodPost, target, mode, payload)
+}
+
+// get ... serves the GET of a commitment, returning its payload
+func (s *grpcService) get(ctx context.Context, mode string, commitment []byte) ([]byte, error) {
+	if len(commitment) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "commitment is required")
+	}
+	return s.serve(ctx, http.MethodGet, GetRoute+hexutil.Encode(commitment), mode, nil)
+}
+
+// serve ... serves a call as an HTTP request to the handler, returning the response body. The request's metadata
+// is forwarded as headers, and the proxy's response headers are sent back as header metadata.
+func (s *grpcService) serve(ctx context.Context, method, target, mode string, body []byte) ([]byte, error) {
+	if mode != "" {
+		target += "?" + url.Values{CommitmentModeKey: {mode}}.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, vs := range md {
+		if strings.HasPrefix(k, ":") || strings.HasPrefix(k, "grpc-") || k == "content-type" {
+			continue
+		}
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	// gRPC calls are answered once served, they can't be turned into jobs
+	req.Header.Del(AsyncHeader)
+	req.Header.Del(AckHeader)
+
+	rec := &responseRecorder{header: make(http.Header)}
+	s.handler.ServeHTTP(rec, req)
+
+	out := metadata.MD{}
+	for k, vs := range rec.header {
+		if strings.HasPrefix(k, "X-Eigenda-Proxy-") || k == "X-Request-Id" {
+			out.Append(k, vs...)
+		}
+	}
+	if len(out) > 0 {
+		if err := grpc.SetHeader(ctx, out); err != nil {
+			s.log.Debug("Failed to set gRPC response headers", "err", err)
+		}
+	}
+
+	if rec.status != 0 && rec.status != http.StatusOK {
+		return nil, status.Error(grpcCode(rec.status), strings.TrimSpace(rec.body.String()))
+	}
+	return rec.body.Bytes(), nil
+}
+
+// grpcCode ... maps the status of an HTTP response to the gRPC status code of the call
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusMethodNotAllowed:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
+
+// serveGRPC ... starts the gRPC listener, serving calls through the main listener's handler
+func (svr *Server) serveGRPC() error {
+	svr.grpcServer = grpc.NewServer()
+	api.RegisterProxyServer(svr.grpcServer, &grpcService{handler: svr.httpServer.Handler, log: svr.log})
+	reflection.Register(svr.grpcServer)
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(svr.opts.GRPC.Addr, strconv.Itoa(svr.opts.GRPC.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to start gRPC listener: %w", err)
+	}
+	svr.log.Info("Serving the gRPC API", "endpoint", listener.Addr().String())
+	go func() {
+		if err := svr.grpcServer.Serve(listener); err != nil {
+			svr.log.Error("gRPC server failed", "err", err)
+		}
+	}()
+	svr.grpcListener = listener
+	return nil
+}
+
+// stopGRPC ... stops the gRPC listener gracefully, cancelling the calls still in flight once ctx is done
+func (svr *Server) stopGRPC(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		svr.grpcServer.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		svr.grpcServer.Stop()
+	}
+}
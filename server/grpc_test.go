@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/api"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPC(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		GRPC: GRPCConfig{Enabled: true, Addr: "127.0.0.1"},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	conn, err := grpc.Dial(svr.GRPCEndpoint(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := api.NewProxyClient(conn)
+	ctx := context.Background()
+
+	t.Run("Unary", func(t *testing.T) {
+		put, err := client.Put(ctx, &api.PutRequest{Payload: []byte("hello")})
+		require.NoError(t, err)
+		require.NotEmpty(t, put.Commitment)
+
+		get, err := client.Get(ctx, &api.GetRequest{Commitment: put.Commitment})
+		require.NoError(t, err)
+		require.Equal(t, []byte("hello"), get.Payload)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		// precomputed-key payloads aren't bound by the memstore's max blob size
+		payload := bytes.Repeat([]byte("0123456789abcdef"), (grpcChunkSize+grpcChunkSize/2)/16)
+		comm := append([]byte{0x00}, crypto.Keccak256(payload)...)
+
+		putStream, err := client.PutStream(ctx)
+		require.NoError(t, err)
+		for chunk := payload; len(chunk) > 0; {
+			n := min(len(chunk), grpcChunkSize/2)
+			require.NoError(t, putStream.Send(&api.PutRequest{Commitment: comm, Payload: chunk[:n]}))
+			chunk = chunk[n:]
+		}
+		_, err = putStream.CloseAndRecv()
+		require.NoError(t, err)
+
+		getStream, err := client.GetStream(ctx, &api.GetRequest{Commitment: comm})
+		require.NoError(t, err)
+		var got []byte
+		chunks := 0
+		for {
+			reply, err := getStream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			got = append(got, reply.Payload...)
+			chunks++
+		}
+		require.Equal(t, payload, got)
+		require.Equal(t, 2, chunks)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.Get(ctx, &api.GetRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = client.Get(ctx, &api.GetRequest{CommitmentMode: "unknown", Commitment: []byte{1, 0, 0}})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		comm := append([]byte{0x00}, crypto.Keccak256([]byte("never stored"))...)
+		_, err = client.Get(ctx, &api.GetRequest{Commitment: comm})
+		require.Error(t, err)
+
+		require.Equal(t, codes.NotFound, grpcCode(http.StatusNotFound))
+		require.Equal(t, codes.Unavailable, grpcCode(http.StatusServiceUnavailable))
+	})
+
+	t.Run("Reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+
+		var services []string
+		for _, s := range resp.GetListServicesResponse().GetService() {
+			services = append(services, s.GetName())
+		}
+		require.Contains(t, services, "eigenda.proxy.v1.Proxy")
+	})
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+)
+
+const (
+	// RollupHeightHeader ... PUT request header carrying the rollup block or batch number the blob is posted for,
+	// e.g. the first L2 block of the batch. The written commitment is indexed by it, see ByHeightRoute.
+	RollupHeightHeader = "X-EigenDA-Proxy-Rollup-Height"
+
+	ByHeightRoute = "/by-height/"
+
+	byHeightLayerParam  = "layer"
+	byHeightTenantParam = "tenant"
+)
+
+// HeightLookup ... commitments indexed at a height, served on ByHeightRoute
+type HeightLookup struct {
+	Layer string `json:"layer"`
+	// Height is the greatest indexed height at or below the requested one
+	Height      uint64           `json:"height"`
+	Commitments []CommitmentInfo `json:"commitments"`
+}
+
+// readRollupHeight ... returns the rollup height of a PUT request, false if it doesn't set one
+func readRollupHeight(r *http.Request) (uint64, bool, error) {
+	v := r.Header.Get(RollupHeightHeader)
+	if v == "" {
+		return 0, false, nil
+	}
+	height, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid %s header %q: expected a block or batch number", RollupHeightHeader, v)
+	}
+	return height, true, nil
+}
+
+// HandleByHeight ... looks up the commitments indexed at the greatest height at or below the requested one, so
+// that operators can find the DA blob of a rollup height. layer is either l2 (default), matching the rollup
+// heights supplied with PUT requests (see RollupHeightHeader), or l1, matching the L1 blocks the backfill scanner
+// found commitments in. Rollup heights are looked up among the commitments of tenant, the tenant of the PUT
+// request (see TenantHeader), which defaults to the default tenant.
+// Example: GET /by-height/1234567?layer=l2&tenant=rollup-a
+func (svr *Server) HandleByHeight(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	raw := path.Base(r.URL.Path)
+	height, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid height %q, expected a block or batch number", raw)
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	query := r.URL.Query()
+	layer := index.LayerRollup
+	if v := query.Get(byHeightLayerParam); v != "" {
+		layer = index.Layer(strings.ToLower(v))
+		if layer != index.LayerRollup && layer != index.LayerL1 {
+			err := fmt.Errorf("invalid %s %q, expected %s or %s", byHeightLayerParam, v, index.LayerRollup, index.LayerL1)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+	}
+
+	tenant := query.Get(byHeightTenantParam)
+	if tenant == "" {
+		tenant = defaultTenant
+	}
+	indexed, entries, ok := svr.router.Index().AtHeight(layer, tenant, height)
+	if !ok {
+		err := fmt.Errorf("no commitment indexed at or below %s height %d", layer, height)
+		svr.WriteNotFound(w, err)
+		return err
+	}
+
+	lookup := HeightLookup{Layer: string(layer), Height: indexed, Commitments: make([]CommitmentInfo, 0, len(entries))}
+	for _, e := range entries {
+		lookup.Commitments = append(lookup.Commitments, svr.commitmentInfo(e))
+	}
+	return svr.writeJSON(w, lookup)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRollupHeight(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/put", nil)
+	_, ok, err := readRollupHeight(req)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	req.Header.Set(RollupHeightHeader, "1234")
+	height, ok, err := readRollupHeight(req)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, uint64(1234), height)
+
+	req.Header.Set(RollupHeightHeader, "-1")
+	_, _, err = readRollupHeight(req)
+	require.Error(t, err)
+}
+
+func TestHandleByHeight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := index.New(10)
+	for _, k := range []string{"cert-a", "cert-b", "cert-c"} {
+		idx.Put([]byte(k), 5, string(commitments.OptimismGeneric))
+		idx.SetTenant([]byte(k), defaultTenant)
+	}
+	idx.SetHeight([]byte("cert-a"), index.LayerRollup, 100)
+	idx.SetHeight([]byte("cert-b"), index.LayerRollup, 200)
+	idx.SetHeight([]byte("cert-b"), index.LayerL1, 20)
+	idx.SetTenant([]byte("cert-c"), "rollup-b")
+	idx.SetHeight([]byte("cert-c"), index.LayerRollup, 100)
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedCode   int
+		expectedHeight uint64
+		expectedKey    string
+	}{
+		{name: "Exact", path: "100", expectedCode: http.StatusOK, expectedHeight: 100, expectedKey: "cert-a"},
+		{name: "Floor", path: "199", expectedCode: http.StatusOK, expectedHeight: 100, expectedKey: "cert-a"},
+		{name: "Above", path: "5000", expectedCode: http.StatusOK, expectedHeight: 200, expectedKey: "cert-b"},
+		{name: "L1", path: "25?layer=l1", expectedCode: http.StatusOK, expectedHeight: 20, expectedKey: "cert-b"},
+		{name: "Tenant", path: "5000?tenant=rollup-b", expectedCode: http.StatusOK, expectedHeight: 100, expectedKey: "cert-c"},
+		{name: "Below", path: "99", expectedCode: http.StatusNotFound},
+		{name: "InvalidHeight", path: "latest", expectedCode: http.StatusBadRequest},
+		{name: "InvalidLayer", path: "100?layer=l3", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			err := server.HandleByHeight(rec, httptest.NewRequest(http.MethodGet, ByHeightRoute+tt.path, nil))
+			require.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode != http.StatusOK {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var lookup HeightLookup
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &lookup))
+			require.Equal(t, tt.expectedHeight, lookup.Height)
+			require.Len(t, lookup.Commitments, 1)
+			require.Equal(t, tt.expectedKey, string(lookup.Commitments[0].Key))
+			require.NotEmpty(t, lookup.Commitments[0].Commitment)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+)
+
+// runHooks ... passes a call through the request hooks, writing the error response if they fail. Requests
+// rejected by a hook are answered with 403 Forbidden, payloads failing schema validation with 400 Bad Request.
+func (svr *Server) runHooks(ctx context.Context, w http.ResponseWriter, call hooks.Call) (hooks.Call, error) {
+	out, err := svr.hooks.Run(ctx, call)
+	if err != nil {
+		switch {
+		case errors.Is(err, hooks.ErrInvalidPayload):
+			svr.WriteBadRequest(w, err)
+		case errors.Is(err, hooks.ErrRejected):
+			svr.WriteForbidden(w, err)
+		default:
+			svr.WriteInternalError(w, err)
+		}
+		return hooks.Call{}, err
+	}
+	return out, nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testFrame = "framed:"
+
+func init() {
+	// frames payloads on PUTs, unframes them on GETs and rejects payloads containing "secret"
+	hooks.Register("test-framing", hooks.HookFunc(func(_ context.Context, call hooks.Call) (hooks.Call, error) {
+		switch call.Stage {
+		case hooks.PrePut:
+			if bytes.Contains(call.Payload, []byte("secret")) {
+				return hooks.Call{}, fmt.Errorf("%w: payload contains a secret", hooks.ErrRejected)
+			}
+			call.Payload = append([]byte(testFrame), call.Payload...)
+		case hooks.PostGet:
+			payload, ok := bytes.CutPrefix(call.Payload, []byte(testFrame))
+			if !ok {
+				return hooks.Call{}, fmt.Errorf("stored payload isn't framed")
+			}
+			call.Payload = payload
+		}
+		return call, nil
+	}))
+}
+
+func TestRequestHooks(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Hooks: hooks.Config{Names: []string{"test-framing"}},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	url := "http://" + svr.Endpoint()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		comm, err := doRequest(http.MethodPost, url+"/put/", []byte("payload"))
+		require.NoError(t, err)
+		data, err := doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x", url, comm), nil)
+		require.NoError(t, err)
+		require.Equal(t, "payload", string(data))
+
+		// the stored blob is framed
+		stored, err := svr.router.Get(context.Background(), comm[3:], commitments.OptimismGeneric)
+		require.NoError(t, err)
+		require.Equal(t, testFrame+"payload", string(stored))
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url+"/put/",
+			bytes.NewReader([]byte("my secret")))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestPayloadSchema(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Hooks: hooks.Config{Schema: hooks.OPFramesSchema},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://"+svr.Endpoint()+"/put/",
+		bytes.NewReader([]byte("not a frame")))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestUnknownHook(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Hooks: hooks.Config{Names: []string{"no-such-hook"}},
+	})
+	require.ErrorContains(t, svr.Start(), "unknown hook")
+}
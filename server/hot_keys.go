@@ -0,0 +1,81 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	AdminHotKeysRoute = AdminRoute + "hot-keys"
+
+	hotKeysLimitParam = "limit"
+
+	defaultHotKeysLimit = 20
+	maxHotKeysLimit     = 1000
+)
+
+// HotCommitment ... an indexed commitment and its reads
+type HotCommitment struct {
+	CommitmentInfo
+	// RecentReads is the estimated number of reads within the access window of the report
+	RecentReads int `json:"recent_reads"`
+	// TotalReads is the estimated number of reads since the commitment was indexed
+	TotalReads int       `json:"total_reads"`
+	LastAccess time.Time `json:"last_access"`
+}
+
+// HotKeysReport ... reads of the indexed commitments within the access window ending at At. Blobs and Bytes are
+// the working set read within the window, i.e. what the in-memory cache must hold to serve all of those reads.
+// Read counts are estimates if reads are sampled, see --access-stats.sample-rate.
+type HotKeysReport struct {
+	At          time.Time       `json:"at"`
+	Window      string          `json:"window"`
+	SampleRate  float64         `json:"sample_rate"`
+	Reads       int             `json:"reads"`
+	Blobs       int             `json:"blobs"`
+	Bytes       int64           `json:"bytes"`
+	Commitments []HotCommitment `json:"commitments"`
+}
+
+// HandleHotKeys ... reports the most read commitments within the access window, most read first, e.g. to detect
+// clients re-fetching the same blob.
+// Example: GET /admin/hot-keys?limit=20
+func (svr *Server) HandleHotKeys(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	limit := defaultHotKeysLimit
+	if raw := r.URL.Query().Get(hotKeysLimitParam); raw != "" {
+		l, err := strconv.Atoi(raw)
+		if err != nil || l <= 0 {
+			err = fmt.Errorf("invalid %s %q", hotKeysLimitParam, raw)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+		limit = min(l, maxHotKeysLimit)
+	}
+
+	rep := svr.router.Index().Hottest(limit)
+	report := HotKeysReport{
+		At:          rep.At,
+		Window:      rep.Window.String(),
+		SampleRate:  rep.SampleRate,
+		Reads:       rep.Reads,
+		Blobs:       rep.Blobs,
+		Bytes:       rep.Bytes,
+		Commitments: make([]HotCommitment, 0, len(rep.Hottest)),
+	}
+	for _, h := range rep.Hottest {
+		report.Commitments = append(report.Commitments, HotCommitment{
+			CommitmentInfo: svr.commitmentInfo(h.Entry),
+			RecentReads:    h.RecentReads,
+			TotalReads:     h.TotalReads,
+			LastAccess:     h.LastAccess,
+		})
+	}
+	return svr.writeJSON(w, report)
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleHotKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := index.New(10)
+	for i, k := range []string{"cert-a", "cert-b", "cert-c"} {
+		idx.Put([]byte(k), 5, string(commitments.OptimismGeneric))
+		for j := 0; j < i; j++ {
+			idx.Touch([]byte(k))
+		}
+	}
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, server.HandleHotKeys(rec, httptest.NewRequest(http.MethodGet, AdminHotKeysRoute+"?limit=1", nil)))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var report HotKeysReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Equal(t, 3, report.Reads)
+	require.Equal(t, 2, report.Blobs)
+	require.Equal(t, int64(10), report.Bytes)
+	require.Len(t, report.Commitments, 1)
+	hot := report.Commitments[0]
+	require.Equal(t, "cert-c", string(hot.Key))
+	require.NotEmpty(t, hot.Commitment)
+	require.Equal(t, 2, hot.RecentReads)
+	require.Equal(t, 2, hot.TotalReads)
+
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleHotKeys(rec, httptest.NewRequest(http.MethodGet, AdminHotKeysRoute+"?limit=x", nil)))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleHotKeys(rec, httptest.NewRequest(http.MethodPost, AdminHotKeysRoute, nil)))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	// bounds of SETTINGS_MAX_FRAME_SIZE, see RFC 9113 section 6.5.2
+	minHTTP2FrameSize = 1 << 14
+	maxHTTP2FrameSize = 1<<24 - 1
+)
+
+// HTTP2Config ... cleartext HTTP/2 (h2c) on the proxy's listeners, next to HTTP/1.1, so that a single batcher
+// connection can multiplex many concurrent requests instead of opening a connection per in-flight request.
+// Clients connect with prior knowledge or upgrade an HTTP/1.1 connection.
+type HTTP2Config struct {
+	Enabled bool
+	// MaxConcurrentStreams is the number of requests a client can have in flight on a single connection
+	MaxConcurrentStreams uint32
+	// MaxReadFrameSize is the largest frame the proxy accepts from clients, larger frames need fewer round trips
+	// for blob uploads
+	MaxReadFrameSize uint32
+}
+
+// Check ... verifies that the HTTP/2 limits are adequately set
+func (c HTTP2Config) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxConcurrentStreams < 1 {
+		return fmt.Errorf("http2 max concurrent streams must be at least 1")
+	}
+	if c.MaxReadFrameSize < minHTTP2FrameSize || c.MaxReadFrameSize > maxHTTP2FrameSize {
+		return fmt.Errorf("http2 max read frame size must be between %d and %d bytes", minHTTP2FrameSize,
+			maxHTTP2FrameSize)
+	}
+	return nil
+}
+
+// configureHTTP2 ... serves h2c on srv, whose handler must be set. HTTP/2 connections are hijacked from the
+// HTTP/1.1 server, registering the HTTP/2 server with it lets Shutdown drain them gracefully.
+func (c HTTP2Config) configureHTTP2(srv *http.Server) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	h2s := &http2.Server{
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+		MaxReadFrameSize:     c.MaxReadFrameSize,
+	}
+	if err := http2.ConfigureServer(srv, h2s); err != nil {
+		return fmt.Errorf("failed to configure http2: %w", err)
+	}
+	srv.Handler = h2c.NewHandler(srv.Handler, h2s)
+	return nil
+}
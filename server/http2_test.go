@@ -0,0 +1,81 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+func TestHTTP2ConfigCheck(t *testing.T) {
+	require.NoError(t, HTTP2Config{}.Check())
+	require.NoError(t, HTTP2Config{Enabled: true, MaxConcurrentStreams: 250, MaxReadFrameSize: 1 << 20}.Check())
+	require.Error(t, HTTP2Config{Enabled: true, MaxReadFrameSize: 1 << 20}.Check())
+	require.Error(t, HTTP2Config{Enabled: true, MaxConcurrentStreams: 250, MaxReadFrameSize: 1 << 10}.Check())
+	require.Error(t, HTTP2Config{Enabled: true, MaxConcurrentStreams: 250, MaxReadFrameSize: 1 << 24}.Check())
+}
+
+func TestHTTP2(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		HTTP2: HTTP2Config{Enabled: true, MaxConcurrentStreams: 16, MaxReadFrameSize: 1 << 20},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+	endpoint := "http://" + svr.Endpoint()
+
+	// h2c client with prior knowledge, all requests are multiplexed over a single connection
+	var dials int
+	var mu sync.Mutex
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			mu.Lock()
+			dials++
+			mu.Unlock()
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := []byte(fmt.Sprintf("blob %d", i))
+			resp, err := client.Post(endpoint+"/put/", "application/octet-stream", bytes.NewReader(payload))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, 2, resp.ProtoMajor)
+			comm, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			resp, err = client.Get(fmt.Sprintf("%s/get/0x%x", endpoint, comm))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			b, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, payload, b)
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, 1, dials)
+
+	// HTTP/1.1 clients are still served
+	resp, err := http.Get(endpoint + "/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 1, resp.ProtoMajor)
+}
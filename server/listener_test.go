@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadListener(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Admin: AdminConfig{Enabled: true},
+		Gateway: GatewayConfig{
+			Enabled:   true,
+			RateLimit: 100,
+			Burst:     100,
+			APIKeys:   []string{"secret"},
+		},
+		ReadListener: ReadListenerConfig{Enabled: true, Addr: "127.0.0.1"},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	private := "http://" + svr.Endpoint()
+	public := "http://" + svr.ReadEndpoint()
+	require.NotEqual(t, private, public)
+
+	do := func(method, url, apiKey string, body []byte) (int, []byte) {
+		req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+		require.NoError(t, err)
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, b
+	}
+
+	// writes are only served on the private listener, without the gateway profile
+	payload := []byte("blob data")
+	code, comm := do(http.MethodPost, private+"/put/", "", payload)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodPost, public+"/put/", "secret", payload)
+	require.Equal(t, http.StatusNotFound, code)
+
+	get := fmt.Sprintf("/get/0x%x", comm)
+	code, b := do(http.MethodGet, private+get, "", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, payload, b)
+
+	// the public listener enforces the gateway profile
+	code, _ = do(http.MethodGet, public+get, "", nil)
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, b = do(http.MethodGet, public+get, "secret", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, payload, b)
+
+	code, _ = do(http.MethodGet, public+"/health", "", nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// operator routes stay private
+	code, _ = do(http.MethodGet, private+AdminStatusRoute, "", nil)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = do(http.MethodGet, public+AdminStatusRoute, "secret", nil)
+	require.Equal(t, http.StatusNotFound, code)
+	code, _ = do(http.MethodGet, public+VersionRoute, "secret", nil)
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -2,20 +2,35 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
 	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/azure"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/gcs"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/localcache"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/redis"
 	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 // populateTargets ... creates a list of storage backends based on the provided target strings
-func populateTargets(targets []string, s3 store.PrecomputedKeyStore, redis *redis.Store) []store.PrecomputedKeyStore {
+func populateTargets(targets []string, s3 store.PrecomputedKeyStore, redis *redis.Store, fs *fs.Store,
+	gcs *gcs.Store, azure *azure.Store, local *localcache.Store) []store.PrecomputedKeyStore {
 	stores := make([]store.PrecomputedKeyStore, len(targets))
 
 	for i, f := range targets {
@@ -34,6 +49,30 @@ func populateTargets(targets []string, s3 store.PrecomputedKeyStore, redis *redi
 			}
 			stores[i] = s3
 
+		case store.FSBackendType:
+			if fs == nil {
+				panic(fmt.Sprintf("FS backend is not configured but specified in targets: %s", f))
+			}
+			stores[i] = fs
+
+		case store.GCSBackendType:
+			if gcs == nil {
+				panic(fmt.Sprintf("GCS backend is not configured but specified in targets: %s", f))
+			}
+			stores[i] = gcs
+
+		case store.AzureBackendType:
+			if azure == nil {
+				panic(fmt.Sprintf("Azure backend is not configured but specified in targets: %s", f))
+			}
+			stores[i] = azure
+
+		case store.LocalBackendType:
+			if local == nil {
+				panic(fmt.Sprintf("Local cache is not configured but specified in targets: %s", f))
+			}
+			stores[i] = local
+
 		case store.EigenDABackendType, store.MemoryBackendType:
 			panic(fmt.Sprintf("Invalid target for fallback: %s", f))
 
@@ -48,38 +87,370 @@ func populateTargets(targets []string, s3 store.PrecomputedKeyStore, redis *redi
 	return stores
 }
 
+// loadKeccakStore ... returns the precomputed-key backend storing the payloads of keccak256 commitments, which is
+// nil if it is S3 and S3 isn't configured
+func loadKeccakStore(backend string, s3 store.PrecomputedKeyStore, redis *redis.Store, fs *fs.Store,
+	gcs *gcs.Store, azure *azure.Store, local *localcache.Store) (store.PrecomputedKeyStore, error) {
+	var s store.PrecomputedKeyStore
+	switch store.StringToBackendType(backend) {
+	case store.S3BackendType:
+		return s3, nil
+	case store.RedisBackendType:
+		if redis != nil {
+			s = redis
+		}
+	case store.FSBackendType:
+		if fs != nil {
+			s = fs
+		}
+	case store.GCSBackendType:
+		if gcs != nil {
+			s = gcs
+		}
+	case store.AzureBackendType:
+		if azure != nil {
+			s = azure
+		}
+	case store.LocalBackendType:
+		if local != nil {
+			s = local
+		}
+	default:
+		if backend == "" {
+			return s3, nil
+		}
+	}
+	if s == nil {
+		return nil, fmt.Errorf("keccak256 backend %s is not configured", backend)
+	}
+	return s, nil
+}
+
+// newEigenDAClient ... creates the EigenDA client, connecting to the disperser with tlsCfg if set. If pooled,
+// the disperser connection is kept open and shared between requests instead of being dialed per request. If a
+// bridge is configured, the disperser is reached through it instead of native gRPC. If several disperser endpoints
+// rpcs are configured, requests fail over between them.
+func newEigenDAClient(log log.Logger, clientCfg clients.EigenDAClientConfig, tlsCfg *tls.Config,
+	pooled bool, bridge eigenda.BridgeConfig, rpcs []string, m metrics.Metricer) (*clients.EigenDAClient, error) {
+	if bridge.URL != "" && clientCfg.RPC == "" {
+		// the EigenDA client requires a disperser address, even though it's only reached through the bridge
+		addr, err := bridge.Addr()
+		if err != nil {
+			return nil, err
+		}
+		clientCfg.RPC = addr
+	}
+	client, err := clients.NewEigenDAClient(log.With("subsystem", "eigenda-client"), clientCfg)
+	if err != nil || (tlsCfg == nil && !pooled && bridge.URL == "" && len(rpcs) == 0) {
+		return client, err
+	}
+
+	newDisperserClient := func(cfg clients.EigenDAClientConfig) (clients.DisperserClient, error) {
+		if pooled {
+			return eigenda.NewPooledDisperserClient(cfg, tlsCfg)
+		}
+		return eigenda.NewDisperserClient(cfg, tlsCfg)
+	}
+	switch {
+	case bridge.URL != "":
+		client.Client, err = eigenda.NewBridgeDisperserClient(client.Config, bridge, tlsCfg)
+	case len(rpcs) > 0:
+		client.Client, err = eigenda.NewFailoverDisperserClient(client.Config, rpcs, newDisperserClient, m, log)
+	default:
+		client.Client, err = newDisperserClient(client.Config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// watchSignerKey ... rebuilds the EigenDA client whenever the signer private key file changes, and swaps it in
+// with set
+func watchSignerKey(ctx context.Context, path string, clientCfg clients.EigenDAClientConfig, tlsCfg *tls.Config,
+	pooled bool, bridge eigenda.BridgeConfig, rpcs []string, set func(*clients.EigenDAClient), log log.Logger,
+	m metrics.Metricer) {
+	utils.WatchSecretFile(ctx, path, utils.DefaultFileWatchInterval,
+		func(key string) {
+			secrets.Register(strings.TrimPrefix(key, "0x"))
+			clientCfg.SignerPrivateKeyHex = key
+			client, err := newEigenDAClient(log, clientCfg, tlsCfg, pooled, bridge, rpcs, m)
+			if err != nil {
+				log.Error("Failed to create EigenDA client with rotated signer key", "file", path, "err", err)
+				return
+			}
+			set(client)
+			log.Info("Rotated EigenDA signer private key", "file", path)
+		},
+		func(err error) { log.Warn("Failed to watch signer private key file", "err", err) },
+	)
+}
+
+// loadTenantClients ... creates the EigenDA clients of the tenants dispersing to their own custom quorums or with
+// their own signer key. The other settings of the proxy's client apply to them too.
+func loadTenantClients(ctx context.Context, tenants map[string]tenancy.Tenant, daCfg Config, tlsCfg *tls.Config,
+	pooled bool, daStore *eigenda.Store, log log.Logger, m metrics.Metricer) error {
+	for _, name := range tenancy.Names(tenants) {
+		t := tenants[name]
+		if t.CustomQuorums == nil && t.SignerKeyFile == "" {
+			continue
+		}
+		clientCfg := daCfg.EdaClientConfig
+		if t.CustomQuorums != nil {
+			clientCfg.CustomQuorumIDs = t.CustomQuorums
+		}
+		if t.SignerKeyFile != "" {
+			key, err := utils.ReadSecretFile(t.SignerKeyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read signer key of namespace %s: %w", name, err)
+			}
+			secrets.Register(strings.TrimPrefix(key, "0x"))
+			clientCfg.SignerPrivateKeyHex = key
+		}
+		client, err := newEigenDAClient(log, clientCfg, tlsCfg, pooled, daCfg.DisperserBridge, daCfg.DisperserRPCs, m)
+		if err != nil {
+			return fmt.Errorf("failed to create EigenDA client of namespace %s: %w", name, err)
+		}
+		set := func(c *clients.EigenDAClient) { daStore.SetTenantClient(name, c) }
+		set(client)
+		log.Info("Dispersing blobs of namespace with its own config", "namespace", name,
+			"quorums", clientCfg.CustomQuorumIDs, "signer", t.SignerKeyFile != "")
+		if t.SignerKeyFile != "" {
+			go watchSignerKey(ctx, t.SignerKeyFile, clientCfg, tlsCfg, pooled, daCfg.DisperserBridge,
+				daCfg.DisperserRPCs, set, log.With("namespace", name), m)
+		}
+	}
+	return nil
+}
+
+// loadPrefixRoutes ... resolves the backends of the configured prefix routes
+func loadPrefixRoutes(cfg Config, eigenDA store.GeneratedKeyStore, s3 store.PrecomputedKeyStore, redis *redis.Store,
+	fs *fs.Store, gcs *gcs.Store, azure *azure.Store, local *localcache.Store,
+	log log.Logger) ([]store.PrefixRoute, error) {
+	routeCfgs, err := cfg.ParsePrefixRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]store.PrefixRoute, 0, len(routeCfgs))
+	for _, rc := range routeCfgs {
+		route := store.PrefixRoute{Prefix: rc.Prefix}
+		for _, name := range rc.Pipeline {
+			var s store.Store
+			switch store.StringToBackendType(name) {
+			case store.EigenDABackendType:
+				if eigenDA != nil {
+					s = eigenDA
+				}
+			case store.S3BackendType:
+				s = s3
+			case store.RedisBackendType:
+				if redis != nil {
+					s = redis
+				}
+			case store.FSBackendType:
+				if fs != nil {
+					s = fs
+				}
+			case store.GCSBackendType:
+				if gcs != nil {
+					s = gcs
+				}
+			case store.AzureBackendType:
+				if azure != nil {
+					s = azure
+				}
+			case store.LocalBackendType:
+				if local != nil {
+					s = local
+				}
+			}
+			if s == nil {
+				return nil, fmt.Errorf("%s backend is not configured but specified in prefix route %x", name, rc.Prefix)
+			}
+			route.Pipeline = append(route.Pipeline, s)
+		}
+
+		pipeline := "default"
+		if len(rc.Pipeline) > 0 {
+			pipeline = strings.Join(rc.Pipeline, "+")
+		}
+		log.Info("Routing reads by commitment prefix", "prefix", fmt.Sprintf("0x%x", rc.Prefix), "pipeline", pipeline)
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// discoverEndpoint ... returns the endpoint a store connects to at startup when its endpoint is discovered
+func discoverEndpoint(ctx context.Context, source string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, utils.DefaultDiscoveryInterval)
+	defer cancel()
+	endpoints, err := utils.ResolveEndpoints(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	return utils.SelectEndpoint("", endpoints), nil
+}
+
+// watchEndpoint ... switches a store to another discovered endpoint once its current one is no longer advertised
+// by the discovery source
+func watchEndpoint(ctx context.Context, backend, source, current string, setEndpoint func(string) error,
+	log log.Logger) {
+	log.Info("Discovered endpoint", "backend", backend, "source", source, "endpoint", current)
+	utils.WatchEndpoints(ctx, source, current, utils.DefaultDiscoveryInterval,
+		func(endpoint string) error {
+			if err := setEndpoint(endpoint); err != nil {
+				return err
+			}
+			log.Info("Switched to discovered endpoint", "backend", backend, "source", source, "endpoint", endpoint)
+			return nil
+		},
+		func(err error) { log.Warn("Failed to discover endpoint", "backend", backend, "err", err) },
+	)
+}
+
 // LoadStoreRouter ... creates storage backend clients and instruments them into a storage routing abstraction
-func LoadStoreRouter(ctx context.Context, cfg CLIConfig, log log.Logger) (store.IRouter, error) {
+func LoadStoreRouter(ctx context.Context, cfg CLIConfig, log log.Logger, m metrics.Metricer) (store.IRouter, error) {
+	startup := startupTrackerFromContext(ctx)
+	backendsDone := startup.Begin(StartupPhaseBackends)
+
 	// create S3 backend store (if enabled)
-	var err error
 	var s3Store store.PrecomputedKeyStore
 	var redisStore *redis.Store
+	var fsStore *fs.Store
+	var gcsStore *gcs.Store
+	var azureStore *azure.Store
+	var localStore *localcache.Store
+
+	tenants, err := cfg.EigenDAConfig.Tenancy.Tenants()
+	if err != nil {
+		return nil, err
+	}
 
-	if cfg.EigenDAConfig.S3Config.Bucket != "" && cfg.EigenDAConfig.S3Config.Endpoint != "" {
+	s3Cfg := &cfg.EigenDAConfig.S3Config
+	for name, t := range tenants {
+		if t.S3Path != "" {
+			if s3Cfg.TenantPaths == nil {
+				s3Cfg.TenantPaths = make(map[string]string)
+			}
+			s3Cfg.TenantPaths[name] = t.S3Path
+		}
+	}
+	if s3Cfg.Bucket != "" && (s3Cfg.Endpoint != "" || s3Cfg.EndpointDiscovery != "") {
 		log.Info("Using S3 backend")
-		s3Store, err = s3.NewS3(cfg.EigenDAConfig.S3Config)
+		if s3Cfg.EndpointDiscovery != "" {
+			if s3Cfg.Endpoint, err = discoverEndpoint(ctx, s3Cfg.EndpointDiscovery); err != nil {
+				return nil, fmt.Errorf("failed to discover S3 endpoint: %w", err)
+			}
+		}
+		s3Backend, err := s3.NewS3(log, *s3Cfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create S3 store: %w", err)
 		}
+		if s3Cfg.EndpointDiscovery != "" {
+			go watchEndpoint(ctx, "S3", s3Cfg.EndpointDiscovery, s3Cfg.Endpoint, s3Backend.SetEndpoint, log)
+		}
+		s3Store = s3Backend
 	}
 
-	if cfg.EigenDAConfig.RedisConfig.Endpoint != "" {
+	redisCfg := &cfg.EigenDAConfig.RedisConfig
+	if redisCfg.Endpoint != "" || redisCfg.EndpointDiscovery != "" {
 		log.Info("Using Redis backend")
+		if redisCfg.EndpointDiscovery != "" {
+			if redisCfg.Endpoint, err = discoverEndpoint(ctx, redisCfg.EndpointDiscovery); err != nil {
+				return nil, fmt.Errorf("failed to discover Redis endpoint: %w", err)
+			}
+		}
+		if cfg.EigenDAConfig.RedisConfig.PasswordFile != "" {
+			cfg.EigenDAConfig.RedisConfig.Password, err = utils.ReadSecretFile(cfg.EigenDAConfig.RedisConfig.PasswordFile)
+			if err != nil {
+				return nil, err
+			}
+		}
 		// create Redis backend store
-		redisStore, err = redis.NewStore(&cfg.EigenDAConfig.RedisConfig)
+		redisStore, err = redis.NewStore(log, &cfg.EigenDAConfig.RedisConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Redis store: %w", err)
 		}
+
+		if path := cfg.EigenDAConfig.RedisConfig.PasswordFile; path != "" {
+			go utils.WatchSecretFile(ctx, path, utils.DefaultFileWatchInterval,
+				func(password string) {
+					if err := redisStore.SetPassword(password); err != nil {
+						log.Error("Failed to reconnect to Redis with rotated password", "file", path, "err", err)
+						return
+					}
+					log.Info("Reconnected to Redis with rotated password", "file", path)
+				},
+				func(err error) { log.Warn("Failed to watch Redis password file", "err", err) },
+			)
+		}
+		if redisCfg.EndpointDiscovery != "" {
+			go watchEndpoint(ctx, "Redis", redisCfg.EndpointDiscovery, redisCfg.Endpoint, redisStore.SetEndpoint, log)
+		}
 	}
 
+	if cfg.EigenDAConfig.FSConfig.Path != "" {
+		log.Info("Using filesystem backend", "path", cfg.EigenDAConfig.FSConfig.Path)
+		fsStore, err = fs.NewStore(log, cfg.EigenDAConfig.FSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create filesystem store: %w", err)
+		}
+		if cfg.EigenDAConfig.FSConfig.CompactionInterval > 0 {
+			go fsStore.RunCompaction(ctx)
+		}
+	}
+
+	if gcsCfg := cfg.EigenDAConfig.GCSConfig; gcsCfg.Bucket != "" {
+		log.Info("Using GCS backend", "bucket", gcsCfg.Bucket, "credential type", gcsCfg.CredentialType)
+		gcsStore, err = gcs.NewStore(ctx, log, gcsCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCS store: %w", err)
+		}
+	}
+
+	if azureCfg := cfg.EigenDAConfig.AzureConfig; azureCfg.Container != "" {
+		log.Info("Using Azure backend", "container", azureCfg.Container, "credential type", azureCfg.CredentialType)
+		azureStore, err = azure.NewStore(log, azureCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure store: %w", err)
+		}
+	}
+
+	if utils.Contains(cfg.EigenDAConfig.CacheTargets, "local") {
+		localCfg := cfg.EigenDAConfig.LocalCacheConfig
+		log.Info("Using local cache", "memory max bytes", localCfg.MemoryMaxBytes, "disk path", localCfg.DiskPath,
+			"disk max bytes", localCfg.DiskMaxBytes)
+		localStore, err = localcache.NewStore(log, localCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local cache: %w", err)
+		}
+	}
+
+	backendsDone()
+
 	// create cert/data verification type
 	daCfg := cfg.EigenDAConfig
 	vCfg := daCfg.VerifierConfig
 
+	verifierStart, heapBefore := time.Now(), heapAlloc()
 	verifier, err := verify.NewVerifier(&vCfg, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create verifier: %w", err)
 	}
+	// the verifier's heap is almost entirely taken by the SRS points
+	srsDuration, certVerifierDuration := verifier.LoadDurations()
+	startup.Record(StartupPhase{
+		Name:            StartupPhaseCertVerifier,
+		Start:           verifierStart,
+		DurationSeconds: certVerifierDuration.Seconds(),
+	})
+	startup.Record(StartupPhase{
+		Name:            StartupPhaseSRSLoad,
+		Start:           verifierStart.Add(certVerifierDuration),
+		DurationSeconds: srsDuration.Seconds(),
+		HeapGrowthBytes: int64(heapAlloc()) - int64(heapBefore), // #nosec G115
+	})
 
 	if vCfg.VerifyCerts {
 		log.Info("Certificate verification with Ethereum enabled")
@@ -88,38 +459,212 @@ func LoadStoreRouter(ctx context.Context, cfg CLIConfig, log log.Logger) (store.
 	}
 
 	// create EigenDA backend store
+	eigenDADone := startup.Begin(StartupPhaseEigenDA)
 	var eigenDA store.GeneratedKeyStore
 	if cfg.EigenDAConfig.MemstoreEnabled {
 		log.Info("Using mem-store backend for EigenDA")
 		eigenDA, err = memstore.New(ctx, verifier, log, cfg.EigenDAConfig.MemstoreConfig)
+		for _, t := range tenants {
+			if t.CustomQuorums != nil || t.SignerKeyFile != "" {
+				log.Warn("Namespace quorums and signer keys are ignored by the mem-store backend")
+				break
+			}
+		}
 	} else {
 		var client *clients.EigenDAClient
 		log.Info("Using EigenDA backend")
-		client, err = clients.NewEigenDAClient(log.With("subsystem", "eigenda-client"), daCfg.EdaClientConfig)
+		if daCfg.SignerPrivateKeyFile != "" {
+			daCfg.EdaClientConfig.SignerPrivateKeyHex, err = utils.ReadSecretFile(daCfg.SignerPrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			secrets.Register(strings.TrimPrefix(daCfg.EdaClientConfig.SignerPrivateKeyHex, "0x"))
+		}
+
+		var tlsCfg *tls.Config
+		if daCfg.DisperserTLS.IsSet() {
+			log.Info("Using custom TLS config for the disperser connection")
+			tlsCfg, err = daCfg.DisperserTLS.Load()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load disperser tls config: %w", err)
+			}
+		}
+
+		pooled := daCfg.WarmPoolInterval > 0
+		if daCfg.DisperserBridge.URL != "" {
+			log.Warn("Reaching the disperser through an HTTP bridge, dispersals are unauthenticated",
+				"url", daCfg.DisperserBridge.URL, "protocol", daCfg.DisperserBridge.Protocol)
+		}
+		if len(daCfg.DisperserRPCs) > 0 {
+			log.Info("Failing over between disperser endpoints", "endpoints", daCfg.DisperserRPCs)
+		}
+		client, err = newEigenDAClient(log, daCfg.EdaClientConfig, tlsCfg, pooled, daCfg.DisperserBridge,
+			daCfg.DisperserRPCs, m)
+		if err != nil {
+			return nil, err
+		}
+
+		storeCfg := &eigenda.StoreConfig{
+			MaxBlobSizeBytes:              cfg.EigenDAConfig.MemstoreConfig.MaxBlobSizeBytes,
+			EthConfirmationDepth:          cfg.EigenDAConfig.VerifierConfig.EthConfirmationDepth,
+			StatusQueryTimeout:            cfg.EigenDAConfig.EdaClientConfig.StatusQueryTimeout,
+			PrevalidateCommitment:         cfg.EigenDAConfig.PrevalidateCommitment,
+			CustomQuorumFallbackAfter:     cfg.EigenDAConfig.CustomQuorumFallbackAfter,
+			PendingDispersalsPath:         cfg.EigenDAConfig.PendingDispersalsPath,
+			PendingDispersalsMaxAge:       cfg.EigenDAConfig.PendingDispersalsMaxAge,
+			StatusQueryQPS:                cfg.EigenDAConfig.StatusQueryQPS,
+			StatusQueryParallelism:        cfg.EigenDAConfig.StatusQueryParallelism,
+			RetrieverRPCs:                 cfg.EigenDAConfig.RetrieverRPCs,
+			RetrieverDisableTLS:           cfg.EigenDAConfig.RetrieverDisableTLS,
+			PointVerificationModeFallback: cfg.EigenDAConfig.PointVerificationModeFallback,
+		}
+		if key := cfg.EigenDAConfig.PendingDispersalsRedisKey; key != "" && redisStore != nil {
+			storeCfg.PendingDispersalsRedisKey = key
+			storeCfg.PendingDispersalsRedis = redisStore
+		}
+
+		var daStore *eigenda.Store
+		daStore, err = eigenda.NewStore(client, verifier, log, storeCfg)
 		if err != nil {
 			return nil, err
 		}
+		eigenDA = daStore
+
+		if n := len(daStore.PendingDispersals()); n > 0 {
+			log.Warn("Found journaled dispersals, resuming their status polling", "pending", n)
+			go daStore.RecoverDispersals(ctx)
+		}
+		if interval := cfg.EigenDAConfig.PendingDispersalsRetryInterval; interval > 0 {
+			go daStore.RetryPendingDispersals(ctx, interval)
+		}
+		if interval := cfg.EigenDAConfig.MaxBlobSizeNegotiationInterval; interval > 0 {
+			// oversized configs are refused upfront rather than failing dispersals at runtime
+			_, nerr := daStore.NegotiateMaxBlobSize(ctx)
+			if errors.Is(nerr, eigenda.ErrMaxBlobSizeExceedsDisperser) {
+				return nil, nerr
+			} else if nerr != nil {
+				log.Warn("Failed to negotiate max blob size with the disperser", "err", nerr)
+			}
+			go daStore.RunMaxBlobSizeNegotiation(ctx, interval)
+		}
 
-		eigenDA, err = eigenda.NewStore(
-			client,
-			verifier,
-			log,
-			&eigenda.StoreConfig{
-				MaxBlobSizeBytes:     cfg.EigenDAConfig.MemstoreConfig.MaxBlobSizeBytes,
-				EthConfirmationDepth: cfg.EigenDAConfig.VerifierConfig.EthConfirmationDepth,
-				StatusQueryTimeout:   cfg.EigenDAConfig.EdaClientConfig.StatusQueryTimeout,
-			},
-		)
+		if daCfg.SignerPrivateKeyFile != "" {
+			go watchSignerKey(ctx, daCfg.SignerPrivateKeyFile, daCfg.EdaClientConfig, tlsCfg, pooled, daCfg.DisperserBridge,
+				daCfg.DisperserRPCs, daStore.SetClient, log, m)
+		}
+		if err := loadTenantClients(ctx, tenants, daCfg, tlsCfg, pooled, daStore, log, m); err != nil {
+			return nil, err
+		}
 	}
 
 	if err != nil {
 		return nil, err
 	}
+	eigenDADone()
+	routerDone := startup.Begin(StartupPhaseRouter)
 
 	// determine read fallbacks
-	fallbacks := populateTargets(cfg.EigenDAConfig.FallbackTargets, s3Store, redisStore)
-	caches := populateTargets(cfg.EigenDAConfig.CacheTargets, s3Store, redisStore)
+	fallbacks := populateTargets(cfg.EigenDAConfig.FallbackTargets, s3Store, redisStore, fsStore, gcsStore, azureStore,
+		localStore)
+	caches := populateTargets(cfg.EigenDAConfig.CacheTargets, s3Store, redisStore, fsStore, gcsStore, azureStore,
+		localStore)
+
+	roles, err := cfg.EigenDAConfig.ParseTargetRoles()
+	if err != nil {
+		return nil, err
+	}
+	for b, role := range roles {
+		log.Info("Restricting target role", "backend", b, "role", role)
+	}
+
+	prefixRoutes, err := loadPrefixRoutes(cfg.EigenDAConfig, eigenDA, s3Store, redisStore, fsStore, gcsStore,
+		azureStore, localStore, log)
+	if err != nil {
+		return nil, err
+	}
+
+	quotas, err := cfg.EigenDAConfig.ParseQuotas()
+	if err != nil {
+		return nil, err
+	}
+	for b, q := range quotas {
+		log.Info("Enforcing backend quota", "backend", b, "max bytes", q.MaxBytes, "policy", q.Policy)
+	}
+
+	deadLetters, err := deadletter.New(cfg.EigenDAConfig.DeadLetterPath)
+	if err != nil {
+		return nil, err
+	}
+	if n := deadLetters.Len(); n > 0 {
+		log.Warn("Dead-letter queue contains failed redundant writes", "tasks", n)
+	}
+
+	// every configured secondary backend can be requested by writes, see store.WithRedundancy
+	var sinks []store.PrecomputedKeyStore
+	if s3Store != nil {
+		sinks = append(sinks, s3Store)
+	}
+	if redisStore != nil {
+		sinks = append(sinks, redisStore)
+	}
+	if fsStore != nil {
+		sinks = append(sinks, fsStore)
+	}
+	if gcsStore != nil {
+		sinks = append(sinks, gcsStore)
+	}
+	if azureStore != nil {
+		sinks = append(sinks, azureStore)
+	}
+	if localStore != nil {
+		sinks = append(sinks, localStore)
+	}
+
+	keccakStore, err := loadKeccakStore(cfg.EigenDAConfig.KeccakBackend, s3Store, redisStore, fsStore, gcsStore,
+		azureStore, localStore)
+	if err != nil {
+		return nil, err
+	}
+	if keccakStore != nil && keccakStore != s3Store {
+		log.Info("Storing keccak256 commitments outside of S3", "backend", keccakStore.BackendType())
+	}
+
+	idx := index.New(index.DefaultMaxEntries)
+	idx.SetAccessOptions(cfg.EigenDAConfig.AccessStats)
+	if path := cfg.EigenDAConfig.QuotaUsagePath; path != "" {
+		n, err := store.LoadQuotaUsage(idx, path)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Restored quota usage", "path", path, "entries", n)
+	}
 
 	log.Info("Creating storage router", "eigenda backend type", eigenDA != nil, "s3 backend type", s3Store != nil)
-	return store.NewRouter(eigenDA, s3Store, log, caches, fallbacks)
+	router, err := store.NewRouter(eigenDA, keccakStore, log, caches, fallbacks, store.RouterOptions{
+		Index:       idx,
+		Quotas:      quotas,
+		Roles:       roles,
+		DeadLetters: deadLetters,
+		Reconcile: store.ReconcileOptions{
+			SampleSize: cfg.EigenDAConfig.ReconcileSampleSize,
+			Repair:     store.ReconcileRepair(cfg.EigenDAConfig.ReconcileRepair),
+		},
+		Tiering:           cfg.EigenDAConfig.Tiering,
+		CoalesceReads:     cfg.EigenDAConfig.CoalesceReads,
+		RefreshStaleCerts: cfg.EigenDAConfig.RefreshStaleCerts,
+		ReadSLO:           cfg.EigenDAConfig.ReadSLO,
+		HedgeDelay:        cfg.EigenDAConfig.HedgeDelay,
+		Envelope:          cfg.EigenDAConfig.Envelope,
+		PrefixRoutes:      prefixRoutes,
+		Sinks:             sinks,
+		Degradation:       cfg.EigenDAConfig.Degradation,
+		Breakers:          cfg.EigenDAConfig.Breakers,
+		Chunking:          cfg.EigenDAConfig.Chunking,
+		Metrics:           m,
+	})
+	if err != nil {
+		return nil, err
+	}
+	routerDone()
+	return router, nil
 }
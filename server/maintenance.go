@@ -0,0 +1,191 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+)
+
+const (
+	// AdminMaintenanceRoute ... returns the maintenance state on GET, enters maintenance mode on POST and leaves it
+	// on DELETE, given the token returned when entering it
+	AdminMaintenanceRoute = AdminRoute + "maintenance"
+
+	maintenanceReasonParam     = "reason"
+	maintenanceRetryAfterParam = "retry_after"
+	maintenanceTokenParam      = "token"
+
+	// defaultMaintenanceRetryAfter ... Retry-After of rejected writes if none is given when entering maintenance mode
+	defaultMaintenanceRetryAfter = time.Minute
+)
+
+var (
+	// ErrMaintenance ... returned for writes received while the proxy is in maintenance mode
+	ErrMaintenance = errors.New("writes are suspended while the proxy is in maintenance mode")
+	// errMaintenanceActive ... the proxy is in maintenance mode already, possibly entered by another operator
+	errMaintenanceActive = errors.New("proxy is in maintenance mode already")
+	// errMaintenanceInactive ... the proxy isn't in maintenance mode
+	errMaintenanceInactive = errors.New("proxy is not in maintenance mode")
+	// errMaintenanceToken ... the token doesn't match the one returned when entering maintenance mode
+	errMaintenanceToken = errors.New("maintenance token does not match")
+)
+
+// MaintenanceStatus ... maintenance state returned by the admin maintenance endpoint
+type MaintenanceStatus struct {
+	Active            bool      `json:"active"`
+	Reason            string    `json:"reason,omitempty"`
+	Since             time.Time `json:"since,omitempty"`
+	RetryAfterSeconds int       `json:"retry_after_seconds,omitempty"`
+	// Token is only returned when entering maintenance mode, it is required to leave it
+	Token string `json:"token,omitempty"`
+	// InFlightWrites and PendingDispersals are drained while writes are frozen, upgrades can proceed once both
+	// reached 0
+	InFlightWrites    int `json:"in_flight_writes"`
+	PendingDispersals int `json:"pending_dispersals"`
+}
+
+// maintenance ... tracks whether writes are frozen. The token returned when entering maintenance mode is
+// required to leave it, so that an operator can't accidentally unfreeze writes frozen by another one.
+type maintenance struct {
+	mu         sync.Mutex
+	token      string
+	reason     string
+	since      time.Time
+	retryAfter time.Duration
+}
+
+// enter ... freezes writes and returns the token required to unfreeze them
+func (m *maintenance) enter(reason string, retryAfter time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token != "" {
+		return "", errMaintenanceActive
+	}
+	m.token = newRequestID()
+	m.reason = reason
+	m.since = time.Now()
+	m.retryAfter = retryAfter
+	return m.token, nil
+}
+
+// exit ... unfreezes writes if token is the one returned when they were frozen
+func (m *maintenance) exit(token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token == "" {
+		return errMaintenanceInactive
+	}
+	if !secrets.Equal(m.token, token) {
+		return errMaintenanceToken
+	}
+	m.token, m.reason, m.since, m.retryAfter = "", "", time.Time{}, 0
+	return nil
+}
+
+// active ... returns the Retry-After of rejected writes, and whether writes are frozen
+func (m *maintenance) active() (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.retryAfter, m.token != ""
+}
+
+// describe ... describes why writes are frozen, or returns an empty string if they aren't
+func (m *maintenance) describe() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token == "" {
+		return ""
+	}
+	desc := fmt.Sprintf("maintenance mode since %s, writes are rejected", m.since.UTC().Format(time.RFC3339))
+	if m.reason != "" {
+		desc += ": " + m.reason
+	}
+	return desc
+}
+
+func (m *maintenance) status() MaintenanceStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token == "" {
+		return MaintenanceStatus{}
+	}
+	return MaintenanceStatus{
+		Active:            true,
+		Reason:            m.reason,
+		Since:             m.since,
+		RetryAfterSeconds: retryAfterSeconds(m.retryAfter),
+	}
+}
+
+// retryAfterSeconds ... rounds d up to the whole seconds of a Retry-After header
+func retryAfterSeconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
+
+// writeMaintenance ... rejects a write received in maintenance mode, if the proxy is in it
+func (svr *Server) writeMaintenance(w http.ResponseWriter) bool {
+	retryAfter, ok := svr.maintenance.active()
+	if !ok {
+		return false
+	}
+	// batchers are expected to retry once the upgrade is over, rather than fail over
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+	w.WriteHeader(http.StatusServiceUnavailable)
+	return true
+}
+
+// HandleMaintenance ... freezes and unfreezes writes, e.g. during EigenDA network upgrades. While frozen, PUTs
+// are rejected with a 503 and a Retry-After header, reads keep being served and in-flight dispersals complete.
+// Example: POST /admin/maintenance?reason=holesky+upgrade&retry_after=10m, then
+// DELETE /admin/maintenance?token=<token returned by the POST>
+func (svr *Server) HandleMaintenance(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	var token string
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		retryAfter := defaultMaintenanceRetryAfter
+		if raw := q.Get(maintenanceRetryAfterParam); raw != "" {
+			d, err := time.ParseDuration(raw)
+			if err != nil || d <= 0 {
+				err = fmt.Errorf("invalid %s %q: expected a positive duration", maintenanceRetryAfterParam, raw)
+				svr.WriteBadRequest(w, err)
+				return err
+			}
+			retryAfter = d
+		}
+		var err error
+		if token, err = svr.maintenance.enter(q.Get(maintenanceReasonParam), retryAfter); err != nil {
+			w.WriteHeader(http.StatusConflict)
+			return err
+		}
+		svr.log.Warn("Entered maintenance mode, rejecting writes", "reason", q.Get(maintenanceReasonParam),
+			"retry_after", retryAfter)
+	case http.MethodDelete:
+		switch err := svr.maintenance.exit(q.Get(maintenanceTokenParam)); {
+		case errors.Is(err, errMaintenanceToken):
+			svr.WriteForbidden(w, err)
+			return err
+		case err != nil:
+			w.WriteHeader(http.StatusConflict)
+			return err
+		}
+		svr.log.Info("Left maintenance mode, accepting writes")
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	status := svr.maintenance.status()
+	status.Token = token
+	status.InFlightWrites = svr.status.inFlightCount(http.MethodPost) + svr.status.inFlightCount(http.MethodPut)
+	if s, ok := svr.pendingDispersals(); ok {
+		status.PendingDispersals = len(s.PendingDispersals())
+	}
+	return svr.writeJSON(w, status)
+}
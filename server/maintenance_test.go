@@ -0,0 +1,83 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().GetEigenDAStore().Return(nil).AnyTimes()
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{Admin: AdminConfig{Enabled: true}})
+
+	maintenance := func(method, query string) (*httptest.ResponseRecorder, MaintenanceStatus) {
+		rec := httptest.NewRecorder()
+		_ = server.HandleMaintenance(rec, httptest.NewRequest(method, AdminMaintenanceRoute+query, nil))
+		var status MaintenanceStatus
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		}
+		return rec, status
+	}
+	put := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		_, _ = server.HandlePut(rec, httptest.NewRequest(http.MethodPost, "/put/", bytes.NewReader([]byte("data"))))
+		return rec
+	}
+
+	rec, status := maintenance(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, status.Active)
+
+	rec, _ = maintenance(http.MethodPost, "?retry_after=-1s")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec, status = maintenance(http.MethodPost, "?reason=upgrade&retry_after=90s")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, status.Active)
+	require.Equal(t, "upgrade", status.Reason)
+	require.Equal(t, 90, status.RetryAfterSeconds)
+	require.NotEmpty(t, status.Token)
+	token := status.Token
+
+	// a second operator can't enter maintenance mode again, nor leave it without the token
+	rec, _ = maintenance(http.MethodPost, "")
+	require.Equal(t, http.StatusConflict, rec.Code)
+	rec, _ = maintenance(http.MethodDelete, "?token=wrong")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = put()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "90", rec.Header().Get("Retry-After"))
+
+	rec, status = maintenance(http.MethodGet, "")
+	require.True(t, status.Active)
+	require.Empty(t, status.Token)
+
+	healthRec := httptest.NewRecorder()
+	require.NoError(t, server.Health(healthRec, httptest.NewRequest(http.MethodGet, "/health", nil)))
+	var health HealthStatus
+	require.NoError(t, json.Unmarshal(healthRec.Body.Bytes(), &health))
+	require.Equal(t, "degraded", health.Status)
+
+	rec, status = maintenance(http.MethodDelete, "?token="+token)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.False(t, status.Active)
+	rec, _ = maintenance(http.MethodDelete, "?token="+token)
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return([]byte(testCommitStr), nil)
+	require.Equal(t, http.StatusOK, put().Code)
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// results of mirrored requests, as recorded by metrics.Metricer.RecordMirroredRequest
+const (
+	// MirrorMatch ... the shadow proxy answered like the proxy
+	MirrorMatch = "match"
+	// MirrorStatusMismatch ... the shadow proxy answered with another status code
+	MirrorStatusMismatch = "status_mismatch"
+	// MirrorCommitmentMismatch ... the shadow proxy returned another commitment for a write
+	MirrorCommitmentMismatch = "commitment_mismatch"
+	// MirrorBlobMismatch ... the shadow proxy returned another blob for a GET
+	MirrorBlobMismatch = "blob_mismatch"
+	// MirrorError ... the request to the shadow proxy failed
+	MirrorError = "error"
+	// MirrorDropped ... the request wasn't mirrored because MaxInFlight requests were already in flight
+	MirrorDropped = "dropped"
+)
+
+// MirrorConfig ... asynchronous mirroring of a share of the PUT and GET requests to a shadow proxy, e.g. a new
+// version under test, comparing its responses to the proxy's. Mirroring is disabled when URL isn't set.
+type MirrorConfig struct {
+	// URL of the shadow proxy
+	URL string
+	// Percentage of the requests mirrored, between 0 and 100
+	Percentage float64
+	// Timeout of a mirrored request
+	Timeout time.Duration
+	// MaxInFlight caps the mirrored requests in flight, further requests aren't mirrored
+	MaxInFlight int
+	// ForwardCredentials forwards the Authorization header of mirrored requests, e.g. to a shadow proxy
+	// authenticating namespaces. Only the proxy headers are forwarded otherwise.
+	ForwardCredentials bool
+}
+
+// Enabled ... whether requests are mirrored
+func (c MirrorConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// Check ... validates the mirroring config
+func (c MirrorConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid mirror url %q", c.URL)
+	}
+	if c.Percentage < 0 || c.Percentage > 100 {
+		return fmt.Errorf("mirror percentage must be between 0 and 100")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("mirror timeout must be positive")
+	}
+	if c.MaxInFlight < 1 {
+		return fmt.Errorf("mirror max in flight must be at least 1")
+	}
+	return nil
+}
+
+// mirror ... sends sampled requests to the shadow proxy and reports how its responses diverge
+type mirror struct {
+	cfg      MirrorConfig
+	client   *http.Client
+	inFlight chan struct{}
+	m        metrics.Metricer
+	log      log.Logger
+	// sample returns whether a request is mirrored, replaced in tests
+	sample func() bool
+}
+
+func newMirror(cfg MirrorConfig, m metrics.Metricer, log log.Logger) *mirror {
+	if !cfg.Enabled() {
+		return nil
+	}
+	return &mirror{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		inFlight: make(chan struct{}, cfg.MaxInFlight),
+		m:        m,
+		log:      log.With("subsystem", "mirror"),
+		sample:   func() bool { return rand.Float64()*100 < cfg.Percentage }, // #nosec G404
+	}
+}
+
+// mirrorRecorder ... records the status code and a digest of the body of the proxy's response. The body is kept
+// as well if keep is set, i.e. for the commitments returned by writes.
+type mirrorRecorder struct {
+	http.ResponseWriter
+	status int
+	digest hash.Hash
+	keep   bool
+	body   bytes.Buffer
+}
+
+func (w *mirrorRecorder) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *mirrorRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.digest.Write(b)
+	if w.keep {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap ... lets http.ResponseController flush streamed responses
+func (w *mirrorRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// mirroredResponse ... the proxy's response to a mirrored request
+type mirroredResponse struct {
+	method string
+	status int
+	digest []byte
+	body   []byte
+	mode   commitments.CommitmentMode
+}
+
+// WithMirror is a middleware that mirrors a sampled share of the requests to the shadow proxy once they're
+// served, without delaying the response. Nothing is mirrored if mr is nil.
+func WithMirror(
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	mr *mirror,
+) func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error) {
+	if mr == nil {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+		if (r.Method != http.MethodGet && r.Method != http.MethodPost && r.Method != http.MethodPut) || !mr.sample() {
+			return handleFn(w, r)
+		}
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return commitments.CommitmentMeta{}, fmt.Errorf("failed to read request body: %w", err)
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		rec := &mirrorRecorder{ResponseWriter: w, digest: sha256.New(), keep: r.Method != http.MethodGet}
+		meta, err := handleFn(rec, r)
+
+		resp := mirroredResponse{
+			method: r.Method,
+			status: rec.status,
+			digest: rec.digest.Sum(nil),
+			body:   rec.body.Bytes(),
+			mode:   meta.Mode,
+		}
+		if resp.status == 0 {
+			resp.status = http.StatusOK
+		}
+		mr.send(r, body, resp)
+		return meta, err
+	}
+}
+
+// send ... replays the request on the shadow proxy in the background, unless MaxInFlight mirrored requests are
+// already in flight
+func (mr *mirror) send(r *http.Request, body []byte, resp mirroredResponse) {
+	select {
+	case mr.inFlight <- struct{}{}:
+	default:
+		mr.m.RecordMirroredRequest(resp.method, MirrorDropped)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), r.Method, mr.cfg.URL+r.URL.RequestURI(),
+		bytes.NewReader(body))
+	if err != nil {
+		<-mr.inFlight
+		mr.m.RecordMirroredRequest(resp.method, MirrorError)
+		return
+	}
+	req.Header = mr.header(r.Header)
+
+	go func() {
+		defer func() { <-mr.inFlight }()
+		result := mr.compare(req, resp)
+		mr.m.RecordMirroredRequest(resp.method, result)
+		if result != MirrorMatch {
+			mr.log.Warn("Shadow proxy response diverged", "method", resp.method, "path", req.URL.Path, "result", result)
+		}
+	}()
+}
+
+// header ... headers of a mirrored request: the headers the handlers read, see journaledHeaders, and its
+// credentials if they are forwarded. Other headers, e.g. cookies or API keys of a gateway in front of the proxy,
+// never leave the proxy.
+func (mr *mirror) header(h http.Header) http.Header {
+	mirrored := journaledHeader(h)
+	if v := h.Values("Authorization"); mr.cfg.ForwardCredentials && len(v) > 0 {
+		mirrored["Authorization"] = append([]string(nil), v...)
+	}
+	return mirrored
+}
+
+// compare ... sends req to the shadow proxy and compares its response to the proxy's. Commitments are only
+// compared in optimism keccak mode, as certs of two dispersals of the same blob differ.
+func (mr *mirror) compare(req *http.Request, resp mirroredResponse) string {
+	shadow, err := mr.client.Do(req)
+	if err != nil {
+		mr.log.Debug("Mirrored request failed", "err", err)
+		return MirrorError
+	}
+	defer shadow.Body.Close()
+
+	digest := sha256.New()
+	var body bytes.Buffer
+	w := io.Writer(digest)
+	if resp.method != http.MethodGet {
+		w = io.MultiWriter(digest, &body)
+	}
+	if _, err := io.Copy(w, shadow.Body); err != nil {
+		mr.log.Debug("Failed to read mirrored response", "err", err)
+		return MirrorError
+	}
+
+	switch {
+	case shadow.StatusCode != resp.status:
+		return MirrorStatusMismatch
+	case resp.status != http.StatusOK:
+		return MirrorMatch
+	case resp.method == http.MethodGet && !bytes.Equal(digest.Sum(nil), resp.digest):
+		return MirrorBlobMismatch
+	case resp.method != http.MethodGet && resp.mode == commitments.OptimismKeccak && !bytes.Equal(body.Bytes(), resp.body):
+		return MirrorCommitmentMismatch
+	default:
+		return MirrorMatch
+	}
+}
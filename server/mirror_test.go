@@ -0,0 +1,120 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMirror(t *testing.T) {
+	// the shadow proxy returns a different blob for one commitment
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(append([]byte{0x00}, body...))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/0xbad") {
+			_, _ = w.Write([]byte("other blob"))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/0xmissing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("blob"))
+	}))
+	defer shadow.Close()
+
+	m := metrics.NewMetrics("default")
+	mr := newMirror(MirrorConfig{URL: shadow.URL, Percentage: 100, Timeout: time.Second, MaxInFlight: 4}, m, log.New())
+	handler := WithMirror(func(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(append([]byte{0x00}, body...))
+			return commitments.CommitmentMeta{Mode: commitments.OptimismKeccak}, nil
+		}
+		_, _ = w.Write([]byte("blob"))
+		return commitments.CommitmentMeta{Mode: commitments.OptimismKeccak}, nil
+	}, mr)
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		result string
+	}{
+		{http.MethodGet, "/get/0xgood", "", MirrorMatch},
+		{http.MethodGet, "/get/0xbad", "", MirrorBlobMismatch},
+		{http.MethodGet, "/get/0xmissing", "", MirrorStatusMismatch},
+		{http.MethodPost, "/put", "payload", MirrorMatch},
+	}
+	for _, tt := range tests {
+		counter := m.MirroredRequests.WithLabelValues(tt.method, tt.result)
+		before := testutil.ToFloat64(counter)
+
+		rec := httptest.NewRecorder()
+		_, err := handler(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		if tt.method == http.MethodPost {
+			// the request body is still readable by the handler
+			require.Equal(t, "\x00"+tt.body, rec.Body.String())
+		}
+
+		require.Eventually(t, func() bool { return testutil.ToFloat64(counter) == before+1 },
+			time.Second, 10*time.Millisecond, "%s %s", tt.method, tt.path)
+	}
+
+	// requests aren't mirrored beyond MaxInFlight
+	for i := 0; i < cap(mr.inFlight); i++ {
+		mr.inFlight <- struct{}{}
+	}
+	rec := httptest.NewRecorder()
+	_, err := handler(rec, httptest.NewRequest(http.MethodGet, "/get/0xgood", nil))
+	require.NoError(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(m.MirroredRequests.WithLabelValues(http.MethodGet, MirrorDropped)))
+}
+
+func TestMirrorHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set(CommitmentModeHeader, "optimism_generic")
+	h.Set(TenantHeader, "rollup-a")
+	h.Set("Authorization", "Bearer key-a")
+	h.Set("Cookie", "session=secret")
+
+	mr := newMirror(MirrorConfig{URL: "http://shadow:3100", MaxInFlight: 1}, metrics.NoopMetrics, log.New())
+	mirrored := mr.header(h)
+	require.Len(t, mirrored, 2)
+	require.Equal(t, "optimism_generic", mirrored.Get(CommitmentModeHeader))
+	require.Equal(t, "rollup-a", mirrored.Get(TenantHeader))
+
+	mr.cfg.ForwardCredentials = true
+	require.Equal(t, "Bearer key-a", mr.header(h).Get("Authorization"))
+	require.Empty(t, mr.header(h).Get("Cookie"))
+}
+
+func TestMirrorConfigCheck(t *testing.T) {
+	cfg := MirrorConfig{URL: "http://shadow:3100", Percentage: 5, Timeout: time.Minute, MaxInFlight: 1}
+	require.NoError(t, cfg.Check())
+	require.NoError(t, MirrorConfig{}.Check())
+
+	invalid := cfg
+	invalid.URL = "shadow:3100"
+	require.Error(t, invalid.Check())
+	invalid = cfg
+	invalid.Percentage = 101
+	require.Error(t, invalid.Check())
+	invalid = cfg
+	invalid.MaxInFlight = 0
+	require.Error(t, invalid.Check())
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+	"github.com/Layr-Labs/eigenda-proxy/flags"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/s3"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+)
+
+// AdminConfig ... configuration for the operator-facing admin API
+type AdminConfig struct {
+	Enabled bool
+}
+
+// ReadListenerConfig ... separate listener serving only the read API, so that reads can be exposed broadly
+// while writes and operator routes stay on the private network. When enabled, the gateway profile only
+// applies to this listener.
+type ReadListenerConfig struct {
+	Enabled bool
+	Addr    string
+	Port    int
+}
+
+// Options ... optional HTTP server features
+type Options struct {
+	Admin        AdminConfig
+	Explorer     ExplorerConfig
+	Gateway      GatewayConfig
+	ReadListener ReadListenerConfig
+	GRPC         GRPCConfig
+	HTTP2        HTTP2Config
+	CORS         CORSConfig
+	Hooks        hooks.Config
+	Usage        UsageConfig
+	Mirror       MirrorConfig
+	Async        AsyncConfig
+	Batch        BatchConfig
+	Priority     PriorityConfig
+	FeatureGates featuregate.Config
+	CrashReports CrashReportConfig
+	// Tenancy selects the namespace of requests by path prefix and rate limits each namespace
+	Tenancy tenancy.Config
+	// SecurityHeaders sets standard security headers on the public GET routes
+	SecurityHeaders bool
+	// Streaming lets GET requests opt into responses streamed while the blob is verified, see StreamHeader
+	Streaming bool
+	// TTLHints lets PUT requests limit how long secondary stores keep their blob, see TTLHeader
+	TTLHints bool
+	// RedundancyRequests lets PUT requests ask for their blob to also be persisted to other secondary backends,
+	// see RedundancyHeader
+	RedundancyRequests bool
+	// EigenDAInfoRefreshInterval is how long the network info served on EigenDAInfoRoute is cached for
+	EigenDAInfoRefreshInterval time.Duration
+	// CommitmentsAPI serves the indexed commitments on CommitmentsRoute
+	CommitmentsAPI bool
+	// HashAlgorithm of precomputed-key commitments, recorded in their commitment type byte. It is the algorithm
+	// the S3 store verifies keys with, keccak256 if empty.
+	HashAlgorithm commitments.HashAlgorithm
+	// Version is served on VersionRoute, it isn't read from flags but filled in at startup
+	Version VersionInfo
+}
+
+// ReadOptions ... parses the server Options from the provided flags or environment variables.
+func ReadOptions(ctx *cli.Context) Options {
+	// prefixes have already been validated by the flag's action
+	var prefixes []hexutil.Bytes
+	for _, p := range ctx.StringSlice(flags.GatewayAllowedPrefixesFlagName) {
+		b, _ := hexutil.Decode(p)
+		prefixes = append(prefixes, b)
+	}
+
+	return Options{
+		Admin: AdminConfig{
+			Enabled: ctx.Bool(flags.AdminEnabledFlagName),
+		},
+		Explorer: ExplorerConfig{
+			Enabled: ctx.Bool(flags.ExplorerEnabledFlagName),
+		},
+		Gateway: GatewayConfig{
+			Enabled:         ctx.Bool(flags.GatewayEnabledFlagName),
+			RateLimit:       ctx.Float64(flags.GatewayRateLimitFlagName),
+			Burst:           ctx.Int(flags.GatewayBurstFlagName),
+			APIKeys:         ctx.StringSlice(flags.GatewayAPIKeysFlagName),
+			AllowedPrefixes: prefixes,
+			CacheMaxAge:     ctx.Duration(flags.GatewayCacheMaxAgeFlagName),
+			TrustedProxies:  ctx.StringSlice(flags.GatewayTrustedProxiesFlagName),
+		},
+		ReadListener: ReadListenerConfig{
+			Enabled: ctx.Int(flags.ReadListenerPortFlagName) != 0,
+			Addr:    ctx.String(flags.ReadListenerAddrFlagName),
+			Port:    ctx.Int(flags.ReadListenerPortFlagName),
+		},
+		GRPC: GRPCConfig{
+			Enabled: ctx.Int(flags.GRPCPortFlagName) != 0,
+			Addr:    ctx.String(flags.GRPCAddrFlagName),
+			Port:    ctx.Int(flags.GRPCPortFlagName),
+		},
+		HTTP2: HTTP2Config{
+			Enabled:              ctx.Bool(flags.HTTP2EnabledFlagName),
+			MaxConcurrentStreams: uint32(ctx.Uint(flags.HTTP2MaxConcurrentStreamsFlagName)), // #nosec G115
+			MaxReadFrameSize:     uint32(ctx.Uint(flags.HTTP2MaxReadFrameSizeFlagName)),     // #nosec G115
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: ctx.StringSlice(flags.CORSAllowedOriginsFlagName),
+			AllowedMethods: ctx.StringSlice(flags.CORSAllowedMethodsFlagName),
+			AllowedHeaders: ctx.StringSlice(flags.CORSAllowedHeadersFlagName),
+		},
+		Hooks:        hooks.ReadConfig(ctx),
+		FeatureGates: featuregate.ReadConfig(ctx),
+		Tenancy:      tenancy.ReadConfig(ctx),
+		Usage: UsageConfig{
+			Tenants: ctx.StringSlice(flags.UsageTenantsFlagName),
+		},
+		CrashReports: CrashReportConfig{
+			DSN:         ctx.String(flags.CrashReportDSNFlagName),
+			Environment: ctx.String(flags.CrashReportEnvironmentFlagName),
+			Timeout:     ctx.Duration(flags.CrashReportTimeoutFlagName),
+		},
+		Mirror: MirrorConfig{
+			URL:                ctx.String(flags.MirrorURLFlagName),
+			Percentage:         ctx.Float64(flags.MirrorPercentageFlagName),
+			Timeout:            ctx.Duration(flags.MirrorTimeoutFlagName),
+			MaxInFlight:        ctx.Int(flags.MirrorMaxInFlightFlagName),
+			ForwardCredentials: ctx.Bool(flags.MirrorForwardCredentialsFlagName),
+		},
+		Async: AsyncConfig{
+			Enabled:     ctx.Bool(flags.AsyncEnabledFlagName),
+			MaxInFlight: ctx.Int(flags.AsyncMaxInFlightFlagName),
+			MaxQueued:   ctx.Int(flags.AsyncMaxQueuedFlagName),
+			Retention:   ctx.Duration(flags.AsyncRetentionFlagName),
+			MinAck:      AckLevel(ctx.String(flags.AsyncMinAckFlagName)),
+			JournalDir:  ctx.String(flags.AsyncJournalDirFlagName),
+		},
+		Batch: BatchConfig{
+			MaxBlobs:    ctx.Int(flags.BatchMaxBlobsFlagName),
+			Concurrency: ctx.Int(flags.BatchConcurrencyFlagName),
+		},
+		Priority: PriorityConfig{
+			Enabled:                ctx.Bool(flags.PriorityEnabledFlagName),
+			InteractiveConcurrency: ctx.Int(flags.PriorityInteractiveConcurrencyFlagName),
+			BatchConcurrency:       ctx.Int(flags.PriorityBatchConcurrencyFlagName),
+			MaxQueued:              ctx.Int(flags.PriorityMaxQueuedFlagName),
+			QueueTimeout:           ctx.Duration(flags.PriorityQueueTimeoutFlagName),
+			BatchAPIKeys:           ctx.StringSlice(flags.PriorityBatchAPIKeysFlagName),
+		},
+		SecurityHeaders:            ctx.Bool(flags.SecurityHeadersFlagName),
+		Streaming:                  ctx.Bool(flags.StreamingEnabledFlagName),
+		TTLHints:                   ctx.Bool(flags.TTLHintsEnabledFlagName),
+		RedundancyRequests:         ctx.Bool(flags.RedundancyRequestsEnabledFlagName),
+		CommitmentsAPI:             ctx.Bool(flags.CommitmentsAPIEnabledFlagName),
+		HashAlgorithm:              commitments.HashAlgorithm(ctx.String(s3.HashAlgorithmFlagName)),
+		EigenDAInfoRefreshInterval: ctx.Duration(flags.EigenDAInfoRefreshIntervalFlagName),
+	}
+}
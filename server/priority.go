@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+)
+
+// RequestClassHeader ... selects the class of a read, see PriorityConfig
+const RequestClassHeader = "X-EigenDA-Proxy-Request-Class"
+
+// RequestClass ... class of a read, each class being served by its own concurrency pool
+type RequestClass string
+
+const (
+	// ClassInteractive ... latency sensitive reads, e.g. of a rollup's derivation pipeline. Reads are interactive
+	// unless classified otherwise.
+	ClassInteractive RequestClass = "interactive"
+	// ClassBatch ... bulk reads whose latency doesn't matter, e.g. archival scans and backfills
+	ClassBatch RequestClass = "batch"
+)
+
+// results of classified requests, as recorded by metrics.Metricer.RecordClassifiedRequest
+const (
+	ClassServed   = "served"
+	ClassRejected = "rejected"
+)
+
+// errClassSaturated ... returned for requests that couldn't get a slot of their class' pool
+var errClassSaturated = errors.New("too many requests of the class in flight")
+
+// PriorityConfig ... separate concurrency pools for interactive and batch reads, so that an archival scan reading
+// thousands of blobs can't add latency to derivation reads. Requests wait in their class' queue for a slot of its
+// pool, and are rejected with 503 once the queue is full or QueueTimeout elapsed.
+type PriorityConfig struct {
+	Enabled                bool
+	InteractiveConcurrency int
+	BatchConcurrency       int
+	// MaxQueued is the number of requests of a class waiting for a slot of its pool
+	MaxQueued    int
+	QueueTimeout time.Duration
+	// BatchAPIKeys are bearer tokens of batch clients, whose requests are batch reads regardless of their
+	// RequestClassHeader
+	BatchAPIKeys []string
+}
+
+// Check ... validates the request class config
+func (c PriorityConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.InteractiveConcurrency < 1 || c.BatchConcurrency < 1 {
+		return fmt.Errorf("request class concurrency must be at least 1")
+	}
+	if c.MaxQueued < 0 {
+		return fmt.Errorf("request class max queued must not be negative")
+	}
+	if c.QueueTimeout <= 0 {
+		return fmt.Errorf("request class queue timeout must be positive")
+	}
+	return nil
+}
+
+// classPool ... concurrency pool of a request class
+type classPool struct {
+	slots     chan struct{}
+	queued    atomic.Int32
+	maxQueued int32
+}
+
+// acquire ... waits up to timeout for a slot of the pool, returning its release function
+func (p *classPool) acquire(ctx context.Context, timeout time.Duration) (func(), error) {
+	release := func() { <-p.slots }
+	select {
+	case p.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if p.queued.Add(1) > p.maxQueued {
+		p.queued.Add(-1)
+		return nil, fmt.Errorf("%w: queue is full", errClassSaturated)
+	}
+	defer p.queued.Add(-1)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case p.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: no slot within %s", errClassSaturated, timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// priorities ... enforces the PriorityConfig on incoming reads
+type priorities struct {
+	cfg   PriorityConfig
+	pools map[RequestClass]*classPool
+	m     metrics.Metricer
+}
+
+func newPriorities(cfg PriorityConfig, m metrics.Metricer) *priorities {
+	if !cfg.Enabled {
+		return nil
+	}
+	newPool := func(concurrency int) *classPool {
+		return &classPool{slots: make(chan struct{}, concurrency), maxQueued: int32(cfg.MaxQueued)} // #nosec G115
+	}
+	return &priorities{
+		cfg: cfg,
+		pools: map[RequestClass]*classPool{
+			ClassInteractive: newPool(cfg.InteractiveConcurrency),
+			ClassBatch:       newPool(cfg.BatchConcurrency),
+		},
+		m: m,
+	}
+}
+
+// classify ... returns the class of the request: batch if it presents a batch API key, else the class of its
+// RequestClassHeader, interactive if unset
+func (p *priorities) classify(r *http.Request) (RequestClass, error) {
+	if key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && key != "" &&
+		secrets.EqualAny(p.cfg.BatchAPIKeys, key) {
+		return ClassBatch, nil
+	}
+
+	switch class := RequestClass(strings.ToLower(r.Header.Get(RequestClassHeader))); class {
+	case "", ClassInteractive:
+		return ClassInteractive, nil
+	case ClassBatch:
+		return ClassBatch, nil
+	default:
+		return "", fmt.Errorf("invalid %s header %q, expected %s or %s", RequestClassHeader, class,
+			ClassInteractive, ClassBatch)
+	}
+}
+
+// WithPriority is a middleware that serves each read within the concurrency pool of its class, see
+// PriorityConfig. Reads waiting for a slot give up once the deadline set by the caller passed. It is a no-op if p
+// is nil.
+func WithPriority(
+	handleFn func(http.ResponseWriter, *http.Request) error,
+	p *priorities,
+) func(http.ResponseWriter, *http.Request) error {
+	if p == nil {
+		return handleFn
+	}
+	return func(w http.ResponseWriter, r *http.Request) error {
+		class, err := p.classify(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return err
+		}
+
+		start := time.Now()
+		release, err := p.pools[class].acquire(r.Context(), p.cfg.QueueTimeout)
+		if err != nil {
+			p.m.RecordClassifiedRequest(string(class), ClassRejected, time.Since(start))
+			if upstreamDeadlineExceeded(r.Context()) {
+				writeDeadlineExceeded(w)
+				return fmt.Errorf("%s: deadline passed while queued as a %s read", ErrCodeDeadlineExceeded, class)
+			}
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return fmt.Errorf("%s read rejected: %w", class, err)
+		}
+		defer release()
+
+		p.m.RecordClassifiedRequest(string(class), ClassServed, time.Since(start))
+		return handleFn(w, r)
+	}
+}
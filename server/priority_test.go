@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPriority(t *testing.T) {
+	p := newPriorities(PriorityConfig{
+		Enabled:                true,
+		InteractiveConcurrency: 2,
+		BatchConcurrency:       1,
+		MaxQueued:              1,
+		QueueTimeout:           50 * time.Millisecond,
+		BatchAPIKeys:           []string{"archive-key"},
+	}, metrics.NoopMetrics)
+
+	entered, unblock := make(chan struct{}), make(chan struct{})
+	handler := WithPriority(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("block") != "" {
+			entered <- struct{}{}
+			<-unblock
+		}
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}, p)
+
+	serve := func(query string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, GetRoute+"0x010000"+query, nil)
+		for k, v := range header {
+			req.Header.Set(k, v[0])
+		}
+		rec := httptest.NewRecorder()
+		_ = handler(rec, req)
+		return rec
+	}
+	batch := http.Header{RequestClassHeader: {"batch"}}
+
+	// a batch read holds the only slot of the batch pool
+	done := make(chan int)
+	go func() { done <- serve("?block=1", batch).Code }()
+	<-entered
+
+	// further batch reads time out in the queue, while interactive reads are served
+	rec := serve("", batch)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, "1", rec.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusOK, serve("", nil).Code)
+	require.Equal(t, http.StatusOK, serve("", http.Header{RequestClassHeader: {"Interactive"}}).Code)
+
+	// batch API keys can't opt out of the batch class
+	rec = serve("", http.Header{"Authorization": {"Bearer archive-key"}, RequestClassHeader: {"interactive"}})
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	require.Equal(t, http.StatusOK, serve("", http.Header{"Authorization": {"Bearer other-key"}}).Code)
+
+	require.Equal(t, http.StatusBadRequest, serve("", http.Header{RequestClassHeader: {"realtime"}}).Code)
+
+	close(unblock)
+	require.Equal(t, http.StatusOK, <-done)
+	require.Equal(t, http.StatusOK, serve("", batch).Code)
+}
+
+func TestClassPool(t *testing.T) {
+	pool := &classPool{slots: make(chan struct{}, 1), maxQueued: 0}
+	release, err := pool.acquire(context.Background(), time.Second)
+	require.NoError(t, err)
+
+	// without a queue, reads are rejected as soon as the pool is busy
+	start := time.Now()
+	_, err = pool.acquire(context.Background(), time.Second)
+	require.ErrorIs(t, err, errClassSaturated)
+	require.Less(t, time.Since(start), time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pool.maxQueued = 1
+	_, err = pool.acquire(ctx, time.Second)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Zero(t, pool.queued.Load())
+
+	release()
+	release, err = pool.acquire(context.Background(), time.Second)
+	require.NoError(t, err)
+	release()
+}
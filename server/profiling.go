@@ -0,0 +1,132 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const (
+	AdminProfilingRoute      = AdminRoute + "profiling"
+	AdminProfilingStartRoute = AdminProfilingRoute + "/start"
+	AdminProfilingStopRoute  = AdminProfilingRoute + "/stop"
+
+	profilingBackendParam  = "backend"
+	profilingDurationParam = "duration"
+)
+
+// profiler ... looks up the profiler of the configured backend named by the request's backend query
+// param, defaulting to s3
+func (svr *Server) profiler(r *http.Request) (*store.Profiler, error) {
+	name := r.URL.Query().Get(profilingBackendParam)
+	if name == "" {
+		name = store.S3BackendType.String()
+	}
+	backend := store.StringToBackendType(name)
+
+	candidates := append([]store.PrecomputedKeyStore{}, svr.router.Caches()...)
+	candidates = append(candidates, svr.router.Fallbacks()...)
+	if keccak := svr.router.GetKeccakStore(); keccak != nil {
+		candidates = append(candidates, keccak)
+	}
+
+	for _, s := range candidates {
+		if s.BackendType() != backend {
+			continue
+		}
+		if p, ok := s.(store.Profileable); ok {
+			return p.Profiler(), nil
+		}
+	}
+	return nil, fmt.Errorf("backend %s is not configured or does not support profiling", name)
+}
+
+// HandleProfilingStart ... starts a profiling session for a bounded duration (default 1m, max 1h).
+// Example: POST /admin/profiling/start?backend=s3&duration=5m
+func (svr *Server) HandleProfilingStart(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	p, err := svr.profiler(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	var d time.Duration
+	if raw := r.URL.Query().Get(profilingDurationParam); raw != "" {
+		d, err = time.ParseDuration(raw)
+		if err != nil {
+			err = fmt.Errorf("invalid profiling duration %s: %w", raw, err)
+			svr.WriteBadRequest(w, err)
+			return err
+		}
+	}
+
+	if err := p.Start(d); err != nil {
+		w.WriteHeader(http.StatusConflict)
+		return err
+	}
+
+	rep, err := p.Report()
+	if err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+	return svr.writeJSON(w, rep)
+}
+
+// HandleProfilingStop ... ends the active profiling session early and returns its report
+func (svr *Server) HandleProfilingStop(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	p, err := svr.profiler(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	rep, err := p.Stop()
+	if err != nil {
+		svr.writeProfilingError(w, err)
+		return err
+	}
+	return svr.writeJSON(w, rep)
+}
+
+// HandleProfilingReport ... returns the report of the active or most recent profiling session
+func (svr *Server) HandleProfilingReport(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	p, err := svr.profiler(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	rep, err := p.Report()
+	if err != nil {
+		svr.writeProfilingError(w, err)
+		return err
+	}
+	return svr.writeJSON(w, rep)
+}
+
+func (svr *Server) writeProfilingError(w http.ResponseWriter, err error) {
+	if errors.Is(err, store.ErrProfilingInactive) {
+		svr.WriteNotFound(w, err)
+		return
+	}
+	svr.WriteInternalError(w, err)
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const AdminReconcileRoute = AdminRoute + "reconcile"
+
+// HandleReconcile ... GET returns the reports of the last reconciliation of the commitment index with the
+// secondary backends, POST runs a reconciliation and returns its reports
+func (svr *Server) HandleReconcile(w http.ResponseWriter, r *http.Request) error {
+	switch r.Method {
+	case http.MethodGet:
+		return svr.writeJSON(w, svr.router.ReconcileReports())
+	case http.MethodPost:
+		return svr.writeJSON(w, svr.router.Reconcile(r.Context()))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+}
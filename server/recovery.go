@@ -0,0 +1,227 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxCrashReportsInFlight ... crash reports uploaded concurrently, further reports are only logged
+const maxCrashReportsInFlight = 4
+
+// CrashReportConfig ... upload of recovered handler panics to a Sentry-compatible crash reporting service.
+// Disabled if DSN isn't set.
+type CrashReportConfig struct {
+	// DSN of the project reports are sent to, i.e. https://<public key>@<host>/<project id>
+	DSN string
+	// Environment reports are tagged with, e.g. mainnet
+	Environment string
+	// Timeout of a report upload
+	Timeout time.Duration
+}
+
+// Check ... validates the crash report config
+func (c CrashReportConfig) Check() error {
+	if c.DSN == "" {
+		return nil
+	}
+	if _, _, err := parseDSN(c.DSN); err != nil {
+		return err
+	}
+	if c.Timeout <= 0 {
+		return errors.New("crash report timeout must be positive")
+	}
+	return nil
+}
+
+// parseDSN ... returns the store endpoint and public key of a Sentry DSN
+func parseDSN(dsn string) (string, string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("invalid crash report dsn: expected <scheme>://<public key>@<host>/<project id>")
+	}
+	// projects may be hosted under a path prefix, e.g. https://key@host/sentry/42
+	i := strings.LastIndex(u.Path, "/")
+	if i < 0 || u.Path[i+1:] == "" {
+		return "", "", fmt.Errorf("invalid crash report dsn: missing project id")
+	}
+	endpoint := fmt.Sprintf("%s://%s%s/api/%s/store/", u.Scheme, u.Host, u.Path[:i], u.Path[i+1:])
+	return endpoint, u.User.Username(), nil
+}
+
+// crashReport ... a recovered panic
+type crashReport struct {
+	requestID  string
+	method     string
+	path       string
+	commitment string
+	panic      string
+	stack      string
+}
+
+// crashReporter ... uploads crash reports as Sentry store API events
+type crashReporter struct {
+	endpoint    string
+	auth        string
+	environment string
+	release     string
+	client      *http.Client
+	inFlight    chan struct{}
+	log         log.Logger
+}
+
+func newCrashReporter(cfg CrashReportConfig, release string, log log.Logger) *crashReporter {
+	if cfg.DSN == "" {
+		return nil
+	}
+	endpoint, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		log.Warn("Crash reports disabled", "err", err)
+		return nil
+	}
+	return &crashReporter{
+		endpoint:    endpoint,
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=eigenda-proxy/%s, sentry_key=%s", release, key),
+		environment: cfg.Environment,
+		release:     release,
+		client:      &http.Client{Timeout: cfg.Timeout},
+		inFlight:    make(chan struct{}, maxCrashReportsInFlight),
+		log:         log,
+	}
+}
+
+// upload ... sends the report in the background, unless maxCrashReportsInFlight reports are in flight already
+func (c *crashReporter) upload(rep crashReport) {
+	select {
+	case c.inFlight <- struct{}{}:
+	default:
+		c.log.Warn("Too many crash reports in flight, dropping report", "request_id", rep.requestID)
+		return
+	}
+
+	go func() {
+		defer func() { <-c.inFlight }()
+		if err := c.send(rep); err != nil {
+			c.log.Warn("Failed to upload crash report", "request_id", rep.requestID, "err", err)
+		}
+	}()
+}
+
+func (c *crashReporter) send(rep crashReport) error {
+	event := map[string]any{
+		"event_id":    strings.ReplaceAll(newRequestID(), "-", ""),
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		"level":       "fatal",
+		"platform":    "go",
+		"logger":      "eigenda-proxy",
+		"release":     c.release,
+		"environment": c.environment,
+		"message":     "panic serving request: " + rep.panic,
+		"exception": map[string]any{
+			"values": []map[string]any{{"type": "panic", "value": rep.panic}},
+		},
+		"request": map[string]any{"method": rep.method, "url": rep.path},
+		"tags":    map[string]string{"request_id": rep.requestID, "method": rep.method},
+		"extra":   map[string]string{"commitment": rep.commitment, "stack": rep.stack},
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.endpoint, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", c.auth)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("received bad status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// WithRecovery is a middleware that recovers panics of handlers, so that a single malformed request can't take
+// down the proxy. The panic is answered with a 500, logged with its stack, counted and uploaded to the crash
+// reporter, if any. Aborted responses (http.ErrAbortHandler) are passed on to net/http.
+func WithRecovery(handler http.Handler, m metrics.Metricer, reporter *crashReporter, log log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if err, ok := rec.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(rec)
+			}
+
+			rep := crashReport{
+				requestID:  store.RequestMetaFromContext(r.Context()).ID,
+				method:     r.Method,
+				path:       r.URL.Path,
+				commitment: commitmentOfPath(r.URL.Path),
+				panic:      secrets.Scrub(fmt.Sprint(rec)),
+				stack:      string(debug.Stack()),
+			}
+			if rep.requestID == "" {
+				// not served behind WithRequestMeta
+				rep.requestID = r.Header.Get(RequestIDHeader)
+			}
+			if rep.requestID == "" {
+				rep.requestID = newRequestID()
+			}
+
+			log.Error("Recovered panic serving request", "request_id", rep.requestID, "method", rep.method,
+				"path", rep.path, "commitment", rep.commitment, "panic", rep.panic, "stack", rep.stack)
+			m.RecordPanic(r.Method)
+			if reporter != nil {
+				reporter.upload(rep)
+			}
+
+			// the status can't be changed anymore if the handler already wrote it, the client then sees a
+			// truncated response
+			w.Header().Set(RequestIDHeader, rep.requestID)
+			w.WriteHeader(http.StatusInternalServerError)
+		}()
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// commitmentOfPath ... returns the commitment of GET and PUT request paths, or an empty string for other routes
+func commitmentOfPath(path string) string {
+	for _, route := range []string{GetRoute, PutRoute} {
+		if c, ok := strings.CutPrefix(path, route); ok {
+			return c
+		}
+	}
+	return ""
+}
+
+// newRequestID ... returns a random UUIDv4 formatted ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
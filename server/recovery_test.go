@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		dsn         string
+		endpoint    string
+		expectError bool
+	}{
+		{dsn: "https://key@sentry.example.com/42", endpoint: "https://sentry.example.com/api/42/store/"},
+		{dsn: "http://key@localhost:9000/sentry/7", endpoint: "http://localhost:9000/sentry/api/7/store/"},
+		{dsn: "https://sentry.example.com/42", expectError: true},
+		{dsn: "https://key@sentry.example.com/", expectError: true},
+		{dsn: "ftp://key@sentry.example.com/42", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			endpoint, key, err := parseDSN(tt.dsn)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.endpoint, endpoint)
+			require.Equal(t, "key", key)
+		})
+	}
+}
+
+func TestWithRecovery(t *testing.T) {
+	events := make(chan map[string]any, 1)
+	sentry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/42/store/", r.URL.Path)
+		require.Contains(t, r.Header.Get("X-Sentry-Auth"), "sentry_key=public")
+		var event map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events <- event
+	}))
+	defer sentry.Close()
+
+	dsn := strings.Replace(sentry.URL, "://", "://public@", 1) + "/42"
+	reporter := newCrashReporter(CrashReportConfig{DSN: dsn, Environment: "test", Timeout: time.Second}, "v1.0.0", log.New())
+	require.NotNil(t, reporter)
+
+	m := metrics.NewMetrics("test")
+	handler := WithRecovery(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		var cert []byte
+		_ = cert[1] // malformed commitment
+	}), m, reporter, log.New())
+
+	req := httptest.NewRequest(http.MethodGet, "/get/0x0102", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	rec := httptest.NewRecorder()
+	require.NotPanics(t, func() { handler.ServeHTTP(rec, req) })
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.HTTPServerPanics.WithLabelValues(http.MethodGet)))
+
+	select {
+	case event := <-events:
+		require.Equal(t, "test", event["environment"])
+		require.Equal(t, "v1.0.0", event["release"])
+		require.Equal(t, "req-1", event["tags"].(map[string]any)["request_id"])
+		extra := event["extra"].(map[string]any)
+		require.Equal(t, "0x0102", extra["commitment"])
+		require.Contains(t, extra["stack"], "TestWithRecovery")
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash report wasn't uploaded")
+	}
+
+	// requests without an ID get a generated one
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/put/", nil))
+	require.Len(t, rec.Header().Get(RequestIDHeader), 36)
+	<-events
+
+	// aborted responses are left to net/http
+	abort := WithRecovery(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}), m, nil, log.New())
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		abort.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/get/0x01", nil))
+	})
+	require.Equal(t, 1.0, testutil.ToFloat64(m.HTTPServerPanics.WithLabelValues(http.MethodPost)))
+}
+
+func TestWithRequestMeta(t *testing.T) {
+	var meta store.RequestMeta
+	handler := WithRequestMeta(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		meta = store.RequestMetaFromContext(r.Context())
+	}), func(*http.Request) string { return "rollup-a" })
+
+	// the ID set by the caller is kept
+	req := httptest.NewRequest(http.MethodGet, "/get/0x00", nil)
+	req.Header.Set(RequestIDHeader, "lb-id")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, store.RequestMeta{ID: "lb-id", Tenant: "rollup-a"}, meta)
+	require.Equal(t, "lb-id", rec.Header().Get(RequestIDHeader))
+
+	// requests without one get a random ID
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/get/0x00", nil))
+	require.Len(t, meta.ID, 36)
+	require.Equal(t, meta.ID, rec.Header().Get(RequestIDHeader))
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const (
+	// RedundancyHeader ... PUT request header listing the secondary backends the blob must also be persisted to,
+	// comma separated (e.g. "s3,fs"), on top of the cache and fallback targets. See Options.RedundancyRequests.
+	RedundancyHeader = "X-EigenDA-Proxy-Redundancy"
+	// AcknowledgedHeader ... PUT response header listing the backends that persisted the blob, comma separated
+	AcknowledgedHeader = "X-EigenDA-Proxy-Acknowledged"
+)
+
+// readRedundancy ... returns the backends requested by a PUT request via RedundancyHeader, nil if it doesn't set
+// the header or redundancy requests are disabled
+func (svr *Server) readRedundancy(r *http.Request) ([]store.BackendType, error) {
+	v := r.Header.Get(RedundancyHeader)
+	if v == "" || !svr.opts.RedundancyRequests ||
+		!svr.gates.Enabled(featuregate.RedundancyRequests, r.Header.Get(TenantHeader)) {
+		return nil, nil
+	}
+
+	var sinks []store.BackendType
+	for _, name := range strings.Split(v, ",") {
+		name = strings.TrimSpace(name)
+		switch b := store.StringToBackendType(name); b {
+		case store.EigenDABackendType, store.MemoryBackendType, store.Unknown:
+			return nil, fmt.Errorf("invalid %s header: %q is not a secondary backend", RedundancyHeader, name)
+		default:
+			sinks = append(sinks, b)
+		}
+	}
+	return sinks, nil
+}
+
+// writeAcknowledged ... sets the acknowledged backends header. Must be called before the response body is written.
+func writeAcknowledged(w http.ResponseWriter, rm *store.ResponseMeta) {
+	acked := rm.Acknowledged()
+	if len(acked) == 0 {
+		return
+	}
+
+	names := make([]string, len(acked))
+	for i, b := range acked {
+		names[i] = strings.ToLower(b.String())
+	}
+	w.Header().Set(AcknowledgedHeader, strings.Join(names, ","))
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRedundancy(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		disabled    bool
+		expected    []store.BackendType
+		expectError bool
+	}{
+		{name: "None"},
+		{name: "Single", header: "s3", expected: []store.BackendType{store.S3BackendType}},
+		{name: "Multiple", header: "S3, fs", expected: []store.BackendType{store.S3BackendType, store.FSBackendType}},
+		{name: "Disabled", header: "s3", disabled: true},
+		{name: "Primary", header: "eigenda", expectError: true},
+		{name: "Unknown", header: "s3,postgres", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := &Server{opts: Options{RedundancyRequests: !tt.disabled}}
+			req := httptest.NewRequest(http.MethodPut, "/put", nil)
+			if tt.header != "" {
+				req.Header.Set(RedundancyHeader, tt.header)
+			}
+			sinks, err := svr.readRedundancy(req)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, sinks)
+		})
+	}
+}
+
+func TestRedundancyRequests(t *testing.T) {
+	fsStore, err := fs.NewStore(log.New(), fs.Config{Path: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fsStore.Close() })
+
+	// the filesystem store is configured, but isn't a cache or fallback target
+	router, err := store.NewRouter(newMemstore(t), nil, log.New(), nil, nil, store.RouterOptions{
+		Sinks: []store.PrecomputedKeyStore{fsStore},
+	})
+	require.NoError(t, err)
+	svr := NewServer("127.0.0.1", 0, router, log.New(), metrics.NoopMetrics, Options{RedundancyRequests: true})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	put := func(redundancy string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, "http://"+svr.Endpoint()+"/put", bytes.NewReader([]byte("genesis")))
+		require.NoError(t, err)
+		if redundancy != "" {
+			req.Header.Set(RedundancyHeader, redundancy)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	resp := put("")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "memory", resp.Header.Get(AcknowledgedHeader))
+	require.Zero(t, fsStore.Stats().Entries)
+
+	resp = put("fs")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "memory,fs", resp.Header.Get(AcknowledgedHeader))
+	require.Equal(t, 1, fsStore.Stats().Entries)
+
+	// backends that aren't configured are rejected
+	resp = put("redis")
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+// RequestIDHeader ... request header identifying a request in logs and crash reports, typically set by a load
+// balancer. A random ID is generated for requests without one. The ID is returned in the same header of every
+// response.
+const RequestIDHeader = "X-Request-ID"
+
+// WithRequestMeta is a middleware that attaches the store.RequestMeta of a request to its context, so that the
+// handlers, the router and the stores label their logs and metrics of the request consistently without passing
+// its ID and tenant around.
+func WithRequestMeta(handler http.Handler, tenant func(*http.Request) string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx := store.WithRequestMeta(r.Context(), store.RequestMeta{ID: id, Tenant: tenant(r)})
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withMiddleware ... wraps a mux into the middlewares applying to every route
+func (svr *Server) withMiddleware(handler http.Handler) http.Handler {
+	return WithNamespaces(WithRequestMeta(WithTracing(WithRecovery(handler, svr.m, svr.crashes, svr.log)),
+		svr.usage.tenant), svr.namespaces)
+}
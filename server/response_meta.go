@@ -0,0 +1,76 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Response metadata headers set on successful GET/PUT responses, so that downstream logging and
+// dashboards can segment behavior without parsing proxy logs.
+const (
+	BackendHeader            = "X-EigenDA-Proxy-Backend"
+	VerificationHeader       = "X-EigenDA-Proxy-Verification"
+	BlobSizeHeader           = "X-EigenDA-Proxy-Blob-Size"
+	DispersalDurationHeader  = "X-EigenDA-Proxy-Dispersal-Duration-Ms"
+	CertReferenceBlockHeader = "X-EigenDA-Proxy-Cert-Reference-Block"
+	EncodedSymbolsHeader     = "X-EigenDA-Proxy-Encoded-Symbols"
+	QuorumPolicyHeader       = "X-EigenDA-Proxy-Quorum-Policy"
+	// QuorumFallbackHeader is set to true on PUT responses whose blob was dispersed to the default quorums only,
+	// since dispersals to the custom quorums failed
+	QuorumFallbackHeader = "X-EigenDA-Proxy-Quorum-Fallback"
+)
+
+// writeResponseMeta ... sets the response metadata headers. Must be called before the response body is written.
+// cert is the (decoded) commitment of the request and is only used to read the cert's reference block number.
+func writeResponseMeta(w http.ResponseWriter, rm *store.ResponseMeta, mode commitments.CommitmentMode,
+	cert []byte, blobSize int) {
+	h := w.Header()
+	h.Set(BackendHeader, rm.Backend().String())
+	h.Set(VerificationHeader, rm.Verification())
+	h.Set(BlobSizeHeader, strconv.Itoa(blobSize))
+	if policy := rm.QuorumPolicy(); policy != "" {
+		h.Set(QuorumPolicyHeader, policy)
+	}
+	if rm.QuorumFallback() {
+		h.Set(QuorumFallbackHeader, "true")
+	}
+
+	if refBlock, ok := certReferenceBlock(mode, cert); ok {
+		h.Set(CertReferenceBlockHeader, strconv.FormatUint(uint64(refBlock), 10))
+	}
+}
+
+// writeDispersalDuration ... sets the dispersal duration header. Must be called before the response body is written.
+func writeDispersalDuration(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set(DispersalDurationHeader, strconv.FormatInt(d.Milliseconds(), 10))
+}
+
+// writeEncodedSymbols ... sets the encoded symbols header. Must be called before the response body is written.
+func writeEncodedSymbols(w http.ResponseWriter, symbols int) {
+	w.Header().Set(EncodedSymbolsHeader, strconv.Itoa(symbols))
+}
+
+// certReferenceBlock ... decodes the reference block number from an RLP encoded EigenDA cert.
+// Returns false for commitment modes that don't carry an EigenDA cert or when decoding fails.
+func certReferenceBlock(mode commitments.CommitmentMode, cert []byte) (uint32, bool) {
+	if mode == commitments.OptimismKeccak {
+		return 0, false
+	}
+
+	var c verify.Certificate
+	if err := rlp.DecodeBytes(cert, &c); err != nil {
+		return 0, false
+	}
+
+	header := c.Proof().GetBatchMetadata().GetBatchHeader()
+	if header == nil {
+		return 0, false
+	}
+	return header.GetReferenceBlockNumber(), true
+}
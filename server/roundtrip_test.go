@@ -0,0 +1,197 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"testing"
+	"testing/quick"
+
+	"github.com/Layr-Labs/eigenda-proxy/client"
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// startRoundTripServer ... starts a proxy backed by a memstore, with a filesystem store used as the
+// keccak256 backend and as a cache, so that payloads pass through every handler and backend
+func startRoundTripServer(t *testing.T) string {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	return "http://" + svr.Endpoint()
+}
+
+// newMemstoreRouter ... router backed by a memstore, with a filesystem store used as the keccak256 backend and
+// as a cache
+func newMemstoreRouter(t *testing.T) store.IRouter {
+	ms := newMemstore(t)
+
+	fsStore, err := fs.NewStore(log.New(), fs.Config{Path: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fsStore.Close() })
+
+	router, err := store.NewRouter(ms, fsStore, log.New(), []store.PrecomputedKeyStore{fsStore}, nil, store.RouterOptions{})
+	require.NoError(t, err)
+	return router
+}
+
+// newMemstore ... memstore verifying its certs against the test SRS
+func newMemstore(t *testing.T) *memstore.MemStore {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	verifier, err := verify.NewVerifier(&verify.Config{
+		KzgConfig: &kzg.KzgConfig{
+			G1Path:          "../resources/g1.point",
+			G2PowerOf2Path:  "../resources/g2.point.powerOf2",
+			CacheDir:        "../resources/SRSTables",
+			SRSOrder:        3000,
+			SRSNumberToLoad: 3000,
+			NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	ms, err := memstore.New(ctx, verifier, log.New(), memstore.Config{MaxBlobSizeBytes: 1024 * 1024})
+	require.NoError(t, err)
+	return ms
+}
+
+// doRequest ... returns the response body of a successful request. Non empty bodies must be served as
+// opaque bytes.
+func doRequest(method, url string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, b)
+	}
+	if ct := resp.Header.Get("Content-Type"); len(b) > 0 && ct != "application/octet-stream" {
+		return nil, fmt.Errorf("%s %s returned content type %s", method, url, ct)
+	}
+	return b, nil
+}
+
+// TestBinaryRoundTrip ... random binary payloads must be returned byte for byte through the full
+// handler path in every commitment mode
+func TestBinaryRoundTrip(t *testing.T) {
+	url := startRoundTripServer(t)
+
+	tests := []struct {
+		name      string
+		roundTrip func(p []byte) ([]byte, error)
+	}{
+		{
+			name: "simple",
+			roundTrip: func(p []byte) ([]byte, error) {
+				c := client.New(&client.Config{URL: url})
+				cert, err := c.SetData(context.Background(), p)
+				if err != nil {
+					return nil, err
+				}
+				return c.GetData(context.Background(), cert)
+			},
+		},
+		{
+			name: "optimism generic",
+			roundTrip: func(p []byte) ([]byte, error) {
+				comm, err := doRequest(http.MethodPost, url+"/put/", p)
+				if err != nil {
+					return nil, err
+				}
+				return doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x", url, comm), nil)
+			},
+		},
+		{
+			name: "optimism keccak",
+			roundTrip: func(p []byte) ([]byte, error) {
+				// [commitment type, keccak256(payload)]
+				comm := append([]byte{0x00}, crypto.Keccak256(p)...)
+				if _, err := doRequest(http.MethodPost, fmt.Sprintf("%s/put/0x%x", url, comm), p); err != nil {
+					return nil, err
+				}
+				return doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x", url, comm), nil)
+			},
+		},
+		{
+			name: "optimism keccak computed by the proxy",
+			roundTrip: func(p []byte) ([]byte, error) {
+				comm, err := doRequest(http.MethodPost, url+"/put/?commitment_mode=optimism_keccak256", p)
+				if err != nil {
+					return nil, err
+				}
+				if want := append([]byte{0x00}, crypto.Keccak256(p)...); !bytes.Equal(want, comm) {
+					return nil, fmt.Errorf("commitment 0x%x is not the keccak256 commitment 0x%x", comm, want)
+				}
+				return doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x", url, comm), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range storetest.BinaryValues() {
+				got, err := tt.roundTrip(value)
+				require.NoError(t, err, name)
+				require.Equal(t, value, got, name)
+			}
+
+			property := func(p storetest.Payload) bool {
+				got, err := tt.roundTrip(p)
+				if err != nil {
+					t.Logf("round trip failed: %v", err)
+					return false
+				}
+				return bytes.Equal(p, got)
+			}
+			require.NoError(t, quick.Check(property, storetest.QuickConfig()))
+		})
+	}
+}
+
+// TestHashAlgorithmMismatch ... precomputed-key commitments recording another hash algorithm than the configured
+// one are rejected
+func TestHashAlgorithmMismatch(t *testing.T) {
+	payload := []byte("payload")
+	for _, h := range []commitments.HashAlgorithm{commitments.Keccak256Hash, commitments.Sha256Hash} {
+		svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{HashAlgorithm: h})
+		require.NoError(t, svr.Start())
+		t.Cleanup(func() { _ = svr.Stop() })
+		url := "http://" + svr.Endpoint()
+
+		for _, other := range commitments.HashAlgorithms {
+			if other == h {
+				continue
+			}
+			comm := commitments.EncodeHashCommitment(other, other.Sum(payload))
+			_, err := doRequest(http.MethodPost, fmt.Sprintf("%s/put/0x%x", url, comm), payload)
+			require.ErrorContains(t, err, "returned status 400", "%s commitment on %s server", other, h)
+			_, err = doRequest(http.MethodGet, fmt.Sprintf("%s/get/0x%x", url, comm), nil)
+			require.ErrorContains(t, err, "returned status 400", "%s commitment on %s server", other, h)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+// AdminRoutingRoute ... route of the dry-run of the routing decision of a read, followed by the encoded commitment
+const AdminRoutingRoute = AdminRoute + "routing/"
+
+// routePlanner ... implemented by routers able to plan a read without performing it
+type routePlanner interface {
+	PlanRead(ctx context.Context, key []byte, cm commitments.CommitmentMode) (store.RoutePlan, error)
+}
+
+// HandleRoutingPlan ... returns the stores a GET of the commitment would read, in order and along with the state of
+// their circuit breakers, without reading them. The commitment is decoded and the cache policy headers applied
+// like for GET requests.
+// Example: GET /admin/routing/0x010000...?commitment_mode=optimism_generic
+func (svr *Server) HandleRoutingPlan(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	planner, ok := svr.router.(routePlanner)
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		return fmt.Errorf("router doesn't support routing plans")
+	}
+
+	meta, err := ReadCommitmentMeta(r)
+	if err != nil {
+		err = fmt.Errorf("invalid commitment mode: %w", err)
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	key := path.Base(r.URL.Path)
+	parsed, err := commitments.StringToCommitment(key, meta.Mode)
+	if err == nil {
+		err = svr.checkHashAlgorithm(parsed)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to decode commitment from key %v (commitment mode %v): %w", key, meta.Mode, err)
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	policy, err := readCachePolicy(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	raw, _ := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	ctx := store.WithCommitment(store.WithCommitmentMode(r.Context(), parsed.Mode), raw)
+	if policy != store.CachePolicyDefault {
+		ctx = store.WithCachePolicy(ctx, policy)
+	}
+	plan, err := planner.PlanRead(ctx, parsed.Cert, parsed.Mode)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	return svr.writeJSON(w, plan)
+}
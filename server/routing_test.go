@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingPlan(t *testing.T) {
+	server := NewServer("localhost", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics,
+		Options{Admin: AdminConfig{Enabled: true}})
+	url := fmt.Sprintf("%s0x010000%s", AdminRoutingRoute, testCommitStr)
+
+	plan := func(policy string) store.RoutePlan {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if policy != "" {
+			req.Header.Set(CachePolicyHeader, policy)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, server.HandleRoutingPlan(rec, req))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var plan store.RoutePlan
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &plan))
+		return plan
+	}
+
+	// the cache target is read before EigenDA
+	p := plan("")
+	require.Equal(t, "sequential", p.Strategy)
+	require.Len(t, p.Steps, 2)
+	require.Equal(t, store.RouteStep{Order: 1, Backend: store.FSBackendType.String(), Role: "cache",
+		Key: p.Steps[0].Key, When: "immediately"}, p.Steps[0])
+	require.Equal(t, "primary", p.Steps[1].Role)
+	require.Equal(t, 2, p.Steps[1].Order)
+
+	// bypassing the cache skips it
+	p = plan("bypass")
+	require.Equal(t, store.CachePolicyBypass, p.CachePolicy)
+	require.Equal(t, "cache policy is bypass", p.Steps[0].Skipped)
+	require.Equal(t, 1, p.Steps[1].Order)
+
+	req := httptest.NewRequest(http.MethodGet, AdminRoutingRoute+"0x99", nil)
+	rec := httptest.NewRecorder()
+	require.Error(t, server.HandleRoutingPlan(rec, req))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, url, nil)
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleRoutingPlan(rec, req))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// routers that can't plan reads don't serve plans
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	server = NewServer("localhost", 0, mocks.NewMockIRouter(ctrl), log.New(), metrics.NoopMetrics,
+		Options{Admin: AdminConfig{Enabled: true}})
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleRoutingPlan(rec, httptest.NewRequest(http.MethodGet, url, nil)))
+	require.Equal(t, http.StatusNotImplemented, rec.Code)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -13,10 +14,15 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc"
 )
 
 var (
@@ -31,6 +37,24 @@ const (
 	CommitmentModeKey = "commitment_mode"
 )
 
+// replicationServer ... implemented by stores that serve their blobs to replicas on other proxies
+type replicationServer interface {
+	ReplicationHandler() http.Handler
+}
+
+// degradationReporter ... implemented by stores that can run with reduced functionality
+type degradationReporter interface {
+	// Degraded returns why the store is degraded, or an empty string if it isn't
+	Degraded() string
+}
+
+// HealthStatus ... body returned by the health route
+type HealthStatus struct {
+	Status string `json:"status"`
+	// Degraded lists the reasons the proxy runs with reduced functionality, while still serving requests
+	Degraded []string `json:"degraded,omitempty"`
+}
+
 type Server struct {
 	log        log.Logger
 	endpoint   string
@@ -38,16 +62,58 @@ type Server struct {
 	m          metrics.Metricer
 	httpServer *http.Server
 	listener   net.Listener
+	opts       Options
+	status     *statusTracker
+	usage      *usageTracker
+	mirror     *mirror
+	crashes    *crashReporter
+	// asynchronous PUTs, nil if disabled
+	jobs *jobTracker
+	// concurrency pools of request classes, nil if disabled
+	priorities *priorities
+	// writes are frozen while in maintenance mode, see HandleMaintenance
+	maintenance *maintenance
+
+	eigenDAInfo *eigenDAInfoCache
+
+	// separate listener serving only the read API, see ReadListenerConfig
+	readServer   *http.Server
+	readListener net.Listener
+
+	// gRPC API, see GRPCConfig
+	grpcServer   *grpc.Server
+	grpcListener net.Listener
+
+	// request hooks, loaded on Start
+	hooks hooks.Chain
+	// feature gates, loaded on Start
+	gates *featuregate.Set
+	// namespaces sharing the proxy, loaded on Start, nil if none is configured
+	namespaces *namespaces
+
+	// config the proxy is running with, see SetConfig
+	config *CLIConfig
+	// phases of the proxy's startup, see SetStartupTracker
+	startup *StartupTracker
 }
 
 func NewServer(host string, port int, router store.IRouter, log log.Logger,
-	m metrics.Metricer) *Server {
+	m metrics.Metricer, opts Options) *Server {
 	endpoint := net.JoinHostPort(host, strconv.Itoa(port))
-	return &Server{
-		m:        m,
-		log:      log,
-		endpoint: endpoint,
-		router:   router,
+	svr := &Server{
+		m:           m,
+		log:         log,
+		endpoint:    endpoint,
+		router:      router,
+		opts:        opts,
+		status:      newStatusTracker(),
+		usage:       newUsageTracker(opts.Usage, opts.Tenancy.Namespaces),
+		mirror:      newMirror(opts.Mirror, m, log),
+		jobs:        newJobTracker(opts.Async, log),
+		priorities:  newPriorities(opts.Priority, m),
+		crashes:     newCrashReporter(opts.CrashReports, opts.Version.Version, log),
+		maintenance: &maintenance{},
+		eigenDAInfo: &eigenDAInfoCache{refreshInterval: opts.EigenDAInfoRefreshInterval},
 		httpServer: &http.Server{
 			Addr:              endpoint,
 			ReadHeaderTimeout: 10 * time.Second,
@@ -55,6 +121,16 @@ func NewServer(host string, port int, router store.IRouter, log log.Logger,
 			WriteTimeout: 40 * time.Minute,
 		},
 	}
+
+	if opts.ReadListener.Enabled {
+		svr.readServer = &http.Server{
+			Addr:              net.JoinHostPort(opts.ReadListener.Addr, strconv.Itoa(opts.ReadListener.Port)),
+			ReadHeaderTimeout: 10 * time.Second,
+			// reads never wait for dispersals
+			WriteTimeout: 5 * time.Minute,
+		}
+	}
+	return svr
 }
 
 // WithMetrics is a middleware that records metrics for the route path.
@@ -81,42 +157,232 @@ func WithMetrics(
 	}
 }
 
+// responseWrittenError ... returned by handlers that already wrote an error response, e.g. a structured one, so
+// that WithLogging only logs the error
+type responseWrittenError struct {
+	error
+}
+
+func (e responseWrittenError) Unwrap() error {
+	return e.error
+}
+
 // WithLogging is a middleware that logs the request method and URL.
 func WithLogging(
 	handleFn func(http.ResponseWriter, *http.Request) error,
 	log log.Logger,
 ) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		log := store.RequestLogger(r.Context(), log)
 		log.Info("request", "method", r.Method, "url", r.URL)
 		err := handleFn(w, r)
+		if errors.Is(err, errStreamAborted) {
+			// the response body was partially written already, abort it so that the client notices
+			log.Error(err.Error())
+			panic(http.ErrAbortHandler)
+		}
 		if err != nil { // #nosec G104
-			w.Write([]byte(err.Error())) //nolint:errcheck // ignore error
+			var written responseWrittenError
+			if !errors.As(err, &written) {
+				w.Write([]byte(secrets.Scrub(err.Error()))) //nolint:errcheck // ignore error
+			}
 			log.Error(err.Error())
 		}
 	}
 }
 
 func (svr *Server) Start() error {
+	if err := svr.start(); err != nil {
+		return err
+	}
+	if svr.opts.GRPC.Enabled {
+		if err := svr.serveGRPC(); err != nil {
+			_ = svr.Stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// start ... loads the request hooks, feature gates and namespaces and starts the HTTP listeners
+func (svr *Server) start() error {
+	chain, err := hooks.Load(svr.opts.Hooks)
+	if err != nil {
+		return fmt.Errorf("failed to load request hooks: %w", err)
+	}
+	svr.hooks = chain
+	if svr.opts.Hooks.Enabled() {
+		svr.log.Info("Request hooks enabled", "hooks", strings.Join(svr.opts.Hooks.Names, ","), "http", svr.opts.Hooks.HTTP.URL)
+	}
+	gates, err := featuregate.New(svr.opts.FeatureGates)
+	if err != nil {
+		return fmt.Errorf("failed to load feature gates: %w", err)
+	}
+	svr.gates = gates
+	svr.namespaces, err = newNamespaces(svr.opts.Tenancy)
+	if err != nil {
+		return fmt.Errorf("failed to load namespaces: %w", err)
+	}
+
+	// with a separate read listener, the gateway profile only applies to reads served on it, while the main
+	// listener keeps serving the full API on the private network
+	if svr.opts.ReadListener.Enabled {
+		readMux := http.NewServeMux()
+		svr.registerReadRoutes(readMux, svr.opts.Gateway.Enabled)
+
+		mux := http.NewServeMux()
+		svr.registerReadRoutes(mux, false)
+		if err := svr.registerWriteRoutes(mux); err != nil {
+			return err
+		}
+
+		svr.httpServer.Handler = svr.withMiddleware(mux)
+		svr.readServer.Handler = svr.withMiddleware(readMux)
+		if err := svr.listen(); err != nil {
+			return err
+		}
+		svr.log.Info("Serving the read API on a separate listener", "gateway", svr.opts.Gateway.Enabled)
+		if err := svr.opts.HTTP2.configureHTTP2(svr.readServer); err != nil {
+			_ = svr.httpServer.Close()
+			return err
+		}
+		listener, err := serve(svr.readServer, svr.readServer.Addr, svr.log)
+		if err != nil {
+			_ = svr.httpServer.Close()
+			return fmt.Errorf("failed to start read listener: %w", err)
+		}
+		svr.readListener = listener
+		return nil
+	}
+
 	mux := http.NewServeMux()
+	svr.registerReadRoutes(mux, svr.opts.Gateway.Enabled)
+
+	// the public read gateway only serves blob reads
+	if svr.opts.Gateway.Enabled {
+		if svr.opts.Admin.Enabled || svr.opts.Explorer.Enabled {
+			svr.log.Warn("Admin API and blob explorer are disabled in gateway mode")
+		}
+		svr.httpServer.Handler = svr.withMiddleware(mux)
+		return svr.listen()
+	}
+
+	if err := svr.registerWriteRoutes(mux); err != nil {
+		return err
+	}
+
+	svr.httpServer.Handler = svr.withMiddleware(mux)
+	return svr.listen()
+}
 
-	mux.HandleFunc(GetRoute, WithLogging(WithMetrics(svr.HandleGet, svr.m), svr.log))
-	mux.HandleFunc(PutRoute, WithLogging(WithMetrics(svr.HandlePut, svr.m), svr.log))
+// registerReadRoutes ... mounts the blob read routes and the health route. With gateway set, the read routes are
+// hardened by the gateway profile.
+func (svr *Server) registerReadRoutes(mux *http.ServeMux, gateway bool) {
+	get := WithLogging(WithDeadline(WithPriority(WithMetrics(WithMirror(WithStatus(svr.HandleGet, svr.status), svr.mirror),
+		svr.m), svr.priorities)), svr.log)
+	if gateway {
+		get = WithGateway(get, svr.opts.Gateway)
+	}
+	if svr.opts.SecurityHeaders {
+		get = WithSecurityHeaders(get)
+	}
+	mux.HandleFunc(GetRoute, WithCORS(get, svr.opts.CORS))
+	registerCommitmentModeRoutes(mux, GetRoute, WithCORS(get, svr.opts.CORS))
 	mux.HandleFunc("/health", WithLogging(svr.Health, svr.log))
 
-	svr.httpServer.Handler = mux
+	// versioned hashes aren't mirrored, the shadow proxy's index doesn't map the same versioned hashes
+	getByVersionedHash := WithLogging(WithDeadline(WithPriority(WithMetrics(WithStatus(svr.HandleGetByVersionedHash,
+		svr.status), svr.m), svr.priorities)), svr.log)
+	if gateway {
+		getByVersionedHash = withGateway(getByVersionedHash, svr.opts.Gateway, svr.versionedHashCommitment)
+		svr.log.Info("Public read gateway enabled, only serving the GET routes",
+			"rate_limit", svr.opts.Gateway.RateLimit, "burst", svr.opts.Gateway.Burst,
+			"authenticated", len(svr.opts.Gateway.APIKeys) > 0, "allowed_prefixes", len(svr.opts.Gateway.AllowedPrefixes),
+			"trusted_proxies", len(svr.opts.Gateway.TrustedProxies))
+	}
+	if svr.opts.SecurityHeaders {
+		getByVersionedHash = WithSecurityHeaders(getByVersionedHash)
+	}
+	mux.HandleFunc(VersionedHashRoute, WithCORS(getByVersionedHash, svr.opts.CORS))
+}
+
+// registerWriteRoutes ... mounts the write route and the operator-facing routes, which are only served on the
+// main listener
+func (svr *Server) registerWriteRoutes(mux *http.ServeMux) error {
+	put := WithMirror(WithStatus(svr.HandlePut, svr.status), svr.mirror)
+	putRoute := WithLogging(WithDeadline(WithMetrics(WithAsync(put, svr.jobs, svr.writeMaintenance), svr.m)), svr.log)
+	mux.HandleFunc(PutRoute, putRoute)
+	registerCommitmentModeRoutes(mux, PutRoute, putRoute)
+	if svr.jobs != nil {
+		svr.log.Info("Asynchronous puts enabled", "route", StatusRoute, "max_in_flight", svr.opts.Async.MaxInFlight,
+			"min_ack", svr.opts.Async.MinAck, "journal_dir", svr.opts.Async.JournalDir)
+		if err := svr.jobs.replay(put); err != nil {
+			return err
+		}
+	}
+	if _, ok := svr.pendingDispersals(); ok || svr.jobs != nil {
+		mux.HandleFunc(StatusRoute, WithLogging(svr.HandleJobStatus, svr.log))
+	}
+	if svr.opts.Batch.Enabled() {
+		mux.HandleFunc(BatchPutRoute, WithLogging(WithDeadline(svr.HandleBatchPut), svr.log))
+	}
+	mux.HandleFunc(VersionRoute, WithLogging(svr.HandleVersion, svr.log))
+	mux.HandleFunc(EigenDAInfoRoute, WithLogging(svr.HandleEigenDAInfo, svr.log))
+	mux.HandleFunc(StartupReportRoute, WithLogging(svr.HandleStartupReport, svr.log))
+
+	if rs, ok := svr.router.GetEigenDAStore().(replicationServer); ok {
+		if h := rs.ReplicationHandler(); h != nil {
+			svr.log.Info("Serving memstore replication", "route", memstore.ReplicationRoute)
+			mux.Handle(memstore.ReplicationRoute, h)
+			mux.Handle(memstore.ReplicationRoute+"/", h)
+		}
+	}
 
-	listener, err := net.Listen("tcp", svr.endpoint)
+	if svr.opts.Admin.Enabled {
+		svr.log.Info("Admin API enabled", "route", AdminRoute)
+		svr.registerAdminRoutes(mux)
+	}
+
+	if svr.opts.CommitmentsAPI {
+		svr.log.Info("Commitments API enabled", "route", CommitmentsRoute)
+		mux.HandleFunc(CommitmentsRoute, WithLogging(svr.HandleCommitments, svr.log))
+		mux.HandleFunc(ByHeightRoute, WithLogging(svr.HandleByHeight, svr.log))
+	}
+
+	if svr.opts.Explorer.Enabled {
+		svr.log.Info("Blob explorer enabled", "route", ExplorerRoute)
+		if err := svr.registerExplorerRoutes(mux); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listen ... starts serving the configured handler
+func (svr *Server) listen() error {
+	if err := svr.opts.HTTP2.configureHTTP2(svr.httpServer); err != nil {
+		return err
+	}
+	listener, err := serve(svr.httpServer, svr.endpoint, svr.log)
 	if err != nil {
-		return fmt.Errorf("failed to listen: %w", err)
+		return err
 	}
 	svr.listener = listener
-
 	svr.endpoint = listener.Addr().String()
+	return nil
+}
 
-	svr.log.Info("Starting DA server", "endpoint", svr.endpoint)
+// serve ... starts serving srv on endpoint, returning once the server is up
+func serve(srv *http.Server, endpoint string, log log.Logger) (net.Listener, error) {
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	log.Info("Starting DA server", "endpoint", listener.Addr().String())
 	errCh := make(chan error, 1)
 	go func() {
-		if err := svr.httpServer.Serve(svr.listener); err != nil {
+		if err := srv.Serve(listener); err != nil {
 			errCh <- err
 		}
 	}()
@@ -127,9 +393,9 @@ func (svr *Server) Start() error {
 
 	select {
 	case err := <-errCh:
-		return fmt.Errorf("http server failed: %w", err)
+		return nil, fmt.Errorf("http server failed: %w", err)
 	case <-tick.C:
-		return nil
+		return listener, nil
 	}
 }
 
@@ -137,18 +403,58 @@ func (svr *Server) Endpoint() string {
 	return svr.listener.Addr().String()
 }
 
+// ReadEndpoint ... endpoint of the separate read listener, empty if it isn't enabled
+func (svr *Server) ReadEndpoint() string {
+	if svr.readListener == nil {
+		return ""
+	}
+	return svr.readListener.Addr().String()
+}
+
+// GRPCEndpoint ... endpoint of the gRPC API, empty if it isn't enabled
+func (svr *Server) GRPCEndpoint() string {
+	if svr.grpcListener == nil {
+		return ""
+	}
+	return svr.grpcListener.Addr().String()
+}
+
 func (svr *Server) Stop() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	if svr.grpcListener != nil {
+		svr.stopGRPC(ctx)
+	}
+	if svr.readListener != nil {
+		if err := svr.readServer.Shutdown(ctx); err != nil {
+			svr.log.Error("Failed to shutdown proxy read listener", "err", err)
+			return err
+		}
+	}
 	if err := svr.httpServer.Shutdown(ctx); err != nil {
 		svr.log.Error("Failed to shutdown proxy server", "err", err)
 		return err
 	}
 	return nil
 }
+
+// Health ... reports whether the proxy is serving requests, and whether it is doing so in a degraded mode
 func (svr *Server) Health(w http.ResponseWriter, _ *http.Request) error {
-	w.WriteHeader(http.StatusOK)
-	return nil
+	health := HealthStatus{Status: "ok"}
+	if reason := svr.maintenance.describe(); reason != "" {
+		health.Status = "degraded"
+		health.Degraded = append(health.Degraded, reason)
+	}
+	// the EigenDA store reports reduced verification, the router a degraded write path
+	for _, c := range []any{svr.router.GetEigenDAStore(), svr.router} {
+		if dr, ok := c.(degradationReporter); ok {
+			if reason := dr.Degraded(); reason != "" {
+				health.Status = "degraded"
+				health.Degraded = append(health.Degraded, reason)
+			}
+		}
+	}
+	return svr.writeJSON(w, health)
 }
 
 // HandleGet handles the GET request for commitments.
@@ -163,7 +469,10 @@ func (svr *Server) HandleGet(w http.ResponseWriter, r *http.Request) (commitment
 		return commitments.CommitmentMeta{}, err
 	}
 	key := path.Base(r.URL.Path)
-	comm, err := commitments.StringToDecodedCommitment(key, meta.Mode)
+	parsed, err := commitments.StringToCommitment(key, meta.Mode)
+	if err == nil {
+		err = svr.checkHashAlgorithm(parsed)
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to decode commitment from key %v (commitment mode %v): %w", key, meta.Mode, err)
 		svr.WriteBadRequest(w, err)
@@ -172,13 +481,57 @@ func (svr *Server) HandleGet(w http.ResponseWriter, r *http.Request) (commitment
 			Meta: meta,
 		}
 	}
+	meta = parsed.Meta()
+	comm := parsed.Cert
+
+	policy, err := readCachePolicy(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
 
-	input, err := svr.router.Get(r.Context(), comm, meta.Mode)
+	// the encoded commitment selects the prefix route the blob is read through, if any
+	raw, _ := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+	ctx, rm := store.WithResponseMeta(store.WithCommitment(store.WithCommitmentMode(r.Context(), meta.Mode), raw))
+	if parsed.Envelope != nil && len(parsed.Envelope.Meta.Chunks) > 0 {
+		ctx = store.WithChunks(ctx, parsed.Envelope.Meta.Chunks)
+	}
+	if policy != store.CachePolicyDefault {
+		svr.log.Info("Reading blob from EigenDA, skipping secondary stores", "policy", policy)
+		ctx = store.WithCachePolicy(ctx, policy)
+	}
+	call, err := svr.runHooks(ctx, w, hooks.Call{Stage: hooks.PreGet, Mode: meta.Mode, Commitment: comm})
+	if err != nil {
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	comm = call.Commitment
+
+	var input []byte
+	var verify func() error
+	if sr, ok := svr.router.(streamingRouter); ok && svr.wantsStream(r) {
+		input, verify, err = sr.GetStreaming(ctx, comm, meta.Mode)
+	} else {
+		input, err = svr.router.Get(ctx, comm, meta.Mode)
+	}
 	if err != nil {
 		err = fmt.Errorf("get request failed with commitment %v (commitment mode %v): %w", comm, meta.Mode, err)
-		if errors.Is(err, ErrNotFound) {
+		switch {
+		case upstreamDeadlineExceeded(ctx):
+			err = fmt.Errorf("%s: %w", ErrCodeDeadlineExceeded, err)
+			svr.WriteDeadlineExceeded(w, err)
+		case errors.Is(err, ErrNotFound):
 			svr.WriteNotFound(w, err)
-		} else {
+		case errors.Is(err, store.ErrArchived):
+			// the archived copy is being restored, which takes minutes to hours depending on the storage class
+			w.Header().Set("Retry-After", "300")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
 			svr.WriteInternalError(w, err)
 		}
 		return commitments.CommitmentMeta{}, MetaError{
@@ -187,6 +540,26 @@ func (svr *Server) HandleGet(w http.ResponseWriter, r *http.Request) (commitment
 		}
 	}
 
+	call, err = svr.runHooks(ctx, w, hooks.Call{Stage: hooks.PostGet, Mode: meta.Mode, Commitment: comm, Payload: input})
+	if err != nil {
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	input = call.Payload
+	svr.m.RecordBlobSize(r.Method, len(input))
+
+	writeResponseMeta(w, rm, meta.Mode, comm, len(input))
+	if verify != nil {
+		if err := svr.writeStream(ctx, w, input, verify); err != nil {
+			return commitments.CommitmentMeta{}, MetaError{
+				Err:  err,
+				Meta: meta,
+			}
+		}
+		return meta, nil
+	}
 	svr.WriteResponse(w, input)
 	return meta, nil
 }
@@ -207,6 +580,38 @@ func (svr *Server) HandlePut(w http.ResponseWriter, r *http.Request) (commitment
 	//TODO: smarter decode needed when there's more than one version
 	meta.CertVersion = byte(commitments.CertV0)
 
+	if svr.writeMaintenance(w) {
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  ErrMaintenance,
+			Meta: meta,
+		}
+	}
+
+	ttl, err := svr.readTTL(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	height, hasHeight, err := readRollupHeight(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	sinks, err := svr.readRedundancy(r)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+
 	input, err := io.ReadAll(r.Body)
 	if err != nil {
 		err = fmt.Errorf("failed to read request body: %w", err)
@@ -219,9 +624,15 @@ func (svr *Server) HandlePut(w http.ResponseWriter, r *http.Request) (commitment
 
 	key := path.Base(r.URL.Path)
 	var comm []byte
-
-	if len(key) > 0 && key != Put { // commitment key already provided (keccak256)
-		comm, err = commitments.StringToDecodedCommitment(key, meta.Mode)
+	// keccak256 commitments are computed by the proxy unless the client provides them
+	keyProvided := len(key) > 0 && key != Put
+
+	if keyProvided { // commitment key already provided (keccak256)
+		var parsed commitments.Commitment
+		parsed, err = commitments.StringToCommitment(key, meta.Mode)
+		if err == nil {
+			err = svr.checkHashAlgorithm(parsed)
+		}
 		if err != nil {
 			err = fmt.Errorf("failed to decode commitment from key %v (commitment mode %v): %w", key, meta.Mode, err)
 			svr.WriteBadRequest(w, err)
@@ -230,29 +641,96 @@ func (svr *Server) HandlePut(w http.ResponseWriter, r *http.Request) (commitment
 				Meta: meta,
 			}
 		}
+		comm = parsed.Cert
 	}
 
-	commitment, err := svr.router.Put(r.Context(), meta.Mode, comm, input)
+	ctx, rm := store.WithResponseMeta(store.WithCommitmentMode(r.Context(), meta.Mode))
+	if ttl > 0 {
+		ctx = store.WithTTL(ctx, ttl)
+	}
+	if hasHeight {
+		ctx = store.WithRollupHeight(ctx, height)
+	}
+	if len(sinks) > 0 {
+		ctx = store.WithRedundancy(ctx, sinks)
+	}
+	call, err := svr.runHooks(ctx, w, hooks.Call{Stage: hooks.PrePut, Mode: meta.Mode, Commitment: comm, Payload: input})
+	if err != nil {
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	comm, input = call.Commitment, call.Payload
+
+	dispersalStart := time.Now()
+	commitment, err := svr.router.Put(ctx, meta.Mode, comm, input)
 	if err != nil {
 		err = fmt.Errorf("put request failed with commitment %v (commitment mode %v): %w", comm, meta.Mode, err)
 
-		if errors.Is(err, store.ErrEigenDAOversizedBlob) || errors.Is(err, store.ErrProxyOversizedBlob) {
+		if errors.Is(err, store.ErrEigenDAOversizedBlob) || errors.Is(err, store.ErrProxyOversizedBlob) ||
+			errors.Is(err, store.ErrInvalidRedundancy) {
 			// we add here any error that should be returned as a 400 instead of a 500.
-			// currently only includes oversized blob requests
+			// currently includes oversized blob and invalid redundancy requests
 			svr.WriteBadRequest(w, err)
 			return meta, err
 		}
 
+		if errors.Is(err, store.ErrWritePathDegraded) {
+			// batchers are expected to retry or fail over, rather than wait for dispersals that are likely to fail
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return commitments.CommitmentMeta{}, MetaError{
+				Err:  err,
+				Meta: meta,
+			}
+		}
+
+		if upstreamDeadlineExceeded(ctx) {
+			err = fmt.Errorf("%s: %w", ErrCodeDeadlineExceeded, err)
+			svr.WriteDeadlineExceeded(w, err)
+			return commitments.CommitmentMeta{}, MetaError{
+				Err:  err,
+				Meta: meta,
+			}
+		}
+
 		svr.WriteInternalError(w, err)
 		return commitments.CommitmentMeta{}, MetaError{
 			Err:  err,
 			Meta: meta,
 		}
 	}
+	svr.m.RecordBlobSize(r.Method, len(input))
 
-	responseCommit, err := commitments.EncodeCommitment(commitment, meta.Mode)
+	call, err = svr.runHooks(ctx, w, hooks.Call{Stage: hooks.PostPut, Mode: meta.Mode, Commitment: commitment, Payload: input})
 	if err != nil {
-		err = fmt.Errorf("failed to encode commitment %v (commitment mode %v): %w", commitment, meta.Mode, err)
+		return commitments.CommitmentMeta{}, MetaError{
+			Err:  err,
+			Meta: meta,
+		}
+	}
+	commitment = call.Commitment
+
+	// writes served while the write path is degraded may be committed to in another mode than requested
+	mode := meta.Mode
+	if m := rm.CommitmentMode(); m != "" {
+		mode = m
+	}
+	var responseCommit []byte
+	if chunks := rm.Chunks(); len(chunks) > 0 {
+		// the commitment is the cert of the manifest of a payload split across several blobs
+		meta.CertVersion = byte(commitments.CertV1Envelope)
+		responseCommit, err = commitments.EncodeEnvelopeCommitment(commitments.Envelope{
+			Version: commitments.EnvelopeV0,
+			Meta:    commitments.EnvelopeMetadata{Chunks: chunks},
+			Cert:    commitment,
+		}, mode)
+	} else {
+		responseCommit, err = svr.encodeCommitment(commitment, mode)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to encode commitment %v (commitment mode %v): %w", commitment, mode, err)
 		svr.WriteInternalError(w, err)
 		return commitments.CommitmentMeta{}, MetaError{
 			Err:  err,
@@ -260,15 +738,31 @@ func (svr *Server) HandlePut(w http.ResponseWriter, r *http.Request) (commitment
 		}
 	}
 
-	svr.log.Info(fmt.Sprintf("response commitment: %x\n", responseCommit))
-	// write commitment to resp body if not in OptimismKeccak mode
-	if meta.Mode != commitments.OptimismKeccak {
+	writeResponseMeta(w, rm, mode, commitment, len(input))
+	writeDispersalDuration(w, time.Since(dispersalStart))
+	writeAcknowledged(w, rm)
+	if symbols := rm.EncodedSymbols(); symbols > 0 {
+		tenant := store.TenantFromContext(ctx)
+		svr.usage.record(tenant, len(input), symbols)
+		svr.m.RecordDispersalUsage(tenant, len(input), symbols)
+		writeEncodedSymbols(w, symbols)
+	}
+
+	svr.log.Info("Response commitment", "commitment", hexutil.Encode(responseCommit))
+	// write commitment to resp body unless the client provided it
+	if meta.Mode != commitments.OptimismKeccak || !keyProvided {
 		svr.WriteResponse(w, responseCommit)
 	}
 	return meta, nil
 }
 
+// WriteResponse ... writes data as the response body. Responses are served as opaque bytes unless the
+// caller set a content type, since net/http would otherwise sniff one from the payload (e.g. text/html
+// for blobs starting with markup) and intermediaries may then transform the body.
 func (svr *Server) WriteResponse(w http.ResponseWriter, data []byte) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
 	if _, err := w.Write(data); err != nil {
 		svr.WriteInternalError(w, err)
 	}
@@ -289,6 +783,16 @@ func (svr *Server) WriteBadRequest(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusBadRequest)
 }
 
+func (svr *Server) WriteForbidden(w http.ResponseWriter, err error) {
+	svr.log.Info("forbidden", "err", err)
+	w.WriteHeader(http.StatusForbidden)
+}
+
+func (svr *Server) WriteDeadlineExceeded(w http.ResponseWriter, err error) {
+	svr.log.Info("deadline exceeded upstream", "err", err)
+	writeDeadlineExceeded(w)
+}
+
 func (svr *Server) Port() int {
 	// read from listener
 	_, portStr, _ := net.SplitHostPort(svr.listener.Addr().String())
@@ -314,11 +818,39 @@ func ReadCommitmentMeta(r *http.Request) (commitments.CommitmentMeta, error) {
 	return commitments.CommitmentMeta{Mode: ct, CertVersion: cv}, nil
 }
 
+// hashAlgorithm ... hash algorithm of precomputed-key commitments
+func (svr *Server) hashAlgorithm() commitments.HashAlgorithm {
+	if svr.opts.HashAlgorithm == "" {
+		return commitments.Keccak256Hash
+	}
+	return svr.opts.HashAlgorithm
+}
+
+// encodeCommitment ... encodes the commitment of key, recording the hash algorithm of precomputed-key commitments
+// in their commitment type byte
+func (svr *Server) encodeCommitment(key []byte, mode commitments.CommitmentMode) ([]byte, error) {
+	if mode == commitments.OptimismKeccak {
+		return commitments.EncodeHashCommitment(svr.hashAlgorithm(), key), nil
+	}
+	return commitments.EncodeCommitment(key, mode)
+}
+
+// checkHashAlgorithm ... rejects precomputed-key commitments of a hash algorithm other than the one verified by
+// the S3 store
+func (svr *Server) checkHashAlgorithm(c commitments.Commitment) error {
+	if c.Mode == commitments.OptimismKeccak && c.Hash != svr.hashAlgorithm() {
+		return fmt.Errorf("commitment is a %s hash, but precomputed keys are verified with %s", c.Hash,
+			svr.hashAlgorithm())
+	}
+	return nil
+}
+
+// ReadCommitmentMode ... reads the commitment mode of a request from the commitment_mode query parameter, the
+// CommitmentModeHeader or a mode route (see CommitmentModeRoute), and otherwise infers it from the commit type byte
+// of the commitment in the path
 func ReadCommitmentMode(r *http.Request) (commitments.CommitmentMode, error) {
-	query := r.URL.Query()
-	key := query.Get(CommitmentModeKey)
-	if key != "" {
-		return commitments.StringToCommitmentMode(key)
+	if mode, ok, err := requestedCommitmentMode(r); ok || err != nil {
+		return mode, err
 	}
 
 	commit := path.Base(r.URL.Path)
@@ -340,7 +872,8 @@ func ReadCommitmentMode(r *http.Request) (commitments.CommitmentMode, error) {
 		case byte(commitments.GenericCommitmentType):
 			return commitments.OptimismGeneric, nil
 
-		case byte(commitments.Keccak256CommitmentType):
+		case byte(commitments.Keccak256CommitmentType), byte(commitments.Sha256CommitmentType),
+			byte(commitments.Blake3CommitmentType):
 			return commitments.OptimismKeccak, nil
 
 		default:
@@ -380,7 +913,7 @@ func (svr *Server) GetEigenDAStats() *store.Stats {
 }
 
 func (svr *Server) GetS3Stats() *store.Stats {
-	return svr.router.GetS3Store().Stats()
+	return svr.router.GetKeccakStore().Stats()
 }
 
 func (svr *Server) GetStoreStats(bt store.BackendType) (*store.Stats, error) {
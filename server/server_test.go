@@ -2,15 +2,22 @@ package server
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/Layr-Labs/eigenda-proxy/commitments"
 	"github.com/Layr-Labs/eigenda-proxy/metrics"
 	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +39,7 @@ func TestGetHandler(t *testing.T) {
 	mockRouter := mocks.NewMockIRouter(ctrl)
 
 	m := metrics.NewMetrics("default")
-	server := NewServer("localhost", 8080, mockRouter, log.New(), m)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), m, Options{})
 
 	tests := []struct {
 		name                   string
@@ -162,7 +169,7 @@ func TestPutHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRouter := mocks.NewMockIRouter(ctrl)
-	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{})
 
 	tests := []struct {
 		name                   string
@@ -222,6 +229,18 @@ func TestPutHandler(t *testing.T) {
 			expectError:            true,
 			expectedCommitmentMeta: commitments.CommitmentMeta{},
 		},
+		{
+			name: "Failure OP Mode Alt-DA - WritePathDegraded",
+			url:  "/put/",
+			body: []byte("some data that won't be dispersed while the write path is degraded"),
+			mockBehavior: func() {
+				mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, store.ErrWritePathDegraded)
+			},
+			expectedCode:           http.StatusServiceUnavailable,
+			expectedBody:           "",
+			expectError:            true,
+			expectedCommitmentMeta: commitments.CommitmentMeta{},
+		},
 		{
 			name: "Success OP Mode Alt-DA",
 			url:  "/put/",
@@ -299,3 +318,96 @@ func TestPutHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestResponseMetaHeaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	cert, err := rlp.EncodeToBytes(&verify.Certificate{
+		BlobHeader: &disperser.BlobHeader{
+			Commitment: &common.G1Commitment{},
+		},
+		BlobVerificationProof: &disperser.BlobVerificationProof{
+			BatchMetadata: &disperser.BatchMetadata{
+				BatchHeader: &disperser.BatchHeader{ReferenceBlockNumber: 42},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	body := []byte("some data that will successfully be written to EigenDA")
+	mockRouter.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(cert, nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/put/?commitment_mode=simple", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	_, err = server.HandlePut(rec, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, strconv.Itoa(len(body)), rec.Header().Get(BlobSizeHeader))
+	require.Equal(t, "42", rec.Header().Get(CertReferenceBlockHeader))
+	require.NotEmpty(t, rec.Header().Get(DispersalDurationHeader))
+
+	mockRouter.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(body, nil)
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/get/0x00%x?commitment_mode=simple", cert), nil)
+	rec = httptest.NewRecorder()
+
+	_, err = server.HandleGet(rec, req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, strconv.Itoa(len(body)), rec.Header().Get(BlobSizeHeader))
+	require.Equal(t, "42", rec.Header().Get(CertReferenceBlockHeader))
+	require.Empty(t, rec.Header().Get(DispersalDurationHeader))
+}
+
+type degradedStore struct {
+	store.GeneratedKeyStore
+	reason string
+}
+
+func (s degradedStore) Degraded() string {
+	return s.reason
+}
+
+func TestHealth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	tests := []struct {
+		name     string
+		store    store.GeneratedKeyStore
+		expected HealthStatus
+	}{
+		{
+			name:     "healthy",
+			store:    degradedStore{},
+			expected: HealthStatus{Status: "ok"},
+		},
+		{
+			name:     "degraded",
+			store:    degradedStore{reason: "cert-only mode"},
+			expected: HealthStatus{Status: "degraded", Degraded: []string{"cert-only mode"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRouter.EXPECT().GetEigenDAStore().Return(tt.store)
+
+			rec := httptest.NewRecorder()
+			require.NoError(t, server.Health(rec, httptest.NewRequest(http.MethodGet, "/health", nil)))
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			var got HealthStatus
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/snapshot"
+)
+
+const (
+	AdminSnapshotRoute = AdminRoute + "snapshot"
+	AdminRestoreRoute  = AdminRoute + "restore"
+)
+
+// HandleSnapshot ... streams a disaster-recovery snapshot archive of the proxy state
+func (svr *Server) HandleSnapshot(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	s, err := snapshot.Take(r.Context(), svr.router, svr.opts.Version.Version)
+	if err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+
+	name := fmt.Sprintf("eigenda-proxy-snapshot-%s.tar.gz", s.Meta.CreatedAt.Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	if err := s.Write(w); err != nil {
+		// the status has been written with the first chunk of the archive, the client sees a truncated archive
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	svr.log.Info("Took snapshot", "index_entries", len(s.Index), "dead_letters", len(s.DeadLetters),
+		"pending_dispersals", len(s.PendingDispersals), "created_at", s.Meta.CreatedAt.Format(time.RFC3339))
+	return nil
+}
+
+// HandleRestore ... restores the snapshot archive of the request body into the proxy state, and returns the
+// RestoreReport.
+// Example: curl -X POST --data-binary @snapshot.tar.gz /admin/restore
+func (svr *Server) HandleRestore(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	s, err := snapshot.Read(r.Body)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	report, err := snapshot.Restore(r.Context(), svr.router, s)
+	if err != nil {
+		svr.WriteInternalError(w, err)
+		return err
+	}
+	return svr.writeJSON(w, report)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/standby"
+)
+
+const (
+	syncSinceParam = "since"
+	syncLimitParam = "limit"
+	syncBlobsParam = "blobs"
+
+	defaultSyncLimit = 1000
+	maxSyncLimit     = 10_000
+)
+
+// HandleSync ... serves the index entries added after the given sequence number to warm standbys, oldest
+// first. The blobs of the given number of most recently added commitments are included, so that standbys
+// can warm their caches.
+// Example: GET /admin/sync?since=42&limit=1000&blobs=100
+func (svr *Server) HandleSync(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	q := r.URL.Query()
+	since, err := queryUint(q.Get(syncSinceParam), 0)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	limit, err := queryUint(q.Get(syncLimitParam), defaultSyncLimit)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+	blobs, err := queryUint(q.Get(syncBlobsParam), 0)
+	if err != nil {
+		svr.WriteBadRequest(w, err)
+		return err
+	}
+
+	idx := svr.router.Index()
+	batch := standby.Batch{Entries: []standby.Entry{}, Seq: idx.Seq(), Epoch: idx.Epoch()}
+	for _, e := range idx.Since(since, int(min(limit, maxSyncLimit))) { // #nosec G115
+		entry := standby.Entry{Entry: e}
+		if e.Mode != "" && e.Seq+blobs > batch.Seq {
+			payload, err := svr.router.Get(r.Context(), e.Key, commitments.CommitmentMode(e.Mode))
+			if err != nil {
+				svr.log.Warn("Failed to read blob for standby sync", "commitment", e.Key, "err", err)
+			} else {
+				entry.Payload = payload
+			}
+		}
+		batch.Entries = append(batch.Entries, entry)
+	}
+
+	return svr.writeJSON(w, batch)
+}
+
+// queryUint ... parses an unsigned integer query parameter, returning def if it is empty
+func queryUint(s string, def uint64) (uint64, error) {
+	if s == "" {
+		return def, nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid query parameter %q: %w", s, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/standby"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleSync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := index.New(10)
+	for _, k := range []string{"a", "b", "c"} {
+		idx.Put([]byte(k), 4, string(commitments.SimpleCommitmentMode))
+	}
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	// only the blob of the most recent commitment is requested
+	mockRouter.EXPECT().Get(gomock.Any(), []byte("c"), commitments.SimpleCommitmentMode).Return([]byte("blob"), nil)
+	server := NewServer("localhost", 8080, mockRouter, log.New(), metrics.NoopMetrics, Options{Admin: AdminConfig{Enabled: true}})
+
+	req := httptest.NewRequest(http.MethodGet, standby.SyncRoute+"?since=1&blobs=1", nil)
+	rec := httptest.NewRecorder()
+	require.NoError(t, server.HandleSync(rec, req))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var batch standby.Batch
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &batch))
+	require.Equal(t, uint64(3), batch.Seq)
+	require.Len(t, batch.Entries, 2)
+	require.Equal(t, []byte("b"), []byte(batch.Entries[0].Key))
+	require.Nil(t, batch.Entries[0].Payload)
+	require.Equal(t, []byte("blob"), []byte(batch.Entries[1].Payload))
+
+	req = httptest.NewRequest(http.MethodGet, standby.SyncRoute+"?since=x", nil)
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleSync(rec, req))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const StartupReportRoute = "/startup-report"
+
+// startup phases reported by the StartupTracker
+const (
+	// StartupPhaseBackends ... creating the secondary backends (S3, Redis, filesystem and local cache)
+	StartupPhaseBackends = "secondary_backends"
+	// StartupPhaseCertVerifier ... dialing the Ethereum RPC and checking the svc manager for cert verification
+	StartupPhaseCertVerifier = "cert_verifier"
+	// StartupPhaseSRSLoad ... loading the KZG SRS points
+	StartupPhaseSRSLoad = "srs_load"
+	// StartupPhaseEigenDA ... creating the EigenDA backend (or memstore), including loading pending dispersals and
+	// negotiating the max blob size with the disperser
+	StartupPhaseEigenDA = "eigenda_backend"
+	// StartupPhaseRouter ... loading the dead-letter queue and creating the storage router
+	StartupPhaseRouter = "router"
+	// StartupPhaseSnapshotRestore ... restoring the snapshot archive of --snapshot.restore-file
+	StartupPhaseSnapshotRestore = "snapshot_restore"
+	// StartupPhaseServer ... starting the HTTP listeners
+	StartupPhaseServer = "server_start"
+)
+
+// StartupPhase ... a timed phase of the proxy's startup
+type StartupPhase struct {
+	Name            string    `json:"name"`
+	Start           time.Time `json:"start"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	// HeapGrowthBytes is the growth of the heap during the phase, e.g. the memory taken by the SRS. Negative if
+	// garbage was collected.
+	HeapGrowthBytes int64  `json:"heap_growth_bytes"`
+	Error           string `json:"error,omitempty"`
+}
+
+// StartupReport ... phases of the proxy's startup, served on StartupReportRoute
+type StartupReport struct {
+	Start time.Time `json:"start"`
+	// Ready is the time the proxy started serving requests, unset while it is starting
+	Ready           time.Time      `json:"ready,omitempty"`
+	DurationSeconds float64        `json:"duration_seconds,omitempty"`
+	Phases          []StartupPhase `json:"phases"`
+	// HeapAllocBytes and SysBytes are the allocated heap and the memory obtained from the OS once ready
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes,omitempty"`
+	SysBytes       uint64 `json:"sys_bytes,omitempty"`
+}
+
+// StartupTracker ... times the phases of the proxy's startup, recording them as metrics and structured logs so
+// that startup-time regressions across releases can be tracked. A nil tracker records nothing.
+type StartupTracker struct {
+	mu     sync.Mutex
+	report StartupReport
+	m      metrics.Metricer
+	log    log.Logger
+}
+
+// NewStartupTracker ... constructor, the startup is timed from now
+func NewStartupTracker(m metrics.Metricer, l log.Logger) *StartupTracker {
+	return &StartupTracker{
+		report: StartupReport{Start: time.Now()},
+		m:      m,
+		log:    l,
+	}
+}
+
+type startupTrackerKey struct{}
+
+// WithStartupTracker ... returns a child context carrying the startup tracker t, so that the phases of
+// LoadStoreRouter are reported to it
+func WithStartupTracker(ctx context.Context, t *StartupTracker) context.Context {
+	return context.WithValue(ctx, startupTrackerKey{}, t)
+}
+
+// startupTrackerFromContext ... returns the startup tracker of the context, nil if none is set
+func startupTrackerFromContext(ctx context.Context) *StartupTracker {
+	t, _ := ctx.Value(startupTrackerKey{}).(*StartupTracker)
+	return t
+}
+
+// heapAlloc ... currently allocated heap bytes
+func heapAlloc() uint64 {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
+
+// Phase ... runs fn as the startup phase name, recording its duration, heap growth and error
+func (t *StartupTracker) Phase(name string, fn func() error) error {
+	if t == nil {
+		return fn()
+	}
+
+	start, heapBefore := time.Now(), heapAlloc()
+	err := fn()
+	t.Record(StartupPhase{
+		Name:            name,
+		Start:           start,
+		DurationSeconds: time.Since(start).Seconds(),
+		HeapGrowthBytes: int64(heapAlloc()) - int64(heapBefore), // #nosec G115
+		Error:           errString(err),
+	})
+	return err
+}
+
+// Begin ... starts timing the startup phase name, the returned func records the phase once it succeeded. Phases
+// that fail abort the startup, so they aren't recorded.
+func (t *StartupTracker) Begin(name string) func() {
+	if t == nil {
+		return func() {}
+	}
+
+	start, heapBefore := time.Now(), heapAlloc()
+	return func() {
+		t.Record(StartupPhase{
+			Name:            name,
+			Start:           start,
+			DurationSeconds: time.Since(start).Seconds(),
+			HeapGrowthBytes: int64(heapAlloc()) - int64(heapBefore), // #nosec G115
+		})
+	}
+}
+
+// Record ... records a startup phase timed by the caller, e.g. a part of a call that can't be timed on its own
+func (t *StartupTracker) Record(p StartupPhase) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	t.report.Phases = append(t.report.Phases, p)
+	t.mu.Unlock()
+
+	duration := time.Duration(p.DurationSeconds * float64(time.Second))
+	t.m.RecordStartupPhase(p.Name, duration, p.HeapGrowthBytes)
+	if p.Error != "" {
+		t.log.Error("Startup phase failed", "phase", p.Name, "duration", duration, "err", p.Error)
+		return
+	}
+	t.log.Info("Startup phase done", "phase", p.Name, "duration", duration, "heap_growth_bytes", p.HeapGrowthBytes)
+}
+
+// Ready ... records that the proxy serves requests, completing the startup
+func (t *StartupTracker) Ready() {
+	if t == nil {
+		return
+	}
+
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	t.mu.Lock()
+	t.report.Ready = time.Now()
+	duration := t.report.Ready.Sub(t.report.Start)
+	t.report.DurationSeconds = duration.Seconds()
+	t.report.HeapAllocBytes, t.report.SysBytes = ms.HeapAlloc, ms.Sys
+	t.mu.Unlock()
+
+	t.m.RecordStartupDone(duration)
+	t.log.Info("Startup done", "duration", duration, "heap_alloc_bytes", ms.HeapAlloc, "sys_bytes", ms.Sys)
+}
+
+// Report ... returns a copy of the startup report
+func (t *StartupTracker) Report() StartupReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rep := t.report
+	rep.Phases = append([]StartupPhase{}, t.report.Phases...)
+	return rep
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// SetStartupTracker ... sets the tracker of the proxy's startup, whose report is served on StartupReportRoute
+func (svr *Server) SetStartupTracker(t *StartupTracker) {
+	svr.startup = t
+}
+
+// HandleStartupReport ... returns the phases of the proxy's startup, with their durations and heap growth
+func (svr *Server) HandleStartupReport(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	if svr.startup == nil {
+		err := fmt.Errorf("startup report unavailable")
+		svr.WriteNotFound(w, err)
+		return err
+	}
+	return svr.writeJSON(w, svr.startup.Report())
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupTracker(t *testing.T) {
+	tracker := NewStartupTracker(metrics.NoopMetrics, log.New())
+	ctx := WithStartupTracker(context.Background(), tracker)
+	require.Same(t, tracker, startupTrackerFromContext(ctx))
+	require.Nil(t, startupTrackerFromContext(context.Background()))
+
+	var buf []byte
+	require.NoError(t, tracker.Phase(StartupPhaseSRSLoad, func() error {
+		buf = make([]byte, 1<<20)
+		return nil
+	}))
+	require.NotEmpty(t, buf)
+	errFailed := errors.New("failed")
+	require.ErrorIs(t, tracker.Phase(StartupPhaseSnapshotRestore, func() error { return errFailed }), errFailed)
+	tracker.Begin(StartupPhaseRouter)()
+
+	rep := tracker.Report()
+	require.True(t, rep.Ready.IsZero())
+	require.Len(t, rep.Phases, 3)
+	require.Equal(t, StartupPhaseSRSLoad, rep.Phases[0].Name)
+	require.Empty(t, rep.Phases[0].Error)
+	require.Equal(t, StartupPhaseSnapshotRestore, rep.Phases[1].Name)
+	require.Equal(t, errFailed.Error(), rep.Phases[1].Error)
+	require.Equal(t, StartupPhaseRouter, rep.Phases[2].Name)
+
+	tracker.Ready()
+	rep = tracker.Report()
+	require.False(t, rep.Ready.IsZero())
+	require.Positive(t, rep.DurationSeconds)
+	require.NotZero(t, rep.HeapAllocBytes)
+
+	// a nil tracker still runs the phases
+	var nilTracker *StartupTracker
+	ran := false
+	require.NoError(t, nilTracker.Phase(StartupPhaseRouter, func() error { ran = true; return nil }))
+	require.True(t, ran)
+	nilTracker.Begin(StartupPhaseRouter)()
+	nilTracker.Ready()
+}
+
+func TestHandleStartupReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := NewServer("localhost", 0, mocks.NewMockIRouter(ctrl), log.New(), metrics.NoopMetrics, Options{})
+
+	rec := httptest.NewRecorder()
+	err := server.HandleStartupReport(rec, httptest.NewRequest(http.MethodGet, StartupReportRoute, nil))
+	require.Error(t, err)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	tracker := NewStartupTracker(metrics.NoopMetrics, log.New())
+	tracker.Begin(StartupPhaseBackends)()
+	tracker.Ready()
+	server.SetStartupTracker(tracker)
+
+	rec = httptest.NewRecorder()
+	require.NoError(t, server.HandleStartupReport(rec, httptest.NewRequest(http.MethodGet, StartupReportRoute, nil)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var rep StartupReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rep))
+	require.Len(t, rep.Phases, 1)
+	require.Equal(t, StartupPhaseBackends, rep.Phases[0].Name)
+	require.False(t, rep.Ready.IsZero())
+
+	rec = httptest.NewRecorder()
+	require.Error(t, server.HandleStartupReport(rec, httptest.NewRequest(http.MethodPost, StartupReportRoute, nil)))
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+)
+
+const (
+	maxRecentErrors  = 20
+	throughputWindow = time.Minute
+)
+
+// RecentError ... an error returned by a request handler, as reported by the admin status endpoint
+type RecentError struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	Error  string    `json:"error"`
+}
+
+type dispersal struct {
+	at   time.Time
+	size int
+}
+
+// statusTracker ... keeps lightweight in-process request statistics that are reported by the admin API.
+// Unlike prometheus metrics these are meant for quick on-call triage and are reset on restart.
+type statusTracker struct {
+	mu    sync.Mutex
+	start time.Time
+
+	inFlight map[string]int
+	requests map[string]map[string]uint64 // method -> outcome -> count
+	reads    map[string]uint64            // backend -> reads served
+
+	recentErrors []RecentError
+	dispersals   []dispersal
+}
+
+func newStatusTracker() *statusTracker {
+	return &statusTracker{
+		start:    time.Now(),
+		inFlight: make(map[string]int),
+		requests: make(map[string]map[string]uint64),
+		reads:    make(map[string]uint64),
+	}
+}
+
+// WithStatus is a middleware that records request outcomes into the status tracker.
+// It relies on the response metadata headers set by the handlers to attribute reads to backends.
+func WithStatus(
+	handleFn func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error),
+	st *statusTracker,
+) func(http.ResponseWriter, *http.Request) (commitments.CommitmentMeta, error) {
+	return func(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+		st.begin(r.Method)
+		meta, err := handleFn(w, r)
+		st.end(r.Method, w.Header(), err)
+		return meta, err
+	}
+}
+
+func (st *statusTracker) begin(method string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.inFlight[method]++
+}
+
+// inFlightCount ... returns the number of in-flight requests of method
+func (st *statusTracker) inFlightCount(method string) int {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.inFlight[method]
+}
+
+func (st *statusTracker) end(method string, h http.Header, err error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.inFlight[method]--
+	if _, ok := st.requests[method]; !ok {
+		st.requests[method] = make(map[string]uint64)
+	}
+
+	if err != nil {
+		st.requests[method]["error"]++
+		st.recentErrors = append(st.recentErrors, RecentError{Time: time.Now(), Method: method, Error: err.Error()})
+		if len(st.recentErrors) > maxRecentErrors {
+			st.recentErrors = st.recentErrors[len(st.recentErrors)-maxRecentErrors:]
+		}
+		return
+	}
+
+	st.requests[method]["success"]++
+	switch method {
+	case http.MethodGet:
+		st.reads[h.Get(BackendHeader)]++
+	case http.MethodPost, http.MethodPut:
+		size, _ := strconv.Atoi(h.Get(BlobSizeHeader))
+		st.dispersals = append(st.dispersals, dispersal{at: time.Now(), size: size})
+		st.pruneDispersals()
+	}
+}
+
+// pruneDispersals ... drops dispersals that fall outside of the throughput window. Caller must hold the lock.
+func (st *statusTracker) pruneDispersals() {
+	cutoff := time.Now().Add(-throughputWindow)
+	i := 0
+	for i < len(st.dispersals) && st.dispersals[i].at.Before(cutoff) {
+		i++
+	}
+	st.dispersals = st.dispersals[i:]
+}
+
+// StatusReport ... snapshot of the proxy's state returned by the admin status endpoint
+type StatusReport struct {
+	Uptime       time.Duration                `json:"uptime"`
+	Backends     []BackendStatus              `json:"backends"`
+	InFlight     map[string]int               `json:"in_flight"`
+	Requests     map[string]map[string]uint64 `json:"requests"`
+	Reads        map[string]uint64            `json:"reads"`
+	CacheHitRate float64                      `json:"cache_hit_rate"`
+	Throughput   Throughput                   `json:"throughput"`
+	RecentErrors []RecentError                `json:"recent_errors"`
+}
+
+// BackendStatus ... describes a configured storage backend
+type BackendStatus struct {
+	Backend string `json:"backend"`
+	Role    string `json:"role"`
+	Entries int    `json:"entries"`
+	Reads   int    `json:"reads"`
+}
+
+// Throughput ... successful dispersals over the last minute
+type Throughput struct {
+	BlobsPerMinute int `json:"blobs_per_minute"`
+	BytesPerMinute int `json:"bytes_per_minute"`
+}
+
+// report ... builds a StatusReport; cacheBackends are the backend names whose reads count as cache hits
+func (st *statusTracker) report(backends []BackendStatus, cacheBackends []string) StatusReport {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	st.pruneDispersals()
+
+	rep := StatusReport{
+		Uptime:       time.Since(st.start),
+		Backends:     backends,
+		InFlight:     make(map[string]int, len(st.inFlight)),
+		Requests:     make(map[string]map[string]uint64, len(st.requests)),
+		Reads:        make(map[string]uint64, len(st.reads)),
+		RecentErrors: append([]RecentError(nil), st.recentErrors...),
+	}
+
+	for method, n := range st.inFlight {
+		rep.InFlight[method] = n
+	}
+	for method, outcomes := range st.requests {
+		rep.Requests[method] = make(map[string]uint64, len(outcomes))
+		for outcome, n := range outcomes {
+			rep.Requests[method][outcome] = n
+		}
+	}
+
+	var total, hits uint64
+	for backend, n := range st.reads {
+		rep.Reads[backend] = n
+		total += n
+		for _, cb := range cacheBackends {
+			if cb == backend {
+				hits += n
+			}
+		}
+	}
+	if total > 0 {
+		rep.CacheHitRate = float64(hits) / float64(total)
+	}
+
+	for _, d := range st.dispersals {
+		rep.Throughput.BlobsPerMinute++
+		rep.Throughput.BytesPerMinute += d.size
+	}
+
+	return rep
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+)
+
+const (
+	// StreamHeader ... request header opting into streamed GET responses, see Options.Streaming
+	StreamHeader = "X-EigenDA-Proxy-Stream"
+	// VerificationResultTrailer ... trailer of streamed GET responses carrying the verification outcome
+	VerificationResultTrailer = "X-EigenDA-Proxy-Verification-Result"
+
+	verificationResultOK = "ok"
+	// streamChunkSize ... size of the chunks streamed blobs are written in, the verification outcome is checked
+	// between chunks so that the stream is aborted early on failure
+	streamChunkSize = 64 * 1024
+)
+
+// errStreamAborted ... returned by handlers whose streamed response must be aborted, see WithLogging
+var errStreamAborted = errors.New("streamed response aborted")
+
+// streamingRouter ... implemented by routers able to return blobs before they are verified
+type streamingRouter interface {
+	GetStreaming(ctx context.Context, key []byte, cm commitments.CommitmentMode) ([]byte, func() error, error)
+}
+
+// wantsStream ... returns whether a GET request opted into a streamed response
+func (svr *Server) wantsStream(r *http.Request) bool {
+	if !svr.opts.Streaming || !svr.gates.Enabled(featuregate.Streaming, r.Header.Get(TenantHeader)) {
+		return false
+	}
+	stream, err := strconv.ParseBool(r.Header.Get(StreamHeader))
+	return err == nil && stream
+}
+
+// writeStream ... writes a blob while it is verified, announcing the verification outcome in the
+// VerificationResultTrailer. If verification fails, the response is aborted instead of being completed, so
+// that clients ignoring trailers still can't mistake the blob for a verified one. Headers must have been set
+// before. Returns an error wrapping errStreamAborted if the response must be aborted.
+func (svr *Server) writeStream(ctx context.Context, w http.ResponseWriter, data []byte, verify func() error) error {
+	verified := make(chan error, 1)
+	go func() { verified <- verify() }()
+
+	h := w.Header()
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", "application/octet-stream")
+	}
+	h.Set("Trailer", VerificationResultTrailer)
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	var result error
+	done := false
+	for len(data) > 0 {
+		n := min(streamChunkSize, len(data))
+		if _, err := w.Write(data[:n]); err != nil {
+			return fmt.Errorf("%w: failed to write blob: %w", errStreamAborted, err)
+		}
+		_ = rc.Flush()
+		data = data[n:]
+
+		select {
+		case result = <-verified:
+			done = true
+		default:
+		}
+		if done && result != nil {
+			break
+		}
+	}
+
+	if !done {
+		select {
+		case result = <-verified:
+		case <-ctx.Done():
+			result = ctx.Err()
+		}
+	}
+	if result != nil {
+		return fmt.Errorf("%w: blob failed verification: %w", errStreamAborted, result)
+	}
+	h.Set(VerificationResultTrailer, verificationResultOK)
+	return nil
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamedGet(t *testing.T) {
+	blob := make([]byte, 3*streamChunkSize+100)
+	for i := range blob {
+		blob[i] = byte(i)
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		stream     bool
+		verifyErr  error
+		expectBody bool
+		trailer    string
+	}{
+		{name: "Verified", enabled: true, stream: true, expectBody: true, trailer: verificationResultOK},
+		{name: "VerificationFailed", enabled: true, stream: true, verifyErr: errors.New("invalid kzg proof")},
+		{name: "NotRequested", enabled: true, stream: false, expectBody: true},
+		{name: "Disabled", enabled: false, stream: true, expectBody: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := store.NewRouter(&storetest.DAStore{Blob: blob, VerifyErr: tt.verifyErr}, nil, log.New(), nil, nil,
+				store.RouterOptions{})
+			require.NoError(t, err)
+			svr := NewServer("127.0.0.1", 0, r, log.New(), metrics.NoopMetrics, Options{Streaming: tt.enabled})
+			require.NoError(t, svr.Start())
+			t.Cleanup(func() { _ = svr.Stop() })
+
+			url := fmt.Sprintf("http://%s/get/0x010000%s", svr.Endpoint(), testCommitStr)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+			require.NoError(t, err)
+			if tt.stream {
+				req.Header.Set(StreamHeader, "true")
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+
+			if !tt.expectBody {
+				// the stream is aborted after the headers were sent
+				require.Equal(t, http.StatusOK, resp.StatusCode)
+				require.Error(t, err)
+				require.Empty(t, resp.Trailer.Get(VerificationResultTrailer))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, blob, body)
+			require.Equal(t, tt.trailer, resp.Trailer.Get(VerificationResultTrailer))
+		})
+	}
+}
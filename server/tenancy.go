@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"golang.org/x/time/rate"
+)
+
+// TenantRoute ... prefix of the routes of a namespace, e.g. /tenants/rollup-a/put/, so that rollups can share a
+// proxy by base URL only. Requests are served as if their namespace was selected by TenantHeader.
+const TenantRoute = "/tenants/"
+
+// namespaces ... namespaces sharing the proxy, see tenancy.Config, their API keys and the rate limiters of those
+// rate limited
+type namespaces struct {
+	names    map[string]struct{}
+	keys     map[string][]string
+	limiters map[string]*rate.Limiter
+	// limits the requests without a namespace, nil if unlimited
+	defaultLimiter *rate.Limiter
+}
+
+// newNamespaces ... loads the configured namespaces, nil if none is configured
+func newNamespaces(cfg tenancy.Config) (*namespaces, error) {
+	tenants, err := cfg.Tenants()
+	if err != nil || len(tenants) == 0 {
+		return nil, err
+	}
+	n := &namespaces{
+		names:    make(map[string]struct{}, len(tenants)),
+		keys:     make(map[string][]string, len(tenants)),
+		limiters: make(map[string]*rate.Limiter),
+	}
+	for name, t := range tenants {
+		n.names[name] = struct{}{}
+		n.keys[name] = t.APIKeys
+		if t.RateLimit > 0 {
+			n.limiters[name] = rate.NewLimiter(rate.Limit(t.RateLimit), cfg.Burst)
+		}
+	}
+	if cfg.DefaultRateLimit > 0 {
+		n.defaultLimiter = rate.NewLimiter(rate.Limit(cfg.DefaultRateLimit), cfg.Burst)
+	}
+	return n, nil
+}
+
+// authenticate ... returns the namespace of the API key presented by the request, empty if it presents none or a
+// key of no namespace, e.g. a gateway API key
+func (n *namespaces) authenticate(r *http.Request) string {
+	key, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || key == "" {
+		return ""
+	}
+	// the keys of every namespace are compared, so that the response time doesn't tell which namespace matched
+	namespace := ""
+	for name, keys := range n.keys {
+		if secrets.EqualAny(keys, key) {
+			namespace = name
+		}
+	}
+	return namespace
+}
+
+// limiter ... rate limiter of the requests of namespace, nil if unlimited. Requests without a namespace, including
+// those whose TenantHeader names a tenant that isn't a namespace, share the default limiter.
+func (n *namespaces) limiter(namespace string) *rate.Limiter {
+	if _, ok := n.names[namespace]; ok {
+		return n.limiters[namespace]
+	}
+	return n.defaultLimiter
+}
+
+// WithNamespaces ... authenticates the namespace of requests and rate limits the requests of each namespace. A
+// request selects its namespace by TenantHeader, by the routes of the namespace under TenantRoute, which are
+// served with the handler of the plain routes by stripping the namespace prefix from the path, or by presenting
+// an API key of the namespace. Requests selecting a namespace must present one of its API keys. Routes of unknown
+// namespaces are answered with 404, requests whose header selects another namespace than their route with 400.
+func WithNamespaces(handler http.Handler, n *namespaces) http.Handler {
+	if n == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace := r.Header.Get(TenantHeader)
+		rest, routed := strings.CutPrefix(r.URL.Path, TenantRoute)
+		path := ""
+		if routed {
+			var name string
+			name, path, _ = strings.Cut(rest, "/")
+			if _, ok := n.names[name]; !ok {
+				http.Error(w, fmt.Sprintf("unknown namespace %s", name), http.StatusNotFound)
+				return
+			}
+			if namespace != "" && namespace != name {
+				http.Error(w, fmt.Sprintf("tenant %s conflicts with the route of namespace %s", namespace, name),
+					http.StatusBadRequest)
+				return
+			}
+			namespace = name
+		}
+
+		owner := n.authenticate(r)
+		if _, ok := n.names[namespace]; ok && owner != namespace {
+			if owner == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				http.Error(w, fmt.Sprintf("namespace %s requires an API key", namespace), http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, fmt.Sprintf("API key doesn't belong to namespace %s", namespace), http.StatusForbidden)
+			return
+		}
+		if namespace == "" {
+			namespace = owner
+		}
+
+		if l := n.limiter(namespace); l != nil && !l.Allow() {
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "namespace rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+
+		if routed || namespace != r.Header.Get(TenantHeader) {
+			r = r.Clone(r.Context())
+			r.Header.Set(TenantHeader, namespace)
+			if routed {
+				r.URL.Path = "/" + path
+				r.URL.RawPath = ""
+				r.RequestURI = r.URL.RequestURI()
+			}
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/tenancy"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithNamespaces(t *testing.T) {
+	n, err := newNamespaces(tenancy.Config{
+		Namespaces:       []string{"rollup-a", "rollup-b"},
+		APIKeys:          []string{"rollup-a=key-a", "rollup-b=key-b"},
+		RateLimits:       []string{"rollup-b=1"},
+		DefaultRateLimit: 1,
+		Burst:            1,
+	})
+	require.NoError(t, err)
+
+	var served *http.Request
+	handler := WithNamespaces(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) { served = r }), n)
+	serve := func(target, tenant, apiKey string) *httptest.ResponseRecorder {
+		served = nil
+		req := httptest.NewRequest(http.MethodPost, target, nil)
+		if tenant != "" {
+			req.Header.Set(TenantHeader, tenant)
+		}
+		if apiKey != "" {
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("Route", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve("/tenants/rollup-a/put/?commitment_mode=simple", "", "key-a").Code)
+		require.Equal(t, "/put/", served.URL.Path)
+		require.Equal(t, "/put/?commitment_mode=simple", served.RequestURI)
+		require.Equal(t, "rollup-a", served.Header.Get(TenantHeader))
+
+		// the header may repeat the namespace of the route
+		require.Equal(t, http.StatusOK, serve("/tenants/rollup-a/get/0x0100", "rollup-a", "key-a").Code)
+		require.Equal(t, "/get/0x0100", served.URL.Path)
+	})
+
+	t.Run("Header", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve("/put/", "rollup-a", "key-a").Code)
+		require.Equal(t, "/put/", served.URL.Path)
+		require.Equal(t, "rollup-a", served.Header.Get(TenantHeader))
+	})
+
+	t.Run("APIKey", func(t *testing.T) {
+		// the key selects its namespace if the request names none
+		require.Equal(t, http.StatusOK, serve("/put/", "", "key-a").Code)
+		require.Equal(t, "rollup-a", served.Header.Get(TenantHeader))
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		rec := serve("/put/", "rollup-a", "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		require.Equal(t, http.StatusUnauthorized, serve("/tenants/rollup-a/put/", "", "wrong").Code)
+		require.Nil(t, served)
+	})
+
+	t.Run("KeyOfAnotherNamespace", func(t *testing.T) {
+		require.Equal(t, http.StatusForbidden, serve("/put/", "rollup-a", "key-b").Code)
+		require.Equal(t, http.StatusForbidden, serve("/tenants/rollup-a/put/", "", "key-b").Code)
+		require.Nil(t, served)
+	})
+
+	t.Run("UnknownNamespace", func(t *testing.T) {
+		require.Equal(t, http.StatusNotFound, serve("/tenants/rollup-c/put/", "", "key-a").Code)
+		require.Nil(t, served)
+	})
+
+	t.Run("ConflictingHeader", func(t *testing.T) {
+		require.Equal(t, http.StatusBadRequest, serve("/tenants/rollup-a/put/", "rollup-b", "key-a").Code)
+		require.Nil(t, served)
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve("/tenants/rollup-b/put/", "", "key-b").Code)
+		rec := serve("/put/", "", "key-b")
+		require.Equal(t, http.StatusTooManyRequests, rec.Code)
+		require.Equal(t, "1", rec.Header().Get("Retry-After"))
+		require.Nil(t, served)
+
+		// other namespaces have limits of their own
+		require.Equal(t, http.StatusOK, serve("/tenants/rollup-a/put/", "", "key-a").Code)
+	})
+
+	t.Run("DefaultRateLimit", func(t *testing.T) {
+		// requests without a namespace, or naming a tenant that isn't one, share the default limit
+		require.Equal(t, http.StatusOK, serve("/put/", "", "").Code)
+		require.Empty(t, served.Header.Get(TenantHeader))
+		require.Equal(t, http.StatusTooManyRequests, serve("/put/", "rollup-c", "").Code)
+		require.Equal(t, http.StatusTooManyRequests, serve("/put/", "", "").Code)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		n, err := newNamespaces(tenancy.Config{})
+		require.NoError(t, err)
+		require.Nil(t, n)
+	})
+}
+
+func TestNamespaceUsage(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Admin:   AdminConfig{Enabled: true},
+		Tenancy: tenancy.Config{Namespaces: []string{"rollup-a"}, APIKeys: []string{"rollup-a=key-a"}, Burst: 1},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		"http://"+svr.Endpoint()+TenantRoute+"rollup-a/put/", bytes.NewReader(make([]byte, 100)))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer key-a")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, svr.HandleUsage(rec, httptest.NewRequest(http.MethodGet, AdminUsageRoute, nil)))
+	var report map[string]TenantUsage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Equal(t, map[string]TenantUsage{
+		"rollup-a": {Dispersals: 1, PayloadBytes: 100, EncodedSymbols: 8, EncodedBytes: 256},
+	}, report)
+}
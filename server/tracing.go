@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithTracing is a middleware that starts the server span of a request, continuing the trace propagated by the
+// client via the traceparent header if any. Spans are named after the route rather than the path, since paths
+// embed commitments.
+func WithTracing(handler http.Handler) http.Handler {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		meta := store.RequestMetaFromContext(r.Context())
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(attribute.String("request.id", meta.ID))
+		if meta.Tenant != "" {
+			span.SetAttributes(attribute.String("tenant", meta.Tenant))
+		}
+		handler.ServeHTTP(w, r)
+	})
+	return otelhttp.NewHandler(inner, "eigenda-proxy", otelhttp.WithSpanNameFormatter(
+		func(_ string, r *http.Request) string {
+			return r.Method + " " + routeOf(r.URL.Path)
+		}))
+}
+
+// routeOf ... returns the route of a request path, i.e. its first segment, e.g. /get for /get/0x...
+func routeOf(path string) string {
+	route, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	return "/" + route
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/featuregate"
+)
+
+// TTLHeader ... PUT request header hinting how long the blob is needed, as a duration (e.g. "72h") or a number
+// of seconds. Writes to secondary stores that support expiry are kept for at most that long, see Options.TTLHints.
+const TTLHeader = "X-EigenDA-Proxy-TTL"
+
+// readTTL ... returns the TTL hint of a PUT request, 0 if it doesn't set one or TTL hints are disabled
+func (svr *Server) readTTL(r *http.Request) (time.Duration, error) {
+	v := r.Header.Get(TTLHeader)
+	if v == "" || !svr.opts.TTLHints || !svr.gates.Enabled(featuregate.TTLHints, r.Header.Get(TenantHeader)) {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil {
+		secs, serr := strconv.ParseUint(v, 10, 32)
+		if serr != nil {
+			return 0, fmt.Errorf("invalid %s header %q: expected a duration or a number of seconds", TTLHeader, v)
+		}
+		ttl = time.Duration(secs) * time.Second
+	}
+	if ttl <= 0 {
+		return 0, errors.New(TTLHeader + " header must be positive")
+	}
+	return ttl, nil
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		header      string
+		disabled    bool
+		expected    time.Duration
+		expectError bool
+	}{
+		{name: "None"},
+		{name: "Duration", header: "72h", expected: 72 * time.Hour},
+		{name: "Seconds", header: "3600", expected: time.Hour},
+		{name: "Disabled", header: "72h", disabled: true},
+		{name: "Zero", header: "0", expectError: true},
+		{name: "Negative", header: "-1h", expectError: true},
+		{name: "Invalid", header: "tomorrow", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr := &Server{opts: Options{TTLHints: !tt.disabled}}
+			req := httptest.NewRequest(http.MethodPut, "/put", nil)
+			if tt.header != "" {
+				req.Header.Set(TTLHeader, tt.header)
+			}
+			ttl, err := svr.readTTL(req)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, ttl)
+		})
+	}
+}
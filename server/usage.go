@@ -0,0 +1,110 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+const (
+	AdminUsageRoute = AdminRoute + "usage"
+
+	// TenantHeader ... request header identifying the tenant a PUT is accounted to, and the namespace of the request
+	// if it names one, see WithNamespaces
+	TenantHeader = "X-EigenDA-Proxy-Tenant"
+
+	defaultTenant = "default"
+	otherTenant   = "other"
+)
+
+// UsageConfig ... per tenant accounting of dispersals
+type UsageConfig struct {
+	// Tenants usage is tracked for, every other tenant is accounted to "other" so that the number of metric
+	// series stays bounded. All usage is accounted to "default" if empty.
+	Tenants []string
+}
+
+// TenantUsage ... dispersal usage of a tenant. Billing by payload bytes under-counts the padding added by
+// the blob encoding, EncodedSymbols is the number of field elements actually dispersed.
+type TenantUsage struct {
+	Dispersals     uint64 `json:"dispersals"`
+	PayloadBytes   uint64 `json:"payload_bytes"`
+	EncodedSymbols uint64 `json:"encoded_symbols"`
+	// EncodedBytes is EncodedSymbols * store.BytesPerSymbol
+	EncodedBytes uint64 `json:"encoded_bytes"`
+}
+
+// usageTracker ... accumulates the dispersal usage of each tenant since startup, reported by the admin API
+type usageTracker struct {
+	tenants map[string]struct{}
+
+	mu    sync.Mutex
+	usage map[string]*TenantUsage
+}
+
+// newUsageTracker ... tracks the usage of the configured tenants and of the namespaces, see tenancy.Config
+func newUsageTracker(cfg UsageConfig, namespaces []string) *usageTracker {
+	u := &usageTracker{
+		tenants: make(map[string]struct{}, len(cfg.Tenants)+len(namespaces)),
+		usage:   make(map[string]*TenantUsage),
+	}
+	for _, t := range cfg.Tenants {
+		u.tenants[t] = struct{}{}
+	}
+	for _, t := range namespaces {
+		u.tenants[t] = struct{}{}
+	}
+	return u
+}
+
+// tenant ... returns the tenant a request is accounted to
+func (u *usageTracker) tenant(r *http.Request) string {
+	if len(u.tenants) == 0 {
+		return defaultTenant
+	}
+	t := r.Header.Get(TenantHeader)
+	if t == "" {
+		return defaultTenant
+	}
+	if _, ok := u.tenants[t]; !ok {
+		return otherTenant
+	}
+	return t
+}
+
+func (u *usageTracker) record(tenant string, payloadBytes, symbols int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	tu, ok := u.usage[tenant]
+	if !ok {
+		tu = &TenantUsage{}
+		u.usage[tenant] = tu
+	}
+	tu.Dispersals++
+	tu.PayloadBytes += uint64(payloadBytes)                   // #nosec G115
+	tu.EncodedSymbols += uint64(symbols)                      // #nosec G115
+	tu.EncodedBytes += uint64(symbols * store.BytesPerSymbol) // #nosec G115
+}
+
+func (u *usageTracker) report() map[string]TenantUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	report := make(map[string]TenantUsage, len(u.usage))
+	for t, tu := range u.usage {
+		report[t] = *tu
+	}
+	return report
+}
+
+// HandleUsage ... returns the dispersal usage of each tenant since startup, keyed by tenant
+func (svr *Server) HandleUsage(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	return svr.writeJSON(w, svr.usage.report())
+}
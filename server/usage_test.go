@@ -0,0 +1,51 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispersalUsage(t *testing.T) {
+	svr := NewServer("127.0.0.1", 0, newMemstoreRouter(t), log.New(), metrics.NoopMetrics, Options{
+		Admin: AdminConfig{Enabled: true},
+		Usage: UsageConfig{Tenants: []string{"rollup-a"}},
+	})
+	require.NoError(t, svr.Start())
+	t.Cleanup(func() { _ = svr.Stop() })
+
+	put := func(tenant string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://"+svr.Endpoint()+"/put/",
+			bytes.NewReader(make([]byte, 100)))
+		require.NoError(t, err)
+		req.Header.Set(TenantHeader, tenant)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp
+	}
+
+	// a 32 byte codec header and 4 symbols of 31 payload bytes each, padded to a power of two by the IFFT
+	resp := put("rollup-a")
+	require.Equal(t, "8", resp.Header.Get(EncodedSymbolsHeader))
+	put("rollup-a")
+	put("rollup-b")
+
+	rec := httptest.NewRecorder()
+	require.NoError(t, svr.HandleUsage(rec, httptest.NewRequest(http.MethodGet, AdminUsageRoute, nil)))
+
+	var report map[string]TenantUsage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	require.Equal(t, map[string]TenantUsage{
+		"rollup-a":  {Dispersals: 2, PayloadBytes: 200, EncodedSymbols: 16, EncodedBytes: 512},
+		otherTenant: {Dispersals: 1, PayloadBytes: 100, EncodedSymbols: 8, EncodedBytes: 256},
+	}, report)
+}
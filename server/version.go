@@ -0,0 +1,232 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/memstore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	VersionRoute = "/version"
+
+	// eigenDAModule ... module providing the EigenDA client
+	eigenDAModule = "github.com/Layr-Labs/eigenda"
+)
+
+// VersionInfo ... build and configuration details served on VersionRoute, so that fleet tooling can
+// detect version and config drift across replicas
+type VersionInfo struct {
+	Version              string   `json:"version"`
+	Commit               string   `json:"commit"`
+	Date                 string   `json:"date"`
+	EigenDAClientVersion string   `json:"eigenda_client_version"`
+	GoVersion            string   `json:"go_version"`
+	Features             []string `json:"features"`
+	// ConfigHash is the Fingerprint of the effective config
+	ConfigHash string `json:"config_hash"`
+}
+
+// NewVersionInfo ... builds the VersionInfo of a proxy running with cfg
+func NewVersionInfo(version, commit, date string, cfg CLIConfig) (VersionInfo, error) {
+	hash, err := cfg.Fingerprint()
+	if err != nil {
+		return VersionInfo{}, err
+	}
+
+	return VersionInfo{
+		Version:              version,
+		Commit:               commit,
+		Date:                 date,
+		EigenDAClientVersion: eigenDAClientVersion(),
+		GoVersion:            runtime.Version(),
+		Features:             cfg.Features(),
+		ConfigHash:           hash,
+	}, nil
+}
+
+// eigenDAClientVersion ... returns the version of the EigenDA client module the binary was built with
+func eigenDAClientVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != eigenDAModule {
+			continue
+		}
+		if dep.Replace != nil {
+			return dep.Replace.Version
+		}
+		return dep.Version
+	}
+	return "unknown"
+}
+
+// Redacted ... returns a copy of the config with secrets replaced, safe to be logged or served. Secrets read
+// from files are redacted as well, in case they were loaded into the config already.
+func (c CLIConfig) Redacted() CLIConfig {
+	signer := &c.EigenDAConfig.EdaClientConfig.SignerPrivateKeyHex
+	*signer = secrets.Redact(*signer)
+	c.EigenDAConfig.S3Config.AccessKeySecret = secrets.Redact(c.EigenDAConfig.S3Config.AccessKeySecret)
+	c.EigenDAConfig.RedisConfig.Password = secrets.Redact(c.EigenDAConfig.RedisConfig.Password)
+	c.EigenDAConfig.AzureConfig.ConnectionString = secrets.Redact(c.EigenDAConfig.AzureConfig.ConnectionString)
+	c.ServerOptions.CrashReports.DSN = secrets.Redact(c.ServerOptions.CrashReports.DSN)
+	c.ServerOptions.Mirror.URL = secrets.RedactURL(c.ServerOptions.Mirror.URL)
+	c.ServerOptions.Hooks.HTTP.URL = secrets.RedactURL(c.ServerOptions.Hooks.HTTP.URL)
+
+	c.ServerOptions.Gateway.APIKeys = redactAll(c.ServerOptions.Gateway.APIKeys)
+	c.ServerOptions.Priority.BatchAPIKeys = redactAll(c.ServerOptions.Priority.BatchAPIKeys)
+	c.EigenDAConfig.Tenancy = c.EigenDAConfig.Tenancy.Redacted()
+	c.ServerOptions.Tenancy = c.ServerOptions.Tenancy.Redacted()
+
+	// build metadata isn't configuration
+	c.ServerOptions.Version = VersionInfo{}
+	return c
+}
+
+// Secrets ... returns the secrets set in the config, to be registered with secrets.Register so that they are
+// scrubbed from logs and error messages. Secrets read from files are registered when they are read.
+func (c CLIConfig) Secrets() []string {
+	s := []string{
+		// covers the key whether printed with or without its prefix
+		strings.TrimPrefix(c.EigenDAConfig.EdaClientConfig.SignerPrivateKeyHex, "0x"),
+		c.EigenDAConfig.S3Config.AccessKeySecret,
+		c.EigenDAConfig.RedisConfig.Password,
+		c.EigenDAConfig.AzureConfig.ConnectionString,
+		c.ServerOptions.CrashReports.DSN,
+	}
+	for _, u := range []string{c.ServerOptions.Mirror.URL, c.ServerOptions.Hooks.HTTP.URL} {
+		if parsed, err := url.Parse(u); err == nil {
+			if password, ok := parsed.User.Password(); ok {
+				s = append(s, password)
+			}
+		}
+	}
+	s = append(s, c.ServerOptions.Gateway.APIKeys...)
+	s = append(s, c.ServerOptions.Tenancy.Secrets()...)
+	return append(s, c.ServerOptions.Priority.BatchAPIKeys...)
+}
+
+// redactAll ... returns a copy of secrets, each redacted
+func redactAll(secretList []string) []string {
+	redacted := make([]string, len(secretList))
+	for i, s := range secretList {
+		redacted[i] = secrets.Redact(s)
+	}
+	return redacted
+}
+
+// Fingerprint ... hex encoded sha256 of the redacted config. Replicas running with the same flags have the
+// same fingerprint, regardless of their secrets.
+func (c CLIConfig) Fingerprint() (string, error) {
+	b, err := json.Marshal(c.Redacted())
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	h := sha256.Sum256(b)
+	return hexutil.Encode(h[:]), nil
+}
+
+// Features ... returns the sorted list of optional features enabled by the config
+func (c CLIConfig) Features() []string {
+	cfg := c.EigenDAConfig
+	opts := c.ServerOptions
+
+	features := []string{}
+	add := func(enabled bool, feature string) {
+		if enabled {
+			features = append(features, feature)
+		}
+	}
+
+	add(cfg.MemstoreEnabled, "memstore")
+	add(cfg.MemstoreConfig.Faults.Enabled(), "memstore-faults")
+	add(cfg.MemstoreConfig.Replication.Role != memstore.RoleStandalone,
+		"memstore-replication:"+string(cfg.MemstoreConfig.Replication.Role))
+	add(cfg.VerifierConfig.VerifyCerts, "cert-verification")
+	add(cfg.VerifierConfig.AllowMissingSRS, "allow-missing-srs")
+	add(len(cfg.VerifierConfig.ReadQuorums) > 0, "read-quorum-policy")
+	for _, t := range cfg.CacheTargets {
+		add(true, "cache:"+t)
+	}
+	for _, t := range cfg.FallbackTargets {
+		add(true, "fallback:"+t)
+	}
+	add(cfg.keccakBackendType() != store.S3BackendType, "keccak:"+cfg.KeccakBackend)
+	add(len(cfg.Quotas) > 0, "quotas")
+	add(len(cfg.Quotas) > 0 && cfg.QuotaUsagePath != "", "quota-usage-persistence")
+	add(cfg.DeadLetterPath != "", "dead-letter-persistence")
+	add(cfg.DeadLetterRetryInterval > 0, "dead-letter-retries")
+	add(cfg.SnapshotRestoreFile != "", "snapshot-restore")
+	add(cfg.ReconcileInterval > 0, "reconciliation")
+	add(cfg.CoalesceReads, "read-coalescing")
+	add(cfg.Envelope, "envelope")
+	add(cfg.RefreshStaleCerts, "stale-cert-refresh")
+	add(cfg.ReadSLO > 0, "read-slo")
+	add(cfg.HedgeDelay > 0 && len(cfg.CacheTargets) > 0, "hedged-reads")
+	add(len(cfg.PrefixRoutes) > 0, "prefix-routes")
+	add(cfg.WarmPoolInterval > 0, "warm-pool")
+	add(cfg.Degradation.Enabled(), "degradation:"+string(cfg.Degradation.Mode))
+	add(cfg.Breakers.Enabled(), "circuit-breakers")
+	add(cfg.Breakers.Enabled() && cfg.Breakers.FlapThreshold > 0, "flap-detection")
+	add(cfg.Tiering.Enabled(), "tiering")
+	add(cfg.AccessStats.SampleRate > 0 && cfg.AccessStats.SampleRate < 1, "access-sampling")
+	add(cfg.Chunking.Enabled(), "chunking")
+	add(cfg.PendingDispersalsPath != "", "pending-dispersal-persistence")
+	add(cfg.PendingDispersalsRedisKey != "", "pending-dispersal-redis")
+	add(cfg.CustomQuorumFallbackAfter > 0, "custom-quorum-fallback")
+	add(len(cfg.RetrieverRPCs) > 0, "node-retrieval")
+	add(cfg.PointVerificationModeFallback, "point-verification-mode-fallback")
+	add(len(cfg.DisperserRPCs) > 0, "disperser-failover")
+	add(cfg.FSConfig.CompactionInterval > 0, "fs-compaction")
+	add(cfg.S3Config.EndpointDiscovery != "", "s3-endpoint-discovery")
+	add(cfg.RedisConfig.EndpointDiscovery != "", "redis-endpoint-discovery")
+	add(cfg.BackfillConfig.Enabled, "backfill")
+	add(cfg.StandbyConfig.Enabled(), "standby")
+	add(c.MetricsCfg.Enabled, "metrics")
+	add(c.TracingCfg.Enabled(), "tracing")
+	add(opts.Admin.Enabled, "admin")
+	add(opts.Explorer.Enabled, "explorer")
+	add(opts.Hooks.Enabled(), "hooks")
+	add(opts.Gateway.Enabled, "gateway")
+	add(opts.ReadListener.Enabled, "read-listener")
+	add(opts.GRPC.Enabled, "grpc")
+	add(opts.HTTP2.Enabled, "http2")
+	add(len(opts.CORS.AllowedOrigins) > 0, "cors")
+	add(opts.SecurityHeaders, "security-headers")
+	add(opts.Streaming, "streaming")
+	add(opts.TTLHints, "ttl-hints")
+	add(opts.RedundancyRequests, "redundancy-requests")
+	add(opts.CommitmentsAPI, "commitments-api")
+	add(opts.Mirror.Enabled(), "mirror")
+	add(opts.Async.Enabled, "async")
+	add(opts.Async.Enabled && opts.Async.JournalDir != "", "put-journal")
+	add(opts.Batch.Enabled(), "batch")
+	add(opts.Priority.Enabled, "request-classes")
+	add(opts.CrashReports.DSN != "", "crash-reports")
+	add(len(opts.FeatureGates.Rules) > 0, "feature-gates")
+	add(opts.Tenancy.Enabled(), "tenancy")
+
+	sort.Strings(features)
+	return features
+}
+
+// HandleVersion ... returns the VersionInfo the server was started with
+func (svr *Server) HandleVersion(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+	return svr.writeJSON(w, svr.opts.Version)
+}
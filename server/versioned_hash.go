@@ -0,0 +1,147 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	VersionedHashRoute = "/versioned-hash/"
+
+	// error codes returned by the versioned hash route
+	ErrCodeInvalidVersionedHash  = "invalid_versioned_hash"
+	ErrCodeUnmappedVersionedHash = "unmapped_versioned_hash"
+	ErrCodeBlobUnavailable       = "blob_unavailable"
+	ErrCodeRejectedByHook        = "rejected_by_hook"
+)
+
+// VersionedHashError ... structured error body returned by the versioned hash route
+type VersionedHashError struct {
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	VersionedHash string `json:"versioned_hash"`
+}
+
+// HandleGetByVersionedHash ... returns the blob addressed by an EIP-4844 style versioned hash of its KZG
+// commitment, for tooling that expects blobs to be addressed that way. The versioned hash is resolved to
+// an EigenDA commitment via the commitment index, so only blobs known to the index can be served.
+// Example: GET /versioned-hash/0x01...
+func (svr *Server) HandleGetByVersionedHash(w http.ResponseWriter, r *http.Request) (commitments.CommitmentMeta, error) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return commitments.CommitmentMeta{}, fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	raw := path.Base(r.URL.Path)
+	vh, err := hexutil.Decode(raw)
+	if err != nil || len(vh) != common.HashLength || vh[0] != verify.VersionedHashVersionKZG {
+		return commitments.CommitmentMeta{}, svr.writeVersionedHashError(w, http.StatusBadRequest, VersionedHashError{
+			Code:          ErrCodeInvalidVersionedHash,
+			Message:       "expected a 0x prefixed 32 byte versioned hash with version 0x01",
+			VersionedHash: raw,
+		})
+	}
+
+	entry, ok := svr.router.Index().Resolve(vh)
+	if !ok {
+		return commitments.CommitmentMeta{}, svr.writeVersionedHashError(w, http.StatusNotFound, VersionedHashError{
+			Code:          ErrCodeUnmappedVersionedHash,
+			Message:       "versioned hash is not mapped to any known EigenDA commitment",
+			VersionedHash: raw,
+		})
+	}
+
+	mode := commitments.CommitmentMode(entry.Mode)
+	if mode == "" {
+		mode = commitments.OptimismGeneric
+	}
+	// the index only maps versioned hashes to V0 certs
+	meta := commitments.CommitmentMeta{Mode: mode, CertVersion: byte(commitments.CertV0)}
+
+	// the hooks see the same call as for a GET of the commitment the versioned hash is mapped to
+	ctx, rm := store.WithResponseMeta(store.WithCommitment(store.WithCommitmentMode(r.Context(), mode), entry.Key))
+	hookFailed := func(err error) (commitments.CommitmentMeta, error) {
+		status, code := http.StatusInternalServerError, ErrCodeBlobUnavailable
+		if errors.Is(err, hooks.ErrRejected) {
+			status, code = http.StatusForbidden, ErrCodeRejectedByHook
+		}
+		return meta, svr.writeVersionedHashError(w, status, VersionedHashError{
+			Code:          code,
+			Message:       err.Error(),
+			VersionedHash: raw,
+		})
+	}
+
+	call, err := svr.hooks.Run(ctx, hooks.Call{Stage: hooks.PreGet, Mode: mode, Commitment: entry.Key})
+	if err != nil {
+		return hookFailed(err)
+	}
+	comm := call.Commitment
+
+	input, err := svr.router.Get(ctx, comm, mode)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		return meta, svr.writeVersionedHashError(w, status, VersionedHashError{
+			Code:          ErrCodeBlobUnavailable,
+			Message:       err.Error(),
+			VersionedHash: raw,
+		})
+	}
+
+	call, err = svr.hooks.Run(ctx, hooks.Call{Stage: hooks.PostGet, Mode: mode, Commitment: comm, Payload: input})
+	if err != nil {
+		return hookFailed(err)
+	}
+	input = call.Payload
+	svr.m.RecordBlobSize(r.Method, len(input))
+
+	writeResponseMeta(w, rm, mode, comm, len(input))
+	svr.WriteResponse(w, input)
+	return meta, nil
+}
+
+// writeVersionedHashError ... writes a structured error with the given status. The returned error carries the
+// error message to the middlewares, which must not write it to the response again.
+func (svr *Server) writeVersionedHashError(w http.ResponseWriter, status int, body VersionedHashError) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		return responseWrittenError{fmt.Errorf("failed to write error response: %w", err)}
+	}
+	return responseWrittenError{fmt.Errorf("%s for versioned hash %s: %s", body.Code, body.VersionedHash, body.Message)}
+}
+
+// versionedHashCommitment ... resolves the versioned hash in the request path to the commitment it is mapped to,
+// encoded as served by the GET route, so that the gateway's prefix allowlist also applies to this route
+func (svr *Server) versionedHashCommitment(r *http.Request) ([]byte, bool) {
+	vh, err := hexutil.Decode(path.Base(r.URL.Path))
+	if err != nil {
+		return nil, false
+	}
+	entry, ok := svr.router.Index().Resolve(vh)
+	if !ok {
+		return nil, false
+	}
+	mode := commitments.CommitmentMode(entry.Mode)
+	if mode == "" {
+		mode = commitments.OptimismGeneric
+	}
+	comm, err := commitments.EncodeCommitment(entry.Key, mode)
+	if err != nil {
+		return nil, false
+	}
+	return comm, true
+}
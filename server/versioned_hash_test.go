@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/hooks"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleGetByVersionedHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vh := append([]byte{0x01}, make([]byte, 31)...)
+	vh[31] = 0xaa
+	unmapped := append([]byte{0x01}, make([]byte, 31)...)
+
+	idx := index.New(10)
+	idx.Put([]byte("cert"), 4, string(commitments.OptimismGeneric))
+	require.True(t, idx.AddAlias(vh, []byte("cert")))
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	mockRouter.EXPECT().Get(gomock.Any(), []byte("cert"), commitments.OptimismGeneric).Return([]byte("blob"), nil)
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	tests := []struct {
+		name      string
+		hash      string
+		expStatus int
+		expBody   string
+		expCode   string
+	}{
+		{name: "Mapped", hash: hexutil.Encode(vh), expStatus: http.StatusOK, expBody: "blob"},
+		{name: "Unmapped", hash: hexutil.Encode(unmapped), expStatus: http.StatusNotFound, expCode: ErrCodeUnmappedVersionedHash},
+		{name: "WrongVersion", hash: "0x02" + strings.Repeat("00", 31), expStatus: http.StatusBadRequest, expCode: ErrCodeInvalidVersionedHash},
+		{name: "WrongLength", hash: "0x0100", expStatus: http.StatusBadRequest, expCode: ErrCodeInvalidVersionedHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, VersionedHashRoute+tt.hash, nil)
+			meta, err := server.HandleGetByVersionedHash(rec, req)
+			require.Equal(t, tt.expStatus, rec.Code)
+
+			if tt.expCode == "" {
+				require.NoError(t, err)
+				require.Equal(t, commitments.OptimismGeneric, meta.Mode)
+				require.Equal(t, tt.expBody, rec.Body.String())
+				return
+			}
+			require.ErrorContains(t, err, tt.expCode)
+			var body VersionedHashError
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, tt.expCode, body.Code)
+			require.Equal(t, tt.hash, body.VersionedHash)
+		})
+	}
+}
+
+func TestVersionedHashGateway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	allowed := append([]byte{0x01}, make([]byte, 31)...)
+	allowed[31] = 0xaa
+	denied := append([]byte{0x01}, make([]byte, 31)...)
+	denied[31] = 0xbb
+	unmapped := append([]byte{0x01}, make([]byte, 31)...)
+
+	idx := index.New(10)
+	idx.Put([]byte("cert"), 4, string(commitments.OptimismGeneric))
+	idx.Put([]byte("simple"), 4, string(commitments.SimpleCommitmentMode))
+	require.True(t, idx.AddAlias(allowed, []byte("cert")))
+	require.True(t, idx.AddAlias(denied, []byte("simple")))
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	mockRouter.EXPECT().Get(gomock.Any(), []byte("cert"), commitments.OptimismGeneric).Return([]byte("blob"), nil)
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+
+	// the allowlist applies to the commitment the versioned hash is mapped to, as encoded by the GET route
+	handler := withGateway(WithLogging(WithMetrics(server.HandleGetByVersionedHash, server.m), server.log), GatewayConfig{
+		Enabled:         true,
+		RateLimit:       100,
+		Burst:           100,
+		AllowedPrefixes: []hexutil.Bytes{{0x01, 0x00, 0x00}},
+	}, server.versionedHashCommitment)
+
+	for hash, status := range map[string]int{
+		hexutil.Encode(allowed):  http.StatusOK,
+		hexutil.Encode(denied):   http.StatusForbidden,
+		hexutil.Encode(unmapped): http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, VersionedHashRoute+hash, nil))
+		require.Equal(t, status, rec.Code, hash)
+	}
+}
+
+func TestVersionedHashHooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vh := append([]byte{0x01}, make([]byte, 31)...)
+	idx := index.New(10)
+	idx.Put([]byte("cert"), 4, string(commitments.OptimismGeneric))
+	require.True(t, idx.AddAlias(vh, []byte("cert")))
+
+	// the pre-get hook rewrites the commitment, like it does for GETs by commitment
+	hooks.Register("test-versioned-hash", hooks.HookFunc(func(_ context.Context, call hooks.Call) (hooks.Call, error) {
+		if call.Stage == hooks.PreGet {
+			call.Commitment = []byte("rewritten")
+		}
+		return call, nil
+	}))
+
+	mockRouter := mocks.NewMockIRouter(ctrl)
+	mockRouter.EXPECT().Index().Return(idx).AnyTimes()
+	mockRouter.EXPECT().Get(gomock.Any(), []byte("rewritten"), commitments.OptimismGeneric).Return([]byte("blob"), nil)
+	server := NewServer("localhost", 0, mockRouter, log.New(), metrics.NoopMetrics, Options{})
+	chain, err := hooks.Load(hooks.Config{Names: []string{"test-versioned-hash"}})
+	require.NoError(t, err)
+	server.hooks = chain
+
+	rec := httptest.NewRecorder()
+	_, err = server.HandleGetByVersionedHash(rec, httptest.NewRequest(http.MethodGet, VersionedHashRoute+hexutil.Encode(vh), nil))
+	require.NoError(t, err)
+	require.Equal(t, "blob", rec.Body.String())
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const AdminWritesRoute = AdminRoute + "writes"
+
+// HandleWrites ... returns the outcomes of the writes to each cache and fallback target since the proxy started,
+// along with their most recent failures, so that flaky targets can be spotted before writes are dead-lettered
+func (svr *Server) HandleWrites(w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return fmt.Errorf("method %s not allowed on %s", r.Method, r.URL.Path)
+	}
+
+	return svr.writeJSON(w, svr.router.WriteSummaries())
+}
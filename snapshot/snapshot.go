@@ -0,0 +1,272 @@
+// Package snapshot implements disaster-recovery snapshots of the proxy state that isn't held by EigenDA or a
+// shared secondary store: the commitment index, the dead-letter queue and pending dispersal journals, and the
+// manifests of the node-local stores (memstore and filesystem backend). A snapshot is a gzipped tar archive,
+// which can be restored on a new node to rebuild its state.
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// FormatVersion ... version of the archive layout, snapshots of other versions are rejected on restore
+	FormatVersion = 1
+
+	metaFile              = "snapshot.json"
+	indexFile             = "index.json"
+	deadLettersFile       = "dead_letters.json"
+	pendingDispersalsFile = "pending_dispersals.json"
+	manifestsDir          = "manifests/"
+
+	// maxFileSize ... upper bound of a single file of the archive
+	maxFileSize = 4 << 30
+)
+
+// Meta ... describes a snapshot
+type Meta struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// ProxyVersion is the version of the proxy that took the snapshot
+	ProxyVersion string `json:"proxy_version,omitempty"`
+}
+
+// Manifest ... keys of the blobs held by a node-local store. The blobs themselves aren't part of the snapshot,
+// the manifest shows which ones were lost or have to be restored from a volume backup.
+type Manifest struct {
+	Backend string          `json:"backend"`
+	Keys    []hexutil.Bytes `json:"keys"`
+}
+
+// Snapshot ... the proxy state bundled in a snapshot archive
+type Snapshot struct {
+	Meta              Meta
+	Index             []index.Record
+	DeadLetters       []deadletter.Task
+	PendingDispersals []eigenda.PendingDispersal
+	Manifests         []Manifest
+}
+
+// RestoreReport ... outcome of restoring a snapshot
+type RestoreReport struct {
+	IndexEntries      int `json:"index_entries"`
+	DeadLetters       int `json:"dead_letters"`
+	PendingDispersals int `json:"pending_dispersals"`
+	// Missing maps the node-local backends of the snapshot's manifests to the number of keys they no longer hold
+	Missing map[string]int `json:"missing"`
+}
+
+// pendingDispersalStore ... implemented by EigenDA backends that keep track of dispersals whose status polling
+// timed out
+type pendingDispersalStore interface {
+	PendingDispersals() []eigenda.PendingDispersal
+	RestorePendingDispersals(dispersals []eigenda.PendingDispersal) error
+}
+
+// localStores ... returns the stores of the router whose contents are lost with the node, keyed by backend
+func localStores(r store.IRouter) map[string]store.Lister {
+	stores := make(map[string]store.Lister)
+	add := func(s store.Store) {
+		l, ok := s.(store.Lister)
+		if !ok {
+			return
+		}
+		switch s.BackendType() {
+		case store.MemoryBackendType, store.FSBackendType:
+			stores[s.BackendType().String()] = l
+		default:
+		}
+	}
+
+	if r.GetEigenDAStore() != nil {
+		add(r.GetEigenDAStore())
+	}
+	for _, s := range append(r.Caches(), r.Fallbacks()...) {
+		add(s)
+	}
+	return stores
+}
+
+// Take ... captures the state of the router's proxy
+func Take(ctx context.Context, r store.IRouter, proxyVersion string) (*Snapshot, error) {
+	s := &Snapshot{
+		Meta:        Meta{Version: FormatVersion, CreatedAt: time.Now().UTC(), ProxyVersion: proxyVersion},
+		Index:       r.Index().Dump(),
+		DeadLetters: r.DeadLetters().List(),
+	}
+	if p, ok := r.GetEigenDAStore().(pendingDispersalStore); ok {
+		s.PendingDispersals = p.PendingDispersals()
+	}
+
+	for backend, l := range localStores(r) {
+		m := Manifest{Backend: backend, Keys: []hexutil.Bytes{}}
+		err := l.Keys(ctx, func(key []byte) error {
+			m.Keys = append(m.Keys, append(hexutil.Bytes(nil), key...))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s keys: %w", backend, err)
+		}
+		s.Manifests = append(s.Manifests, m)
+	}
+	return s, nil
+}
+
+// Restore ... merges the snapshot into the router's state, and checks the router's node-local stores against
+// the snapshot's manifests
+func Restore(ctx context.Context, r store.IRouter, s *Snapshot) (RestoreReport, error) {
+	if s.Meta.Version != FormatVersion {
+		return RestoreReport{}, fmt.Errorf("unsupported snapshot version %d, expected %d", s.Meta.Version,
+			FormatVersion)
+	}
+
+	report := RestoreReport{
+		IndexEntries: r.Index().Restore(s.Index),
+		Missing:      make(map[string]int),
+	}
+
+	if err := r.DeadLetters().Restore(s.DeadLetters); err != nil {
+		return report, fmt.Errorf("failed to restore dead-letter queue: %w", err)
+	}
+	report.DeadLetters = len(s.DeadLetters)
+
+	if len(s.PendingDispersals) > 0 {
+		p, ok := r.GetEigenDAStore().(pendingDispersalStore)
+		if !ok {
+			return report, errors.New("snapshot contains pending dispersals, but the EigenDA backend doesn't track them")
+		}
+		if err := p.RestorePendingDispersals(s.PendingDispersals); err != nil {
+			return report, fmt.Errorf("failed to restore pending dispersals: %w", err)
+		}
+		report.PendingDispersals = len(s.PendingDispersals)
+	}
+
+	local := localStores(r)
+	for _, m := range s.Manifests {
+		l, ok := local[m.Backend]
+		if !ok {
+			report.Missing[m.Backend] = len(m.Keys)
+			continue
+		}
+		held := make(map[string]struct{})
+		err := l.Keys(ctx, func(key []byte) error {
+			held[string(key)] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return report, fmt.Errorf("failed to list %s keys: %w", m.Backend, err)
+		}
+		for _, k := range m.Keys {
+			if _, ok := held[string(k)]; !ok {
+				report.Missing[m.Backend]++
+			}
+		}
+	}
+	return report, nil
+}
+
+// archiveFile ... JSON encoded file of a snapshot archive
+type archiveFile struct {
+	name string
+	v    any
+}
+
+// Write ... writes the snapshot as a gzipped tar archive
+func (s *Snapshot) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	files := []archiveFile{
+		{metaFile, s.Meta},
+		{indexFile, s.Index},
+		{deadLettersFile, s.DeadLetters},
+		{pendingDispersalsFile, s.PendingDispersals},
+	}
+	for _, m := range s.Manifests {
+		files = append(files, archiveFile{manifestsDir + m.Backend + ".json", m})
+	}
+
+	for _, f := range files {
+		b, err := json.Marshal(f.v)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", f.name, err)
+		}
+		hdr := &tar.Header{Name: f.name, Mode: 0o600, Size: int64(len(b)), ModTime: s.Meta.CreatedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := tw.Write(b); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read ... reads a snapshot written by Write
+func Read(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	s := &Snapshot{}
+	hasMeta := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+		if hdr.Size > maxFileSize {
+			return nil, fmt.Errorf("snapshot file %s too large: %d bytes", hdr.Name, hdr.Size)
+		}
+
+		var v any
+		switch name := path.Clean(hdr.Name); {
+		case name == metaFile:
+			v, hasMeta = &s.Meta, true
+		case name == indexFile:
+			v = &s.Index
+		case name == deadLettersFile:
+			v = &s.DeadLetters
+		case name == pendingDispersalsFile:
+			v = &s.PendingDispersals
+		case strings.HasPrefix(name, manifestsDir):
+			s.Manifests = append(s.Manifests, Manifest{})
+			v = &s.Manifests[len(s.Manifests)-1]
+		default:
+			// unknown files, e.g. added by newer versions, are skipped
+			continue
+		}
+		if err := json.NewDecoder(io.LimitReader(tr, maxFileSize)).Decode(v); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot file %s: %w", hdr.Name, err)
+		}
+	}
+
+	if !hasMeta {
+		return nil, fmt.Errorf("snapshot archive is missing %s", metaFile)
+	}
+	return s, nil
+}
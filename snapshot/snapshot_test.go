@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fsRouter ... returns a router with a filesystem cache target, and the cache target
+func fsRouter(t *testing.T) (store.IRouter, *fs.Store) {
+	fsStore, err := fs.NewStore(log.New(), fs.Config{Path: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = fsStore.Close() })
+
+	r, err := store.NewRouter(nil, nil, log.New(), []store.PrecomputedKeyStore{fsStore}, nil, store.RouterOptions{})
+	require.NoError(t, err)
+	return r, fsStore
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	src, srcFS := fsRouter(t)
+	for _, k := range []string{"a", "b", "c"} {
+		require.NoError(t, srcFS.Put(ctx, []byte(k), []byte("blob")))
+		src.Index().Put([]byte(k), 4, string(commitments.OptimismGeneric))
+	}
+	require.True(t, src.Index().AddAlias([]byte("alias"), []byte("b")))
+	require.NoError(t, src.DeadLetters().Add(deadletter.KindReplication, "S3", []byte("c"), 3,
+		[]error{errors.New("timeout")}))
+
+	s, err := Take(ctx, src, "v1.0.0")
+	require.NoError(t, err)
+	require.Len(t, s.Index, 3)
+	require.Len(t, s.Manifests, 1)
+	require.Equal(t, store.FSBackendType.String(), s.Manifests[0].Backend)
+	require.Len(t, s.Manifests[0].Keys, 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, s.Write(&buf))
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Equal(t, "v1.0.0", read.Meta.ProxyVersion)
+
+	// the new node only holds one of the blobs
+	dst, dstFS := fsRouter(t)
+	require.NoError(t, dstFS.Put(ctx, []byte("a"), []byte("blob")))
+
+	report, err := Restore(ctx, dst, read)
+	require.NoError(t, err)
+	require.Equal(t, RestoreReport{
+		IndexEntries: 3,
+		DeadLetters:  1,
+		Missing:      map[string]int{store.FSBackendType.String(): 2},
+	}, report)
+
+	e, ok := dst.Index().Resolve([]byte("alias"))
+	require.True(t, ok)
+	require.Equal(t, "b", string(e.Key))
+	tasks := dst.DeadLetters().List()
+	require.Len(t, tasks, 1)
+	require.Equal(t, src.DeadLetters().List()[0].ID, tasks[0].ID)
+	require.Equal(t, 3, tasks[0].Attempts)
+
+	// restoring again doesn't add entries
+	report, err = Restore(ctx, dst, read)
+	require.NoError(t, err)
+	require.Zero(t, report.IndexEntries)
+	require.Equal(t, 3, dst.Index().Len())
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	r, _ := fsRouter(t)
+	_, err := Restore(context.Background(), r, &Snapshot{Meta: Meta{Version: FormatVersion + 1}})
+	require.Error(t, err)
+
+	_, err = Read(bytes.NewReader([]byte("not an archive")))
+	require.Error(t, err)
+}
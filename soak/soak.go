@@ -0,0 +1,314 @@
+// Package soak implements a long-running soak test of a proxy: blobs are continuously written and read back
+// through the proxy's HTTP API while invariants are asserted, and a Report is produced once the test ends.
+package soak
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// invariants asserted by the soak test
+const (
+	// InvariantReadable ... every blob whose write was confirmed with a commitment can be read back
+	InvariantReadable = "confirmed-write-readable"
+	// InvariantIntegrity ... a blob read back is the blob that was written
+	InvariantIntegrity = "read-matches-write"
+	// InvariantVerification ... every read passes the same verification checks, i.e. verification never
+	// degrades or is skipped
+	InvariantVerification = "verification-passes"
+	// InvariantMemory ... the heap doesn't grow beyond MaxHeapGrowth over its baseline
+	InvariantMemory = "bounded-memory"
+)
+
+const (
+	// maxTrackedBlobs ... number of written blobs kept for re-reads, older ones are dropped
+	maxTrackedBlobs = 10000
+	// maxReportedViolations ... number of violations listed in the report, all of them are counted
+	maxReportedViolations = 100
+)
+
+// Config ... soak test parameters
+type Config struct {
+	// URL of the proxy under test
+	URL string
+	// Duration of the soak test
+	Duration time.Duration
+	// Interval between writes
+	Interval time.Duration
+	// MaxBlobSize is the upper bound of the random sizes of written blobs
+	MaxBlobSize int
+	// Rereads is the number of previously written blobs read again after each write
+	Rereads int
+	// Verification is the verification mode every read must report, see server.VerificationHeader. The mode
+	// of the first read is expected if empty.
+	Verification string
+	// MaxHeapGrowth bounds the growth of the heap over its baseline, checked every MemoryCheckInterval. The
+	// heap isn't checked if 0, e.g. when the proxy runs in another process.
+	MaxHeapGrowth       uint64
+	MemoryCheckInterval time.Duration
+}
+
+// Check ... validates the soak test config
+func (c Config) Check() error {
+	if c.URL == "" {
+		return errors.New("soak url must be set")
+	}
+	if c.Duration <= 0 || c.Interval <= 0 {
+		return errors.New("soak duration and interval must be positive")
+	}
+	if c.MaxBlobSize < 1 {
+		return errors.New("soak max blob size must be at least 1")
+	}
+	if c.Rereads < 0 {
+		return errors.New("soak rereads must not be negative")
+	}
+	if c.MaxHeapGrowth > 0 && c.MemoryCheckInterval <= 0 {
+		return errors.New("soak memory check interval must be positive")
+	}
+	return nil
+}
+
+// Violation ... a violated invariant
+type Violation struct {
+	Time       time.Time `json:"time"`
+	Invariant  string    `json:"invariant"`
+	Commitment string    `json:"commitment,omitempty"`
+	Detail     string    `json:"detail"`
+}
+
+// Report ... outcome of a soak test. The test passed if no invariant was violated.
+type Report struct {
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	Writes      int       `json:"writes"`
+	WriteErrors int       `json:"write_errors"`
+	Reads       int       `json:"reads"`
+	Rereads     int       `json:"rereads"`
+	// Verification is the verification mode reads were expected to report
+	Verification string `json:"verification"`
+	// HeapBaseline and HeapPeak are in bytes, zero if the heap wasn't checked
+	HeapBaseline   uint64      `json:"heap_baseline_bytes,omitempty"`
+	HeapPeak       uint64      `json:"heap_peak_bytes,omitempty"`
+	ViolationCount int         `json:"violation_count"`
+	Violations     []Violation `json:"violations,omitempty"`
+	Passed         bool        `json:"passed"`
+}
+
+// blob ... a written blob, identified by its commitment and digest
+type blob struct {
+	commitment []byte
+	digest     [sha256.Size]byte
+}
+
+// Runner ... runs a soak test against a proxy
+type Runner struct {
+	cfg    Config
+	client *http.Client
+	log    log.Logger
+
+	blobs  []blob
+	report Report
+}
+
+func New(cfg Config, l log.Logger) *Runner {
+	return &Runner{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 30 * time.Minute},
+		log:    l,
+	}
+}
+
+// Run ... writes a blob every Interval until Duration passed or ctx is done, reads it and Rereads earlier
+// blobs back, and returns the report of the test
+func (r *Runner) Run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Duration)
+	defer cancel()
+
+	r.report = Report{Start: time.Now(), Verification: r.cfg.Verification}
+	if r.cfg.MaxHeapGrowth > 0 {
+		r.report.HeapBaseline = heapAlloc()
+		r.report.HeapPeak = r.report.HeapBaseline
+	}
+
+	writes := time.NewTicker(r.cfg.Interval)
+	defer writes.Stop()
+	var memory <-chan time.Time
+	if r.cfg.MaxHeapGrowth > 0 {
+		t := time.NewTicker(r.cfg.MemoryCheckInterval)
+		defer t.Stop()
+		memory = t.C
+	}
+	progress := time.NewTicker(time.Minute)
+	defer progress.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.report.End = time.Now()
+			r.report.Passed = r.report.ViolationCount == 0
+			return r.report
+		case <-writes.C:
+			r.round(ctx)
+		case <-memory:
+			r.checkMemory()
+		case <-progress.C:
+			r.log.Info("Soak test progress", "elapsed", time.Since(r.report.Start).Truncate(time.Second),
+				"writes", r.report.Writes, "write_errors", r.report.WriteErrors, "reads", r.report.Reads,
+				"violations", r.report.ViolationCount)
+		}
+	}
+}
+
+// round ... writes a random blob, reads it back and re-reads a sample of the earlier blobs
+func (r *Runner) round(ctx context.Context) {
+	data, err := randomBlob(r.cfg.MaxBlobSize)
+	if err != nil {
+		r.log.Error("Failed to generate blob", "err", err)
+		return
+	}
+
+	r.report.Writes++
+	commitment, err := r.put(ctx, data)
+	if err != nil {
+		if ctx.Err() == nil {
+			// unconfirmed writes don't violate an invariant, the batcher would retry them
+			r.report.WriteErrors++
+			r.log.Warn("Soak write failed", "err", err)
+		}
+		return
+	}
+
+	b := blob{commitment: commitment, digest: sha256.Sum256(data)}
+	r.read(ctx, b)
+	for i := 0; i < r.cfg.Rereads && len(r.blobs) > 0; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(r.blobs))))
+		if err != nil {
+			break
+		}
+		r.report.Rereads++
+		r.read(ctx, r.blobs[n.Int64()])
+	}
+
+	if len(r.blobs) == maxTrackedBlobs {
+		r.blobs = r.blobs[1:]
+	}
+	r.blobs = append(r.blobs, b)
+}
+
+// read ... reads b back and asserts the read invariants
+func (r *Runner) read(ctx context.Context, b blob) {
+	r.report.Reads++
+	data, verification, err := r.get(ctx, b.commitment)
+	switch {
+	case ctx.Err() != nil:
+		r.report.Reads--
+	case err != nil:
+		r.violate(InvariantReadable, b.commitment, err.Error())
+	case sha256.Sum256(data) != b.digest:
+		r.violate(InvariantIntegrity, b.commitment, fmt.Sprintf("read %d bytes not matching the written blob", len(data)))
+	case r.report.Verification == "":
+		r.report.Verification = verification
+	case verification != r.report.Verification:
+		r.violate(InvariantVerification, b.commitment,
+			fmt.Sprintf("read verified with %q instead of %q", verification, r.report.Verification))
+	}
+}
+
+// checkMemory ... asserts that the heap didn't grow beyond its bound
+func (r *Runner) checkMemory() {
+	heap := heapAlloc()
+	r.report.HeapPeak = max(r.report.HeapPeak, heap)
+	if heap > r.report.HeapBaseline+r.cfg.MaxHeapGrowth {
+		r.violate(InvariantMemory, nil, fmt.Sprintf("heap of %d bytes grew by more than %d bytes over its baseline of %d bytes",
+			heap, r.cfg.MaxHeapGrowth, r.report.HeapBaseline))
+	}
+}
+
+func (r *Runner) violate(invariant string, commitment []byte, detail string) {
+	v := Violation{Time: time.Now(), Invariant: invariant, Detail: detail}
+	if commitment != nil {
+		v.Commitment = fmt.Sprintf("0x%x", commitment)
+	}
+	r.log.Error("Soak invariant violated", "invariant", invariant, "commitment", v.Commitment, "detail", detail)
+
+	r.report.ViolationCount++
+	if len(r.report.Violations) < maxReportedViolations {
+		r.report.Violations = append(r.report.Violations, v)
+	}
+}
+
+// put ... writes data in simple commitment mode and returns its commitment
+func (r *Runner) put(ctx context.Context, data []byte) ([]byte, error) {
+	url := strings.TrimSuffix(r.cfg.URL, "/") + "/put/?commitment_mode=simple"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	body, _, err := r.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		return nil, errors.New("empty commitment returned")
+	}
+	return body, nil
+}
+
+// get ... reads the blob of a commitment and returns it along with the verification mode it was served with
+func (r *Runner) get(ctx context.Context, commitment []byte) ([]byte, string, error) {
+	url := fmt.Sprintf("%s/get/0x%x?commitment_mode=simple", strings.TrimSuffix(r.cfg.URL, "/"), commitment)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return r.do(req)
+}
+
+func (r *Runner) do(req *http.Request) ([]byte, string, error) {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("received bad status code %d: %s", resp.StatusCode, string(body))
+	}
+	return body, resp.Header.Get(server.VerificationHeader), nil
+}
+
+// randomBlob ... returns a blob of random content and size between 1 and maxSize bytes
+func randomBlob(maxSize int) ([]byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(maxSize)))
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, n.Int64()+1)
+	_, err = rand.Read(data)
+	return data, err
+}
+
+// heapAlloc ... returns the bytes of live heap objects after a garbage collection
+func heapAlloc() uint64 {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return ms.HeapAlloc
+}
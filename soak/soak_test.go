@@ -0,0 +1,107 @@
+package soak
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/server"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProxy ... serves simple commitment mode PUTs and GETs from memory. Reads are corrupted or served with
+// another verification mode once the respective number of reads was served.
+type fakeProxy struct {
+	mu          sync.Mutex
+	blobs       map[string][]byte
+	reads       atomic.Int64
+	corruptFrom int64
+	degradeFrom int64
+}
+
+func (p *fakeProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if r.Method == http.MethodPost {
+		data, _ := io.ReadAll(r.Body)
+		digest := sha256.Sum256(data)
+		p.blobs[string(digest[:])] = data
+		_, _ = w.Write(digest[:])
+		return
+	}
+
+	commitment, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, "/get/"))
+	data, ok := p.blobs[string(commitment)]
+	if err != nil || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	n := p.reads.Add(1)
+	verification := "kzg+cert"
+	if p.degradeFrom > 0 && n >= p.degradeFrom {
+		verification = "cert"
+	}
+	if p.corruptFrom > 0 && n >= p.corruptFrom {
+		data = append([]byte{0}, data...)
+	}
+	w.Header().Set(server.VerificationHeader, verification)
+	_, _ = w.Write(data)
+}
+
+func TestSoak(t *testing.T) {
+	tests := []struct {
+		name        string
+		proxy       *fakeProxy
+		violated    string
+		maxHeapGrow uint64
+	}{
+		{name: "Passed", proxy: &fakeProxy{}, maxHeapGrow: 1 << 30},
+		{name: "CorruptedRead", proxy: &fakeProxy{corruptFrom: 5}, violated: InvariantIntegrity},
+		{name: "DegradedVerification", proxy: &fakeProxy{degradeFrom: 5}, violated: InvariantVerification},
+		// the blobs tracked for re-reads alone grow the heap by more than a byte
+		{name: "HeapGrowth", proxy: &fakeProxy{}, violated: InvariantMemory, maxHeapGrow: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.proxy.blobs = make(map[string][]byte)
+			srv := httptest.NewServer(tt.proxy)
+			defer srv.Close()
+
+			cfg := Config{
+				URL:                 srv.URL,
+				Duration:            300 * time.Millisecond,
+				Interval:            10 * time.Millisecond,
+				MaxBlobSize:         1024,
+				Rereads:             2,
+				MaxHeapGrowth:       tt.maxHeapGrow,
+				MemoryCheckInterval: 50 * time.Millisecond,
+			}
+			require.NoError(t, cfg.Check())
+
+			report := New(cfg, log.New()).Run(context.Background())
+			require.NotZero(t, report.Writes)
+			require.Zero(t, report.WriteErrors)
+			require.NotZero(t, report.Rereads)
+			require.Equal(t, "kzg+cert", report.Verification)
+			if tt.violated == "" {
+				require.True(t, report.Passed)
+				require.Zero(t, report.ViolationCount)
+				return
+			}
+			require.False(t, report.Passed)
+			require.NotZero(t, report.ViolationCount)
+			require.Equal(t, tt.violated, report.Violations[0].Invariant)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package standby
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ActiveURLFlagName = withFlagPrefix("active-url")
+	IntervalFlagName  = withFlagPrefix("interval")
+	BatchSizeFlagName = withFlagPrefix("batch-size")
+	WarmBlobsFlagName = withFlagPrefix("warm-blobs")
+)
+
+func withFlagPrefix(s string) string {
+	return "standby." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_STANDBY_" + s}
+}
+
+// CLIFlags ... used for the warm standby mode configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     ActiveURLFlagName,
+			Usage:    "Base URL of the active proxy to sync the commitment index and recent blobs from. If set, the proxy runs as a warm standby. The active proxy must have the admin API enabled.",
+			EnvVars:  withEnvPrefix(envPrefix, "ACTIVE_URL"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     IntervalFlagName,
+				Usage:    "Interval between syncs from the active proxy.",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "INTERVAL"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+		&cli.IntFlag{
+			Name:     BatchSizeFlagName,
+			Usage:    "Number of index entries fetched from the active proxy per request.",
+			Value:    1000,
+			EnvVars:  withEnvPrefix(envPrefix, "BATCH_SIZE"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     WarmBlobsFlagName,
+			Usage:    "Number of most recently added commitments whose blobs are synced into the cache targets. Set to 0 if the caches are shared with the active proxy, e.g. the same Redis instance.",
+			Value:    1000,
+			EnvVars:  withEnvPrefix(envPrefix, "WARM_BLOBS"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		ActiveURL: ctx.String(ActiveURLFlagName),
+		Interval:  ctx.Duration(IntervalFlagName),
+		BatchSize: ctx.Int(BatchSizeFlagName),
+		WarmBlobs: ctx.Int(WarmBlobsFlagName),
+	}
+}
@@ -0,0 +1,214 @@
+// Package standby implements a warm standby mode: a standby proxy continuously syncs the commitment index and
+// the blobs of recently added commitments from the active proxy's admin API, so that a failover doesn't start
+// from a cold cache and an empty index.
+package standby
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// SyncRoute ... admin route under which the active proxy serves its index to standbys.
+	// GET ?since=<seq>&limit=<n>&blobs=<n> returns a JSON encoded Batch.
+	SyncRoute = "/admin/sync"
+
+	// maxBatchResponseSize ... upper bound of a batch response, blobs are at most 16MiB
+	maxBatchResponseSize = 1 << 30
+)
+
+// Entry ... synced index entry
+type Entry struct {
+	index.Entry
+	// Payload is the blob of the commitment, only set for recently added commitments
+	Payload hexutil.Bytes `json:"payload,omitempty"`
+}
+
+// Batch ... index entries added to the active proxy's index after the requested sequence number, oldest first
+type Batch struct {
+	Entries []Entry `json:"entries"`
+	// Seq is the sequence number of the most recent entry of the active proxy's index
+	Seq uint64 `json:"seq"`
+	// Epoch identifies the active proxy's index, it changes when the active proxy restarts
+	Epoch uint64 `json:"epoch,omitempty"`
+}
+
+// Config ... configuration of the warm standby mode
+type Config struct {
+	// ActiveURL is the base URL of the active proxy, the standby mode is disabled if empty
+	ActiveURL string
+	Interval  time.Duration
+	// BatchSize is the number of index entries fetched per request
+	BatchSize int
+	// WarmBlobs is the number of most recently added commitments whose blobs are synced into the caches
+	WarmBlobs int
+}
+
+// Enabled ... returns whether the proxy runs as a warm standby
+func (c Config) Enabled() bool {
+	return c.ActiveURL != ""
+}
+
+// Check ... verifies that configuration values are adequately set
+func (c Config) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if _, err := url.ParseRequestURI(c.ActiveURL); err != nil {
+		return fmt.Errorf("invalid standby active url: %w", err)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("standby sync interval must be positive")
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("standby sync batch size must be at least 1")
+	}
+	if c.WarmBlobs < 0 {
+		return fmt.Errorf("standby warm blobs must not be negative")
+	}
+	return nil
+}
+
+// Importer ... indexes synced commitments and caches their blobs, implemented by store.IRouter
+type Importer interface {
+	Import(ctx context.Context, cm commitments.CommitmentMode, commitment []byte, size int, value []byte) error
+}
+
+// Syncer ... periodically pulls the index entries added to the active proxy since the last sync
+type Syncer struct {
+	cfg    Config
+	url    string
+	client *http.Client
+	router Importer
+	log    log.Logger
+
+	// cursor is the sequence number of the last synced entry, in the active proxy's index of the given epoch
+	cursor uint64
+	epoch  uint64
+}
+
+// New ... constructor
+func New(cfg Config, router Importer, l log.Logger) *Syncer {
+	return &Syncer{
+		cfg:    cfg,
+		url:    strings.TrimSuffix(cfg.ActiveURL, "/") + SyncRoute,
+		client: &http.Client{Timeout: time.Minute},
+		router: router,
+		log:    l,
+	}
+}
+
+// Run ... syncs from the active proxy every interval until ctx is done
+func (s *Syncer) Run(ctx context.Context) {
+	s.log.Info("Starting warm standby sync", "active", s.cfg.ActiveURL, "interval", s.cfg.Interval)
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.Sync(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.log.Warn("Failed to sync from active proxy", "active", s.cfg.ActiveURL, "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync ... imports every entry added to the active proxy's index since the last sync, returning the number of
+// entries imported. Entries that fail to be imported are logged and skipped.
+func (s *Syncer) Sync(ctx context.Context) (int, error) {
+	imported := 0
+	for {
+		batch, err := s.fetch(ctx)
+		if err != nil {
+			return imported, err
+		}
+
+		// the active proxy's index starts over when it restarts. The sequence number alone doesn't tell, since the
+		// restarted proxy may have added more entries than were synced before by the time the standby polls it.
+		// Active proxies that don't serve an epoch are only detected by their sequence number.
+		if s.reset(batch) {
+			s.log.Warn("Active proxy index was reset, syncing from scratch", "cursor", s.cursor, "active seq", batch.Seq,
+				"epoch", s.epoch, "active epoch", batch.Epoch)
+			s.cursor, s.epoch = 0, batch.Epoch
+			continue
+		}
+		s.epoch = batch.Epoch
+
+		for _, e := range batch.Entries {
+			err := s.router.Import(ctx, commitments.CommitmentMode(e.Mode), e.Key, e.Size, e.Payload)
+			if err != nil {
+				s.log.Warn("Failed to import synced commitment", "commitment", e.Key, "err", err)
+			} else {
+				imported++
+			}
+			s.cursor = e.Seq
+		}
+
+		if len(batch.Entries) < s.cfg.BatchSize {
+			if imported > 0 {
+				s.log.Debug("Synced from active proxy", "imported", imported, "cursor", s.cursor)
+			}
+			return imported, nil
+		}
+	}
+}
+
+// reset ... whether the batch was served by another index than the one the cursor points into
+func (s *Syncer) reset(batch Batch) bool {
+	if s.cursor == 0 {
+		return false
+	}
+	if batch.Epoch != 0 && s.epoch != 0 && batch.Epoch != s.epoch {
+		return true
+	}
+	return batch.Seq < s.cursor
+}
+
+func (s *Syncer) fetch(ctx context.Context) (Batch, error) {
+	q := url.Values{}
+	q.Set("since", strconv.FormatUint(s.cursor, 10))
+	q.Set("limit", strconv.Itoa(s.cfg.BatchSize))
+	q.Set("blobs", strconv.Itoa(s.cfg.WarmBlobs))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"?"+q.Encode(), nil)
+	if err != nil {
+		return Batch{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to reach active proxy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBatchResponseSize))
+	if err != nil {
+		return Batch{}, fmt.Errorf("failed to read active proxy response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Batch{}, fmt.Errorf("active proxy returned status %d: %s", resp.StatusCode,
+			strings.TrimSpace(string(body)))
+	}
+
+	var batch Batch
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return Batch{}, fmt.Errorf("failed to unmarshal sync batch: %w", err)
+	}
+	return batch, nil
+}
@@ -0,0 +1,111 @@
+package standby
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type importerFunc func(cm commitments.CommitmentMode, commitment []byte, size int, value []byte) error
+
+func (f importerFunc) Import(_ context.Context, cm commitments.CommitmentMode, commitment []byte, size int,
+	value []byte) error {
+	return f(cm, commitment, size, value)
+}
+
+// activeProxy ... serves the sync route from an index, attaching a payload to every entry
+func activeProxy(t *testing.T, idx *index.Index) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, SyncRoute, r.URL.Path)
+		since, err := strconv.ParseUint(r.URL.Query().Get("since"), 10, 64)
+		require.NoError(t, err)
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+
+		batch := Batch{Seq: idx.Seq(), Epoch: idx.Epoch()}
+		for _, e := range idx.Since(since, limit) {
+			batch.Entries = append(batch.Entries, Entry{Entry: e, Payload: []byte("blob")})
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(batch))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+
+	idx := index.New(100)
+	for _, k := range []string{"a", "b", "c"} {
+		idx.Put([]byte(k), 4, string(commitments.OptimismGeneric))
+	}
+	srv := activeProxy(t, idx)
+
+	var imported []string
+	importer := importerFunc(func(cm commitments.CommitmentMode, commitment []byte, size int, value []byte) error {
+		require.Equal(t, commitments.OptimismGeneric, cm)
+		require.Equal(t, 4, size)
+		require.Equal(t, []byte("blob"), value)
+		imported = append(imported, string(commitment))
+		return nil
+	})
+
+	s := New(Config{ActiveURL: srv.URL, Interval: time.Second, BatchSize: 2}, importer, log.New())
+
+	// syncs across multiple batches
+	n, err := s.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, []string{"a", "b", "c"}, imported)
+
+	// only new entries are synced
+	idx.Put([]byte("d"), 4, string(commitments.OptimismGeneric))
+	n, err = s.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, "d", imported[3])
+
+	// a restarted active proxy is synced from scratch
+	idx = index.New(100)
+	idx.Put([]byte("e"), 4, string(commitments.OptimismGeneric))
+	srv.Config.Handler = activeProxy(t, idx).Config.Handler
+	n, err = s.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, "e", imported[4])
+
+	// as well as one that added more entries than were synced before it restarted
+	idx = index.New(100)
+	for _, k := range []string{"f", "g", "h"} {
+		idx.Put([]byte(k), 4, string(commitments.OptimismGeneric))
+	}
+	srv.Config.Handler = activeProxy(t, idx).Config.Handler
+	n, err = s.Sync(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, []string{"f", "g", "h"}, imported[5:])
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, Config{}.Check())
+
+	cfg := Config{ActiveURL: "http://proxy-0:3100", Interval: time.Second, BatchSize: 100, WarmBlobs: 10}
+	require.NoError(t, cfg.Check())
+
+	bad := cfg
+	bad.ActiveURL = "proxy-0"
+	require.Error(t, bad.Check())
+
+	bad = cfg
+	bad.BatchSize = 0
+	require.Error(t, bad.Check())
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+)
+
+// Backfill ... indexes a commitment that was dispersed outside of this proxy instance, e.g. one found in
+// historical L1 batcher transactions. If populateCaches is set, the blob is also fetched from EigenDA and
+// verified against the commitment and written to the cache and fallback targets, unless the index already knows of a secondary copy. The L1 block
+// the commitment was included in is indexed if set with WithL1Block.
+func (r *Router) Backfill(ctx context.Context, cm commitments.CommitmentMode, commitment []byte,
+	populateCaches bool) error {
+	if cm == commitments.OptimismKeccak {
+		return errors.New("backfilling is unsupported for keccak256 commitments")
+	}
+	if r.eigenda == nil {
+		return errors.New("expected EigenDA backend for DA commitment type, but none configured")
+	}
+
+	if !populateCaches || (!r.cacheEnabled() && !r.fallbackEnabled()) {
+		r.indexCert(commitment, 0, cm)
+		r.indexHeights(ctx, commitment)
+		return nil
+	}
+
+	if e, ok := r.index.Get(commitment); ok && len(e.Backends) > 0 {
+		r.indexHeights(ctx, commitment)
+		return nil
+	}
+
+	data, err := r.eigenda.Get(ctx, commitment)
+	if err != nil {
+		return fmt.Errorf("failed to fetch blob from EigenDA: %w", err)
+	}
+	if err := r.verify(ctx, r.eigenda, commitment, data); err != nil {
+		r.recordVerificationFailure(r.eigenda)
+		return fmt.Errorf("failed to verify backfilled blob: %w", err)
+	}
+	r.indexCert(commitment, len(data), cm)
+	r.indexHeights(ctx, commitment)
+
+	return r.handleRedundantWrites(ctx, commitment, data, nil).Err()
+}
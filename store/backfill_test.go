@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	cache := &mapStore{data: make(map[string][]byte)}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// index only
+	require.NoError(t, r.Backfill(ctx, commitments.OptimismGeneric, []byte("a"), false))
+	e, ok := r.Index().Get([]byte("a"))
+	require.True(t, ok)
+	require.Equal(t, 0, e.Size)
+	require.Equal(t, string(commitments.OptimismGeneric), e.Mode)
+	require.Empty(t, cache.data)
+
+	// populating caches fetches the blob and fills in the size
+	require.NoError(t, r.Backfill(ctx, commitments.OptimismGeneric, []byte("a"), true))
+	e, _ = r.Index().Get([]byte("a"))
+	require.Equal(t, len(blob), e.Size)
+	require.Contains(t, e.Backends, RedisBackendType.String())
+	require.Len(t, cache.data, 1)
+
+	require.Error(t, r.Backfill(ctx, commitments.OptimismKeccak, []byte("b"), false))
+
+	// blobs failing verification aren't written to the caches
+	r, err = NewRouter(&fakeDAStore{blob: blob, verifyErr: errors.New("invalid commitment")}, nil, log.New(),
+		[]PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+	require.ErrorContains(t, r.Backfill(ctx, commitments.OptimismGeneric, []byte("c"), true), "invalid commitment")
+	require.Len(t, cache.data, 1)
+
+	// nor can commitments be backfilled without an EigenDA backend
+	r, err = NewRouter(nil, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+	require.ErrorContains(t, r.Backfill(ctx, commitments.OptimismGeneric, []byte("d"), false), "expected EigenDA backend")
+}
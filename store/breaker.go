@@ -0,0 +1,385 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BreakerState ... state of the circuit breaker of a cache or fallback target
+type BreakerState string
+
+const (
+	// BreakerClosed ... the target is healthy and used
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen ... the target failed too many operations in a row and is skipped
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen ... the target was open for long enough, and single operations probe whether it recovered
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// breakerStateValues ... values of the states recorded by metrics.Metricer.RecordCircuitBreakerState
+var breakerStateValues = map[BreakerState]float64{BreakerClosed: 0, BreakerHalfOpen: 1, BreakerOpen: 2}
+
+// healthHistorySize ... number of state changes kept per target
+const healthHistorySize = 32
+
+// ErrTargetUnavailable ... reported for writes that weren't attempted because the circuit breaker of their target
+// is open
+var ErrTargetUnavailable = errors.New("circuit breaker of the target is open")
+
+// BreakerOptions ... per-target circuit breakers of the cache and fallback targets, so that a degraded S3 region or
+// Redis instance doesn't make every request wait for its timeout. Once FailureThreshold consecutive operations on
+// a target failed, its breaker opens and the target is skipped for OpenDuration. The breaker then half-opens: one
+// operation at a time is let through as a probe, and the breaker closes once HalfOpenSuccesses probes in a row
+// succeeded, or opens again as soon as one fails. Disabled if FailureThreshold is 0.
+//
+// A breaker reopening within FlapWindow of closing is a flap. Targets that flapped at least FlapThreshold times
+// within FlapWindow are flapping, and their breaker stays open for FlapOpenDuration rather than OpenDuration, so that
+// requests aren't routed back and forth between a target and the next one with every blip. Flap detection is
+// disabled if FlapThreshold is 0.
+type BreakerOptions struct {
+	FailureThreshold  int
+	OpenDuration      time.Duration
+	HalfOpenSuccesses int
+	FlapWindow        time.Duration
+	FlapThreshold     int
+	FlapOpenDuration  time.Duration
+}
+
+// Enabled ... whether targets have circuit breakers
+func (o BreakerOptions) Enabled() bool {
+	return o.FailureThreshold > 0
+}
+
+// Check ... validates the circuit breaker options
+func (o BreakerOptions) Check() error {
+	if o.FailureThreshold < 0 {
+		return fmt.Errorf("circuit breaker failure threshold must not be negative")
+	}
+	if !o.Enabled() {
+		return nil
+	}
+	if o.OpenDuration <= 0 {
+		return fmt.Errorf("circuit breaker open duration must be positive")
+	}
+	if o.HalfOpenSuccesses < 1 {
+		return fmt.Errorf("circuit breaker half-open successes must be at least 1")
+	}
+	if o.FlapThreshold < 0 {
+		return fmt.Errorf("circuit breaker flap threshold must not be negative")
+	}
+	if o.FlapThreshold > 0 && o.FlapWindow <= 0 {
+		return fmt.Errorf("circuit breaker flap window must be positive")
+	}
+	if o.FlapThreshold > 0 && o.FlapOpenDuration < o.OpenDuration {
+		return fmt.Errorf("circuit breaker flap open duration must be at least the open duration")
+	}
+	return nil
+}
+
+// HealthTransition ... change of the state of the circuit breaker of a target
+type HealthTransition struct {
+	At   time.Time    `json:"at"`
+	From BreakerState `json:"from"`
+	To   BreakerState `json:"to"`
+}
+
+// TargetHealth ... health history of a cache or fallback target, as seen by its circuit breaker
+type TargetHealth struct {
+	Backend  string       `json:"backend"`
+	State    BreakerState `json:"state"`
+	Flapping bool         `json:"flapping"`
+	// Flaps is the number of flaps since the proxy started, RecentFlaps the number within the flap window
+	Flaps       int `json:"flaps"`
+	RecentFlaps int `json:"recent_flaps"`
+	// Transitions are the most recent state changes of the breaker, oldest first
+	Transitions []HealthTransition `json:"transitions"`
+}
+
+// breaker ... circuit breaker of a target
+type breaker struct {
+	state BreakerState
+	// failures are the consecutive failed operations while closed, successes the consecutive successful probes
+	// while half-open
+	failures  int
+	successes int
+	openedAt  time.Time
+	// probeAt is when the probe in flight was let through while half-open, zero if there is none
+	probeAt time.Time
+	// openFor is how long the breaker stays open since it last opened
+	openFor  time.Duration
+	closedAt time.Time
+
+	// flaps are the times of the flaps within the flap window, totalFlaps the number of flaps since the proxy
+	// started
+	flaps      []time.Time
+	totalFlaps int
+	flapping   bool
+	history    []HealthTransition
+}
+
+// breakers ... circuit breakers of the cache and fallback targets, by backend. A nil breakers never skips a target.
+type breakers struct {
+	opts    BreakerOptions
+	targets map[BackendType]*breaker
+	m       metrics.Metricer
+	log     log.Logger
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+func newBreakers(opts BreakerOptions, targets []PrecomputedKeyStore, m metrics.Metricer, l log.Logger) *breakers {
+	b := &breakers{
+		opts:    opts,
+		targets: make(map[BackendType]*breaker, len(targets)),
+		m:       m,
+		log:     l.With("subsystem", "circuit-breaker"),
+		now:     time.Now,
+	}
+	if opts.FlapThreshold > 0 {
+		b.log.Info("Circuit breaker flap detection enabled", "window", opts.FlapWindow, "threshold",
+			opts.FlapThreshold, "open_for", opts.FlapOpenDuration)
+	}
+	for _, t := range targets {
+		b.targets[t.BackendType()] = &breaker{state: BreakerClosed}
+		m.RecordCircuitBreakerState(t.BackendType().String(), breakerStateValues[BreakerClosed])
+		m.RecordCircuitBreakerFlapping(t.BackendType().String(), false)
+	}
+	return b
+}
+
+// allow ... returns whether an operation may be sent to the target s. Once the open duration of an open breaker
+// elapsed, the operation is let through as the probe of the half-open breaker, and other operations are denied
+// until its outcome is recorded. A probe whose outcome is never recorded is given up on after the open duration.
+func (b *breakers) allow(s Store) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.targets[s.BackendType()]
+	if !ok {
+		return true
+	}
+	now := b.now()
+	if t.flapping {
+		b.updateFlapping(s, t, now)
+	}
+	switch t.state {
+	case BreakerOpen:
+		if now.Sub(t.openedAt) < t.openFor {
+			return false
+		}
+		b.transition(s, t, BreakerHalfOpen)
+		t.successes = 0
+	case BreakerHalfOpen:
+		if !t.probeAt.IsZero() && now.Sub(t.probeAt) < b.opts.OpenDuration {
+			return false
+		}
+	default:
+		return true
+	}
+	t.probeAt = now
+	return true
+}
+
+// peek ... returns the state of the circuit breaker of the target s, if it has one, and whether allow would let an
+// operation through, without letting it through
+func (b *breakers) peek(s Store) (BreakerState, bool) {
+	if b == nil {
+		return "", true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.targets[s.BackendType()]
+	if !ok {
+		return "", true
+	}
+	now := b.now()
+	switch t.state {
+	case BreakerOpen:
+		return t.state, now.Sub(t.openedAt) >= t.openFor
+	case BreakerHalfOpen:
+		return t.state, t.probeAt.IsZero() || now.Sub(t.probeAt) >= b.opts.OpenDuration
+	default:
+		return t.state, true
+	}
+}
+
+// record ... records the outcome of an operation on the target s. Missing or archived keys and cancelled operations
+// say nothing about the health of the target, and aren't counted.
+func (b *breakers) record(s Store, err error) {
+	if b == nil || errors.Is(err, ErrKeyNotFound) || errors.Is(err, ErrArchived) || errors.Is(err, context.Canceled) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.targets[s.BackendType()]
+	if !ok {
+		return
+	}
+	switch t.state {
+	case BreakerClosed:
+		if err == nil {
+			t.failures = 0
+			return
+		}
+		t.failures++
+		if t.failures >= b.opts.FailureThreshold {
+			b.open(s, t)
+			b.log.Error("Target failed too many operations in a row, opening its circuit breaker",
+				"backend", s.BackendType(), "failures", t.failures, "err", err, "open_for", t.openFor,
+				"flapping", t.flapping)
+		}
+	case BreakerHalfOpen:
+		t.probeAt = time.Time{}
+		if err != nil {
+			b.log.Warn("Circuit breaker probe failed, target stays open", "backend", s.BackendType(), "err", err)
+			b.open(s, t)
+			return
+		}
+		t.successes++
+		if t.successes >= b.opts.HalfOpenSuccesses {
+			b.log.Info("Target recovered, closing its circuit breaker", "backend", s.BackendType(),
+				"open_for", b.now().Sub(t.openedAt))
+			t.failures = 0
+			b.transition(s, t, BreakerClosed)
+		}
+	case BreakerOpen:
+		// outcome of an operation started before the breaker opened
+	}
+}
+
+// open ... opens the breaker of the target s, for longer if the target is flapping. Reopening a breaker within the
+// flap window of closing it is a flap.
+func (b *breakers) open(s Store, t *breaker) {
+	now := b.now()
+	if b.opts.FlapThreshold > 0 && t.state == BreakerClosed && !t.closedAt.IsZero() &&
+		now.Sub(t.closedAt) < b.opts.FlapWindow {
+		t.flaps = append(t.flaps, now)
+		t.totalFlaps++
+		b.m.RecordCircuitBreakerFlap(s.BackendType().String())
+	}
+	b.updateFlapping(s, t, now)
+
+	t.openedAt = now
+	t.probeAt = time.Time{}
+	t.openFor = b.opts.OpenDuration
+	if t.flapping {
+		t.openFor = b.opts.FlapOpenDuration
+	}
+	b.transition(s, t, BreakerOpen)
+}
+
+// updateFlapping ... forgets the flaps of the target s that fell out of the flap window, and updates whether it is
+// flapping
+func (b *breakers) updateFlapping(s Store, t *breaker, now time.Time) {
+	i := 0
+	for i < len(t.flaps) && now.Sub(t.flaps[i]) >= b.opts.FlapWindow {
+		i++
+	}
+	t.flaps = t.flaps[i:]
+
+	flapping := b.opts.FlapThreshold > 0 && len(t.flaps) >= b.opts.FlapThreshold
+	if flapping == t.flapping {
+		return
+	}
+	t.flapping = flapping
+	if flapping {
+		b.log.Warn("Target is flapping, keeping its circuit breaker open for longer", "backend", s.BackendType(),
+			"flaps", len(t.flaps), "window", b.opts.FlapWindow, "open_for", b.opts.FlapOpenDuration)
+	} else {
+		b.log.Info("Target stopped flapping", "backend", s.BackendType())
+	}
+	b.m.RecordCircuitBreakerFlapping(s.BackendType().String(), flapping)
+}
+
+func (b *breakers) transition(s Store, t *breaker, state BreakerState) {
+	now := b.now()
+	t.history = append(t.history, HealthTransition{At: now, From: t.state, To: state})
+	if len(t.history) > healthHistorySize {
+		t.history = t.history[len(t.history)-healthHistorySize:]
+	}
+	if state == BreakerClosed {
+		t.closedAt = now
+	}
+	t.state = state
+	b.m.RecordCircuitBreakerState(s.BackendType().String(), breakerStateValues[state])
+}
+
+// health ... returns the health history of each target, by backend
+func (b *breakers) health() []TargetHealth {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	health := make([]TargetHealth, 0, len(b.targets))
+	for backend, t := range b.targets {
+		recent := 0
+		for _, at := range t.flaps {
+			if now.Sub(at) < b.opts.FlapWindow {
+				recent++
+			}
+		}
+		health = append(health, TargetHealth{
+			Backend:     backend.String(),
+			State:       t.state,
+			Flapping:    t.flapping && recent >= b.opts.FlapThreshold,
+			Flaps:       t.totalFlaps,
+			RecentFlaps: recent,
+			Transitions: append([]HealthTransition{}, t.history...),
+		})
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Backend < health[j].Backend })
+	return health
+}
+
+// states ... returns the state of the circuit breaker of each target, by backend
+func (b *breakers) states() map[string]BreakerState {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]BreakerState, len(b.targets))
+	for backend, t := range b.targets {
+		states[backend.String()] = t.state
+	}
+	return states
+}
+
+// reason ... describes the targets whose circuit breaker isn't closed, or returns an empty string if there are none
+func (b *breakers) reason() string {
+	var unhealthy []string
+	for backend, state := range b.states() {
+		if state != BreakerClosed {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", backend, state))
+		}
+	}
+	if len(unhealthy) == 0 {
+		return ""
+	}
+	sort.Strings(unhealthy)
+	return "circuit breakers of targets not closed: " + strings.Join(unhealthy, ", ")
+}
+
+// BackendHealth ... returns the health history of the targets with a circuit breaker, by backend
+func (r *Router) BackendHealth() []TargetHealth {
+	return r.breakers.health()
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// downStore ... mapStore whose operations fail while down is set, counting the reads it served
+type downStore struct {
+	mapStore
+	down  bool
+	reads int
+}
+
+func (s *downStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	s.reads++
+	if s.down {
+		return nil, errUnavailable
+	}
+	return s.mapStore.Get(ctx, key)
+}
+
+func (s *downStore) Put(ctx context.Context, key []byte, value []byte) error {
+	if s.down {
+		return errUnavailable
+	}
+	return s.mapStore.Put(ctx, key, value)
+}
+
+func TestCircuitBreakers(t *testing.T) {
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	redis := &downStore{mapStore: mapStore{data: make(map[string][]byte)}, down: true}
+	backup := &s3MapStore{mapStore{data: map[string][]byte{key: blob}}}
+	m := metrics.NewMetrics("test")
+	r, err := NewRouter(&fakeDAStore{blob: blob, unavailable: true}, nil, log.New(), nil, []PrecomputedKeyStore{redis, backup},
+		RouterOptions{Metrics: m, Breakers: BreakerOptions{FailureThreshold: 2, OpenDuration: time.Minute,
+			HalfOpenSuccesses: 1}})
+	require.NoError(t, err)
+	router := r.(*Router)
+	now := time.Now()
+	router.breakers.now = func() time.Time { return now }
+
+	read := func() {
+		data, err := r.Get(context.Background(), commitment, commitments.SimpleCommitmentMode)
+		require.NoError(t, err)
+		require.Equal(t, blob, data)
+	}
+
+	// the breaker opens after two failed reads, after which Redis is skipped
+	read()
+	read()
+	require.Equal(t, 2, redis.reads)
+	require.Equal(t, 2.0, testutil.ToFloat64(m.CircuitBreakerState.WithLabelValues("Redis")))
+	require.Contains(t, router.Degraded(), "Redis (open)")
+	read()
+	require.Equal(t, 2, redis.reads)
+
+	// writes to the open target are dead-lettered without being attempted
+	outcomes := router.handleRedundantWrites(context.Background(), []byte("other"), blob, nil)
+	require.Equal(t, WriteOutcome{Backend: RedisBackendType, Status: WriteDeadLettered, Err: ErrTargetUnavailable},
+		outcomes[0])
+	require.Equal(t, WriteSucceeded, outcomes[1].Status)
+	require.Len(t, router.deadLetters.List(), 1)
+
+	// once the open duration elapsed, a successful probe closes the breaker
+	redis.down = false
+	now = now.Add(time.Minute)
+	read()
+	require.Equal(t, 3, redis.reads)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.CircuitBreakerState.WithLabelValues("Redis")))
+	require.Empty(t, router.Degraded())
+}
+
+func TestBreakerTransitions(t *testing.T) {
+	redis := &mapStore{}
+	b := newBreakers(BreakerOptions{FailureThreshold: 2, OpenDuration: time.Minute, HalfOpenSuccesses: 2},
+		[]PrecomputedKeyStore{redis}, metrics.NoopMetrics, log.New())
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	state := func() BreakerState { return b.states()["Redis"] }
+
+	// missing keys and cancelled operations aren't failures, and a success resets the count
+	b.record(redis, fmt.Errorf("get: %w", ErrKeyNotFound))
+	b.record(redis, context.Canceled)
+	b.record(redis, errUnavailable)
+	b.record(redis, nil)
+	b.record(redis, errUnavailable)
+	require.Equal(t, BreakerClosed, state())
+	b.record(redis, errUnavailable)
+	require.Equal(t, BreakerOpen, state())
+	require.False(t, b.allow(redis))
+
+	// a single probe is let through at a time, and a failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	require.True(t, b.allow(redis))
+	require.Equal(t, BreakerHalfOpen, state())
+	require.False(t, b.allow(redis))
+	b.record(redis, errUnavailable)
+	require.Equal(t, BreakerOpen, state())
+
+	// the breaker closes after two successful probes
+	now = now.Add(time.Minute)
+	require.True(t, b.allow(redis))
+	b.record(redis, nil)
+	require.Equal(t, BreakerHalfOpen, state())
+	require.True(t, b.allow(redis))
+	b.record(redis, nil)
+	require.Equal(t, BreakerClosed, state())
+
+	// stores without a breaker are never skipped
+	var none *breakers
+	require.True(t, none.allow(redis))
+	require.True(t, b.allow(&s3MapStore{}))
+}
+
+func TestBreakerFlapping(t *testing.T) {
+	redis := &mapStore{}
+	m := metrics.NewMetrics("test")
+	b := newBreakers(BreakerOptions{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 1,
+		FlapWindow: 10 * time.Minute, FlapThreshold: 2, FlapOpenDuration: 5 * time.Minute},
+		[]PrecomputedKeyStore{redis}, m, log.New())
+	now := time.Now()
+	b.now = func() time.Time { return now }
+	closeBreaker := func() {
+		now = now.Add(time.Minute)
+		require.True(t, b.allow(redis))
+		b.record(redis, nil)
+		require.Equal(t, BreakerClosed, b.states()["Redis"])
+	}
+
+	// the first opening isn't a flap, reopening shortly after closing is
+	b.record(redis, errUnavailable)
+	closeBreaker()
+	b.record(redis, errUnavailable)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.CircuitBreakerFlaps.WithLabelValues("Redis")))
+	require.False(t, b.health()[0].Flapping)
+
+	// once flapping, the breaker stays open for the flap open duration
+	closeBreaker()
+	b.record(redis, errUnavailable)
+	health := b.health()[0]
+	require.True(t, health.Flapping)
+	require.Equal(t, 2, health.Flaps)
+	require.Len(t, health.Transitions, 7)
+	require.Equal(t, HealthTransition{At: now, From: BreakerClosed, To: BreakerOpen}, health.Transitions[6])
+	require.Equal(t, 1.0, testutil.ToFloat64(m.CircuitBreakerFlapping.WithLabelValues("Redis")))
+	now = now.Add(time.Minute)
+	require.False(t, b.allow(redis))
+	now = now.Add(4 * time.Minute)
+	require.True(t, b.allow(redis))
+
+	// the target stops flapping once its flaps fell out of the window
+	now = now.Add(10 * time.Minute)
+	b.allow(redis)
+	require.Zero(t, b.health()[0].RecentFlaps)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.CircuitBreakerFlapping.WithLabelValues("Redis")))
+}
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CachePolicy ... how a read of a DA commitment uses the cache and fallback targets
+type CachePolicy string
+
+const (
+	// CachePolicyDefault ... reads are served from cache targets, then EigenDA, then fallback targets
+	CachePolicyDefault CachePolicy = ""
+	// CachePolicyBypass ... reads are served from EigenDA only, e.g. when a cache is suspected to hold corrupt data
+	CachePolicyBypass CachePolicy = "bypass"
+	// CachePolicyRefresh ... like CachePolicyBypass, and the blob read from EigenDA overwrites the cache targets
+	CachePolicyRefresh CachePolicy = "refresh"
+)
+
+// ParseCachePolicy ... parses a non-default cache policy
+func ParseCachePolicy(s string) (CachePolicy, error) {
+	switch p := CachePolicy(s); p {
+	case CachePolicyBypass, CachePolicyRefresh:
+		return p, nil
+	default:
+		return CachePolicyDefault, fmt.Errorf("unknown cache policy: %q", s)
+	}
+}
+
+// WithCachePolicy ... returns a child context whose reads follow the cache policy p
+func WithCachePolicy(ctx context.Context, p CachePolicy) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.CachePolicy = p })
+}
+
+// cachePolicyFromContext ... returns the cache policy of the context, the default policy if none is set
+func cachePolicyFromContext(ctx context.Context) CachePolicy {
+	return RequestMetaFromContext(ctx).CachePolicy
+}
+
+// refreshCaches ... overwrites the blob in every writable cache target, read-through or not
+func (r *Router) refreshCaches(ctx context.Context, commitment []byte, value []byte) {
+	r.cacheLock.RLock()
+	defer r.cacheLock.RUnlock()
+
+	key := crypto.Keccak256(commitment)
+	for _, c := range r.caches {
+		if !r.writable(c) || !r.breakers.allow(c) {
+			continue
+		}
+		err := c.Put(ctx, key, r.seal(value))
+		r.breakers.record(c, err)
+		if err != nil {
+			r.log.Warn("Failed to refresh cache target", "backend", c.BackendType(), "err", err)
+			continue
+		}
+		r.index.AddBackend(commitment, len(value), c.BackendType().String())
+		r.recordQuotaUtilization(c)
+	}
+}
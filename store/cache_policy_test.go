@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachePolicy(t *testing.T) {
+	commitment, blob, corrupt := []byte("commitment"), []byte("blob"), []byte("corrupt")
+	key := string(crypto.Keccak256(commitment))
+
+	tests := []struct {
+		name     string
+		policy   CachePolicy
+		expected []byte
+		cached   []byte
+	}{
+		{name: "Default", policy: CachePolicyDefault, expected: corrupt, cached: corrupt},
+		{name: "Bypass", policy: CachePolicyBypass, expected: blob, cached: corrupt},
+		{name: "Refresh", policy: CachePolicyRefresh, expected: blob, cached: blob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// mapStore doesn't verify the data it serves, standing in for a cache holding corrupt data
+			cache := &mapStore{data: map[string][]byte{key: corrupt}}
+			r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+				RouterOptions{})
+			require.NoError(t, err)
+
+			data, err := r.Get(WithCachePolicy(context.Background(), tt.policy), commitment,
+				commitments.SimpleCommitmentMode)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, data)
+			require.Equal(t, tt.cached, cache.data[key])
+		})
+	}
+}
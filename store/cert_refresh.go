@@ -0,0 +1,71 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+)
+
+// currentCert ... returns the cert a commitment's blob is read and verified with: the refreshed cert if the
+// commitment's batch was re-confirmed, the commitment itself otherwise
+func (r *Router) currentCert(commitment []byte) []byte {
+	if cert := r.index.RefreshedCert(commitment); cert != nil {
+		return cert
+	}
+	return commitment
+}
+
+// refreshCert ... looks up the current cert of a commitment whose cert failed to be read or verified, in case the
+// disperser re-submitted its batch and the blob was confirmed in a new one. Requires the disperser request ID of
+// the commitment, which is only indexed for blobs dispersed through the proxy.
+func (r *Router) refreshCert(ctx context.Context, commitment, cert []byte) ([]byte, error) {
+	if !r.refreshStaleCerts {
+		return nil, errors.New("stale cert refresh is disabled")
+	}
+	refresher, ok := r.eigenda.(CertRefresher)
+	if !ok {
+		return nil, fmt.Errorf("%s backend can't refresh certs", r.eigenda.BackendType())
+	}
+	e, ok := r.index.Get(commitment)
+	if !ok || len(e.RequestID) == 0 {
+		return nil, errors.New("disperser request ID of commitment is unknown")
+	}
+
+	refreshed, err := refresher.RefreshCert(ctx, cert, e.RequestID)
+	if err != nil {
+		r.log.Debug("Failed to refresh cert", "dispersal_request_id", e.RequestID.String(), "err", err)
+		return nil, err
+	}
+	if bytes.Equal(refreshed, cert) {
+		return nil, errors.New("cert is up to date")
+	}
+
+	r.log.Info("Resolved stale cert via its re-confirmed batch", "dispersal_request_id", e.RequestID.String())
+	r.index.SetRefreshedCert(commitment, refreshed)
+	// the refreshed cert is served like any other commitment of the blob
+	r.indexCert(refreshed, e.Size, commitments.CommitmentMode(e.Mode))
+	r.index.SetRequestID(refreshed, e.RequestID)
+	return refreshed, nil
+}
+
+// verifyCert ... verifies a blob read from EigenDA against cert, refreshing the cert of the commitment if the
+// verification fails. The blob of a re-confirmed batch is unchanged, only its cert differs.
+func (r *Router) verifyCert(ctx context.Context, commitment, cert, data []byte) error {
+	err := r.verify(ctx, r.eigenda, cert, data)
+	if err == nil {
+		return nil
+	}
+	refreshed, rerr := r.refreshCert(ctx, commitment, cert)
+	if rerr != nil {
+		r.recordVerificationFailure(r.eigenda)
+		return err
+	}
+	if err := r.verify(ctx, r.eigenda, refreshed, data); err != nil {
+		r.recordVerificationFailure(r.eigenda)
+		return err
+	}
+	return nil
+}
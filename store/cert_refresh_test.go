@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	staleCert   = []byte("stale cert")
+	currentCert = []byte("current cert")
+	requestID   = []byte("request id")
+)
+
+// rebatchedStore ... fakeDAStore whose only blob was re-confirmed in a new batch after its dispersal, so that the
+// cert returned by Put no longer verifies
+func rebatchedStore(blob []byte, retrievable bool) *fakeDAStore {
+	return &fakeDAStore{
+		blob:        blob,
+		unavailable: !retrievable,
+		cert:        staleCert,
+		requestID:   requestID,
+		currentCert: currentCert,
+	}
+}
+
+func TestRefreshStaleCert(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	for _, retrievable := range []bool{false, true} {
+		da := rebatchedStore(blob, retrievable)
+		r, err := NewRouter(da, nil, log.New(), nil, nil, RouterOptions{RefreshStaleCerts: true})
+		require.NoError(t, err)
+
+		commit, err := r.Put(ctx, commitments.OptimismGeneric, nil, blob)
+		require.NoError(t, err)
+		e, ok := r.Index().Get(commit)
+		require.True(t, ok)
+		require.Equal(t, requestID, []byte(e.RequestID))
+
+		got, err := r.Get(ctx, commit, commitments.OptimismGeneric)
+		require.NoError(t, err)
+		require.Equal(t, blob, got)
+		require.Equal(t, currentCert, r.Index().RefreshedCert(commit))
+
+		// the current cert is indexed as well, and later reads use it without querying the disperser
+		_, ok = r.Index().Get(currentCert)
+		require.True(t, ok)
+		_, err = r.Get(ctx, commit, commitments.OptimismGeneric)
+		require.NoError(t, err)
+		require.Equal(t, 1, da.refreshes)
+	}
+}
+
+func TestRefreshStaleCertDisabled(t *testing.T) {
+	ctx := context.Background()
+
+	da := rebatchedStore([]byte("blob"), false)
+	r, err := NewRouter(da, nil, log.New(), nil, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	commit, err := r.Put(ctx, commitments.OptimismGeneric, nil, da.blob)
+	require.NoError(t, err)
+	_, err = r.Get(ctx, commit, commitments.OptimismGeneric)
+	require.Error(t, err)
+	require.Zero(t, da.refreshes)
+}
@@ -0,0 +1,279 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// chunkLatencyWeight ... weight of the latest dispersal in the moving averages of a chunk size
+	chunkLatencyWeight = 0.3
+	// maxChunkErrorRate ... dispersal error rate above which chunks of a size are considered congested
+	maxChunkErrorRate = 0.25
+)
+
+// ChunkingOptions ... splitting of payloads larger than MaxSize into several blobs. The size of the chunks is
+// chosen between MinSize and MaxSize from the recent dispersals of chunks of similar sizes: the largest size whose
+// dispersals take at most TargetLatency on average and rarely fail is used, so that chunks shrink while the
+// network is congested. Dispersal statistics older than Window are forgotten, so that larger sizes are tried again
+// once the congestion cleared. Disabled if MaxSize is 0.
+type ChunkingOptions struct {
+	MinSize       int
+	MaxSize       int
+	TargetLatency time.Duration
+	Window        time.Duration
+}
+
+// Enabled ... whether oversized payloads are split into chunks
+func (o ChunkingOptions) Enabled() bool {
+	return o.MaxSize > 0
+}
+
+// Check ... validates the chunking options
+func (o ChunkingOptions) Check() error {
+	if !o.Enabled() {
+		return nil
+	}
+	if o.MinSize <= 0 || o.MinSize > o.MaxSize {
+		return fmt.Errorf("chunk min size must be positive and at most the chunk max size")
+	}
+	if o.TargetLatency <= 0 || o.Window <= 0 {
+		return fmt.Errorf("chunk target latency and window must be positive")
+	}
+	return nil
+}
+
+// chunkStats ... moving averages of the recent dispersals of chunks of up to size bytes
+type chunkStats struct {
+	size      int
+	latency   time.Duration
+	errorRate float64
+	updated   time.Time
+}
+
+// chunker ... chooses the size of the chunks payloads are split into. A nil chunker never splits payloads.
+type chunker struct {
+	opts ChunkingOptions
+
+	mu sync.Mutex
+	// buckets are the sizes chunks are chosen from, by descending size
+	buckets []chunkStats
+	now     func() time.Time
+}
+
+func newChunker(opts ChunkingOptions) *chunker {
+	c := &chunker{opts: opts, now: time.Now}
+	for size := opts.MaxSize; size > opts.MinSize; size /= 2 {
+		c.buckets = append(c.buckets, chunkStats{size: size})
+	}
+	c.buckets = append(c.buckets, chunkStats{size: opts.MinSize})
+	return c
+}
+
+// splits ... whether a payload is split into chunks
+func (c *chunker) splits(value []byte) bool {
+	return c != nil && len(value) > c.opts.MaxSize
+}
+
+// size ... returns the largest chunk size which isn't congested, or the minimum size if all of them are
+func (c *chunker) size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, b := range c.buckets {
+		if b.updated.IsZero() || now.Sub(b.updated) > c.opts.Window {
+			return b.size
+		}
+		if b.latency <= c.opts.TargetLatency && b.errorRate <= maxChunkErrorRate {
+			return b.size
+		}
+	}
+	return c.opts.MinSize
+}
+
+// observe ... records the outcome of the dispersal of a chunk of size bytes
+func (c *chunker) observe(size int, latency time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the smallest bucket the chunk fits in
+	i := len(c.buckets) - 1
+	for i > 0 && c.buckets[i].size < size {
+		i--
+	}
+	b := &c.buckets[i]
+
+	now := c.now()
+	if b.updated.IsZero() || now.Sub(b.updated) > c.opts.Window {
+		*b = chunkStats{size: b.size}
+		if err == nil {
+			b.latency = latency
+		} else {
+			b.errorRate = 1
+		}
+		b.updated = now
+		return
+	}
+
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	} else {
+		// failed dispersals may fail fast or time out, either way their latency isn't representative
+		b.latency += time.Duration(chunkLatencyWeight * float64(latency-b.latency))
+	}
+	b.errorRate += chunkLatencyWeight * (failed - b.errorRate)
+	b.updated = now
+}
+
+// split ... splits a payload into chunks of at most the current chunk size, of equal sizes but for the last one
+func (c *chunker) split(value []byte) [][]byte {
+	size := c.size()
+	n := (len(value) + size - 1) / size
+	size = (len(value) + n - 1) / n
+
+	chunks := make([][]byte, 0, n)
+	for len(value) > 0 {
+		end := min(size, len(value))
+		chunks = append(chunks, value[:end])
+		value = value[end:]
+	}
+	return chunks
+}
+
+// WithChunks ... returns a child context whose request reads a payload split across the blobs listed by the
+// manifest blob of its commitment
+func WithChunks(ctx context.Context, chunks []commitments.EnvelopeChunk) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Chunks = chunks })
+}
+
+// recordChunks ... records the chunks a written payload was split into, and the symbols of their encoded blobs,
+// into the context's ResponseMeta (if any)
+func recordChunks(ctx context.Context, chunks []commitments.EnvelopeChunk, symbols int) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.chunks = chunks
+	meta.encodedSymbols += symbols
+}
+
+// Chunks ... returns the chunks the payload written by the request was split into, nil if it wasn't split. The
+// returned commitment is then the cert of the manifest blob listing the certs of the chunks, which the server
+// wraps into an envelope together with the chunks.
+func (m *ResponseMeta) Chunks() []commitments.EnvelopeChunk {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.chunks
+}
+
+// putChunked ... disperses the chunks of a payload concurrently, then a manifest blob listing their certs, and
+// returns the cert and blob of the manifest. Chunks are indexed and written to the redundant targets like other
+// blobs.
+func (r *Router) putChunked(ctx context.Context, cm commitments.CommitmentMode, value []byte,
+	sinks []PrecomputedKeyStore) ([]byte, []byte, error) {
+	parts := r.chunker.split(value)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("payload.chunks", len(parts)),
+		attribute.Int("payload.chunk_size", len(parts[0])))
+	RequestLogger(ctx, r.log).Info("Splitting payload into chunks", "size", len(value), "chunks", len(parts),
+		"chunkSize", len(parts[0]))
+
+	certs := make([][]byte, len(parts))
+	chunks := make([]commitments.EnvelopeChunk, len(parts))
+	symbols := make([]int, len(parts))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, part := range parts {
+		i, part := i, part
+		chunks[i] = commitments.EnvelopeChunk{Size: uint64(len(part)), Hash: crypto.Keccak256Hash(part)}
+		g.Go(func() error {
+			// chunks are accounted into their own response metadata, the request's describes the manifest
+			chunkCtx, chunkMeta := WithResponseMeta(gctx)
+			start := time.Now()
+			cert, err := r.putWithoutKey(chunkCtx, part)
+			if !errors.Is(err, context.Canceled) {
+				r.chunker.observe(len(part), time.Since(start), err)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to disperse chunk %d of %d: %w", i+1, len(parts), err)
+			}
+			certs[i], symbols[i] = cert, chunkMeta.EncodedSymbols()
+			r.persist(chunkCtx, cm, cert, part, sinks)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	manifest, err := rlp.EncodeToBytes(certs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode chunk manifest: %w", err)
+	}
+	commit, err := r.putWithoutKey(ctx, manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to disperse chunk manifest: %w", err)
+	}
+	total := 0
+	for _, n := range symbols {
+		total += n
+	}
+	recordChunks(ctx, chunks, total)
+	return commit, manifest, nil
+}
+
+// getChunked ... reads the manifest blob of a chunked payload, then its chunks concurrently, and reassembles the
+// payload once each chunk matches the size and hash committed to
+func (r *Router) getChunked(ctx context.Context, key []byte, cm commitments.CommitmentMode,
+	chunks []commitments.EnvelopeChunk) ([]byte, error) {
+	manifest, err := r.get(ctx, key, cm)
+	if err != nil {
+		return nil, err
+	}
+	var certs [][]byte
+	if err := rlp.DecodeBytes(manifest, &certs); err != nil {
+		return nil, fmt.Errorf("failed to decode chunk manifest: %w", err)
+	}
+	if len(certs) != len(chunks) {
+		return nil, fmt.Errorf("chunk manifest lists %d chunks, commitment %d", len(certs), len(chunks))
+	}
+
+	parts := make([][]byte, len(certs))
+	g, gctx := errgroup.WithContext(ctx)
+	for i, cert := range certs {
+		i, cert := i, cert
+		g.Go(func() error {
+			chunkCtx, _ := WithResponseMeta(gctx)
+			data, err := r.get(chunkCtx, cert, cm)
+			if err != nil {
+				return fmt.Errorf("failed to read chunk %d of %d: %w", i+1, len(certs), err)
+			}
+			if uint64(len(data)) != chunks[i].Size {
+				return fmt.Errorf("chunk %d of %d has %d bytes, expected %d", i+1, len(certs), len(data), chunks[i].Size)
+			}
+			if crypto.Keccak256Hash(data) != chunks[i].Hash {
+				return fmt.Errorf("chunk %d of %d hash mismatch", i+1, len(certs))
+			}
+			parts[i] = data
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return bytes.Join(parts, nil), nil
+}
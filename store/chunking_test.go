@@ -0,0 +1,60 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkerSize(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := newChunker(ChunkingOptions{MinSize: 100, MaxSize: 1000, TargetLatency: time.Minute, Window: 10 * time.Minute})
+	c.now = func() time.Time { return now }
+	require.Equal(t, []int{1000, 500, 250, 125, 100}, []int{c.buckets[0].size, c.buckets[1].size, c.buckets[2].size,
+		c.buckets[3].size, c.buckets[4].size})
+
+	// sizes without dispersals are tried first
+	require.Equal(t, 1000, c.size())
+
+	// large chunks are slow to disperse, smaller ones meet the target
+	c.observe(1000, 3*time.Minute, nil)
+	c.observe(480, 30*time.Second, nil)
+	require.Equal(t, 500, c.size())
+
+	// failing dispersals are congested too, whatever their latency
+	c.observe(500, time.Second, errors.New("resource exhausted"))
+	require.Equal(t, 250, c.size())
+
+	// repeated successes make a size uncongested again
+	for i := 0; i < 5; i++ {
+		c.observe(500, 30*time.Second, nil)
+	}
+	require.Equal(t, 500, c.size())
+
+	// larger sizes are tried again once their statistics are out of the window
+	now = now.Add(11 * time.Minute)
+	require.Equal(t, 1000, c.size())
+
+	// the minimum size is used if every size is congested
+	for _, size := range []int{1000, 500, 250, 125, 100} {
+		c.observe(size, time.Hour, nil)
+	}
+	require.Equal(t, 100, c.size())
+}
+
+func TestChunkerSplit(t *testing.T) {
+	c := newChunker(ChunkingOptions{MinSize: 100, MaxSize: 1000, TargetLatency: time.Minute, Window: time.Minute})
+
+	payload := bytes.Repeat([]byte{0x2a}, 2500)
+	require.True(t, c.splits(payload))
+	require.False(t, c.splits(payload[:1000]))
+	require.False(t, (*chunker)(nil).splits(payload))
+
+	chunks := c.split(payload)
+	require.Len(t, chunks, 3)
+	require.Equal(t, []int{834, 834, 832}, []int{len(chunks[0]), len(chunks[1]), len(chunks[2])})
+	require.Equal(t, payload, bytes.Join(chunks, nil))
+}
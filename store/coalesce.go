@@ -0,0 +1,64 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"golang.org/x/sync/singleflight"
+)
+
+// coalescedRead ... result of a backend fetch shared by concurrent reads of a commitment
+type coalescedRead struct {
+	data []byte
+	meta *ResponseMeta
+}
+
+// coalescedGet ... joins the in-flight fetch of the commitment if there is one, or starts it otherwise. During
+// sync storms many derivation nodes request the same commitment at once, which would otherwise each fetch and
+// verify the blob from EigenDA.
+func (r *Router) coalescedGet(ctx context.Context, key []byte, cm commitments.CommitmentMode) ([]byte, error) {
+	leader := false
+	// reads bypassing the secondary stores must not be served by a read that may have used them
+	flight := string(cm) + ":" + string(cachePolicyFromContext(ctx)) + ":" + string(key)
+	ch := r.reads.DoChan(flight, func() (interface{}, error) {
+		leader = true
+		fetchCtx, meta := WithResponseMeta(ctx)
+		data, err := r.get(fetchCtx, key, cm)
+		return coalescedRead{data: data, meta: meta}, err
+	})
+
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+
+	if !leader {
+		r.m.RecordCoalescedRead(string(cm))
+		// the fetch ran with the context of the request that started it, which may have been cancelled or timed
+		// out independently of this one
+		if isContextErr(res.Err) && ctx.Err() == nil {
+			return r.get(ctx, key, cm)
+		}
+	}
+
+	read := res.Val.(coalescedRead)
+	if meta := ResponseMetaFromContext(ctx); meta != nil {
+		meta.copyServedBy(read.meta)
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	// the data is shared between requests, which may modify it e.g. in hooks
+	if res.Shared {
+		return bytes.Clone(read.data), nil
+	}
+	return read.data, nil
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingDAStore ... fakeDAStore whose reads block until released
+type blockingDAStore struct {
+	fakeDAStore
+	gets    atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingDAStore(blob []byte) *blockingDAStore {
+	return &blockingDAStore{
+		fakeDAStore: fakeDAStore{blob: blob},
+		started:     make(chan struct{}, 10),
+		release:     make(chan struct{}),
+	}
+}
+
+func (s *blockingDAStore) Get(ctx context.Context, _ []byte) ([]byte, error) {
+	s.gets.Add(1)
+	s.started <- struct{}{}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.release:
+		return s.blob, nil
+	}
+}
+
+// joinDelay ... time given to concurrent reads to join an in-flight fetch
+const joinDelay = 100 * time.Millisecond
+
+func TestCoalescedGet(t *testing.T) {
+	blob := []byte("blob")
+
+	t.Run("SingleFetch", func(t *testing.T) {
+		da := newBlockingDAStore(blob)
+		r, err := NewRouter(da, nil, log.New(), nil, nil, RouterOptions{CoalesceReads: true})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		results := make([][]byte, 10)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ctx, meta := WithResponseMeta(context.Background())
+				data, err := r.Get(ctx, []byte("commitment"), commitments.SimpleCommitmentMode)
+				require.NoError(t, err)
+				require.Equal(t, EigenDABackendType, meta.Backend())
+				results[i] = data
+			}(i)
+		}
+		<-da.started
+		time.Sleep(joinDelay)
+		close(da.release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), da.gets.Load())
+		for _, data := range results {
+			require.Equal(t, blob, data)
+		}
+		// shared results are copied, so that requests can't modify each other's data
+		results[0][0] = 'x'
+		require.Equal(t, blob, results[1])
+	})
+
+	t.Run("LeaderCancelled", func(t *testing.T) {
+		da := newBlockingDAStore(blob)
+		r, err := NewRouter(da, nil, log.New(), nil, nil, RouterOptions{CoalesceReads: true})
+		require.NoError(t, err)
+
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		go func() {
+			_, err := r.Get(leaderCtx, []byte("commitment"), commitments.SimpleCommitmentMode)
+			require.ErrorIs(t, err, context.Canceled)
+		}()
+		<-da.started
+
+		done := make(chan []byte)
+		go func() {
+			data, err := r.Get(context.Background(), []byte("commitment"), commitments.SimpleCommitmentMode)
+			require.NoError(t, err)
+			done <- data
+		}()
+		time.Sleep(joinDelay)
+		cancel()
+
+		// the waiting read fetches the blob itself
+		<-da.started
+		close(da.release)
+		require.Equal(t, blob, <-done)
+		require.Equal(t, int32(2), da.gets.Load())
+	})
+}
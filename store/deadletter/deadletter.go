@@ -0,0 +1,250 @@
+package deadletter
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// KindReplication ... a failed write of a dispersed blob to a secondary backend
+	KindReplication = "replication"
+
+	// maxErrorHistory ... number of errors kept per task
+	maxErrorHistory = 10
+)
+
+var ErrTaskNotFound = errors.New("dead-letter task not found")
+
+// TaskError ... a single failed attempt of a task
+type TaskError struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+}
+
+// Task ... a task that exhausted its retries. The payload itself is not stored; it is re-fetched
+// from EigenDA using the commitment when the task is re-driven.
+type Task struct {
+	ID         string        `json:"id"`
+	Kind       string        `json:"kind"`
+	Backend    string        `json:"backend"`
+	Commitment hexutil.Bytes `json:"commitment"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Attempts   int           `json:"attempts"`
+	Errors     []TaskError   `json:"errors"`
+	// Retries counts the failed background re-drives of the task, see Backoff
+	Retries int `json:"retries,omitempty"`
+	// NextRetry is when the task is due for its next background re-drive, right away if zero
+	NextRetry time.Time `json:"next_retry,omitempty"`
+}
+
+// TaskID ... deterministic ID of a task, so repeated failures of the same work are merged
+func TaskID(kind, backend string, commitment []byte) string {
+	h := crypto.Keccak256([]byte(kind), []byte(backend), commitment)
+	return hex.EncodeToString(h[:8])
+}
+
+// Queue ... dead-letter queue of failed tasks. If a path is set, the queue is persisted to it as JSON
+// after every change so that tasks survive restarts.
+type Queue struct {
+	mu    sync.Mutex
+	path  string
+	tasks map[string]*Task
+}
+
+// New ... constructor, loading previously persisted tasks if path is set and exists
+func New(path string) (*Queue, error) {
+	q := &Queue{path: path, tasks: make(map[string]*Task)}
+	if path == "" {
+		return q, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return q, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read dead-letter queue %s: %w", path, err)
+	}
+
+	var tasks []*Task
+	if err := json.Unmarshal(b, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to decode dead-letter queue %s: %w", path, err)
+	}
+	for _, t := range tasks {
+		q.tasks[t.ID] = t
+	}
+	return q, nil
+}
+
+// Add ... records failed attempts of a task, merging them into an existing task with the same ID
+func (q *Queue) Add(kind, backend string, commitment []byte, attempts int, errs []error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	id := TaskID(kind, backend, commitment)
+	t, ok := q.tasks[id]
+	if !ok {
+		t = &Task{
+			ID:         id,
+			Kind:       kind,
+			Backend:    backend,
+			Commitment: append(hexutil.Bytes(nil), commitment...),
+			CreatedAt:  time.Now(),
+		}
+		q.tasks[id] = t
+	}
+
+	t.Attempts += attempts
+	for _, err := range errs {
+		t.Errors = append(t.Errors, TaskError{Time: time.Now(), Error: err.Error()})
+	}
+	if len(t.Errors) > maxErrorHistory {
+		t.Errors = t.Errors[len(t.Errors)-maxErrorHistory:]
+	}
+
+	return q.persist()
+}
+
+// Get ... returns a copy of the task with the given ID
+func (q *Queue) Get(id string) (Task, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t, ok := q.tasks[id]
+	if !ok {
+		return Task{}, fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+	return t.clone(), nil
+}
+
+// List ... returns copies of all tasks, oldest first
+func (q *Queue) List() []Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.list()
+}
+
+// list ... caller must hold the lock
+func (q *Queue) list() []Task {
+	tasks := make([]Task, 0, len(q.tasks))
+	for _, t := range q.tasks {
+		tasks = append(tasks, t.clone())
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].CreatedAt.Before(tasks[j].CreatedAt)
+	})
+	return tasks
+}
+
+// Due ... returns copies of the tasks due for a background re-drive at now, oldest first
+func (q *Queue) Due(now time.Time) []Task {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var due []Task
+	for _, t := range q.list() {
+		if !t.NextRetry.After(now) {
+			due = append(due, t)
+		}
+	}
+	return due
+}
+
+// Backoff ... postpones the next background re-drive of a task after it failed again, by base doubled with every
+// failed re-drive and capped at maxDelay
+func (q *Queue) Backoff(id string, base, maxDelay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	t, ok := q.tasks[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+	delay := base
+	for i := 0; i < t.Retries && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	t.Retries++
+	t.NextRetry = time.Now().Add(delay)
+	return q.persist()
+}
+
+// Remove ... deletes a task, e.g. once it has been re-driven successfully
+func (q *Queue) Remove(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.tasks[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+	}
+	delete(q.tasks, id)
+	return q.persist()
+}
+
+// Restore ... adds tasks, e.g. from a snapshot of another proxy, replacing existing tasks with the same ID
+func (q *Queue) Restore(tasks []Task) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, t := range tasks {
+		c := t.clone()
+		q.tasks[c.ID] = &c
+	}
+	return q.persist()
+}
+
+// Len ... number of tasks in the queue
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.tasks)
+}
+
+// persist ... atomically writes the queue to its path. Caller must hold the lock.
+func (q *Queue) persist() error {
+	if q.path == "" {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(q.list(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(q.path), filepath.Base(q.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to persist dead-letter queue: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to persist dead-letter queue: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to persist dead-letter queue: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), q.path); err != nil {
+		return fmt.Errorf("failed to persist dead-letter queue: %w", err)
+	}
+	return nil
+}
+
+func (t *Task) clone() Task {
+	c := *t
+	c.Commitment = append(hexutil.Bytes(nil), t.Commitment...)
+	c.Errors = append([]TaskError(nil), t.Errors...)
+	return c
+}
@@ -0,0 +1,62 @@
+package deadletter
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead-letters.json")
+
+	q, err := New(path)
+	require.NoError(t, err)
+
+	commitment := []byte{0x01, 0x02}
+	require.NoError(t, q.Add(KindReplication, "Redis", commitment, 3, []error{errors.New("timeout")}))
+	// repeated failures of the same task are merged
+	require.NoError(t, q.Add(KindReplication, "Redis", commitment, 1, []error{errors.New("refused")}))
+	require.Equal(t, 1, q.Len())
+
+	// tasks survive a restart
+	q, err = New(path)
+	require.NoError(t, err)
+	tasks := q.List()
+	require.Len(t, tasks, 1)
+	require.Equal(t, TaskID(KindReplication, "Redis", commitment), tasks[0].ID)
+	require.Equal(t, 4, tasks[0].Attempts)
+	require.Len(t, tasks[0].Errors, 2)
+
+	require.NoError(t, q.Remove(tasks[0].ID))
+	require.ErrorIs(t, q.Remove(tasks[0].ID), ErrTaskNotFound)
+
+	q, err = New(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, q.Len())
+}
+
+func TestQueueBackoff(t *testing.T) {
+	q, err := New("")
+	require.NoError(t, err)
+	require.NoError(t, q.Add(KindReplication, "S3", []byte{0x01}, 3, []error{errors.New("timeout")}))
+	id := TaskID(KindReplication, "S3", []byte{0x01})
+
+	// new tasks are due right away
+	now := time.Now()
+	require.Len(t, q.Due(now), 1)
+
+	// the backoff doubles with every failed re-drive, up to the max
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute} {
+		require.NoError(t, q.Backoff(id, time.Minute, 5*time.Minute))
+		task, err := q.Get(id)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(want), task.NextRetry, time.Second)
+	}
+	require.Empty(t, q.Due(now))
+	require.Len(t, q.Due(now.Add(time.Hour)), 1)
+
+	require.ErrorIs(t, q.Backoff("unknown", time.Minute, time.Hour), ErrTaskNotFound)
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Identifier is implemented by secondary stores to describe the physical store backing them, e.g. the Redis
+// endpoint and database, so that targets pointing at the same store can be detected.
+type Identifier interface {
+	// Identity returns a string that is equal for stores backed by the same physical store.
+	Identity() string
+}
+
+// identity ... returns the physical identity of s, or that of the instance if it isn't an Identifier
+func identity(s PrecomputedKeyStore) string {
+	if id, ok := s.(Identifier); ok {
+		return id.Identity()
+	}
+	return fmt.Sprintf("%s@%p", s.BackendType(), s)
+}
+
+// dedupeTargets ... drops cache targets backed by the same physical store as an earlier cache target, and
+// fallback targets backed by the same store as a cache or earlier fallback target. Otherwise every blob would
+// be written to the store twice, and reads from it would be counted twice in hit rates.
+func dedupeTargets(l log.Logger, caches, fallbacks []PrecomputedKeyStore) ([]PrecomputedKeyStore,
+	[]PrecomputedKeyStore) {
+	seen := make(map[string]PrecomputedKeyStore)
+	dedupe := func(targets []PrecomputedKeyStore, kind string) []PrecomputedKeyStore {
+		var kept []PrecomputedKeyStore
+		for _, t := range targets {
+			id := identity(t)
+			if first, ok := seen[id]; ok {
+				l.Warn("Skipping target backed by the same store as another target", "kind", kind,
+					"backend", t.BackendType(), "duplicate of", first.BackendType(), "store", id)
+				continue
+			}
+			seen[id] = t
+			kept = append(kept, t)
+		}
+		return kept
+	}
+
+	return dedupe(caches, "cache"), dedupe(fallbacks, "fallback")
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// identifiedStore ... mapStore backed by a named physical store
+type identifiedStore struct {
+	mapStore
+	id string
+}
+
+func (s *identifiedStore) Identity() string { return s.id }
+
+func TestDedupeTargets(t *testing.T) {
+	a := &identifiedStore{mapStore{data: map[string][]byte{}}, "redis://a/0"}
+	aAgain := &identifiedStore{mapStore{data: map[string][]byte{}}, "redis://a/0"}
+	b := &identifiedStore{mapStore{data: map[string][]byte{}}, "redis://a/1"}
+	plain := &mapStore{data: map[string][]byte{}}
+
+	r, err := NewRouter(nil, nil, log.New(), []PrecomputedKeyStore{a, aAgain, plain},
+		[]PrecomputedKeyStore{a, b, plain}, RouterOptions{})
+	require.NoError(t, err)
+
+	// stores without an identity are only deduplicated if they are the same instance
+	require.Equal(t, []PrecomputedKeyStore{a, plain}, r.Caches())
+	require.Equal(t, []PrecomputedKeyStore{b}, r.Fallbacks())
+}
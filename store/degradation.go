@@ -0,0 +1,244 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DegradedMode ... how writes are served while the dispersal error rate exceeds its threshold
+type DegradedMode string
+
+const (
+	// DegradedModeFailFast ... writes are rejected with ErrWritePathDegraded instead of being dispersed, so that
+	// batchers fail over immediately rather than after their dispersals time out
+	DegradedModeFailFast DegradedMode = "fail-fast"
+	// DegradedModeKeccak ... blobs of OptimismGeneric writes are written to the keccak256 backend (S3 unless
+	// configured otherwise) under their hash (keccak256 unless S3 verifies another hash algorithm), which is
+	// returned as an OptimismKeccak commitment. Writes of other commitment modes are rejected as in fail-fast mode.
+	DegradedModeKeccak DegradedMode = "keccak"
+)
+
+// ErrWritePathDegraded ... returned for writes that aren't served while the write path is degraded
+var ErrWritePathDegraded = errors.New("dispersals are suspended while the dispersal error rate is above its threshold")
+
+// degradationBuckets ... number of buckets the error rate window is split into
+const degradationBuckets = 10
+
+// DegradationOptions ... automatic degradation of the write path when dispersals keep failing. Once ErrorRate of
+// at least MinDispersals dispersals within Window failed, writes are served in Mode until HealthyProbes
+// consecutive health probes of the EigenDA store, taken every ProbeInterval, succeeded. Disabled if Mode is empty.
+type DegradationOptions struct {
+	Mode          DegradedMode
+	ErrorRate     float64
+	MinDispersals int
+	Window        time.Duration
+	ProbeInterval time.Duration
+	HealthyProbes int
+}
+
+// Enabled ... whether the write path is degraded automatically
+func (o DegradationOptions) Enabled() bool {
+	return o.Mode != ""
+}
+
+// Check ... validates the degradation options
+func (o DegradationOptions) Check() error {
+	if !o.Enabled() {
+		return nil
+	}
+	switch o.Mode {
+	case DegradedModeFailFast, DegradedModeKeccak:
+	default:
+		return fmt.Errorf("unknown degraded mode provided: %s", o.Mode)
+	}
+	if o.ErrorRate <= 0 || o.ErrorRate > 1 {
+		return fmt.Errorf("degradation error rate must be in (0, 1]")
+	}
+	if o.MinDispersals < 1 {
+		return fmt.Errorf("degradation min dispersals must be at least 1")
+	}
+	if o.Window <= 0 || o.ProbeInterval <= 0 {
+		return fmt.Errorf("degradation window and probe interval must be positive")
+	}
+	if o.HealthyProbes < 1 {
+		return fmt.Errorf("degradation healthy probes must be at least 1")
+	}
+	return nil
+}
+
+// dispersalBucket ... dispersal outcomes of a slice of the error rate window
+type dispersalBucket struct {
+	start  time.Time
+	total  int
+	failed int
+}
+
+// degradation ... tracks the dispersal error rate and degrades the write path while it is too high. A nil
+// degradation never degrades.
+type degradation struct {
+	opts DegradationOptions
+	// probe checks whether EigenDA is healthy again, nil if the store can't be probed, in which case the write
+	// path recovers once HealthyProbes intervals passed
+	probe func(ctx context.Context) error
+	m     metrics.Metricer
+	log   log.Logger
+
+	mu       sync.Mutex
+	buckets  [degradationBuckets]dispersalBucket
+	degraded bool
+	since    time.Time
+	now      func() time.Time
+}
+
+func newDegradation(opts DegradationOptions, eigenda GeneratedKeyStore, m metrics.Metricer, l log.Logger) *degradation {
+	d := &degradation{
+		opts: opts,
+		m:    m,
+		log:  l.With("subsystem", "degradation"),
+		now:  time.Now,
+	}
+	if p, ok := eigenda.(Pinger); ok {
+		d.probe = p.Ping
+	}
+	m.RecordWritePathDegraded(string(opts.Mode), false)
+	return d
+}
+
+// active ... whether writes are currently served in the degraded mode
+func (d *degradation) active() bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.degraded
+}
+
+// reason ... describes why the write path is degraded, or returns an empty string if it isn't
+func (d *degradation) reason() string {
+	if d == nil {
+		return ""
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.degraded {
+		return ""
+	}
+	return fmt.Sprintf("write path degraded to %s mode since %s: dispersal error rate exceeded %g",
+		d.opts.Mode, d.since.UTC().Format(time.RFC3339), d.opts.ErrorRate)
+}
+
+// record ... records the outcome of a dispersal, degrading the write path if the error rate within the window
+// exceeds the threshold. Rejections of the request itself, i.e. oversized blobs and canceled requests, don't
+// count.
+func (d *degradation) record(err error) {
+	if d == nil || errors.Is(err, ErrProxyOversizedBlob) || errors.Is(err, ErrEigenDAOversizedBlob) ||
+		errors.Is(err, context.Canceled) {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	width := max(d.opts.Window/degradationBuckets, time.Nanosecond)
+	start := now.Truncate(width)
+	b := &d.buckets[(start.UnixNano()/int64(width))%degradationBuckets]
+	if !b.start.Equal(start) {
+		*b = dispersalBucket{start: start}
+	}
+	b.total++
+	if err != nil {
+		b.failed++
+	}
+
+	if d.degraded {
+		return
+	}
+	total, failed := 0, 0
+	for _, b := range d.buckets {
+		if now.Sub(b.start) < d.opts.Window {
+			total += b.total
+			failed += b.failed
+		}
+	}
+	if total < d.opts.MinDispersals || float64(failed)/float64(total) < d.opts.ErrorRate {
+		return
+	}
+
+	d.degraded = true
+	d.since = now
+	d.m.RecordWritePathDegraded(string(d.opts.Mode), true)
+	d.log.Error("Dispersal error rate exceeded its threshold, degrading the write path", "mode", d.opts.Mode,
+		"failed", failed, "dispersals", total, "window", d.opts.Window)
+	go d.recover()
+}
+
+// recover ... probes EigenDA every ProbeInterval and restores the write path once HealthyProbes consecutive
+// probes succeeded
+func (d *degradation) recover() {
+	ticker := time.NewTicker(d.opts.ProbeInterval)
+	defer ticker.Stop()
+
+	healthy := 0
+	for range ticker.C {
+		if d.probe != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), d.opts.ProbeInterval)
+			err := d.probe(ctx)
+			cancel()
+			if err != nil {
+				d.log.Warn("EigenDA health probe failed, write path stays degraded", "err", err)
+				healthy = 0
+				continue
+			}
+		}
+		healthy++
+		if healthy < d.opts.HealthyProbes {
+			continue
+		}
+
+		d.mu.Lock()
+		d.degraded = false
+		d.buckets = [degradationBuckets]dispersalBucket{}
+		since := d.since
+		d.mu.Unlock()
+
+		d.m.RecordWritePathDegraded(string(d.opts.Mode), false)
+		d.log.Info("EigenDA is healthy again, restoring the write path", "degraded for", time.Since(since))
+		return
+	}
+}
+
+// putDegraded ... serves a write of a generated key commitment mode while the write path is degraded
+func (r *Router) putDegraded(ctx context.Context, cm commitments.CommitmentMode, value []byte) ([]byte, error) {
+	if r.degradation.opts.Mode != DegradedModeKeccak || cm != commitments.OptimismGeneric || r.keccak == nil {
+		return nil, ErrWritePathDegraded
+	}
+
+	commit, err := r.putWithKey(ctx, nil, value)
+	if err != nil {
+		return nil, err
+	}
+	recordCommitmentMode(ctx, commitments.OptimismKeccak)
+	return commit, nil
+}
+
+// Degraded ... returns why the write path is degraded or targets are skipped by their circuit breaker, or an
+// empty string if neither is
+func (r *Router) Degraded() string {
+	var reasons []string
+	for _, reason := range []string{r.degradation.reason(), r.breakers.reason()} {
+		if reason != "" {
+			reasons = append(reasons, reason)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyDAStore ... GeneratedKeyStore whose dispersals and health pings fail while down is set
+type flakyDAStore struct {
+	fakeDAStore
+	down atomic.Bool
+}
+
+func (s *flakyDAStore) Put(_ context.Context, value []byte) ([]byte, error) {
+	if s.down.Load() {
+		return nil, errors.New("disperser unavailable")
+	}
+	return crypto.Keccak256([]byte("cert"), value), nil
+}
+
+func (s *flakyDAStore) Ping(context.Context) error {
+	if s.down.Load() {
+		return errors.New("disperser unavailable")
+	}
+	return nil
+}
+
+func TestWritePathDegradation(t *testing.T) {
+	ctx := context.Background()
+	da := &flakyDAStore{fakeDAStore: fakeDAStore{unavailable: true}}
+	s3 := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	m := metrics.NewMetrics("test")
+	r, err := NewRouter(da, s3, log.New(), nil, nil, RouterOptions{
+		Metrics: m,
+		Degradation: DegradationOptions{
+			Mode:          DegradedModeKeccak,
+			ErrorRate:     0.5,
+			MinDispersals: 3,
+			Window:        time.Minute,
+			ProbeInterval: 10 * time.Millisecond,
+			HealthyProbes: 2,
+		},
+	})
+	require.NoError(t, err)
+	router := r.(*Router)
+
+	// oversized blobs are rejections of the request, not dispersal failures
+	router.degradation.record(fmt.Errorf("put: %w", ErrProxyOversizedBlob))
+	_, err = r.Put(ctx, commitments.OptimismGeneric, nil, []byte("ok"))
+	require.NoError(t, err)
+	da.down.Store(true)
+	_, err = r.Put(ctx, commitments.OptimismGeneric, nil, []byte("a"))
+	require.Error(t, err)
+	require.Empty(t, router.Degraded())
+	_, err = r.Put(ctx, commitments.OptimismGeneric, nil, []byte("b"))
+	require.Error(t, err)
+	require.NotEmpty(t, router.Degraded())
+	require.Equal(t, 1.0, testutil.ToFloat64(m.WritePathDegraded.WithLabelValues("keccak")))
+
+	// generic writes are committed to by the keccak256 hash of their blob in S3, other modes are rejected
+	value := []byte("degraded")
+	ctx, meta := WithResponseMeta(ctx)
+	commit, err := r.Put(ctx, commitments.OptimismGeneric, nil, value)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256(value), commit)
+	require.Equal(t, value, s3.data[string(commit)])
+	require.Equal(t, commitments.OptimismKeccak, meta.CommitmentMode())
+	require.Equal(t, S3BackendType, meta.Backend())
+	_, err = r.Put(ctx, commitments.SimpleCommitmentMode, nil, value)
+	require.ErrorIs(t, err, ErrWritePathDegraded)
+
+	// the write path stays degraded while probes fail, and recovers after consecutive healthy probes
+	time.Sleep(50 * time.Millisecond)
+	require.NotEmpty(t, router.Degraded())
+	da.down.Store(false)
+	require.Eventually(t, func() bool { return router.Degraded() == "" }, time.Second, 5*time.Millisecond)
+	require.Equal(t, 0.0, testutil.ToFloat64(m.WritePathDegraded.WithLabelValues("keccak")))
+
+	ctx, meta = WithResponseMeta(context.Background())
+	commit, err = r.Put(ctx, commitments.OptimismGeneric, nil, value)
+	require.NoError(t, err)
+	require.NotEqual(t, crypto.Keccak256(value), commit)
+	require.Empty(t, meta.CommitmentMode())
+}
@@ -0,0 +1,118 @@
+// Package envelope wraps the values written to secondary stores with integrity metadata, so that corrupt,
+// truncated or otherwise unexpected values are detected when they are read rather than failing verification
+// against their cert.
+package envelope
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+)
+
+// Version ... serialization version of an envelope, allowing its layout to evolve
+type Version byte
+
+const (
+	V0 Version = 0
+)
+
+// Codec ... how the payload of an envelope is encoded
+type Codec byte
+
+const (
+	// CodecRaw ... the payload is stored as is
+	CodecRaw Codec = 0
+)
+
+// Magic ... prefix of enveloped values
+var Magic = []byte{0xed, 'e', 'n', 'v'}
+
+// HeaderSize ... size of the envelope header of layout V0:
+//
+//	[magic (4 bytes), version (1 byte), codec (1 byte), crc32c of the payload (4 bytes),
+//	 decoded size (8 bytes), created at (unix milliseconds, 8 bytes), payload (remaining bytes)]
+const HeaderSize = 26
+
+var (
+	ErrTruncated          = errors.New("enveloped value is truncated")
+	ErrChecksumMismatch   = errors.New("enveloped value checksum mismatch")
+	ErrSizeMismatch       = errors.New("enveloped value size mismatch")
+	ErrUnsupportedVersion = errors.New("unsupported envelope version")
+	ErrUnsupportedCodec   = errors.New("unsupported envelope codec")
+)
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// Header ... metadata of an enveloped value
+type Header struct {
+	Version Version
+	Codec   Codec
+	// Checksum is the crc32c of the encoded payload
+	Checksum uint32
+	// Size of the payload once decoded
+	Size      uint64
+	CreatedAt time.Time
+}
+
+// Seal ... wraps value in a V0 envelope created at now
+func Seal(value []byte, now time.Time) []byte {
+	b := make([]byte, HeaderSize, HeaderSize+len(value))
+	copy(b, Magic)
+	b[4] = byte(V0)
+	b[5] = byte(CodecRaw)
+	binary.BigEndian.PutUint32(b[6:], crc32.Checksum(value, castagnoli))
+	binary.BigEndian.PutUint64(b[10:], uint64(len(value)))
+	binary.BigEndian.PutUint64(b[18:], uint64(now.UnixMilli())) // #nosec G115
+	return append(b, value...)
+}
+
+// IsSealed ... returns whether data starts with the envelope magic
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, Magic)
+}
+
+// Open ... unwraps the value of an envelope. Data without the envelope magic was written before envelopes were
+// enabled and is returned as is.
+func Open(data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return data, nil
+	}
+	value, _, err := Parse(data)
+	return value, err
+}
+
+// Parse ... unwraps the value and header of an envelope, checking the value's integrity
+func Parse(data []byte) ([]byte, Header, error) {
+	if !IsSealed(data) || len(data) < HeaderSize {
+		return nil, Header{}, fmt.Errorf("%w: %d bytes", ErrTruncated, len(data))
+	}
+
+	h := Header{
+		Version:   Version(data[4]),
+		Codec:     Codec(data[5]),
+		Checksum:  binary.BigEndian.Uint32(data[6:]),
+		Size:      binary.BigEndian.Uint64(data[10:]),
+		CreatedAt: time.UnixMilli(int64(binary.BigEndian.Uint64(data[18:]))), // #nosec G115
+	}
+	if h.Version != V0 {
+		return nil, h, fmt.Errorf("%w: %d", ErrUnsupportedVersion, h.Version)
+	}
+	if h.Codec != CodecRaw {
+		return nil, h, fmt.Errorf("%w: %d", ErrUnsupportedCodec, h.Codec)
+	}
+
+	payload := data[HeaderSize:]
+	if uint64(len(payload)) < h.Size {
+		return nil, h, fmt.Errorf("%w: %d of %d bytes", ErrTruncated, len(payload), h.Size)
+	}
+	if uint64(len(payload)) != h.Size {
+		return nil, h, fmt.Errorf("%w: %d bytes, expected %d", ErrSizeMismatch, len(payload), h.Size)
+	}
+	if sum := crc32.Checksum(payload, castagnoli); sum != h.Checksum {
+		return nil, h, fmt.Errorf("%w: %08x, expected %08x", ErrChecksumMismatch, sum, h.Checksum)
+	}
+	return payload, h, nil
+}
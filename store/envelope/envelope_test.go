@@ -0,0 +1,56 @@
+package envelope
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	value := []byte("blob")
+	sealed := Seal(value, now)
+	require.Len(t, sealed, HeaderSize+len(value))
+	require.True(t, IsSealed(sealed))
+
+	opened, h, err := Parse(sealed)
+	require.NoError(t, err)
+	require.Equal(t, value, opened)
+	require.Equal(t, Header{Version: V0, Codec: CodecRaw, Checksum: h.Checksum, Size: 4, CreatedAt: now}, h)
+
+	// values written without an envelope are returned as is
+	opened, err = Open(value)
+	require.NoError(t, err)
+	require.Equal(t, value, opened)
+
+	empty, err := Open(Seal(nil, now))
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func TestOpenInvalid(t *testing.T) {
+	sealed := Seal([]byte("blob"), time.Now())
+	modify := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte(nil), sealed...))
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{name: "TruncatedHeader", data: sealed[:HeaderSize-1], expected: ErrTruncated},
+		{name: "TruncatedPayload", data: sealed[:len(sealed)-1], expected: ErrTruncated},
+		{name: "Trailing", data: append(append([]byte(nil), sealed...), 0), expected: ErrSizeMismatch},
+		{name: "Corrupt", data: modify(func(b []byte) []byte { b[HeaderSize] ^= 1; return b }), expected: ErrChecksumMismatch},
+		{name: "Version", data: modify(func(b []byte) []byte { b[4] = 1; return b }), expected: ErrUnsupportedVersion},
+		{name: "Codec", data: modify(func(b []byte) []byte { b[5] = 1; return b }), expected: ErrUnsupportedCodec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Open(tt.data)
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
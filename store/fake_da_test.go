@@ -0,0 +1,70 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+)
+
+// fakeDAStore ... GeneratedKeyStore standing in for EigenDA. It serves blob for every cert and verifies any value
+// against every cert, the fields make it fail the way EigenDA does.
+// NOTE: storetest.DAStore is the equivalent for other packages, which this package's tests can't import.
+type fakeDAStore struct {
+	blob []byte
+	// unavailable fails reads, as if the blob couldn't be retrieved from EigenDA. Values read from secondary backends
+	// instead only verify if they match blob.
+	unavailable bool
+	// verifyErr fails the verification of every blob
+	verifyErr error
+	// fallbackDecoded reports every blob as decoded by a fallback
+	fallbackDecoded bool
+
+	// cert is returned by Put, and recorded as dispersed with requestID if set
+	cert      []byte
+	requestID []byte
+	// currentCert, if set, is the only cert blob verifies against and can be read with when unavailable, as if the
+	// blob was re-confirmed in a new batch after its dispersal. RefreshCert maps certs dispersed with requestID to it.
+	currentCert []byte
+	refreshes   int
+}
+
+func (s *fakeDAStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if s.unavailable && (s.currentCert == nil || !bytes.Equal(key, s.currentCert)) {
+		return nil, errors.New("blob not available")
+	}
+	if s.fallbackDecoded {
+		RecordFallbackDecode(ctx)
+	}
+	return s.blob, nil
+}
+
+func (s *fakeDAStore) Put(ctx context.Context, _ []byte) ([]byte, error) {
+	if s.requestID != nil {
+		RecordDispersalRequest(ctx, s.requestID)
+	}
+	return s.cert, nil
+}
+
+func (s *fakeDAStore) Verify(key []byte, value []byte) error {
+	if s.verifyErr != nil {
+		return s.verifyErr
+	}
+	if s.currentCert != nil && !bytes.Equal(key, s.currentCert) {
+		return errors.New("batch metadata hash mismatch")
+	}
+	if s.unavailable && !bytes.Equal(value, s.blob) {
+		return errors.New("kzg commitment mismatch")
+	}
+	return nil
+}
+
+func (s *fakeDAStore) RefreshCert(_ context.Context, _ []byte, requestID []byte) ([]byte, error) {
+	s.refreshes++
+	if s.currentCert == nil || !bytes.Equal(requestID, s.requestID) {
+		return nil, errors.New("unknown request")
+	}
+	return s.currentCert, nil
+}
+
+func (s *fakeDAStore) BackendType() BackendType { return EigenDABackendType }
+func (s *fakeDAStore) Stats() *Stats            { return &Stats{} }
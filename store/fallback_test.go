@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackReadVerification(t *testing.T) {
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	// the first fallback target holds data that doesn't match the cert, so the blob is served by the second one
+	corrupt := &mapStore{data: map[string][]byte{key: []byte("corrupt")}}
+	backup := &s3MapStore{mapStore{data: map[string][]byte{key: blob}}}
+	m := metrics.NewMetrics("test")
+	r, err := NewRouter(&fakeDAStore{blob: blob, unavailable: true}, nil, log.New(), nil, []PrecomputedKeyStore{corrupt, backup},
+		RouterOptions{Metrics: m})
+	require.NoError(t, err)
+
+	ctx, meta := WithResponseMeta(context.Background())
+	data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+	require.Equal(t, S3BackendType, meta.Backend())
+
+	require.Equal(t, 1.0, testutil.ToFloat64(m.FallbackReads.WithLabelValues("Redis", "verification_failed")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.FallbackReads.WithLabelValues("S3", "served")))
+}
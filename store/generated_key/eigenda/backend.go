@@ -0,0 +1,69 @@
+//go:generate mockgen -package mocks --destination ../../../mocks/eigenda_backend.go . EigenDABackend
+
+package eigenda
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// EigenDABackend is the EigenDA API the store disperses and retrieves blobs through. It is implemented by the
+// disperser clients (see NewClientBackend), by memstore and by mocks.MockEigenDABackend, so that the store can
+// be unit tested without network access and alternate backends, e.g. simulators, plug in via SetBackend.
+type EigenDABackend interface {
+	// Disperse submits an encoded blob for dispersal to the quorums, in addition to the default ones, and
+	// returns its initial status along with the request ID its status is queried with
+	Disperse(ctx context.Context, encodedBlob []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error)
+	// GetStatus returns the status of the dispersal with requestID, and the blob info once confirmed
+	GetStatus(ctx context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error)
+	// Retrieve returns the encoded blob at blobIndex of the batch with batchHeaderHash
+	Retrieve(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error)
+}
+
+// clientBackend ... EigenDABackend of a disperser client, dispersals are authenticated
+type clientBackend struct {
+	client clients.DisperserClient
+}
+
+var (
+	_ EigenDABackend    = (*clientBackend)(nil)
+	_ store.Pinger      = (*clientBackend)(nil)
+	_ store.Reconnector = (*clientBackend)(nil)
+)
+
+// NewClientBackend ... returns the EigenDABackend of a disperser client
+func NewClientBackend(client clients.DisperserClient) EigenDABackend {
+	return &clientBackend{client: client}
+}
+
+func (b *clientBackend) Disperse(ctx context.Context, encodedBlob []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	return b.client.DisperseBlobAuthenticated(ctx, encodedBlob, quorums)
+}
+
+func (b *clientBackend) GetStatus(ctx context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error) {
+	return b.client.GetBlobStatus(ctx, requestID)
+}
+
+func (b *clientBackend) Retrieve(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	return b.client.RetrieveBlob(ctx, batchHeaderHash, blobIndex)
+}
+
+// Ping checks the pooled disperser connection, if the client pools it
+func (b *clientBackend) Ping(ctx context.Context) error {
+	if p, ok := b.client.(store.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// Reconnect replaces the pooled disperser connection, if the client pools it
+func (b *clientBackend) Reconnect(ctx context.Context) error {
+	if r, ok := b.client.(store.Reconnector); ok {
+		return r.Reconnect(ctx)
+	}
+	return nil
+}
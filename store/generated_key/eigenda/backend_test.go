@@ -0,0 +1,46 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetThroughBackend(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	backend := mocks.NewMockEigenDABackend(ctrl)
+	s := newTestStore(t, "", &fakeDisperser{})
+	s.SetBackend(backend)
+
+	reply, err := (&fakeDisperser{}).GetBlobStatus(context.Background(), nil)
+	require.NoError(t, err)
+	reply.Info.BlobVerificationProof.BatchMetadata.BatchHeaderHash = []byte{0xab}
+	cert, err := rlp.EncodeToBytes((*verify.Certificate)(reply.Info))
+	require.NoError(t, err)
+
+	payload := []byte("blob")
+	encoded, err := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()).EncodeBlob(payload)
+	require.NoError(t, err)
+
+	backend.EXPECT().Retrieve(gomock.Any(), []byte{0xab}, uint32(7)).Return(encoded, nil)
+	actual, err := s.Get(context.Background(), cert)
+	require.NoError(t, err)
+	require.Equal(t, payload, actual)
+
+	backend.EXPECT().Retrieve(gomock.Any(), []byte{0xab}, uint32(7)).Return(nil, errors.New("unavailable"))
+	_, err = s.Get(context.Background(), cert)
+	require.ErrorContains(t, err, "unavailable")
+
+	backend.EXPECT().Retrieve(gomock.Any(), []byte{0xab}, uint32(7)).Return(nil, nil)
+	_, err = s.Get(context.Background(), cert)
+	require.ErrorContains(t, err, "length zero")
+}
@@ -0,0 +1,359 @@
+package eigenda
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// BridgeProtocol ... protocol spoken by an HTTP bridge in front of the disperser
+type BridgeProtocol string
+
+const (
+	// BridgeProtocolGRPCWeb ... gRPC-web, e.g. served by Envoy's grpc_web filter
+	BridgeProtocolGRPCWeb BridgeProtocol = "grpc-web"
+	// BridgeProtocolConnect ... the Connect protocol, e.g. served by a Vanguard or connect-go proxy
+	BridgeProtocolConnect BridgeProtocol = "connect"
+)
+
+// ParseBridgeProtocol ... parses a bridge protocol
+func ParseBridgeProtocol(s string) (BridgeProtocol, error) {
+	switch p := BridgeProtocol(s); p {
+	case BridgeProtocolGRPCWeb, BridgeProtocolConnect:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown disperser bridge protocol: %q", s)
+	}
+}
+
+// BridgeConfig ... HTTP bridge through which the disperser is reached, instead of native gRPC if URL is set
+type BridgeConfig struct {
+	URL      string
+	Protocol BridgeProtocol
+}
+
+// Addr ... returns the host:port of the bridge
+func (b BridgeConfig) Addr() (string, error) {
+	u, err := url.Parse(b.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid disperser bridge url: %w", err)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// grpcWebTrailerFlag ... flag of the gRPC-web frame carrying the trailers
+const grpcWebTrailerFlag = 0x80
+
+// bridgeClient ... disperser client reaching the disperser through a gRPC-web or Connect bridge over HTTP(S), for
+// environments only allowing outbound HTTPS. Errors are mapped to gRPC status errors, so that callers retry
+// and give up on the same status codes as with native gRPC.
+//
+// Neither protocol carries the bidirectional stream of authenticated dispersals over HTTP/1.1, so blobs are
+// dispersed through the unauthenticated endpoint, subject to the disperser's unauthenticated rate limits.
+type bridgeClient struct {
+	url      string
+	protocol BridgeProtocol
+	timeout  time.Duration
+	client   *http.Client
+}
+
+var _ clients.DisperserClient = (*bridgeClient)(nil)
+
+// NewBridgeDisperserClient ... builds a disperser client reaching the disperser through the configured bridge,
+// connecting with tlsCfg if set
+func NewBridgeDisperserClient(cfg clients.EigenDAClientConfig, bridge BridgeConfig,
+	tlsCfg *tls.Config) (clients.DisperserClient, error) {
+	protocol, err := ParseBridgeProtocol(string(bridge.Protocol))
+	if err != nil {
+		return nil, err
+	}
+	bridgeURL := bridge.URL
+	u, err := url.Parse(bridgeURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disperser bridge url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid disperser bridge url %q: scheme must be http or https", bridgeURL)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+	return &bridgeClient{
+		url:      strings.TrimSuffix(bridgeURL, "/"),
+		protocol: protocol,
+		timeout:  cfg.ResponseTimeout,
+		client:   &http.Client{Transport: transport},
+	}, nil
+}
+
+// Ping ... checks that the bridge forwards requests to the disperser, like the native client's Ping
+func (c *bridgeClient) Ping(ctx context.Context) error {
+	err := c.invoke(ctx, grpcdisperser.Disperser_GetBlobStatus_FullMethodName, &grpcdisperser.BlobStatusRequest{},
+		&grpcdisperser.BlobStatusReply{})
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return fmt.Errorf("failed to ping disperser bridge: %w", err)
+	default:
+		return nil
+	}
+}
+
+// Reconnect ... drops the idle connections to the bridge, so that the next request dials a new one
+func (c *bridgeClient) Reconnect(_ context.Context) error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+func (c *bridgeClient) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	reply := &grpcdisperser.DisperseBlobReply{}
+	err := c.invoke(ctx, grpcdisperser.Disperser_DisperseBlob_FullMethodName, &grpcdisperser.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: quorumNumbers(quorums),
+	}, reply)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := disperser.FromBlobStatusProto(reply.GetResult())
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, reply.GetRequestId(), nil
+}
+
+// DisperseBlobAuthenticated ... disperses through the unauthenticated endpoint, see bridgeClient
+func (c *bridgeClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	return c.DisperseBlob(ctx, data, quorums)
+}
+
+func (c *bridgeClient) GetBlobStatus(ctx context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error) {
+	ctx, cancel := context.WithTimeout(ctx, disperserQueryTimeout)
+	defer cancel()
+
+	reply := &grpcdisperser.BlobStatusReply{}
+	err := c.invoke(ctx, grpcdisperser.Disperser_GetBlobStatus_FullMethodName, &grpcdisperser.BlobStatusRequest{
+		RequestId: requestID,
+	}, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (c *bridgeClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, disperserQueryTimeout)
+	defer cancel()
+
+	reply := &grpcdisperser.RetrieveBlobReply{}
+	err := c.invoke(ctx, grpcdisperser.Disperser_RetrieveBlob_FullMethodName, &grpcdisperser.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       blobIndex,
+	}, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+// invoke ... calls the unary method of the disperser through the bridge, returning gRPC status errors
+func (c *bridgeClient) invoke(ctx context.Context, method string, req, reply proto.Message) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	contentType := "application/proto"
+	if c.protocol == BridgeProtocolGRPCWeb {
+		contentType = "application/grpc-web+proto"
+		body = grpcWebFrame(0, body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+method, bytes.NewReader(body))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	if c.protocol == BridgeProtocolGRPCWeb {
+		httpReq.Header.Set("X-Grpc-Web", "1")
+	} else {
+		httpReq.Header.Set("Connect-Protocol-Version", "1")
+	}
+
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return transportError(ctx, err)
+	}
+	defer resp.Body.Close()
+
+	// bounded like the gRPC receive buffer of blob retrievals
+	payload, err := io.ReadAll(io.LimitReader(resp.Body, maxRetrieveBlobSize+1))
+	if err != nil {
+		return transportError(ctx, err)
+	}
+	if len(payload) > maxRetrieveBlobSize {
+		return status.Errorf(codes.ResourceExhausted, "bridge response larger than %d bytes", maxRetrieveBlobSize)
+	}
+
+	if c.protocol == BridgeProtocolGRPCWeb {
+		payload, err = parseGRPCWebResponse(resp, payload)
+	} else {
+		payload, err = parseConnectResponse(resp, payload)
+	}
+	if err != nil {
+		return err
+	}
+	if err := proto.Unmarshal(payload, reply); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode bridge response: %v", err)
+	}
+	return nil
+}
+
+// transportError ... maps an HTTP transport error to the status gRPC reports for connection failures
+func transportError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// httpStatusCode ... maps the HTTP status of a failed request to a gRPC status code, as gRPC clients do
+func httpStatusCode(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+func grpcWebFrame(flag byte, msg []byte) []byte {
+	frame := make([]byte, 5+len(msg))
+	frame[0] = flag
+	binary.BigEndian.PutUint32(frame[1:5], uint32(len(msg))) // #nosec G115
+	copy(frame[5:], msg)
+	return frame
+}
+
+// parseGRPCWebResponse ... returns the message of a gRPC-web response, or the error status of its trailers
+func parseGRPCWebResponse(resp *http.Response, payload []byte) ([]byte, error) {
+	if resp.StatusCode != http.StatusOK {
+		return nil, status.Errorf(httpStatusCode(resp.StatusCode), "disperser bridge returned http status %d", resp.StatusCode)
+	}
+
+	// trailers-only responses carry the status in the headers
+	trailers := textproto.MIMEHeader(resp.Header)
+	var msg []byte
+	for len(payload) > 0 {
+		if len(payload) < 5 {
+			return nil, status.Error(codes.Internal, "truncated gRPC-web frame")
+		}
+		n := binary.BigEndian.Uint32(payload[1:5])
+		if uint64(len(payload)-5) < uint64(n) {
+			return nil, status.Error(codes.Internal, "truncated gRPC-web frame")
+		}
+		data := payload[5 : 5+n]
+		if payload[0]&grpcWebTrailerFlag != 0 {
+			r := textproto.NewReader(bufio.NewReader(io.MultiReader(bytes.NewReader(data), strings.NewReader("\r\n"))))
+			parsed, err := r.ReadMIMEHeader()
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, status.Errorf(codes.Internal, "invalid gRPC-web trailers: %v", err)
+			}
+			trailers = parsed
+		} else {
+			msg = data
+		}
+		payload = payload[5+n:]
+	}
+
+	code, err := strconv.Atoi(trailers.Get("Grpc-Status"))
+	if err != nil {
+		return nil, status.Error(codes.Internal, "gRPC-web response without grpc-status")
+	}
+	if codes.Code(code) != codes.OK { // #nosec G115
+		message, _ := url.PathUnescape(trailers.Get("Grpc-Message"))
+		return nil, status.Error(codes.Code(code), message) // #nosec G115
+	}
+	return msg, nil
+}
+
+// connectCodes ... gRPC status codes of the Connect protocol's error codes
+var connectCodes = map[string]codes.Code{
+	"canceled":            codes.Canceled,
+	"unknown":             codes.Unknown,
+	"invalid_argument":    codes.InvalidArgument,
+	"deadline_exceeded":   codes.DeadlineExceeded,
+	"not_found":           codes.NotFound,
+	"already_exists":      codes.AlreadyExists,
+	"permission_denied":   codes.PermissionDenied,
+	"resource_exhausted":  codes.ResourceExhausted,
+	"failed_precondition": codes.FailedPrecondition,
+	"aborted":             codes.Aborted,
+	"out_of_range":        codes.OutOfRange,
+	"unimplemented":       codes.Unimplemented,
+	"internal":            codes.Internal,
+	"unavailable":         codes.Unavailable,
+	"data_loss":           codes.DataLoss,
+	"unauthenticated":     codes.Unauthenticated,
+}
+
+// parseConnectResponse ... returns the message of a Connect unary response, or its error status
+func parseConnectResponse(resp *http.Response, payload []byte) ([]byte, error) {
+	if resp.StatusCode == http.StatusOK {
+		return payload, nil
+	}
+
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(payload, &connectErr) == nil {
+		if code, ok := connectCodes[connectErr.Code]; ok {
+			return nil, status.Error(code, connectErr.Message)
+		}
+	}
+	return nil, status.Errorf(httpStatusCode(resp.StatusCode), "disperser bridge returned http status %d", resp.StatusCode)
+}
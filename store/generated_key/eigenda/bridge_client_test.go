@@ -0,0 +1,109 @@
+package eigenda
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// bridgeHandler ... serves DisperseBlob like a bridge in front of a disperser accepting blobs of up to 4 bytes
+func bridgeHandler(t *testing.T, protocol BridgeProtocol) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, grpcdisperser.Disperser_DisperseBlob_FullMethodName, r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if protocol == BridgeProtocolGRPCWeb {
+			require.Equal(t, "application/grpc-web+proto", r.Header.Get("Content-Type"))
+			body = body[5:]
+		}
+
+		var req grpcdisperser.DisperseBlobRequest
+		require.NoError(t, proto.Unmarshal(body, &req))
+
+		if len(req.Data) > 4 {
+			if protocol == BridgeProtocolGRPCWeb {
+				w.Header().Set("Content-Type", "application/grpc-web+proto")
+				_, _ = w.Write(grpcWebFrame(grpcWebTrailerFlag,
+					[]byte("grpc-status: 3\r\ngrpc-message: blob%20size%20cannot%20exceed%204%20Bytes\r\n")))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"invalid_argument","message":"blob size cannot exceed 4 Bytes"}`))
+			return
+		}
+
+		reply, err := proto.Marshal(&grpcdisperser.DisperseBlobReply{
+			Result:    grpcdisperser.BlobStatus_PROCESSING,
+			RequestId: []byte{0x01, 0x02},
+		})
+		require.NoError(t, err)
+		if protocol == BridgeProtocolGRPCWeb {
+			w.Header().Set("Content-Type", "application/grpc-web+proto")
+			_, _ = w.Write(grpcWebFrame(0, reply))
+			_, _ = w.Write(grpcWebFrame(grpcWebTrailerFlag, []byte("grpc-status: 0\r\n")))
+			return
+		}
+		w.Header().Set("Content-Type", "application/proto")
+		_, _ = w.Write(reply)
+	}
+}
+
+func TestBridgeDisperserClient(t *testing.T) {
+	for _, protocol := range []BridgeProtocol{BridgeProtocolGRPCWeb, BridgeProtocolConnect} {
+		t.Run(string(protocol), func(t *testing.T) {
+			srv := httptest.NewServer(bridgeHandler(t, protocol))
+			defer srv.Close()
+
+			c, err := NewBridgeDisperserClient(clients.EigenDAClientConfig{ResponseTimeout: time.Second},
+				BridgeConfig{URL: srv.URL, Protocol: protocol}, nil)
+			require.NoError(t, err)
+
+			s, requestID, err := c.DisperseBlobAuthenticated(context.Background(), []byte{0, 1, 2, 3}, nil)
+			require.NoError(t, err)
+			require.Equal(t, disperser.Processing, *s)
+			require.Equal(t, []byte{0x01, 0x02}, requestID)
+
+			_, _, err = c.DisperseBlob(context.Background(), make([]byte, 8), nil)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+			size, ok := parseMaxBlobSize(err)
+			require.True(t, ok)
+			require.Equal(t, uint64(4), size)
+		})
+	}
+}
+
+func TestBridgeDisperserClientUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	c, err := NewBridgeDisperserClient(clients.EigenDAClientConfig{}, BridgeConfig{URL: srv.URL, Protocol: BridgeProtocolGRPCWeb}, nil)
+	require.NoError(t, err)
+
+	// bridge errors and transport failures are reported as unavailable, like native gRPC connection failures
+	_, err = c.GetBlobStatus(context.Background(), []byte{0x01})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	srv.Close()
+	_, err = c.GetBlobStatus(context.Background(), []byte{0x01})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Error(t, c.(*bridgeClient).Ping(context.Background()))
+}
+
+func TestParseGRPCWebResponseTruncated(t *testing.T) {
+	frame := grpcWebFrame(0, []byte{0x01, 0x02})
+	binary.BigEndian.PutUint32(frame[1:5], 10)
+	_, err := parseGRPCWebResponse(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, frame)
+	require.Equal(t, codes.Internal, status.Code(err))
+}
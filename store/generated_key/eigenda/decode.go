@@ -0,0 +1,67 @@
+package eigenda
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// decodeBlob ... decodes a blob retrieved from EigenDA with codec, the codec of the EigenDA client, which decodes
+// it in the point verification mode of the client with the encoding version indicated by its header. If that fails
+// and fallback is set, the blob is decoded with the default encoding regardless of the version in its header, both
+// as retrieved and from its FFT, i.e. in either point verification mode, so that blobs written by a client
+// configured with the other mode are still served. Returns whether the blob was decoded by the fallback.
+func decodeBlob(codec codecs.BlobCodec, encoded []byte, fallback bool, l log.Logger) ([]byte, bool, error) {
+	decoded, err := codec.DecodeBlob(encoded)
+	if err == nil || !fallback {
+		return decoded, false, err
+	}
+
+	c, verr := codecs.BlobEncodingVersionToCodec(codecs.DefaultBlobEncoding)
+	if verr != nil {
+		return nil, false, err
+	}
+	evaluations, ferr := codecs.FFT(encoded)
+	if ferr != nil {
+		evaluations = nil
+	}
+	for _, candidate := range []struct {
+		data              []byte
+		pointVerification bool
+	}{{evaluations, true}, {encoded, false}} {
+		if candidate.data == nil {
+			continue
+		}
+		decoded, derr := decodeBlobHeaderless(c, candidate.data)
+		if derr != nil {
+			continue
+		}
+		l.Warn("Decoded blob in the fallback point verification mode", "point_verification",
+			candidate.pointVerification, "header_version", headerVersion(candidate.data), "err", err)
+		return decoded, true, nil
+	}
+	return nil, false, err
+}
+
+// decodeBlobHeaderless ... decodes data with c regardless of the encoding version in its header. The first byte
+// of the header is zero for every encoding, which rules out most data decoded in the wrong point verification
+// mode.
+func decodeBlobHeaderless(c codecs.BlobCodec, data []byte) ([]byte, error) {
+	if len(data) < 32 {
+		return nil, fmt.Errorf("blob does not contain 32 header bytes")
+	}
+	if data[0] != 0 {
+		return nil, errors.New("blob header doesn't start with a zero byte")
+	}
+	return c.DecodeBlob(data)
+}
+
+// headerVersion ... encoding version in the header of an encoded blob
+func headerVersion(data []byte) int {
+	if len(data) < 2 {
+		return -1
+	}
+	return int(data[1])
+}
@@ -0,0 +1,51 @@
+package eigenda
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeBlobFallback(t *testing.T) {
+	payload := []byte("payload dispersed by another client")
+	codec := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec())
+
+	encoded, err := codec.EncodeBlob(payload)
+	require.NoError(t, err)
+	decoded, fellBack, err := decodeBlob(codec, encoded, true, log.New())
+	require.NoError(t, err)
+	require.False(t, fellBack)
+	require.Equal(t, payload, decoded)
+
+	// header recording an encoding version unknown to the codec
+	unknownVersion, err := codecs.NewDefaultBlobCodec().EncodeBlob(payload)
+	require.NoError(t, err)
+	unknownVersion[1] = 0x7
+	unknownVersion, err = codecs.IFFT(unknownVersion)
+	require.NoError(t, err)
+
+	// dispersed with point verification mode disabled
+	noIFFT, err := codecs.NewNoIFFTCodec(codecs.NewDefaultBlobCodec()).EncodeBlob(payload)
+	require.NoError(t, err)
+
+	for name, blob := range map[string][]byte{"UnknownVersion": unknownVersion, "NoPointVerification": noIFFT} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := decodeBlob(codec, blob, false, log.New())
+			require.Error(t, err)
+
+			decoded, fellBack, err := decodeBlob(codec, blob, true, log.New())
+			require.NoError(t, err)
+			require.True(t, fellBack)
+			require.Equal(t, payload, decoded)
+		})
+	}
+
+	garbage := make([]byte, len(encoded))
+	for i := range garbage {
+		garbage[i] = 0xff
+	}
+	_, _, err = decodeBlob(codec, garbage, true, log.New())
+	require.Error(t, err)
+}
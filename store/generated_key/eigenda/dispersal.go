@@ -0,0 +1,333 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ErrDispersalFailed ... returned when the disperser gave up on a blob, which then has to be dispersed again
+var ErrDispersalFailed = errors.New("EigenDA blob dispersal failed in processing")
+
+// disperse returns the blob info of a payload. If an earlier dispersal of the same payload timed out, polling
+// its status is resumed instead of dispersing the payload again. The disperser request ID is journaled once the
+// disperser accepted the blob, ahead of polling its status, and kept when polling times out so that the
+// dispersal can be recovered later, even if the proxy stops meanwhile.
+func (e *Store) disperse(ctx context.Context, value, encodedBlob []byte) (*grpcdisperser.BlobInfo, error) {
+	client, backend, own := e.dispersalClient(ctx)
+	id := DispersalID(value)
+	if own {
+		// the same payload dispersed by another tenant was dispersed with another config
+		id = TenantDispersalID(store.TenantFromContext(ctx), value)
+	}
+	if p, err := e.pending.get(id); err == nil {
+		info, err := e.resumePending(ctx, p)
+		if !errors.Is(err, ErrDispersalFailed) {
+			return info, err
+		}
+		store.RequestLogger(ctx, e.log).Warn("Pending dispersal failed, dispersing blob again", "id", id, "err", err)
+	}
+
+	quorums := make([]uint8, len(client.Config.CustomQuorumIDs))
+	for i, q := range client.Config.CustomQuorumIDs {
+		quorums[i] = uint8(q) // #nosec G115
+	}
+
+	for attempt := 1; ; attempt++ {
+		info, err := e.disperseTo(ctx, backend, id, encodedBlob, quorums)
+		after := e.cfg.CustomQuorumFallbackAfter
+		if len(quorums) == 0 || after == 0 || !quorumFailure(ctx, err) {
+			return info, err
+		}
+		if attempt < after {
+			store.RequestLogger(ctx, e.log).Warn("Dispersal to custom quorums failed, retrying", "id", id, "quorums", quorums, "attempt", attempt, "err", err)
+			continue
+		}
+		// keeps the chain live during an outage of a custom quorum, at the cost of the blob's custom quorum security
+		store.RequestLogger(ctx, e.log).Error("Dispersal to custom quorums failed repeatedly, DISPERSING TO THE DEFAULT QUORUMS ONLY", "id", id, "quorums", quorums, "attempts", attempt, "err", err)
+		store.RecordQuorumFallback(ctx)
+		quorums = nil
+	}
+}
+
+// quorumFailure returns whether a dispersal failed in a way an unavailable custom quorum could have caused, as
+// opposed to e.g. a timeout while polling the status of an accepted blob or an oversized blob
+func quorumFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ErrDispersalPending) {
+		return false
+	}
+	_, oversized := parseMaxBlobSize(err)
+	return !oversized
+}
+
+// disperseTo disperses a blob through backend to the default quorums and the given custom quorums, and polls its
+// status
+func (e *Store) disperseTo(ctx context.Context, backend EigenDABackend, id string, encodedBlob []byte,
+	quorums []uint8) (*grpcdisperser.BlobInfo, error) {
+	status, requestID, err := backend.Disperse(ctx, encodedBlob, quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to disperse blob to EigenDA: %w", err)
+	}
+	if *status == disperser.Failed {
+		return nil, fmt.Errorf("%w: disperser returned status %s", ErrDispersalFailed, status.String())
+	}
+	store.RequestLogger(ctx, e.log).Info("Blob dispersed to EigenDA, now waiting for confirmation", "id", id, "dispersal_request_id", hexutil.Encode(requestID))
+
+	if jerr := e.pending.add(id, requestID, DispersalStatusDispersing); jerr != nil {
+		store.RequestLogger(ctx, e.log).Error("Failed to journal dispersal", "id", id, "dispersal_request_id", hexutil.Encode(requestID), "err", jerr)
+	}
+
+	info, err := e.pollStatus(ctx, requestID)
+	if err == nil {
+		store.RecordDispersalRequest(ctx, requestID)
+	}
+	if errors.Is(err, ErrDispersalPending) {
+		if perr := e.pending.add(id, requestID, DispersalStatusPending); perr != nil {
+			store.RequestLogger(ctx, e.log).Error("Failed to persist pending dispersal", "id", id, "dispersal_request_id", hexutil.Encode(requestID), "err", perr)
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w, request id %s saved for recovery as %s", err, hexutil.Encode(requestID), id)
+	}
+	e.forget(id)
+	return info, err
+}
+
+// resumePending returns the cert of a recovered dispersal, or polls the disperser until a pending one
+// completes or times out again
+func (e *Store) resumePending(ctx context.Context, p PendingDispersal) (*grpcdisperser.BlobInfo, error) {
+	var info *grpcdisperser.BlobInfo
+	var err error
+
+	if p.Status == DispersalStatusRecovered {
+		var cert verify.Certificate
+		if err := rlp.DecodeBytes(p.Cert, &cert); err != nil {
+			return nil, fmt.Errorf("failed to decode recovered DA cert: %w", err)
+		}
+		info = (*grpcdisperser.BlobInfo)(&cert)
+	} else {
+		e.log.Info("Resuming pending dispersal", "id", p.ID, "dispersal_request_id", p.RequestID.String())
+		info, err = e.pollStatus(ctx, p.RequestID)
+	}
+
+	switch {
+	case err == nil:
+		if err := e.pending.remove(p.ID); err != nil && !errors.Is(err, ErrDispersalNotFound) {
+			e.log.Error("Failed to remove recovered dispersal", "id", p.ID, "err", err)
+		}
+		store.RecordDispersalRequest(ctx, p.RequestID)
+		return info, nil
+	case errors.Is(err, ErrDispersalFailed):
+		_ = e.pending.remove(p.ID)
+		return nil, err
+	default:
+		e.recordAttempt(p.ID, err)
+		return nil, fmt.Errorf("resumed dispersal %s: %w", p.ID, err)
+	}
+}
+
+// pollStatus waits for a dispersal to complete using the shared status poller, until the status query
+// timeout expires
+func (e *Store) pollStatus(ctx context.Context, requestID []byte) (*grpcdisperser.BlobInfo, error) {
+	client := e.getClient()
+
+	ctx, cancel := context.WithTimeout(ctx, client.Config.StatusQueryTimeout)
+	defer cancel()
+
+	replies, stop := e.poller.watch(requestID)
+	defer stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: timed out waiting for EigenDA to confirm blob: %w", ErrDispersalPending, ctx.Err())
+		case reply := <-replies:
+			if state, ok := dispersalState(reply.Status); ok {
+				store.ReportDispersalState(ctx, state)
+			}
+			info, err := blobInfo(reply, client.Config.WaitForFinalization)
+			if errors.Is(err, ErrDispersalPending) {
+				e.log.Debug("Waiting for EigenDA to confirm blob", "dispersal_request_id", hexutil.Encode(requestID), "status", reply.Status)
+				continue
+			}
+			return info, err
+		}
+	}
+}
+
+// blobInfo returns the blob info of a completed dispersal, or ErrDispersalPending while it is in progress
+func blobInfo(reply *grpcdisperser.BlobStatusReply, waitForFinalization bool) (*grpcdisperser.BlobInfo, error) {
+	switch reply.Status {
+	case grpcdisperser.BlobStatus_PROCESSING, grpcdisperser.BlobStatus_DISPERSING:
+		return nil, ErrDispersalPending
+	case grpcdisperser.BlobStatus_CONFIRMED:
+		if waitForFinalization {
+			return nil, ErrDispersalPending
+		}
+		return reply.Info, nil
+	case grpcdisperser.BlobStatus_FINALIZED:
+		return reply.Info, nil
+	case grpcdisperser.BlobStatus_FAILED, grpcdisperser.BlobStatus_INSUFFICIENT_SIGNATURES:
+		return nil, fmt.Errorf("%w with status %s", ErrDispersalFailed, reply.Status)
+	default:
+		return nil, fmt.Errorf("%w with unexpected status %s", ErrDispersalFailed, reply.Status)
+	}
+}
+
+// dispersalState maps the status of a dispersal to the progress reported to the request waiting for it, false if
+// the dispersal failed
+func dispersalState(status grpcdisperser.BlobStatus) (store.DispersalState, bool) {
+	switch status {
+	case grpcdisperser.BlobStatus_PROCESSING, grpcdisperser.BlobStatus_DISPERSING:
+		return store.DispersalDispersing, true
+	case grpcdisperser.BlobStatus_CONFIRMED:
+		return store.DispersalConfirmed, true
+	case grpcdisperser.BlobStatus_FINALIZED:
+		return store.DispersalFinalized, true
+	default:
+		return "", false
+	}
+}
+
+// recordAttempt records a resumed attempt of a pending dispersal that didn't complete
+func (e *Store) recordAttempt(id string, err error) {
+	uerr := e.pending.update(id, func(d *PendingDispersal) {
+		d.Attempts++
+		d.LastError = err.Error()
+	})
+	if uerr != nil {
+		e.log.Error("Failed to update pending dispersal", "id", id, "err", uerr)
+	}
+}
+
+// PendingDispersals returns the dispersals whose status polling timed out, oldest first
+func (e *Store) PendingDispersals() []PendingDispersal {
+	return e.pending.list()
+}
+
+// RestorePendingDispersals adds dispersals, e.g. from a snapshot of another proxy, so that they are resumed
+// by this proxy
+func (e *Store) RestorePendingDispersals(dispersals []PendingDispersal) error {
+	return e.pending.restore(dispersals)
+}
+
+// ResumeDispersal queries the disperser once for the status of a pending dispersal. Once confirmed, the
+// dispersal is marked as recovered and its cert is returned by the next Put of the same payload. Failed
+// dispersals are forgotten, so that the payload is dispersed again. Returns ErrDispersalPending if the
+// disperser hasn't confirmed the blob yet.
+func (e *Store) ResumeDispersal(ctx context.Context, id string) error {
+	p, err := e.pending.get(id)
+	if err != nil {
+		return err
+	}
+	if p.Status == DispersalStatusRecovered {
+		return nil
+	}
+
+	client := e.getClient()
+	reply, err := e.poller.query(ctx, p.RequestID)
+	if err != nil {
+		err = fmt.Errorf("failed to retrieve blob dispersal status: %w", err)
+		e.recordAttempt(id, err)
+		return err
+	}
+
+	info, err := blobInfo(reply, client.Config.WaitForFinalization)
+	return e.settle(p, info, err)
+}
+
+// settle records the outcome of a status query of a pending dispersal
+func (e *Store) settle(p PendingDispersal, info *grpcdisperser.BlobInfo, err error) error {
+	id := p.ID
+	switch {
+	case err == nil:
+		cert, err := rlp.EncodeToBytes((*verify.Certificate)(info))
+		if err != nil {
+			return fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
+		}
+		e.log.Info("Recovered pending dispersal", "id", id, "dispersal_request_id", p.RequestID.String())
+		return e.pending.update(id, func(d *PendingDispersal) {
+			d.Attempts++
+			d.Status = DispersalStatusRecovered
+			d.LastError = ""
+			d.Cert = cert
+		})
+	case errors.Is(err, ErrDispersalFailed):
+		if rerr := e.pending.remove(id); rerr != nil {
+			return rerr
+		}
+		return err
+	default:
+		e.recordAttempt(id, err)
+		return err
+	}
+}
+
+// RecoverDispersals polls the status of every pending dispersal, e.g. of those journaled by a proxy that stopped
+// mid-dispersal, until they complete or the status query timeout expires. Confirmed dispersals are marked as
+// recovered, and their cert is returned by the next Put of the same payload.
+func (e *Store) RecoverDispersals(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, p := range e.pending.list() {
+		if p.Status != DispersalStatusPending {
+			continue
+		}
+		wg.Add(1)
+		go func(p PendingDispersal) {
+			defer wg.Done()
+			e.log.Info("Resuming status polling of journaled dispersal", "id", p.ID, "dispersal_request_id", p.RequestID.String())
+			info, err := e.pollStatus(ctx, p.RequestID)
+			if err := e.settle(p, info, err); err != nil && !errors.Is(err, ErrDispersalPending) {
+				e.log.Warn("Failed to recover journaled dispersal", "id", p.ID, "err", err)
+			}
+		}(p)
+	}
+	wg.Wait()
+}
+
+// forget drops a dispersal that completed from the journal
+func (e *Store) forget(id string) {
+	if err := e.pending.remove(id); err != nil && !errors.Is(err, ErrDispersalNotFound) {
+		e.log.Error("Failed to remove dispersal from the journal", "id", id, "err", err)
+	}
+}
+
+// RetryPendingDispersals resumes every pending dispersal each interval, until ctx is done. Dispersals older than
+// the configured PendingDispersalsMaxAge are dropped beforehand.
+func (e *Store) RetryPendingDispersals(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if maxAge := e.cfg.PendingDispersalsMaxAge; maxAge > 0 {
+				dropped, err := e.pending.compact(time.Now().Add(-maxAge))
+				if err != nil {
+					e.log.Warn("Failed to drop expired pending dispersals", "err", err)
+				} else if dropped > 0 {
+					e.log.Info("Dropped expired pending dispersals", "dropped", dropped, "max age", maxAge)
+				}
+			}
+			for _, p := range e.pending.list() {
+				if p.Status != DispersalStatusPending {
+					continue
+				}
+				err := e.ResumeDispersal(ctx, p.ID)
+				if err != nil && !errors.Is(err, ErrDispersalPending) {
+					e.log.Warn("Failed to resume pending dispersal", "id", p.ID, "dispersal_request_id", p.RequestID.String(), "err", err)
+				}
+			}
+		}
+	}
+}
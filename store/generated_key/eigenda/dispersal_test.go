@@ -0,0 +1,382 @@
+package eigenda
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/grpc/common"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDisperser ... disperser client whose blob status is controlled by the test
+type fakeDisperser struct {
+	mu         sync.Mutex
+	status     grpcdisperser.BlobStatus
+	dispersals int
+	queries    int
+	// customQuorumsDown fails dispersals to custom quorums
+	customQuorumsDown bool
+	quorums           [][]uint8
+}
+
+func (f *fakeDisperser) queryCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.queries
+}
+
+func (f *fakeDisperser) setStatus(s grpcdisperser.BlobStatus) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status = s
+}
+
+func (f *fakeDisperser) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	return f.DisperseBlobAuthenticated(ctx, data, quorums)
+}
+
+func (f *fakeDisperser) DisperseBlobAuthenticated(_ context.Context, _ []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dispersals++
+	f.quorums = append(f.quorums, quorums)
+	status := disperser.Processing
+	if f.customQuorumsDown && len(quorums) > 0 {
+		status = disperser.Failed
+	}
+	return &status, []byte{0x01, 0x02, 0x03}, nil
+}
+
+func (f *fakeDisperser) GetBlobStatus(_ context.Context, _ []byte) (*grpcdisperser.BlobStatusReply, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries++
+	return &grpcdisperser.BlobStatusReply{
+		Status: f.status,
+		Info: &grpcdisperser.BlobInfo{
+			BlobHeader: &grpcdisperser.BlobHeader{
+				Commitment: &common.G1Commitment{X: []byte{0x01}, Y: []byte{0x02}},
+				DataLength: 1,
+			},
+			BlobVerificationProof: &grpcdisperser.BlobVerificationProof{
+				BatchMetadata: &grpcdisperser.BatchMetadata{BatchHeader: &grpcdisperser.BatchHeader{}},
+				BlobIndex:     7,
+			},
+		},
+	}, nil
+}
+
+func (f *fakeDisperser) RetrieveBlob(_ context.Context, _ []byte, _ uint32) ([]byte, error) {
+	return nil, nil
+}
+
+func newTestStore(t *testing.T, path string, d *fakeDisperser) *Store {
+	client := &clients.EigenDAClient{
+		Config: clients.EigenDAClientConfig{
+			StatusQueryRetryInterval: 10 * time.Millisecond,
+			StatusQueryTimeout:       50 * time.Millisecond,
+		},
+		Log:    log.New(),
+		Client: d,
+		Codec:  codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
+	}
+	s, err := NewStore(client, nil, log.New(), &StoreConfig{PendingDispersalsPath: path})
+	require.NoError(t, err)
+	return s
+}
+
+func TestPendingDispersalRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending-dispersals.json")
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, path, d)
+
+	value := []byte("stuck blob")
+	id := DispersalID(value)
+
+	// polling times out, the request id is persisted
+	_, err := s.disperse(context.Background(), value, value)
+	require.ErrorIs(t, err, ErrDispersalPending)
+	pending := s.PendingDispersals()
+	require.Len(t, pending, 1)
+	require.Equal(t, id, pending[0].ID)
+	require.Equal(t, DispersalStatusPending, pending[0].Status)
+
+	// pending dispersals survive a restart
+	s = newTestStore(t, path, d)
+	require.ErrorIs(t, s.ResumeDispersal(context.Background(), id), ErrDispersalPending)
+	pending = s.PendingDispersals()
+	require.Len(t, pending, 1)
+	require.Equal(t, 1, pending[0].Attempts)
+
+	d.setStatus(grpcdisperser.BlobStatus_CONFIRMED)
+	require.NoError(t, s.ResumeDispersal(context.Background(), id))
+	pending = s.PendingDispersals()
+	require.Len(t, pending, 1)
+	require.Equal(t, DispersalStatusRecovered, pending[0].Status)
+	require.NotEmpty(t, pending[0].Cert)
+
+	// putting the same payload again returns the recovered cert without dispersing it again
+	info, err := s.disperse(context.Background(), value, value)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), info.GetBlobVerificationProof().GetBlobIndex())
+	require.Equal(t, 1, d.dispersals)
+	require.Empty(t, s.PendingDispersals())
+
+	require.ErrorIs(t, s.ResumeDispersal(context.Background(), id), ErrDispersalNotFound)
+}
+
+func TestPendingDispersalFailed(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, "", d)
+
+	value := []byte("failed blob")
+	_, err := s.disperse(context.Background(), value, value)
+	require.ErrorIs(t, err, ErrDispersalPending)
+
+	// failed dispersals are forgotten, so that the next put disperses the payload again
+	d.setStatus(grpcdisperser.BlobStatus_FAILED)
+	require.ErrorIs(t, s.ResumeDispersal(context.Background(), DispersalID(value)), ErrDispersalFailed)
+	require.Empty(t, s.PendingDispersals())
+
+	d.setStatus(grpcdisperser.BlobStatus_FINALIZED)
+	_, err = s.disperse(context.Background(), value, value)
+	require.NoError(t, err)
+	require.Equal(t, 2, d.dispersals)
+}
+
+func TestRetryPendingDispersals(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, "", d)
+
+	_, err := s.disperse(context.Background(), []byte("blob"), []byte("blob"))
+	require.ErrorIs(t, err, ErrDispersalPending)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.RetryPendingDispersals(ctx, 10*time.Millisecond)
+
+	d.setStatus(grpcdisperser.BlobStatus_CONFIRMED)
+	require.Eventually(t, func() bool {
+		pending := s.PendingDispersals()
+		return len(pending) == 1 && pending[0].Status == DispersalStatusRecovered
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCompactPendingDispersals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pending-dispersals.json")
+	p, err := newPendingDispersals(&fileJournal{path: path})
+	require.NoError(t, err)
+
+	require.NoError(t, p.add("old", []byte{0x01}, DispersalStatusPending))
+	require.NoError(t, p.update("old", func(d *PendingDispersal) {
+		d.CreatedAt = time.Now().Add(-time.Hour)
+		d.Status = DispersalStatusRecovered
+	}))
+	require.NoError(t, p.add("new", []byte{0x02}, DispersalStatusPending))
+
+	dropped, err := p.compact(time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, dropped)
+
+	// the compacted journal is persisted
+	reloaded, err := newPendingDispersals(&fileJournal{path: path})
+	require.NoError(t, err)
+	dispersals := reloaded.list()
+	require.Len(t, dispersals, 1)
+	require.Equal(t, "new", dispersals[0].ID)
+}
+
+// mapHashStore ... in-memory HashStore
+type mapHashStore struct {
+	mu     sync.Mutex
+	hashes map[string]map[string][]byte
+}
+
+func (m *mapHashStore) HashSet(_ context.Context, key, field string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.hashes[key] == nil {
+		m.hashes[key] = make(map[string][]byte)
+	}
+	m.hashes[key][field] = value
+	return nil
+}
+
+func (m *mapHashStore) HashDelete(_ context.Context, key, field string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.hashes[key], field)
+	return nil
+}
+
+func (m *mapHashStore) HashGetAll(_ context.Context, key string) (map[string][]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fields := make(map[string][]byte, len(m.hashes[key]))
+	for f, v := range m.hashes[key] {
+		fields[f] = v
+	}
+	return fields, nil
+}
+
+func TestDispersalJournal(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	newStore := func(hashes *mapHashStore) *Store {
+		client := &clients.EigenDAClient{
+			Config: clients.EigenDAClientConfig{
+				StatusQueryRetryInterval: 10 * time.Millisecond,
+				StatusQueryTimeout:       time.Second,
+			},
+			Log:    log.New(),
+			Client: d,
+			Codec:  codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
+		}
+		s, err := NewStore(client, nil, log.New(),
+			&StoreConfig{PendingDispersalsRedisKey: "pending", PendingDispersalsRedis: hashes})
+		require.NoError(t, err)
+		return s
+	}
+	hashes := &mapHashStore{hashes: make(map[string]map[string][]byte)}
+	s := newStore(hashes)
+
+	value := []byte("blob of a proxy stopping mid-dispersal")
+	id := DispersalID(value)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = s.disperse(ctx, value, value)
+	}()
+
+	// the dispersal is journaled while its status is polled
+	var journaled map[string][]byte
+	require.Eventually(t, func() bool {
+		journaled, _ = hashes.HashGetAll(context.Background(), "pending")
+		return len(journaled) == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, DispersalStatusDispersing, s.PendingDispersals()[0].Status)
+	cancel()
+	<-done
+
+	// a proxy restarting from the journal resumes polling the dispersal
+	restarted := newStore(&mapHashStore{hashes: map[string]map[string][]byte{"pending": journaled}})
+	pending := restarted.PendingDispersals()
+	require.Len(t, pending, 1)
+	require.Equal(t, id, pending[0].ID)
+	require.Equal(t, DispersalStatusPending, pending[0].Status)
+
+	d.setStatus(grpcdisperser.BlobStatus_CONFIRMED)
+	restarted.RecoverDispersals(context.Background())
+	pending = restarted.PendingDispersals()
+	require.Len(t, pending, 1)
+	require.Equal(t, DispersalStatusRecovered, pending[0].Status)
+
+	// completed dispersals are dropped from the journal
+	_, err := s.disperse(context.Background(), []byte("confirmed blob"), []byte("confirmed blob"))
+	require.NoError(t, err)
+	journaled, _ = hashes.HashGetAll(context.Background(), "pending")
+	require.Len(t, journaled, 1)
+	require.Contains(t, journaled, id)
+}
+
+func TestCustomQuorumFallback(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_CONFIRMED, customQuorumsDown: true}
+	newStore := func(after int) *Store {
+		s := newTestStore(t, "", d)
+		s.getClient().Config.CustomQuorumIDs = []uint{2}
+		s.cfg.CustomQuorumFallbackAfter = after
+		return s
+	}
+
+	// without fallback, the dispersal fails
+	_, err := newStore(0).disperse(context.Background(), []byte("blob"), []byte("blob"))
+	require.ErrorIs(t, err, ErrDispersalFailed)
+	require.Equal(t, [][]uint8{{2}}, d.quorums)
+
+	// with fallback, the blob is dispersed to the default quorums after the custom quorums failed twice
+	d.quorums = nil
+	ctx, meta := store.WithResponseMeta(context.Background())
+	info, err := newStore(2).disperse(ctx, []byte("blob"), []byte("blob"))
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	require.Equal(t, [][]uint8{{2}, {2}, nil}, d.quorums)
+	require.True(t, meta.QuorumFallback())
+
+	// dispersals to healthy custom quorums don't fall back
+	d.quorums, d.customQuorumsDown = nil, false
+	ctx, meta = store.WithResponseMeta(context.Background())
+	_, err = newStore(2).disperse(ctx, []byte("other blob"), []byte("other blob"))
+	require.NoError(t, err)
+	require.Equal(t, [][]uint8{{2}}, d.quorums)
+	require.False(t, meta.QuorumFallback())
+}
+
+func TestTenantDispersal(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, "", d)
+	td := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	tenantClient := *s.getClient()
+	tenantClient.Config.CustomQuorumIDs = []uint{4}
+	tenantClient.Client = td
+	s.SetTenantClient("rollup-a", &tenantClient)
+
+	// blobs of the tenant are dispersed through its client, to its quorums
+	value := []byte("blob")
+	_, err := s.disperse(store.WithTenant(context.Background(), "rollup-a"), value, value)
+	require.ErrorIs(t, err, ErrDispersalPending)
+	require.Equal(t, [][]uint8{{4}}, td.quorums)
+	require.Zero(t, d.dispersals)
+
+	// the same payload of other tenants is dispersed separately, through the store's client
+	_, err = s.disperse(store.WithTenant(context.Background(), "rollup-b"), value, value)
+	require.ErrorIs(t, err, ErrDispersalPending)
+	require.Equal(t, [][]uint8{{}}, d.quorums)
+
+	var ids []string
+	for _, p := range s.PendingDispersals() {
+		ids = append(ids, p.ID)
+	}
+	require.ElementsMatch(t, []string{TenantDispersalID("rollup-a", value), DispersalID(value)}, ids)
+}
+
+func TestPrevalidateCommitment(t *testing.T) {
+	v, err := verify.NewVerifier(&verify.Config{
+		KzgConfig: &kzg.KzgConfig{
+			G1Path:          "../../../resources/g1.point",
+			G2PowerOf2Path:  "../../../resources/g2.point.powerOf2",
+			CacheDir:        "../../../resources/SRSTables",
+			SRSOrder:        3000,
+			SRSNumberToLoad: 3000,
+			NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		},
+	}, log.New())
+	require.NoError(t, err)
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, "", d)
+	s.verifier = v
+	s.cfg.PrevalidateCommitment = true
+	s.maxBlobSize.Store(1 << 20)
+
+	// a blob exceeding the loaded srs points is rejected before it's paid for
+	_, err = s.Put(context.Background(), make([]byte, 4000*32))
+	require.ErrorContains(t, err, "before dispersal")
+	require.Zero(t, d.dispersals)
+
+	// without pre-validation, it is dispersed
+	s.cfg.PrevalidateCommitment = false
+	_, err = s.Put(context.Background(), make([]byte, 4000*32))
+	require.Error(t, err)
+	require.Equal(t, 1, d.dispersals)
+}
@@ -0,0 +1,327 @@
+package eigenda
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// disperserQueryTimeout ... timeout of status and retrieval queries, matches the EigenDA client's
+	disperserQueryTimeout = 60 * time.Second
+	// maxRetrieveBlobSize ... gRPC receive buffer of blob retrievals, matches the EigenDA client's
+	maxRetrieveBlobSize = 100 * 1024 * 1024
+)
+
+// disperserClient ... disperser client connecting with a custom TLS config, e.g. to trust a private CA or present
+// a client certificate, or over a pooled connection. The EigenDA client (v0.8.4) builds its dial options itself,
+// always connecting with the default TLS config and dialing a new connection per request, and offers no way to
+// pass others, so the four calls of its disperser client are made here over the configured connection. Clients
+// connecting like the EigenDA client are built by it instead, see NewDisperserClient.
+type disperserClient struct {
+	addr    string
+	timeout time.Duration
+	signer  core.BlobRequestSigner
+	creds   credentials.TransportCredentials
+
+	// pooled clients share a single connection across requests, see NewPooledDisperserClient
+	pooled bool
+	connMu sync.Mutex
+	conn   *grpc.ClientConn
+}
+
+var _ clients.DisperserClient = (*disperserClient)(nil)
+
+// NewDisperserClient ... builds a disperser client for the configured disperser connecting with tlsCfg, to
+// replace the client built by the EigenDA client. The EigenDA client's own disperser client, connecting with the
+// default TLS config or no TLS, as configured, is returned if tlsCfg is nil.
+func NewDisperserClient(cfg clients.EigenDAClientConfig, tlsCfg *tls.Config) (clients.DisperserClient, error) {
+	signer, err := newBlobRequestSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg == nil {
+		host, port, err := net.SplitHostPort(cfg.RPC)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EigenDA RPC: %w", err)
+		}
+		return clients.NewDisperserClient(clients.NewConfig(host, port, cfg.ResponseTimeout, !cfg.DisableTLS), signer), nil
+	}
+	return &disperserClient{
+		addr:    cfg.RPC,
+		timeout: cfg.ResponseTimeout,
+		signer:  signer,
+		creds:   credentials.NewTLS(tlsCfg),
+	}, nil
+}
+
+// NewPooledDisperserClient ... builds a disperser client keeping a single connection open across requests,
+// so that requests after idle periods don't pay for connection setup. The connection uses tlsCfg if set, and
+// the default TLS config or no TLS, as configured, otherwise.
+func NewPooledDisperserClient(cfg clients.EigenDAClientConfig, tlsCfg *tls.Config) (clients.DisperserClient, error) {
+	signer, err := newBlobRequestSigner(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg == nil {
+		tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	creds := credentials.NewTLS(tlsCfg)
+	if cfg.DisableTLS {
+		creds = insecure.NewCredentials()
+	}
+	return &disperserClient{
+		addr:    cfg.RPC,
+		timeout: cfg.ResponseTimeout,
+		signer:  signer,
+		creds:   creds,
+		pooled:  true,
+	}, nil
+}
+
+// newBlobRequestSigner ... signer of authenticated dispersals, like the EigenDA client's
+func newBlobRequestSigner(cfg clients.EigenDAClientConfig) (core.BlobRequestSigner, error) {
+	switch len(cfg.SignerPrivateKeyHex) {
+	case 64:
+		return auth.NewLocalBlobRequestSigner(cfg.SignerPrivateKeyHex), nil
+	case 0:
+		return auth.NewLocalNoopSigner(), nil
+	default:
+		return nil, fmt.Errorf("invalid length for signer private key")
+	}
+}
+
+func (c *disperserClient) dial() (*grpc.ClientConn, error) {
+	return grpc.Dial(c.addr, grpc.WithTransportCredentials(c.creds))
+}
+
+// connect ... returns the connection to use for a request and the function releasing it once the request is
+// done. Pooled clients share a connection, which is dialed on first use.
+func (c *disperserClient) connect() (*grpc.ClientConn, func(), error) {
+	if !c.pooled {
+		conn, err := c.dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		return conn, func() { _ = conn.Close() }, nil
+	}
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.conn == nil {
+		conn, err := c.dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		c.conn = conn
+	}
+	return c.conn, func() {}, nil
+}
+
+// do ... runs call against the disperser on a connection, bounded by timeout
+func (c *disperserClient) do(ctx context.Context, timeout time.Duration,
+	call func(ctx context.Context, client grpcdisperser.DisperserClient) error) error {
+	conn, release, err := c.connect()
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return call(ctx, grpcdisperser.NewDisperserClient(conn))
+}
+
+// Ping ... checks that the disperser answers on the client's connection by querying the status of an empty
+// request ID. Any reply, including the error status the disperser answers the invalid request with, shows
+// that the connection is alive.
+func (c *disperserClient) Ping(ctx context.Context) error {
+	err := c.do(ctx, disperserQueryTimeout, func(ctx context.Context, client grpcdisperser.DisperserClient) error {
+		_, err := client.GetBlobStatus(ctx, &grpcdisperser.BlobStatusRequest{})
+		return err
+	})
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return fmt.Errorf("failed to ping disperser: %w", err)
+	default:
+		return nil
+	}
+}
+
+// Reconnect ... replaces the pooled connection with a new one, e.g. after it was silently dropped by a NAT
+// gateway. No-op for clients dialing per request.
+func (c *disperserClient) Reconnect(_ context.Context) error {
+	if !c.pooled {
+		return nil
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return err
+	}
+	conn.Connect()
+
+	c.connMu.Lock()
+	old := c.conn
+	c.conn = conn
+	c.connMu.Unlock()
+
+	if old == nil {
+		return nil
+	}
+	return old.Close()
+}
+
+func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	if err := checkFieldElements(data); err != nil {
+		return nil, nil, err
+	}
+
+	var reply *grpcdisperser.DisperseBlobReply
+	err := c.do(ctx, c.timeout, func(ctx context.Context, client grpcdisperser.DisperserClient) (err error) {
+		reply, err = client.DisperseBlob(ctx, &grpcdisperser.DisperseBlobRequest{
+			Data:                data,
+			CustomQuorumNumbers: quorumNumbers(quorums),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := disperser.FromBlobStatusProto(reply.GetResult())
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, reply.GetRequestId(), nil
+}
+
+func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	accountID, err := c.signer.GetAccountID()
+	if err != nil {
+		return nil, nil, fmt.Errorf("please configure signer key if you want to use authenticated endpoint %w", err)
+	}
+	if err := checkFieldElements(data); err != nil {
+		return nil, nil, err
+	}
+
+	var reply *grpcdisperser.DisperseBlobReply
+	err = c.do(ctx, c.timeout, func(ctx context.Context, client grpcdisperser.DisperserClient) error {
+		stream, err := client.DisperseBlobAuthenticated(ctx)
+		if err != nil {
+			return fmt.Errorf("error while calling DisperseBlobAuthenticated: %w", err)
+		}
+
+		err = stream.Send(&grpcdisperser.AuthenticatedRequest{Payload: &grpcdisperser.AuthenticatedRequest_DisperseRequest{
+			DisperseRequest: &grpcdisperser.DisperseBlobRequest{
+				Data:                data,
+				CustomQuorumNumbers: quorumNumbers(quorums),
+				AccountId:           accountID,
+			},
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to send request: %w", err)
+		}
+
+		// the disperser challenges the request with a nonce to be signed
+		challenge, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("error while receiving: %w", err)
+		}
+		header, ok := challenge.Payload.(*grpcdisperser.AuthenticatedReply_BlobAuthHeader)
+		if !ok {
+			return errors.New("expected challenge")
+		}
+		authData, err := c.signer.SignBlobRequest(core.BlobAuthHeader{
+			BlobCommitments: encoding.BlobCommitments{},
+			Nonce:           header.BlobAuthHeader.ChallengeParameter,
+		})
+		if err != nil {
+			return errors.New("error signing blob request")
+		}
+
+		err = stream.Send(&grpcdisperser.AuthenticatedRequest{Payload: &grpcdisperser.AuthenticatedRequest_AuthenticationData{
+			AuthenticationData: &grpcdisperser.AuthenticationData{AuthenticationData: authData},
+		}})
+		if err != nil {
+			return fmt.Errorf("failed to send challenge reply: %w", err)
+		}
+
+		final, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("error while receiving final reply: %w", err)
+		}
+		disperseReply, ok := final.Payload.(*grpcdisperser.AuthenticatedReply_DisperseReply)
+		if !ok {
+			return errors.New("expected DisperseReply")
+		}
+		reply = disperseReply.DisperseReply
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status, err := disperser.FromBlobStatusProto(reply.GetResult())
+	if err != nil {
+		return nil, nil, err
+	}
+	return status, reply.GetRequestId(), nil
+}
+
+func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (reply *grpcdisperser.BlobStatusReply, err error) {
+	err = c.do(ctx, disperserQueryTimeout, func(ctx context.Context, client grpcdisperser.DisperserClient) error {
+		reply, err = client.GetBlobStatus(ctx, &grpcdisperser.BlobStatusRequest{RequestId: requestID})
+		return err
+	})
+	return reply, err
+}
+
+func (c *disperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	var reply *grpcdisperser.RetrieveBlobReply
+	err := c.do(ctx, disperserQueryTimeout, func(ctx context.Context, client grpcdisperser.DisperserClient) (err error) {
+		reply, err = client.RetrieveBlob(ctx, &grpcdisperser.RetrieveBlobRequest{
+			BatchHeaderHash: batchHeaderHash,
+			BlobIndex:       blobIndex,
+		}, grpc.MaxCallRecvMsgSize(maxRetrieveBlobSize))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Data, nil
+}
+
+// checkFieldElements ... checks that every 32 bytes of data are a valid bn254 field element, like the EigenDA
+// client does before dispersing
+func checkFieldElements(data []byte) error {
+	if _, err := rs.ToFrArray(data); err != nil {
+		return fmt.Errorf("encountered an error to convert a 32-bytes into a valid field element: %w", err)
+	}
+	return nil
+}
+
+func quorumNumbers(quorums []uint8) []uint32 {
+	numbers := make([]uint32, len(quorums))
+	for i, q := range quorums {
+		numbers[i] = uint32(q)
+	}
+	return numbers
+}
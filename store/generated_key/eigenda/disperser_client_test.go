@@ -0,0 +1,115 @@
+package eigenda
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type statusServer struct {
+	grpcdisperser.UnimplementedDisperserServer
+}
+
+func (statusServer) GetBlobStatus(context.Context, *grpcdisperser.BlobStatusRequest) (*grpcdisperser.BlobStatusReply, error) {
+	return &grpcdisperser.BlobStatusReply{Status: grpcdisperser.BlobStatus_CONFIRMED}, nil
+}
+
+func TestDisperserClientTLS(t *testing.T) {
+	// borrow the self-signed certificate of an httptest server, valid for 127.0.0.1
+	https := httptest.NewTLSServer(http.NotFoundHandler())
+	cert, ca := https.TLS.Certificates[0], https.Certificate()
+	https.Close()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+	grpcdisperser.RegisterDisperserServer(srv, statusServer{})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	cfg := clients.EigenDAClientConfig{RPC: lis.Addr().String()}
+
+	t.Run("TrustedCA", func(t *testing.T) {
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+		client, err := NewDisperserClient(cfg, &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots})
+		require.NoError(t, err)
+
+		reply, err := client.GetBlobStatus(context.Background(), []byte{0x01})
+		require.NoError(t, err)
+		require.Equal(t, grpcdisperser.BlobStatus_CONFIRMED, reply.Status)
+	})
+
+	t.Run("UntrustedCA", func(t *testing.T) {
+		client, err := NewDisperserClient(cfg, &tls.Config{MinVersion: tls.VersionTLS12})
+		require.NoError(t, err)
+
+		_, err = client.GetBlobStatus(context.Background(), []byte{0x01})
+		require.Error(t, err)
+	})
+
+	t.Run("Pooled", func(t *testing.T) {
+		roots := x509.NewCertPool()
+		roots.AddCert(ca)
+		client, err := NewPooledDisperserClient(cfg, &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots})
+		require.NoError(t, err)
+		c := client.(*disperserClient)
+
+		require.NoError(t, c.Ping(context.Background()))
+		conn := c.conn
+		reply, err := c.GetBlobStatus(context.Background(), []byte{0x01})
+		require.NoError(t, err)
+		require.Equal(t, grpcdisperser.BlobStatus_CONFIRMED, reply.Status)
+		require.Same(t, conn, c.conn, "requests should reuse the pooled connection")
+
+		require.NoError(t, c.Reconnect(context.Background()))
+		require.NotSame(t, conn, c.conn)
+		require.NoError(t, c.Ping(context.Background()))
+	})
+
+	t.Run("PingUntrustedCA", func(t *testing.T) {
+		client, err := NewPooledDisperserClient(cfg, nil)
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.Error(t, client.(*disperserClient).Ping(ctx))
+	})
+
+	t.Run("DefaultTLS", func(t *testing.T) {
+		// without a custom TLS config, the EigenDA client's own disperser client is used
+		client, err := NewDisperserClient(cfg, nil)
+		require.NoError(t, err)
+		_, ok := client.(*disperserClient)
+		require.False(t, ok)
+
+		_, err = NewDisperserClient(clients.EigenDAClientConfig{RPC: "disperser"}, nil)
+		require.ErrorContains(t, err, "failed to parse EigenDA RPC")
+	})
+
+	t.Run("InvalidFieldElement", func(t *testing.T) {
+		client, err := NewDisperserClient(cfg, &tls.Config{MinVersion: tls.VersionTLS12})
+		require.NoError(t, err)
+		data := make([]byte, 32)
+		for i := range data {
+			data[i] = 0xff
+		}
+		_, _, err = client.DisperseBlob(context.Background(), data, nil)
+		require.ErrorContains(t, err, "valid field element")
+	})
+
+	t.Run("InvalidSignerKey", func(t *testing.T) {
+		_, err := NewDisperserClient(clients.EigenDAClientConfig{SignerPrivateKeyHex: "0x01"}, &tls.Config{MinVersion: tls.VersionTLS12})
+		require.Error(t, err)
+	})
+}
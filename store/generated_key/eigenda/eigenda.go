@@ -4,16 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = tracing.Tracer("store/generated_key/eigenda")
+
 type StoreConfig struct {
+	// configured max blob length, the effective max blob size is lowered if the disperser accepts less
 	MaxBlobSizeBytes uint64
 	// the # of Ethereum blocks to wait after the EigenDA L1BlockReference # before attempting to verify
 	// & accredit a blob
@@ -21,114 +30,399 @@ type StoreConfig struct {
 
 	// total duration time that client waits for blob to confirm
 	StatusQueryTimeout time.Duration
+
+	// compute the kzg commitment before dispersal, see Put
+	PrevalidateCommitment bool
+
+	// number of failed dispersals of a blob to the custom quorums after which it is dispersed to the default
+	// quorums only, never if 0
+	CustomQuorumFallbackAfter int
+
+	// file the dispersals are journaled to once accepted by the disperser, until confirmed, so that they are
+	// recovered after a restart. Kept in memory only if empty.
+	PendingDispersalsPath string
+	// hash of PendingDispersalsRedis the dispersals are journaled to instead of PendingDispersalsPath, if set
+	PendingDispersalsRedisKey string
+	PendingDispersalsRedis    HashStore
+	// age after which dispersals are dropped from the pending dispersals, kept until resolved if 0
+	PendingDispersalsMaxAge time.Duration
+
+	// global cap on the status queries of all in-flight dispersals, unlimited if 0
+	StatusQueryQPS float64
+	// number of concurrent status queries
+	StatusQueryParallelism int
+
+	// EigenDA retriever services blobs are read from, in order, when the disperser fails to serve them
+	RetrieverRPCs []string
+	// reach the retriever services without TLS
+	RetrieverDisableTLS bool
+
+	// decode a retrieved blob failing to decode in the other point verification mode, see decodeBlob
+	PointVerificationModeFallback bool
 }
 
 // Store does storage interactions and verifications for blobs with DA.
 type Store struct {
+	clientMu sync.RWMutex
+	// client provides the dispersal config and blob codec, blobs are dispersed and retrieved through backend
 	client   *clients.EigenDAClient
+	backend  EigenDABackend
 	verifier *verify.Verifier
 	cfg      *StoreConfig
 	log      log.Logger
+	pending  *pendingDispersals
+	poller   *statusPoller
+	// retrievers read blobs from the operator nodes when the disperser fails to serve them
+	retrievers []*retrieverClient
+	// effective max blob size, see NegotiateMaxBlobSize
+	maxBlobSize atomic.Uint64
+	// clients dispersing the blobs of tenants with their own dispersal config, see SetTenantClient
+	tenantClients map[string]tenantClient
 }
 
-var _ store.GeneratedKeyStore = (*Store)(nil)
+// tenantClient ... EigenDA client and backend dispersing the blobs of a tenant
+type tenantClient struct {
+	client  *clients.EigenDAClient
+	backend EigenDABackend
+}
+
+var (
+	_ store.GeneratedKeyStore   = (*Store)(nil)
+	_ store.Pinger              = (*Store)(nil)
+	_ store.Reconnector         = (*Store)(nil)
+	_ store.NetworkInfoReporter = (*Store)(nil)
+)
 
 func NewStore(client *clients.EigenDAClient,
 	v *verify.Verifier, log log.Logger, cfg *StoreConfig) (*Store, error) {
-	return &Store{
+	pending, err := newPendingDispersals(newJournal(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Store{
 		client:   client,
+		backend:  NewClientBackend(client.Client),
 		verifier: v,
 		log:      log,
 		cfg:      cfg,
-	}, nil
+		pending:  pending,
+	}
+	for _, rpc := range cfg.RetrieverRPCs {
+		r, err := newRetrieverClient(rpc, nil, cfg.RetrieverDisableTLS)
+		if err != nil {
+			return nil, err
+		}
+		e.retrievers = append(e.retrievers, r)
+	}
+	e.maxBlobSize.Store(cfg.MaxBlobSizeBytes)
+	e.poller = newStatusPoller(client.Config.StatusQueryRetryInterval, cfg.StatusQueryTimeout, cfg.StatusQueryQPS,
+		cfg.StatusQueryParallelism, e.getBackend, log)
+	return e, nil
+}
+
+// SetClient swaps the underlying EigenDA client, e.g. after the signer private key has been rotated, and
+// disperses and retrieves blobs through its disperser client from then on. Requests that are already in flight
+// keep using the previous client.
+func (e *Store) SetClient(client *clients.EigenDAClient) {
+	e.clientMu.Lock()
+	defer e.clientMu.Unlock()
+	e.client = client
+	e.backend = NewClientBackend(client.Client)
+}
+
+// SetTenantClient ... disperses the blobs written by tenant (see store.TenantFromContext) through client, e.g. to
+// sign them with the tenant's key or disperse them to the tenant's custom quorums. The tenant of the context must
+// be authenticated, see server.WithNamespaces. Blobs of other tenants are
+// dispersed through the store's client. Blobs are retrieved and dispersals polled through the store's client
+// regardless of their tenant.
+func (e *Store) SetTenantClient(tenant string, client *clients.EigenDAClient) {
+	e.clientMu.Lock()
+	defer e.clientMu.Unlock()
+	if e.tenantClients == nil {
+		e.tenantClients = make(map[string]tenantClient)
+	}
+	e.tenantClients[tenant] = tenantClient{client: client, backend: NewClientBackend(client.Client)}
+}
+
+// dispersalClient ... returns the client and backend dispersing the blobs of the tenant of ctx, and whether they
+// are the tenant's own
+func (e *Store) dispersalClient(ctx context.Context) (*clients.EigenDAClient, EigenDABackend, bool) {
+	e.clientMu.RLock()
+	defer e.clientMu.RUnlock()
+	if tc, ok := e.tenantClients[store.TenantFromContext(ctx)]; ok {
+		return tc.client, tc.backend, true
+	}
+	return e.client, e.backend, false
+}
+
+// SetBackend swaps the backend blobs are dispersed and retrieved through, e.g. for a simulator. The dispersal
+// config and blob codec of the EigenDA client still apply.
+func (e *Store) SetBackend(backend EigenDABackend) {
+	e.clientMu.Lock()
+	defer e.clientMu.Unlock()
+	e.backend = backend
+}
+
+func (e *Store) getClient() *clients.EigenDAClient {
+	e.clientMu.RLock()
+	defer e.clientMu.RUnlock()
+	return e.client
+}
+
+func (e *Store) getBackend() EigenDABackend {
+	e.clientMu.RLock()
+	defer e.clientMu.RUnlock()
+	return e.backend
+}
+
+// Ping checks the connections to the disperser, if pooled, and to the ETH RPC node used for cert verification,
+// if enabled.
+func (e *Store) Ping(ctx context.Context) error {
+	var errs []error
+	if p, ok := e.getBackend().(store.Pinger); ok {
+		errs = append(errs, p.Ping(ctx))
+	}
+	errs = append(errs, e.verifier.Ping(ctx))
+	return errors.Join(errs...)
+}
+
+// Reconnect replaces the pooled disperser connection, if any.
+func (e *Store) Reconnect(ctx context.Context) error {
+	if r, ok := e.getBackend().(store.Reconnector); ok {
+		return r.Reconnect(ctx)
+	}
+	return nil
 }
 
 // Get fetches a blob from DA using certificate fields and verifies blob
 // against commitment to ensure data is valid and non-tampered.
-func (e Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+func (e *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 	var cert verify.Certificate
 	err := rlp.DecodeBytes(key, &cert)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
 
-	decodedBlob, err := e.client.GetBlob(ctx, cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash, cert.BlobVerificationProof.BlobIndex)
+	retrieveCtx, span := tracer.Start(ctx, "EigenDA retrieve", trace.WithAttributes(
+		attribute.Int64("blob.index", int64(cert.BlobVerificationProof.BlobIndex))))
+	encodedBlob, err := e.retrieve(retrieveCtx, &cert)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedBlob, fellBack, err := decodeBlob(e.getClient().GetCodec(), encodedBlob,
+		e.cfg.PointVerificationModeFallback, store.RequestLogger(ctx, e.log))
 	if err != nil {
-		return nil, fmt.Errorf("EigenDA client failed to retrieve decoded blob: %w", err)
+		return nil, fmt.Errorf("EigenDA client failed to decode blob: %w", err)
+	}
+	if fellBack {
+		// the payload doesn't re-encode to the retrieved blob, so Verify can't verify it: the blob is verified as
+		// retrieved instead, and the router told not to verify or cache the payload
+		if err := e.verifyCommitment(&cert, encodedBlob); err != nil {
+			return nil, fmt.Errorf("failed to verify commitment of fallback decoded blob: %w", err)
+		}
+		if err := e.verifier.VerifyReadCert(&cert); err != nil {
+			return nil, fmt.Errorf("failed to verify cert of fallback decoded blob: %w", err)
+		}
+		store.RecordFallbackDecode(ctx)
 	}
 
 	return decodedBlob, nil
 }
 
+// retrieve ... returns the encoded blob of cert as served by the disperser, or by the first retriever service
+// serving it if the disperser fails to
+func (e *Store) retrieve(ctx context.Context, cert *verify.Certificate) ([]byte, error) {
+	encodedBlob, err := e.getBackend().Retrieve(ctx, cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash,
+		cert.BlobVerificationProof.BlobIndex)
+	if err == nil && len(encodedBlob) == 0 {
+		err = errors.New("blob has length zero")
+	}
+	if err == nil {
+		return encodedBlob, nil
+	}
+	err = fmt.Errorf("EigenDA client failed to retrieve decoded blob: %w", err)
+	if len(e.retrievers) == 0 || ctx.Err() != nil {
+		return nil, err
+	}
+
+	errs := []error{err}
+	for _, r := range e.retrievers {
+		nodeCtx, span := tracer.Start(ctx, "EigenDA node retrieve", trace.WithAttributes(
+			attribute.String("retriever", r.addr)))
+		encodedBlob, err := r.Retrieve(nodeCtx, cert)
+		tracing.End(span, err)
+		if err == nil {
+			e.log.Warn("Disperser failed to serve blob, retrieved it from the operator nodes", "retriever", r.addr,
+				"err", errs[0])
+			return encodedBlob, nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
 // Put disperses a blob for some pre-image and returns the associated RLP encoded certificate commit.
-func (e Store) Put(ctx context.Context, value []byte) ([]byte, error) {
-	encodedBlob, err := e.client.GetCodec().EncodeBlob(value)
+func (e *Store) Put(ctx context.Context, value []byte) ([]byte, error) {
+	encodedBlob, err := e.getClient().GetCodec().EncodeBlob(value)
 	if err != nil {
 		return nil, fmt.Errorf("EigenDA client failed to re-encode blob: %w", err)
 	}
-	if uint64(len(encodedBlob)) > e.cfg.MaxBlobSizeBytes {
-		return nil, fmt.Errorf("%w: blob length %d, max blob size %d", store.ErrProxyOversizedBlob, len(value), e.cfg.MaxBlobSizeBytes)
+	if maxSize := e.maxBlobSizeBytes(); uint64(len(encodedBlob)) > maxSize {
+		return nil, fmt.Errorf("%w: blob length %d, max blob size %d", store.ErrProxyOversizedBlob, len(value), maxSize)
+	}
+	store.RecordEncodedBlob(ctx, encodedBlob)
+
+	// with pre-validation, the commitment is computed before paying for dispersal so that blobs the verifier
+	// can't commit to are rejected upfront, and the returned cert can be checked as soon as it arrives
+	var commitment *bn254.G1Affine
+	if e.cfg.PrevalidateCommitment && e.verifier.KZGEnabled() {
+		commitment, err = e.verifier.Commit(encodedBlob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute kzg commitment before dispersal: %w", err)
+		}
 	}
 
 	dispersalStart := time.Now()
-	blobInfo, err := e.client.PutBlob(ctx, value)
+	disperseCtx, span := tracer.Start(ctx, "EigenDA disperse", trace.WithAttributes(
+		attribute.Int("blob.encoded_size", len(encodedBlob))))
+	blobInfo, err := e.disperse(disperseCtx, value, encodedBlob)
+	tracing.End(span, err)
 	if err != nil {
-		return nil, err
+		return nil, e.checkOversized(err)
 	}
 	cert := (*verify.Certificate)(blobInfo)
 
-	err = e.verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob)
-	if err != nil {
+	if commitment != nil {
+		err = verify.CompareCommitment(cert.BlobHeader.Commitment, commitment)
+	} else {
+		err = e.verifyCommitment(cert, encodedBlob)
+	}
+	if errors.Is(err, verify.ErrCommitmentMismatch) {
+		store.RequestLogger(ctx, e.log).Error("Disperser returned a cert whose commitment doesn't match the dispersed blob",
+			"batch_header_hash", cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash, "err", err)
+		return nil, fmt.Errorf("rejected cert returned by disperser: %w", err)
+	} else if err != nil {
 		return nil, err
 	}
 
 	dispersalDuration := time.Since(dispersalStart)
 	remainingTimeout := e.cfg.StatusQueryTimeout - dispersalDuration
 
+	if err := e.awaitCertVerification(ctx, cert, remainingTimeout); err != nil {
+		return nil, err
+	}
+
+	bytes, err := rlp.EncodeToBytes(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
+	}
+
+	return bytes, nil
+}
+
+// awaitCertVerification ... verifies the cert of a dispersed blob against the batch metadata bridged to Ethereum
+// once per block, until the batch is confirmed at the configured depth or timeout elapses
+func (e *Store) awaitCertVerification(ctx context.Context, cert *verify.Certificate, timeout time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx, "EigenDA verify cert")
+	defer func() { tracing.End(span, err) }()
+
 	ticker := time.NewTicker(12 * time.Second) // avg. eth block time
 	defer ticker.Stop()
-	ctx, cancel := context.WithTimeout(context.Background(), remainingTimeout)
+	// bounded by the request's deadline as well, if the caller set one
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	done := false
-	for !done {
+	for {
 		select {
 		case <-ctx.Done():
-			return nil, fmt.Errorf("timed out when trying to verify the DA certificate for a blob batch after dispersal")
+			return fmt.Errorf("timed out when trying to verify the DA certificate for a blob batch after dispersal: %w", ctx.Err())
 		case <-ticker.C:
-			err = e.verifier.VerifyCert(cert)
+			err := e.verifier.VerifyCert(cert)
 			switch {
 			case err == nil:
-				done = true
+				return nil
 			case errors.Is(err, verify.ErrBatchMetadataHashNotFound):
-				e.log.Info("Blob confirmed, waiting for sufficient confirmation depth...", "targetDepth", e.cfg.EthConfirmationDepth)
+				span.AddEvent("waiting for confirmation depth")
+				store.RequestLogger(ctx, e.log).Info("Blob confirmed, waiting for sufficient confirmation depth...", "targetDepth", e.cfg.EthConfirmationDepth)
 			default:
-				return nil, err
+				return err
 			}
 		}
 	}
-
-	bytes, err := rlp.EncodeToBytes(cert)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
-	}
-
-	return bytes, nil
 }
 
 // Entries are a no-op for EigenDA Store
-func (e Store) Stats() *store.Stats {
+func (e *Store) Stats() *store.Stats {
 	return nil
 }
 
 // Backend returns the backend type for EigenDA Store
-func (e Store) BackendType() store.BackendType {
+func (e *Store) BackendType() store.BackendType {
 	return store.EigenDABackendType
 }
 
+// VerificationMode returns the checks performed by Verify
+func (e *Store) VerificationMode() string {
+	switch {
+	case !e.verifier.KZGEnabled():
+		return store.VerificationCert
+	case e.verifier.CertVerificationEnabled():
+		return store.VerificationKZGCert
+	default:
+		return store.VerificationKZG
+	}
+}
+
+// ReadQuorumPolicy returns the quorum policy applied by Verify, or an empty string if certs aren't verified
+func (e *Store) ReadQuorumPolicy() string {
+	if !e.verifier.CertVerificationEnabled() {
+		return ""
+	}
+	return e.verifier.ReadQuorumPolicy().String()
+}
+
+// NetworkInfo returns the proxy's effective max blob size and, if cert verification is enabled, the network parameters
+// configured in the EigenDA service manager
+func (e *Store) NetworkInfo(ctx context.Context) (store.NetworkInfo, error) {
+	info := store.NetworkInfo{MaxBlobSizeBytes: e.maxBlobSizeBytes()}
+	if !e.verifier.CertVerificationEnabled() {
+		return info, nil
+	}
+
+	params, err := e.verifier.NetworkParams(ctx)
+	if err != nil {
+		return store.NetworkInfo{}, err
+	}
+	info.RequiredQuorums = params.RequiredQuorums
+	info.AdversaryThresholds = params.AdversaryThresholdPercentages
+	info.ConfirmationThresholds = params.ConfirmationThresholdPercentages
+	info.RetentionBlocks = params.StoreDurationBlocks
+	return info, nil
+}
+
+// Degraded returns why the store runs with reduced verification, or an empty string if it doesn't
+func (e *Store) Degraded() string {
+	return e.verifier.Degraded()
+}
+
+// verifyCommitment verifies the blob against the cert's kzg commitment, unless the verifier runs in
+// cert-only mode
+func (e *Store) verifyCommitment(cert *verify.Certificate, encodedBlob []byte) error {
+	if !e.verifier.KZGEnabled() {
+		return nil
+	}
+	return e.verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob)
+}
+
 // Key is used to recover certificate fields and that verifies blob
 // against commitment to ensure data is valid and non-tampered.
-func (e Store) Verify(key []byte, value []byte) error {
+func (e *Store) Verify(key []byte, value []byte) error {
 	var cert verify.Certificate
 	err := rlp.DecodeBytes(key, &cert)
 	if err != nil {
@@ -136,17 +430,18 @@ func (e Store) Verify(key []byte, value []byte) error {
 	}
 
 	// re-encode blob for verification
-	encodedBlob, err := e.client.GetCodec().EncodeBlob(value)
+	encodedBlob, err := e.getClient().GetCodec().EncodeBlob(value)
 	if err != nil {
 		return fmt.Errorf("EigenDA client failed to re-encode blob: %w", err)
 	}
 
 	// verify kzg data commitment
-	err = e.verifier.VerifyCommitment(cert.BlobHeader.Commitment, encodedBlob)
+	err = e.verifyCommitment(&cert, encodedBlob)
 	if err != nil {
 		return fmt.Errorf("failed to verify commitment: %w", err)
 	}
 
-	// verify DA certificate against EigenDA's batch metadata that's bridged to Ethereum
-	return e.verifier.VerifyCert(&cert)
+	// verify DA certificate against EigenDA's batch metadata that's bridged to Ethereum, requiring the quorums
+	// of the read quorum policy
+	return e.verifier.VerifyReadCert(&cert)
 }
@@ -0,0 +1,239 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// disperserEndpoint ... disperser client of one of the endpoints of a failoverDisperserClient
+type disperserEndpoint struct {
+	addr   string
+	client clients.DisperserClient
+	// healthy is false once a request to the endpoint failed with an error of the endpoint, until one succeeds
+	healthy bool
+}
+
+// failoverDisperserClient ... disperser client spreading over several endpoints of the same disperser, e.g. regional
+// entrypoints, so that an endpoint going down doesn't fail dispersals and retrievals. Requests are sent to the
+// current endpoint, and to the next ones in turn while they fail with an error of the endpoint rather than of the
+// request, e.g. Unavailable or DeadlineExceeded. Dispersals only fail over while the blob can't have reached the
+// endpoint, i.e. on Unavailable, so that a blob isn't dispersed and paid for twice. Healthy endpoints are tried
+// before unhealthy ones. The endpoint that served a request becomes the current one.
+type failoverDisperserClient struct {
+	endpoints []*disperserEndpoint
+	m         metrics.Metricer
+	log       log.Logger
+
+	mu      sync.Mutex
+	current int
+}
+
+var (
+	_ clients.DisperserClient = (*failoverDisperserClient)(nil)
+	_ store.Pinger            = (*failoverDisperserClient)(nil)
+	_ store.Reconnector       = (*failoverDisperserClient)(nil)
+)
+
+// NewFailoverDisperserClient ... builds a disperser client failing over between the disperser endpoints rpcs, in
+// order, whose clients are built by newClient from cfg with the RPC of the endpoint
+func NewFailoverDisperserClient(cfg clients.EigenDAClientConfig, rpcs []string,
+	newClient func(clients.EigenDAClientConfig) (clients.DisperserClient, error), m metrics.Metricer,
+	l log.Logger) (clients.DisperserClient, error) {
+	c := &failoverDisperserClient{m: m, log: l.With("subsystem", "disperser-failover")}
+	for _, rpc := range rpcs {
+		cfg.RPC = rpc
+		client, err := newClient(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create disperser client for %s: %w", rpc, err)
+		}
+		c.endpoints = append(c.endpoints, &disperserEndpoint{addr: rpc, client: client, healthy: true})
+		m.RecordDisperserEndpointHealth(rpc, true)
+	}
+	if len(c.endpoints) == 0 {
+		return nil, errors.New("no disperser endpoints provided")
+	}
+	return c, nil
+}
+
+// do ... sends a request to the current endpoint, then to the next ones in turn while they fail with an error
+// failover accepts
+func (c *failoverDisperserClient) do(ctx context.Context, op string, failover func(error) bool,
+	call func(clients.DisperserClient) error) error {
+	order := c.order()
+	var errs []error
+	for i, idx := range order {
+		e := c.endpoints[idx]
+		err := call(e.client)
+		c.record(idx, err)
+		if err == nil || !failover(err) || ctx.Err() != nil {
+			return err
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+		if i < len(order)-1 {
+			store.RequestLogger(ctx, c.log).Warn("Disperser endpoint failed, failing over to the next one", "op", op,
+				"endpoint", e.addr, "next", c.endpoints[order[i+1]].addr, "err", err)
+		}
+	}
+	return fmt.Errorf("all disperser endpoints failed: %w", errors.Join(errs...))
+}
+
+// order ... indexes of the endpoints in the order requests try them: the current endpoint and those after it,
+// healthy endpoints before unhealthy ones, which are only tried as a last resort
+func (c *failoverDisperserClient) order() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	healthy := make([]int, 0, len(c.endpoints))
+	var unhealthy []int
+	for i := range c.endpoints {
+		idx := (c.current + i) % len(c.endpoints)
+		if c.endpoints[idx].healthy {
+			healthy = append(healthy, idx)
+		} else {
+			unhealthy = append(unhealthy, idx)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+// record ... records the outcome of a request to the endpoint at idx, which becomes the current one if it served
+// the request
+func (c *failoverDisperserClient) record(idx int, err error) {
+	e := c.endpoints[idx]
+	healthy := err == nil || !isEndpointError(err)
+	c.m.RecordDisperserEndpointRequest(e.addr, err == nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil && c.current != idx {
+		c.log.Info("Switched disperser endpoint", "from", c.endpoints[c.current].addr, "to", e.addr)
+		c.current = idx
+	}
+	c.setHealthy(e, healthy)
+}
+
+// setHealthy ... records the health of the endpoint e, the caller must hold the lock
+func (c *failoverDisperserClient) setHealthy(e *disperserEndpoint, healthy bool) {
+	if healthy == e.healthy {
+		return
+	}
+	e.healthy = healthy
+	c.m.RecordDisperserEndpointHealth(e.addr, healthy)
+	if !healthy {
+		c.log.Warn("Disperser endpoint is unhealthy", "endpoint", e.addr)
+	} else {
+		c.log.Info("Disperser endpoint recovered", "endpoint", e.addr)
+	}
+}
+
+// isEndpointError ... whether err suggests that the endpoint, rather than the request, is at fault, so that the
+// request may succeed on another endpoint. The endpoint may have processed the request nonetheless.
+func isEndpointError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// isUnsentError ... whether err shows that the request didn't reach the endpoint, e.g. because the connection
+// failed, so that sending it to another endpoint can't process it twice
+func isUnsentError(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+func (c *failoverDisperserClient) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (
+	*disperser.BlobStatus, []byte, error) {
+	var blobStatus *disperser.BlobStatus
+	var requestID []byte
+	err := c.do(ctx, "disperse", isUnsentError, func(client clients.DisperserClient) error {
+		var err error
+		blobStatus, requestID, err = client.DisperseBlob(ctx, data, quorums)
+		return err
+	})
+	return blobStatus, requestID, err
+}
+
+func (c *failoverDisperserClient) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (
+	*disperser.BlobStatus, []byte, error) {
+	var blobStatus *disperser.BlobStatus
+	var requestID []byte
+	err := c.do(ctx, "disperse", isUnsentError, func(client clients.DisperserClient) error {
+		var err error
+		blobStatus, requestID, err = client.DisperseBlobAuthenticated(ctx, data, quorums)
+		return err
+	})
+	return blobStatus, requestID, err
+}
+
+func (c *failoverDisperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (
+	*grpcdisperser.BlobStatusReply, error) {
+	var reply *grpcdisperser.BlobStatusReply
+	err := c.do(ctx, "status", isEndpointError, func(client clients.DisperserClient) error {
+		var err error
+		reply, err = client.GetBlobStatus(ctx, requestID)
+		return err
+	})
+	return reply, err
+}
+
+func (c *failoverDisperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) (
+	[]byte, error) {
+	var data []byte
+	err := c.do(ctx, "retrieve", isEndpointError, func(client clients.DisperserClient) error {
+		var err error
+		data, err = client.RetrieveBlob(ctx, batchHeaderHash, blobIndex)
+		return err
+	})
+	return data, err
+}
+
+// Ping ... pings every endpoint whose client pools its connection, recording its health, and fails if none of them
+// answers
+func (c *failoverDisperserClient) Ping(ctx context.Context) error {
+	var errs []error
+	for _, e := range c.endpoints {
+		p, ok := e.client.(store.Pinger)
+		if !ok {
+			continue
+		}
+		err := p.Ping(ctx)
+		c.mu.Lock()
+		c.setHealthy(e, err == nil)
+		c.mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+		}
+	}
+	if len(errs) < len(c.endpoints) {
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// Reconnect ... replaces the pooled connections of the endpoints, if pooled
+func (c *failoverDisperserClient) Reconnect(ctx context.Context) error {
+	var errs []error
+	for _, e := range c.endpoints {
+		if r, ok := e.client.(store.Reconnector); ok {
+			if err := r.Reconnect(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", e.addr, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
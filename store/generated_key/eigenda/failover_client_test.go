@@ -0,0 +1,155 @@
+package eigenda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// downDisperser ... fakeDisperser whose status queries and dispersals fail with err while it is set
+type downDisperser struct {
+	fakeDisperser
+	err error
+}
+
+func (d *downDisperser) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	if d.err != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.dispersals++
+		return nil, nil, d.err
+	}
+	return d.fakeDisperser.DisperseBlobAuthenticated(ctx, data, quorums)
+}
+
+func (d *downDisperser) Ping(context.Context) error {
+	return d.err
+}
+
+func (d *downDisperser) dispersalCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dispersals
+}
+
+func (d *downDisperser) GetBlobStatus(ctx context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.fakeDisperser.GetBlobStatus(ctx, requestID)
+}
+
+func TestFailoverDisperserClient(t *testing.T) {
+	ctx := context.Background()
+	endpoints := map[string]*downDisperser{"a:443": {}, "b:443": {}}
+	m := metrics.NewMetrics("test")
+	c, err := NewFailoverDisperserClient(clients.EigenDAClientConfig{}, []string{"a:443", "b:443"},
+		func(cfg clients.EigenDAClientConfig) (clients.DisperserClient, error) {
+			return endpoints[cfg.RPC], nil
+		}, m, log.New())
+	require.NoError(t, err)
+
+	// requests are sent to the first endpoint while it serves them
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, endpoints["a:443"].queryCount())
+
+	// and fail over to the next one once it's unavailable, which then serves the following requests
+	endpoints["a:443"].err = status.Error(codes.Unavailable, "connection refused")
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, endpoints["b:443"].queryCount())
+	require.Equal(t, 0.0, testutil.ToFloat64(m.DisperserEndpointHealthy.WithLabelValues("a:443")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DisperserEndpointRequests.WithLabelValues("a:443", "error")))
+
+	// errors of the request aren't failed over
+	endpoints["b:443"].err = status.Error(codes.InvalidArgument, "invalid request id")
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DisperserEndpointRequests.WithLabelValues("a:443", "error")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DisperserEndpointHealthy.WithLabelValues("b:443")))
+
+	// requests fail once every endpoint failed
+	endpoints["b:443"].err = status.Error(codes.DeadlineExceeded, "timeout")
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.ErrorContains(t, err, "all disperser endpoints failed")
+	require.ErrorContains(t, err, "a:443")
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+// newFailoverTestClient ... failover client over a downDisperser per address
+func newFailoverTestClient(t *testing.T, addrs ...string) (clients.DisperserClient, map[string]*downDisperser) {
+	endpoints := make(map[string]*downDisperser, len(addrs))
+	for _, addr := range addrs {
+		endpoints[addr] = &downDisperser{}
+	}
+	c, err := NewFailoverDisperserClient(clients.EigenDAClientConfig{}, addrs,
+		func(cfg clients.EigenDAClientConfig) (clients.DisperserClient, error) {
+			return endpoints[cfg.RPC], nil
+		}, metrics.NoopMetrics, log.New())
+	require.NoError(t, err)
+	return c, endpoints
+}
+
+func TestFailoverDisperserClientDispersal(t *testing.T) {
+	ctx := context.Background()
+
+	// the endpoint may have accepted a dispersal that failed otherwise, so it isn't dispersed again elsewhere
+	for _, code := range []codes.Code{codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.Aborted} {
+		c, endpoints := newFailoverTestClient(t, "a:443", "b:443")
+		endpoints["a:443"].err = status.Error(code, "failed")
+		_, _, err := c.DisperseBlobAuthenticated(ctx, []byte{0x01}, nil)
+		require.Equal(t, code, status.Code(err))
+		require.Equal(t, 1, endpoints["a:443"].dispersalCount())
+		require.Equal(t, 0, endpoints["b:443"].dispersalCount())
+	}
+
+	// while an unavailable endpoint can't have received it
+	c, endpoints := newFailoverTestClient(t, "a:443", "b:443")
+	endpoints["a:443"].err = status.Error(codes.Unavailable, "connection refused")
+	_, _, err := c.DisperseBlobAuthenticated(ctx, []byte{0x01}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, endpoints["a:443"].dispersalCount())
+	require.Equal(t, 1, endpoints["b:443"].dispersalCount())
+}
+
+func TestFailoverDisperserClientSkipsUnhealthy(t *testing.T) {
+	ctx := context.Background()
+	c, endpoints := newFailoverTestClient(t, "a:443", "b:443", "c:443")
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	// a and b fail, so c serves
+	endpoints["a:443"].err, endpoints["b:443"].err = unavailable, unavailable
+	_, err := c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, endpoints["c:443"].queryCount())
+
+	// pings find a recovered, which serves once c fails
+	endpoints["a:443"].err = nil
+	require.NoError(t, c.(store.Pinger).Ping(ctx))
+	endpoints["c:443"].err = unavailable
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, endpoints["a:443"].queryCount())
+
+	// pings find c recovered but not b, so once a fails, c is tried before b, although b recovered since
+	endpoints["c:443"].err = nil
+	require.NoError(t, c.(store.Pinger).Ping(ctx))
+	endpoints["a:443"].err, endpoints["b:443"].err = unavailable, nil
+	_, err = c.GetBlobStatus(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, endpoints["c:443"].queryCount())
+	require.Equal(t, 0, endpoints["b:443"].queryCount())
+}
@@ -0,0 +1,140 @@
+package eigenda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// journal ... durable storage of the pending dispersals, written ahead of status polling so that a proxy
+// stopping mid-dispersal can recover its dispersals on restart
+type journal interface {
+	// load ... returns the persisted dispersals
+	load() ([]*PendingDispersal, error)
+	// save ... persists the changes to the dispersals of p with the given ids, which were removed if p no longer
+	// holds them. Called with the lock of p held.
+	save(p *pendingDispersals, ids []string) error
+}
+
+// HashStore ... key-value store holding hashes, e.g. Redis, the pending dispersals can be journaled to instead of
+// a local file
+type HashStore interface {
+	HashSet(ctx context.Context, key, field string, value []byte) error
+	HashDelete(ctx context.Context, key, field string) error
+	HashGetAll(ctx context.Context, key string) (map[string][]byte, error)
+}
+
+// journalTimeout ... timeout of the operations of journals backed by a remote store
+const journalTimeout = 5 * time.Second
+
+// newJournal ... returns the journal of the pending dispersals of cfg, nil if they are kept in memory only
+func newJournal(cfg *StoreConfig) journal {
+	switch {
+	case cfg.PendingDispersalsRedisKey != "" && cfg.PendingDispersalsRedis != nil:
+		return &hashJournal{store: cfg.PendingDispersalsRedis, key: cfg.PendingDispersalsRedisKey}
+	case cfg.PendingDispersalsPath != "":
+		return &fileJournal{path: cfg.PendingDispersalsPath}
+	default:
+		return nil
+	}
+}
+
+// fileJournal ... journals all dispersals to a JSON file, rewritten atomically on every change
+type fileJournal struct {
+	path string
+}
+
+func (j *fileJournal) load() ([]*PendingDispersal, error) {
+	b, err := os.ReadFile(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read pending dispersals %s: %w", j.path, err)
+	}
+
+	var dispersals []*PendingDispersal
+	if err := json.Unmarshal(b, &dispersals); err != nil {
+		return nil, fmt.Errorf("failed to decode pending dispersals %s: %w", j.path, err)
+	}
+	return dispersals, nil
+}
+
+func (j *fileJournal) save(p *pendingDispersals, _ []string) error {
+	b, err := json.MarshalIndent(p.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	// synced so that a dispersal is never lost once written ahead of its status polling
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), j.path)
+}
+
+// hashJournal ... journals each dispersal to a field of a hash, keyed by its ID, so that only changed dispersals
+// are written
+type hashJournal struct {
+	store HashStore
+	key   string
+}
+
+func (j *hashJournal) load() ([]*PendingDispersal, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), journalTimeout)
+	defer cancel()
+
+	fields, err := j.store.HashGetAll(ctx, j.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pending dispersals from hash %s: %w", j.key, err)
+	}
+	dispersals := make([]*PendingDispersal, 0, len(fields))
+	for id, b := range fields {
+		var d PendingDispersal
+		if err := json.Unmarshal(b, &d); err != nil {
+			return nil, fmt.Errorf("failed to decode pending dispersal %s: %w", id, err)
+		}
+		dispersals = append(dispersals, &d)
+	}
+	return dispersals, nil
+}
+
+func (j *hashJournal) save(p *pendingDispersals, ids []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), journalTimeout)
+	defer cancel()
+
+	for _, id := range ids {
+		d, ok := p.dispersals[id]
+		if !ok {
+			if err := j.store.HashDelete(ctx, j.key, id); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		if err := j.store.HashSet(ctx, j.key, id, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
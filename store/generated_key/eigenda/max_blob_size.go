@@ -0,0 +1,121 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// probeQuorum ... quorum number above the disperser's maximum quorum ID of 254. The disperser validates it after
+// the blob size, so a probe carrying it is rejected either for its size or for its quorum, and never dispersed.
+const probeQuorum uint8 = 255
+
+// ErrMaxBlobSizeExceedsDisperser ... returned when the configured max blob length is larger than the max blob
+// size accepted by the disperser
+var ErrMaxBlobSizeExceedsDisperser = errors.New("max blob length exceeds the disperser's max blob size")
+
+// maxBlobSizeRe ... matches the error of the disperser rejecting a blob for its size
+var maxBlobSizeRe = regexp.MustCompile(`blob size cannot exceed (\d+) Bytes`)
+
+// parseMaxBlobSize returns the max blob size reported by a disperser error, if the error rejects a blob for its
+// size
+func parseMaxBlobSize(err error) (uint64, bool) {
+	if err == nil {
+		return 0, false
+	}
+	m := maxBlobSizeRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, false
+	}
+	size, perr := strconv.ParseUint(m[1], 10, 64)
+	if perr != nil {
+		return 0, false
+	}
+	return size, true
+}
+
+// maxBlobSizeBytes returns the effective max blob size, the configured one unless the disperser accepts less
+func (e *Store) maxBlobSizeBytes() uint64 {
+	return e.maxBlobSize.Load()
+}
+
+// shrinkMaxBlobSize lowers the effective max blob size to the size accepted by the disperser
+func (e *Store) shrinkMaxBlobSize(size uint64) {
+	for {
+		current := e.maxBlobSize.Load()
+		if size >= current {
+			return
+		}
+		if e.maxBlobSize.CompareAndSwap(current, size) {
+			e.log.Warn("Disperser reduced its max blob size, shrinking the effective max blob length",
+				"configured", e.cfg.MaxBlobSizeBytes, "previous", current, "disperser", size)
+			return
+		}
+	}
+}
+
+// NegotiateMaxBlobSize probes the disperser with a blob of the configured max blob length and reconciles the
+// effective max blob size with the size the disperser accepts: it shrinks to the disperser's limit if the
+// disperser rejects the probe for its size, and is restored to the configured length otherwise. The returned
+// max blob size of the disperser is 0 if it accepts blobs of the configured length.
+func (e *Store) NegotiateMaxBlobSize(ctx context.Context) (uint64, error) {
+	// zeroed bytes are valid field elements, so that only the size and quorum checks can reject the probe
+	probe := make([]byte, e.cfg.MaxBlobSizeBytes)
+	_, _, err := e.getClient().Client.DisperseBlob(ctx, probe, []uint8{probeQuorum})
+	if err == nil {
+		// a disperser accepting the probe doesn't validate quorums, so nothing can be learned from it
+		e.log.Warn("Disperser accepted the max blob size probe")
+		return 0, nil
+	}
+
+	size, ok := parseMaxBlobSize(err)
+	if !ok {
+		if status.Code(err) != codes.InvalidArgument {
+			return 0, fmt.Errorf("failed to probe the disperser's max blob size: %w", err)
+		}
+		// rejected for the probe quorum, blobs of the configured length are accepted
+		if e.maxBlobSize.Swap(e.cfg.MaxBlobSizeBytes) < e.cfg.MaxBlobSizeBytes {
+			e.log.Info("Disperser accepts the configured max blob length again", "max blob length", e.cfg.MaxBlobSizeBytes)
+		}
+		return 0, nil
+	}
+
+	e.shrinkMaxBlobSize(size)
+	return size, fmt.Errorf("%w: max blob length %d, disperser max blob size %d", ErrMaxBlobSizeExceedsDisperser,
+		e.cfg.MaxBlobSizeBytes, size)
+}
+
+// RunMaxBlobSizeNegotiation negotiates the max blob size with the disperser each interval, until ctx is done
+func (e *Store) RunMaxBlobSizeNegotiation(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := e.NegotiateMaxBlobSize(ctx); err != nil && !errors.Is(err, ErrMaxBlobSizeExceedsDisperser) {
+				e.log.Warn("Failed to negotiate max blob size with the disperser", "err", err)
+			}
+		}
+	}
+}
+
+// checkOversized shrinks the effective max blob size if the disperser rejected a dispersal for its size, and
+// returns the dispersal error wrapped as an oversized blob error
+func (e *Store) checkOversized(err error) error {
+	size, ok := parseMaxBlobSize(err)
+	if !ok {
+		return err
+	}
+	e.shrinkMaxBlobSize(size)
+	return fmt.Errorf("%w: %w", store.ErrProxyOversizedBlob, err)
+}
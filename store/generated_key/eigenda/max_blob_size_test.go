@@ -0,0 +1,93 @@
+package eigenda
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// limitedDisperser ... disperser client validating requests like the disperser does, rejecting blobs larger
+// than maxSize
+type limitedDisperser struct {
+	fakeDisperser
+	maxSize int
+}
+
+func (d *limitedDisperser) validate(data []byte, quorums []uint8) error {
+	if len(data) > d.maxSize {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("blob size cannot exceed %v Bytes", d.maxSize))
+	}
+	for _, q := range quorums {
+		if q > 254 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("custom_quorum_numbers must be in range [0, 254], but found %d", q))
+		}
+	}
+	return nil
+}
+
+func (d *limitedDisperser) DisperseBlob(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	if err := d.validate(data, quorums); err != nil {
+		return nil, nil, err
+	}
+	return d.fakeDisperser.DisperseBlobAuthenticated(ctx, data, quorums)
+}
+
+func (d *limitedDisperser) DisperseBlobAuthenticated(ctx context.Context, data []byte, quorums []uint8) (*disperser.BlobStatus, []byte, error) {
+	if err := d.validate(data, quorums); err != nil {
+		return nil, nil, err
+	}
+	return d.fakeDisperser.DisperseBlobAuthenticated(ctx, data, quorums)
+}
+
+func TestNegotiateMaxBlobSize(t *testing.T) {
+	d := &limitedDisperser{maxSize: 1024}
+	s := newTestStore(t, "", &d.fakeDisperser)
+	s.getClient().Client = d
+	s.SetBackend(NewClientBackend(d))
+	s.cfg.MaxBlobSizeBytes = 1024
+	s.maxBlobSize.Store(1024)
+	ctx := context.Background()
+
+	// the disperser accepts the configured length
+	size, err := s.NegotiateMaxBlobSize(ctx)
+	require.NoError(t, err)
+	require.Zero(t, size)
+	require.Equal(t, uint64(1024), s.maxBlobSizeBytes())
+	require.Zero(t, d.dispersals)
+
+	// the disperser lowers its limit
+	d.maxSize = 512
+	size, err = s.NegotiateMaxBlobSize(ctx)
+	require.ErrorIs(t, err, ErrMaxBlobSizeExceedsDisperser)
+	require.Equal(t, uint64(512), size)
+	require.Equal(t, uint64(512), s.maxBlobSizeBytes())
+
+	_, err = s.Put(ctx, make([]byte, 600))
+	require.ErrorIs(t, err, store.ErrProxyOversizedBlob)
+
+	// the disperser restores its limit
+	d.maxSize = 2048
+	_, err = s.NegotiateMaxBlobSize(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1024), s.maxBlobSizeBytes())
+	require.Zero(t, d.dispersals)
+}
+
+func TestPutShrinksMaxBlobSizeOnRejection(t *testing.T) {
+	d := &limitedDisperser{maxSize: 256}
+	s := newTestStore(t, "", &d.fakeDisperser)
+	s.getClient().Client = d
+	s.SetBackend(NewClientBackend(d))
+	s.maxBlobSize.Store(1024)
+
+	_, err := s.disperse(context.Background(), make([]byte, 300), make([]byte, 300))
+	require.Error(t, err)
+	require.ErrorIs(t, s.checkOversized(err), store.ErrProxyOversizedBlob)
+	require.Equal(t, uint64(256), s.maxBlobSizeBytes())
+}
@@ -0,0 +1,212 @@
+package eigenda
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// DispersalStatusDispersing ... the disperser accepted the blob and the proxy is polling its status. Left in
+	// the journal by a proxy that stopped mid-dispersal, such dispersals are pending once the journal is loaded.
+	DispersalStatusDispersing = "dispersing"
+	// DispersalStatusPending ... the disperser hasn't confirmed the blob yet
+	DispersalStatusPending = "pending"
+	// DispersalStatusRecovered ... the blob has been confirmed, and its cert is returned by the next Put of the
+	// same payload
+	DispersalStatusRecovered = "recovered"
+)
+
+var (
+	ErrDispersalNotFound = errors.New("pending dispersal not found")
+	// ErrDispersalPending ... returned when the disperser hasn't confirmed a pending dispersal yet
+	ErrDispersalPending = errors.New("dispersal is still pending")
+)
+
+// PendingDispersal ... a dispersal accepted by the disperser but not confirmed yet, either still polled or whose
+// status polling timed out. The payload itself is not stored, the recovered cert is returned when the same payload
+// is put again.
+type PendingDispersal struct {
+	// ID is the keccak256 hash of the payload
+	ID        string        `json:"id"`
+	RequestID hexutil.Bytes `json:"request_id"`
+	CreatedAt time.Time     `json:"created_at"`
+	Status    string        `json:"status"`
+	// Attempts counts how often polling has been resumed
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error,omitempty"`
+	// Cert is the RLP encoded cert, set once the dispersal has been recovered
+	Cert hexutil.Bytes `json:"cert,omitempty"`
+}
+
+// DispersalID ... ID of the dispersal of a payload
+func DispersalID(payload []byte) string {
+	return hexutil.Encode(crypto.Keccak256(payload))
+}
+
+// TenantDispersalID ... ID of the dispersal of a payload by a tenant with its own dispersal config, see
+// Store.SetTenantClient
+func TenantDispersalID(tenant string, payload []byte) string {
+	return tenant + ":" + DispersalID(payload)
+}
+
+// pendingDispersals ... dispersals in flight or that timed out, keyed by ID. If a journal is set, they are
+// persisted to it after every change so that they survive restarts.
+type pendingDispersals struct {
+	mu         sync.Mutex
+	journal    journal
+	dispersals map[string]*PendingDispersal
+}
+
+// newPendingDispersals ... constructor, loading previously persisted dispersals if journal is set. Dispersals
+// whose status was being polled when they were persisted are loaded as pending.
+func newPendingDispersals(j journal) (*pendingDispersals, error) {
+	p := &pendingDispersals{journal: j, dispersals: make(map[string]*PendingDispersal)}
+	if j == nil {
+		return p, nil
+	}
+
+	dispersals, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range dispersals {
+		if d.Status == DispersalStatusDispersing {
+			d.Status = DispersalStatusPending
+		}
+		p.dispersals[d.ID] = d
+	}
+	return p, nil
+}
+
+// add ... records a dispersal accepted by the disperser with the given status, replacing an existing one with the
+// same ID
+func (p *pendingDispersals) add(id string, requestID []byte, status string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.dispersals[id] = &PendingDispersal{
+		ID:        id,
+		RequestID: append(hexutil.Bytes(nil), requestID...),
+		CreatedAt: time.Now(),
+		Status:    status,
+	}
+	return p.persist(id)
+}
+
+// restore ... adds dispersals, replacing existing ones with the same ID. Dispersals still polled by the proxy
+// they were taken from are restored as pending.
+func (p *pendingDispersals) restore(dispersals []PendingDispersal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, 0, len(dispersals))
+	for _, d := range dispersals {
+		c := d.clone()
+		if c.Status == DispersalStatusDispersing {
+			c.Status = DispersalStatusPending
+		}
+		p.dispersals[c.ID] = &c
+		ids = append(ids, c.ID)
+	}
+	return p.persist(ids...)
+}
+
+// update ... applies fn to the dispersal with the given ID and persists the result
+func (p *pendingDispersals) update(id string, fn func(d *PendingDispersal)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	d, ok := p.dispersals[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrDispersalNotFound, id)
+	}
+	fn(d)
+	return p.persist(id)
+}
+
+// get ... returns a copy of the dispersal with the given ID
+func (p *pendingDispersals) get(id string) (PendingDispersal, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	d, ok := p.dispersals[id]
+	if !ok {
+		return PendingDispersal{}, fmt.Errorf("%w: %s", ErrDispersalNotFound, id)
+	}
+	return d.clone(), nil
+}
+
+// list ... returns copies of all dispersals, oldest first
+func (p *pendingDispersals) list() []PendingDispersal {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.sorted()
+}
+
+// sorted ... caller must hold the lock
+func (p *pendingDispersals) sorted() []PendingDispersal {
+	dispersals := make([]PendingDispersal, 0, len(p.dispersals))
+	for _, d := range p.dispersals {
+		dispersals = append(dispersals, d.clone())
+	}
+	sort.Slice(dispersals, func(i, j int) bool {
+		return dispersals[i].CreatedAt.Before(dispersals[j].CreatedAt)
+	})
+	return dispersals
+}
+
+// remove ... deletes a dispersal, e.g. once its cert has been returned or it failed for good
+func (p *pendingDispersals) remove(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.dispersals[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrDispersalNotFound, id)
+	}
+	delete(p.dispersals, id)
+	return p.persist(id)
+}
+
+// compact ... drops the dispersals created before cutoff, whatever their status. Returns the number of
+// dropped dispersals.
+func (p *pendingDispersals) compact(cutoff time.Time) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var dropped []string
+	for id, d := range p.dispersals {
+		if d.CreatedAt.Before(cutoff) {
+			delete(p.dispersals, id)
+			dropped = append(dropped, id)
+		}
+	}
+	if len(dropped) == 0 {
+		return 0, nil
+	}
+	return len(dropped), p.persist(dropped...)
+}
+
+// persist ... persists the changes to the dispersals with the given ids to the journal, if any. Caller must hold
+// the lock.
+func (p *pendingDispersals) persist(ids ...string) error {
+	if p.journal == nil {
+		return nil
+	}
+	if err := p.journal.save(p, ids); err != nil {
+		return fmt.Errorf("failed to persist pending dispersals: %w", err)
+	}
+	return nil
+}
+
+func (d *PendingDispersal) clone() PendingDispersal {
+	c := *d
+	c.RequestID = append(hexutil.Bytes(nil), d.RequestID...)
+	c.Cert = append(hexutil.Bytes(nil), d.Cert...)
+	return c
+}
@@ -0,0 +1,196 @@
+package eigenda
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// defaultStatusQueryRetryInterval ... matches the EigenDA client's default
+	defaultStatusQueryRetryInterval = 5 * time.Second
+	// defaultStatusQueryTimeout ... matches the EigenDA client's default
+	defaultStatusQueryTimeout = 25 * time.Minute
+)
+
+// statusPoller ... queries the disperser for the status of every in-flight dispersal from a single loop, so
+// that the GetBlobStatus load is independent of the number of concurrent PUTs. Each round, the status of
+// every watched request is queried once by a fixed number of workers, paced by a global QPS cap, and the
+// reply is delivered to every request waiting on it. The loop only runs while requests are being watched.
+type statusPoller struct {
+	interval time.Duration
+	// timeout bounds each query, so that a hanging disperser doesn't block a worker, and the round, forever
+	timeout     time.Duration
+	parallelism int
+	limiter     *rate.Limiter
+	disperser   func() EigenDABackend
+	log         log.Logger
+
+	mu      sync.Mutex
+	watches map[string]*statusWatch
+	running bool
+}
+
+// statusWatch ... requests waiting for the status of a dispersal
+type statusWatch struct {
+	requestID []byte
+	waiters   map[chan *grpcdisperser.BlobStatusReply]struct{}
+}
+
+// newStatusPoller ... constructor. A qps of 0 disables the QPS cap. Each query is given up after timeout, the
+// status query timeout of the dispersals, since none of them waits longer on its status.
+func newStatusPoller(interval, timeout time.Duration, qps float64, parallelism int,
+	disperser func() EigenDABackend, log log.Logger) *statusPoller {
+	limit := rate.Inf
+	if qps > 0 {
+		limit = rate.Limit(qps)
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if interval <= 0 {
+		interval = defaultStatusQueryRetryInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultStatusQueryTimeout
+	}
+
+	return &statusPoller{
+		interval:    interval,
+		timeout:     timeout,
+		parallelism: parallelism,
+		limiter:     rate.NewLimiter(limit, 1),
+		disperser:   disperser,
+		log:         log,
+		watches:     make(map[string]*statusWatch),
+	}
+}
+
+// watch ... subscribes to the status of a dispersal. The channel holds the latest status reply, older replies
+// are dropped if they haven't been received yet. stop must be called once the status is no longer needed.
+func (p *statusPoller) watch(requestID []byte) (replies <-chan *grpcdisperser.BlobStatusReply, stop func()) {
+	ch := make(chan *grpcdisperser.BlobStatusReply, 1)
+	key := string(requestID)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.watches[key]
+	if !ok {
+		w = &statusWatch{
+			requestID: append([]byte(nil), requestID...),
+			waiters:   make(map[chan *grpcdisperser.BlobStatusReply]struct{}),
+		}
+		p.watches[key] = w
+	}
+	w.waiters[ch] = struct{}{}
+
+	if !p.running {
+		p.running = true
+		go p.run()
+	}
+
+	return ch, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		delete(w.waiters, ch)
+		if len(w.waiters) == 0 && p.watches[key] == w {
+			delete(p.watches, key)
+		}
+	}
+}
+
+// query ... queries the status of a dispersal once, subject to the QPS cap and the query timeout
+func (p *statusPoller) query(ctx context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	if err := p.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return p.disperser().GetStatus(ctx, requestID)
+}
+
+// run ... polls every watched dispersal each interval, until no dispersal is watched anymore
+func (p *statusPoller) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		requestIDs := p.watched()
+		if len(requestIDs) == 0 {
+			return
+		}
+		p.poll(requestIDs)
+	}
+}
+
+// watched ... returns the watched request IDs, marking the poller as stopped if there are none
+func (p *statusPoller) watched() [][]byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	requestIDs := make([][]byte, 0, len(p.watches))
+	for _, w := range p.watches {
+		requestIDs = append(requestIDs, w.requestID)
+	}
+	if len(requestIDs) == 0 {
+		p.running = false
+	}
+	return requestIDs
+}
+
+// poll ... queries the status of each request once, using up to parallelism concurrent queries
+func (p *statusPoller) poll(requestIDs [][]byte) {
+	jobs := make(chan []byte)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.parallelism && i < len(requestIDs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for requestID := range jobs {
+				reply, err := p.query(context.Background(), requestID)
+				if err != nil {
+					p.log.Error("Unable to retrieve blob dispersal status, will retry", "dispersal_request_id", hexutil.Encode(requestID), "err", err)
+					continue
+				}
+				p.deliver(requestID, reply)
+			}
+		}()
+	}
+
+	for _, requestID := range requestIDs {
+		jobs <- requestID
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// deliver ... hands a status reply to every request waiting on it, replacing replies they haven't received yet
+func (p *statusPoller) deliver(requestID []byte, reply *grpcdisperser.BlobStatusReply) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.watches[string(requestID)]
+	if !ok {
+		return
+	}
+	for ch := range w.waiters {
+		select {
+		case ch <- reply:
+		default:
+			// only the poller sends, so draining the stale reply frees the buffer
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- reply
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package eigenda
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusPollerSharesQueries(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	s := newTestStore(t, "", d)
+	s.getClient().Config.StatusQueryTimeout = time.Second
+
+	// concurrent requests waiting on the same dispersal share a single query per round
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.pollStatus(context.Background(), []byte{0x01})
+			errs <- err
+		}()
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	d.setStatus(grpcdisperser.BlobStatus_CONFIRMED)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// ~10 rounds of 10ms, instead of ~200 queries when every request polls on its own
+	require.Less(t, d.queryCount(), 30)
+}
+
+func TestStatusPollerQPSCap(t *testing.T) {
+	d := &fakeDisperser{status: grpcdisperser.BlobStatus_PROCESSING}
+	p := newStatusPoller(10*time.Millisecond, time.Second, 20, 4, func() EigenDABackend { return NewClientBackend(d) }, log.New())
+
+	for i := 0; i < 10; i++ {
+		_, stop := p.watch([]byte(fmt.Sprintf("request-%d", i)))
+		defer stop()
+	}
+
+	time.Sleep(500 * time.Millisecond)
+	// at most 20 qps plus the initial burst, even though 10 dispersals are polled every 10ms
+	require.LessOrEqual(t, d.queryCount(), 15)
+	require.Greater(t, d.queryCount(), 0)
+}
+
+// hangingDisperser ... fakeDisperser whose status queries hang until their context is done
+type hangingDisperser struct {
+	fakeDisperser
+}
+
+func (d *hangingDisperser) GetBlobStatus(ctx context.Context, _ []byte) (*grpcdisperser.BlobStatusReply, error) {
+	d.mu.Lock()
+	d.queries++
+	d.mu.Unlock()
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestStatusPollerQueryTimeout(t *testing.T) {
+	d := &hangingDisperser{}
+	p := newStatusPoller(10*time.Millisecond, 20*time.Millisecond, 0, 1,
+		func() EigenDABackend { return NewClientBackend(d) }, log.New())
+
+	_, stop := p.watch([]byte{0x01})
+	defer stop()
+
+	// hanging queries are given up, so that the dispersal is queried again the next rounds
+	require.Eventually(t, func() bool { return d.queryCount() >= 3 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,61 @@
+package eigenda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var _ store.CertRefresher = (*Store)(nil)
+
+// RefreshCert queries the disperser for the current cert of the blob dispersed with requestID. If the disperser
+// re-submitted the blob's batch, e.g. after its confirmation transaction failed, the blob is confirmed in a new
+// batch and the cert returned by the original dispersal no longer resolves. The current cert must commit to the
+// same blob as cert.
+func (e *Store) RefreshCert(ctx context.Context, cert []byte, requestID []byte) ([]byte, error) {
+	var stale verify.Certificate
+	if err := rlp.DecodeBytes(cert, &stale); err != nil {
+		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
+	}
+
+	reply, err := e.poller.query(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve blob dispersal status: %w", err)
+	}
+	info, err := blobInfo(reply, e.getClient().Config.WaitForFinalization)
+	if err != nil {
+		return nil, err
+	}
+	current := (*verify.Certificate)(info)
+
+	if err := sameBlob(&stale, current); err != nil {
+		return nil, fmt.Errorf("disperser returned the cert of another blob for request %x: %w", requestID, err)
+	}
+
+	b, err := rlp.EncodeToBytes(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode DA cert to RLP format: %w", err)
+	}
+	return b, nil
+}
+
+// sameBlob returns an error unless both certs commit to the same blob
+func sameBlob(a, b *verify.Certificate) error {
+	va, err := a.VersionedHash()
+	if err != nil {
+		return err
+	}
+	vb, err := b.VersionedHash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(va[:], vb[:]) || a.BlobHeader.DataLength != b.BlobHeader.DataLength {
+		return errors.New("blob commitment mismatch")
+	}
+	return nil
+}
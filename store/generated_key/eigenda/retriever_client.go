@@ -0,0 +1,74 @@
+package eigenda
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	grpcretriever "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// retrieverClient ... reads blobs through an EigenDA retriever service, which collects the chunks of a blob from
+// the operator nodes and reconstructs it, so that blobs stay retrievable while the disperser is unavailable
+type retrieverClient struct {
+	addr string
+	conn *grpc.ClientConn
+}
+
+// newRetrieverClient ... connects to the retriever service at addr with tlsCfg, or without TLS if disableTLS is
+// set. The connection is established on first use and shared across requests.
+func newRetrieverClient(addr string, tlsCfg *tls.Config, disableTLS bool) (*retrieverClient, error) {
+	creds := insecure.NewCredentials()
+	if !disableTLS {
+		if tlsCfg == nil {
+			tlsCfg = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		creds = credentials.NewTLS(tlsCfg)
+	}
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRetrieveBlobSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to EigenDA retriever %s: %w", addr, err)
+	}
+	return &retrieverClient{addr: addr, conn: conn}, nil
+}
+
+// Retrieve ... returns the encoded blob of cert, reconstructed from the chunks of the first of its quorums the
+// operator nodes serve
+func (c *retrieverClient) Retrieve(ctx context.Context, cert *verify.Certificate) ([]byte, error) {
+	proof := cert.BlobVerificationProof
+	if proof == nil || proof.BatchMetadata == nil || proof.BatchMetadata.BatchHeader == nil {
+		return nil, errors.New("cert lacks the batch header to retrieve the blob with")
+	}
+	if cert.BlobHeader == nil || len(cert.BlobHeader.BlobQuorumParams) == 0 {
+		return nil, errors.New("cert lists no quorums to retrieve the blob from")
+	}
+
+	var errs []error
+	for _, qp := range cert.BlobHeader.BlobQuorumParams {
+		queryCtx, cancel := context.WithTimeout(ctx, disperserQueryTimeout)
+		reply, err := grpcretriever.NewRetrieverClient(c.conn).RetrieveBlob(queryCtx, &grpcretriever.BlobRequest{
+			BatchHeaderHash:      proof.BatchMetadata.BatchHeaderHash,
+			BlobIndex:            proof.BlobIndex,
+			ReferenceBlockNumber: proof.BatchMetadata.BatchHeader.ReferenceBlockNumber,
+			QuorumId:             qp.QuorumNumber,
+		})
+		cancel()
+		if err == nil && len(reply.GetData()) > 0 {
+			return reply.GetData(), nil
+		}
+		if err == nil {
+			err = errors.New("blob has length zero")
+		}
+		errs = append(errs, fmt.Errorf("quorum %d: %w", qp.QuorumNumber, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, fmt.Errorf("EigenDA retriever %s failed to retrieve blob: %w", c.addr, errors.Join(errs...))
+}
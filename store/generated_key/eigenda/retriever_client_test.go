@@ -0,0 +1,80 @@
+package eigenda
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/mocks"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	grpcretriever "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retrieverServer ... retriever service serving a blob from the chunks of a single quorum
+type retrieverServer struct {
+	grpcretriever.UnimplementedRetrieverServer
+	quorum uint32
+	blob   []byte
+}
+
+func (s *retrieverServer) RetrieveBlob(_ context.Context, req *grpcretriever.BlobRequest) (*grpcretriever.BlobReply,
+	error) {
+	if req.QuorumId != s.quorum || req.BlobIndex != 7 || req.ReferenceBlockNumber != 100 {
+		return nil, status.Error(codes.NotFound, "not enough chunks")
+	}
+	return &grpcretriever.BlobReply{Data: s.blob}, nil
+}
+
+func TestGetFromRetriever(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	payload := []byte("blob")
+	encoded, err := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()).EncodeBlob(payload)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	grpcretriever.RegisterRetrieverServer(srv, &retrieverServer{quorum: 1, blob: encoded})
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	s := newTestStore(t, "", &fakeDisperser{})
+	r, err := newRetrieverClient(lis.Addr().String(), nil, true)
+	require.NoError(t, err)
+	s.retrievers = append(s.retrievers, r)
+	backend := mocks.NewMockEigenDABackend(ctrl)
+	s.SetBackend(backend)
+
+	reply, err := (&fakeDisperser{}).GetBlobStatus(context.Background(), nil)
+	require.NoError(t, err)
+	reply.Info.BlobVerificationProof.BatchMetadata.BatchHeader.ReferenceBlockNumber = 100
+	reply.Info.BlobHeader.BlobQuorumParams = []*grpcdisperser.BlobQuorumParam{{QuorumNumber: 0}, {QuorumNumber: 1}}
+	cert, err := rlp.EncodeToBytes((*verify.Certificate)(reply.Info))
+	require.NoError(t, err)
+
+	// the blob is reconstructed from the second quorum when the disperser fails to serve it
+	backend.EXPECT().Retrieve(gomock.Any(), gomock.Any(), uint32(7)).Return(nil, errors.New("unavailable"))
+	actual, err := s.Get(context.Background(), cert)
+	require.NoError(t, err)
+	require.Equal(t, payload, actual)
+
+	// the errors of the disperser and of every quorum are reported if no quorum serves it
+	reply.Info.BlobHeader.BlobQuorumParams = []*grpcdisperser.BlobQuorumParam{{QuorumNumber: 0}}
+	cert, err = rlp.EncodeToBytes((*verify.Certificate)(reply.Info))
+	require.NoError(t, err)
+	backend.EXPECT().Retrieve(gomock.Any(), gomock.Any(), uint32(7)).Return(nil, nil)
+	_, err = s.Get(context.Background(), cert)
+	require.ErrorContains(t, err, "length zero")
+	require.ErrorContains(t, err, "not enough chunks")
+}
@@ -0,0 +1,80 @@
+package memstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	coredisperser "github.com/Layr-Labs/eigenda/disperser"
+)
+
+var _ eigenda.EigenDABackend = (*MemStore)(nil)
+
+// Disperse ... stores an encoded blob like Put, so that an EigenDA store can run against memstore instead of a
+// disperser. Dispersals are confirmed right away, their request ID is the key the blob is stored under, which
+// also serves as the batch header hash of its cert. Replicas don't support dispersals, and faults don't apply.
+func (e *MemStore) Disperse(ctx context.Context, encodedBlob []byte, _ []uint8) (*coredisperser.BlobStatus, []byte, error) {
+	time.Sleep(e.config.PutLatency)
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if e.primary != nil {
+		return nil, nil, errors.New("memstore replicas don't support dispersals")
+	}
+	if uint64(len(encodedBlob)) > e.config.MaxBlobSizeBytes {
+		return nil, nil, fmt.Errorf("blob length %d exceeds max blob size %d", len(encodedBlob), e.config.MaxBlobSizeBytes)
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	cert, err := e.mockCert(encodedBlob)
+	if err != nil {
+		return nil, nil, err
+	}
+	key := cert.BlobVerificationProof.InclusionProof
+	cert.BlobVerificationProof.BatchMetadata.BatchHeaderHash = key
+
+	e.store[string(key)] = encodedBlob
+	e.keyStarts[string(key)] = time.Now()
+	e.dispersals[string(key)] = cert
+
+	status := coredisperser.Confirmed
+	return &status, key, nil
+}
+
+// GetStatus ... returns the finalized status of a dispersal, until its blob expires
+func (e *MemStore) GetStatus(_ context.Context, requestID []byte) (*grpcdisperser.BlobStatusReply, error) {
+	e.RLock()
+	defer e.RUnlock()
+
+	cert, exists := e.dispersals[string(requestID)]
+	if !exists {
+		return nil, fmt.Errorf("dispersal %x not found", requestID)
+	}
+	return &grpcdisperser.BlobStatusReply{
+		Status: grpcdisperser.BlobStatus_FINALIZED,
+		Info:   (*grpcdisperser.BlobInfo)(cert),
+	}, nil
+}
+
+// Retrieve ... returns the encoded blob of a dispersal, the blob index is ignored since every batch holds a
+// single blob
+func (e *MemStore) Retrieve(ctx context.Context, batchHeaderHash []byte, _ uint32) ([]byte, error) {
+	time.Sleep(e.config.GetLatency)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	e.RLock()
+	defer e.RUnlock()
+
+	encodedBlob, exists := e.store[string(batchHeaderHash)]
+	if !exists {
+		return nil, fmt.Errorf("blob of batch %x not found", batchHeaderHash)
+	}
+	return encodedBlob, nil
+}
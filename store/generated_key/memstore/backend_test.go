@@ -0,0 +1,55 @@
+package memstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/generated_key/eigenda"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	grpcdisperser "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEigenDABackend(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier, err := verify.NewVerifier(getDefaultVerifierTestConfig(), nil)
+	require.NoError(t, err)
+	ms, err := New(ctx, verifier, log.New(), getDefaultMemStoreTestConfig())
+	require.NoError(t, err)
+
+	codec := codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec())
+	encoded, err := codec.EncodeBlob([]byte(testPreimage))
+	require.NoError(t, err)
+
+	_, requestID, err := ms.Disperse(ctx, encoded, nil)
+	require.NoError(t, err)
+	reply, err := ms.GetStatus(ctx, requestID)
+	require.NoError(t, err)
+	require.Equal(t, grpcdisperser.BlobStatus_FINALIZED, reply.Status)
+
+	_, err = ms.GetStatus(ctx, []byte("unknown"))
+	require.Error(t, err)
+
+	// an EigenDA store reads the dispersed blob through memstore
+	client := &clients.EigenDAClient{Log: log.New(), Codec: codec}
+	daStore, err := eigenda.NewStore(client, verifier, log.New(), &eigenda.StoreConfig{})
+	require.NoError(t, err)
+	daStore.SetBackend(ms)
+
+	cert, err := rlp.EncodeToBytes((*verify.Certificate)(reply.Info))
+	require.NoError(t, err)
+	actual, err := daStore.Get(ctx, cert)
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), actual)
+
+	// the cert is a regular memstore cert too
+	actual, err = ms.Get(ctx, cert)
+	require.NoError(t, err)
+	require.Equal(t, []byte(testPreimage), actual)
+}
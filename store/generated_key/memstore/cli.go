@@ -3,6 +3,7 @@ package memstore
 import (
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/urfave/cli/v2"
 )
@@ -12,6 +13,14 @@ var (
 	ExpirationFlagName = withFlagPrefix("expiration")
 	PutLatencyFlagName = withFlagPrefix("put-latency")
 	GetLatencyFlagName = withFlagPrefix("get-latency")
+
+	FaultFailReadsFlagName    = withFlagPrefix("fault.fail-reads")
+	FaultFailWritesFlagName   = withFlagPrefix("fault.fail-writes")
+	FaultCorruptReadsFlagName = withFlagPrefix("fault.corrupt-reads")
+	FaultKeyRangeFlagName     = withFlagPrefix("fault.key-range")
+
+	ReplicationRoleFlagName       = withFlagPrefix("replication.role")
+	ReplicationPrimaryURLFlagName = withFlagPrefix("replication.primary-url")
 )
 
 func withFlagPrefix(s string) string {
@@ -32,31 +41,89 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "ENABLED"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     ExpirationFlagName,
-			Usage:    "Duration that a memstore blob/commitment pair is allowed to live.",
-			Value:    25 * time.Minute,
-			EnvVars:  withEnvPrefix(envPrefix, "EXPIRATION"),
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     ExpirationFlagName,
+				Usage:    "Duration that a memstore blob/commitment pair is allowed to live.",
+				Value:    25 * time.Minute,
+				EnvVars:  withEnvPrefix(envPrefix, "EXPIRATION"),
+				Category: category,
+			},
+			Min:       time.Minute,
+			AllowZero: true,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     PutLatencyFlagName,
+				Usage:    "Artificial latency added for memstore backend to mimic EigenDA's dispersal latency.",
+				Value:    0,
+				EnvVars:  withEnvPrefix(envPrefix, "PUT_LATENCY"),
+				Category: category,
+			},
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     GetLatencyFlagName,
+				Usage:    "Artificial latency added for memstore backend to mimic EigenDA's retrieval latency.",
+				Value:    0,
+				EnvVars:  withEnvPrefix(envPrefix, "GET_LATENCY"),
+				Category: category,
+			},
+		},
+		&cli.BoolFlag{
+			Name:     FaultFailReadsFlagName,
+			Usage:    "Simulate a partition where memstore reads fail. Only applies to keys within the fault key range.",
+			EnvVars:  withEnvPrefix(envPrefix, "FAULT_FAIL_READS"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     PutLatencyFlagName,
-			Usage:    "Artificial latency added for memstore backend to mimic EigenDA's dispersal latency.",
-			Value:    0,
-			EnvVars:  withEnvPrefix(envPrefix, "PUT_LATENCY"),
+		&cli.BoolFlag{
+			Name:     FaultFailWritesFlagName,
+			Usage:    "Simulate a partition where memstore writes fail while reads keep working. Only applies to keys within the fault key range.",
+			EnvVars:  withEnvPrefix(envPrefix, "FAULT_FAIL_WRITES"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     GetLatencyFlagName,
-			Usage:    "Artificial latency added for memstore backend to mimic EigenDA's retrieval latency.",
-			Value:    0,
-			EnvVars:  withEnvPrefix(envPrefix, "GET_LATENCY"),
+		&cli.BoolFlag{
+			Name:     FaultCorruptReadsFlagName,
+			Usage:    "Simulate poisoned data by returning corrupted payloads from memstore reads. Only applies to keys within the fault key range.",
+			EnvVars:  withEnvPrefix(envPrefix, "FAULT_CORRUPT_READS"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:    FaultKeyRangeFlagName,
+			Usage:   "Inclusive hex range over the first byte of keccak256(cert) that memstore faults apply to, e.g. '00-7f'. Defaults to every key.",
+			EnvVars: withEnvPrefix(envPrefix, "FAULT_KEY_RANGE"),
+			Action: func(_ *cli.Context, s string) error {
+				_, err := ParseKeyRange(s)
+				return err
+			},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:    ReplicationRoleFlagName,
+			Usage:   "Replication role used to share a single memstore across the proxies of a multi-node devnet. Options are 'standalone', 'primary' (serves its blobs to replicas) and 'replica' (forwards writes to and reads missing blobs from the primary).",
+			Value:   "standalone",
+			EnvVars: withEnvPrefix(envPrefix, "REPLICATION_ROLE"),
+			Action: func(_ *cli.Context, s string) error {
+				_, err := StringToRole(s)
+				return err
+			},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ReplicationPrimaryURLFlagName,
+			Usage:    "Base URL of the primary proxy, e.g. http://proxy-0:3100. Required for replicas.",
+			EnvVars:  withEnvPrefix(envPrefix, "REPLICATION_PRIMARY_URL"),
 			Category: category,
 		},
 	}
 }
 
 func ReadConfig(ctx *cli.Context) Config {
+	// the key range has already been validated by the flag's action
+	keyRange, _ := ParseKeyRange(ctx.String(FaultKeyRangeFlagName))
+	// and so has the replication role
+	role, _ := StringToRole(ctx.String(ReplicationRoleFlagName))
+
 	return Config{
 		// TODO: there has to be a better way to get MaxBlobLengthBytes
 		// right now we get it from the verifier cli, but there's probably a way to share flags more nicely?
@@ -66,5 +133,15 @@ func ReadConfig(ctx *cli.Context) Config {
 		BlobExpiration:   ctx.Duration(ExpirationFlagName),
 		PutLatency:       ctx.Duration(PutLatencyFlagName),
 		GetLatency:       ctx.Duration(GetLatencyFlagName),
+		Faults: FaultConfig{
+			FailReads:    ctx.Bool(FaultFailReadsFlagName),
+			FailWrites:   ctx.Bool(FaultFailWritesFlagName),
+			CorruptReads: ctx.Bool(FaultCorruptReadsFlagName),
+			KeyRange:     keyRange,
+		},
+		Replication: ReplicationConfig{
+			Role:       role,
+			PrimaryURL: ctx.String(ReplicationPrimaryURLFlagName),
+		},
 	}
 }
@@ -0,0 +1,113 @@
+package memstore
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrPartitioned ... returned by operations failed by a simulated network partition
+var ErrPartitioned = errors.New("memstore: simulated network partition")
+
+// KeyRange ... inclusive range over the first byte of keccak256(cert), which is also the key the router
+// uses for cache and fallback targets, so the same range selects the same blobs across backends.
+// The zero value matches no key; use FullKeyRange to match every key.
+type KeyRange struct {
+	Start byte
+	End   byte
+	set   bool
+}
+
+// FullKeyRange ... matches every key
+var FullKeyRange = KeyRange{Start: 0x00, End: 0xff, set: true}
+
+// ParseKeyRange ... parses a range of the form "00-7f" (hex, inclusive). An empty string matches every key.
+func ParseKeyRange(s string) (KeyRange, error) {
+	if s == "" {
+		return FullKeyRange, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return KeyRange{}, fmt.Errorf("invalid key range %q: expected <start>-<end>", s)
+	}
+	start, err := strconv.ParseUint(startStr, 16, 8)
+	if err != nil {
+		return KeyRange{}, fmt.Errorf("invalid key range start %q: %w", startStr, err)
+	}
+	end, err := strconv.ParseUint(endStr, 16, 8)
+	if err != nil {
+		return KeyRange{}, fmt.Errorf("invalid key range end %q: %w", endStr, err)
+	}
+	if start > end {
+		return KeyRange{}, fmt.Errorf("invalid key range %q: start is greater than end", s)
+	}
+
+	return KeyRange{Start: byte(start), End: byte(end), set: true}, nil
+}
+
+// Contains ... whether the range includes the key of the given cert
+func (kr KeyRange) Contains(cert []byte) bool {
+	if !kr.set {
+		return false
+	}
+	b := crypto.Keccak256(cert)[0]
+	return b >= kr.Start && b <= kr.End
+}
+
+func (kr KeyRange) String() string {
+	if !kr.set {
+		return "none"
+	}
+	return fmt.Sprintf("%02x-%02x", kr.Start, kr.End)
+}
+
+// FaultConfig ... simulated partial network partitions, used to exercise the router's handling of
+// asymmetric failures. Faults only apply to certs within KeyRange.
+type FaultConfig struct {
+	// FailReads ... reads return ErrPartitioned (DA layer unreachable)
+	FailReads bool
+	// FailWrites ... writes return ErrPartitioned after dispersal was attempted, while reads keep working
+	FailWrites bool
+	// CorruptReads ... reads return a corrupted payload instead of failing (DA layer serving poisoned data)
+	CorruptReads bool
+	KeyRange     KeyRange
+}
+
+// Enabled ... whether any fault is configured
+func (f FaultConfig) Enabled() bool {
+	return f.FailReads || f.FailWrites || f.CorruptReads
+}
+
+// SetFaults ... replaces the active fault configuration, allowing tests to start and heal partitions at runtime
+func (e *MemStore) SetFaults(f FaultConfig) {
+	e.faultMu.Lock()
+	defer e.faultMu.Unlock()
+
+	if f.Enabled() {
+		e.l.Warn("memstore fault injection enabled", "fail_reads", f.FailReads, "fail_writes", f.FailWrites,
+			"corrupt_reads", f.CorruptReads, "key_range", f.KeyRange)
+	}
+	e.faults = f
+}
+
+func (e *MemStore) getFaults() FaultConfig {
+	e.faultMu.RLock()
+	defer e.faultMu.RUnlock()
+	return e.faults
+}
+
+// corrupt ... returns a copy of the payload with its bits flipped
+func corrupt(data []byte) []byte {
+	c := make([]byte, len(data))
+	for i, b := range data {
+		c[i] = ^b
+	}
+	if len(c) == 0 {
+		c = []byte{0xff}
+	}
+	return c
+}
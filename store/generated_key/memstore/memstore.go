@@ -3,6 +3,7 @@ package memstore
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
@@ -29,6 +30,10 @@ type Config struct {
 	// artificial latency added for memstore backend to mimic eigenda's latency
 	PutLatency time.Duration
 	GetLatency time.Duration
+	// simulated network partitions, see FaultConfig
+	Faults FaultConfig
+	// sharing the blob set across proxies, see ReplicationConfig
+	Replication ReplicationConfig
 }
 
 /*
@@ -43,25 +48,50 @@ type MemStore struct {
 	l         log.Logger
 	keyStarts map[string]time.Time
 	store     map[string][]byte
-	verifier  *verify.Verifier
-	codec     codecs.BlobCodec
+	// certs of blobs dispersed through the EigenDABackend API, by the key the blob is stored under
+	dispersals map[string]*verify.Certificate
+	verifier   *verify.Verifier
+	codec      codecs.BlobCodec
+
+	faultMu sync.RWMutex
+	faults  FaultConfig
+
+	// primary is set for replicas
+	primary *primaryClient
 
 	reads int
 }
 
-var _ store.GeneratedKeyStore = (*MemStore)(nil)
+var (
+	_ store.GeneratedKeyStore   = (*MemStore)(nil)
+	_ store.Lister              = (*MemStore)(nil)
+	_ store.NetworkInfoReporter = (*MemStore)(nil)
+)
 
 // New ... constructor
 func New(
 	ctx context.Context, verifier *verify.Verifier, l log.Logger, config Config,
 ) (*MemStore, error) {
 	store := &MemStore{
-		l:         l,
-		config:    config,
-		keyStarts: make(map[string]time.Time),
-		store:     make(map[string][]byte),
-		verifier:  verifier,
-		codec:     codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
+		l:          l,
+		config:     config,
+		keyStarts:  make(map[string]time.Time),
+		store:      make(map[string][]byte),
+		dispersals: make(map[string]*verify.Certificate),
+		verifier:   verifier,
+		codec:      codecs.NewIFFTCodec(codecs.NewDefaultBlobCodec()),
+	}
+	store.SetFaults(config.Faults)
+
+	switch config.Replication.Role {
+	case RolePrimary:
+		l.Info("memstore replication enabled", "role", RolePrimary, "route", ReplicationRoute)
+	case RoleReplica:
+		if config.Replication.PrimaryURL == "" {
+			return nil, fmt.Errorf("memstore replica requires a primary url")
+		}
+		l.Info("memstore replication enabled", "role", RoleReplica, "primary", config.Replication.PrimaryURL)
+		store.primary = newPrimaryClient(config.Replication.PrimaryURL)
 	}
 
 	if store.config.BlobExpiration != 0 {
@@ -97,6 +127,7 @@ func (e *MemStore) pruneExpired() {
 		if time.Since(dur) >= e.config.BlobExpiration {
 			delete(e.keyStarts, commit)
 			delete(e.store, commit)
+			delete(e.dispersals, commit)
 
 			e.l.Info("blob pruned", "commit", commit)
 		}
@@ -104,11 +135,17 @@ func (e *MemStore) pruneExpired() {
 }
 
 // Get fetches a value from the store.
-func (e *MemStore) Get(_ context.Context, commit []byte) ([]byte, error) {
+func (e *MemStore) Get(ctx context.Context, commit []byte) ([]byte, error) {
 	time.Sleep(e.config.GetLatency)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	e.reads++
-	e.RLock()
-	defer e.RUnlock()
+
+	faults := e.getFaults()
+	if faults.FailReads && faults.KeyRange.Contains(commit) {
+		return nil, fmt.Errorf("%w: read failed", ErrPartitioned)
+	}
 
 	var cert verify.Certificate
 	err := rlp.DecodeBytes(commit, &cert)
@@ -116,10 +153,9 @@ func (e *MemStore) Get(_ context.Context, commit []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
 	}
 
-	var encodedBlob []byte
-	var exists bool
-	if encodedBlob, exists = e.store[string(cert.BlobVerificationProof.InclusionProof)]; !exists {
-		return nil, fmt.Errorf("commitment key not found")
+	encodedBlob, err := e.getEncoded(ctx, string(cert.BlobVerificationProof.InclusionProof), commit)
+	if err != nil {
+		return nil, err
 	}
 
 	// Don't need to do this really since it's a mock store
@@ -128,16 +164,72 @@ func (e *MemStore) Get(_ context.Context, commit []byte) ([]byte, error) {
 		return nil, err
 	}
 
-	return e.codec.DecodeBlob(encodedBlob)
+	data, err := e.codec.DecodeBlob(encodedBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	if faults.CorruptReads && faults.KeyRange.Contains(commit) {
+		return corrupt(data), nil
+	}
+	return data, nil
+}
+
+// getEncoded ... returns the encoded blob stored under key. Replicas fetch blobs they don't hold
+// from the primary and keep a local copy.
+func (e *MemStore) getEncoded(ctx context.Context, key string, commit []byte) ([]byte, error) {
+	e.RLock()
+	encodedBlob, exists := e.store[key]
+	e.RUnlock()
+	if exists {
+		return encodedBlob, nil
+	}
+
+	if e.primary == nil {
+		return nil, fmt.Errorf("commitment key not found")
+	}
+
+	encodedBlob, err := e.primary.getEncoded(ctx, commit)
+	if errors.Is(err, errPrimaryNotFound) {
+		return nil, fmt.Errorf("commitment key not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	e.store[key] = encodedBlob
+	e.keyStarts[key] = time.Now()
+	return encodedBlob, nil
 }
 
 // Put inserts a value into the store.
-func (e *MemStore) Put(_ context.Context, value []byte) ([]byte, error) {
+func (e *MemStore) Put(ctx context.Context, value []byte) ([]byte, error) {
 	time.Sleep(e.config.PutLatency)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if uint64(len(value)) > e.config.MaxBlobSizeBytes {
 		return nil, fmt.Errorf("%w: blob length %d, max blob size %d", store.ErrProxyOversizedBlob, len(value), e.config.MaxBlobSizeBytes)
 	}
 
+	// replicas don't generate certs themselves so that every proxy sees the same blob set
+	if e.primary != nil {
+		certBytes, err := e.primary.put(ctx, value)
+		if err != nil {
+			return nil, err
+		}
+		// the cert is only known once the primary stored the blob, so a partitioned replica write
+		// behaves like a lost response
+		if faults := e.getFaults(); faults.FailWrites && faults.KeyRange.Contains(certBytes) {
+			return nil, fmt.Errorf("%w: write failed", ErrPartitioned)
+		}
+		if encodedVal, err := e.codec.EncodeBlob(value); err == nil {
+			store.RecordEncodedBlob(ctx, encodedVal)
+		}
+		return certBytes, nil
+	}
+
 	e.Lock()
 	defer e.Unlock()
 
@@ -145,7 +237,39 @@ func (e *MemStore) Put(_ context.Context, value []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	store.RecordEncodedBlob(ctx, encodedVal)
+
+	cert, err := e.mockCert(encodedVal)
+	if err != nil {
+		return nil, err
+	}
 
+	certBytes, err := rlp.EncodeToBytes(cert)
+	if err != nil {
+		return nil, err
+	}
+	if faults := e.getFaults(); faults.FailWrites && faults.KeyRange.Contains(certBytes) {
+		return nil, fmt.Errorf("%w: write failed", ErrPartitioned)
+	}
+
+	// construct key
+	bytesKeys := cert.BlobVerificationProof.InclusionProof
+
+	certStr := string(bytesKeys)
+
+	if _, exists := e.store[certStr]; exists {
+		return nil, fmt.Errorf("commitment key already exists")
+	}
+
+	e.store[certStr] = encodedVal
+	// add expiration
+	e.keyStarts[certStr] = time.Now()
+
+	return certBytes, nil
+}
+
+// mockCert ... returns a cert committing to encodedVal, with a random inclusion proof the blob is stored under
+func (e *MemStore) mockCert(encodedVal []byte) (*verify.Certificate, error) {
 	commitment, err := e.verifier.Commit(encodedVal)
 	if err != nil {
 		return nil, err
@@ -198,30 +322,18 @@ func (e *MemStore) Put(_ context.Context, value []byte) ([]byte, error) {
 		},
 	}
 
-	certBytes, err := rlp.EncodeToBytes(cert)
-	if err != nil {
-		return nil, err
-	}
-	// construct key
-	bytesKeys := cert.BlobVerificationProof.InclusionProof
-
-	certStr := string(bytesKeys)
-
-	if _, exists := e.store[certStr]; exists {
-		return nil, fmt.Errorf("commitment key already exists")
-	}
-
-	e.store[certStr] = encodedVal
-	// add expiration
-	e.keyStarts[certStr] = time.Now()
-
-	return certBytes, nil
+	return cert, nil
 }
 
 func (e *MemStore) Verify(_, _ []byte) error {
 	return nil
 }
 
+// VerificationMode ... memstore only recomputes the KZG commitment on reads since its certs are mocked
+func (e *MemStore) VerificationMode() string {
+	return store.VerificationKZG
+}
+
 // Stats ... returns the current usage metrics of the in-memory key-value data store.
 func (e *MemStore) Stats() *store.Stats {
 	e.RLock()
@@ -232,6 +344,34 @@ func (e *MemStore) Stats() *store.Stats {
 	}
 }
 
+// NetworkInfo ... returns the max blob size and blob expiration the memstore emulates EigenDA with
+func (e *MemStore) NetworkInfo(_ context.Context) (store.NetworkInfo, error) {
+	return store.NetworkInfo{
+		MaxBlobSizeBytes: e.config.MaxBlobSizeBytes,
+		Retention:        e.config.BlobExpiration,
+	}, nil
+}
+
 func (e *MemStore) BackendType() store.BackendType {
 	return store.MemoryBackendType
 }
+
+// Keys ... calls fn with the key of every stored blob, i.e. the inclusion proof of its cert
+func (e *MemStore) Keys(ctx context.Context, fn func(key []byte) error) error {
+	e.RLock()
+	keys := make([]string, 0, len(e.store))
+	for k := range e.store {
+		keys = append(keys, k)
+	}
+	e.RUnlock()
+
+	for _, k := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn([]byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package memstore
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
 	"github.com/Layr-Labs/eigenda-proxy/verify"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -127,3 +132,151 @@ func TestLatency(t *testing.T) {
 	require.GreaterOrEqual(t, time.Since(timeBeforeGet), getLatency)
 
 }
+
+func TestFaults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier, err := verify.NewVerifier(getDefaultVerifierTestConfig(), nil)
+	require.NoError(t, err)
+
+	ms, err := New(ctx, verifier, log.New(), getDefaultMemStoreTestConfig())
+	require.NoError(t, err)
+
+	expected := []byte(testPreimage)
+	key, err := ms.Put(ctx, expected)
+	require.NoError(t, err)
+
+	b := crypto.Keccak256(key)[0]
+	inRange := KeyRange{Start: b, End: b, set: true}
+	outOfRange := KeyRange{Start: b + 1, End: b + 1, set: true}
+
+	// reads succeed but writes fail
+	ms.SetFaults(FaultConfig{FailWrites: true, KeyRange: FullKeyRange})
+	_, err = ms.Put(ctx, expected)
+	require.ErrorIs(t, err, ErrPartitioned)
+	actual, err := ms.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// only keys within the range fail
+	ms.SetFaults(FaultConfig{FailReads: true, KeyRange: inRange})
+	_, err = ms.Get(ctx, key)
+	require.ErrorIs(t, err, ErrPartitioned)
+
+	ms.SetFaults(FaultConfig{FailReads: true, KeyRange: outOfRange})
+	_, err = ms.Get(ctx, key)
+	require.NoError(t, err)
+
+	// poisoned reads
+	ms.SetFaults(FaultConfig{CorruptReads: true, KeyRange: inRange})
+	actual, err = ms.Get(ctx, key)
+	require.NoError(t, err)
+	require.NotEqual(t, expected, actual)
+
+	// healing the partition
+	ms.SetFaults(FaultConfig{})
+	actual, err = ms.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestParseKeyRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected KeyRange
+		wantErr  bool
+	}{
+		{in: "", expected: FullKeyRange},
+		{in: "00-7f", expected: KeyRange{Start: 0x00, End: 0x7f, set: true}},
+		{in: "a0-a0", expected: KeyRange{Start: 0xa0, End: 0xa0, set: true}},
+		{in: "80-10", wantErr: true},
+		{in: "00", wantErr: true},
+		{in: "00-100", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			kr, err := ParseKeyRange(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, kr)
+		})
+	}
+}
+
+func TestReplication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier, err := verify.NewVerifier(getDefaultVerifierTestConfig(), nil)
+	require.NoError(t, err)
+
+	primaryCfg := getDefaultMemStoreTestConfig()
+	primaryCfg.Replication = ReplicationConfig{Role: RolePrimary}
+	primary, err := New(ctx, verifier, log.New(), primaryCfg)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(ReplicationRoute, primary.ReplicationHandler())
+	mux.Handle(ReplicationRoute+"/", primary.ReplicationHandler())
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	replicaCfg := getDefaultMemStoreTestConfig()
+	replicaCfg.Replication = ReplicationConfig{Role: RoleReplica, PrimaryURL: srv.URL}
+	replicaA, err := New(ctx, verifier, log.New(), replicaCfg)
+	require.NoError(t, err)
+	replicaB, err := New(ctx, verifier, log.New(), replicaCfg)
+	require.NoError(t, err)
+	require.Nil(t, replicaA.ReplicationHandler())
+
+	// a write to one replica is readable from the primary and every other replica
+	expected := []byte(testPreimage)
+	key, err := replicaA.Put(ctx, expected)
+	require.NoError(t, err)
+
+	for _, ms := range []*MemStore{primary, replicaA, replicaB} {
+		actual, err := ms.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	// writes to the primary are visible to replicas as well
+	key, err = primary.Put(ctx, expected)
+	require.NoError(t, err)
+	actual, err := replicaB.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// unknown certs are reported as missing
+	otherPrimary, err := New(ctx, verifier, log.New(), getDefaultMemStoreTestConfig())
+	require.NoError(t, err)
+	unknown, err := otherPrimary.Put(ctx, expected)
+	require.NoError(t, err)
+	_, err = replicaA.Get(ctx, unknown)
+	require.ErrorContains(t, err, "commitment key not found")
+}
+
+func TestConformance(t *testing.T) {
+	verifierConfig := getDefaultVerifierTestConfig()
+	// large values need more SRS points than the other tests
+	verifierConfig.KzgConfig.SRSOrder = 4 * storetest.LargeValueSize / 32
+	verifierConfig.KzgConfig.SRSNumberToLoad = verifierConfig.KzgConfig.SRSOrder
+	verifier, err := verify.NewVerifier(verifierConfig, nil)
+	require.NoError(t, err)
+
+	storetest.RunGeneratedKeyConformanceTests(t, func(t *testing.T, ttl time.Duration) store.GeneratedKeyStore {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		config := getDefaultMemStoreTestConfig()
+		config.BlobExpiration = ttl
+		ms, err := New(ctx, verifier, log.New(), config)
+		require.NoError(t, err)
+		return ms
+	})
+}
@@ -0,0 +1,167 @@
+package memstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReplicationRoute ... route under which a primary memstore serves its blobs to replicas.
+// POST stores a payload and returns its cert, GET /<0x cert> returns the encoded blob for a cert.
+const ReplicationRoute = "/memstore/blobs"
+
+// Role ... replication role of a memstore, used to share a single fake DA layer across the proxies
+// of a multi-node devnet
+type Role string
+
+const (
+	// RoleStandalone ... memstore isn't replicated
+	RoleStandalone Role = ""
+	// RolePrimary ... memstore holds the authoritative blob set and serves it to replicas
+	RolePrimary Role = "primary"
+	// RoleReplica ... memstore forwards writes to the primary and fetches blobs missing locally from it
+	RoleReplica Role = "replica"
+)
+
+// StringToRole ... parses a replication role. "standalone" and the empty string map to RoleStandalone.
+func StringToRole(s string) (Role, error) {
+	switch strings.ToLower(s) {
+	case "", "standalone":
+		return RoleStandalone, nil
+	case string(RolePrimary):
+		return RolePrimary, nil
+	case string(RoleReplica):
+		return RoleReplica, nil
+	default:
+		return "", fmt.Errorf("unknown memstore replication role %q", s)
+	}
+}
+
+// ReplicationConfig ... primary/replica replication of the memstore over HTTP
+type ReplicationConfig struct {
+	Role Role
+	// PrimaryURL is the base URL of the primary proxy, required for replicas
+	PrimaryURL string
+}
+
+var errPrimaryNotFound = errors.New("blob not found on memstore primary")
+
+// primaryClient ... client used by replicas to forward writes to and fetch blobs from the primary
+type primaryClient struct {
+	url    string
+	client *http.Client
+}
+
+func newPrimaryClient(url string) *primaryClient {
+	return &primaryClient{
+		url:    strings.TrimSuffix(url, "/") + ReplicationRoute,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// put ... stores a payload on the primary, returning its cert
+func (c *primaryClient) put(ctx context.Context, value []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(value))
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// getEncoded ... fetches the encoded blob for a cert from the primary
+func (c *primaryClient) getEncoded(ctx context.Context, cert []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/"+hexutil.Encode(cert), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+func (c *primaryClient) do(req *http.Request) ([]byte, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach memstore primary: %w", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memstore primary response: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return b, nil
+	case http.StatusNotFound:
+		return nil, errPrimaryNotFound
+	default:
+		return nil, fmt.Errorf("memstore primary returned status %d: %s", resp.StatusCode, b)
+	}
+}
+
+// ReplicationHandler ... returns the handler serving this memstore's blobs to replicas, or nil
+// if the memstore isn't a primary. It is expected to be mounted on ReplicationRoute.
+func (e *MemStore) ReplicationHandler() http.Handler {
+	if e.config.Replication.Role != RolePrimary {
+		return nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			value, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			cert, err := e.Put(r.Context(), value)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			_, _ = w.Write(cert)
+
+		case http.MethodGet:
+			cert, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, ReplicationRoute+"/"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			encoded, err := e.getLocalEncoded(cert)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(encoded)
+
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// getLocalEncoded ... returns the encoded blob for an RLP encoded cert held by this memstore
+func (e *MemStore) getLocalEncoded(commit []byte) ([]byte, error) {
+	var cert verify.Certificate
+	if err := rlp.DecodeBytes(commit, &cert); err != nil {
+		return nil, fmt.Errorf("failed to decode DA cert to RLP format: %w", err)
+	}
+
+	e.RLock()
+	defer e.RUnlock()
+
+	encodedBlob, exists := e.store[string(cert.BlobVerificationProof.InclusionProof)]
+	if !exists {
+		return nil, fmt.Errorf("commitment key not found")
+	}
+	return encodedBlob, nil
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// hedgedResult ... outcome of one of the reads issued by hedgedReadAll
+type hedgedResult struct {
+	data    []byte
+	meta    *ResponseMeta
+	err     error
+	eigenda bool
+}
+
+// hedgedReadAll ... reads a blob from all readable cache targets in parallel, and from EigenDA once the hedge delay
+// elapsed or every cache target missed. The first verified blob is served and the other reads are cancelled, so
+// that a slow cache target doesn't hold up the read. The EigenDA read falls back to the fallback targets as
+// sequential reads do, and populates the read-through caches.
+func (r *Router) hedgedReadAll(ctx context.Context, key []byte) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the reads that lost the race
+
+	r.cacheLock.RLock()
+	var targets []PrecomputedKeyStore
+	for _, c := range r.caches {
+		if r.readable(c) && r.breakers.allow(c) {
+			targets = append(targets, c)
+		}
+	}
+	r.cacheLock.RUnlock()
+
+	// each read records the backend serving it into its own response metadata, only the winner's is kept
+	results := make(chan hedgedResult, len(targets)+1)
+	for _, src := range targets {
+		src := src
+		go func() {
+			rctx, meta := WithResponseMeta(ctx)
+			data, err := r.readTarget(rctx, src, key, false)
+			if err != nil {
+				r.logTargetReadError(ctx, src, err)
+			}
+			results <- hedgedResult{data: data, meta: meta, err: err}
+		}()
+	}
+
+	pending := len(targets)
+	hedged := false
+	startEigenDA := func() {
+		hedged = true
+		pending++
+		go func() {
+			rctx, meta := WithResponseMeta(ctx)
+			data, err := r.readEigenDAOrFallbacks(rctx, key)
+			results <- hedgedResult{data: data, meta: meta, err: err, eigenda: true}
+		}()
+	}
+	if pending == 0 {
+		startEigenDA()
+	}
+
+	delay := time.NewTimer(r.hedgeDelay)
+	defer delay.Stop()
+
+	var err error
+	for pending > 0 {
+		select {
+		case <-delay.C:
+			if !hedged {
+				startEigenDA()
+			}
+
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				r.m.RecordHedgedRead(res.meta.Backend().String(), hedged)
+				if meta := ResponseMetaFromContext(ctx); meta != nil {
+					meta.copyServedBy(res.meta)
+				}
+				return res.data, nil
+			}
+			if res.eigenda {
+				err = res.err
+			}
+			if pending == 0 && !hedged {
+				startEigenDA()
+			}
+		}
+	}
+
+	r.m.RecordHedgedRead("none", hedged)
+	return nil, err
+}
+
+// readEigenDAOrFallbacks ... reads a blob from EigenDA, racing it against the fallback targets once it exceeds the
+// read SLO if enabled, and from the fallback targets if it failed otherwise. Blobs are written to the read-through
+// caches, unless decoded by a fallback of the EigenDA store.
+func (r *Router) readEigenDAOrFallbacks(ctx context.Context, key []byte) ([]byte, error) {
+	if r.readSLO > 0 && r.fallbackEnabled() {
+		return r.raceRead(ctx, key)
+	}
+
+	data, fallbackDecoded, err := r.readEigenDA(ctx, key)
+	if err != nil && r.fallbackEnabled() {
+		data, err = r.multiSourceRead(ctx, key, true)
+		if err != nil {
+			RequestLogger(ctx, r.log).Error("Failed to read from fallback targets", "err", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !fallbackDecoded {
+		r.fillReadThroughCaches(ctx, key, data)
+	}
+	return data, nil
+}
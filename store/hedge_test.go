@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// slowCacheStore ... mapStore serving its values after a delay, or failing once the read is cancelled
+type slowCacheStore struct {
+	mapStore
+	delay time.Duration
+}
+
+func (s *slowCacheStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	select {
+	case <-time.After(s.delay):
+		return s.mapStore.Get(ctx, key)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestHedgedReads(t *testing.T) {
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	tests := []struct {
+		name       string
+		cacheDelay time.Duration
+		cached     map[string][]byte
+		daDelay    time.Duration
+		servedBy   BackendType
+		hedged     string
+	}{
+		{name: "CacheWithinDelay", cached: map[string][]byte{key: blob}, daDelay: time.Hour,
+			servedBy: RedisBackendType, hedged: "false"},
+		{name: "EigenDAAfterDelay", cacheDelay: time.Hour, cached: map[string][]byte{key: blob},
+			servedBy: EigenDABackendType, hedged: "true"},
+		{name: "EigenDAAfterCacheMiss", cached: map[string][]byte{}, servedBy: EigenDABackendType, hedged: "true"},
+		{name: "CacheAfterDelay", cacheDelay: 100 * time.Millisecond, cached: map[string][]byte{key: blob},
+			daDelay: time.Hour, servedBy: RedisBackendType, hedged: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			da := &slowDAStore{fakeDAStore: fakeDAStore{blob: blob, unavailable: true}, delay: tt.daDelay}
+			cache := &slowCacheStore{mapStore: mapStore{data: tt.cached}, delay: tt.cacheDelay}
+			m := metrics.NewMetrics("test")
+			r, err := NewRouter(da, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+				RouterOptions{Metrics: m, HedgeDelay: 10 * time.Millisecond})
+			require.NoError(t, err)
+
+			ctx, meta := WithResponseMeta(context.Background())
+			data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+			require.NoError(t, err)
+			require.Equal(t, blob, data)
+			require.Equal(t, tt.servedBy, meta.Backend())
+			require.Equal(t, 1.0, testutil.ToFloat64(m.HedgedReads.WithLabelValues(tt.servedBy.String(), tt.hedged)))
+		})
+	}
+}
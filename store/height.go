@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+)
+
+// WithRollupHeight ... returns a child context whose write is posted for the rollup block or batch number height,
+// which the written commitment is indexed by
+func WithRollupHeight(ctx context.Context, height uint64) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.RollupHeight = &height })
+}
+
+// WithL1Block ... returns a child context whose backfilled commitment was included in L1 block n, which the
+// commitment is indexed by
+func WithL1Block(ctx context.Context, n uint64) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.L1Block = &n })
+}
+
+// indexHeights ... records the rollup height and L1 block of the request's commitment in the index, if known
+func (r *Router) indexHeights(ctx context.Context, key []byte) {
+	meta := RequestMetaFromContext(ctx)
+	if meta.RollupHeight != nil {
+		r.index.SetHeight(key, index.LayerRollup, *meta.RollupHeight)
+	}
+	if meta.L1Block != nil {
+		r.index.SetHeight(key, index.LayerL1, *meta.L1Block)
+	}
+}
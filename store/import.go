@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Import ... indexes a commitment synced from another proxy instance, e.g. the active proxy of a warm standby
+// pair. If value is set, it is verified against the commitment and written to the writable cache targets that
+// don't hold it yet, so that the blob is served from cache after a failover.
+func (r *Router) Import(ctx context.Context, cm commitments.CommitmentMode, commitment []byte, size int,
+	value []byte) error {
+	if cm == commitments.OptimismKeccak {
+		return errors.New("importing is unsupported for keccak256 commitments")
+	}
+	if r.eigenda == nil {
+		return errors.New("expected EigenDA backend for DA commitment type, but none configured")
+	}
+
+	r.indexCert(commitment, size, cm)
+	if value == nil || !r.cacheEnabled() {
+		return nil
+	}
+
+	if err := r.verify(ctx, r.eigenda, commitment, value); err != nil {
+		return fmt.Errorf("failed to verify imported blob: %w", err)
+	}
+
+	r.cacheLock.RLock()
+	defer r.cacheLock.RUnlock()
+
+	e, _ := r.index.Get(commitment)
+	key := crypto.Keccak256(commitment)
+	var errs []error
+	for _, c := range r.caches {
+		if _, ok := e.Backends[c.BackendType().String()]; ok || !r.writable(c) {
+			continue
+		}
+		if err := r.reserveQuota(ctx, c, len(value)); err != nil {
+			errs = append(errs, r.skipWrite(c, commitment, err).Err)
+			continue
+		}
+		if o := r.putRedundant(ctx, c, commitment, key, value); !o.Succeeded() {
+			errs = append(errs, fmt.Errorf("%s: %s after %d attempts: %w", c.BackendType(), o.Status, o.Attempts, o.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
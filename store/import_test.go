@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	cache := &mapStore{data: make(map[string][]byte)}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// index only
+	require.NoError(t, r.Import(ctx, commitments.SimpleCommitmentMode, []byte("a"), len(blob), nil))
+	e, ok := r.Index().Get([]byte("a"))
+	require.True(t, ok)
+	require.Equal(t, len(blob), e.Size)
+	require.Equal(t, string(commitments.SimpleCommitmentMode), e.Mode)
+	require.Empty(t, cache.data)
+
+	// blobs are written to the caches
+	require.NoError(t, r.Import(ctx, commitments.SimpleCommitmentMode, []byte("b"), len(blob), blob))
+	require.Equal(t, blob, cache.data[string(crypto.Keccak256([]byte("b")))])
+	e, _ = r.Index().Get([]byte("b"))
+	require.Contains(t, e.Backends, RedisBackendType.String())
+
+	require.Error(t, r.Import(ctx, commitments.OptimismKeccak, []byte("c"), len(blob), blob))
+}
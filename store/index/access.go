@@ -0,0 +1,138 @@
+package index
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultAccessWindow ... window recent reads are counted over
+const DefaultAccessWindow = 10 * time.Minute
+
+// AccessOptions ... recording of the reads of the indexed blobs. Only a SampleRate fraction of the reads is
+// recorded, each counting for 1/SampleRate reads, to bound the overhead of recording the reads of hot blobs.
+// Recent reads are counted over a sliding Window. Zero values record every read and use DefaultAccessWindow.
+type AccessOptions struct {
+	SampleRate float64
+	Window     time.Duration
+}
+
+// Check ... validates the access options
+func (o AccessOptions) Check() error {
+	if o.SampleRate < 0 || o.SampleRate > 1 {
+		return fmt.Errorf("access sample rate must be between 0 and 1, got %v", o.SampleRate)
+	}
+	if o.Window < 0 {
+		return fmt.Errorf("access window must not be negative")
+	}
+	return nil
+}
+
+// SetAccessOptions ... sets how reads are recorded, see AccessOptions. Must be called before the index is used.
+func (i *Index) SetAccessOptions(o AccessOptions) {
+	if o.SampleRate == 0 {
+		o.SampleRate = 1
+	}
+	if o.Window == 0 {
+		o.Window = DefaultAccessWindow
+	}
+	i.access = o
+	i.readWeight = max(1, int(math.Round(1/o.SampleRate)))
+}
+
+// accessWindow ... number of the access window t falls into, and the elapsed fraction of that window
+func (i *Index) accessWindow(t time.Time) (int64, float64) {
+	w := int64(i.access.Window)
+	return t.UnixNano() / w, float64(t.UnixNano()%w) / float64(w)
+}
+
+// addRecentReads ... counts n reads of the entry into the access window w
+func (e *Entry) addRecentReads(w int64, n int) {
+	switch e.window {
+	case w:
+	case w - 1:
+		e.prevWindowReads, e.windowReads = e.windowReads, 0
+	default:
+		e.prevWindowReads, e.windowReads = 0, 0
+	}
+	e.window = w
+	e.windowReads += n
+}
+
+// recentReads ... estimated reads of the entry within the sliding window ending elapsed into access window w.
+// The reads of the previous access window are weighted by the share of it still covered by the sliding window.
+func (e *Entry) recentReads(w int64, elapsed float64) int {
+	switch e.window {
+	case w:
+		return e.windowReads + int(math.Round(float64(e.prevWindowReads)*(1-elapsed)))
+	case w - 1:
+		return int(math.Round(float64(e.windowReads) * (1 - elapsed)))
+	default:
+		return 0
+	}
+}
+
+// HotEntry ... an entry and its recent reads
+type HotEntry struct {
+	Entry
+	RecentReads int
+}
+
+// AccessReport ... reads of the indexed blobs within the access window ending at At
+type AccessReport struct {
+	At         time.Time
+	Window     time.Duration
+	SampleRate float64
+	// Reads is the number of reads within the window, of Blobs distinct blobs of Bytes total size, i.e. the working
+	// set a cache must hold to serve them
+	Reads int
+	Blobs int
+	Bytes int64
+	// Hottest are the most read blobs within the window, most read first
+	Hottest []HotEntry
+}
+
+// Hottest ... reports the reads within the access window and the limit most read blobs
+func (i *Index) Hottest(limit int) AccessReport {
+	now := time.Now()
+	w, elapsed := i.accessWindow(now)
+	rep := AccessReport{At: now, Window: i.access.Window, SampleRate: i.access.SampleRate}
+
+	i.mu.RLock()
+	var hot []HotEntry
+	for _, e := range i.entries {
+		n := e.recentReads(w, elapsed)
+		if n == 0 {
+			continue
+		}
+		rep.Reads += n
+		rep.Blobs++
+		rep.Bytes += int64(e.Size)
+		if limit > 0 && (len(hot) < limit || n >= hot[len(hot)-1].RecentReads) {
+			hot = insertHot(hot, HotEntry{Entry: e.clone(), RecentReads: n}, limit)
+		}
+	}
+	i.mu.RUnlock()
+
+	rep.Hottest = hot
+	return rep
+}
+
+// insertHot ... inserts h into hot, which is sorted by recent reads then sequence number and bounded to limit
+func insertHot(hot []HotEntry, h HotEntry, limit int) []HotEntry {
+	idx := sort.Search(len(hot), func(j int) bool {
+		return hot[j].RecentReads < h.RecentReads ||
+			(hot[j].RecentReads == h.RecentReads && hot[j].Seq > h.Seq)
+	})
+	if idx >= limit {
+		return hot
+	}
+	hot = append(hot, HotEntry{})
+	copy(hot[idx+1:], hot[idx:])
+	hot[idx] = h
+	if len(hot) > limit {
+		hot = hot[:limit]
+	}
+	return hot
+}
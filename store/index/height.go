@@ -0,0 +1,188 @@
+package index
+
+import (
+	"slices"
+	"sort"
+)
+
+// Layer ... chain whose block heights commitments are indexed by
+type Layer string
+
+const (
+	// LayerRollup ... rollup (L2) block or batch numbers, supplied by the batcher along with its writes
+	LayerRollup Layer = "l2"
+	// LayerL1 ... numbers of the L1 blocks the commitments were included in, learned by the L1 scanner
+	LayerL1 Layer = "l1"
+)
+
+// heightScope ... heights of one layer of the commitments of one tenant, since the rollups of different tenants
+// have overlapping heights. L1 blocks are shared by every tenant.
+type heightScope struct {
+	layer  Layer
+	tenant string
+}
+
+func scopeOf(layer Layer, tenant string) heightScope {
+	if layer == LayerL1 {
+		tenant = ""
+	}
+	return heightScope{layer: layer, tenant: tenant}
+}
+
+// heightIndex ... indexed heights of a scope mapped to the keys of their commitments. Heights whose commitments
+// were all dropped are kept with an empty key list until more than half of them are empty, so that dropping the
+// oldest entries doesn't shift the sorted heights every time.
+type heightIndex struct {
+	sorted []uint64
+	keys   map[uint64][]string
+	empty  int
+}
+
+// height ... returns the height of the entry on layer, nil if none is set
+func (e *Entry) height(layer Layer) *uint64 {
+	switch layer {
+	case LayerRollup:
+		return e.RollupHeight
+	case LayerL1:
+		return e.L1Block
+	default:
+		return nil
+	}
+}
+
+func (e *Entry) setHeight(layer Layer, height *uint64) {
+	switch layer {
+	case LayerRollup:
+		e.RollupHeight = height
+	case LayerL1:
+		e.L1Block = height
+	default:
+	}
+}
+
+// SetHeight ... records the height of the indexed key on layer, replacing the one recorded before. Returns false
+// if key isn't indexed or layer is unknown.
+func (i *Index) SetHeight(key []byte, layer Layer, height uint64) bool {
+	if layer != LayerRollup && layer != LayerL1 {
+		return false
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	i.unregisterHeight(e, layer)
+	e.setHeight(layer, &height)
+	i.registerHeight(e, layer)
+	return true
+}
+
+// AtHeight ... returns the entries of the tenant's commitments indexed at the greatest height on layer at or below
+// height, along with that height. If the batcher supplies the first rollup block of each batch, these are the
+// commitments of the batch containing the rollup block at height. tenant is ignored for LayerL1. Returns false if
+// no commitment is indexed at or below height.
+func (i *Index) AtHeight(layer Layer, tenant string, height uint64) (uint64, []Entry, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	hi, ok := i.heights[scopeOf(layer, tenant)]
+	if !ok {
+		return 0, nil, false
+	}
+	j := sort.Search(len(hi.sorted), func(j int) bool { return hi.sorted[j] > height }) - 1
+	for ; j >= 0; j-- {
+		keys := hi.keys[hi.sorted[j]]
+		if len(keys) == 0 {
+			continue
+		}
+		entries := make([]Entry, 0, len(keys))
+		for _, k := range keys {
+			entries = append(entries, i.entries[k].clone())
+		}
+		return hi.sorted[j], entries, true
+	}
+	return 0, nil, false
+}
+
+// registerHeights ... adds the entry to the height indexes of every layer it has a height on. Caller must hold
+// the lock.
+func (i *Index) registerHeights(e *Entry) {
+	i.registerHeight(e, LayerRollup)
+	i.registerHeight(e, LayerL1)
+}
+
+// unregisterHeights ... removes the entry from the height indexes of every layer. Caller must hold the lock.
+func (i *Index) unregisterHeights(e *Entry) {
+	i.unregisterHeight(e, LayerRollup)
+	i.unregisterHeight(e, LayerL1)
+}
+
+// registerHeight ... caller must hold the lock
+func (i *Index) registerHeight(e *Entry, layer Layer) {
+	h := e.height(layer)
+	if h == nil {
+		return
+	}
+
+	scope := scopeOf(layer, e.Tenant)
+	hi, ok := i.heights[scope]
+	if !ok {
+		hi = &heightIndex{keys: make(map[uint64][]string)}
+		i.heights[scope] = hi
+	}
+
+	keys, ok := hi.keys[*h]
+	switch {
+	case !ok:
+		// heights mostly increase, in which case they are appended
+		j := sort.Search(len(hi.sorted), func(j int) bool { return hi.sorted[j] > *h })
+		hi.sorted = append(hi.sorted, 0)
+		copy(hi.sorted[j+1:], hi.sorted[j:])
+		hi.sorted[j] = *h
+	case len(keys) == 0:
+		hi.empty--
+	default:
+	}
+	hi.keys[*h] = append(keys, string(e.Key))
+}
+
+// unregisterHeight ... caller must hold the lock
+func (i *Index) unregisterHeight(e *Entry, layer Layer) {
+	h := e.height(layer)
+	if h == nil {
+		return
+	}
+	hi, ok := i.heights[scopeOf(layer, e.Tenant)]
+	if !ok {
+		return
+	}
+
+	keys := hi.keys[*h]
+	j := slices.Index(keys, string(e.Key))
+	if j < 0 {
+		return
+	}
+	keys = append(keys[:j:j], keys[j+1:]...)
+	hi.keys[*h] = keys
+	if len(keys) > 0 {
+		return
+	}
+
+	hi.empty++
+	if hi.empty*2 <= len(hi.sorted) {
+		return
+	}
+	// compact the heights without commitments
+	sorted := hi.sorted[:0]
+	for _, h := range hi.sorted {
+		if len(hi.keys[h]) > 0 {
+			sorted = append(sorted, h)
+		} else {
+			delete(hi.keys, h)
+		}
+	}
+	hi.sorted, hi.empty = sorted, 0
+}
@@ -0,0 +1,590 @@
+package index
+
+import (
+	"container/list"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DefaultMaxEntries ... number of commitments kept in the index before the oldest are dropped
+const DefaultMaxEntries = 1_000_000
+
+// Entry ... metadata tracked for a single commitment
+type Entry struct {
+	// Seq increases with every commitment added to the index, starting at 1
+	Seq  uint64        `json:"seq"`
+	Key  hexutil.Bytes `json:"key"`
+	Size int           `json:"size"`
+	// commitment mode the blob was written with, empty if unknown
+	Mode      string    `json:"mode,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// secondary backends holding a copy of the blob, mapped to the time it was written
+	Backends map[string]time.Time `json:"backends"`
+	// disperser request ID of an EigenDA cert, used to look up its current cert if its batch was re-confirmed
+	RequestID hexutil.Bytes `json:"request_id,omitempty"`
+	// current cert of a stale EigenDA cert, set once the blob has been resolved via the disperser
+	RefreshedCert hexutil.Bytes `json:"refreshed_cert,omitempty"`
+	// tenant the blob was written by, empty if unknown
+	Tenant string `json:"tenant,omitempty"`
+	// rollup block or batch number the blob was written for, as supplied by the batcher
+	RollupHeight *uint64 `json:"rollup_height,omitempty"`
+	// L1 block the commitment was included in, as found by the L1 scanner
+	L1Block *uint64 `json:"l1_block,omitempty"`
+	// time the blob was last served, zero if it wasn't served since it was indexed
+	LastAccess time.Time `json:"last_access,omitempty"`
+	// number of times the blob was served since it was last moved between storage tiers
+	Reads int `json:"reads,omitempty"`
+	// number of times the blob was served since it was indexed. Read counts are estimates if reads are sampled, see
+	// AccessOptions.
+	TotalReads int `json:"total_reads,omitempty"`
+	// secondary backends whose copy of the blob was moved to an archive storage class, mapped to the time it was
+	// archived
+	Archived map[string]time.Time `json:"archived,omitempty"`
+
+	aliases []string
+	// elements of the entry in the held lists of its backends, see Index.Oldest
+	held map[string]*list.Element
+	// reads counted into the current and previous access window, see Index.Hottest
+	window          int64
+	windowReads     int
+	prevWindowReads int
+}
+
+func (e *Entry) clone() Entry {
+	c := *e
+	c.Key = append(hexutil.Bytes(nil), e.Key...)
+	c.RequestID = append(hexutil.Bytes(nil), e.RequestID...)
+	c.RefreshedCert = append(hexutil.Bytes(nil), e.RefreshedCert...)
+	c.aliases = nil
+	c.held = nil
+	c.Backends = make(map[string]time.Time, len(e.Backends))
+	for b, t := range e.Backends {
+		c.Backends[b] = t
+	}
+	if e.Archived != nil {
+		c.Archived = make(map[string]time.Time, len(e.Archived))
+		for b, t := range e.Archived {
+			c.Archived[b] = t
+		}
+	}
+	return c
+}
+
+// Index ... in-memory index of the commitments served by the proxy and the secondary backends holding them.
+// It is bounded to maxEntries; once full, the oldest commitments are forgotten.
+// NOTE: backend names are plain strings rather than store.BackendType since the store package depends on the index.
+type Index struct {
+	mu         sync.RWMutex
+	maxEntries int
+	entries    map[string]*Entry
+	order      []string // keys in insertion order, may contain keys that have since been dropped
+	bytes      map[string]int64
+	// keys of the entries held by each backend, in the order they were written to it, so that the oldest are found
+	// without scanning the index
+	held       map[string]*list.List
+	aliases    map[string]string // alternative identifiers (e.g. versioned hashes) mapped to keys
+	seq        uint64            // sequence number of the last added entry
+	epoch      uint64            // identifies this index, sequence numbers of different indexes aren't comparable
+	heights    map[heightScope]*heightIndex
+	access     AccessOptions
+	readWeight int // reads a recorded read counts for
+}
+
+// New ... constructor. maxEntries <= 0 uses DefaultMaxEntries.
+func New(maxEntries int) *Index {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &Index{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*Entry),
+		bytes:      make(map[string]int64),
+		held:       make(map[string]*list.List),
+		aliases:    make(map[string]string),
+		heights:    make(map[heightScope]*heightIndex),
+		access:     AccessOptions{SampleRate: 1, Window: DefaultAccessWindow},
+		readWeight: 1,
+		epoch:      rand.Uint64(), // #nosec G404 -- only needs to differ between restarts
+	}
+}
+
+// Put ... records a commitment of a blob with the given size and commitment mode. Existing entries are left
+// untouched, except that a missing size or mode is filled in.
+func (i *Index) Put(key []byte, size int, mode string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e := i.put(key, size)
+	if e.Mode == "" {
+		e.Mode = mode
+	}
+}
+
+// put ... caller must hold the lock
+func (i *Index) put(key []byte, size int) *Entry {
+	if e, ok := i.entries[string(key)]; ok {
+		// entries backfilled without fetching the blob have an unknown (zero) size
+		if e.Size == 0 && size > 0 {
+			e.Size = size
+		}
+		return e
+	}
+
+	i.seq++
+	e := &Entry{
+		Seq:       i.seq,
+		Key:       append(hexutil.Bytes(nil), key...),
+		Size:      size,
+		CreatedAt: time.Now(),
+		Backends:  make(map[string]time.Time),
+	}
+	i.entries[string(key)] = e
+	i.order = append(i.order, string(key))
+
+	for len(i.entries) > i.maxEntries {
+		i.drop(i.order[0])
+		i.order = i.order[1:]
+	}
+	return e
+}
+
+// drop ... removes an entry and its backend accounting. Caller must hold the lock.
+func (i *Index) drop(key string) {
+	e, ok := i.entries[key]
+	if !ok {
+		return
+	}
+	for b := range e.Backends {
+		i.release(e, b)
+	}
+	for _, a := range e.aliases {
+		// the alias may have since been re-registered for another key, e.g. the same payload dispersed twice
+		if i.aliases[a] == key {
+			delete(i.aliases, a)
+		}
+	}
+	i.unregisterHeights(e)
+	delete(i.entries, key)
+}
+
+// AddBackend ... records that the blob for key has been written to backend, creating the entry if needed
+func (i *Index) AddBackend(key []byte, size int, backend string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e := i.put(key, size)
+	if _, ok := e.Backends[backend]; ok {
+		return
+	}
+	e.Backends[backend] = time.Now()
+	i.hold(e, backend)
+}
+
+// hold ... accounts the blob of e to backend, which must have just been added to e.Backends. Caller must hold the
+// lock.
+func (i *Index) hold(e *Entry, backend string) {
+	i.bytes[backend] += int64(e.Size)
+	l, ok := i.held[backend]
+	if !ok {
+		l = list.New()
+		i.held[backend] = l
+	}
+	if e.held == nil {
+		e.held = make(map[string]*list.Element)
+	}
+	e.held[backend] = l.PushBack(string(e.Key))
+}
+
+// release ... stops accounting the blob of e to backend. Caller must hold the lock.
+func (i *Index) release(e *Entry, backend string) {
+	i.bytes[backend] -= int64(e.Size)
+	if el, ok := e.held[backend]; ok {
+		i.held[backend].Remove(el)
+		delete(e.held, backend)
+	}
+}
+
+// SetRequestID ... records the disperser request ID of the indexed EigenDA cert key. Returns false if key isn't
+// indexed.
+func (i *Index) SetRequestID(key, requestID []byte) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	e.RequestID = append(hexutil.Bytes(nil), requestID...)
+	return true
+}
+
+// SetRefreshedCert ... records the current cert of the indexed EigenDA cert key, whose batch was re-confirmed.
+// Returns false if key isn't indexed.
+func (i *Index) SetRefreshedCert(key, cert []byte) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	e.RefreshedCert = append(hexutil.Bytes(nil), cert...)
+	return true
+}
+
+// SetTenant ... records the tenant that wrote the blob for the indexed key. Returns false if key isn't indexed.
+func (i *Index) SetTenant(key []byte, tenant string) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	// heights are indexed per tenant
+	i.unregisterHeights(e)
+	e.Tenant = tenant
+	i.registerHeights(e)
+	return true
+}
+
+// RefreshedCert ... returns the current cert recorded for key, or nil if there is none
+func (i *Index) RefreshedCert(key []byte) []byte {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if e, ok := i.entries[string(key)]; ok && len(e.RefreshedCert) > 0 {
+		return append([]byte(nil), e.RefreshedCert...)
+	}
+	return nil
+}
+
+// RemoveBackend ... records that the blob for key is no longer held by backend
+func (i *Index) RemoveBackend(key []byte, backend string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return
+	}
+	if _, ok := e.Backends[backend]; !ok {
+		return
+	}
+	delete(e.Backends, backend)
+	delete(e.Archived, backend)
+	i.release(e, backend)
+}
+
+// Touch ... records that the blob for key was served, see Entry.LastAccess and Entry.Reads. Only a sample of the
+// reads is recorded if configured, see AccessOptions.
+func (i *Index) Touch(key []byte) {
+	if i.access.SampleRate < 1 && rand.Float64() >= i.access.SampleRate { // #nosec G404
+		return
+	}
+	now := time.Now()
+	w, _ := i.accessWindow(now)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if e, ok := i.entries[string(key)]; ok {
+		e.LastAccess = now
+		e.Reads += i.readWeight
+		e.TotalReads += i.readWeight
+		e.addRecentReads(w, i.readWeight)
+	}
+}
+
+// ResetReads ... restarts counting the reads of the blob for key, e.g. once it was moved to another tier
+func (i *Index) ResetReads(key []byte) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if e, ok := i.entries[string(key)]; ok {
+		e.Reads = 0
+	}
+}
+
+// SetArchived ... records whether the copy of the blob for key held by backend is archived. Returns false if key
+// isn't indexed or not held by backend.
+func (i *Index) SetArchived(key []byte, backend string, archived bool) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	if _, ok := e.Backends[backend]; !ok {
+		return false
+	}
+	if !archived {
+		delete(e.Archived, backend)
+		return true
+	}
+	if e.Archived == nil {
+		e.Archived = make(map[string]time.Time)
+	}
+	e.Archived[backend] = time.Now()
+	return true
+}
+
+// TierCount ... number of indexed blobs held by a backend, of which Archived are archived
+type TierCount struct {
+	Blobs    int
+	Archived int
+}
+
+// TierCounts ... returns the number of indexed blobs held by each backend
+func (i *Index) TierCounts() map[string]TierCount {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	counts := make(map[string]TierCount)
+	for _, e := range i.entries {
+		for b := range e.Backends {
+			c := counts[b]
+			c.Blobs++
+			if _, ok := e.Archived[b]; ok {
+				c.Archived++
+			}
+			counts[b] = c
+		}
+	}
+	return counts
+}
+
+// Get ... returns a copy of the entry for key
+func (i *Index) Get(key []byte) (Entry, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return Entry{}, false
+	}
+	return e.clone(), true
+}
+
+// AddAlias ... registers an alternative identifier of an indexed commitment, e.g. the versioned hash of
+// its blob. Aliases are dropped along with the commitment. Returns false if key isn't indexed.
+func (i *Index) AddAlias(alias, key []byte) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	e, ok := i.entries[string(key)]
+	if !ok {
+		return false
+	}
+	if _, ok := i.aliases[string(alias)]; !ok {
+		e.aliases = append(e.aliases, string(alias))
+	}
+	i.aliases[string(alias)] = string(key)
+	return true
+}
+
+// Resolve ... returns a copy of the entry registered under alias
+func (i *Index) Resolve(alias []byte) (Entry, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	key, ok := i.aliases[string(alias)]
+	if !ok {
+		return Entry{}, false
+	}
+	e, ok := i.entries[key]
+	if !ok {
+		return Entry{}, false
+	}
+	return e.clone(), true
+}
+
+// Len ... number of indexed commitments
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.entries)
+}
+
+// BackendBytes ... total size of the indexed blobs held by backend
+func (i *Index) BackendBytes(backend string) int64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.bytes[backend]
+}
+
+// Oldest ... returns up to limit of the entries held by backend the longest, i.e. in the order they were written
+// to it, oldest first
+func (i *Index) Oldest(backend string, limit int) []Entry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	l, ok := i.held[backend]
+	if !ok {
+		return nil
+	}
+	var entries []Entry
+	for el := l.Front(); el != nil && len(entries) < limit; el = el.Next() {
+		entries = append(entries, i.entries[el.Value.(string)].clone())
+	}
+	return entries
+}
+
+// Recent ... returns up to limit of the most recently indexed entries, newest first
+func (i *Index) Recent(limit int) []Entry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var entries []Entry
+	for j := len(i.order) - 1; j >= 0 && len(entries) < limit; j-- {
+		e, ok := i.entries[i.order[j]]
+		if !ok {
+			continue
+		}
+		entries = append(entries, e.clone())
+	}
+	return entries
+}
+
+// Epoch ... random identifier of the index, which differs between restarts of the proxy. Sequence numbers are only
+// comparable between entries of the same epoch.
+func (i *Index) Epoch() uint64 {
+	return i.epoch
+}
+
+// Seq ... sequence number of the most recently added entry, 0 if none was added yet
+func (i *Index) Seq() uint64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.seq
+}
+
+// Since ... returns up to limit of the entries added after the one with sequence number seq, oldest first,
+// e.g. to incrementally sync the index to another proxy instance
+func (i *Index) Since(seq uint64, limit int) []Entry {
+	return i.List(Filter{Since: seq}, limit)
+}
+
+// Filter ... criteria of the entries returned by List, zero fields match every entry
+type Filter struct {
+	// Since only matches entries added after the one with this sequence number
+	Since uint64
+	// CreatedAfter only matches entries created after this time
+	CreatedAfter time.Time
+	Tenant       string
+	// Backend only matches entries held by this secondary backend
+	Backend string
+	// IdleBefore only matches entries last served, or created if never served, before this time
+	IdleBefore time.Time
+	// MinReads only matches entries served at least this many times since they were last moved between tiers
+	MinReads int
+}
+
+func (f Filter) matches(e *Entry) bool {
+	if e.Seq <= f.Since || !e.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+	if f.Tenant != "" && e.Tenant != f.Tenant {
+		return false
+	}
+	if f.Backend != "" {
+		if _, ok := e.Backends[f.Backend]; !ok {
+			return false
+		}
+	}
+	if !f.IdleBefore.IsZero() {
+		lastAccess := e.LastAccess
+		if lastAccess.IsZero() {
+			lastAccess = e.CreatedAt
+		}
+		if !lastAccess.Before(f.IdleBefore) {
+			return false
+		}
+	}
+	return e.Reads >= f.MinReads
+}
+
+// List ... returns up to limit of the entries matching f, oldest first. Following pages are listed by setting
+// f.Since to the sequence number of the last returned entry.
+func (i *Index) List(f Filter, limit int) []Entry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// order is sorted by sequence number, so walk back to the first entry after f.Since
+	start := len(i.order)
+	for start > 0 {
+		e, ok := i.entries[i.order[start-1]]
+		if ok && e.Seq <= f.Since {
+			break
+		}
+		start--
+	}
+
+	var entries []Entry
+	for _, key := range i.order[start:] {
+		if len(entries) >= limit {
+			break
+		}
+		if e, ok := i.entries[key]; ok && f.matches(e) {
+			entries = append(entries, e.clone())
+		}
+	}
+	return entries
+}
+
+// Sample ... returns up to n entries held by backend, chosen uniformly at random
+func (i *Index) Sample(backend string, n int) []Entry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	// reservoir sampling over the entries held by backend
+	var sample []*Entry
+	seen := 0
+	for _, e := range i.entries {
+		if _, ok := e.Backends[backend]; !ok {
+			continue
+		}
+		seen++
+		if len(sample) < n {
+			sample = append(sample, e)
+		} else if j := rand.Intn(seen); j < n { // #nosec G404
+			sample[j] = e
+		}
+	}
+
+	entries := make([]Entry, len(sample))
+	for j, e := range sample {
+		entries[j] = e.clone()
+	}
+	return entries
+}
+
+// Keys ... returns the keys of every indexed commitment
+func (i *Index) Keys() [][]byte {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	keys := make([][]byte, 0, len(i.entries))
+	for k := range i.entries {
+		keys = append(keys, []byte(k))
+	}
+	return keys
+}
+
+// BackendKeys ... returns the keys of the indexed commitments held by backend
+func (i *Index) BackendKeys(backend string) [][]byte {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var keys [][]byte
+	for k, e := range i.entries {
+		if _, ok := e.Backends[backend]; ok {
+			keys = append(keys, []byte(k))
+		}
+	}
+	return keys
+}
@@ -0,0 +1,365 @@
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex(t *testing.T) {
+	idx := New(2)
+
+	idx.Put([]byte("a"), 10, "optimism_generic")
+	idx.AddBackend([]byte("a"), 10, "Redis")
+	idx.AddBackend([]byte("b"), 20, "Redis")
+	idx.AddBackend([]byte("b"), 20, "S3")
+
+	// adding the same backend twice must not double count
+	idx.AddBackend([]byte("b"), 20, "S3")
+
+	require.Equal(t, 2, idx.Len())
+	require.Equal(t, int64(30), idx.BackendBytes("Redis"))
+	require.Equal(t, int64(20), idx.BackendBytes("S3"))
+
+	oldest := idx.Oldest("Redis", 1)
+	require.Len(t, oldest, 1)
+	require.Equal(t, []byte("a"), []byte(oldest[0].Key))
+
+	idx.RemoveBackend([]byte("a"), "Redis")
+	require.Equal(t, int64(20), idx.BackendBytes("Redis"))
+	e, ok := idx.Get([]byte("a"))
+	require.True(t, ok)
+	require.Empty(t, e.Backends)
+
+	// exceeding max entries drops the oldest commitment along with its backend accounting
+	idx.Put([]byte("c"), 5, "optimism_generic")
+	require.Equal(t, 2, idx.Len())
+	_, ok = idx.Get([]byte("a"))
+	require.False(t, ok)
+
+	idx.Put([]byte("d"), 5, "optimism_keccak256")
+	require.Equal(t, int64(0), idx.BackendBytes("Redis"))
+	require.Equal(t, int64(0), idx.BackendBytes("S3"))
+}
+
+func TestIndexOldest(t *testing.T) {
+	idx := New(10)
+	for _, key := range []string{"a", "b", "c"} {
+		idx.Put([]byte(key), 10, "optimism_generic")
+	}
+	// entries are evicted in the order they were written to the backend, not the order they were indexed in
+	for _, key := range []string{"c", "a", "b"} {
+		idx.AddBackend([]byte(key), 10, "Redis")
+	}
+	idx.AddBackend([]byte("a"), 10, "S3")
+
+	keys := func(idx *Index, limit int) []string {
+		var keys []string
+		for _, e := range idx.Oldest("Redis", limit) {
+			keys = append(keys, string(e.Key))
+		}
+		return keys
+	}
+	require.Equal(t, []string{"c", "a"}, keys(idx, 2))
+
+	idx.RemoveBackend([]byte("c"), "Redis")
+	idx.AddBackend([]byte("c"), 10, "Redis")
+	require.Equal(t, []string{"a", "b", "c"}, keys(idx, 10))
+
+	// the entries held by a backend restore in the same order
+	records := idx.DumpHeld("Redis")
+	require.Len(t, records, 3)
+	restored := New(10)
+	require.Equal(t, 3, restored.Restore(records))
+	require.Equal(t, []string{"a", "b", "c"}, keys(restored, 10))
+	require.Equal(t, int64(30), restored.BackendBytes("Redis"))
+	require.Equal(t, int64(10), restored.BackendBytes("S3"))
+	require.Empty(t, New(10).DumpHeld("Redis"))
+}
+
+func TestIndexRecent(t *testing.T) {
+	idx := New(10)
+
+	idx.Put([]byte("a"), 1, "simple")
+	idx.AddBackend([]byte("b"), 2, "Redis")
+	idx.Put([]byte("b"), 2, "simple") // fills in the unknown mode
+	idx.Put([]byte("c"), 3, "simple")
+
+	recent := idx.Recent(2)
+	require.Len(t, recent, 2)
+	require.Equal(t, []byte("c"), []byte(recent[0].Key))
+	require.Equal(t, []byte("b"), []byte(recent[1].Key))
+	require.Equal(t, "simple", recent[1].Mode)
+
+	require.Len(t, idx.Recent(10), 3)
+}
+
+func TestIndexAliases(t *testing.T) {
+	idx := New(1)
+
+	require.False(t, idx.AddAlias([]byte("alias"), []byte("a")))
+
+	idx.Put([]byte("a"), 1, "simple")
+	require.True(t, idx.AddAlias([]byte("alias"), []byte("a")))
+	e, ok := idx.Resolve([]byte("alias"))
+	require.True(t, ok)
+	require.Equal(t, []byte("a"), []byte(e.Key))
+
+	// dropping the commitment drops its aliases
+	idx.Put([]byte("b"), 1, "simple")
+	_, ok = idx.Resolve([]byte("alias"))
+	require.False(t, ok)
+}
+
+func TestIndexSince(t *testing.T) {
+	idx := New(3)
+	require.Equal(t, uint64(0), idx.Seq())
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		idx.Put([]byte(k), 1, "simple")
+	}
+	// re-adding an existing commitment doesn't advance the sequence
+	idx.Put([]byte("d"), 1, "simple")
+	require.Equal(t, uint64(4), idx.Seq())
+
+	// a was dropped, so syncing from scratch starts at b
+	entries := idx.Since(0, 2)
+	require.Len(t, entries, 2)
+	require.Equal(t, []byte("b"), []byte(entries[0].Key))
+	require.Equal(t, uint64(2), entries[0].Seq)
+	require.Equal(t, []byte("c"), []byte(entries[1].Key))
+
+	entries = idx.Since(entries[1].Seq, 2)
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("d"), []byte(entries[0].Key))
+
+	require.Empty(t, idx.Since(4, 2))
+}
+
+func TestIndexList(t *testing.T) {
+	idx := New(10)
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		idx.Put([]byte(k), 1, "simple")
+	}
+	idx.SetTenant([]byte("a"), "rollup-a")
+	idx.SetTenant([]byte("c"), "rollup-a")
+	idx.SetTenant([]byte("d"), "rollup-a")
+	idx.AddBackend([]byte("c"), 1, "S3")
+	idx.AddBackend([]byte("d"), 1, "S3")
+	require.False(t, idx.SetTenant([]byte("unknown"), "rollup-a"))
+
+	entries := idx.List(Filter{Tenant: "rollup-a"}, 2)
+	require.Len(t, entries, 2)
+	require.Equal(t, []byte("a"), []byte(entries[0].Key))
+	require.Equal(t, []byte("c"), []byte(entries[1].Key))
+	require.Equal(t, "rollup-a", entries[1].Tenant)
+
+	// the next page starts after the last returned entry
+	entries = idx.List(Filter{Since: entries[1].Seq, Tenant: "rollup-a"}, 2)
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("d"), []byte(entries[0].Key))
+
+	entries = idx.List(Filter{Tenant: "rollup-a", Backend: "S3"}, 10)
+	require.Len(t, entries, 2)
+	require.Equal(t, []byte("c"), []byte(entries[0].Key))
+
+	require.Empty(t, idx.List(Filter{Tenant: "rollup-b"}, 10))
+	require.Empty(t, idx.List(Filter{CreatedAfter: time.Now()}, 10))
+	require.Len(t, idx.List(Filter{}, 10), 4)
+}
+
+func TestIndexSample(t *testing.T) {
+	idx := New(0)
+	for _, k := range []string{"a", "b", "c", "d"} {
+		idx.Put([]byte(k), 1, "simple")
+		if k != "d" {
+			idx.AddBackend([]byte(k), 1, "redis")
+		}
+	}
+
+	require.Len(t, idx.Keys(), 4)
+	require.Len(t, idx.Sample("redis", 2), 2)
+
+	sample := idx.Sample("redis", 10)
+	require.Len(t, sample, 3)
+	for _, e := range sample {
+		require.NotEqual(t, []byte("d"), []byte(e.Key))
+	}
+	require.Empty(t, idx.Sample("s3", 10))
+
+	keys := idx.BackendKeys("redis")
+	require.Len(t, keys, 3)
+	require.NotContains(t, keys, []byte("d"))
+}
+
+func TestIndexHeights(t *testing.T) {
+	idx := New(4)
+
+	idx.Put([]byte("a"), 1, "optimism_generic")
+	idx.Put([]byte("b"), 1, "optimism_generic")
+	idx.Put([]byte("c"), 1, "optimism_generic")
+	require.True(t, idx.SetHeight([]byte("a"), LayerRollup, 100))
+	require.True(t, idx.SetHeight([]byte("b"), LayerRollup, 200))
+	require.True(t, idx.SetHeight([]byte("c"), LayerRollup, 200))
+	require.True(t, idx.SetHeight([]byte("a"), LayerL1, 7))
+	require.False(t, idx.SetHeight([]byte("unknown"), LayerRollup, 1))
+	require.False(t, idx.SetHeight([]byte("a"), Layer("l3"), 1))
+
+	// lookups resolve to the greatest indexed height at or below the requested one
+	_, _, ok := idx.AtHeight(LayerRollup, "", 99)
+	require.False(t, ok)
+	height, entries, ok := idx.AtHeight(LayerRollup, "", 150)
+	require.True(t, ok)
+	require.Equal(t, uint64(100), height)
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("a"), []byte(entries[0].Key))
+	height, entries, ok = idx.AtHeight(LayerRollup, "", 1000)
+	require.True(t, ok)
+	require.Equal(t, uint64(200), height)
+	require.Len(t, entries, 2)
+	height, _, ok = idx.AtHeight(LayerL1, "", 7)
+	require.True(t, ok)
+	require.Equal(t, uint64(7), height)
+
+	// heights are indexed per tenant
+	require.True(t, idx.SetTenant([]byte("c"), "rollup-a"))
+	_, entries, _ = idx.AtHeight(LayerRollup, "", 200)
+	require.Len(t, entries, 1)
+	_, entries, ok = idx.AtHeight(LayerRollup, "rollup-a", 200)
+	require.True(t, ok)
+	require.Equal(t, []byte("c"), []byte(entries[0].Key))
+	// L1 blocks are shared by every tenant
+	require.True(t, idx.SetHeight([]byte("c"), LayerL1, 8))
+	_, entries, _ = idx.AtHeight(LayerL1, "", 8)
+	require.Equal(t, []byte("c"), []byte(entries[0].Key))
+
+	// a replaced height is no longer resolved
+	require.True(t, idx.SetHeight([]byte("b"), LayerRollup, 300))
+	height, _, _ = idx.AtHeight(LayerRollup, "", 250)
+	require.Equal(t, uint64(100), height)
+
+	// dropped entries are no longer resolved, falling back to lower heights
+	idx.Put([]byte("d"), 1, "optimism_generic")
+	idx.Put([]byte("e"), 1, "optimism_generic")
+	_, _, ok = idx.AtHeight(LayerL1, "", 7)
+	require.False(t, ok)
+	_, _, ok = idx.AtHeight(LayerRollup, "", 150)
+	require.False(t, ok)
+	height, _, ok = idx.AtHeight(LayerRollup, "", 1000)
+	require.True(t, ok)
+	require.Equal(t, uint64(300), height)
+
+	// heights are carried over by snapshots
+	restored := New(0)
+	restored.Restore(idx.Dump())
+	height, entries, ok = restored.AtHeight(LayerRollup, "rollup-a", 200)
+	require.True(t, ok)
+	require.Equal(t, uint64(200), height)
+	require.Equal(t, []byte("c"), []byte(entries[0].Key))
+}
+
+func TestIndexTiers(t *testing.T) {
+	idx := New(0)
+	for _, k := range []string{"a", "b", "c"} {
+		idx.AddBackend([]byte(k), 1, "S3")
+	}
+	idx.AddBackend([]byte("a"), 1, "redis")
+	idx.Touch([]byte("a"))
+	idx.Touch([]byte("a"))
+	idx.Touch([]byte("unknown"))
+
+	// b and c were never served, so they are idle since they were created
+	entries := idx.List(Filter{IdleBefore: time.Now()}, 10)
+	require.Len(t, entries, 3)
+	entries = idx.List(Filter{MinReads: 2}, 10)
+	require.Len(t, entries, 1)
+	require.Equal(t, []byte("a"), []byte(entries[0].Key))
+	idx.ResetReads([]byte("a"))
+	require.Empty(t, idx.List(Filter{MinReads: 1}, 10))
+
+	require.True(t, idx.SetArchived([]byte("b"), "S3", true))
+	require.False(t, idx.SetArchived([]byte("b"), "redis", true))
+	require.False(t, idx.SetArchived([]byte("unknown"), "S3", true))
+	require.Equal(t, map[string]TierCount{
+		"S3":    {Blobs: 3, Archived: 1},
+		"redis": {Blobs: 1},
+	}, idx.TierCounts())
+
+	// archived copies are forgotten along with their backend
+	idx.RemoveBackend([]byte("b"), "S3")
+	e, ok := idx.Get([]byte("b"))
+	require.True(t, ok)
+	require.Empty(t, e.Archived)
+}
+
+func TestIndexAccess(t *testing.T) {
+	idx := New(10)
+	for _, k := range []string{"a", "b", "c"} {
+		idx.Put([]byte(k), 10, "simple")
+	}
+	for i := 0; i < 3; i++ {
+		idx.Touch([]byte("b"))
+	}
+	idx.Touch([]byte("c"))
+	idx.Touch([]byte("unknown"))
+
+	rep := idx.Hottest(1)
+	require.Equal(t, DefaultAccessWindow, rep.Window)
+	require.Equal(t, 4, rep.Reads)
+	require.Equal(t, 2, rep.Blobs)
+	require.Equal(t, int64(20), rep.Bytes)
+	require.Len(t, rep.Hottest, 1)
+	require.Equal(t, []byte("b"), []byte(rep.Hottest[0].Key))
+	require.Equal(t, 3, rep.Hottest[0].RecentReads)
+	require.Equal(t, 3, rep.Hottest[0].TotalReads)
+
+	// moving a blob between tiers restarts its reads but not its total reads
+	idx.ResetReads([]byte("b"))
+	e, _ := idx.Get([]byte("b"))
+	require.Zero(t, e.Reads)
+	require.Equal(t, 3, e.TotalReads)
+
+	hottest := idx.Hottest(10).Hottest
+	require.Len(t, hottest, 2)
+	require.Equal(t, []byte("c"), []byte(hottest[1].Key))
+	require.Empty(t, idx.Hottest(0).Hottest)
+}
+
+func TestIndexAccessSampling(t *testing.T) {
+	require.Error(t, AccessOptions{SampleRate: 1.5}.Check())
+	require.Error(t, AccessOptions{Window: -time.Second}.Check())
+	require.NoError(t, AccessOptions{}.Check())
+
+	idx := New(10)
+	idx.SetAccessOptions(AccessOptions{SampleRate: 0.25})
+	idx.Put([]byte("a"), 10, "simple")
+	for i := 0; i < 4000; i++ {
+		idx.Touch([]byte("a"))
+	}
+
+	// every sampled read counts for 1/SampleRate reads
+	e, _ := idx.Get([]byte("a"))
+	require.Zero(t, e.TotalReads%4)
+	require.InDelta(t, 4000, e.TotalReads, 800)
+	rep := idx.Hottest(1)
+	require.Equal(t, DefaultAccessWindow, rep.Window)
+	require.Equal(t, 0.25, rep.SampleRate)
+}
+
+func TestAccessWindow(t *testing.T) {
+	var e Entry
+	e.addRecentReads(10, 4)
+	e.addRecentReads(10, 2)
+	require.Equal(t, 6, e.recentReads(10, 0.5))
+
+	// the reads of the previous window are weighted by the share of it still covered
+	e.addRecentReads(11, 1)
+	require.Equal(t, 4, e.recentReads(11, 0.5))
+	require.Equal(t, 1, e.recentReads(12, 0.5))
+	require.Zero(t, e.recentReads(13, 0))
+
+	e.addRecentReads(20, 1)
+	require.Equal(t, 1, e.recentReads(20, 0))
+}
@@ -0,0 +1,147 @@
+package index
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Record ... an entry together with the aliases registered for it, as dumped to and restored from snapshots
+type Record struct {
+	Entry
+	Aliases []hexutil.Bytes `json:"aliases,omitempty"`
+}
+
+// Dump ... returns every entry with its aliases, oldest first
+func (i *Index) Dump() []Record {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	records := make([]Record, 0, len(i.entries))
+	// order may contain a key twice if it was dropped and added again
+	seen := make(map[string]struct{}, len(i.entries))
+	for _, key := range i.order {
+		e, ok := i.entries[key]
+		if !ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		records = append(records, i.record(e))
+	}
+	return records
+}
+
+// DumpHeld ... returns the entries held by any of backends with their aliases, ordered by the time they were first
+// written to one of them, oldest first, so that restoring them preserves the order entries are evicted in
+func (i *Index) DumpHeld(backends ...string) []Record {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var records []Record
+	seen := make(map[string]struct{})
+	for _, b := range backends {
+		l, ok := i.held[b]
+		if !ok {
+			continue
+		}
+		for el := l.Front(); el != nil; el = el.Next() {
+			key := el.Value.(string)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			records = append(records, i.record(i.entries[key]))
+		}
+	}
+
+	heldSince := func(r Record) time.Time {
+		var since time.Time
+		for _, b := range backends {
+			if t, ok := r.Backends[b]; ok && (since.IsZero() || t.Before(since)) {
+				since = t
+			}
+		}
+		return since
+	}
+	sort.SliceStable(records, func(a, b int) bool { return heldSince(records[a]).Before(heldSince(records[b])) })
+	return records
+}
+
+// record ... copy of e with the aliases still registered for it. Caller must hold the lock.
+func (i *Index) record(e *Entry) Record {
+	r := Record{Entry: e.clone()}
+	for _, a := range e.aliases {
+		if i.aliases[a] == string(e.Key) {
+			r.Aliases = append(r.Aliases, hexutil.Bytes(a))
+		}
+	}
+	return r
+}
+
+// Restore ... adds the entries of records, e.g. dumped by another proxy, keeping their creation time, commitment
+// mode, tenant, heights, backends, archived copies, last access and disperser metadata. Records of already indexed
+// commitments are merged into the existing entries. The restored entries are assigned new sequence numbers. Returns
+// the number of entries added.
+func (i *Index) Restore(records []Record) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	added := 0
+	for _, r := range records {
+		if _, ok := i.entries[string(r.Key)]; !ok {
+			added++
+		}
+		e := i.put(r.Key, r.Size)
+		if e.Mode == "" {
+			e.Mode = r.Mode
+		}
+		if len(e.RequestID) == 0 {
+			e.RequestID = append(hexutil.Bytes(nil), r.RequestID...)
+		}
+		if len(e.RefreshedCert) == 0 {
+			e.RefreshedCert = append(hexutil.Bytes(nil), r.RefreshedCert...)
+		}
+		if e.Tenant == "" && r.Tenant != "" {
+			i.unregisterHeights(e)
+			e.Tenant = r.Tenant
+			i.registerHeights(e)
+		}
+		for _, layer := range []Layer{LayerRollup, LayerL1} {
+			if h := r.height(layer); h != nil && e.height(layer) == nil {
+				e.setHeight(layer, h)
+				i.registerHeight(e, layer)
+			}
+		}
+		if !r.CreatedAt.IsZero() && r.CreatedAt.Before(e.CreatedAt) {
+			e.CreatedAt = r.CreatedAt
+		}
+		for b, t := range r.Backends {
+			if _, ok := e.Backends[b]; ok {
+				continue
+			}
+			e.Backends[b] = t
+			i.hold(e, b)
+			if at, ok := r.Archived[b]; ok {
+				if e.Archived == nil {
+					e.Archived = make(map[string]time.Time)
+				}
+				e.Archived[b] = at
+			}
+		}
+		if r.LastAccess.After(e.LastAccess) {
+			e.LastAccess = r.LastAccess
+		}
+		e.TotalReads = max(e.TotalReads, r.TotalReads)
+		for _, a := range r.Aliases {
+			if _, ok := i.aliases[string(a)]; !ok {
+				e.aliases = append(e.aliases, string(a))
+			}
+			i.aliases[string(a)] = string(r.Key)
+		}
+	}
+	return added
+}
@@ -0,0 +1,280 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+)
+
+const (
+	VerificationNone      = "none"
+	VerificationKeccak256 = "keccak256"
+	VerificationKZG       = "kzg"
+	VerificationKZGCert   = "kzg+cert"
+	VerificationCert      = "cert"
+)
+
+// BytesPerSymbol ... size of a bn254 field element, the unit blobs are encoded and dispersed in
+const BytesPerSymbol = 32
+
+// VerificationReporter ... optionally implemented by stores to describe which checks their Verify
+// method performs, so that it can be surfaced to callers in response metadata.
+type VerificationReporter interface {
+	VerificationMode() string
+}
+
+// HashReporter ... optionally implemented by precomputed key stores whose keys are verified with a hash algorithm
+// other than keccak256, see commitments.HashAlgorithm
+type HashReporter interface {
+	HashAlgorithm() commitments.HashAlgorithm
+}
+
+// HashAlgorithmOf ... returns the hash algorithm the keys of the precomputed key store s are verified with,
+// keccak256 unless s is a HashReporter
+func HashAlgorithmOf(s any) commitments.HashAlgorithm {
+	if hr, ok := s.(HashReporter); ok {
+		return hr.HashAlgorithm()
+	}
+	return commitments.Keccak256Hash
+}
+
+// QuorumPolicyReporter ... optionally implemented by EigenDA stores to describe which quorums' confirmations
+// their Verify method requires of certs, see verify.QuorumPolicy
+type QuorumPolicyReporter interface {
+	ReadQuorumPolicy() string
+}
+
+// NetworkInfo ... limits and parameters of the network a GeneratedKeyStore disperses blobs to. Parameters the
+// store can't determine are left zero.
+type NetworkInfo struct {
+	MaxBlobSizeBytes uint64
+	RequiredQuorums  []uint8
+	// AdversaryThresholds and ConfirmationThresholds are percentages indexed by quorum number
+	AdversaryThresholds    []uint8
+	ConfirmationThresholds []uint8
+	// RetentionBlocks is the number of Ethereum blocks a blob is retrievable for after its confirmation
+	RetentionBlocks uint32
+	// Retention is how long a blob is retrievable for, if the store measures it in time rather than blocks
+	Retention time.Duration
+}
+
+// NetworkInfoReporter ... optionally implemented by EigenDA stores to report the parameters of their network, so
+// that clients can adapt e.g. their payload sizes to it
+type NetworkInfoReporter interface {
+	NetworkInfo(ctx context.Context) (NetworkInfo, error)
+}
+
+// verificationMode ... returns the verification mode reported by a store, or none if the
+// store doesn't report one
+func verificationMode(s Store) string {
+	if vr, ok := s.(VerificationReporter); ok {
+		return vr.VerificationMode()
+	}
+	return VerificationNone
+}
+
+// ResponseMeta ... describes how a request was served by the router. The server attaches it to
+// the request context so that the router can fill it in and the server can echo it back
+// via response headers.
+type ResponseMeta struct {
+	mu           sync.Mutex
+	backend      BackendType
+	verification string
+	// symbols of the encoded blob dispersed by the request, including the chunks of a split payload, 0 if nothing
+	// was dispersed
+	encodedSymbols int
+	// disperser request ID of the blob dispersed by the request, nil if nothing was dispersed
+	dispersalRequestID []byte
+	// quorum policy the cert of the read blob was verified with, empty if certs weren't verified
+	quorumPolicy string
+	// mode of the commitment returned by a write, if it differs from the requested one
+	commitmentMode commitments.CommitmentMode
+	// backends that acknowledged a write, in the order they acknowledged it
+	acknowledged []BackendType
+	// chunks the payload of a write was split into, nil if it wasn't split
+	chunks []commitments.EnvelopeChunk
+	// whether the blob of a write was dispersed to the default quorums only after its custom quorums failed
+	quorumFallback bool
+}
+
+type responseMetaKey struct{}
+
+// WithResponseMeta ... returns a child context carrying an empty ResponseMeta
+func WithResponseMeta(ctx context.Context) (context.Context, *ResponseMeta) {
+	meta := &ResponseMeta{backend: Unknown, verification: VerificationNone}
+	return context.WithValue(ctx, responseMetaKey{}, meta), meta
+}
+
+// ResponseMetaFromContext ... returns the ResponseMeta attached to the context, or nil if none
+func ResponseMetaFromContext(ctx context.Context) *ResponseMeta {
+	meta, _ := ctx.Value(responseMetaKey{}).(*ResponseMeta)
+	return meta
+}
+
+// recordServedBy ... records the store that served a request, and the store whose verification
+// checks were applied to the data, into the context's ResponseMeta (if any)
+func recordServedBy(ctx context.Context, served Store, verifiedBy Store) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.backend = served.BackendType()
+	meta.verification = verificationMode(verifiedBy)
+}
+
+// recordQuorumPolicy ... records the quorum policy the cert of a read blob was verified with by verifiedBy into
+// the context's ResponseMeta (if any)
+func recordQuorumPolicy(ctx context.Context, verifiedBy Store) {
+	meta := ResponseMetaFromContext(ctx)
+	qr, ok := verifiedBy.(QuorumPolicyReporter)
+	if meta == nil || !ok {
+		return
+	}
+
+	policy := qr.ReadQuorumPolicy()
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.quorumPolicy = policy
+}
+
+// copyServedBy ... copies the backend, verification mode and quorum policy recorded by a request served on
+// behalf of the request of meta
+func (m *ResponseMeta) copyServedBy(from *ResponseMeta) {
+	backend, verification, policy := from.Backend(), from.Verification(), from.QuorumPolicy()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.backend = backend
+	m.verification = verification
+	m.quorumPolicy = policy
+}
+
+// RecordEncodedBlob ... records the number of symbols of the encoded blob dispersed by a request into the
+// context's ResponseMeta (if any). Called by GeneratedKeyStore implementations, since only they know the
+// encoding, including any padding, the payload is dispersed with.
+func RecordEncodedBlob(ctx context.Context, encoded []byte) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.encodedSymbols = (len(encoded) + BytesPerSymbol - 1) / BytesPerSymbol
+}
+
+// RecordDispersalRequest ... records the disperser request ID of the blob dispersed by a request into the
+// context's ResponseMeta (if any), so that the router can index it along with the returned cert
+func RecordDispersalRequest(ctx context.Context, requestID []byte) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.dispersalRequestID = append([]byte(nil), requestID...)
+}
+
+// RecordQuorumFallback ... records that the blob dispersed by a request fell back to the default quorums, since
+// its custom quorums failed, into the context's ResponseMeta (if any)
+func RecordQuorumFallback(ctx context.Context) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.quorumFallback = true
+}
+
+type fallbackDecodeKey struct{}
+
+// withFallbackDecode ... returns a child context in which a GeneratedKeyStore reading a blob can report with
+// RecordFallbackDecode that it decoded the blob with a fallback, and a function reporting whether it did
+func withFallbackDecode(ctx context.Context) (context.Context, func() bool) {
+	decoded := new(atomic.Bool)
+	return context.WithValue(ctx, fallbackDecodeKey{}, decoded), decoded.Load
+}
+
+// RecordFallbackDecode ... records that the blob read by a request was decoded by a fallback of the
+// GeneratedKeyStore, e.g. in another point verification mode, whose payload doesn't re-encode to the blob it was
+// read from. The store must have verified the blob and its cert as retrieved, since its Verify can't verify the
+// payload. The router neither verifies the payload again nor writes it to its caches.
+func RecordFallbackDecode(ctx context.Context) {
+	if decoded, ok := ctx.Value(fallbackDecodeKey{}).(*atomic.Bool); ok {
+		decoded.Store(true)
+	}
+}
+
+// recordCommitmentMode ... records that the commitment returned by a write is of another mode than requested
+// into the context's ResponseMeta (if any)
+func recordCommitmentMode(ctx context.Context, mode commitments.CommitmentMode) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.commitmentMode = mode
+}
+
+// Backend ... returns the backend that served the request
+func (m *ResponseMeta) Backend() BackendType {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.backend
+}
+
+// Verification ... returns the verification mode applied to the served data
+func (m *ResponseMeta) Verification() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.verification
+}
+
+// EncodedSymbols ... returns the number of symbols of the encoded blob dispersed by the request, 0 if nothing
+// was dispersed
+func (m *ResponseMeta) EncodedSymbols() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.encodedSymbols
+}
+
+// DispersalRequestID ... returns the disperser request ID of the blob dispersed by the request, nil if nothing
+// was dispersed
+func (m *ResponseMeta) DispersalRequestID() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dispersalRequestID
+}
+
+// QuorumPolicy ... returns the quorum policy the cert of the read blob was verified with, empty if certs weren't
+// verified
+func (m *ResponseMeta) QuorumPolicy() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.quorumPolicy
+}
+
+// CommitmentMode ... returns the mode of the commitment returned by a write if it differs from the requested
+// one, e.g. while the write path is degraded, and an empty mode otherwise
+func (m *ResponseMeta) CommitmentMode() commitments.CommitmentMode {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.commitmentMode
+}
+
+// QuorumFallback ... returns whether the blob dispersed by the request fell back to the default quorums
+func (m *ResponseMeta) QuorumFallback() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.quorumFallback
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// backend operations, as recorded by metrics.Metricer.RecordBackendOperation
+const (
+	opRead  = "read"
+	opWrite = "write"
+)
+
+var tracer = tracing.Tracer("store")
+
+// startOp ... starts the span of an operation of a backend, and returns the function to call with the operation's
+// error once it completed, ending the span and recording the operation's metrics
+func (r *Router) startOp(ctx context.Context, s Store, op string) (context.Context, func(err error)) {
+	backend := s.BackendType().String()
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, backend+" "+op, trace.WithAttributes(tracing.Backend(backend)))
+	return ctx, func(err error) {
+		r.m.RecordBackendOperation(backend, op, err == nil, time.Since(start))
+		r.breakers.record(s, err)
+		tracing.End(span, err)
+	}
+}
+
+// commitmentModeAttr ... span attribute of the commitment mode of a request
+func commitmentModeAttr(cm commitments.CommitmentMode) attribute.KeyValue {
+	return attribute.String("commitment_mode", string(cm))
+}
+
+// verify ... verifies a blob read from s against cert in a span
+func (r *Router) verify(ctx context.Context, s Store, cert, data []byte) error {
+	_, span := tracer.Start(ctx, "verify", trace.WithAttributes(tracing.Backend(s.BackendType().String())))
+	err := r.eigenda.Verify(cert, data)
+	tracing.End(span, err)
+	return err
+}
+
+// recordVerificationFailure ... records a blob read from a backend that failed verification
+func (r *Router) recordVerificationFailure(s Store) {
+	r.m.RecordVerificationFailure(s.BackendType().String())
+}
+
+// dispersalErrorReason ... classifies a dispersal error into a bounded set of metric labels, i.e. the
+// snake-cased gRPC status code returned by the disperser if any
+func dispersalErrorReason(err error) string {
+	switch {
+	case errors.Is(err, ErrProxyOversizedBlob):
+		return "oversized_blob"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		return snakeCase(st.Code().String())
+	}
+	return "other"
+}
+
+// snakeCase ... converts a CamelCase identifier, e.g. ResourceExhausted, to resource_exhausted
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
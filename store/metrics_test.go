@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDispersalErrorReason(t *testing.T) {
+	tests := []struct {
+		err    error
+		reason string
+	}{
+		{err: fmt.Errorf("%w: blob length 10", ErrProxyOversizedBlob), reason: "oversized_blob"},
+		{err: fmt.Errorf("timed out: %w", context.DeadlineExceeded), reason: "deadline_exceeded"},
+		{err: context.Canceled, reason: "canceled"},
+		{err: fmt.Errorf("disperse: %w", status.Error(codes.ResourceExhausted, "rate limited")), reason: "resource_exhausted"},
+		{err: status.Error(codes.Unavailable, "connection refused"), reason: "unavailable"},
+		{err: errors.New("rejected cert"), reason: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			require.Equal(t, tt.reason, dispersalErrorReason(tt.err))
+		})
+	}
+}
+
+func TestBackendMetrics(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.NewMetrics("test")
+
+	cache := &failingStore{mapStore: mapStore{data: make(map[string][]byte)}, failing: true}
+	r, err := NewRouter(&fakeDAStore{blob: []byte("blob")}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+		RouterOptions{Metrics: m})
+	require.NoError(t, err)
+
+	require.Error(t, r.(*Router).handleRedundantWrites(ctx, []byte("a"), []byte("blob"), nil).Err())
+	cache.failing = false
+	require.NoError(t, r.(*Router).handleRedundantWrites(ctx, []byte("a"), []byte("blob"), nil).Err())
+
+	backend := RedisBackendType.String()
+	require.Equal(t, float64(1), testutil.ToFloat64(m.BackendOperations.WithLabelValues(backend, opWrite, "error")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.BackendOperations.WithLabelValues(backend, opWrite, "success")))
+
+	// blobs read from a target are verified against their cert
+	_, err = r.(*Router).multiSourceRead(ctx, []byte("a"), false)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(m.BackendOperations.WithLabelValues(backend, opRead, "success")))
+	require.Zero(t, testutil.ToFloat64(m.VerificationFailures.WithLabelValues(backend)))
+
+	r, err = NewRouter(&fakeDAStore{verifyErr: errors.New("commitment mismatch")}, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{Metrics: m})
+	require.NoError(t, err)
+	_, err = r.(*Router).multiSourceRead(ctx, []byte("a"), false)
+	require.Error(t, err)
+	require.Equal(t, float64(1), testutil.ToFloat64(m.VerificationFailures.WithLabelValues(backend)))
+}
+
+func TestBackendSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	cache := &failingStore{mapStore: mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{blob: []byte("blob")}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+		RouterOptions{})
+	require.NoError(t, err)
+	_, err = r.Put(context.Background(), commitments.SimpleCommitmentMode, nil, []byte("blob"))
+	require.NoError(t, err)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Contains(t, spans, "Router.Put")
+	root := spans["Router.Put"].SpanContext().SpanID()
+	for _, name := range []string{"EigenDA write", "Redis write"} {
+		require.Contains(t, spans, name)
+		require.Equal(t, root, spans[name].Parent().SpanID(), name)
+	}
+}
@@ -0,0 +1,289 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	CredentialTypeConnectionString CredentialType = "connection-string"
+	CredentialTypeManagedIdentity  CredentialType = "managed-identity"
+	CredentialTypeUnknown          CredentialType = "unknown"
+
+	// TTLDaysTag ... blob index tag carrying the TTL hint of a blob in days, rounded up, to be matched by the
+	// filters of lifecycle management rules
+	TTLDaysTag = "eigenda-proxy-ttl-days"
+)
+
+func StringToCredentialType(s string) CredentialType {
+	switch s {
+	case "connection-string":
+		return CredentialTypeConnectionString
+	case "managed-identity":
+		return CredentialTypeManagedIdentity
+	default:
+		return CredentialTypeUnknown
+	}
+}
+
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Profileable         = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.Exister             = (*Store)(nil)
+	_ store.Lister              = (*Store)(nil)
+	_ store.Pinger              = (*Store)(nil)
+	_ store.Reconnector         = (*Store)(nil)
+
+	errNotFound = fmt.Errorf("value not found in azure container: %w", store.ErrKeyNotFound)
+)
+
+type CredentialType string
+type Config struct {
+	CredentialType CredentialType
+	// ConnectionString of the storage account, used with CredentialTypeConnectionString
+	ConnectionString string
+	// AccountURL is the blob service URL of the storage account (https://<account>.blob.core.windows.net), used
+	// with CredentialTypeManagedIdentity
+	AccountURL string
+	// ManagedIdentityClientID selects a user-assigned managed identity, the system-assigned one is used if unset
+	ManagedIdentityClientID string
+	Container               string
+	Path                    string
+	Timeout                 time.Duration
+}
+
+type Store struct {
+	cfg Config
+
+	client *azblob.Client
+	// transport of the client, its idle connections are dropped on Reconnect
+	transport *http.Transport
+
+	// counters served by Stats
+	entries atomic.Int64
+	reads   atomic.Int64
+
+	// records profiling sessions
+	profiler *store.Profiler
+}
+
+// NewStore ... creates a store backed by a container of an Azure storage account
+func NewStore(l log.Logger, cfg Config) (*Store, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	opts := &azblob.ClientOptions{ClientOptions: azcore.ClientOptions{
+		Transport: &http.Client{Transport: transport},
+		Retry:     policy.RetryOptions{TryTimeout: cfg.Timeout},
+	}}
+
+	var client *azblob.Client
+	var err error
+	switch cfg.CredentialType {
+	case CredentialTypeConnectionString:
+		client, err = azblob.NewClientFromConnectionString(cfg.ConnectionString, opts)
+	case CredentialTypeManagedIdentity:
+		credOpts := &azidentity.ManagedIdentityCredentialOptions{}
+		if cfg.ManagedIdentityClientID != "" {
+			credOpts.ID = azidentity.ClientID(cfg.ManagedIdentityClientID)
+		}
+		var cred *azidentity.ManagedIdentityCredential
+		cred, err = azidentity.NewManagedIdentityCredential(credOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create azure managed identity credential: %w", err)
+		}
+		client, err = azblob.NewClient(cfg.AccountURL, cred, opts)
+	case CredentialTypeUnknown:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("unknown azure credential type: %s", cfg.CredentialType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure blob client: %w", err)
+	}
+
+	l.Info("Using Azure container", "backend", store.AzureBackendType, "container", cfg.Container,
+		"credentialType", cfg.CredentialType)
+	return &Store{
+		cfg:       cfg,
+		client:    client,
+		transport: transport,
+		profiler:  store.NewProfiler(store.AzureBackendType),
+	}, nil
+}
+
+// blobName ... name of the blob holding the value of key
+func (s *Store) blobName(key []byte) string {
+	return strings.TrimPrefix(path.Join(s.cfg.Path, hex.EncodeToString(key)), "/")
+}
+
+// prefix ... prefix of the names of the blobs under the configured path
+func (s *Store) prefix() string {
+	if p := path.Clean(s.cfg.Path); p != "." && p != "/" {
+		return strings.TrimPrefix(p, "/") + "/"
+	}
+	return ""
+}
+
+// Ping ... checks that the container is reachable, keeping a connection of the client's pool alive. Blobs are
+// listed rather than the container's properties read, since the latter requires control plane permissions.
+func (s *Store) Ping(ctx context.Context) error {
+	maxResults := int32(1)
+	pager := s.client.NewListBlobsFlatPager(s.cfg.Container, &azblob.ListBlobsFlatOptions{MaxResults: &maxResults})
+	_, err := pager.NextPage(ctx)
+	return err
+}
+
+// Reconnect ... drops the idle connections of the client's pool
+func (s *Store) Reconnect(_ context.Context) error {
+	s.transport.CloseIdleConnections()
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	start := time.Now()
+	data, err := s.get(ctx, key)
+	s.profiler.Record("get", time.Since(start), len(data), err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.reads.Add(1)
+	return data, nil
+}
+
+func (s *Store) get(ctx context.Context, key []byte) ([]byte, error) {
+	resp, err := s.client.DownloadStream(ctx, s.cfg.Container, s.blobName(key), nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	start := time.Now()
+	_, err := s.client.UploadBuffer(ctx, s.cfg.Container, s.blobName(key), value, uploadOptions(ctx))
+	s.profiler.Record("put", time.Since(start), len(value), err)
+	if err != nil {
+		return err
+	}
+
+	s.entries.Add(1)
+	return nil
+}
+
+// uploadOptions ... tags blobs written with a TTL hint, since Azure can only expire blobs via the lifecycle
+// management rules of the storage account
+func uploadOptions(ctx context.Context) *azblob.UploadBufferOptions {
+	ttl := store.TTLFromContext(ctx)
+	if ttl <= 0 {
+		return nil
+	}
+	days := (ttl + 24*time.Hour - 1) / (24 * time.Hour)
+	return &azblob.UploadBufferOptions{
+		Tags: map[string]string{TTLDaysTag: strconv.FormatInt(int64(days), 10)},
+	}
+}
+
+// Delete ... removes a blob from the container. Deleting a missing blob is not an error.
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	_, err := s.client.DeleteBlob(ctx, s.cfg.Container, s.blobName(key), nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Exists ... checks for a blob without downloading it
+func (s *Store) Exists(ctx context.Context, key []byte) (bool, error) {
+	blob := s.client.ServiceClient().NewContainerClient(s.cfg.Container).NewBlobClient(s.blobName(key))
+	_, err := blob.GetProperties(ctx, nil)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Keys ... lists the blobs under the configured path. Blobs whose names aren't hex encoded keys are skipped.
+func (s *Store) Keys(ctx context.Context, fn func(key []byte) error) error {
+	prefix := s.prefix()
+	pager := s.client.NewListBlobsFlatPager(s.cfg.Container, &azblob.ListBlobsFlatOptions{Prefix: &prefix})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+			key, err := hex.DecodeString(path.Base(*item.Name))
+			if err != nil {
+				continue
+			}
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) Verify(key []byte, value []byte) error {
+	h := crypto.Keccak256Hash(value)
+	if !bytes.Equal(h[:], key) {
+		return errors.New("key does not match value")
+	}
+
+	return nil
+}
+
+func (s *Store) VerificationMode() string {
+	return store.VerificationKeccak256
+}
+
+func (s *Store) Stats() *store.Stats {
+	return &store.Stats{
+		Entries: int(s.entries.Load()),
+		Reads:   int(s.reads.Load()),
+	}
+}
+
+// Profiler ... returns the profiler used to run detailed profiling sessions against this store
+func (s *Store) Profiler() *store.Profiler {
+	return s.profiler
+}
+
+// Identity ... the blob service URL, container and path backing the store
+func (s *Store) Identity() string {
+	return strings.TrimSuffix(s.client.URL(), "/") + "/" + path.Join(s.cfg.Container, s.cfg.Path)
+}
+
+func (s *Store) BackendType() store.BackendType {
+	return store.AzureBackendType
+}
@@ -0,0 +1,112 @@
+package azure
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// well-known development account of azurite
+const azuriteConnectionString = "DefaultEndpointsProtocol=http;AccountName=devstoreaccount1;" +
+	"AccountKey=Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw==;" +
+	"BlobEndpoint=http://127.0.0.1:10000/devstoreaccount1;" // #nosec G101
+
+func newTestStore(t *testing.T, path string) *Store {
+	s, err := NewStore(log.New(), Config{
+		CredentialType:   CredentialTypeConnectionString,
+		ConnectionString: azuriteConnectionString,
+		Container:        "eigenda-proxy-conformance-" + strings.TrimPrefix(hexutil.Encode(storetest.RandomValue(t, 4)), "0x"),
+		Path:             path,
+		Timeout:          5 * time.Second,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+// TestConformance ... requires an azurite server on localhost:10000 (see `make run-azurite`)
+func TestConformance(t *testing.T) {
+	if os.Getenv("INTEGRATION") != "true" && os.Getenv("INTEGRATION") != "1" {
+		t.Skip("Skipping test as INTEGRATION env var not set")
+	}
+
+	s := newTestStore(t, "blobs")
+	ctx := context.Background()
+	_, err := s.client.CreateContainer(ctx, s.cfg.Container, nil)
+	require.NoError(t, err)
+	require.NoError(t, s.Ping(ctx))
+
+	storetest.RunConformanceTests(t, func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore {
+		if ttl != 0 {
+			t.Skip("azure backend only expires entries through lifecycle management rules")
+		}
+		return s
+	})
+
+	t.Run("KeysExistsDelete", func(t *testing.T) {
+		var keys [][]byte
+		for i := 0; i < 3; i++ {
+			value := storetest.RandomValue(t, 64)
+			key := crypto.Keccak256(value)
+			require.NoError(t, s.Put(store.WithTTL(ctx, 48*time.Hour), key, value))
+			keys = append(keys, key)
+		}
+
+		var listed [][]byte
+		require.NoError(t, s.Keys(ctx, func(key []byte) error {
+			listed = append(listed, key)
+			return nil
+		}))
+		require.Subset(t, listed, keys)
+		errStop := io.EOF
+		require.ErrorIs(t, s.Keys(ctx, func([]byte) error { return errStop }), errStop)
+
+		ok, err := s.Exists(ctx, keys[0])
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, s.Delete(ctx, keys[0]))
+		ok, err = s.Exists(ctx, keys[0])
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, err = s.Get(ctx, keys[0])
+		require.ErrorIs(t, err, errNotFound)
+
+		// deleting a missing blob is not an error
+		require.NoError(t, s.Delete(ctx, keys[0]))
+	})
+}
+
+func TestStore(t *testing.T) {
+	s := newTestStore(t, "/a/b/")
+	require.Equal(t, "a/b/0102", s.blobName([]byte{1, 2}))
+	require.Equal(t, "a/b/", s.prefix())
+	require.Equal(t, "http://127.0.0.1:10000/devstoreaccount1/"+s.cfg.Container+"/a/b", s.Identity())
+
+	s = newTestStore(t, "")
+	require.Equal(t, "0102", s.blobName([]byte{1, 2}))
+	require.Equal(t, "", s.prefix())
+}
+
+func TestUploadOptions(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, uploadOptions(ctx))
+	require.Equal(t, map[string]string{TTLDaysTag: "2"}, uploadOptions(store.WithTTL(ctx, 25*time.Hour)).Tags)
+	require.Equal(t, map[string]string{TTLDaysTag: "1"}, uploadOptions(store.WithTTL(ctx, 24*time.Hour)).Tags)
+}
+
+func TestNewStoreCredentials(t *testing.T) {
+	_, err := NewStore(log.New(), Config{CredentialType: CredentialTypeUnknown, Container: "blobs"})
+	require.Error(t, err)
+
+	_, err = NewStore(log.New(), Config{CredentialType: CredentialTypeConnectionString, ConnectionString: "invalid"})
+	require.Error(t, err)
+}
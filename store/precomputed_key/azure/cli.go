@@ -0,0 +1,91 @@
+package azure
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	CredentialTypeFlagName          = withFlagPrefix("credential-type")
+	ConnectionStringFlagName        = withFlagPrefix("connection-string")
+	AccountURLFlagName              = withFlagPrefix("account-url")
+	ManagedIdentityClientIDFlagName = withFlagPrefix("managed-identity-client-id")
+	ContainerFlagName               = withFlagPrefix("container")
+	PathFlagName                    = withFlagPrefix("path")
+	TimeoutFlagName                 = withFlagPrefix("timeout")
+)
+
+func withFlagPrefix(s string) string {
+	return "azure." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_AZURE_" + s}
+}
+
+// CLIFlags ... used for Azure Blob Storage backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     CredentialTypeFlagName,
+			Usage:    "the way to authenticate to Azure Blob Storage, options are [connection-string, managed-identity]",
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIAL_TYPE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ConnectionStringFlagName,
+			Usage:    "connection string of the storage account used with the connection-string credential type",
+			EnvVars:  withEnvPrefix(envPrefix, "CONNECTION_STRING"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     AccountURLFlagName,
+			Usage:    "blob service URL of the storage account (e.g. https://<account>.blob.core.windows.net) used with the managed-identity credential type",
+			EnvVars:  withEnvPrefix(envPrefix, "ACCOUNT_URL"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ManagedIdentityClientIDFlagName,
+			Usage:    "client ID of the user-assigned managed identity to authenticate as, the system-assigned identity is used if unset",
+			EnvVars:  withEnvPrefix(envPrefix, "MANAGED_IDENTITY_CLIENT_ID"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ContainerFlagName,
+			Usage:    "container name for Azure Blob Storage",
+			EnvVars:  withEnvPrefix(envPrefix, "CONTAINER"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     PathFlagName,
+			Usage:    "path for Azure Blob Storage",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     TimeoutFlagName,
+				Usage:    "timeout for Azure Blob Storage operations (e.g. get, put)",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		CredentialType:          StringToCredentialType(ctx.String(CredentialTypeFlagName)),
+		ConnectionString:        ctx.String(ConnectionStringFlagName),
+		AccountURL:              ctx.String(AccountURLFlagName),
+		ManagedIdentityClientID: ctx.String(ManagedIdentityClientIDFlagName),
+		Container:               ctx.String(ContainerFlagName),
+		Path:                    ctx.String(PathFlagName),
+		Timeout:                 ctx.Duration(TimeoutFlagName),
+	}
+}
@@ -0,0 +1,70 @@
+package fs
+
+import (
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	PathFlagName               = withFlagPrefix("path")
+	CompactionIntervalFlagName = withFlagPrefix("compaction-interval")
+	MaxBytesFlagName           = withFlagPrefix("max-bytes")
+	CompactionIORateFlagName   = withFlagPrefix("compaction-io-rate")
+)
+
+func withFlagPrefix(s string) string {
+	return "fs." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_FS_" + s}
+}
+
+// CLIFlags ... used for filesystem backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     PathFlagName,
+			Usage:    "Directory used by the filesystem backend. The directory is locked for exclusive use by a single proxy instance.",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     CompactionIntervalFlagName,
+				Usage:    "Interval of background compactions of the filesystem backend, deleting expired values and files of interrupted writes and enforcing fs.max-bytes. Set to 0 to disable.",
+				Value:    0,
+				EnvVars:  withEnvPrefix(envPrefix, "COMPACTION_INTERVAL"),
+				Category: category,
+			},
+		},
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:     MaxBytesFlagName,
+				Usage:    "Maximum total size of the values stored by the filesystem backend, e.g. 20GiB. Compactions delete the oldest values exceeding it. Set to 0 for no limit.",
+				Value:    "0",
+				EnvVars:  withEnvPrefix(envPrefix, "MAX_BYTES"),
+				Category: category,
+			},
+		},
+		&cli.Float64Flag{
+			Name:     CompactionIORateFlagName,
+			Usage:    "Maximum number of file operations per second of a compaction, so that it doesn't compete with requests for disk IO. Set to 0 for no limit.",
+			Value:    100,
+			EnvVars:  withEnvPrefix(envPrefix, "COMPACTION_IO_RATE"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	// the size has already been validated by the flag's action
+	maxBytes, _ := utils.ParseBytesAmount(ctx.String(MaxBytesFlagName))
+	return Config{
+		Path:               ctx.String(PathFlagName),
+		CompactionInterval: ctx.Duration(CompactionIntervalFlagName),
+		MaxBytes:           maxBytes,
+		CompactionIORate:   ctx.Float64(CompactionIORateFlagName),
+	}
+}
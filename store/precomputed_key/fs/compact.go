@@ -0,0 +1,142 @@
+package fs
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// staleTempAge ... age after which the temporary file of a write is considered left behind by an interrupted
+// write rather than still being written
+const staleTempAge = time.Hour
+
+// CompactionReport ... outcome of a compaction of the filesystem store
+type CompactionReport struct {
+	// Expired counts values deleted because their TTL hint expired
+	Expired int
+	// Evicted counts values deleted, oldest first, to bring the store below its size cap
+	Evicted int
+	// Removed counts temporary files of interrupted writes and expiry files without a value
+	Removed int
+	// Bytes is the total size of the values kept
+	Bytes int64
+}
+
+// value ... a stored value considered for eviction
+type value struct {
+	key     []byte
+	size    int64
+	modTime time.Time
+}
+
+// Compact ... drops expired values and files left behind by interrupted writes, and evicts the oldest values
+// while the store exceeds its configured MaxBytes. File operations are throttled to the configured
+// CompactionIORate so that compaction doesn't compete with requests for disk IO.
+func (s *Store) Compact(ctx context.Context) (CompactionReport, error) {
+	limit := rate.Inf
+	if s.cfg.CompactionIORate > 0 {
+		limit = rate.Limit(s.cfg.CompactionIORate)
+	}
+	limiter := rate.NewLimiter(limit, 1)
+
+	var rep CompactionReport
+	var values []value
+	err := filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		name := d.Name()
+		switch {
+		case name == lockFileName:
+			return nil
+		case strings.Contains(name, ".tmp-"):
+			if info, err := d.Info(); err == nil && time.Since(info.ModTime()) > staleTempAge {
+				if os.Remove(path) == nil {
+					rep.Removed++
+				}
+			}
+			return nil
+		case strings.HasSuffix(name, expirySuffix):
+			if _, err := os.Stat(strings.TrimSuffix(path, expirySuffix)); errors.Is(err, os.ErrNotExist) {
+				if os.Remove(path) == nil {
+					rep.Removed++
+				}
+			}
+			return nil
+		}
+
+		key, err := hex.DecodeString(name)
+		if err != nil {
+			return nil
+		}
+		if s.expired(key) {
+			rep.Expired++
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, os.ErrNotExist) { // deleted concurrently
+			return nil
+		} else if err != nil {
+			return err
+		}
+		values = append(values, value{key: key, size: info.Size(), modTime: info.ModTime()})
+		rep.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return rep, err
+	}
+
+	if s.cfg.MaxBytes > 0 && rep.Bytes > int64(s.cfg.MaxBytes) { // #nosec G115
+		sort.Slice(values, func(i, j int) bool { return values[i].modTime.Before(values[j].modTime) })
+		for _, v := range values {
+			if rep.Bytes <= int64(s.cfg.MaxBytes) { // #nosec G115
+				break
+			}
+			if err := limiter.Wait(ctx); err != nil {
+				return rep, err
+			}
+			if err := s.Delete(ctx, v.key); err != nil {
+				return rep, err
+			}
+			rep.Evicted++
+			rep.Bytes -= v.size
+		}
+	}
+	return rep, nil
+}
+
+// RunCompaction ... compacts the store every configured CompactionInterval until ctx is done
+func (s *Store) RunCompaction(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.CompactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			rep, err := s.Compact(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.log.Warn("Failed to compact filesystem backend", "err", err)
+				continue
+			}
+			s.log.Info("Compacted filesystem backend", "expired", rep.Expired, "evicted", rep.Evicted,
+				"removed", rep.Removed, "bytes", rep.Bytes, "duration", time.Since(start))
+		}
+	}
+}
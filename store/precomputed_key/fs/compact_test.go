@@ -0,0 +1,55 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewStore(log.New(), Config{Path: t.TempDir(), MaxBytes: 10})
+	require.NoError(t, err)
+	defer s.Close()
+
+	expiring, oldest, newest := []byte("expiring"), []byte("oldest"), []byte("newest")
+	require.NoError(t, s.Put(store.WithTTL(ctx, time.Millisecond), crypto.Keccak256(expiring), expiring))
+	require.NoError(t, s.Put(ctx, crypto.Keccak256(oldest), oldest))
+	require.NoError(t, s.Put(ctx, crypto.Keccak256(newest), newest))
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(s.keyPath(crypto.Keccak256(oldest)), past, past))
+
+	// left behind by an interrupted write
+	tmp := s.keyPath(crypto.Keccak256(newest)) + ".tmp-1"
+	require.NoError(t, os.WriteFile(tmp, []byte("partial"), 0o600))
+	stale := time.Now().Add(-2 * staleTempAge)
+	require.NoError(t, os.Chtimes(tmp, stale, stale))
+	time.Sleep(10 * time.Millisecond)
+
+	rep, err := s.Compact(ctx)
+	require.NoError(t, err)
+	require.Equal(t, CompactionReport{Expired: 1, Evicted: 1, Removed: 1, Bytes: int64(len(newest))}, rep)
+
+	_, err = os.Stat(tmp)
+	require.ErrorIs(t, err, os.ErrNotExist)
+	for _, v := range [][]byte{expiring, oldest} {
+		got, err := s.Get(ctx, crypto.Keccak256(v))
+		require.NoError(t, err)
+		require.Nil(t, got)
+	}
+	got, err := s.Get(ctx, crypto.Keccak256(newest))
+	require.NoError(t, err)
+	require.Equal(t, newest, got)
+
+	matches, err := filepath.Glob(filepath.Join(s.dir, "*", "*"+expirySuffix))
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
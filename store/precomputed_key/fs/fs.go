@@ -0,0 +1,312 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	lockFileName = "LOCK"
+	// expirySuffix ... suffix of the files recording the expiry time of values written with a TTL hint
+	expirySuffix = ".expiry"
+)
+
+// Config ... user configurable
+type Config struct {
+	Path string
+	// CompactionInterval is the interval of background compactions, disabled if 0
+	CompactionInterval time.Duration
+	// MaxBytes caps the total size of the stored values, enforced by compactions. Unbounded if 0.
+	MaxBytes uint64
+	// CompactionIORate limits the file operations of compactions per second, unlimited if 0
+	CompactionIORate float64
+}
+
+// Store ... filesystem storage backend implementation. Values are stored in one file per key under the
+// configured directory, which is locked for exclusive use so that two proxies never share the same data dir.
+type Store struct {
+	cfg  Config
+	dir  string
+	lock *fileLock
+	log  log.Logger
+
+	mu    sync.Mutex
+	stats store.Stats
+}
+
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.Exister             = (*Store)(nil)
+	_ store.Lister              = (*Store)(nil)
+)
+
+// NewStore ... constructor
+func NewStore(l log.Logger, cfg Config) (*Store, error) {
+	dir, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve fs backend path %s: %w", cfg.Path, err)
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create fs backend directory %s: %w", dir, err)
+	}
+
+	lock, err := acquireLock(filepath.Join(dir, lockFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock fs backend directory %s: %w", dir, err)
+	}
+
+	s := &Store{
+		cfg:  cfg,
+		dir:  dir,
+		lock: lock,
+		log:  l.New("backend", store.FSBackendType),
+	}
+
+	// values written before a restart are entries too. Expired values deleted by the walk aren't counted.
+	entries := 0
+	err = s.Walk(func([]byte, int64, time.Time) error {
+		entries++
+		return nil
+	})
+	if err != nil {
+		_ = lock.release()
+		return nil, fmt.Errorf("failed to count values in fs backend directory %s: %w", dir, err)
+	}
+	s.stats.Entries = entries
+
+	return s, nil
+}
+
+// keyPath ... returns the file path for a key. Keys are hex encoded and sharded by their first byte to keep
+// directory sizes manageable; filepath is used throughout so paths are valid on both unix and windows.
+func (s *Store) keyPath(key []byte) string {
+	name := hex.EncodeToString(key)
+	if len(name) < 2 {
+		return filepath.Join(s.dir, name)
+	}
+	return filepath.Join(s.dir, name[:2], name)
+}
+
+// expired ... returns whether the value of key was written with a TTL hint that has since expired, in which
+// case it is deleted
+func (s *Store) expired(key []byte) bool {
+	b, err := os.ReadFile(s.keyPath(key) + expirySuffix)
+	if err != nil {
+		return false
+	}
+	expiry, err := time.Parse(time.RFC3339Nano, string(b))
+	if err != nil || time.Now().Before(expiry) {
+		return false
+	}
+	if err := s.Delete(context.Background(), key); err != nil {
+		s.log.Warn("Failed to delete expired value", "key", hex.EncodeToString(key), "err", err)
+	}
+	return true
+}
+
+// Get ... retrieves a value from the filesystem store. Returns nil if the key is not found or has expired.
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.expired(key) {
+		return nil, nil
+	}
+
+	value, err := os.ReadFile(s.keyPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.stats.Reads++
+	s.mu.Unlock()
+
+	return value, nil
+}
+
+// Put ... inserts a value into the filesystem store. The value is written to a temporary file which is
+// then renamed into place, so readers never observe partially written values. Values written with a TTL
+// hint are deleted once they are accessed after expiring.
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := s.keyPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	// windows does not allow renaming files that are still open
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// overwrites of a value, e.g. a blob written again by a re-drive, aren't new entries. The lock spans the
+	// check and the rename so that concurrent writes of the same key count it once.
+	s.mu.Lock()
+	_, err = os.Stat(path)
+	exists := err == nil
+	err = os.Rename(tmp.Name(), path)
+	if err == nil && !exists {
+		s.stats.Entries++
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return s.putExpiry(path, store.TTLFromContext(ctx))
+}
+
+// putExpiry ... records the expiry time of the value at path, or removes a previously recorded one if the
+// value was overwritten without a TTL hint
+func (s *Store) putExpiry(path string, ttl time.Duration) error {
+	if ttl <= 0 {
+		err := os.Remove(path + expirySuffix)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	expiry := time.Now().Add(ttl).UTC().Format(time.RFC3339Nano)
+	return os.WriteFile(path+expirySuffix, []byte(expiry), 0o600)
+}
+
+// Delete ... removes a value from the filesystem store
+func (s *Store) Delete(_ context.Context, key []byte) error {
+	path := s.keyPath(key)
+	if err := os.Remove(path + expirySuffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	// the lock spans the removal so that concurrent deletes of the same key count it once, see Put
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	s.stats.Entries--
+	return nil
+}
+
+// Exists ... checks for a key without reading its value
+func (s *Store) Exists(ctx context.Context, key []byte) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	if s.expired(key) {
+		return false, nil
+	}
+	_, err := os.Stat(s.keyPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Keys ... lists the stored keys
+func (s *Store) Keys(ctx context.Context, fn func(key []byte) error) error {
+	return s.Walk(func(key []byte, _ int64, _ time.Time) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return fn(key)
+	})
+}
+
+// Walk ... calls fn with the key, size and modification time of every stored value, e.g. to rebuild an
+// index of the store after a restart. Expired values are deleted instead.
+func (s *Store) Walk(fn func(key []byte, size int64, modTime time.Time) error) error {
+	return filepath.WalkDir(s.dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// skip the lock file, expiry files and temporary files of interrupted writes
+		if d.IsDir() || d.Name() == lockFileName || strings.HasSuffix(d.Name(), expirySuffix) ||
+			strings.Contains(d.Name(), ".tmp-") {
+			return nil
+		}
+		key, err := hex.DecodeString(d.Name())
+		if err != nil {
+			return nil
+		}
+		if s.expired(key) {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, os.ErrNotExist) { // deleted concurrently
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return fn(key, info.Size(), info.ModTime())
+	})
+}
+
+// Verify ... ensures that the key is the keccak256 hash of the value
+func (s *Store) Verify(key []byte, value []byte) error {
+	h := crypto.Keccak256Hash(value)
+	if !bytes.Equal(h[:], key) {
+		return errors.New("key does not match value")
+	}
+
+	return nil
+}
+
+func (s *Store) VerificationMode() string {
+	return store.VerificationKeccak256
+}
+
+// Identity ... the directory backing the store
+func (s *Store) Identity() string {
+	return "file://" + filepath.ToSlash(s.dir)
+}
+
+func (s *Store) BackendType() store.BackendType {
+	return store.FSBackendType
+}
+
+func (s *Store) Stats() *store.Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	return &stats
+}
+
+// Close ... releases the lock on the data directory
+func (s *Store) Close() error {
+	return s.lock.release()
+}
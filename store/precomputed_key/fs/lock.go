@@ -0,0 +1,37 @@
+package fs
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrLocked is returned when the data directory is already locked by another process.
+var ErrLocked = errors.New("directory is locked by another process")
+
+// fileLock ... an exclusive advisory lock held on a file for the lifetime of the process.
+// The platform specific lockFile/unlockFile implementations live in lock_*.go.
+type fileLock struct {
+	f *os.File
+}
+
+func acquireLock(path string) (*fileLock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &fileLock{f: f}, nil
+}
+
+func (l *fileLock) release() error {
+	if err := unlockFile(l.f); err != nil {
+		_ = l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
@@ -0,0 +1,15 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package fs
+
+import "os"
+
+// lockFile ... file locking is not supported on this platform, so exclusive use of the
+// data directory is not enforced.
+func lockFile(_ *os.File) error {
+	return nil
+}
+
+func unlockFile(_ *os.File) error {
+	return nil
+}
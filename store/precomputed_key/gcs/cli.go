@@ -0,0 +1,84 @@
+package gcs
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	CredentialTypeFlagName  = withFlagPrefix("credential-type")
+	CredentialsFileFlagName = withFlagPrefix("credentials-file") // #nosec G101
+	EndpointFlagName        = withFlagPrefix("endpoint")
+	BucketFlagName          = withFlagPrefix("bucket")
+	PathFlagName            = withFlagPrefix("path")
+	TimeoutFlagName         = withFlagPrefix("timeout")
+)
+
+func withFlagPrefix(s string) string {
+	return "gcs." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_GCS_" + s}
+}
+
+// CLIFlags ... used for GCS backend configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     CredentialTypeFlagName,
+			Usage:    "the way to authenticate to GCS, options are [service-account, workload-identity, anonymous]. workload-identity uses the application default credentials, e.g. GKE workload identity; anonymous is meant for emulators",
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIAL_TYPE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     CredentialsFileFlagName,
+			Usage:    "path to the JSON key of the service account used with the service-account credential type",
+			EnvVars:  withEnvPrefix(envPrefix, "CREDENTIALS_FILE"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "endpoint of the GCS JSON API, only needed to reach an emulator (e.g. fake-gcs-server)",
+			Value:    DefaultEndpoint,
+			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     BucketFlagName,
+			Usage:    "bucket name for GCS storage",
+			EnvVars:  withEnvPrefix(envPrefix, "BUCKET"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     PathFlagName,
+			Usage:    "path for GCS storage",
+			EnvVars:  withEnvPrefix(envPrefix, "PATH"),
+			Category: category,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     TimeoutFlagName,
+				Usage:    "timeout for GCS storage operations (e.g. get, put)",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		CredentialType:  StringToCredentialType(ctx.String(CredentialTypeFlagName)),
+		CredentialsFile: ctx.String(CredentialsFileFlagName),
+		Endpoint:        ctx.String(EndpointFlagName),
+		Bucket:          ctx.String(BucketFlagName),
+		Path:            ctx.String(PathFlagName),
+		Timeout:         ctx.Duration(TimeoutFlagName),
+	}
+}
@@ -0,0 +1,412 @@
+package gcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	CredentialTypeServiceAccount   CredentialType = "service-account"
+	CredentialTypeWorkloadIdentity CredentialType = "workload-identity"
+	CredentialTypeAnonymous        CredentialType = "anonymous"
+	CredentialTypeUnknown          CredentialType = "unknown"
+
+	// DefaultEndpoint ... endpoint of the Google Cloud Storage JSON API
+	DefaultEndpoint = "https://storage.googleapis.com"
+
+	// scope ... OAuth2 scope granting read and write access to objects
+	scope = "https://www.googleapis.com/auth/devstorage.read_write"
+)
+
+func StringToCredentialType(s string) CredentialType {
+	switch s {
+	case "service-account":
+		return CredentialTypeServiceAccount
+	case "workload-identity":
+		return CredentialTypeWorkloadIdentity
+	case "anonymous":
+		return CredentialTypeAnonymous
+	default:
+		return CredentialTypeUnknown
+	}
+}
+
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Profileable         = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.Exister             = (*Store)(nil)
+	_ store.Lister              = (*Store)(nil)
+	_ store.Pinger              = (*Store)(nil)
+	_ store.Reconnector         = (*Store)(nil)
+
+	errNotFound = fmt.Errorf("value not found in gcs bucket: %w", store.ErrKeyNotFound)
+)
+
+type CredentialType string
+type Config struct {
+	CredentialType CredentialType
+	// CredentialsFile is the JSON key of the service account used with CredentialTypeServiceAccount
+	CredentialsFile string
+	// Endpoint of the JSON API, only set to reach an emulator (e.g. fake-gcs-server)
+	Endpoint string
+	Bucket   string
+	Path     string
+	Timeout  time.Duration
+}
+
+type Store struct {
+	cfg      Config
+	endpoint string
+
+	client *http.Client
+	// transport of the client, its idle connections are dropped on Reconnect
+	transport *http.Transport
+
+	// counters served by Stats
+	entries atomic.Int64
+	reads   atomic.Int64
+
+	// records profiling sessions
+	profiler *store.Profiler
+}
+
+// NewStore ... creates a store backed by a GCS bucket. With CredentialTypeWorkloadIdentity, credentials are
+// found the way Google client libraries find them, i.e. from the GKE metadata server when running with workload
+// identity, or from GOOGLE_APPLICATION_CREDENTIALS (e.g. a workload identity federation config).
+func NewStore(ctx context.Context, l log.Logger, cfg Config) (*Store, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &http.Client{Transport: transport, Timeout: cfg.Timeout}
+
+	switch cfg.CredentialType {
+	case CredentialTypeServiceAccount:
+		b, err := os.ReadFile(cfg.CredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gcs credentials file: %w", err)
+		}
+		creds, err := google.CredentialsFromJSON(ctx, b, scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse gcs credentials file: %w", err)
+		}
+		client.Transport = &oauth2.Transport{Source: creds.TokenSource, Base: transport}
+	case CredentialTypeWorkloadIdentity:
+		creds, err := google.FindDefaultCredentials(ctx, scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find gcs workload credentials: %w", err)
+		}
+		client.Transport = &oauth2.Transport{Source: creds.TokenSource, Base: transport}
+	case CredentialTypeAnonymous:
+	case CredentialTypeUnknown:
+		fallthrough
+	default:
+		return nil, fmt.Errorf("unknown gcs credential type: %s", cfg.CredentialType)
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	l.Info("Using GCS bucket", "backend", store.GCSBackendType, "bucket", cfg.Bucket, "endpoint", endpoint,
+		"credentialType", cfg.CredentialType)
+	return &Store{
+		cfg:       cfg,
+		endpoint:  strings.TrimSuffix(endpoint, "/"),
+		client:    client,
+		transport: transport,
+		profiler:  store.NewProfiler(store.GCSBackendType),
+	}, nil
+}
+
+// objectName ... name of the object holding the value of key
+func (s *Store) objectName(key []byte) string {
+	return strings.TrimPrefix(path.Join(s.cfg.Path, hex.EncodeToString(key)), "/")
+}
+
+// objectURL ... JSON API URL of the object holding the value of key
+func (s *Store) objectURL(key []byte) string {
+	return s.endpoint + "/storage/v1/b/" + url.PathEscape(s.cfg.Bucket) + "/o/" + url.PathEscape(s.objectName(key))
+}
+
+// apiError ... error returned by the JSON API
+type apiError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// do ... sends the request, returning the response if its status is expected. The response body is closed
+// otherwise.
+func (s *Store) do(req *http.Request, expected ...int) (*http.Response, error) {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range expected {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+
+	var apiErr apiError
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err := json.Unmarshal(b, &apiErr); err == nil && apiErr.Error.Message != "" {
+		return nil, fmt.Errorf("gcs %s %s: %d %s", req.Method, req.URL.Path, resp.StatusCode, apiErr.Error.Message)
+	}
+	return nil, fmt.Errorf("gcs %s %s: %s", req.Method, req.URL.Path, resp.Status)
+}
+
+// Ping ... checks that the bucket is reachable, keeping a connection of the client's pool alive. Objects are
+// listed rather than the bucket read, since the latter requires bucket permissions.
+func (s *Store) Ping(ctx context.Context) error {
+	u := s.endpoint + "/storage/v1/b/" + url.PathEscape(s.cfg.Bucket) + "/o?maxResults=1&fields=nextPageToken"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Reconnect ... drops the idle connections of the client's pool
+func (s *Store) Reconnect(_ context.Context) error {
+	s.transport.CloseIdleConnections()
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	start := time.Now()
+	data, err := s.get(ctx, key)
+	s.profiler.Record("get", time.Since(start), len(data), err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.reads.Add(1)
+	return data, nil
+}
+
+func (s *Store) get(ctx context.Context, key []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key)+"?alt=media", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	start := time.Now()
+	err := s.put(ctx, key, value)
+	s.profiler.Record("put", time.Since(start), len(value), err)
+	if err != nil {
+		return err
+	}
+
+	s.entries.Add(1)
+	return nil
+}
+
+// objectMetadata ... metadata of an uploaded object
+type objectMetadata struct {
+	Name string `json:"name"`
+	// CustomTime is set to the expiry of blobs written with a TTL hint, to be matched by a daysSinceCustomTime
+	// lifecycle rule of the bucket
+	CustomTime string `json:"customTime,omitempty"`
+}
+
+// put ... uploads the value in a single multipart request carrying the object's metadata
+func (s *Store) put(ctx context.Context, key []byte, value []byte) error {
+	meta := objectMetadata{Name: s.objectName(key)}
+	if ttl := store.TTLFromContext(ctx); ttl > 0 {
+		meta.CustomTime = time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	}
+	metaJSON, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		data        []byte
+	}{
+		{"application/json; charset=UTF-8", metaJSON},
+		{"application/octet-stream", value},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(part.data); err != nil {
+			return err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	u := s.endpoint + "/upload/storage/v1/b/" + url.PathEscape(s.cfg.Bucket) + "/o?uploadType=multipart&fields=name"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "multipart/related; boundary="+mw.Boundary())
+	resp, err := s.do(req, http.StatusOK)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Delete ... removes an object from the bucket. Deleting a missing object is not an error.
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// Exists ... checks for an object without downloading it
+func (s *Store) Exists(ctx context.Context, key []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key)+"?fields=name", nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := s.do(req, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// objectList ... a page of objects listed by the JSON API
+type objectList struct {
+	Items []struct {
+		Name string `json:"name"`
+	} `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+}
+
+// Keys ... lists the objects under the configured path. Objects whose names aren't hex encoded keys are skipped.
+func (s *Store) Keys(ctx context.Context, fn func(key []byte) error) error {
+	prefix := ""
+	if p := path.Clean(s.cfg.Path); p != "." && p != "/" {
+		prefix = strings.TrimPrefix(p, "/") + "/"
+	}
+
+	pageToken := ""
+	for {
+		query := url.Values{"fields": {"items(name),nextPageToken"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if pageToken != "" {
+			query.Set("pageToken", pageToken)
+		}
+		u := s.endpoint + "/storage/v1/b/" + url.PathEscape(s.cfg.Bucket) + "/o?" + query.Encode()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := s.do(req, http.StatusOK)
+		if err != nil {
+			return err
+		}
+		var page objectList
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode gcs object list: %w", err)
+		}
+
+		for _, obj := range page.Items {
+			key, err := hex.DecodeString(path.Base(obj.Name))
+			if err != nil {
+				continue
+			}
+			if err := fn(key); err != nil {
+				return err
+			}
+		}
+		if page.NextPageToken == "" {
+			return nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+func (s *Store) Verify(key []byte, value []byte) error {
+	h := crypto.Keccak256Hash(value)
+	if !bytes.Equal(h[:], key) {
+		return errors.New("key does not match value")
+	}
+
+	return nil
+}
+
+func (s *Store) VerificationMode() string {
+	return store.VerificationKeccak256
+}
+
+func (s *Store) Stats() *store.Stats {
+	return &store.Stats{
+		Entries: int(s.entries.Load()),
+		Reads:   int(s.reads.Load()),
+	}
+}
+
+// Profiler ... returns the profiler used to run detailed profiling sessions against this store
+func (s *Store) Profiler() *store.Profiler {
+	return s.profiler
+}
+
+// Identity ... the bucket and path backing the store, along with the endpoint if it isn't the default one
+func (s *Store) Identity() string {
+	if s.endpoint != DefaultEndpoint {
+		return "gcs+" + s.endpoint + "/" + path.Join(s.cfg.Bucket, s.cfg.Path)
+	}
+	return "gs://" + path.Join(s.cfg.Bucket, s.cfg.Path)
+}
+
+func (s *Store) BackendType() store.BackendType {
+	return store.GCSBackendType
+}
@@ -0,0 +1,225 @@
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testBucket = "eigenda-proxy"
+
+// fakeGCS ... minimal in-memory implementation of the GCS JSON API endpoints used by the store
+type fakeGCS struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	meta    map[string]objectMetadata
+	// pageSize of object listings, small enough for the tests to page through listings
+	pageSize int
+}
+
+func newFakeGCS(t *testing.T) (*fakeGCS, *httptest.Server) {
+	f := &fakeGCS{objects: make(map[string][]byte), meta: make(map[string]objectMetadata), pageSize: 2}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"No such object"}}`))
+	}
+	bucketPath := "/storage/v1/b/" + testBucket
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/"+testBucket+"/o":
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || r.URL.Query().Get("uploadType") != "multipart" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		var parts [][]byte
+		for {
+			p, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			b, _ := io.ReadAll(p)
+			parts = append(parts, b)
+		}
+		var meta objectMetadata
+		if len(parts) != 2 || json.Unmarshal(parts[0], &meta) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[meta.Name], f.meta[meta.Name] = parts[1], meta
+		_, _ = w.Write([]byte(`{"name":"` + meta.Name + `"}`))
+
+	case r.Method == http.MethodGet && r.URL.Path == bucketPath+"/o":
+		prefix := r.URL.Query().Get("prefix")
+		var names []string
+		for name := range f.objects {
+			if strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		start := sort.SearchStrings(names, r.URL.Query().Get("pageToken"))
+		var page objectList
+		for _, name := range names[start:min(start+f.pageSize, len(names))] {
+			page.Items = append(page.Items, struct {
+				Name string `json:"name"`
+			}{name})
+		}
+		if start+f.pageSize < len(names) {
+			page.NextPageToken = names[start+f.pageSize]
+		}
+		_ = json.NewEncoder(w).Encode(page)
+
+	case strings.HasPrefix(r.URL.Path, bucketPath+"/o/"):
+		name := strings.TrimPrefix(r.URL.Path, bucketPath+"/o/")
+		value, ok := f.objects[name]
+		if !ok {
+			notFound()
+			return
+		}
+		switch {
+		case r.Method == http.MethodDelete:
+			delete(f.objects, name)
+			delete(f.meta, name)
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write(value)
+		default:
+			_, _ = w.Write([]byte(`{"name":"` + name + `"}`))
+		}
+
+	default:
+		notFound()
+	}
+}
+
+func newTestStore(t *testing.T, srv *httptest.Server, path string) *Store {
+	s, err := NewStore(context.Background(), log.New(), Config{
+		CredentialType: CredentialTypeAnonymous,
+		Endpoint:       srv.URL,
+		Bucket:         testBucket,
+		Path:           path,
+		Timeout:        5 * time.Second,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestConformance(t *testing.T) {
+	_, srv := newFakeGCS(t)
+	s := newTestStore(t, srv, "blobs")
+
+	storetest.RunConformanceTests(t, func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore {
+		if ttl != 0 {
+			t.Skip("gcs backend only expires entries through bucket lifecycle rules")
+		}
+		return s
+	})
+}
+
+func TestStore(t *testing.T) {
+	fake, srv := newFakeGCS(t)
+	s := newTestStore(t, srv, "/blobs/")
+	ctx := context.Background()
+	require.NoError(t, s.Ping(ctx))
+	require.Equal(t, "gcs+"+srv.URL+"/"+testBucket+"/blobs", s.Identity())
+
+	var keys [][]byte
+	for i := 0; i < 5; i++ {
+		value := storetest.RandomValue(t, 64)
+		key := crypto.Keccak256(value)
+		require.NoError(t, s.Put(ctx, key, value))
+		keys = append(keys, key)
+	}
+	// objects outside the path, or whose names aren't keys, aren't listed
+	fake.objects["other/"+strings.Repeat("ab", 32)] = []byte("other")
+	fake.objects["blobs/not-a-key"] = []byte("other")
+
+	t.Run("Keys", func(t *testing.T) {
+		var listed [][]byte
+		require.NoError(t, s.Keys(ctx, func(key []byte) error {
+			listed = append(listed, key)
+			return nil
+		}))
+		require.ElementsMatch(t, keys, listed)
+
+		errStop := io.EOF
+		calls := 0
+		require.ErrorIs(t, s.Keys(ctx, func([]byte) error { calls++; return errStop }), errStop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("ExistsDelete", func(t *testing.T) {
+		ok, err := s.Exists(ctx, keys[0])
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		require.NoError(t, s.Delete(ctx, keys[0]))
+		ok, err = s.Exists(ctx, keys[0])
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, err = s.Get(ctx, keys[0])
+		require.ErrorIs(t, err, errNotFound)
+
+		// deleting a missing object is not an error
+		require.NoError(t, s.Delete(ctx, keys[0]))
+	})
+
+	t.Run("TTLHint", func(t *testing.T) {
+		value := storetest.RandomValue(t, 64)
+		key := crypto.Keccak256(value)
+		require.NoError(t, s.Put(store.WithTTL(ctx, 48*time.Hour), key, value))
+
+		customTime, err := time.Parse(time.RFC3339, fake.meta[s.objectName(key)].CustomTime)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(48*time.Hour), customTime, time.Minute)
+	})
+}
+
+func TestObjectURL(t *testing.T) {
+	s := &Store{cfg: Config{Bucket: testBucket, Path: "a/b"}, endpoint: DefaultEndpoint}
+	require.Equal(t, DefaultEndpoint+"/storage/v1/b/"+testBucket+"/o/"+url.PathEscape("a/b/0102"),
+		s.objectURL([]byte{1, 2}))
+	require.Equal(t, "gs://"+testBucket+"/a/b", s.Identity())
+}
+
+func TestNewStoreCredentials(t *testing.T) {
+	_, err := NewStore(context.Background(), log.New(), Config{CredentialType: CredentialTypeUnknown, Bucket: testBucket})
+	require.Error(t, err)
+
+	_, err = NewStore(context.Background(), log.New(), Config{
+		CredentialType:  CredentialTypeServiceAccount,
+		CredentialsFile: t.TempDir() + "/missing.json",
+		Bucket:          testBucket,
+	})
+	require.Error(t, err)
+}
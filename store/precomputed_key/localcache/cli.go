@@ -0,0 +1,62 @@
+package localcache
+
+import (
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	MemoryMaxBytesFlagName = withFlagPrefix("memory-max-bytes")
+	DiskPathFlagName       = withFlagPrefix("disk-path")
+	DiskMaxBytesFlagName   = withFlagPrefix("disk-max-bytes")
+)
+
+func withFlagPrefix(s string) string {
+	return "local-cache." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_LOCAL_CACHE_" + s}
+}
+
+// CLIFlags ... used for local cache configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:     MemoryMaxBytesFlagName,
+				Usage:    "Maximum total size of the blobs held in memory by the local cache, e.g. 512MiB.",
+				Value:    "256MiB",
+				EnvVars:  withEnvPrefix(envPrefix, "MEMORY_MAX_BYTES"),
+				Category: category,
+			},
+		},
+		&cli.StringFlag{
+			Name:     DiskPathFlagName,
+			Usage:    "Directory blobs evicted from memory overflow to. The disk tier is disabled if unset. The directory is locked for exclusive use by a single proxy instance.",
+			EnvVars:  withEnvPrefix(envPrefix, "DISK_PATH"),
+			Category: category,
+		},
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:     DiskMaxBytesFlagName,
+				Usage:    "Maximum total size of the blobs stored on disk by the local cache, e.g. 20GiB.",
+				Value:    "10GiB",
+				EnvVars:  withEnvPrefix(envPrefix, "DISK_MAX_BYTES"),
+				Category: category,
+			},
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	// sizes have already been validated by the flags' actions
+	memoryMaxBytes, _ := utils.ParseBytesAmount(ctx.String(MemoryMaxBytesFlagName))
+	diskMaxBytes, _ := utils.ParseBytesAmount(ctx.String(DiskMaxBytesFlagName))
+	return Config{
+		MemoryMaxBytes: memoryMaxBytes,
+		DiskPath:       ctx.String(DiskPathFlagName),
+		DiskMaxBytes:   diskMaxBytes,
+	}
+}
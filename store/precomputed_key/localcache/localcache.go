@@ -0,0 +1,301 @@
+// Package localcache provides a two-tier local cache target: an in-memory LRU whose evictions overflow to a
+// bounded on-disk cache, so that read-heavy nodes serve their working set with near-local latency without
+// holding it in RAM.
+package localcache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/precomputed_key/fs"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config ... user configurable
+type Config struct {
+	// MemoryMaxBytes bounds the total size of the values held in memory
+	MemoryMaxBytes uint64
+	// DiskPath is the directory of the disk tier, which is disabled if empty
+	DiskPath string
+	// DiskMaxBytes bounds the total size of the values stored on disk
+	DiskMaxBytes uint64
+}
+
+// TierStats ... hit rates and usage of the cache tiers
+type TierStats struct {
+	MemoryHits  int    `json:"memory_hits"`
+	DiskHits    int    `json:"disk_hits"`
+	Misses      int    `json:"misses"`
+	MemoryBytes uint64 `json:"memory_bytes"`
+	DiskBytes   uint64 `json:"disk_bytes"`
+}
+
+// Store ... two-tier cache. Values evicted from the memory tier are spilled to the disk tier, values read
+// from the disk tier are promoted back into memory while being kept on disk, so that evicting them again
+// doesn't rewrite them. Both tiers evict their least recently used values.
+type Store struct {
+	memMu sync.Mutex
+	mem   *lru
+
+	// disk is nil if the disk tier is disabled
+	disk      *fs.Store
+	diskMu    sync.Mutex
+	diskIndex *lru
+
+	mu    sync.Mutex
+	stats store.Stats
+	tiers TierStats
+}
+
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.ReadThroughCache    = (*Store)(nil)
+)
+
+// NewStore ... constructor. Values already present in the disk directory, e.g. from before a restart, are
+// indexed by their modification time and evicted if they exceed the configured bound.
+func NewStore(l log.Logger, cfg Config) (*Store, error) {
+	s := &Store{
+		mem: newLRU(cfg.MemoryMaxBytes),
+	}
+	if cfg.DiskPath == "" {
+		return s, nil
+	}
+
+	disk, err := fs.NewStore(l, fs.Config{Path: cfg.DiskPath})
+	if err != nil {
+		return nil, err
+	}
+	s.disk = disk
+	s.diskIndex = newLRU(cfg.DiskMaxBytes)
+
+	type file struct {
+		key     []byte
+		size    int64
+		modTime time.Time
+	}
+	var files []file
+	err = disk.Walk(func(key []byte, size int64, modTime time.Time) error {
+		files = append(files, file{key, size, modTime})
+		return nil
+	})
+	if err != nil {
+		_ = disk.Close()
+		return nil, fmt.Errorf("failed to index local cache directory %s: %w", cfg.DiskPath, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	evictedCount := 0
+	for _, f := range files {
+		evicted := s.diskIndex.add(&entry{key: string(f.key), size: uint64(f.size)}) // #nosec G115
+		if err := s.deleteFromDisk(context.Background(), evicted); err != nil {
+			_ = disk.Close()
+			return nil, err
+		}
+		evictedCount += len(evicted)
+	}
+	if len(files) > 0 {
+		l.Info("Indexed local cache disk tier", "backend", store.LocalBackendType, "path", cfg.DiskPath,
+			"values", len(files)-evictedCount, "evicted", evictedCount)
+	}
+
+	return s, nil
+}
+
+// Get ... retrieves a value from the memory tier, or from the disk tier promoting it into memory. Returns
+// nil if the key is in neither tier.
+func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.memMu.Lock()
+	if e, ok := s.mem.get(string(key)); ok {
+		s.memMu.Unlock()
+		s.recordRead(func(t *TierStats) { t.MemoryHits++ })
+		return e.value, nil
+	}
+	s.memMu.Unlock()
+
+	if s.disk == nil {
+		s.recordMiss()
+		return nil, nil
+	}
+
+	s.diskMu.Lock()
+	_, ok := s.diskIndex.get(string(key))
+	s.diskMu.Unlock()
+	if !ok {
+		s.recordMiss()
+		return nil, nil
+	}
+
+	value, err := s.disk.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil { // evicted concurrently
+		s.recordMiss()
+		return nil, nil
+	}
+	s.recordRead(func(t *TierStats) { t.DiskHits++ })
+
+	if err := s.addToMemory(ctx, key, value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Put ... inserts a value into the memory tier, spilling the least recently used values to disk
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := s.addToMemory(ctx, key, bytes.Clone(value)); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.stats.Entries++
+	s.mu.Unlock()
+	return nil
+}
+
+// Delete ... removes a value from both tiers
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	s.memMu.Lock()
+	s.mem.remove(string(key))
+	s.memMu.Unlock()
+
+	if s.disk == nil {
+		return nil
+	}
+
+	s.diskMu.Lock()
+	s.diskIndex.remove(string(key))
+	s.diskMu.Unlock()
+	return s.disk.Delete(ctx, key)
+}
+
+// addToMemory ... inserts a value into the memory tier and spills the values it evicts to disk
+func (s *Store) addToMemory(ctx context.Context, key []byte, value []byte) error {
+	s.memMu.Lock()
+	evicted := s.mem.add(&entry{key: string(key), value: value, size: uint64(len(value))})
+	s.memMu.Unlock()
+
+	for _, e := range evicted {
+		if err := s.spill(ctx, e); err != nil {
+			return fmt.Errorf("failed to spill value to local cache disk tier: %w", err)
+		}
+	}
+	return nil
+}
+
+// spill ... writes a value evicted from memory to the disk tier, unless it is already stored there or is
+// larger than the whole disk tier
+func (s *Store) spill(ctx context.Context, e *entry) error {
+	if s.disk == nil || e.size > s.diskIndex.maxBytes {
+		return nil
+	}
+
+	s.diskMu.Lock()
+	_, ok := s.diskIndex.get(e.key)
+	s.diskMu.Unlock()
+	if ok {
+		return nil
+	}
+
+	if err := s.disk.Put(ctx, []byte(e.key), e.value); err != nil {
+		return err
+	}
+
+	s.diskMu.Lock()
+	evicted := s.diskIndex.add(&entry{key: e.key, size: e.size})
+	s.diskMu.Unlock()
+	return s.deleteFromDisk(ctx, evicted)
+}
+
+func (s *Store) deleteFromDisk(ctx context.Context, evicted []*entry) error {
+	var errs []error
+	for _, e := range evicted {
+		if err := s.disk.Delete(ctx, []byte(e.key)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Store) recordRead(hit func(t *TierStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stats.Reads++
+	hit(&s.tiers)
+}
+
+func (s *Store) recordMiss() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tiers.Misses++
+}
+
+// Verify ... ensures that the key is the keccak256 hash of the value
+func (s *Store) Verify(key []byte, value []byte) error {
+	h := crypto.Keccak256Hash(value)
+	if !bytes.Equal(h[:], key) {
+		return errors.New("key does not match value")
+	}
+
+	return nil
+}
+
+// ReadThrough ... the cache is populated with blobs read from other backends, since read-heavy nodes
+// rarely write blobs through the proxy
+func (s *Store) ReadThrough() bool {
+	return true
+}
+
+func (s *Store) BackendType() store.BackendType {
+	return store.LocalBackendType
+}
+
+func (s *Store) Stats() *store.Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	return &stats
+}
+
+// TierStats ... returns the hit rates and usage of the cache tiers
+func (s *Store) TierStats() TierStats {
+	s.mu.Lock()
+	tiers := s.tiers
+	s.mu.Unlock()
+
+	s.memMu.Lock()
+	tiers.MemoryBytes = s.mem.bytes
+	s.memMu.Unlock()
+
+	if s.disk != nil {
+		s.diskMu.Lock()
+		tiers.DiskBytes = s.diskIndex.bytes
+		s.diskMu.Unlock()
+	}
+	return tiers
+}
+
+// Close ... releases the lock on the disk tier directory
+func (s *Store) Close() error {
+	if s.disk == nil {
+		return nil
+	}
+	return s.disk.Close()
+}
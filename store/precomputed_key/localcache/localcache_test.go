@@ -0,0 +1,123 @@
+package localcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func put(t *testing.T, s *Store, value string) []byte {
+	key := crypto.Keccak256([]byte(value))
+	require.NoError(t, s.Put(context.Background(), key, []byte(value)))
+	return key
+}
+
+func TestMemoryOnly(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewStore(log.New(), Config{MemoryMaxBytes: 8})
+	require.NoError(t, err)
+
+	a := put(t, s, "aaaa")
+	b := put(t, s, "bbbb")
+
+	// reading a makes b the least recently used value
+	got, err := s.Get(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaa"), got)
+
+	put(t, s, "cccc")
+	got, err = s.Get(ctx, b)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	got, err = s.Get(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaa"), got)
+
+	tiers := s.TierStats()
+	require.Equal(t, 2, tiers.MemoryHits)
+	require.Equal(t, 1, tiers.Misses)
+	require.Equal(t, uint64(8), tiers.MemoryBytes)
+}
+
+func TestOverflowToDisk(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	s, err := NewStore(log.New(), Config{MemoryMaxBytes: 4, DiskPath: dir, DiskMaxBytes: 8})
+	require.NoError(t, err)
+
+	a := put(t, s, "aaaa")
+	b := put(t, s, "bbbb") // spills a
+	c := put(t, s, "cccc") // spills b
+
+	tiers := s.TierStats()
+	require.Equal(t, uint64(4), tiers.MemoryBytes)
+	require.Equal(t, uint64(8), tiers.DiskBytes)
+
+	// a is promoted into memory, spilling c to disk which evicts b as the least recently used
+	got, err := s.Get(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaa"), got)
+	require.Equal(t, 1, s.TierStats().DiskHits)
+
+	got, err = s.Get(ctx, b)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	got, err = s.Get(ctx, c)
+	require.NoError(t, err)
+	require.Equal(t, []byte("cccc"), got)
+
+	require.NoError(t, s.Delete(ctx, c))
+	got, err = s.Get(ctx, c)
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	// the disk tier survives restarts
+	require.NoError(t, s.Close())
+	s, err = NewStore(log.New(), Config{MemoryMaxBytes: 4, DiskPath: dir, DiskMaxBytes: 8})
+	require.NoError(t, err)
+	defer s.Close()
+
+	got, err = s.Get(ctx, a)
+	require.NoError(t, err)
+	require.Equal(t, []byte("aaaa"), got)
+}
+
+func TestReindexEvictsOverBound(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := NewStore(log.New(), Config{MemoryMaxBytes: 0, DiskPath: dir, DiskMaxBytes: 12})
+	require.NoError(t, err)
+	for _, v := range []string{"aaaa", "bbbb", "cccc"} {
+		put(t, s, v)
+	}
+	require.Equal(t, uint64(12), s.TierStats().DiskBytes)
+	require.NoError(t, s.Close())
+
+	// shrinking the disk tier evicts the values exceeding the new bound
+	s, err = NewStore(log.New(), Config{MemoryMaxBytes: 0, DiskPath: dir, DiskMaxBytes: 4})
+	require.NoError(t, err)
+	defer s.Close()
+	require.Equal(t, uint64(4), s.TierStats().DiskBytes)
+}
+
+func TestConformance(t *testing.T) {
+	storetest.RunConformanceTests(t, func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore {
+		if ttl != 0 {
+			t.Skip("local cache does not expire entries")
+		}
+		s, err := NewStore(log.New(), Config{MemoryMaxBytes: 1 << 20, DiskPath: t.TempDir(), DiskMaxBytes: 1 << 20})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = s.Close() })
+		return s
+	})
+}
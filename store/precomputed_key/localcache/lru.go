@@ -0,0 +1,82 @@
+package localcache
+
+import "container/list"
+
+// entry ... value of a tier. The disk tier only tracks sizes, its values are nil.
+type entry struct {
+	key   string
+	value []byte
+	size  uint64
+}
+
+// lru ... least recently used set of entries bounded by their total size. Not safe for concurrent use.
+type lru struct {
+	maxBytes uint64
+	bytes    uint64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+func newLRU(maxBytes uint64) *lru {
+	return &lru{
+		maxBytes: maxBytes,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// get ... returns the entry of key and marks it as most recently used
+func (l *lru) get(key string) (*entry, bool) {
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return el.Value.(*entry), true
+}
+
+// contains ... returns whether key is present without changing its recency
+func (l *lru) contains(key string) bool {
+	_, ok := l.items[key]
+	return ok
+}
+
+// add ... inserts or replaces the entry as most recently used, and evicts the least recently used entries
+// until the total size is within bounds. Returns the evicted entries, oldest first.
+func (l *lru) add(e *entry) []*entry {
+	if el, ok := l.items[e.key]; ok {
+		l.bytes -= el.Value.(*entry).size
+		el.Value = e
+		l.ll.MoveToFront(el)
+	} else {
+		l.items[e.key] = l.ll.PushFront(e)
+	}
+	l.bytes += e.size
+
+	var evicted []*entry
+	for l.bytes > l.maxBytes {
+		oldest := l.ll.Back()
+		old := oldest.Value.(*entry)
+		l.ll.Remove(oldest)
+		delete(l.items, old.key)
+		l.bytes -= old.size
+		evicted = append(evicted, old)
+	}
+	return evicted
+}
+
+// remove ... deletes the entry of key, returning whether it was present
+func (l *lru) remove(key string) bool {
+	el, ok := l.items[key]
+	if !ok {
+		return false
+	}
+	l.ll.Remove(el)
+	delete(l.items, key)
+	l.bytes -= el.Value.(*entry).size
+	return true
+}
+
+func (l *lru) len() int {
+	return l.ll.Len()
+}
@@ -3,14 +3,20 @@ package redis
 import (
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	EndpointFlagName = withFlagPrefix("endpoint")
-	PasswordFlagName = withFlagPrefix("password")
-	DBFlagName       = withFlagPrefix("db")
-	EvictionFlagName = withFlagPrefix("eviction")
+	EndpointFlagName     = withFlagPrefix("endpoint")
+	DiscoveryFlagName    = withFlagPrefix("endpoint-discovery")
+	PasswordFlagName     = withFlagPrefix("password")
+	PasswordFileFlagName = withFlagPrefix("password-file")
+	DBFlagName           = withFlagPrefix("db")
+	EvictionFlagName     = withFlagPrefix("eviction")
+	EnableTLSFlagName    = withFlagPrefix("enable-tls")
+	MinIdleConnsFlagName = withFlagPrefix("min-idle-conns")
+	TLSFlagPrefix        = withFlagPrefix("tls")
 )
 
 func withFlagPrefix(s string) string {
@@ -24,19 +30,31 @@ func withEnvPrefix(envPrefix, s string) []string {
 // CLIFlags ... used for Redis backend configuration
 // category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
 func CLIFlags(envPrefix, category string) []cli.Flag {
-	return []cli.Flag{
+	flags := []cli.Flag{
 		&cli.StringFlag{
 			Name:     EndpointFlagName,
 			Usage:    "Redis endpoint",
 			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     DiscoveryFlagName,
+			Usage:    "Discover the Redis endpoint from an SRV record (srv://<name>) or a URL returning a JSON array of host:port endpoints, instead of setting it. Membership changes are followed without a restart.",
+			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT_DISCOVERY"),
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name:     PasswordFlagName,
 			Usage:    "Redis password",
 			EnvVars:  withEnvPrefix(envPrefix, "PASSWORD"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     PasswordFileFlagName,
+			Usage:    "Path to a file containing the Redis password (e.g. a mounted Kubernetes secret). Changes to the file are picked up without a restart.",
+			EnvVars:  withEnvPrefix(envPrefix, "PASSWORD_FILE"),
+			Category: category,
+		},
 		&cli.IntFlag{
 			Name:     DBFlagName,
 			Usage:    "Redis database",
@@ -44,21 +62,43 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "DB"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     EvictionFlagName,
-			Usage:    "Redis eviction time",
-			Value:    24 * time.Hour,
-			EnvVars:  withEnvPrefix(envPrefix, "EVICTION"),
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     EvictionFlagName,
+				Usage:    "Redis eviction time",
+				Value:    24 * time.Hour,
+				EnvVars:  withEnvPrefix(envPrefix, "EVICTION"),
+				Category: category,
+			},
+		},
+		&cli.BoolFlag{
+			Name:     EnableTLSFlagName,
+			Usage:    "Whether to connect to Redis over TLS.",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "ENABLE_TLS"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     MinIdleConnsFlagName,
+			Usage:    "Number of idle connections kept open to Redis, so that requests after idle periods don't pay for connection setup.",
+			Value:    0,
+			EnvVars:  withEnvPrefix(envPrefix, "MIN_IDLE_CONNS"),
 			Category: category,
 		},
 	}
+	return append(flags, utils.TLSFlags(TLSFlagPrefix, envPrefix+"_REDIS_TLS", "Redis", category)...)
 }
 
 func ReadConfig(ctx *cli.Context) Config {
 	return Config{
-		Endpoint: ctx.String(EndpointFlagName),
-		Password: ctx.String(PasswordFlagName),
-		DB:       ctx.Int(DBFlagName),
-		Eviction: ctx.Duration(EvictionFlagName),
+		Endpoint:          ctx.String(EndpointFlagName),
+		EndpointDiscovery: ctx.String(DiscoveryFlagName),
+		Password:          ctx.String(PasswordFlagName),
+		PasswordFile:      ctx.String(PasswordFileFlagName),
+		DB:                ctx.Int(DBFlagName),
+		Eviction:          ctx.Duration(EvictionFlagName),
+		EnableTLS:         ctx.Bool(EnableTLSFlagName),
+		MinIdleConns:      ctx.Int(MinIdleConnsFlagName),
+		TLS:               utils.ReadTLSConfig(ctx, TLSFlagPrefix),
 	}
 }
@@ -2,65 +2,202 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-redis/redis/v8"
 )
 
 // Config ... user configurable
 type Config struct {
 	Endpoint string
-	Password string
-	DB       int
-	Eviction time.Duration
-	Profile  bool
+	// EndpointDiscovery is an alternative to Endpoint, the endpoint is then discovered from the SRV record or URL
+	// and followed as the fleet's membership changes, see utils.ResolveEndpoints
+	EndpointDiscovery string
+	Password          string
+	PasswordFile      string
+	DB                int
+	Eviction          time.Duration
+	Profile           bool
+	EnableTLS         bool
+	// MinIdleConns is the number of idle connections kept open to avoid connection setup on requests
+	MinIdleConns int
+	// TLS customizes the connection to the endpoint, it requires EnableTLS
+	TLS utils.TLSConfig
 }
 
 // Store ... Redis storage backend implementation (This not safe for concurrent usage)
 type Store struct {
+	cfg      Config
 	eviction time.Duration
+	log      log.Logger
 
-	client *redis.Client
+	clientMu sync.RWMutex
+	client   *sharedClient
+	// endpoint the client is connected to, possibly rediscovered since startup
+	endpoint string
+	// password the client connects with, possibly rotated since startup
+	password string
+	// tls is reused when reconnecting with a rotated password
+	tls *tls.Config
 
 	profile bool
 	reads   int
 	entries int
 }
 
-var _ store.PrecomputedKeyStore = (*Store)(nil)
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.Exister             = (*Store)(nil)
+	_ store.Lister              = (*Store)(nil)
+	_ store.Pinger              = (*Store)(nil)
+	_ store.Reconnector         = (*Store)(nil)
+)
+
+// sharedClient ... client counting the requests in flight on it, so that it is only closed once they are done
+// after being replaced, see Store.connect
+type sharedClient struct {
+	*redis.Client
+	users sync.WaitGroup
+}
 
 // NewStore ... constructor
-func NewStore(cfg *Config) (*Store, error) {
+func NewStore(l log.Logger, cfg *Config) (*Store, error) {
+	var tlsCfg *tls.Config
+	if cfg.EnableTLS {
+		var err error
+		if tlsCfg, err = cfg.TLS.Load(); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := newClient(*cfg, cfg.Endpoint, cfg.Password, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		cfg:      *cfg,
+		eviction: cfg.Eviction,
+		log:      l.New("backend", store.RedisBackendType),
+		client:   &sharedClient{Client: client},
+		endpoint: cfg.Endpoint,
+		password: cfg.Password,
+		tls:      tlsCfg,
+		profile:  cfg.Profile,
+		reads:    0,
+	}, nil
+}
+
+// newClient ... creates a redis client and ensures the server can be pinged using it. TLS is used if
+// tlsCfg is set.
+func newClient(cfg Config, endpoint, password string, tlsCfg *tls.Config) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
-		Addr:     cfg.Endpoint,
-		Password: cfg.Password,
-		DB:       cfg.DB,
+		Addr:         endpoint,
+		Password:     password,
+		DB:           cfg.DB,
+		TLSConfig:    tlsCfg,
+		MinIdleConns: cfg.MinIdleConns,
 	})
 
-	// ensure server can be pinged using potential client connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	cmd := client.Ping(ctx)
 	if cmd.Err() != nil {
+		_ = client.Close()
 		return nil, fmt.Errorf("failed to ping redis server: %w", cmd.Err())
 	}
 
-	return &Store{
-		eviction: cfg.Eviction,
-		client:   client,
-		profile:  cfg.Profile,
-		reads:    0,
-	}, nil
+	return client, nil
+}
+
+// SetPassword ... reconnects to the Redis server using a new password (e.g. after a secret rotation).
+// The existing client is only replaced once the new one has been verified to work.
+func (r *Store) SetPassword(password string) error {
+	r.clientMu.RLock()
+	endpoint := r.endpoint
+	r.clientMu.RUnlock()
+	return r.connect(endpoint, password)
+}
+
+// SetEndpoint ... reconnects to another Redis server of the fleet (e.g. after the current one was removed from
+// the discovered endpoints). The existing client is only replaced once the new one has been verified to work.
+func (r *Store) SetEndpoint(endpoint string) error {
+	r.clientMu.RLock()
+	password := r.password
+	r.clientMu.RUnlock()
+	return r.connect(endpoint, password)
+}
+
+// connect ... replaces the client with one connected to endpoint using password. The replaced client is closed in
+// the background once the requests in flight on it are done.
+func (r *Store) connect(endpoint, password string) error {
+	client, err := newClient(r.cfg, endpoint, password, r.tls)
+	if err != nil {
+		return err
+	}
+	r.swap(client, endpoint, password)
+	r.log.Info("Reconnected to Redis", "endpoint", endpoint)
+	return nil
+}
+
+// swap ... replaces the client with client
+func (r *Store) swap(client *redis.Client, endpoint, password string) {
+	r.clientMu.Lock()
+	old := r.client
+	r.client = &sharedClient{Client: client}
+	r.endpoint = endpoint
+	r.password = password
+	r.clientMu.Unlock()
+
+	// no request acquires the replaced client anymore, see acquire
+	go func() {
+		old.users.Wait()
+		if err := old.Close(); err != nil {
+			r.log.Warn("Failed to close replaced Redis client", "err", err)
+		}
+	}()
+}
+
+// Ping ... pings the Redis server over the connection pool
+func (r *Store) Ping(ctx context.Context) error {
+	client, release := r.acquire()
+	defer release()
+	return client.Ping(ctx).Err()
+}
+
+// Reconnect ... replaces the connection pool with a new one using the current endpoint and password
+func (r *Store) Reconnect(_ context.Context) error {
+	r.clientMu.RLock()
+	endpoint, password := r.endpoint, r.password
+	r.clientMu.RUnlock()
+	return r.connect(endpoint, password)
+}
+
+// acquire ... returns the current client and the function to call once the request made with it is done
+func (r *Store) acquire() (*redis.Client, func()) {
+	r.clientMu.RLock()
+	defer r.clientMu.RUnlock()
+	c := r.client
+	c.users.Add(1)
+	return c.Client, c.users.Done
 }
 
 // Get ... retrieves a value from the Redis store. Returns nil if the key is not found vs. an error
 // if the key is found but the value is not retrievable.
 func (r *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
-	value, err := r.client.Get(ctx, string(key)).Result()
+	client, release := r.acquire()
+	defer release()
+	value, err := client.Get(ctx, string(key)).Result()
 	if errors.Is(err, redis.Nil) { // key DNE
 		return nil, nil
 	} else if err != nil {
@@ -75,9 +212,12 @@ func (r *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 	return []byte(value), nil
 }
 
-// Put ... inserts a value into the Redis store
+// Put ... inserts a value into the Redis store. The value expires after the configured eviction time, or the
+// context's TTL hint if that is shorter.
 func (r *Store) Put(ctx context.Context, key []byte, value []byte) error {
-	err := r.client.Set(ctx, string(key), string(value), r.eviction).Err()
+	client, release := r.acquire()
+	defer release()
+	err := client.Set(ctx, string(key), string(value), store.ShortestTTL(ctx, r.eviction)).Err()
 	if err == nil && r.profile {
 		r.entries++
 	}
@@ -85,10 +225,78 @@ func (r *Store) Put(ctx context.Context, key []byte, value []byte) error {
 	return err
 }
 
+// Delete ... removes a value from the Redis store
+func (r *Store) Delete(ctx context.Context, key []byte) error {
+	client, release := r.acquire()
+	defer release()
+	return client.Del(ctx, string(key)).Err()
+}
+
+// Exists ... checks for a key without reading its value
+func (r *Store) Exists(ctx context.Context, key []byte) (bool, error) {
+	client, release := r.acquire()
+	defer release()
+	n, err := client.Exists(ctx, string(key)).Result()
+	return n > 0, err
+}
+
+// Keys ... scans the database for keys. Only keys of keccak256 hash length are reported, since the database
+// may be shared with other applications.
+func (r *Store) Keys(ctx context.Context, fn func(key []byte) error) error {
+	client, release := r.acquire()
+	defer release()
+	iter := client.Scan(ctx, 0, "", 0).Iterator()
+	for iter.Next(ctx) {
+		if key := iter.Val(); len(key) == common.HashLength {
+			if err := fn([]byte(key)); err != nil {
+				return err
+			}
+		}
+	}
+	return iter.Err()
+}
+
+// HashSet ... sets a field of the hash at key, e.g. to journal pending EigenDA dispersals. Hashes don't expire.
+func (r *Store) HashSet(ctx context.Context, key, field string, value []byte) error {
+	client, release := r.acquire()
+	defer release()
+	return client.HSet(ctx, key, field, value).Err()
+}
+
+// HashDelete ... removes a field of the hash at key
+func (r *Store) HashDelete(ctx context.Context, key, field string) error {
+	client, release := r.acquire()
+	defer release()
+	return client.HDel(ctx, key, field).Err()
+}
+
+// HashGetAll ... returns the fields of the hash at key, none if it doesn't exist
+func (r *Store) HashGetAll(ctx context.Context, key string) (map[string][]byte, error) {
+	client, release := r.acquire()
+	defer release()
+	values, err := client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string][]byte, len(values))
+	for field, value := range values {
+		fields[field] = []byte(value)
+	}
+	return fields, nil
+}
+
 func (r *Store) Verify(_ []byte, _ []byte) error {
 	return nil
 }
 
+// Identity ... the Redis endpoint, or the discovery source of the fleet, and database backing the store
+func (r *Store) Identity() string {
+	if r.cfg.EndpointDiscovery != "" {
+		return fmt.Sprintf("redis+%s/%d", r.cfg.EndpointDiscovery, r.cfg.DB)
+	}
+	return fmt.Sprintf("redis://%s/%d", r.cfg.Endpoint, r.cfg.DB)
+}
+
 func (r *Store) BackendType() store.BackendType {
 	return store.RedisBackendType
 }
@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance ... requires a redis server on 127.0.0.1:9001 (see `make run-redis`)
+func TestConformance(t *testing.T) {
+	if os.Getenv("INTEGRATION") != "true" && os.Getenv("INTEGRATION") != "1" {
+		t.Skip("Skipping test as INTEGRATION env var not set")
+	}
+
+	storetest.RunConformanceTests(t, func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore {
+		s, err := NewStore(log.New(), &Config{
+			Endpoint: "127.0.0.1:9001",
+			Eviction: ttl,
+		})
+		require.NoError(t, err)
+		return s
+	})
+}
+
+func TestSwapDrainsRequests(t *testing.T) {
+	// the clients are never connected, commands fail to dial until the client is closed
+	newClient := func() *redis.Client {
+		return redis.NewClient(&redis.Options{Addr: "127.0.0.1:0", MaxRetries: -1})
+	}
+	s := &Store{log: log.New(), client: &sharedClient{Client: newClient()}}
+	ctx := context.Background()
+
+	old, release := s.acquire()
+	s.swap(newClient(), "127.0.0.1:0", "rotated")
+
+	// requests in flight keep using the replaced client until they are done
+	time.Sleep(10 * time.Millisecond)
+	require.NotErrorIs(t, old.Ping(ctx).Err(), redis.ErrClosed)
+	release()
+	require.Eventually(t, func() bool { return old.Ping(ctx).Err() == redis.ErrClosed }, time.Second, time.Millisecond)
+
+	current, release := s.acquire()
+	defer release()
+	require.NotSame(t, old, current)
+	require.Equal(t, "rotated", s.password)
+}
@@ -3,19 +3,29 @@ package s3
 import (
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/urfave/cli/v2"
 )
 
 var (
-	EndpointFlagName        = withFlagPrefix("endpoint")
-	EnableTLSFlagName       = withFlagPrefix("enable-tls")
-	CredentialTypeFlagName  = withFlagPrefix("credential-type")
-	AccessKeyIDFlagName     = withFlagPrefix("access-key-id")     // #nosec G101
-	AccessKeySecretFlagName = withFlagPrefix("access-key-secret") // #nosec G101
-	BucketFlagName          = withFlagPrefix("bucket")
-	PathFlagName            = withFlagPrefix("path")
-	BackupFlagName          = withFlagPrefix("backup")
-	TimeoutFlagName         = withFlagPrefix("timeout")
+	EndpointFlagName            = withFlagPrefix("endpoint")
+	DiscoveryFlagName           = withFlagPrefix("endpoint-discovery")
+	EnableTLSFlagName           = withFlagPrefix("enable-tls")
+	CredentialTypeFlagName      = withFlagPrefix("credential-type")
+	AccessKeyIDFlagName         = withFlagPrefix("access-key-id")          // #nosec G101
+	AccessKeySecretFlagName     = withFlagPrefix("access-key-secret")      // #nosec G101
+	AccessKeySecretFileFlagName = withFlagPrefix("access-key-secret-file") // #nosec G101
+	BucketFlagName              = withFlagPrefix("bucket")
+	PathFlagName                = withFlagPrefix("path")
+	BackupFlagName              = withFlagPrefix("backup")
+	TimeoutFlagName             = withFlagPrefix("timeout")
+	TLSFlagPrefix               = withFlagPrefix("tls")
+	HealthCheckIntervalFlagName = withFlagPrefix("health-check-interval")
+	BucketNotificationsFlagName = withFlagPrefix("bucket-notifications")
+	HashAlgorithmFlagName       = withFlagPrefix("hash-algorithm")
+	ArchiveStorageClassFlagName = withFlagPrefix("archive-storage-class")
+	RestoreDaysFlagName         = withFlagPrefix("restore-days")
 )
 
 func withFlagPrefix(s string) string {
@@ -29,13 +39,19 @@ func withEnvPrefix(envPrefix, s string) []string {
 // CLIFlags ... used for S3 backend configuration
 // category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
 func CLIFlags(envPrefix, category string) []cli.Flag {
-	return []cli.Flag{
+	flags := []cli.Flag{
 		&cli.StringFlag{
 			Name:     EndpointFlagName,
 			Usage:    "endpoint for S3 storage",
 			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     DiscoveryFlagName,
+			Usage:    "discover the S3 endpoint from an SRV record (srv://<name>) or a URL returning a JSON array of host:port endpoints, instead of setting it. Membership changes are followed without a restart.",
+			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT_DISCOVERY"),
+			Category: category,
+		},
 		&cli.BoolFlag{
 			Name:     EnableTLSFlagName,
 			Usage:    "enable TLS connection to S3 endpoint",
@@ -61,6 +77,12 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "ACCESS_KEY_SECRET"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     AccessKeySecretFileFlagName,
+			Usage:    "path to a file containing the access key secret for S3 storage (e.g. a mounted Kubernetes secret). Changes to the file are picked up without a restart",
+			EnvVars:  withEnvPrefix(envPrefix, "ACCESS_KEY_SECRET_FILE"),
+			Category: category,
+		},
 		&cli.StringFlag{
 			Name:     BucketFlagName,
 			Usage:    "bucket name for S3 storage",
@@ -80,26 +102,76 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "BACKUP"),
 			Category: category,
 		},
-		&cli.DurationFlag{
-			Name:     TimeoutFlagName,
-			Usage:    "timeout for S3 storage operations (e.g. get, put)",
-			Value:    5 * time.Second,
-			EnvVars:  withEnvPrefix(envPrefix, "TIMEOUT"),
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     TimeoutFlagName,
+				Usage:    "timeout for S3 storage operations (e.g. get, put)",
+				Value:    5 * time.Second,
+				EnvVars:  withEnvPrefix(envPrefix, "TIMEOUT"),
+				Category: category,
+			},
+			Min: time.Millisecond,
+		},
+		&utils.DurationFlag{
+			DurationFlag: cli.DurationFlag{
+				Name:     HealthCheckIntervalFlagName,
+				Usage:    "interval of the S3 client's health check of the endpoint. While the endpoint is offline, requests fail fast instead of timing out. 0 disables the health check",
+				EnvVars:  withEnvPrefix(envPrefix, "HEALTH_CHECK_INTERVAL"),
+				Category: category,
+			},
+			Min:       time.Second,
+			AllowZero: true,
+		},
+		&cli.BoolFlag{
+			Name:     BucketNotificationsFlagName,
+			Usage:    "subscribe to the bucket's object removal notifications (MinIO only), so that blobs deleted or expired by lifecycle rules are removed from the commitment index",
+			Value:    false,
+			EnvVars:  withEnvPrefix(envPrefix, "BUCKET_NOTIFICATIONS"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     HashAlgorithmFlagName,
+			Usage:    "hash algorithm of precomputed-key (optimism_keccak256 mode) commitments, options are [keccak256, sha256, blake3]. It is recorded in the commitment type byte, which is 0x00 for keccak256 as expected by the op-node, 0x10 for sha256 and 0x11 for blake3",
+			Value:    string(commitments.Keccak256Hash),
+			EnvVars:  withEnvPrefix(envPrefix, "HASH_ALGORITHM"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ArchiveStorageClassFlagName,
+			Usage:    "storage class blobs are moved to when archived by the tiering policy (e.g. GLACIER, DEEP_ARCHIVE). Archived blobs are restored on demand",
+			Value:    DefaultArchiveStorageClass,
+			EnvVars:  withEnvPrefix(envPrefix, "ARCHIVE_STORAGE_CLASS"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     RestoreDaysFlagName,
+			Usage:    "number of days restored copies of archived blobs are kept readable",
+			Value:    DefaultRestoreDays,
+			EnvVars:  withEnvPrefix(envPrefix, "RESTORE_DAYS"),
 			Category: category,
 		},
 	}
+	return append(flags, utils.TLSFlags(TLSFlagPrefix, envPrefix+"_S3_TLS", "S3", category)...)
 }
 
 func ReadConfig(ctx *cli.Context) Config {
 	return Config{
-		CredentialType:  StringToCredentialType(ctx.String(CredentialTypeFlagName)),
-		Endpoint:        ctx.String(EndpointFlagName),
-		EnableTLS:       ctx.Bool(EnableTLSFlagName),
-		AccessKeyID:     ctx.String(AccessKeyIDFlagName),
-		AccessKeySecret: ctx.String(AccessKeySecretFlagName),
-		Bucket:          ctx.String(BucketFlagName),
-		Path:            ctx.String(PathFlagName),
-		Backup:          ctx.Bool(BackupFlagName),
-		Timeout:         ctx.Duration(TimeoutFlagName),
+		CredentialType:      StringToCredentialType(ctx.String(CredentialTypeFlagName)),
+		Endpoint:            ctx.String(EndpointFlagName),
+		EndpointDiscovery:   ctx.String(DiscoveryFlagName),
+		EnableTLS:           ctx.Bool(EnableTLSFlagName),
+		AccessKeyID:         ctx.String(AccessKeyIDFlagName),
+		AccessKeySecret:     ctx.String(AccessKeySecretFlagName),
+		AccessKeySecretFile: ctx.String(AccessKeySecretFileFlagName),
+		Bucket:              ctx.String(BucketFlagName),
+		Path:                ctx.String(PathFlagName),
+		Backup:              ctx.Bool(BackupFlagName),
+		Timeout:             ctx.Duration(TimeoutFlagName),
+		TLS:                 utils.ReadTLSConfig(ctx, TLSFlagPrefix),
+		HealthCheckInterval: ctx.Duration(HealthCheckIntervalFlagName),
+		BucketNotifications: ctx.Bool(BucketNotificationsFlagName),
+		HashAlgorithm:       commitments.HashAlgorithm(ctx.String(HashAlgorithmFlagName)),
+		ArchiveStorageClass: ctx.String(ArchiveStorageClassFlagName),
+		RestoreDays:         ctx.Int(RestoreDaysFlagName),
 	}
 }
@@ -0,0 +1,40 @@
+package s3
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// fileSecretProvider ... static v4 credentials provider whose access key secret is read from a mounted file.
+// The file is re-read at most every refreshInterval so that rotated secrets are picked up without a restart.
+type fileSecretProvider struct {
+	accessKeyID     string
+	secretFile      string
+	refreshInterval time.Duration
+
+	lastRetrieved time.Time
+}
+
+var _ credentials.Provider = (*fileSecretProvider)(nil)
+
+// Retrieve ... reads the access key secret from the file. minio serializes calls to Retrieve and IsExpired.
+func (p *fileSecretProvider) Retrieve() (credentials.Value, error) {
+	secret, err := utils.ReadSecretFile(p.secretFile)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	p.lastRetrieved = time.Now()
+	return credentials.Value{
+		AccessKeyID:     p.accessKeyID,
+		SecretAccessKey: secret,
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
+
+// IsExpired ... reports whether the secret file should be re-read
+func (p *fileSecretProvider) IsExpired() bool {
+	return time.Since(p.lastRetrieved) >= p.refreshInterval
+}
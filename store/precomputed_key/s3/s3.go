@@ -5,21 +5,42 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
 	"github.com/Layr-Labs/eigenda-proxy/store"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/Layr-Labs/eigenda-proxy/utils"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/minio/minio-go/v7"
-
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/notification"
 )
 
 const (
 	CredentialTypeStatic  CredentialType = "static"
 	CredentialTypeIAM     CredentialType = "iam"
 	CredentialTypeUnknown CredentialType = "unknown"
+
+	// TTLDaysTag ... object tag carrying the TTL hint of a blob in days, rounded up, to be matched by bucket
+	// lifecycle expiration rules
+	TTLDaysTag = "eigenda-proxy-ttl-days"
+
+	// gcsEndpoint ... endpoint of GCS's S3-compatible API
+	gcsEndpoint = "storage.googleapis.com"
+
+	// DefaultArchiveStorageClass ... storage class objects are moved to when archived
+	DefaultArchiveStorageClass = "GLACIER"
+	// DefaultRestoreDays ... number of days a restored copy of an archived object is kept
+	DefaultRestoreDays = 1
 )
 
 func StringToCredentialType(s string) CredentialType {
@@ -33,93 +54,390 @@ func StringToCredentialType(s string) CredentialType {
 	}
 }
 
-var _ store.PrecomputedKeyStore = (*Store)(nil)
+var (
+	_ store.PrecomputedKeyStore = (*Store)(nil)
+	_ store.Profileable         = (*Store)(nil)
+	_ store.Archiver            = (*Store)(nil)
+	_ store.Deleter             = (*Store)(nil)
+	_ store.Exister             = (*Store)(nil)
+	_ store.HashReporter        = (*Store)(nil)
+	_ store.Lister              = (*Store)(nil)
+	_ store.Pinger              = (*Store)(nil)
+	_ store.Reconnector         = (*Store)(nil)
+	_ store.RemovalWatcher      = (*Store)(nil)
+)
+
+// removalEvents ... bucket notification events of removed objects. MinIO also reports objects expired by lifecycle
+// rules as removed.
+var removalEvents = []string{string(notification.ObjectRemovedAll)}
 
 type CredentialType string
 type Config struct {
-	CredentialType  CredentialType
-	Endpoint        string
-	EnableTLS       bool
-	AccessKeyID     string
-	AccessKeySecret string
-	Bucket          string
-	Path            string
-	Backup          bool
-	Timeout         time.Duration
-	Profiling       bool
+	CredentialType CredentialType
+	Endpoint       string
+	// EndpointDiscovery is an alternative to Endpoint, the endpoint is then discovered from the SRV record or URL
+	// and followed as the fleet's membership changes, see utils.ResolveEndpoints
+	EndpointDiscovery string
+	EnableTLS         bool
+	AccessKeyID       string
+	AccessKeySecret   string
+	// AccessKeySecretFile is an alternative to AccessKeySecret, re-read periodically to support secret rotation
+	AccessKeySecretFile string
+	Bucket              string
+	Path                string
+	Backup              bool
+	Timeout             time.Duration
+	// TLS customizes the connection to the endpoint, it requires EnableTLS
+	TLS utils.TLSConfig
+	// HealthCheckInterval enables the client's health check, failing requests fast while the endpoint is offline
+	HealthCheckInterval time.Duration
+	// BucketNotifications subscribes to the bucket's object removal notifications, a MinIO extension
+	BucketNotifications bool
+	// HashAlgorithm of the keys of precomputed-key commitments, keccak256 if empty
+	HashAlgorithm commitments.HashAlgorithm
+	// ArchiveStorageClass objects are moved to by Archive, DefaultArchiveStorageClass if empty
+	ArchiveStorageClass string
+	// RestoreDays is the number of days restored copies of archived objects are kept, DefaultRestoreDays if 0
+	RestoreDays int
+	// TenantPaths are the paths the objects of the tenants are stored under instead of Path, by tenant
+	TenantPaths map[string]string
 }
 
 type Store struct {
-	cfg    Config
-	client *minio.Client
-	stats  *store.Stats
+	cfg Config
+	log log.Logger
+
+	clientMu sync.RWMutex
+	client   *minio.Client
+	// opts the client was created with, reused when switching to a discovered endpoint
+	opts *minio.Options
+	// transport of the client, its idle connections are dropped on Reconnect
+	transport *http.Transport
+	// stops the health check of the client, if enabled
+	stopHealthCheck context.CancelFunc
+
+	// counters served by Stats
+	entries atomic.Int64
+	reads   atomic.Int64
+
+	// records profiling sessions
+	profiler *store.Profiler
 }
 
-func NewS3(cfg Config) (*Store, error) {
-	client, err := minio.New(cfg.Endpoint, &minio.Options{
-		Creds:  creds(cfg),
-		Secure: cfg.EnableTLS,
-	})
+func NewS3(l log.Logger, cfg Config) (*Store, error) {
+	transport, err := minio.DefaultTransport(cfg.EnableTLS)
 	if err != nil {
 		return nil, err
 	}
+	if cfg.TLS.IsSet() {
+		tlsCfg, err := cfg.TLS.Load()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsCfg
+	}
+	opts := &minio.Options{
+		Creds:     creds(cfg),
+		Secure:    cfg.EnableTLS,
+		Transport: transport,
+	}
 
-	return &Store{
-		cfg:    cfg,
-		client: client,
-		stats: &store.Stats{
-			Entries: 0,
-			Reads:   0,
-		},
-	}, nil
+	s := &Store{
+		cfg:       cfg,
+		log:       l.New("backend", store.S3BackendType),
+		opts:      opts,
+		transport: transport,
+		profiler:  store.NewProfiler(store.S3BackendType),
+	}
+	if err := s.SetEndpoint(cfg.Endpoint); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetEndpoint ... switches to another S3 endpoint of the fleet (e.g. after the current one was removed from the
+// discovered endpoints). Requests in flight complete against the previous endpoint.
+func (s *Store) SetEndpoint(endpoint string) error {
+	client, err := minio.New(endpoint, s.opts)
+	if err != nil {
+		return err
+	}
+	var stop context.CancelFunc
+	if s.cfg.HealthCheckInterval > 0 {
+		if stop, err = client.HealthCheck(s.cfg.HealthCheckInterval); err != nil {
+			return err
+		}
+	}
+
+	s.clientMu.Lock()
+	s.client = client
+	prev := s.stopHealthCheck
+	s.stopHealthCheck = stop
+	s.clientMu.Unlock()
+
+	if prev != nil {
+		prev()
+	}
+	s.log.Info("Using S3 endpoint", "endpoint", endpoint, "healthCheckInterval", s.cfg.HealthCheckInterval)
+	return nil
+}
+
+func (s *Store) getClient() *minio.Client {
+	s.clientMu.RLock()
+	defer s.clientMu.RUnlock()
+	return s.client
+}
+
+// Ping ... checks that the bucket is reachable, keeping a connection of the client's pool alive. Fails without a
+// round trip while the health check reports the endpoint offline.
+func (s *Store) Ping(ctx context.Context) error {
+	client := s.getClient()
+	if client.IsOffline() {
+		return fmt.Errorf("s3 endpoint %s is offline", client.EndpointURL().Host)
+	}
+	_, err := client.BucketExists(ctx, s.cfg.Bucket)
+	return err
+}
+
+// Reconnect ... drops the idle connections of the client's pool
+func (s *Store) Reconnect(_ context.Context) error {
+	s.transport.CloseIdleConnections()
+	return nil
 }
 
 func (s *Store) Get(ctx context.Context, key []byte) ([]byte, error) {
-	result, err := s.client.GetObject(ctx, s.cfg.Bucket, path.Join(s.cfg.Path, hex.EncodeToString(key)), minio.GetObjectOptions{})
+	start := time.Now()
+	data, err := s.get(ctx, key)
+	s.profiler.Record("get", time.Since(start), len(data), err)
+	if err != nil {
+		return nil, err
+	}
+
+	s.reads.Add(1)
+	return data, nil
+}
+
+func (s *Store) get(ctx context.Context, key []byte) ([]byte, error) {
+	result, err := s.getClient().GetObject(ctx, s.cfg.Bucket, s.objectName(ctx, key), minio.GetObjectOptions{})
 	if err != nil {
 		errResponse := minio.ToErrorResponse(err)
 		if errResponse.Code == "NoSuchKey" {
-			return nil, errors.New("value not found in s3 bucket")
+			return nil, fmt.Errorf("value not found in s3 bucket: %w", store.ErrKeyNotFound)
 		}
 		return nil, err
 	}
 	defer result.Close()
 	data, err := io.ReadAll(result)
+	if err != nil && minio.ToErrorResponse(err).Code == "InvalidObjectState" {
+		return nil, fmt.Errorf("value is not restored from the %s storage class: %w", s.archiveStorageClass(),
+			store.ErrArchived)
+	}
+	return data, err
+}
+
+func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
+	start := time.Now()
+	_, err := s.getClient().PutObject(ctx, s.cfg.Bucket, s.objectName(ctx, key), bytes.NewReader(value), int64(len(value)), putOptions(ctx))
+	s.profiler.Record("put", time.Since(start), len(value), err)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if s.cfg.Profiling {
-		s.stats.Reads++
+	s.entries.Add(1)
+	return nil
+}
+
+// putOptions ... tags objects written with a TTL hint, since S3 can only expire objects via the bucket's
+// lifecycle rules
+func putOptions(ctx context.Context) minio.PutObjectOptions {
+	ttl := store.TTLFromContext(ctx)
+	if ttl <= 0 {
+		return minio.PutObjectOptions{}
 	}
+	days := (ttl + 24*time.Hour - 1) / (24 * time.Hour)
+	return minio.PutObjectOptions{
+		Expires:  time.Now().Add(ttl),
+		UserTags: map[string]string{TTLDaysTag: strconv.FormatInt(int64(days), 10)},
+	}
+}
 
-	return data, nil
+// Delete ... removes an object from the bucket. S3 does not return an error for missing objects.
+func (s *Store) Delete(ctx context.Context, key []byte) error {
+	return s.getClient().RemoveObject(ctx, s.cfg.Bucket, s.objectName(ctx, key), minio.RemoveObjectOptions{})
 }
 
-func (s *Store) Put(ctx context.Context, key []byte, value []byte) error {
-	_, err := s.client.PutObject(ctx, s.cfg.Bucket, path.Join(s.cfg.Path, hex.EncodeToString(key)), bytes.NewReader(value), int64(len(value)), minio.PutObjectOptions{})
+// Archive ... moves an object to the archive storage class by copying it onto itself. Bucket lifecycle rules can
+// transition objects too, Archive lets the tiering policy decide when.
+func (s *Store) Archive(ctx context.Context, key []byte) error {
+	object := s.objectName(ctx, key)
+	dst := minio.CopyDestOptions{
+		Bucket:          s.cfg.Bucket,
+		Object:          object,
+		ReplaceMetadata: true,
+		UserMetadata:    map[string]string{"X-Amz-Storage-Class": s.archiveStorageClass()},
+	}
+	_, err := s.getClient().CopyObject(ctx, dst, minio.CopySrcOptions{Bucket: s.cfg.Bucket, Object: object})
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("value not found in s3 bucket: %w", store.ErrKeyNotFound)
+	}
+	return err
+}
+
+// Restore ... starts restoring an archived object, whose restored copy can be read for the configured number of
+// days once the restore completed
+func (s *Store) Restore(ctx context.Context, key []byte) error {
+	days := s.cfg.RestoreDays
+	if days <= 0 {
+		days = DefaultRestoreDays
+	}
+	req := minio.RestoreRequest{}
+	req.SetDays(days)
+	err := s.getClient().RestoreObject(ctx, s.cfg.Bucket, s.objectName(ctx, key), "", req)
+	if err != nil && minio.ToErrorResponse(err).Code == "RestoreAlreadyInProgress" {
+		return nil
+	}
+	return err
+}
+
+func (s *Store) archiveStorageClass() string {
+	if s.cfg.ArchiveStorageClass == "" {
+		return DefaultArchiveStorageClass
+	}
+	return s.cfg.ArchiveStorageClass
+}
+
+// Exists ... checks for an object without downloading it
+func (s *Store) Exists(ctx context.Context, key []byte) (bool, error) {
+	_, err := s.getClient().StatObject(ctx, s.cfg.Bucket, s.objectName(ctx, key), minio.StatObjectOptions{})
 	if err != nil {
-		return err
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return false, nil
+		}
+		return false, err
 	}
+	return true, nil
+}
 
-	if s.cfg.Profiling {
-		s.stats.Entries++
+// objectName ... name of the object of key, under the path of the context's tenant if it has one
+func (s *Store) objectName(ctx context.Context, key []byte) string {
+	p := s.cfg.Path
+	if tp, ok := s.cfg.TenantPaths[store.TenantFromContext(ctx)]; ok {
+		p = tp
 	}
+	return path.Join(p, hex.EncodeToString(key))
+}
 
-	return nil
+// prefix ... prefix of the names of the objects under the configured path
+func (s *Store) prefix() string {
+	if p := path.Clean(s.cfg.Path); p != "." && p != "/" {
+		return strings.TrimPrefix(p, "/") + "/"
+	}
+	return ""
+}
+
+// Keys ... lists the objects under the configured path, not the paths of the tenants. Objects whose names aren't hex encoded keys are skipped.
+func (s *Store) Keys(ctx context.Context, fn func(key []byte) error) error {
+	prefix := s.prefix()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the listing if fn returns early
+
+	for obj := range s.getClient().ListObjects(ctx, s.cfg.Bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return obj.Err
+		}
+		key, err := hex.DecodeString(path.Base(obj.Key))
+		if err != nil {
+			continue
+		}
+		if err := fn(key); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// WatchRemovals ... listens for the removal notifications of objects under the configured path, until ctx is done
+// or the notification stream fails. Only MinIO supports listening for bucket notifications.
+func (s *Store) WatchRemovals(ctx context.Context, fn func(key []byte)) error {
+	if !s.cfg.BucketNotifications {
+		return store.ErrRemovalsUnsupported
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the notification stream on errors
+
+	for info := range s.getClient().ListenBucketNotification(ctx, s.cfg.Bucket, s.prefix(), "", removalEvents) {
+		if info.Err != nil {
+			return info.Err
+		}
+		for _, event := range info.Records {
+			key, ok := objectKey(event.S3.Object.Key)
+			if !ok {
+				s.log.Debug("Ignoring removal notification of an object that isn't a blob", "object", event.S3.Object.Key)
+				continue
+			}
+			fn(key)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("bucket notification stream closed")
+}
+
+// objectKey ... decodes the key of a blob from the URL encoded object name of a bucket notification
+func objectKey(name string) ([]byte, bool) {
+	name, err := url.QueryUnescape(name)
+	if err != nil {
+		return nil, false
+	}
+	key, err := hex.DecodeString(path.Base(name))
+	return key, err == nil
 }
 
 func (s *Store) Verify(key []byte, value []byte) error {
-	h := crypto.Keccak256Hash(value)
-	if !bytes.Equal(h[:], key) {
+	if !bytes.Equal(s.HashAlgorithm().Sum(value), key) {
 		return errors.New("key does not match value")
 	}
 
 	return nil
 }
 
+// HashAlgorithm ... hash algorithm keys are verified with
+func (s *Store) HashAlgorithm() commitments.HashAlgorithm {
+	if s.cfg.HashAlgorithm == "" {
+		return commitments.Keccak256Hash
+	}
+	return s.cfg.HashAlgorithm
+}
+
+func (s *Store) VerificationMode() string {
+	return string(s.HashAlgorithm())
+}
+
 func (s *Store) Stats() *store.Stats {
-	return s.stats
+	return &store.Stats{
+		Entries: int(s.entries.Load()),
+		Reads:   int(s.reads.Load()),
+	}
+}
+
+// Profiler ... returns the profiler used to run detailed profiling sessions against this store
+func (s *Store) Profiler() *store.Profiler {
+	return s.profiler
+}
+
+// Identity ... the S3 endpoint, or the discovery source of the fleet, bucket and path backing the store
+func (s *Store) Identity() string {
+	if s.cfg.EndpointDiscovery != "" {
+		return "s3+" + s.cfg.EndpointDiscovery + "/" + path.Join(s.cfg.Bucket, s.cfg.Path)
+	}
+	// buckets served by GCS's S3-compatible API are those of the GCS backend, see gcs.Store.Identity
+	if strings.EqualFold(strings.TrimSuffix(s.cfg.Endpoint, ":443"), gcsEndpoint) {
+		return "gs://" + path.Join(s.cfg.Bucket, s.cfg.Path)
+	}
+	return "s3://" + path.Join(s.cfg.Endpoint, s.cfg.Bucket, s.cfg.Path)
 }
 
 func (s *Store) BackendType() store.BackendType {
@@ -130,5 +448,12 @@ func creds(cfg Config) *credentials.Credentials {
 	if cfg.CredentialType == CredentialTypeIAM {
 		return credentials.NewIAM("")
 	}
+	if cfg.AccessKeySecretFile != "" {
+		return credentials.New(&fileSecretProvider{
+			accessKeyID:     cfg.AccessKeyID,
+			secretFile:      cfg.AccessKeySecretFile,
+			refreshInterval: utils.DefaultFileWatchInterval,
+		})
+	}
 	return credentials.NewStaticV4(cfg.AccessKeyID, cfg.AccessKeySecret, "")
 }
@@ -0,0 +1,71 @@
+package s3
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/Layr-Labs/eigenda-proxy/store/storetest"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance ... requires a minio server on localhost:4566 (see `make run-minio`)
+func TestConformance(t *testing.T) {
+	if os.Getenv("INTEGRATION") != "true" && os.Getenv("INTEGRATION") != "1" {
+		t.Skip("Skipping test as INTEGRATION env var not set")
+	}
+
+	cfg := Config{
+		CredentialType:  CredentialTypeStatic,
+		Endpoint:        "localhost:4566",
+		AccessKeyID:     "minioadmin",
+		AccessKeySecret: "minioadmin",
+		Bucket:          "eigenda-proxy-conformance-" + strings.TrimPrefix(hexutil.Encode(storetest.RandomValue(t, 4)), "0x"),
+		Timeout:         5 * time.Second,
+	}
+	s, err := NewS3(log.New(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, s.getClient().MakeBucket(context.Background(), cfg.Bucket, minio.MakeBucketOptions{Region: "us-east-1"}))
+
+	storetest.RunConformanceTests(t, func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore {
+		if ttl != 0 {
+			t.Skip("s3 backend does not expire entries")
+		}
+		return s
+	})
+}
+
+func TestObjectKey(t *testing.T) {
+	key, ok := objectKey("blobs%2F0a0b")
+	require.True(t, ok)
+	require.Equal(t, []byte{0x0a, 0x0b}, key)
+
+	key, ok = objectKey("0c")
+	require.True(t, ok)
+	require.Equal(t, []byte{0x0c}, key)
+
+	_, ok = objectKey("blobs/not-a-key")
+	require.False(t, ok)
+}
+
+func TestObjectName(t *testing.T) {
+	s := &Store{cfg: Config{Path: "blobs", TenantPaths: map[string]string{"rollup-a": "rollups/a"}}}
+	require.Equal(t, "blobs/0a0b", s.objectName(context.Background(), []byte{0x0a, 0x0b}))
+	require.Equal(t, "blobs/0a0b", s.objectName(store.WithTenant(context.Background(), "rollup-b"), []byte{0x0a, 0x0b}))
+	require.Equal(t, "rollups/a/0a0b", s.objectName(store.WithTenant(context.Background(), "rollup-a"), []byte{0x0a, 0x0b}))
+}
+
+func TestIdentity(t *testing.T) {
+	s := &Store{cfg: Config{Endpoint: "minio:9000", Bucket: "bucket", Path: "blobs"}}
+	require.Equal(t, "s3://minio:9000/bucket/blobs", s.Identity())
+
+	// the same bucket as the GCS backend's, see gcs.Store.Identity
+	s.cfg.Endpoint = "storage.googleapis.com:443"
+	require.Equal(t, "gs://bucket/blobs", s.Identity())
+}
@@ -0,0 +1,107 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PrefixRoute ... resolves reads of DA commitments starting with Prefix through Pipeline instead of the default
+// read path, e.g. so that legacy commitments of a chain are only read from an S3 archive while newer ones are read
+// from EigenDA
+type PrefixRoute struct {
+	// Prefix is matched against the encoded commitment sent by the client, i.e. including its mode and version
+	// bytes. The route with the longest matching prefix applies.
+	Prefix []byte
+	// Pipeline lists the stores read in order until one serves a verified blob. The GeneratedKeyStore is read by
+	// cert, secondary stores by the keccak256 hash of the cert like fallback targets. Commitments of a route without
+	// a pipeline take the default read path, e.g. to exempt a longer prefix from the route of a shorter one.
+	Pipeline []Store
+}
+
+// WithCommitment ... returns a child context whose request reads the encoded commitment, as sent by the client
+func WithCommitment(ctx context.Context, commitment []byte) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Commitment = commitment })
+}
+
+// sortPrefixRoutes ... orders routes by descending prefix length, so that the first matching route is the longest
+func sortPrefixRoutes(routes []PrefixRoute) []PrefixRoute {
+	sorted := append([]PrefixRoute(nil), routes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Prefix) > len(sorted[j].Prefix)
+	})
+	return sorted
+}
+
+// prefixRoute ... returns the route matching the commitment of the request, if any
+func (r *Router) prefixRoute(ctx context.Context) (PrefixRoute, bool) {
+	commitment := RequestMetaFromContext(ctx).Commitment
+	if len(commitment) == 0 {
+		return PrefixRoute{}, false
+	}
+	for _, route := range r.prefixRoutes {
+		if bytes.HasPrefix(commitment, route.Prefix) {
+			return route, true
+		}
+	}
+	return PrefixRoute{}, false
+}
+
+// prefixPipeline ... returns the pipeline of the route matching the commitment of the request, if any
+func (r *Router) prefixPipeline(ctx context.Context) ([]Store, bool) {
+	route, ok := r.prefixRoute(ctx)
+	return route.Pipeline, ok && len(route.Pipeline) > 0
+}
+
+// readPipeline ... reads the blob of a commitment from the stores of a prefix route, returning the first one
+// passing verification against the cert
+func (r *Router) readPipeline(ctx context.Context, commitment []byte, pipeline []Store) ([]byte, error) {
+	var errs []error
+	for _, s := range pipeline {
+		var data []byte
+		var err error
+		switch s := s.(type) {
+		case GeneratedKeyStore:
+			var cert []byte
+			var fallbackDecoded bool
+			if data, cert, fallbackDecoded, err = r.getEigenDA(ctx, commitment); err == nil && !fallbackDecoded {
+				err = r.verifyCert(ctx, commitment, cert, data)
+			}
+		case PrecomputedKeyStore:
+			if !r.breakers.allow(s) {
+				err = ErrTargetUnavailable
+				break
+			}
+			opCtx, done := r.startOp(ctx, s, opRead)
+			data, err = s.Get(opCtx, crypto.Keccak256(commitment))
+			done(err)
+			if err == nil && data == nil {
+				err = errors.New("no data found")
+			}
+			if err == nil {
+				data, err = openSealed(s, data)
+			}
+			if err == nil {
+				if err = r.verify(ctx, s, r.currentCert(commitment), data); err != nil {
+					r.recordVerificationFailure(s)
+				}
+			}
+		default:
+			err = errors.New("unsupported store")
+		}
+		if err != nil {
+			RequestLogger(ctx, r.log).Warn("Failed to read from prefix route store", "backend", s.BackendType(), "err", err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.BackendType(), err))
+			continue
+		}
+
+		recordServedBy(ctx, s, r.eigenda)
+		recordQuorumPolicy(ctx, r.eigenda)
+		return data, nil
+	}
+	return nil, fmt.Errorf("no data found in the stores of the commitment's prefix route: %w", errors.Join(errs...))
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixRoutes(t *testing.T) {
+	cert, blob := []byte("cert"), []byte("blob")
+	archive := &s3MapStore{mapStore{data: map[string][]byte{string(crypto.Keccak256(cert)): blob}}}
+	unavailable := &fakeDAStore{blob: blob, unavailable: true}
+	available := &slowDAStore{fakeDAStore: fakeDAStore{blob: blob, unavailable: true}}
+
+	tests := []struct {
+		name       string
+		eigenda    GeneratedKeyStore
+		routes     []PrefixRoute
+		commitment []byte
+		servedBy   BackendType
+		wantErr    bool
+	}{
+		{
+			name:       "NoRoute",
+			eigenda:    unavailable,
+			commitment: []byte{0x01, 0x00, 0x00},
+			wantErr:    true,
+		},
+		{
+			name:       "ArchiveRoute",
+			eigenda:    unavailable,
+			routes:     []PrefixRoute{{Prefix: []byte{0x01, 0x00}, Pipeline: []Store{archive}}},
+			commitment: []byte{0x01, 0x00, 0x00},
+			servedBy:   S3BackendType,
+		},
+		{
+			name:    "LongestPrefixTakesDefaultPath",
+			eigenda: unavailable,
+			routes: []PrefixRoute{
+				{Prefix: []byte{0x01}, Pipeline: []Store{archive}},
+				{Prefix: []byte{0x01, 0x00, 0x01}},
+			},
+			commitment: []byte{0x01, 0x00, 0x01},
+			wantErr:    true,
+		},
+		{
+			name:       "UnmatchedPrefix",
+			eigenda:    available,
+			routes:     []PrefixRoute{{Prefix: []byte{0x01, 0x00, 0x00}, Pipeline: []Store{archive}}},
+			commitment: []byte{0x01, 0x00, 0x01},
+			servedBy:   EigenDABackendType,
+		},
+		{
+			name:       "PipelineOrder",
+			eigenda:    available,
+			routes:     []PrefixRoute{{Prefix: []byte{0x00}, Pipeline: []Store{available, archive}}},
+			commitment: []byte{0x00},
+			servedBy:   EigenDABackendType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRouter(tt.eigenda, nil, log.New(), nil, nil, RouterOptions{PrefixRoutes: tt.routes})
+			require.NoError(t, err)
+
+			ctx, meta := WithResponseMeta(WithCommitment(context.Background(), tt.commitment))
+			data, err := r.Get(ctx, cert, commitments.OptimismGeneric)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, blob, data)
+			require.Equal(t, tt.servedBy, meta.Backend())
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxProfilingDuration ... upper bound on the length of a profiling session
+	MaxProfilingDuration = time.Hour
+	// DefaultProfilingDuration ... length of a profiling session when none is provided
+	DefaultProfilingDuration = time.Minute
+)
+
+var (
+	ErrProfilingActive   = errors.New("profiling session already active")
+	ErrProfilingInactive = errors.New("no profiling session has been started")
+)
+
+var (
+	// latency bucket upper bounds in milliseconds
+	latencyBucketsMs = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+	// object size bucket upper bounds in bytes
+	sizeBucketsBytes = []float64{1 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+)
+
+// Histogram ... fixed bucket histogram. Counts[i] holds observations <= Buckets[i], with the final
+// element of Counts holding observations larger than every bucket.
+type Histogram struct {
+	Buckets []float64 `json:"buckets"`
+	Counts  []uint64  `json:"counts"`
+	Sum     float64   `json:"sum"`
+	Count   uint64    `json:"count"`
+}
+
+func newHistogram(buckets []float64) *Histogram {
+	return &Histogram{Buckets: buckets, Counts: make([]uint64, len(buckets)+1)}
+}
+
+func (h *Histogram) observe(v float64) {
+	i := 0
+	for i < len(h.Buckets) && v > h.Buckets[i] {
+		i++
+	}
+	h.Counts[i]++
+	h.Sum += v
+	h.Count++
+}
+
+func (h *Histogram) clone() *Histogram {
+	c := *h
+	c.Counts = append([]uint64(nil), h.Counts...)
+	return &c
+}
+
+// OpProfile ... detailed timings and object sizes recorded for a single operation type (e.g. get, put)
+type OpProfile struct {
+	Errors    uint64     `json:"errors"`
+	LatencyMs *Histogram `json:"latency_ms"`
+	SizeBytes *Histogram `json:"size_bytes"`
+}
+
+// ProfileReport ... JSON serializable result of a profiling session, meant for offline analysis
+type ProfileReport struct {
+	Backend string                `json:"backend"`
+	Active  bool                  `json:"active"`
+	Start   time.Time             `json:"start"`
+	End     time.Time             `json:"end"`
+	Ops     map[string]*OpProfile `json:"ops"`
+}
+
+// Profiler ... records detailed per-operation profiles for a bounded duration. Recording is a single
+// atomic load when no session is active, so stores can call Record unconditionally.
+type Profiler struct {
+	backend BackendType
+	active  atomic.Bool
+
+	mu      sync.Mutex
+	start   time.Time
+	end     time.Time
+	ops     map[string]*OpProfile
+	stopped bool
+}
+
+// NewProfiler ... constructor
+func NewProfiler(backend BackendType) *Profiler {
+	return &Profiler{backend: backend}
+}
+
+// Profileable ... implemented by stores that support profiling sessions
+type Profileable interface {
+	Profiler() *Profiler
+}
+
+// Start ... begins a new profiling session lasting d, discarding the results of any previous session
+func (p *Profiler) Start(d time.Duration) error {
+	if d <= 0 {
+		d = DefaultProfilingDuration
+	}
+	d = min(d, MaxProfilingDuration)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.active.Load() && time.Now().Before(p.end) {
+		return ErrProfilingActive
+	}
+
+	p.start = time.Now()
+	p.end = p.start.Add(d)
+	p.ops = make(map[string]*OpProfile)
+	p.stopped = false
+	p.active.Store(true)
+	return nil
+}
+
+// Stop ... ends the current profiling session early and returns its report
+func (p *Profiler) Stop() (ProfileReport, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ops == nil {
+		return ProfileReport{}, ErrProfilingInactive
+	}
+	if !p.stopped && time.Now().Before(p.end) {
+		p.end = time.Now()
+	}
+	p.stopped = true
+	p.active.Store(false)
+	return p.report(), nil
+}
+
+// Report ... returns the report of the current or most recent profiling session
+func (p *Profiler) Report() (ProfileReport, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ops == nil {
+		return ProfileReport{}, ErrProfilingInactive
+	}
+	return p.report(), nil
+}
+
+// report ... caller must hold the lock
+func (p *Profiler) report() ProfileReport {
+	rep := ProfileReport{
+		Backend: p.backend.String(),
+		Active:  !p.stopped && time.Now().Before(p.end),
+		Start:   p.start,
+		End:     p.end,
+		Ops:     make(map[string]*OpProfile, len(p.ops)),
+	}
+	for op, prof := range p.ops {
+		rep.Ops[op] = &OpProfile{
+			Errors:    prof.Errors,
+			LatencyMs: prof.LatencyMs.clone(),
+			SizeBytes: prof.SizeBytes.clone(),
+		}
+	}
+	return rep
+}
+
+// Record ... records a single operation if a profiling session is active. size is ignored for failed operations.
+func (p *Profiler) Record(op string, took time.Duration, size int, err error) {
+	if !p.active.Load() {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	if p.stopped || now.After(p.end) {
+		p.active.Store(false)
+		return
+	}
+
+	prof, ok := p.ops[op]
+	if !ok {
+		prof = &OpProfile{
+			LatencyMs: newHistogram(latencyBucketsMs),
+			SizeBytes: newHistogram(sizeBucketsBytes),
+		}
+		p.ops[op] = prof
+	}
+
+	prof.LatencyMs.observe(float64(took) / float64(time.Millisecond))
+	if err != nil {
+		prof.Errors++
+		return
+	}
+	prof.SizeBytes.observe(float64(size))
+}
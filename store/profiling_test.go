@@ -0,0 +1,53 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiler(t *testing.T) {
+	p := NewProfiler(S3BackendType)
+
+	// recording without an active session is a no-op
+	p.Record("get", time.Millisecond, 10, nil)
+	_, err := p.Report()
+	require.ErrorIs(t, err, ErrProfilingInactive)
+
+	require.NoError(t, p.Start(time.Minute))
+	require.ErrorIs(t, p.Start(time.Minute), ErrProfilingActive)
+
+	p.Record("get", 3*time.Millisecond, 2048, nil)
+	p.Record("get", 2*time.Second, 0, errors.New("timeout"))
+	p.Record("put", time.Millisecond, 32<<20, nil)
+
+	rep, err := p.Stop()
+	require.NoError(t, err)
+	require.False(t, rep.Active)
+	require.Equal(t, "S3", rep.Backend)
+
+	get := rep.Ops["get"]
+	require.Equal(t, uint64(1), get.Errors)
+	require.Equal(t, uint64(2), get.LatencyMs.Count)
+	require.Equal(t, uint64(1), get.LatencyMs.Counts[1]) // <= 5ms
+	require.Equal(t, uint64(1), get.LatencyMs.Counts[9]) // <= 2500ms
+	require.Equal(t, uint64(1), get.SizeBytes.Counts[1]) // <= 16KiB
+	require.Equal(t, uint64(1), rep.Ops["put"].SizeBytes.Counts[len(sizeBucketsBytes)])
+
+	// no more samples are recorded once stopped
+	p.Record("get", time.Millisecond, 10, nil)
+	rep, err = p.Report()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), rep.Ops["get"].LatencyMs.Count)
+
+	// sessions end on their own once their duration has elapsed
+	require.NoError(t, p.Start(time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	p.Record("get", time.Millisecond, 10, nil)
+	rep, err = p.Report()
+	require.NoError(t, err)
+	require.False(t, rep.Active)
+	require.Empty(t, rep.Ops)
+}
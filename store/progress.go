@@ -0,0 +1,30 @@
+package store
+
+import "context"
+
+// DispersalState ... progress of the dispersal of a blob, as reported by the EigenDA backend while it waits for
+// the disperser
+type DispersalState string
+
+const (
+	// DispersalDispersing ... the blob was accepted by the disperser and is being dispersed
+	DispersalDispersing DispersalState = "dispersing"
+	// DispersalConfirmed ... the batch of the blob was confirmed on Ethereum
+	DispersalConfirmed DispersalState = "confirmed"
+	// DispersalFinalized ... the batch of the blob was confirmed in a finalized Ethereum block
+	DispersalFinalized DispersalState = "finalized"
+)
+
+// WithDispersalProgress ... returns a child context whose writes report the progress of their dispersals to fn.
+// fn may be called concurrently, e.g. by the dispersals of the chunks of a payload.
+func WithDispersalProgress(ctx context.Context, fn func(DispersalState)) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.OnDispersal = fn })
+}
+
+// ReportDispersalState ... reports the progress of a dispersal to the request of the context (if watched). Called
+// by GeneratedKeyStore implementations, since only they know the status of their dispersals.
+func ReportDispersalState(ctx context.Context, state DispersalState) {
+	if fn := RequestMetaFromContext(ctx).OnDispersal; fn != nil {
+		fn(state)
+	}
+}
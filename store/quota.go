@@ -0,0 +1,161 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type QuotaPolicy string
+
+const (
+	// QuotaPolicyReject ... refuse writes that would exceed the quota
+	QuotaPolicyReject QuotaPolicy = "reject"
+	// QuotaPolicyEvict ... evict the oldest entries until the write fits within the quota
+	QuotaPolicyEvict QuotaPolicy = "evict"
+)
+
+var ErrQuotaExceeded = errors.New("backend quota exceeded")
+
+// QuotaUsageSaveInterval ... interval at which PersistQuotaUsage saves quota usage
+const QuotaUsageSaveInterval = time.Minute
+
+// Quota ... maximum number of bytes a secondary backend may hold, as tracked by the commitment index.
+// Only blobs written by this proxy are accounted for, so the quota is never applied to unrelated keys.
+type Quota struct {
+	MaxBytes int64
+	Policy   QuotaPolicy
+}
+
+// reserveQuota ... ensures that a blob of the given size fits within the quota of the backend, evicting
+// the oldest indexed entries if the policy allows it. Quotas are soft: concurrent writes may briefly overshoot.
+func (r *Router) reserveQuota(ctx context.Context, s PrecomputedKeyStore, size int) error {
+	quota, ok := r.quotas[s.BackendType()]
+	if !ok {
+		return nil
+	}
+	if int64(size) > quota.MaxBytes {
+		return fmt.Errorf("%w: blob of %d bytes is larger than %s quota of %d bytes",
+			ErrQuotaExceeded, size, s.BackendType(), quota.MaxBytes)
+	}
+
+	r.quotaLock.Lock()
+	defer r.quotaLock.Unlock()
+
+	backend := s.BackendType().String()
+	used := r.index.BackendBytes(backend)
+	if used+int64(size) <= quota.MaxBytes {
+		return nil
+	}
+
+	deleter, ok := s.(Deleter)
+	if quota.Policy != QuotaPolicyEvict || !ok {
+		return fmt.Errorf("%w: %s holds %d of %d bytes", ErrQuotaExceeded, s.BackendType(), used, quota.MaxBytes)
+	}
+
+	// evict in small batches until the blob fits
+	for used+int64(size) > quota.MaxBytes {
+		oldest := r.index.Oldest(backend, 16)
+		if len(oldest) == 0 {
+			return fmt.Errorf("%w: no more %s entries to evict", ErrQuotaExceeded, s.BackendType())
+		}
+
+		for _, e := range oldest {
+			if used+int64(size) <= quota.MaxBytes {
+				break
+			}
+			if err := deleter.Delete(WithTenant(ctx, e.Tenant), crypto.Keccak256(e.Key)); err != nil {
+				return fmt.Errorf("failed to evict entry from %s: %w", s.BackendType(), err)
+			}
+			r.index.RemoveBackend(e.Key, backend)
+			used -= int64(e.Size)
+			r.log.Debug("Evicted entry to enforce quota", "backend", s.BackendType(), "size", e.Size)
+		}
+	}
+
+	return nil
+}
+
+// recordQuotaUtilization ... reports the share of the backend's quota in use, if one is configured
+func (r *Router) recordQuotaUtilization(s PrecomputedKeyStore) {
+	quota, ok := r.quotas[s.BackendType()]
+	if !ok {
+		return
+	}
+	backend := s.BackendType().String()
+	r.m.RecordQuotaUtilization(backend, r.index.BackendBytes(backend), quota.MaxBytes)
+}
+
+// LoadQuotaUsage ... restores the index entries saved by SaveQuotaUsage into idx, so that quota usage and the order
+// entries are evicted in survive restarts. Returns the number of entries restored, 0 if path doesn't exist yet.
+func LoadQuotaUsage(idx *index.Index, path string) (int, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to read quota usage %s: %w", path, err)
+	}
+
+	var records []index.Record
+	if err := json.Unmarshal(b, &records); err != nil {
+		return 0, fmt.Errorf("failed to decode quota usage %s: %w", path, err)
+	}
+	return idx.Restore(records), nil
+}
+
+// SaveQuotaUsage ... atomically writes the index entries held by backends with a quota to path. Only entries are
+// saved, never backend listings, so that eviction still only ever deletes blobs written by the proxy.
+func SaveQuotaUsage(idx *index.Index, quotas map[BackendType]Quota, path string) error {
+	backends := make([]string, 0, len(quotas))
+	for b := range quotas {
+		backends = append(backends, b.String())
+	}
+	b, err := json.Marshal(idx.DumpHeld(backends...))
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to save quota usage: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to save quota usage: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to save quota usage: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save quota usage: %w", err)
+	}
+	return nil
+}
+
+// PersistQuotaUsage ... saves quota usage to path every QuotaUsageSaveInterval until ctx is done. Callers save it
+// once more on shutdown, so that only the writes since the last save are lost on a crash.
+func PersistQuotaUsage(ctx context.Context, idx *index.Index, quotas map[BackendType]Quota, path string,
+	l log.Logger) {
+	ticker := time.NewTicker(QuotaUsageSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := SaveQuotaUsage(idx, quotas, path); err != nil {
+				l.Error("Failed to save quota usage", "path", path, "err", err)
+			}
+		}
+	}
+}
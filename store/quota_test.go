@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// mapStore ... minimal in-memory PrecomputedKeyStore used to exercise the router
+type mapStore struct {
+	data map[string][]byte
+}
+
+func (m *mapStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	return m.data[string(key)], nil
+}
+func (m *mapStore) Put(_ context.Context, key []byte, value []byte) error {
+	m.data[string(key)] = value
+	return nil
+}
+func (m *mapStore) Delete(_ context.Context, key []byte) error {
+	delete(m.data, string(key))
+	return nil
+}
+func (m *mapStore) Verify(_ []byte, _ []byte) error { return nil }
+func (m *mapStore) BackendType() BackendType        { return RedisBackendType }
+func (m *mapStore) Stats() *Stats                   { return &Stats{} }
+
+func TestQuotaEnforcement(t *testing.T) {
+	ctx := context.Background()
+	value := make([]byte, 40)
+
+	tests := []struct {
+		name    string
+		policy  QuotaPolicy
+		stored  []string
+		skipped string
+	}{
+		{name: "Reject", policy: QuotaPolicyReject, stored: []string{"a", "b"}, skipped: "c"},
+		{name: "Evict", policy: QuotaPolicyEvict, stored: []string{"b", "c"}, skipped: "a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &mapStore{data: make(map[string][]byte)}
+			idx := index.New(0)
+			r, err := NewRouter(nil, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{
+				Index:  idx,
+				Quotas: map[BackendType]Quota{RedisBackendType: {MaxBytes: 100, Policy: tt.policy}},
+			})
+			require.NoError(t, err)
+			router := r.(*Router)
+
+			for _, commitment := range []string{"a", "b", "c"} {
+				_ = router.handleRedundantWrites(ctx, []byte(commitment), value, nil)
+			}
+
+			for _, commitment := range tt.stored {
+				require.Contains(t, cache.data, string(crypto.Keccak256([]byte(commitment))))
+			}
+			require.NotContains(t, cache.data, string(crypto.Keccak256([]byte(tt.skipped))))
+			require.Equal(t, int64(80), idx.BackendBytes(RedisBackendType.String()))
+		})
+	}
+}
+
+func TestQuotaUsagePersistence(t *testing.T) {
+	ctx := context.Background()
+	value := make([]byte, 40)
+	path := filepath.Join(t.TempDir(), "quota-usage.json")
+	quotas := map[BackendType]Quota{RedisBackendType: {MaxBytes: 100, Policy: QuotaPolicyEvict}}
+	cache := &mapStore{data: make(map[string][]byte)}
+
+	n, err := LoadQuotaUsage(index.New(0), path)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	newRouter := func(idx *index.Index) *Router {
+		r, err := NewRouter(nil, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{Index: idx, Quotas: quotas})
+		require.NoError(t, err)
+		return r.(*Router)
+	}
+	idx := index.New(0)
+	router := newRouter(idx)
+	for _, commitment := range []string{"a", "b"} {
+		_ = router.handleRedundantWrites(ctx, []byte(commitment), value, nil)
+	}
+	require.NoError(t, SaveQuotaUsage(idx, quotas, path))
+
+	// after a restart, usage is restored and the oldest blob written before it is evicted first
+	restored := index.New(0)
+	n, err = LoadQuotaUsage(restored, path)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, int64(80), restored.BackendBytes(RedisBackendType.String()))
+
+	_ = newRouter(restored).handleRedundantWrites(ctx, []byte("c"), value, nil)
+	require.NotContains(t, cache.data, string(crypto.Keccak256([]byte("a"))))
+	require.Contains(t, cache.data, string(crypto.Keccak256([]byte("b"))))
+	require.Contains(t, cache.data, string(crypto.Keccak256([]byte("c"))))
+	require.Equal(t, int64(80), restored.BackendBytes(RedisBackendType.String()))
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// racedRead ... outcome of one of the reads raced by raceRead
+type racedRead struct {
+	data     []byte
+	meta     *ResponseMeta
+	err      error
+	fallback bool
+	// whether the blob read from EigenDA was decoded by a fallback, see getEigenDA
+	fallbackDecoded bool
+}
+
+// readEigenDA ... reads the blob of a commitment from EigenDA and verifies it against the cert it was read with,
+// unless it was decoded by a fallback, see getEigenDA
+func (r *Router) readEigenDA(ctx context.Context, key []byte) ([]byte, bool, error) {
+	data, cert, fallbackDecoded, err := r.getEigenDA(ctx, key)
+	if err == nil && !fallbackDecoded {
+		err = r.verifyCert(ctx, key, cert, data)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	recordServedBy(ctx, r.eigenda, r.eigenda)
+	recordQuorumPolicy(ctx, r.eigenda)
+	return data, fallbackDecoded, nil
+}
+
+// raceRead ... reads a blob from EigenDA, and from the fallback targets in parallel once the EigenDA read took
+// longer than the read SLO or failed. The first verified blob is served and the other read is cancelled, so
+// that a slow retrieval doesn't hold up the read for the full EigenDA timeout.
+func (r *Router) raceRead(ctx context.Context, key []byte) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the read that lost the race
+
+	// each read records the backend serving it into its own response metadata, only the winner's is kept
+	results := make(chan racedRead, 2)
+	go func() {
+		rctx, meta := WithResponseMeta(ctx)
+		data, fallbackDecoded, err := r.readEigenDA(rctx, key)
+		results <- racedRead{data: data, meta: meta, err: err, fallbackDecoded: fallbackDecoded}
+	}()
+
+	pending := 1
+	fallbackStarted := false
+	startFallback := func() {
+		fallbackStarted = true
+		pending++
+		go func() {
+			rctx, meta := WithResponseMeta(ctx)
+			data, err := r.multiSourceRead(rctx, key, true)
+			results <- racedRead{data: data, meta: meta, err: err, fallback: true}
+		}()
+	}
+
+	slo := time.NewTimer(r.readSLO)
+	defer slo.Stop()
+
+	exceeded := false
+	var eigendaErr, fallbackErr error
+	for pending > 0 {
+		select {
+		case <-slo.C:
+			if fallbackStarted {
+				continue
+			}
+			exceeded = true
+			RequestLogger(ctx, r.log).Warn("EigenDA read exceeded the read SLO, reading from fallback targets in parallel",
+				"slo", r.readSLO)
+			startFallback()
+
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				if exceeded {
+					r.m.RecordReadSLOExceeded(res.meta.Backend().String())
+				}
+				if meta := ResponseMetaFromContext(ctx); meta != nil {
+					meta.copyServedBy(res.meta)
+				}
+				if !res.fallbackDecoded {
+					r.fillReadThroughCaches(ctx, key, res.data)
+				}
+				return res.data, nil
+			}
+
+			if res.fallback {
+				fallbackErr = res.err
+				RequestLogger(ctx, r.log).Error("Failed to read from fallback targets", "err", res.err)
+				continue
+			}
+			eigendaErr = res.err
+			if !fallbackStarted {
+				startFallback()
+			}
+		}
+	}
+
+	if exceeded {
+		r.m.RecordReadSLOExceeded("none")
+	}
+	if fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	return nil, eigendaErr
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// slowDAStore ... GeneratedKeyStore serving its blob after a delay, or failing once the read is cancelled
+type slowDAStore struct {
+	fakeDAStore
+	delay time.Duration
+}
+
+func (s *slowDAStore) Get(ctx context.Context, _ []byte) ([]byte, error) {
+	select {
+	case <-time.After(s.delay):
+		return s.blob, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestReadSLO(t *testing.T) {
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	tests := []struct {
+		name     string
+		delay    time.Duration
+		fallback []byte
+		servedBy BackendType
+		exceeded string
+	}{
+		{name: "EigenDAWithinSLO", delay: 0, fallback: blob, servedBy: EigenDABackendType},
+		{name: "FallbackAfterSLO", delay: time.Hour, fallback: blob, servedBy: S3BackendType, exceeded: "S3"},
+		{name: "EigenDAAfterSLO", delay: 100 * time.Millisecond, fallback: []byte("corrupt"),
+			servedBy: EigenDABackendType, exceeded: "EigenDA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			da := &slowDAStore{fakeDAStore: fakeDAStore{blob: blob, unavailable: true}, delay: tt.delay}
+			backup := &s3MapStore{mapStore{data: map[string][]byte{key: tt.fallback}}}
+			m := metrics.NewMetrics("test")
+			r, err := NewRouter(da, nil, log.New(), nil, []PrecomputedKeyStore{backup},
+				RouterOptions{Metrics: m, ReadSLO: 10 * time.Millisecond})
+			require.NoError(t, err)
+
+			ctx, meta := WithResponseMeta(context.Background())
+			data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+			require.NoError(t, err)
+			require.Equal(t, blob, data)
+			require.Equal(t, tt.servedBy, meta.Backend())
+			if tt.exceeded != "" {
+				require.Equal(t, 1.0, testutil.ToFloat64(m.ReadSLOExceeded.WithLabelValues(tt.exceeded)))
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ReconcileRepair ... direction in which inconsistencies between the commitment index and the secondary
+// backends are repaired
+type ReconcileRepair string
+
+const (
+	// ReconcileRepairNone ... inconsistencies are only reported
+	ReconcileRepairNone ReconcileRepair = "none"
+	// ReconcileRepairIndex ... the index is made to match the backends, i.e. blobs missing from a backend are
+	// no longer accounted to it
+	ReconcileRepairIndex ReconcileRepair = "index"
+	// ReconcileRepairBackend ... the backends are made to match the index, i.e. missing blobs are fetched from
+	// EigenDA and rewritten
+	ReconcileRepairBackend ReconcileRepair = "backend"
+)
+
+// maxReconcileErrors ... number of errors kept per report
+const maxReconcileErrors = 10
+
+var errStopListing = errors.New("stop listing")
+
+// ReconcileOptions ... reconciliation of the commitment index with the secondary backends
+type ReconcileOptions struct {
+	// SampleSize is the number of indexed entries checked and the number of objects listed per backend and run
+	SampleSize int
+	Repair     ReconcileRepair
+}
+
+// ReconcileReport ... outcome of reconciling a backend with the commitment index
+type ReconcileReport struct {
+	Backend string    `json:"backend"`
+	Role    string    `json:"role"`
+	At      time.Time `json:"at"`
+	// Sampled indexed entries were checked for, of which Missing weren't found in the backend
+	Sampled int `json:"sampled"`
+	Missing int `json:"missing"`
+	// Listed objects were enumerated from the backend, of which Orphaned are unknown to the index. Listed is
+	// zero for backends that can't enumerate their keys.
+	Listed   int      `json:"listed"`
+	Orphaned int      `json:"orphaned"`
+	Repaired int      `json:"repaired"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+func (rep *ReconcileReport) fail(err error) {
+	if len(rep.Errors) < maxReconcileErrors {
+		rep.Errors = append(rep.Errors, err.Error())
+	}
+}
+
+// Reconcile ... compares a sample of the commitment index with the contents of each writable cache and fallback
+// target, reporting indexed blobs missing from a backend and objects unknown to the index, and repairs the missing
+// blobs according to the configured repair direction
+func (r *Router) Reconcile(ctx context.Context) []ReconcileReport {
+	r.cacheLock.RLock()
+	caches := append([]PrecomputedKeyStore(nil), r.caches...)
+	r.cacheLock.RUnlock()
+	r.fallbackLock.RLock()
+	fallbacks := append([]PrecomputedKeyStore(nil), r.fallbacks...)
+	r.fallbackLock.RUnlock()
+
+	// read-only targets are never written by the proxy, so there is nothing to reconcile or repair
+	reports := make([]ReconcileReport, 0, len(caches)+len(fallbacks))
+	for _, c := range caches {
+		if r.writable(c) {
+			reports = append(reports, r.reconcile(ctx, c, true))
+		}
+	}
+	for _, f := range fallbacks {
+		if r.writable(f) {
+			reports = append(reports, r.reconcile(ctx, f, false))
+		}
+	}
+
+	for _, rep := range reports {
+		r.m.RecordReconciliation(rep.Backend, rep.Missing, rep.Orphaned)
+		if rep.Missing > 0 || rep.Orphaned > 0 || len(rep.Errors) > 0 {
+			r.log.Warn("Commitment index and backend are inconsistent", "backend", rep.Backend,
+				"missing", rep.Missing, "orphaned", rep.Orphaned, "repaired", rep.Repaired, "errors", len(rep.Errors))
+		}
+	}
+
+	r.reconcileLock.Lock()
+	r.reconcileReports = reports
+	r.reconcileLock.Unlock()
+	return reports
+}
+
+// ReconcileReports ... returns the reports of the last reconciliation run
+func (r *Router) ReconcileReports() []ReconcileReport {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+	return r.reconcileReports
+}
+
+// RunReconciliation ... reconciles the router's index with its secondary backends every interval until ctx is
+// done
+func RunReconciliation(ctx context.Context, r IRouter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Reconcile(ctx)
+		}
+	}
+}
+
+// reconcile ... reconciles a single backend. Orphaned objects are only reported, never deleted: the index doesn't
+// hold blobs written before a restart, evicted from it or written by other proxies sharing the backend, nor
+// keccak256 payloads, of which the backend may hold the only copy.
+func (r *Router) reconcile(ctx context.Context, s PrecomputedKeyStore, cache bool) ReconcileReport {
+	backend := s.BackendType().String()
+	rep := ReconcileReport{Backend: backend, Role: "fallback", At: time.Now()}
+	if cache {
+		rep.Role = "cache"
+	}
+
+	// indexed blobs missing from the backend
+	for _, e := range r.index.Sample(backend, r.reconcileOpts.SampleSize) {
+		rep.Sampled++
+		ctx := WithTenant(ctx, e.Tenant)
+		ok, err := exists(ctx, s, crypto.Keccak256(e.Key))
+		if err != nil {
+			rep.fail(err)
+			continue
+		}
+		if ok {
+			continue
+		}
+		rep.Missing++
+
+		switch r.reconcileOpts.Repair {
+		case ReconcileRepairIndex:
+			r.index.RemoveBackend(e.Key, backend)
+			rep.Repaired++
+		case ReconcileRepairBackend:
+			if err := r.rewrite(ctx, s, e.Key); err != nil {
+				rep.fail(err)
+				continue
+			}
+			rep.Repaired++
+		case ReconcileRepairNone:
+		}
+	}
+
+	// objects unknown to the index
+	lister, ok := s.(Lister)
+	if !ok {
+		return rep
+	}
+	known := make(map[string]struct{}, r.index.Len())
+	for _, k := range r.index.Keys() {
+		known[string(crypto.Keccak256(k))] = struct{}{}
+	}
+	err := lister.Keys(ctx, func(key []byte) error {
+		if rep.Listed >= r.reconcileOpts.SampleSize {
+			return errStopListing
+		}
+		rep.Listed++
+		if _, ok := known[string(key)]; !ok {
+			rep.Orphaned++
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopListing) {
+		rep.fail(fmt.Errorf("failed to list keys: %w", err))
+	}
+	return rep
+}
+
+// rewrite ... fetches a blob from EigenDA and writes it to the backend again
+func (r *Router) rewrite(ctx context.Context, s PrecomputedKeyStore, commitment []byte) error {
+	if r.eigenda == nil {
+		return errors.New("expected EigenDA backend to rewrite missing blob, but none configured")
+	}
+	data, err := r.eigenda.Get(ctx, commitment)
+	if err != nil {
+		return fmt.Errorf("failed to fetch missing blob from EigenDA: %w", err)
+	}
+	if err := r.verify(ctx, r.eigenda, commitment, data); err != nil {
+		return err
+	}
+	return s.Put(ctx, crypto.Keccak256(commitment), r.seal(data))
+}
+
+// exists ... checks for a key, reading its value if the store can't check for keys
+func exists(ctx context.Context, s PrecomputedKeyStore, key []byte) (bool, error) {
+	if e, ok := s.(Exister); ok {
+		return e.Exists(ctx, key)
+	}
+	value, err := s.Get(ctx, key)
+	return value != nil, err
+}
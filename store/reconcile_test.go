@@ -0,0 +1,107 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// listStore ... mapStore able to enumerate its keys
+type listStore struct {
+	*mapStore
+}
+
+func (l listStore) Keys(_ context.Context, fn func(key []byte) error) error {
+	for k := range l.data {
+		if err := fn([]byte(k)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	tests := []struct {
+		name   string
+		repair ReconcileRepair
+		// whether the missing entry is still accounted to the cache afterwards
+		indexed bool
+		// whether the cache holds the missing blob afterwards
+		rewritten bool
+	}{
+		{name: "None", repair: ReconcileRepairNone, indexed: true},
+		{name: "Index", repair: ReconcileRepairIndex, indexed: false},
+		{name: "Backend", repair: ReconcileRepairBackend, indexed: true, rewritten: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := listStore{&mapStore{data: map[string][]byte{
+				string(crypto.Keccak256([]byte("a"))): blob,
+				string(crypto.Keccak256([]byte("x"))): blob,
+			}}}
+			idx := index.New(0)
+			for _, k := range []string{"a", "b"} {
+				idx.Put([]byte(k), len(blob), "simple")
+				idx.AddBackend([]byte(k), len(blob), RedisBackendType.String())
+			}
+
+			r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+				RouterOptions{Index: idx, Reconcile: ReconcileOptions{SampleSize: 10, Repair: tt.repair}})
+			require.NoError(t, err)
+
+			reports := r.Reconcile(ctx)
+			require.Len(t, reports, 1)
+			rep := reports[0]
+			require.Equal(t, "cache", rep.Role)
+			require.Equal(t, 2, rep.Sampled)
+			require.Equal(t, 1, rep.Missing)
+			// objects are listed after missing blobs were rewritten
+			listed := 2
+			if tt.rewritten {
+				listed++
+			}
+			require.Equal(t, listed, rep.Listed)
+			require.Equal(t, 1, rep.Orphaned)
+			require.Empty(t, rep.Errors)
+			require.Equal(t, reports, r.ReconcileReports())
+
+			e, _ := idx.Get([]byte("b"))
+			_, indexed := e.Backends[RedisBackendType.String()]
+			require.Equal(t, tt.indexed, indexed)
+			_, rewritten := cache.data[string(crypto.Keccak256([]byte("b")))]
+			require.Equal(t, tt.rewritten, rewritten)
+			// orphaned objects are never deleted, the index doesn't know every blob the backend holds
+			_, orphan := cache.data[string(crypto.Keccak256([]byte("x")))]
+			require.True(t, orphan)
+		})
+	}
+}
+
+func TestReconcileKeepsOrphans(t *testing.T) {
+	newTarget := func() listStore {
+		return listStore{&mapStore{data: map[string][]byte{
+			string(crypto.Keccak256([]byte("x"))): []byte("blob"),
+		}}}
+	}
+	cache, fallback := newTarget(), newTarget()
+	r, err := NewRouter(&fakeDAStore{}, nil, log.New(), []PrecomputedKeyStore{cache}, []PrecomputedKeyStore{fallback},
+		RouterOptions{Reconcile: ReconcileOptions{SampleSize: 10, Repair: ReconcileRepairBackend}})
+	require.NoError(t, err)
+
+	reports := r.Reconcile(context.Background())
+	require.Len(t, reports, 2)
+	for _, rep := range reports {
+		require.Equal(t, 1, rep.Orphaned)
+		require.Equal(t, 0, rep.Repaired)
+	}
+	require.Len(t, cache.data, 1)
+	require.Len(t, fallback.data, 1)
+}
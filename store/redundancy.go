@@ -0,0 +1,75 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidRedundancy ... returned for writes requesting redundancy the router can't provide, e.g. a backend
+// that isn't configured
+var ErrInvalidRedundancy = errors.New("invalid redundancy request")
+
+// WithRedundancy ... returns a child context whose writes are also persisted to the secondary backends sinks, on
+// top of the cache and fallback targets, e.g. for critical payloads like genesis or upgrade batches
+func WithRedundancy(ctx context.Context, sinks []BackendType) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Redundancy = sinks })
+}
+
+// redundancySinks ... resolves the sinks requested by the context which aren't cache or fallback targets already
+func (r *Router) redundancySinks(ctx context.Context) ([]PrecomputedKeyStore, error) {
+	requested := RequestMetaFromContext(ctx).Redundancy
+	if len(requested) == 0 {
+		return nil, nil
+	}
+
+	r.cacheLock.RLock()
+	r.fallbackLock.RLock()
+	defer func() {
+		r.cacheLock.RUnlock()
+		r.fallbackLock.RUnlock()
+	}()
+
+	var sinks []PrecomputedKeyStore
+	for _, b := range requested {
+		sink, ok := r.sinks[b]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s backend is not configured", ErrInvalidRedundancy, b)
+		}
+		if !r.writable(sink) {
+			return nil, fmt.Errorf("%w: %s backend is read-only", ErrInvalidRedundancy, b)
+		}
+		if !containsStore(r.caches, sink) && !containsStore(r.fallbacks, sink) && !containsStore(sinks, sink) {
+			sinks = append(sinks, sink)
+		}
+	}
+	return sinks, nil
+}
+
+func containsStore(stores []PrecomputedKeyStore, s PrecomputedKeyStore) bool {
+	for _, other := range stores {
+		if other == s {
+			return true
+		}
+	}
+	return false
+}
+
+// recordAcknowledged ... records that backend persisted a write into the context's ResponseMeta (if any)
+func recordAcknowledged(ctx context.Context, backend BackendType) {
+	meta := ResponseMetaFromContext(ctx)
+	if meta == nil {
+		return
+	}
+
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.acknowledged = append(meta.acknowledged, backend)
+}
+
+// Acknowledged ... returns the backends that persisted the blob written by the request, the primary backend first
+func (m *ResponseMeta) Acknowledged() []BackendType {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]BackendType(nil), m.acknowledged...)
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDAStore ... fakeDAStore committing to every blob with the same commitment, counting its dispersals
+type countingDAStore struct {
+	fakeDAStore
+	puts int
+}
+
+func (s *countingDAStore) Put(context.Context, []byte) ([]byte, error) {
+	s.puts++
+	return []byte("commitment"), nil
+}
+
+func TestRedundancy(t *testing.T) {
+	blob := []byte("blob")
+	key := string(crypto.Keccak256([]byte("commitment")))
+
+	da := &countingDAStore{}
+	cache := &mapStore{data: make(map[string][]byte)}
+	backup := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(da, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{
+		Sinks: []PrecomputedKeyStore{cache, backup},
+	})
+	require.NoError(t, err)
+
+	// writes only go to the cache targets by default
+	ctx, rm := WithResponseMeta(context.Background())
+	_, err = r.Put(ctx, commitments.SimpleCommitmentMode, nil, blob)
+	require.NoError(t, err)
+	require.Equal(t, []BackendType{EigenDABackendType, RedisBackendType}, rm.Acknowledged())
+	require.Empty(t, backup.data)
+
+	// requested sinks are written once, whether they are targets or not
+	ctx, rm = WithResponseMeta(WithRedundancy(context.Background(), []BackendType{S3BackendType, RedisBackendType}))
+	_, err = r.Put(ctx, commitments.SimpleCommitmentMode, nil, blob)
+	require.NoError(t, err)
+	require.Equal(t, []BackendType{EigenDABackendType, RedisBackendType, S3BackendType}, rm.Acknowledged())
+	require.Equal(t, blob, backup.data[key])
+
+	// invalid requests are rejected before dispersing
+	puts := da.puts
+	ctx = WithRedundancy(context.Background(), []BackendType{FSBackendType})
+	_, err = r.Put(ctx, commitments.SimpleCommitmentMode, nil, blob)
+	require.ErrorIs(t, err, ErrInvalidRedundancy)
+	_, err = r.Put(ctx, commitments.OptimismKeccak, crypto.Keccak256(blob), blob)
+	require.ErrorIs(t, err, ErrInvalidRedundancy)
+	require.Equal(t, puts, da.puts)
+
+	r.(*Router).roles = map[BackendType]TargetRole{S3BackendType: TargetRoleReadOnly}
+	_, err = r.Put(WithRedundancy(context.Background(), []BackendType{S3BackendType}), commitments.SimpleCommitmentMode,
+		nil, blob)
+	require.ErrorIs(t, err, ErrInvalidRedundancy)
+	require.Equal(t, puts, da.puts)
+}
+
+func TestRedundantWritesKeepTargets(t *testing.T) {
+	cache := &mapStore{data: make(map[string][]byte)}
+	backup := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{}, nil, log.New(), nil, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// targets with spare capacity, as left by appending them together
+	router := r.(*Router)
+	router.caches = append(make([]PrecomputedKeyStore, 0, 4), cache)
+
+	require.NoError(t, router.handleRedundantWrites(context.Background(), []byte("commitment"), []byte("blob"),
+		[]PrecomputedKeyStore{backup}).Err())
+	require.Nil(t, router.caches[:cap(router.caches)][1], "the sinks of a write were appended to the cache targets")
+}
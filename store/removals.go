@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// removalBatchInterval ... how often removed keys are applied to the index
+	removalBatchInterval = time.Second
+	// removalRetryInterval ... delay before resubscribing after a subscription failed
+	removalRetryInterval = 10 * time.Second
+)
+
+// WatchRemovals ... subscribes to the keys removed from every cache and fallback target supporting it, e.g. objects
+// expired by bucket lifecycle rules, and removes the target from the commitment index entries of removed keys, so
+// that reads and quotas stop accounting blobs the target no longer holds. Returns once ctx is done.
+func WatchRemovals(ctx context.Context, r IRouter, l log.Logger) {
+	var wg sync.WaitGroup
+	seen := make(map[string]struct{})
+	for _, s := range append(append([]PrecomputedKeyStore{}, r.Caches()...), r.Fallbacks()...) {
+		w, ok := s.(RemovalWatcher)
+		if !ok {
+			continue
+		}
+		id := identity(s)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		backend := s.BackendType().String()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			watchRemovals(ctx, r.Index(), backend, w, l.With("backend", backend))
+		}()
+	}
+	wg.Wait()
+}
+
+// watchRemovals ... applies the removals of a single backend in batches until ctx is done, resubscribing when the
+// subscription fails
+func watchRemovals(ctx context.Context, idx *index.Index, backend string, w RemovalWatcher, l log.Logger) {
+	removed := make(chan []byte, 1024)
+	subscribed := make(chan struct{})
+	go func() {
+		defer close(subscribed)
+		for {
+			err := w.WatchRemovals(ctx, func(key []byte) {
+				select {
+				case removed <- key:
+				case <-ctx.Done():
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, ErrRemovalsUnsupported) {
+				l.Debug("Removal notifications aren't enabled")
+				return
+			}
+			l.Warn("Removal notification subscription failed, resubscribing", "err", err,
+				"retry_in", removalRetryInterval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(removalRetryInterval):
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(removalBatchInterval)
+	defer ticker.Stop()
+	pending := make(map[string]struct{})
+	for {
+		select {
+		case <-subscribed:
+			return
+		case key := <-removed:
+			pending[string(key)] = struct{}{}
+		case <-ticker.C:
+			if len(pending) == 0 {
+				continue
+			}
+			n := forgetRemoved(idx, backend, pending)
+			l.Debug("Applied removal notifications to the commitment index", "removed", len(pending), "indexed", n)
+			pending = make(map[string]struct{})
+		}
+	}
+}
+
+// forgetRemoved ... removes backend from the index entries whose keccak256 backend key was removed, and returns how
+// many entries were updated. Keys of commitments that aren't indexed are ignored.
+func forgetRemoved(idx *index.Index, backend string, removed map[string]struct{}) int {
+	n := 0
+	for _, k := range idx.BackendKeys(backend) {
+		if _, ok := removed[string(crypto.Keccak256(k))]; ok {
+			idx.RemoveBackend(k, backend)
+			n++
+		}
+	}
+	return n
+}
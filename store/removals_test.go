@@ -0,0 +1,76 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// watchedStore ... mapStore notifying of the keys sent to removals
+type watchedStore struct {
+	mapStore
+	removals chan []byte
+}
+
+func (w *watchedStore) WatchRemovals(ctx context.Context, fn func(key []byte)) error {
+	if w.removals == nil {
+		return ErrRemovalsUnsupported
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case key := <-w.removals:
+			fn(key)
+		}
+	}
+}
+
+func TestWatchRemovals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cache := &watchedStore{mapStore: mapStore{data: make(map[string][]byte)}, removals: make(chan []byte)}
+	r, err := NewRouter(&fakeDAStore{blob: []byte("blob")}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+		RouterOptions{})
+	require.NoError(t, err)
+	for _, c := range []string{"a", "b"} {
+		require.NoError(t, r.(*Router).handleRedundantWrites(ctx, []byte(c), []byte("blob"), nil).Err())
+	}
+	require.Equal(t, int64(8), r.Index().BackendBytes("Redis"))
+
+	done := make(chan struct{})
+	go func() {
+		WatchRemovals(ctx, r, log.New())
+		close(done)
+	}()
+
+	// objects of commitments that aren't indexed are ignored
+	cache.removals <- crypto.Keccak256([]byte("a"))
+	cache.removals <- crypto.Keccak256([]byte("unknown"))
+	require.Eventually(t, func() bool {
+		e, _ := r.Index().Get([]byte("a"))
+		return len(e.Backends) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	e, ok := r.Index().Get([]byte("b"))
+	require.True(t, ok)
+	require.Contains(t, e.Backends, "Redis")
+	require.Equal(t, int64(4), r.Index().BackendBytes("Redis"))
+
+	cancel()
+	<-done
+}
+
+func TestWatchRemovalsUnsupported(t *testing.T) {
+	cache := &watchedStore{mapStore: mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{}, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// returns right away since the only target doesn't notify of removals
+	WatchRemovals(context.Background(), r, log.New())
+}
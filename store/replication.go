@@ -0,0 +1,191 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// putRedundant ... writes a dispersed blob to a secondary backend. A failed write isn't retried inline, which would
+// hold up the PUT for the backoff, but persisted to the dead-letter queue right away, from which RetryDeadLetters
+// re-drives it in the background, or the admin API on demand. The outcome is accounted in the write summaries of
+// the backend.
+func (r *Router) putRedundant(ctx context.Context, src PrecomputedKeyStore, commitment, key, value []byte) WriteOutcome {
+	opCtx, done := r.startOp(ctx, src, opWrite)
+	err := src.Put(opCtx, key, r.seal(value))
+	done(err)
+
+	o := WriteOutcome{Backend: src.BackendType(), Status: WriteSucceeded, Attempts: 1}
+	if err == nil {
+		r.index.AddBackend(commitment, len(value), src.BackendType().String())
+		r.recordQuotaUtilization(src)
+	} else {
+		o.Status, o.Err = WriteDeadLettered, err
+		if dlErr := r.deadLetters.Add(deadletter.KindReplication, src.BackendType().String(), commitment, 1,
+			[]error{err}); dlErr != nil {
+			r.log.Error("Failed to add task to dead-letter queue", "backend", src.BackendType(), "err", dlErr)
+			o.Status = WriteFailed
+		}
+	}
+	r.writes.record(commitment, o)
+	return o
+}
+
+// deadLetterWrite ... accounts a write that wasn't attempted on a backend that is unavailable, persisting it to the
+// dead-letter queue so that it can be re-driven once the backend recovered
+func (r *Router) deadLetterWrite(src PrecomputedKeyStore, commitment []byte, err error) WriteOutcome {
+	status := WriteDeadLettered
+	if dlErr := r.deadLetters.Add(deadletter.KindReplication, src.BackendType().String(), commitment, 0,
+		[]error{err}); dlErr != nil {
+		r.log.Error("Failed to add task to dead-letter queue", "backend", src.BackendType(), "err", dlErr)
+		status = WriteFailed
+	}
+	o := WriteOutcome{Backend: src.BackendType(), Status: status, Err: err}
+	r.writes.record(commitment, o)
+	return o
+}
+
+// skipWrite ... accounts a write that wasn't attempted on a backend, e.g. because its quota is exhausted
+func (r *Router) skipWrite(src PrecomputedKeyStore, commitment []byte, err error) WriteOutcome {
+	o := WriteOutcome{Backend: src.BackendType(), Status: WriteSkipped, Err: err}
+	r.writes.record(commitment, o)
+	return o
+}
+
+// Redrive ... retries a dead-lettered replication task. The blob is re-fetched from EigenDA using the
+// task's commitment, verified, and written to the task's backend. The task is removed on success, which is
+// accounted as a retried write, and the failure is appended to its error history otherwise. A blob that can no
+// longer be retrieved from EigenDA isn't re-dispersed: re-dispersing it would yield a new cert, under which the
+// rollup, holding the original commitment, never reads it.
+func (r *Router) Redrive(ctx context.Context, id string) error {
+	task, err := r.deadLetters.Get(id)
+	if err != nil {
+		return err
+	}
+	if task.Kind != deadletter.KindReplication {
+		return fmt.Errorf("unsupported dead-letter task kind %s", task.Kind)
+	}
+
+	var target PrecomputedKeyStore
+	for _, targets := range [][]PrecomputedKeyStore{r.Caches(), r.Fallbacks()} {
+		for _, s := range targets {
+			if s.BackendType().String() == task.Backend {
+				target = s
+			}
+		}
+	}
+	// tasks of writes to requested sinks, which needn't be cache or fallback targets
+	if target == nil {
+		for b, s := range r.sinks {
+			if b.String() == task.Backend {
+				target = s
+			}
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("backend %s of dead-letter task %s is not configured", task.Backend, id)
+	}
+
+	err = r.redrive(ctx, target, task.Commitment)
+	if err != nil {
+		if addErr := r.deadLetters.Add(task.Kind, task.Backend, task.Commitment, 1, []error{err}); addErr != nil {
+			r.log.Error("Failed to update dead-letter task", "id", id, "err", addErr)
+		}
+		return err
+	}
+
+	o := WriteOutcome{Backend: target.BackendType(), Status: WriteRetried, Attempts: task.Attempts + 1}
+	if len(task.Errors) > 0 {
+		o.Err = errors.New(task.Errors[len(task.Errors)-1].Error)
+	}
+	r.writes.record(task.Commitment, o)
+	return r.deadLetters.Remove(id)
+}
+
+// RetryDeadLetters ... re-drives the dead-lettered tasks of the router in the background every interval, until ctx
+// is done. A task whose re-drive fails again is retried after an exponential backoff, starting at interval and
+// capped at maxBackoff. The number of outstanding tasks of each backend is recorded after every round.
+func RetryDeadLetters(ctx context.Context, r IRouter, interval, maxBackoff time.Duration, m metrics.Metricer,
+	l log.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// backends outstanding tasks were recorded for, reset once their tasks are drained
+	reported := make(map[string]bool)
+	for _, s := range append(append([]PrecomputedKeyStore{}, r.Caches()...), r.Fallbacks()...) {
+		reported[s.BackendType().String()] = true
+	}
+	recordDeadLetters(r.DeadLetters(), reported, m)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, task := range r.DeadLetters().Due(time.Now()) {
+			err := r.Redrive(ctx, task.ID)
+			if ctx.Err() != nil {
+				return
+			}
+			m.RecordDeadLetterRetry(task.Backend, err == nil)
+			if err == nil {
+				l.Info("Re-drove dead-lettered write", "id", task.ID, "backend", task.Backend)
+				continue
+			}
+			l.Warn("Failed to re-drive dead-lettered write", "id", task.ID, "backend", task.Backend,
+				"retries", task.Retries+1, "err", err)
+			if err := r.DeadLetters().Backoff(task.ID, interval, maxBackoff); err != nil {
+				l.Error("Failed to update dead-letter task", "id", task.ID, "err", err)
+			}
+		}
+		recordDeadLetters(r.DeadLetters(), reported, m)
+	}
+}
+
+// recordDeadLetters ... records the number of outstanding tasks of each backend of the queue, and of the reported
+// backends, which are added to
+func recordDeadLetters(q *deadletter.Queue, reported map[string]bool, m metrics.Metricer) {
+	outstanding := make(map[string]int)
+	for backend := range reported {
+		outstanding[backend] = 0
+	}
+	for _, task := range q.List() {
+		outstanding[task.Backend]++
+		reported[task.Backend] = true
+	}
+	for backend, n := range outstanding {
+		m.RecordDeadLetters(backend, n)
+	}
+}
+
+func (r *Router) redrive(ctx context.Context, target PrecomputedKeyStore, commitment []byte) error {
+	if r.eigenda == nil {
+		return fmt.Errorf("no EigenDA backend configured to re-fetch the blob from")
+	}
+
+	data, err := r.eigenda.Get(ctx, commitment)
+	if err != nil {
+		return fmt.Errorf("failed to re-fetch blob from EigenDA: %w", err)
+	}
+	if err := r.verify(ctx, r.eigenda, commitment, data); err != nil {
+		return fmt.Errorf("failed to verify re-fetched blob: %w", err)
+	}
+
+	if err := r.reserveQuota(ctx, target, len(data)); err != nil {
+		return err
+	}
+	if err := target.Put(ctx, crypto.Keccak256(commitment), r.seal(data)); err != nil {
+		return err
+	}
+	r.index.AddBackend(commitment, len(data), target.BackendType().String())
+	r.recordQuotaUtilization(target)
+	return nil
+}
@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore ... mapStore whose writes fail until failing is unset
+type failingStore struct {
+	mapStore
+	failing bool
+}
+
+func (f *failingStore) Put(ctx context.Context, key []byte, value []byte) error {
+	if f.failing {
+		return errors.New("connection refused")
+	}
+	return f.mapStore.Put(ctx, key, value)
+}
+
+func TestDeadLetterRedrive(t *testing.T) {
+	ctx := context.Background()
+	commitment, blob := []byte("commitment"), []byte("blob")
+
+	cache := &failingStore{mapStore: mapStore{data: make(map[string][]byte)}, failing: true}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	require.Error(t, r.(*Router).handleRedundantWrites(ctx, commitment, blob, nil).Err())
+
+	// the write isn't retried inline, but dead-lettered right away
+	tasks := r.DeadLetters().List()
+	require.Len(t, tasks, 1)
+	require.Equal(t, 1, tasks[0].Attempts)
+
+	// re-driving while the backend is still down keeps the task around
+	require.Error(t, r.Redrive(ctx, tasks[0].ID))
+	require.Equal(t, 1, r.DeadLetters().Len())
+
+	cache.failing = false
+	require.NoError(t, r.Redrive(ctx, tasks[0].ID))
+	require.Equal(t, 0, r.DeadLetters().Len())
+	require.Len(t, cache.data, 1)
+
+	// the re-driven write is accounted as retried
+	summaries := r.WriteSummaries()
+	require.Equal(t, map[WriteStatus]int{WriteDeadLettered: 1, WriteRetried: 1}, summaries[0].Counts)
+	require.Equal(t, 3, summaries[0].Recent[0].Attempts)
+}
+
+func TestRetryDeadLetters(t *testing.T) {
+	m := metrics.NewMetrics("test")
+	commitment, blob := []byte("commitment"), []byte("blob")
+
+	cache := &failingStore{mapStore: mapStore{data: make(map[string][]byte)}, failing: true}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+		RouterOptions{Metrics: m})
+	require.NoError(t, err)
+	require.Error(t, r.(*Router).handleRedundantWrites(context.Background(), commitment, blob, nil).Err())
+	backend := cache.BackendType().String()
+
+	// runs the retry worker until cond holds
+	retryUntil := func(cond func() bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			RetryDeadLetters(ctx, r, 10*time.Millisecond, 40*time.Millisecond, m, log.New())
+		}()
+		require.Eventually(t, cond, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		<-done
+	}
+
+	// failed re-drives are backed off
+	retryUntil(func() bool {
+		tasks := r.DeadLetters().List()
+		return len(tasks) == 1 && tasks[0].Retries >= 3
+	})
+	task := r.DeadLetters().List()[0]
+	require.True(t, task.NextRetry.After(task.Errors[len(task.Errors)-1].Time))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DeadLetters.WithLabelValues(backend)))
+	require.GreaterOrEqual(t, testutil.ToFloat64(m.DeadLetterRetries.WithLabelValues(backend, "failure")), 3.0)
+
+	// once the backend recovered, the task is re-driven and removed
+	cache.failing = false
+	retryUntil(func() bool {
+		return r.DeadLetters().Len() == 0 && testutil.ToFloat64(m.DeadLetters.WithLabelValues(backend)) == 0
+	})
+	require.Len(t, cache.data, 1)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.DeadLetterRetries.WithLabelValues(backend, "success")))
+}
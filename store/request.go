@@ -0,0 +1,99 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RequestMeta ... request-scoped metadata, attached to the request context by the server and read by the router,
+// the stores and their verification, so that they don't need to be passed as parameters through every layer
+type RequestMeta struct {
+	// ID identifies the request in logs and crash reports
+	ID string
+	// Tenant the request is accounted to, writes are indexed as written by it
+	Tenant string
+	// Mode of the commitment the request reads or writes, empty until the server parsed it
+	Mode commitments.CommitmentMode
+	// Commitment is the encoded commitment read by the request as sent by the client, nil for writes
+	Commitment []byte
+	// Deadline set by the caller, zero if none
+	Deadline time.Time
+	// TTL is the retention hint of writes to secondary stores, 0 if none. Stores supporting expiry keep the blob
+	// for at most TTL, or for their configured retention if that is shorter.
+	TTL time.Duration
+	// CachePolicy is how reads use the cache and fallback targets
+	CachePolicy CachePolicy
+	// RollupHeight is the rollup block or batch number a write is posted for, nil if the client didn't supply it
+	RollupHeight *uint64
+	// L1Block is the L1 block a backfilled commitment was included in, nil if unknown
+	L1Block *uint64
+	// Redundancy lists the secondary backends a write must also be persisted to, on top of the cache and fallback
+	// targets
+	Redundancy []BackendType
+	// Chunks of the payload read by the request if it was split across several blobs, nil otherwise
+	Chunks []commitments.EnvelopeChunk
+	// OnDispersal is called with the progress of the dispersals of a write, nil if nobody is watching
+	OnDispersal func(DispersalState)
+}
+
+type requestMetaKey struct{}
+
+// WithRequestMeta ... returns a child context carrying the request metadata m
+func WithRequestMeta(ctx context.Context, m RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, m)
+}
+
+// RequestMetaFromContext ... returns the request metadata of the context, zero if none is set
+func RequestMetaFromContext(ctx context.Context) RequestMeta {
+	m, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return m
+}
+
+// updateRequestMeta ... returns a child context carrying the request metadata of ctx as modified by update
+func updateRequestMeta(ctx context.Context, update func(*RequestMeta)) context.Context {
+	m := RequestMetaFromContext(ctx)
+	update(&m)
+	return WithRequestMeta(ctx, m)
+}
+
+// WithCommitmentMode ... returns a child context whose request reads or writes a commitment of mode
+func WithCommitmentMode(ctx context.Context, mode commitments.CommitmentMode) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Mode = mode })
+}
+
+// WithCallerDeadline ... returns a child context whose request must be served before the deadline set by its
+// caller. The deadline is only recorded, it is applied by deriving a context with context.WithDeadline.
+func WithCallerDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Deadline = deadline })
+}
+
+// LogAttrs ... returns the set fields of the metadata as log key-value pairs, so that every layer labels its
+// logs of a request the same way
+func (m RequestMeta) LogAttrs() []any {
+	var attrs []any
+	if m.ID != "" {
+		attrs = append(attrs, "request_id", m.ID)
+	}
+	if m.Tenant != "" {
+		attrs = append(attrs, "tenant", m.Tenant)
+	}
+	if m.Mode != "" {
+		attrs = append(attrs, "commitment_mode", string(m.Mode))
+	}
+	if !m.Deadline.IsZero() {
+		attrs = append(attrs, "deadline", m.Deadline.Format(time.RFC3339Nano))
+	}
+	return attrs
+}
+
+// RequestLogger ... returns l labeled with the request metadata of ctx
+func RequestLogger(ctx context.Context, l log.Logger) log.Logger {
+	attrs := RequestMetaFromContext(ctx).LogAttrs()
+	if len(attrs) == 0 {
+		return l
+	}
+	return l.With(attrs...)
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMeta(t *testing.T) {
+	deadline := time.Unix(1700000000, 0).UTC()
+
+	ctx := WithRequestMeta(context.Background(), RequestMeta{ID: "req-1", Tenant: "rollup-a"})
+	ctx = WithCommitmentMode(ctx, commitments.OptimismGeneric)
+	ctx = WithCallerDeadline(ctx, deadline)
+	ctx = WithTTL(ctx, time.Hour)
+	ctx = WithCachePolicy(ctx, CachePolicyBypass)
+
+	// updates keep the fields set earlier
+	require.Equal(t, RequestMeta{
+		ID:          "req-1",
+		Tenant:      "rollup-a",
+		Mode:        commitments.OptimismGeneric,
+		Deadline:    deadline,
+		TTL:         time.Hour,
+		CachePolicy: CachePolicyBypass,
+	}, RequestMetaFromContext(ctx))
+	require.Equal(t, "rollup-a", TenantFromContext(ctx))
+	require.Equal(t, time.Hour, TTLFromContext(ctx))
+	require.Equal(t, CachePolicyBypass, cachePolicyFromContext(ctx))
+
+	require.Equal(t, []any{"request_id", "req-1", "tenant", "rollup-a", "commitment_mode", "optimism_generic",
+		"deadline", "2023-11-14T22:13:20Z"}, RequestMetaFromContext(ctx).LogAttrs())
+
+	// contexts without metadata carry the zero value
+	require.Equal(t, RequestMeta{}, RequestMetaFromContext(context.Background()))
+	require.Empty(t, RequestMeta{}.LogAttrs())
+}
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RouteStep ... store a read would be sent to, see Router.PlanRead
+type RouteStep struct {
+	// Order is the position of the step in the read, steps of the same order are read in parallel. Skipped steps
+	// have no order.
+	Order   int    `json:"order,omitempty"`
+	Backend string `json:"backend"`
+	// Role is primary, keccak256, cache, fallback or pipeline
+	Role string `json:"role"`
+	// Key is the hex encoded key the store is read with, i.e. the keccak256 hash of the cert for secondary stores
+	Key string `json:"key,omitempty"`
+	// When describes what starts the read of the store
+	When    string       `json:"when,omitempty"`
+	Breaker BreakerState `json:"breaker,omitempty"`
+	// Skipped is why the store wouldn't be read, empty if it would
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// RoutePlan ... routing decision of a read, as planned by Router.PlanRead
+type RoutePlan struct {
+	Mode        commitments.CommitmentMode `json:"mode"`
+	CachePolicy CachePolicy                `json:"cache_policy,omitempty"`
+	// PrefixRoute is the hex encoded prefix of the route the commitment matches, if any
+	PrefixRoute string `json:"prefix_route,omitempty"`
+	// Strategy is keccak256, prefix-route, sequential, read-slo or hedged
+	Strategy  string      `json:"strategy"`
+	Coalesced bool        `json:"coalesced,omitempty"`
+	Steps     []RouteStep `json:"steps"`
+	// Degraded lists the reasons the router is degraded, see Router.Degraded
+	Degraded string `json:"degraded,omitempty"`
+}
+
+// PlanRead ... returns the stores a Get of the commitment would read, in order and along with the state of their
+// circuit breakers, without reading them. The plan follows the cache policy and encoded commitment of the request
+// like Get, and is that of a non-streamed read.
+func (r *Router) PlanRead(ctx context.Context, key []byte, cm commitments.CommitmentMode) (RoutePlan, error) {
+	plan := RoutePlan{
+		Mode:      cm,
+		Coalesced: r.coalesceReads,
+		Steps:     []RouteStep{},
+		Degraded:  r.Degraded(),
+	}
+	hashed := hex.EncodeToString(crypto.Keccak256(key))
+
+	switch cm {
+	case commitments.OptimismKeccak:
+		if r.keccak == nil {
+			return RoutePlan{}, errors.New("expected precomputed-key backend for OP keccak256 commitment type, but none configured")
+		}
+		plan.Strategy = "keccak256"
+		plan.Steps = append(plan.Steps, RouteStep{Order: 1, Backend: r.keccak.BackendType().String(), Role: "keccak256",
+			Key: hex.EncodeToString(key), When: "immediately"})
+		return plan, nil
+
+	case commitments.SimpleCommitmentMode, commitments.OptimismGeneric:
+		if r.eigenda == nil {
+			return RoutePlan{}, errors.New("expected EigenDA backend for DA commitment type, but none configured")
+		}
+
+	default:
+		return RoutePlan{}, errors.New(
+			"could not determine which storage backend to route to based on unknown commitment mode")
+	}
+
+	primary := RouteStep{Backend: r.eigenda.BackendType().String(), Role: "primary"}
+	order := 0
+	// next appends a step read after the previous ones, unless it is skipped
+	next := func(step RouteStep, when string) {
+		if step.Skipped == "" {
+			order++
+			step.Order, step.When = order, when
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	if route, ok := r.prefixRoute(ctx); ok {
+		plan.PrefixRoute = "0x" + hex.EncodeToString(route.Prefix)
+		if len(route.Pipeline) > 0 {
+			plan.Strategy = "prefix-route"
+			for i, s := range route.Pipeline {
+				step := RouteStep{Backend: s.BackendType().String(), Role: "pipeline"}
+				if s, ok := s.(PrecomputedKeyStore); ok {
+					step = r.planTarget(s, "pipeline", hashed)
+				}
+				next(step, whenNext(i == 0, "after the previous store failed"))
+			}
+			return plan, nil
+		}
+	}
+
+	r.cacheLock.RLock()
+	caches := append([]PrecomputedKeyStore(nil), r.caches...)
+	r.cacheLock.RUnlock()
+	r.fallbackLock.RLock()
+	fallbacks := append([]PrecomputedKeyStore(nil), r.fallbacks...)
+	r.fallbackLock.RUnlock()
+
+	plan.CachePolicy = cachePolicyFromContext(ctx)
+	if plan.CachePolicy != CachePolicyDefault {
+		plan.Strategy = "sequential"
+		for _, s := range caches {
+			plan.Steps = append(plan.Steps, r.planSkipped(s, "cache", hashed, plan.CachePolicy))
+		}
+		next(primary, "immediately")
+		for _, s := range fallbacks {
+			plan.Steps = append(plan.Steps, r.planSkipped(s, "fallback", hashed, plan.CachePolicy))
+		}
+		return plan, nil
+	}
+
+	// 1 - cache targets, in parallel with hedged reads
+	var primaryWhen string
+	switch {
+	case r.hedgeDelay > 0 && len(caches) > 0:
+		plan.Strategy = "hedged"
+		read := false
+		for _, s := range caches {
+			step := r.planTarget(s, "cache", hashed)
+			if step.Skipped == "" {
+				step.Order, step.When, read = 1, "immediately", true
+			}
+			plan.Steps = append(plan.Steps, step)
+		}
+		primaryWhen = "immediately"
+		if read {
+			order = 1
+			primaryWhen = fmt.Sprintf("after %s, or once every cache target missed", r.hedgeDelay)
+		}
+	default:
+		plan.Strategy = "sequential"
+		for _, s := range caches {
+			next(r.planTarget(s, "cache", hashed), whenNext(order == 0, "after the previous cache target missed"))
+		}
+		primaryWhen = whenNext(order == 0, "after every cache target missed")
+	}
+
+	// 2 - EigenDA
+	next(primary, primaryWhen)
+
+	// 3 - fallback targets, raced against EigenDA once it exceeds the read SLO
+	first := "after the EigenDA read failed"
+	if r.readSLO > 0 && len(fallbacks) > 0 {
+		if plan.Strategy == "sequential" {
+			plan.Strategy = "read-slo"
+		}
+		first = fmt.Sprintf("in parallel once the EigenDA read took longer than %s, or failed", r.readSLO)
+	}
+	for _, s := range fallbacks {
+		step := r.planTarget(s, "fallback", hashed)
+		next(step, first)
+		if step.Skipped == "" {
+			first = "after the previous fallback target failed"
+		}
+	}
+	return plan, nil
+}
+
+// planTarget ... returns the step reading the secondary store s, skipped if its role or circuit breaker keep it
+// from being read
+func (r *Router) planTarget(s PrecomputedKeyStore, role string, key string) RouteStep {
+	step := RouteStep{Backend: s.BackendType().String(), Role: role, Key: key}
+	state, allowed := r.breakers.peek(s)
+	step.Breaker = state
+	switch {
+	case !r.readable(s):
+		step.Skipped = "target is write-only"
+	case !allowed:
+		step.Skipped = fmt.Sprintf("circuit breaker is %s", state)
+	}
+	return step
+}
+
+// planSkipped ... returns the step of a secondary store skipped by the cache policy of the read
+func (r *Router) planSkipped(s PrecomputedKeyStore, role string, key string, policy CachePolicy) RouteStep {
+	step := r.planTarget(s, role, key)
+	step.Skipped = fmt.Sprintf("cache policy is %s", policy)
+	return step
+}
+
+func whenNext(first bool, after string) string {
+	if first {
+		return "immediately"
+	}
+	return after
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRead(t *testing.T) {
+	commitment := []byte("commitment")
+	redis := &downStore{mapStore: mapStore{data: make(map[string][]byte)}}
+	backup := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	da := &fakeDAStore{unavailable: true}
+
+	// order, backend and skip reason of each step
+	type step struct {
+		order   int
+		backend string
+		skipped string
+	}
+	steps := func(plan RoutePlan) []step {
+		var s []step
+		for _, st := range plan.Steps {
+			s = append(s, step{st.Order, st.Backend, st.Skipped})
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		opts     RouterOptions
+		ctx      context.Context
+		strategy string
+		steps    []step
+	}{
+		{name: "Sequential", strategy: "sequential",
+			steps: []step{{1, "Redis", ""}, {2, "EigenDA", ""}, {3, "S3", ""}}},
+		{name: "Hedged", opts: RouterOptions{HedgeDelay: time.Second, ReadSLO: time.Second}, strategy: "hedged",
+			steps: []step{{1, "Redis", ""}, {2, "EigenDA", ""}, {3, "S3", ""}}},
+		{name: "WriteOnlyCache", opts: RouterOptions{Roles: map[BackendType]TargetRole{RedisBackendType: TargetRoleWriteOnly}},
+			strategy: "sequential", steps: []step{{0, "Redis", "target is write-only"}, {1, "EigenDA", ""}, {2, "S3", ""}}},
+		{name: "PrefixRoute", opts: RouterOptions{PrefixRoutes: []PrefixRoute{{Prefix: []byte{1}, Pipeline: []Store{backup, da}}}},
+			ctx: WithCommitment(context.Background(), []byte{1, 0, 0}), strategy: "prefix-route",
+			steps: []step{{1, "S3", ""}, {2, "EigenDA", ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRouter(da, nil, log.New(), []PrecomputedKeyStore{redis}, []PrecomputedKeyStore{backup}, tt.opts)
+			require.NoError(t, err)
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			plan, err := r.(*Router).PlanRead(ctx, commitment, commitments.OptimismGeneric)
+			require.NoError(t, err)
+			require.Equal(t, tt.strategy, plan.Strategy)
+			require.Equal(t, tt.steps, steps(plan))
+		})
+	}
+
+	t.Run("OpenBreaker", func(t *testing.T) {
+		r, err := NewRouter(da, nil, log.New(), []PrecomputedKeyStore{redis}, []PrecomputedKeyStore{backup},
+			RouterOptions{Breakers: BreakerOptions{FailureThreshold: 1, OpenDuration: time.Minute, HalfOpenSuccesses: 1}})
+		require.NoError(t, err)
+		router := r.(*Router)
+		router.breakers.record(redis, errUnavailable)
+
+		// planning doesn't let a probe through
+		for i := 0; i < 2; i++ {
+			plan, err := router.PlanRead(context.Background(), commitment, commitments.OptimismGeneric)
+			require.NoError(t, err)
+			require.Equal(t, []step{{0, "Redis", "circuit breaker is open"}, {1, "EigenDA", ""}, {2, "S3", ""}},
+				steps(plan))
+			require.Equal(t, BreakerOpen, plan.Steps[0].Breaker)
+			require.Contains(t, plan.Degraded, "Redis (open)")
+		}
+	})
+}
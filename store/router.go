@@ -3,14 +3,23 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/Layr-Labs/eigenda-proxy/tracing"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
 )
 
 type IRouter interface {
@@ -18,113 +27,397 @@ type IRouter interface {
 	Put(ctx context.Context, cm commitments.CommitmentMode, key, value []byte) ([]byte, error)
 
 	GetEigenDAStore() GeneratedKeyStore
-	GetS3Store() PrecomputedKeyStore
+	GetKeccakStore() PrecomputedKeyStore
 	Caches() []PrecomputedKeyStore
 	Fallbacks() []PrecomputedKeyStore
+	Index() *index.Index
+	DeadLetters() *deadletter.Queue
+	Redrive(ctx context.Context, id string) error
+	Backfill(ctx context.Context, cm commitments.CommitmentMode, commitment []byte, populateCaches bool) error
+	Import(ctx context.Context, cm commitments.CommitmentMode, commitment []byte, size int, value []byte) error
+	Reconcile(ctx context.Context) []ReconcileReport
+	ReconcileReports() []ReconcileReport
+	Tier(ctx context.Context) TieringReport
+	WriteSummaries() []TargetWriteSummary
+	BackendHealth() []TargetHealth
 }
 
 // Router ... storage backend routing layer
 type Router struct {
 	log     log.Logger
 	eigenda GeneratedKeyStore
-	s3      PrecomputedKeyStore
+	// precomputed-key backend of keccak256 commitments, S3 unless configured otherwise
+	keccak PrecomputedKeyStore
 
 	caches    []PrecomputedKeyStore
 	cacheLock sync.RWMutex
 
 	fallbacks    []PrecomputedKeyStore
 	fallbackLock sync.RWMutex
+
+	index     *index.Index
+	quotas    map[BackendType]Quota
+	quotaLock sync.Mutex
+
+	deadLetters *deadletter.Queue
+
+	reconcileOpts    ReconcileOptions
+	reconcileLock    sync.Mutex
+	reconcileReports []ReconcileReport
+
+	tiering TieringOptions
+
+	coalesceReads bool
+	reads         singleflight.Group
+
+	refreshStaleCerts bool
+
+	// readSLO is the EigenDA read latency after which fallbacks are read in parallel, 0 if disabled
+	readSLO time.Duration
+	// hedgeDelay is the delay after which EigenDA is read while cache reads are in flight, 0 if disabled
+	hedgeDelay time.Duration
+
+	// prefixRoutes are ordered by descending prefix length
+	prefixRoutes []PrefixRoute
+
+	roles map[BackendType]TargetRole
+
+	// writes aggregates the outcomes of the writes to each cache and fallback target
+	writes *writeTracker
+
+	// envelope wraps the values written to cache and fallback targets with integrity metadata
+	envelope bool
+
+	// sinks are the configured secondary backends writes can request redundancy on, see WithRedundancy
+	sinks map[BackendType]PrecomputedKeyStore
+
+	degradation *degradation
+
+	// breakers skip cache and fallback targets that keep failing, nil if disabled
+	breakers *breakers
+
+	chunker *chunker
+
+	m metrics.Metricer
+}
+
+// RouterOptions ... optional router dependencies, zero values fall back to sensible defaults
+type RouterOptions struct {
+	// Index tracks the commitments served by the router, a new index is created if nil
+	Index *index.Index
+	// Quotas are per secondary backend byte quotas
+	Quotas map[BackendType]Quota
+	// DeadLetters receives redundant writes that exhausted their retries, an in-memory queue is used if nil
+	DeadLetters *deadletter.Queue
+	// Reconcile configures the periodic reconciliation of the index with the secondary backends
+	Reconcile ReconcileOptions
+	// Tiering configures the policy moving blobs between the cache targets, fallback targets and archive storage
+	Tiering TieringOptions
+	// CoalesceReads serves concurrent reads of the same commitment with a single backend fetch
+	CoalesceReads bool
+	// RefreshStaleCerts resolves blobs of EigenDA certs that fail to be read or verified via their current cert,
+	// in case their batch was re-confirmed. It requires the disperser request ID of the cert to be indexed, so
+	// other proxies reading the same cert fail to, which can diverge the derivation of rollup nodes.
+	RefreshStaleCerts bool
+	// ReadSLO is the EigenDA read latency after which the fallback targets are read in parallel, the first verified
+	// blob is served. Reads from EigenDA aren't raced if 0.
+	ReadSLO time.Duration
+	// HedgeDelay reads the cache targets in parallel, and EigenDA once no cache target served the blob within the
+	// delay, the first verified blob is served. Cache targets are read one after another if 0.
+	HedgeDelay time.Duration
+	// PrefixRoutes resolve reads of commitments with a given prefix through other stores than the default read path
+	PrefixRoutes []PrefixRoute
+	// Roles restricts cache and fallback targets to reads or writes, targets without a role are read and written
+	Roles map[BackendType]TargetRole
+	// Envelope wraps the values written to cache and fallback targets with their checksum, size and creation time,
+	// so that corrupt or truncated values are detected when read. Values are read whether wrapped or not.
+	Envelope bool
+	// Sinks are the configured secondary backends writes can request to also be persisted to, whether they are
+	// cache or fallback targets or not
+	Sinks []PrecomputedKeyStore
+	// Degradation switches writes into a degraded mode while dispersals keep failing
+	Degradation DegradationOptions
+	// Chunking splits payloads too large for a single blob into several blobs
+	Chunking ChunkingOptions
+	// Breakers skip cache and fallback targets, and sinks, that keep failing until they recover
+	Breakers BreakerOptions
+	Metrics  metrics.Metricer
 }
 
-func NewRouter(eigenda GeneratedKeyStore, s3 PrecomputedKeyStore, l log.Logger,
-	caches []PrecomputedKeyStore, fallbacks []PrecomputedKeyStore) (IRouter, error) {
-	return &Router{
-		log:          l,
-		eigenda:      eigenda,
-		s3:           s3,
-		caches:       caches,
-		cacheLock:    sync.RWMutex{},
-		fallbacks:    fallbacks,
-		fallbackLock: sync.RWMutex{},
-	}, nil
+func NewRouter(eigenda GeneratedKeyStore, keccakStore PrecomputedKeyStore, l log.Logger,
+	caches []PrecomputedKeyStore, fallbacks []PrecomputedKeyStore, opts RouterOptions) (IRouter, error) {
+	if opts.Index == nil {
+		opts.Index = index.New(index.DefaultMaxEntries)
+	}
+	if opts.Metrics == nil {
+		opts.Metrics = metrics.NoopMetrics
+	}
+	if opts.DeadLetters == nil {
+		opts.DeadLetters, _ = deadletter.New("")
+	}
+	caches, fallbacks = dedupeTargets(l, caches, fallbacks)
+
+	r := &Router{
+		log:               l,
+		eigenda:           eigenda,
+		keccak:            keccakStore,
+		caches:            caches,
+		cacheLock:         sync.RWMutex{},
+		fallbacks:         fallbacks,
+		fallbackLock:      sync.RWMutex{},
+		index:             opts.Index,
+		quotas:            opts.Quotas,
+		deadLetters:       opts.DeadLetters,
+		reconcileOpts:     opts.Reconcile,
+		tiering:           opts.Tiering,
+		coalesceReads:     opts.CoalesceReads,
+		refreshStaleCerts: opts.RefreshStaleCerts,
+		readSLO:           opts.ReadSLO,
+		hedgeDelay:        opts.HedgeDelay,
+		prefixRoutes:      sortPrefixRoutes(opts.PrefixRoutes),
+		roles:             opts.Roles,
+		envelope:          opts.Envelope,
+		writes:            newWriteTracker(),
+		sinks:             make(map[BackendType]PrecomputedKeyStore, len(opts.Sinks)),
+		m:                 opts.Metrics,
+	}
+	for _, s := range opts.Sinks {
+		r.sinks[s.BackendType()] = s
+	}
+	if opts.Degradation.Enabled() {
+		r.degradation = newDegradation(opts.Degradation, eigenda, opts.Metrics, l)
+	}
+	if opts.Chunking.Enabled() {
+		r.chunker = newChunker(opts.Chunking)
+	}
+	if opts.Breakers.Enabled() {
+		targets := append(append([]PrecomputedKeyStore{}, caches...), fallbacks...)
+		targets = append(targets, opts.Sinks...)
+		r.breakers = newBreakers(opts.Breakers, targets, opts.Metrics, l)
+	}
+	return r, nil
 }
 
-// Get ... fetches a value from a storage backend based on the (commitment mode, type)
+// Get ... fetches a value from a storage backend based on the (commitment mode, type). Concurrent reads of the
+// same commitment are coalesced into a single backend fetch if enabled.
 func (r *Router) Get(ctx context.Context, key []byte, cm commitments.CommitmentMode) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "Router.Get", trace.WithAttributes(commitmentModeAttr(cm)))
+	var data []byte
+	var err error
+	if chunks := RequestMetaFromContext(ctx).Chunks; len(chunks) > 0 {
+		data, err = r.getChunked(ctx, key, cm, chunks)
+	} else if r.coalesceReads {
+		data, err = r.coalescedGet(ctx, key, cm)
+	} else {
+		data, err = r.get(ctx, key, cm)
+	}
+	if err == nil {
+		r.index.Touch(key)
+	}
+	tracing.End(span, err)
+	return data, err
+}
+
+// get ... fetches a value from the storage backend of the commitment mode
+func (r *Router) get(ctx context.Context, key []byte, cm commitments.CommitmentMode) ([]byte, error) {
+	data, _, err := r.read(ctx, key, cm, false)
+	return data, err
+}
+
+// GetStreaming ... like Get, but returns a blob read from EigenDA before it is verified, together with the
+// function verifying it, so that the caller can serve the blob while it is verified. verify is nil if the
+// returned data was already verified, e.g. because it was read from a cache target. Reads aren't coalesced.
+func (r *Router) GetStreaming(ctx context.Context, key []byte, cm commitments.CommitmentMode) ([]byte, func() error,
+	error) {
+	ctx, span := tracer.Start(ctx, "Router.GetStreaming", trace.WithAttributes(commitmentModeAttr(cm)))
+	// chunks are verified as they are read, so that the payload can be reassembled
+	if chunks := RequestMetaFromContext(ctx).Chunks; len(chunks) > 0 {
+		data, err := r.getChunked(ctx, key, cm, chunks)
+		tracing.End(span, err)
+		return data, nil, err
+	}
+	data, verify, err := r.read(ctx, key, cm, true)
+	if err == nil {
+		r.index.Touch(key)
+	}
+	tracing.End(span, err)
+	return data, verify, err
+}
+
+// read ... fetches a value from the storage backend of the commitment mode. If deferVerify is set, the
+// verification of blobs read from EigenDA is returned instead of being run.
+func (r *Router) read(ctx context.Context, key []byte, cm commitments.CommitmentMode, deferVerify bool) ([]byte,
+	func() error, error) {
 	switch cm {
 	case commitments.OptimismKeccak:
 
-		if r.s3 == nil {
-			return nil, errors.New("expected S3 backend for OP keccak256 commitment type, but none configured")
+		if r.keccak == nil {
+			return nil, nil, errors.New("expected precomputed-key backend for OP keccak256 commitment type, but none configured")
 		}
 
-		r.log.Debug("Retrieving data from S3 backend")
-		value, err := r.s3.Get(ctx, key)
+		r.log.Debug("Retrieving data from keccak256 backend", "backend", r.keccak.BackendType())
+		opCtx, done := r.startOp(ctx, r.keccak, opRead)
+		value, err := r.keccak.Get(opCtx, key)
+		done(err)
+		if errors.Is(err, ErrArchived) {
+			r.restoreArchived(ctx, r.keccak, key)
+		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		err = r.s3.Verify(key, value)
+		err = verifyHashKey(r.keccak, key, value)
 		if err != nil {
-			return nil, err
+			r.recordVerificationFailure(r.keccak)
+			return nil, nil, err
 		}
-		return value, nil
+		recordServedBy(ctx, r.keccak, r.keccak)
+		return value, nil, nil
 
 	case commitments.SimpleCommitmentMode, commitments.OptimismGeneric:
 		if r.eigenda == nil {
-			return nil, errors.New("expected EigenDA backend for DA commitment type, but none configured")
+			return nil, nil, errors.New("expected EigenDA backend for DA commitment type, but none configured")
+		}
+
+		// commitments of a prefix route are only resolved by the stores of its pipeline
+		if pipeline, ok := r.prefixPipeline(ctx); ok {
+			data, err := r.readPipeline(ctx, key, pipeline)
+			return data, nil, err
+		}
+
+		policy := cachePolicyFromContext(ctx)
+
+		// hedged reads race the cache targets against EigenDA rather than reading them one after another
+		if r.hedgeDelay > 0 && r.cacheEnabled() && !deferVerify && policy == CachePolicyDefault {
+			data, err := r.hedgedReadAll(ctx, key)
+			return data, nil, err
 		}
 
 		// 1 - read blob from cache if enabled
-		if r.cacheEnabled() {
+		if r.cacheEnabled() && policy == CachePolicyDefault {
 			r.log.Debug("Retrieving data from cached backends")
 			data, err := r.multiSourceRead(ctx, key, false)
 			if err == nil {
-				return data, nil
+				return data, nil, nil
 			}
 
-			r.log.Warn("Failed to read from cache targets", "err", err)
+			RequestLogger(ctx, r.log).Warn("Failed to read from cache targets", "err", err)
 		}
 
-		// 2 - read blob from EigenDA
-		data, err := r.eigenda.Get(ctx, key)
+		// 2 - read blob from EigenDA, racing it against the fallbacks once it exceeds the read SLO if enabled
+		if r.readSLO > 0 && !deferVerify && r.fallbackEnabled() && policy == CachePolicyDefault {
+			data, err := r.raceRead(ctx, key)
+			return data, nil, err
+		}
+		data, cert, fallbackDecoded, err := r.getEigenDA(ctx, key)
 		if err == nil {
-			// verify
-			err = r.eigenda.Verify(key, data)
-			if err != nil {
-				return nil, err
+			recordServedBy(ctx, r.eigenda, r.eigenda)
+			recordQuorumPolicy(ctx, r.eigenda)
+			verify := func() error {
+				if fallbackDecoded {
+					return nil
+				}
+				if err := r.verifyCert(ctx, key, cert, data); err != nil {
+					return err
+				}
+				if policy == CachePolicyRefresh {
+					r.refreshCaches(ctx, key, data)
+				} else {
+					r.fillReadThroughCaches(ctx, key, data)
+				}
+				return nil
+			}
+			if deferVerify {
+				return data, verify, nil
 			}
-			return data, nil
+			if err := verify(); err != nil {
+				return nil, nil, err
+			}
+			return data, nil, nil
 		}
 
 		// 3 - read blob from fallbacks if enabled and data is non-retrievable from EigenDA
-		if r.fallbackEnabled() {
+		if r.fallbackEnabled() && policy == CachePolicyDefault {
 			data, err = r.multiSourceRead(ctx, key, true)
 			if err != nil {
-				r.log.Error("Failed to read from fallback targets", "err", err)
-				return nil, err
+				RequestLogger(ctx, r.log).Error("Failed to read from fallback targets", "err", err)
+				return nil, nil, err
 			}
+			r.fillReadThroughCaches(ctx, key, data)
 		} else {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return data, err
+		return data, nil, err
 
 	default:
-		return nil, errors.New("could not determine which storage backend to route to based on unknown commitment mode")
+		return nil, nil, errors.New("could not determine which storage backend to route to based on unknown commitment mode")
 	}
 }
 
+// getEigenDA ... reads the blob of a commitment from EigenDA, via the current cert of the commitment if its
+// batch was re-confirmed, and returns it along with the cert it was read with and whether it was decoded by a
+// fallback of the store, see RecordFallbackDecode, in which case it was verified as retrieved and must not be
+// verified again or cached
+func (r *Router) getEigenDA(ctx context.Context, key []byte) (data, cert []byte, fallbackDecoded bool, err error) {
+	cert = r.currentCert(key)
+	ctx, decoded := withFallbackDecode(ctx)
+	opCtx, done := r.startOp(ctx, r.eigenda, opRead)
+	data, err = r.eigenda.Get(opCtx, cert)
+	done(err)
+	if err != nil {
+		if refreshed, rerr := r.refreshCert(ctx, key, cert); rerr == nil {
+			cert = refreshed
+			opCtx, done = r.startOp(ctx, r.eigenda, opRead)
+			data, err = r.eigenda.Get(opCtx, cert)
+			done(err)
+		}
+	}
+	return data, cert, err == nil && decoded(), err
+}
+
 // Put ... inserts a value into a storage backend based on the commitment mode
 func (r *Router) Put(ctx context.Context, cm commitments.CommitmentMode, key, value []byte) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "Router.Put", trace.WithAttributes(commitmentModeAttr(cm),
+		attribute.Int("payload.size", len(value))))
+	commit, err := r.put(ctx, cm, key, value)
+	tracing.End(span, err)
+	return commit, err
+}
+
+func (r *Router) put(ctx context.Context, cm commitments.CommitmentMode, key, value []byte) ([]byte, error) {
 	var commit []byte
 	var err error
+	meta := ResponseMetaFromContext(ctx)
 
+	var sinks []PrecomputedKeyStore
 	switch cm {
 	case commitments.OptimismKeccak: // caching and fallbacks are unsupported for this commitment mode
+		if len(RequestMetaFromContext(ctx).Redundancy) > 0 {
+			return nil, fmt.Errorf("%w: keccak256 commitments are only written to their precomputed-key backend",
+				ErrInvalidRedundancy)
+		}
 		return r.putWithKey(ctx, key, value)
 	case commitments.OptimismGeneric, commitments.SimpleCommitmentMode:
-		commit, err = r.putWithoutKey(ctx, value)
+		// requested sinks are resolved before dispersing, so that invalid requests don't pay for a dispersal
+		sinks, err = r.redundancySinks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		// the disperser request ID of the blob is recorded into the response metadata to be indexed with the cert
+		if meta == nil {
+			ctx, meta = WithResponseMeta(ctx)
+		}
+		if r.degradation.active() {
+			return r.putDegraded(ctx, cm, value)
+		}
+		if r.chunker.splits(value) {
+			// the blob of the returned cert is the manifest listing the certs of the chunks
+			commit, value, err = r.putChunked(ctx, cm, value, sinks)
+		} else {
+			commit, err = r.putWithoutKey(ctx, value)
+		}
+		r.degradation.record(err)
 	default:
 		return nil, fmt.Errorf("unknown commitment mode")
 	}
@@ -132,23 +425,41 @@ func (r *Router) Put(ctx context.Context, cm commitments.CommitmentMode, key, va
 	if err != nil {
 		return nil, err
 	}
+	r.indexHeights(ctx, commit)
+	r.persist(ctx, cm, commit, value, sinks)
+	return commit, nil
+}
+
+// persist ... indexes the cert of a dispersed blob along with the request that wrote it, and writes the blob to
+// the redundant targets
+func (r *Router) persist(ctx context.Context, cm commitments.CommitmentMode, commit, value []byte,
+	sinks []PrecomputedKeyStore) {
+	r.indexCert(commit, len(value), cm)
+	if meta := ResponseMetaFromContext(ctx); meta != nil {
+		if id := meta.DispersalRequestID(); id != nil {
+			r.index.SetRequestID(commit, id)
+		}
+	}
+	if tenant := TenantFromContext(ctx); tenant != "" {
+		r.index.SetTenant(commit, tenant)
+	}
 
-	if r.cacheEnabled() || r.fallbackEnabled() {
-		err = r.handleRedundantWrites(ctx, commit, value)
+	if r.cacheEnabled() || r.fallbackEnabled() || len(sinks) > 0 {
+		err := r.handleRedundantWrites(ctx, commit, value, sinks).Err()
 		if err != nil {
-			log.Error("Failed to write to redundant backends", "err", err)
+			RequestLogger(ctx, r.log).Error("Failed to write to redundant backends", "err", err)
 		}
 	}
-
-	return commit, nil
 }
 
-// handleRedundantWrites ... writes to both sets of backends (i.e, fallback, cache)
-// and returns an error if NONE of them succeed
+// handleRedundantWrites ... writes to both sets of backends (i.e, fallback, cache), and to the sinks requested
+// by the write, and returns the outcome of the write on each of them. See WriteOutcomes.Err to check whether any
+// of them succeeded.
 // NOTE: multi-target set writes are done at once to avoid re-invocation of the same write function at the same
 // caller step for different target sets vs. reading which is done conditionally to segment between a cached read type
 // vs a fallback read type
-func (r *Router) handleRedundantWrites(ctx context.Context, commitment []byte, value []byte) error {
+func (r *Router) handleRedundantWrites(ctx context.Context, commitment []byte, value []byte,
+	sinks []PrecomputedKeyStore) WriteOutcomes {
 	r.cacheLock.RLock()
 	r.fallbackLock.RLock()
 
@@ -157,26 +468,43 @@ func (r *Router) handleRedundantWrites(ctx context.Context, commitment []byte, v
 		r.fallbackLock.RUnlock()
 	}()
 
-	sources := r.caches
+	// a new slice, appending to r.caches would write into its spare capacity, which is shared by concurrent writes
+	sources := make([]PrecomputedKeyStore, 0, len(r.caches)+len(r.fallbacks)+len(sinks))
+	sources = append(sources, r.caches...)
 	sources = append(sources, r.fallbacks...)
+	sources = append(sources, sinks...)
 
 	key := crypto.Keccak256(commitment)
-	successes := 0
+	var outcomes WriteOutcomes
 
 	for _, src := range sources {
-		err := src.Put(ctx, key, value)
-		if err != nil {
-			r.log.Warn("Failed to write to redundant target", "backend", src.BackendType(), "err", err)
-		} else {
-			successes++
+		if !r.writable(src) {
+			continue
+		}
+
+		if !r.breakers.allow(src) {
+			RequestLogger(ctx, r.log).Warn("Skipping write to unavailable redundant target", "backend", src.BackendType())
+			outcomes = append(outcomes, r.deadLetterWrite(src, commitment, ErrTargetUnavailable))
+			continue
+		}
+
+		if err := r.reserveQuota(ctx, src, len(value)); err != nil {
+			RequestLogger(ctx, r.log).Warn("Skipping write to redundant target", "backend", src.BackendType(), "err", err)
+			outcomes = append(outcomes, r.skipWrite(src, commitment, err))
+			continue
 		}
-	}
 
-	if successes == 0 {
-		return errors.New("failed to write blob to any redundant targets")
+		o := r.putRedundant(ctx, src, commitment, key, value)
+		outcomes = append(outcomes, o)
+		if !o.Succeeded() {
+			RequestLogger(ctx, r.log).Warn("Failed to write to redundant target", "backend", src.BackendType(),
+				"status", o.Status, "attempts", o.Attempts, "err", o.Err)
+			continue
+		}
+		recordAcknowledged(ctx, src.BackendType())
 	}
 
-	return nil
+	return outcomes
 }
 
 // multiSourceRead ... reads from a set of backends and returns the first successfully read blob
@@ -194,53 +522,153 @@ func (r *Router) multiSourceRead(ctx context.Context, commitment []byte, fallbac
 		sources = r.caches
 	}
 
-	key := crypto.Keccak256(commitment)
+	archived := false
 	for _, src := range sources {
-		data, err := src.Get(ctx, key)
-		if err != nil {
-			r.log.Warn("Failed to read from redundant target", "backend", src.BackendType(), "err", err)
+		if !r.readable(src) || !r.breakers.allow(src) {
 			continue
 		}
 
-		if data == nil {
-			r.log.Debug("No data found in redundant target", "backend", src.BackendType())
+		data, err := r.readTarget(ctx, src, commitment, fallback)
+		if errors.Is(err, ErrArchived) {
+			archived = true
 			continue
 		}
-
-		// verify cert:data using EigenDA verification checks
-		err = r.eigenda.Verify(commitment, data)
 		if err != nil {
-			log.Warn("Failed to verify blob", "err", err, "backend", src.BackendType())
+			r.logTargetReadError(ctx, src, err)
 			continue
 		}
-
 		return data, nil
 	}
+	if archived {
+		return nil, fmt.Errorf("no data found in any redundant backend, restoring the archived copy: %w", ErrArchived)
+	}
 	return nil, errors.New("no data found in any redundant backend")
 }
 
+// errNoData ... returned by readTarget for targets that don't hold the blob
+var errNoData = errors.New("no data found")
+
+// readTarget ... reads the blob of a commitment from a cache or fallback target, and verifies it against the cert
+func (r *Router) readTarget(ctx context.Context, src PrecomputedKeyStore, commitment []byte, fallback bool) ([]byte,
+	error) {
+	opCtx, done := r.startOp(ctx, src, opRead)
+	data, err := src.Get(opCtx, crypto.Keccak256(commitment))
+	done(err)
+	if errors.Is(err, ErrArchived) {
+		// the blob can be read once restored, so that a retry of the read is served
+		r.restoreArchived(ctx, src, crypto.Keccak256(commitment))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errNoData
+	}
+	if data, err = openSealed(src, data); err != nil {
+		return nil, err
+	}
+
+	// verify cert:data using EigenDA verification checks, i.e. against the cert's KZG commitment and
+	// batch metadata, rather than only the keccak256 key of the target
+	err = r.verify(ctx, src, r.currentCert(commitment), data)
+	if fallback {
+		r.m.RecordFallbackRead(src.BackendType().String(), err == nil)
+	}
+	if err != nil {
+		r.recordVerificationFailure(src)
+		return nil, fmt.Errorf("failed to verify blob: %w", err)
+	}
+
+	recordServedBy(ctx, src, r.eigenda)
+	recordQuorumPolicy(ctx, r.eigenda)
+	return data, nil
+}
+
+// logTargetReadError ... logs a failed read of a cache or fallback target
+func (r *Router) logTargetReadError(ctx context.Context, src PrecomputedKeyStore, err error) {
+	if errors.Is(err, errNoData) {
+		r.log.Debug("No data found in redundant target", "backend", src.BackendType())
+		return
+	}
+	RequestLogger(ctx, r.log).Warn("Failed to read from redundant target", "backend", src.BackendType(), "err", err)
+}
+
+// fillReadThroughCaches ... writes a blob read from EigenDA or a fallback target to the cache targets which
+// are populated on reads
+func (r *Router) fillReadThroughCaches(ctx context.Context, commitment []byte, value []byte) {
+	r.cacheLock.RLock()
+	defer r.cacheLock.RUnlock()
+
+	key := crypto.Keccak256(commitment)
+	for _, c := range r.caches {
+		if rt, ok := c.(ReadThroughCache); !ok || !rt.ReadThrough() || !r.writable(c) || !r.breakers.allow(c) {
+			continue
+		}
+		err := c.Put(ctx, key, r.seal(value))
+		r.breakers.record(c, err)
+		if err != nil {
+			RequestLogger(ctx, r.log).Warn("Failed to populate read-through cache", "backend", c.BackendType(), "err", err)
+		}
+	}
+}
+
 // putWithoutKey ... inserts a value into a storage backend that computes the key on-demand (i.e, EigenDA)
 func (r *Router) putWithoutKey(ctx context.Context, value []byte) ([]byte, error) {
 	if r.eigenda != nil {
 		r.log.Debug("Storing data to EigenDA backend")
-		return r.eigenda.Put(ctx, value)
+		opCtx, done := r.startOp(ctx, r.eigenda, opWrite)
+		commit, err := r.eigenda.Put(opCtx, value)
+		done(err)
+		if err != nil {
+			r.m.RecordDispersalError(dispersalErrorReason(err))
+			return nil, err
+		}
+		recordServedBy(ctx, r.eigenda, r.eigenda)
+		recordAcknowledged(ctx, r.eigenda.BackendType())
+		return commit, nil
 	}
 
 	return nil, errors.New("no DA storage backend found")
 }
 
-// putWithKey ... only supported for S3 storage backends using OP's alt-da keccak256 commitment type
+// putWithKey ... inserts a value into the precomputed-key backend of OP's alt-da keccak256 commitment type. The key
+// is computed from the value if nil.
 func (r *Router) putWithKey(ctx context.Context, key []byte, value []byte) ([]byte, error) {
-	if r.s3 == nil {
-		return nil, errors.New("S3 is disabled but is only supported for posting known commitment keys")
+	if r.keccak == nil {
+		return nil, errors.New("no precomputed-key backend is configured for keccak256 commitments")
+	}
+	if key == nil {
+		key = HashAlgorithmOf(r.keccak).Sum(value)
+	}
+
+	err := verifyHashKey(r.keccak, key, value)
+	if err != nil {
+		return nil, err
 	}
 
-	err := r.s3.Verify(key, value)
+	opCtx, done := r.startOp(ctx, r.keccak, opWrite)
+	err = r.keccak.Put(opCtx, key, value)
+	done(err)
 	if err != nil {
 		return nil, err
 	}
+	r.index.Put(key, len(value), string(commitments.OptimismKeccak))
+	if tenant := TenantFromContext(ctx); tenant != "" {
+		r.index.SetTenant(key, tenant)
+	}
+	r.indexHeights(ctx, key)
+	recordServedBy(ctx, r.keccak, r.keccak)
+	recordAcknowledged(ctx, r.keccak.BackendType())
+	return key, nil
+}
 
-	return key, r.s3.Put(ctx, key, value)
+// verifyHashKey ... ensures that key is the hash of value, as the precomputed-key backend s verifies it. The hash is
+// checked here since some backends, e.g. Redis, don't verify their keys.
+func verifyHashKey(s PrecomputedKeyStore, key, value []byte) error {
+	if !bytes.Equal(HashAlgorithmOf(s).Sum(value), key) {
+		return fmt.Errorf("key does not match the %s hash of the value", HashAlgorithmOf(s))
+	}
+	return s.Verify(key, value)
 }
 
 func (r *Router) fallbackEnabled() bool {
@@ -256,9 +684,9 @@ func (r *Router) GetEigenDAStore() GeneratedKeyStore {
 	return r.eigenda
 }
 
-// GetS3Store ...
-func (r *Router) GetS3Store() PrecomputedKeyStore {
-	return r.s3
+// GetKeccakStore ... returns the precomputed-key backend of keccak256 commitments, S3 unless configured otherwise
+func (r *Router) GetKeccakStore() PrecomputedKeyStore {
+	return r.keccak
 }
 
 // Caches ...
@@ -270,3 +698,13 @@ func (r *Router) Caches() []PrecomputedKeyStore {
 func (r *Router) Fallbacks() []PrecomputedKeyStore {
 	return r.fallbacks
 }
+
+// Index ...
+func (r *Router) Index() *index.Index {
+	return r.index
+}
+
+// DeadLetters ...
+func (r *Router) DeadLetters() *deadletter.Queue {
+	return r.deadLetters
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// readThroughStore ... mapStore populated on reads
+type readThroughStore struct {
+	mapStore
+}
+
+func (s *readThroughStore) ReadThrough() bool { return true }
+
+func TestReadThroughCaches(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+	commitment := []byte("a")
+
+	cache := &mapStore{data: make(map[string][]byte)}
+	readThrough := &readThroughStore{mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(),
+		[]PrecomputedKeyStore{cache, readThrough}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	got, err := r.Get(ctx, commitment, commitments.OptimismGeneric)
+	require.NoError(t, err)
+	require.Equal(t, blob, got)
+
+	// only the read-through cache is populated by reads
+	require.Empty(t, cache.data)
+	require.Equal(t, blob, readThrough.data[string(crypto.Keccak256(commitment))])
+}
+
+func TestFallbackDecodedBlobs(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+	commitment := []byte("a")
+
+	// the payload of a fallback decoded blob doesn't re-encode to the blob, so it fails Verify
+	readThrough := &readThroughStore{mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{blob: blob, fallbackDecoded: true, verifyErr: errors.New("invalid commitment")},
+		nil, log.New(), []PrecomputedKeyStore{readThrough}, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// it's served as verified by the store, but not cached
+	got, err := r.Get(ctx, commitment, commitments.OptimismGeneric)
+	require.NoError(t, err)
+	require.Equal(t, blob, got)
+	require.Empty(t, readThrough.data)
+}
+
+func TestKeccakBackend(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	// the Redis mapStore doesn't verify its keys, the router does
+	backend := &mapStore{data: make(map[string][]byte)}
+	r, err := NewRouter(nil, backend, log.New(), nil, nil, RouterOptions{})
+	require.NoError(t, err)
+
+	// keys are computed from the payload unless provided
+	key, err := r.Put(ctx, commitments.OptimismKeccak, nil, blob)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256(blob), key)
+	require.Equal(t, blob, backend.data[string(key)])
+	data, err := r.Get(ctx, key, commitments.OptimismKeccak)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+
+	_, err = r.Put(ctx, commitments.OptimismKeccak, crypto.Keccak256([]byte("other")), blob)
+	require.ErrorContains(t, err, "key does not match")
+	backend.data[string(key)] = []byte("corrupt")
+	_, err = r.Get(ctx, key, commitments.OptimismKeccak)
+	require.ErrorContains(t, err, "key does not match")
+}
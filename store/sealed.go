@@ -0,0 +1,27 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/envelope"
+)
+
+// seal ... wraps a value written to a cache or fallback target in an envelope carrying its checksum and size, if
+// enabled
+func (r *Router) seal(value []byte) []byte {
+	if !r.envelope {
+		return value
+	}
+	return envelope.Seal(value, time.Now())
+}
+
+// openSealed ... unwraps a value read from a cache or fallback target, failing if its envelope shows it was
+// corrupted or truncated. Values written without an envelope are returned as is.
+func openSealed(s PrecomputedKeyStore, data []byte) ([]byte, error) {
+	value, err := envelope.Open(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value in %s backend: %w", s.BackendType(), err)
+	}
+	return value, nil
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/store/envelope"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	ctx := context.Background()
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	cache := &mapStore{data: make(map[string][]byte)}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache}, nil,
+		RouterOptions{Envelope: true})
+	require.NoError(t, err)
+
+	require.NoError(t, r.(*Router).handleRedundantWrites(ctx, commitment, blob, nil).Err())
+	require.True(t, envelope.IsSealed(cache.data[key]))
+	data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+
+	// the static EigenDA store accepts any blob, only the envelope reveals that the cached blob is truncated
+	sealed := cache.data[key]
+	cache.data[key] = append(append([]byte(nil), sealed[:len(sealed)-1]...), 'X')
+	ctx, rm := WithResponseMeta(ctx)
+	data, err = r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+	require.Equal(t, EigenDABackendType, rm.Backend())
+
+	// blobs written before envelopes were enabled are still served
+	cache.data[key] = []byte("legacy")
+	data, err = r.Get(context.Background(), commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, []byte("legacy"), data)
+}
@@ -13,6 +13,10 @@ const (
 	MemoryBackendType
 	S3BackendType
 	RedisBackendType
+	FSBackendType
+	LocalBackendType
+	GCSBackendType
+	AzureBackendType
 
 	Unknown
 )
@@ -20,6 +24,12 @@ const (
 var (
 	ErrProxyOversizedBlob   = fmt.Errorf("encoded blob is larger than max blob size")
 	ErrEigenDAOversizedBlob = fmt.Errorf("blob size cannot exceed")
+	ErrRemovalsUnsupported  = fmt.Errorf("removal notifications are not enabled for the store")
+	// ErrKeyNotFound is wrapped by the errors of stores that report missing keys as errors rather than nil values
+	ErrKeyNotFound = fmt.Errorf("key not found")
+	// ErrArchived is wrapped by the errors of stores whose copy of a key was archived and must be restored before
+	// it can be read, see Archiver
+	ErrArchived = fmt.Errorf("value is archived")
 )
 
 func (b BackendType) String() string {
@@ -32,6 +42,14 @@ func (b BackendType) String() string {
 		return "S3"
 	case RedisBackendType:
 		return "Redis"
+	case FSBackendType:
+		return "FS"
+	case LocalBackendType:
+		return "Local"
+	case GCSBackendType:
+		return "GCS"
+	case AzureBackendType:
+		return "Azure"
 	case Unknown:
 		fallthrough
 	default:
@@ -51,6 +69,14 @@ func StringToBackendType(s string) BackendType {
 		return S3BackendType
 	case "redis":
 		return RedisBackendType
+	case "fs":
+		return FSBackendType
+	case "local":
+		return LocalBackendType
+	case "gcs":
+		return GCSBackendType
+	case "azure":
+		return AzureBackendType
 	case "unknown":
 		fallthrough
 	default:
@@ -58,7 +84,8 @@ func StringToBackendType(s string) BackendType {
 	}
 }
 
-// Used for E2E tests
+// Used for E2E tests. Stores count them at all times, which is cheap enough to not need a flag, while detailed
+// per-op timings and object sizes are only recorded during a profiling session, see Profiler.
 type Stats struct {
 	Entries int
 	Reads   int
@@ -88,3 +115,67 @@ type PrecomputedKeyStore interface {
 	// Put inserts the given value into the key-value data store.
 	Put(ctx context.Context, key []byte, value []byte) error
 }
+
+// Deleter is implemented by precomputed key stores that support removing entries, e.g. to enforce quotas.
+type Deleter interface {
+	// Delete removes the given key from the key-value data store. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key []byte) error
+}
+
+// Exister is implemented by precomputed key stores that can check for a key without reading its value.
+type Exister interface {
+	// Exists returns whether the given key is present in the key-value data store.
+	Exists(ctx context.Context, key []byte) (bool, error)
+}
+
+// Lister is implemented by precomputed key stores that can enumerate their keys.
+type Lister interface {
+	// Keys calls fn with every key of the key-value data store, stopping at the first error returned by fn.
+	Keys(ctx context.Context, fn func(key []byte) error) error
+}
+
+// Archiver is implemented by precomputed key stores that can move entries to a cheaper archive storage class,
+// whose entries can't be read until they are restored.
+type Archiver interface {
+	// Archive moves the given key to the archive storage class.
+	Archive(ctx context.Context, key []byte) error
+	// Restore starts restoring the given archived key. Restores are asynchronous, the key can be read once its
+	// restore completed. Restoring a key whose restore is in progress is not an error.
+	Restore(ctx context.Context, key []byte) error
+}
+
+// Pinger is implemented by stores backed by a remote dependency, so that their connections can be kept warm.
+type Pinger interface {
+	// Ping performs a cheap round trip to the remote dependency over the store's existing connections.
+	Ping(ctx context.Context) error
+}
+
+// Reconnector is implemented by stores that can replace their connections, e.g. after a failed Ping.
+type Reconnector interface {
+	// Reconnect discards the store's existing connections and establishes new ones. Stores using an HTTP client
+	// drop the idle connections of its pool, so that the next request dials a new one instead of reusing a
+	// connection that may have been silently dropped by a NAT gateway.
+	Reconnect(ctx context.Context) error
+}
+
+// RemovalWatcher is implemented by precomputed key stores that can be notified of keys removed by other clients,
+// e.g. expired by bucket lifecycle rules.
+type RemovalWatcher interface {
+	// WatchRemovals calls fn with every key removed from the key-value data store until ctx is done or the
+	// subscription fails. It returns ErrRemovalsUnsupported if notifications aren't enabled for the store.
+	WatchRemovals(ctx context.Context, fn func(key []byte)) error
+}
+
+// CertRefresher is implemented by EigenDA stores that can look up the current cert of a blob, e.g. after the
+// disperser re-submitted the batch the blob was confirmed in and the original cert no longer verifies.
+type CertRefresher interface {
+	// RefreshCert returns the current cert of the blob of cert, which was dispersed with requestID.
+	RefreshCert(ctx context.Context, cert []byte, requestID []byte) ([]byte, error)
+}
+
+// ReadThroughCache is implemented by cache targets that are also populated with blobs read from EigenDA or the
+// fallback targets, e.g. local caches of read-heavy nodes which never write blobs through the proxy.
+type ReadThroughCache interface {
+	// ReadThrough returns whether blobs read from other backends should be written to the cache.
+	ReadThrough() bool
+}
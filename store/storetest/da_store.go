@@ -0,0 +1,46 @@
+package storetest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+)
+
+// DAStore ... configurable GeneratedKeyStore standing in for EigenDA in tests of the packages built on the router.
+// It serves Blob for every cert and verifies every blob, unless VerifyErr is set, and reports Info as the network
+// info, unless InfoErr is set.
+// NOTE: tests of the store package itself can't import storetest, they use its fakeDAStore instead.
+type DAStore struct {
+	Blob      []byte
+	VerifyErr error
+	Info      store.NetworkInfo
+	InfoErr   error
+
+	mu          sync.Mutex
+	infoFetches int
+}
+
+func (s *DAStore) Get(context.Context, []byte) ([]byte, error) { return s.Blob, nil }
+func (s *DAStore) Put(context.Context, []byte) ([]byte, error) { return nil, nil }
+func (s *DAStore) Verify([]byte, []byte) error                 { return s.VerifyErr }
+func (s *DAStore) BackendType() store.BackendType              { return store.EigenDABackendType }
+func (s *DAStore) Stats() *store.Stats                         { return &store.Stats{} }
+
+// NetworkInfo ... implements store.NetworkInfoReporter
+func (s *DAStore) NetworkInfo(context.Context) (store.NetworkInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.infoFetches++
+	if s.InfoErr != nil {
+		return store.NetworkInfo{}, s.InfoErr
+	}
+	return s.Info, nil
+}
+
+// InfoFetches ... number of times the network info was requested
+func (s *DAStore) InfoFetches() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.infoFetches
+}
@@ -0,0 +1,309 @@
+package storetest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	mrand "math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// LargeValueSize ... size of the value written by the large value test. Every backend is expected to
+	// accept values of at least this size.
+	LargeValueSize = 256 * 1024
+
+	// ttl ... expiry requested from factories by the TTL test
+	ttl = 500 * time.Millisecond
+	// expiryTimeout ... how long the TTL test waits for an expired value to disappear
+	expiryTimeout = 10 * time.Second
+)
+
+// Factory ... constructs a precomputed key store for a single test. ttl is the expiry the store should be
+// configured with, zero meaning entries never expire; factories of backends that don't support expiry
+// should call t.Skip when ttl is non zero. Stores may share state across tests since the suite only
+// uses random values.
+type Factory func(t *testing.T, ttl time.Duration) store.PrecomputedKeyStore
+
+// GeneratedKeyFactory ... same as Factory, for generated key stores
+type GeneratedKeyFactory func(t *testing.T, ttl time.Duration) store.GeneratedKeyStore
+
+// BinaryValues ... payloads that backends commonly mangle when treating values as text, e.g. by
+// trimming line endings, stopping at NUL bytes or re-encoding invalid UTF-8
+func BinaryValues() map[string][]byte {
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+
+	return map[string][]byte{
+		"all byte values":    all,
+		"nul bytes":          {0x00, 0x00, 0x01, 0x00},
+		"trailing crlf":      []byte("payload\r\n"),
+		"leading whitespace": []byte(" \t\r\npayload"),
+		"http framing":       []byte("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n"),
+		"invalid utf8":       {0xff, 0xfe, 0xc3, 0x28, 0xa0, 0xa1},
+		"utf8 bom":           {0xef, 0xbb, 0xbf, 'h', 'i'},
+		"rlp like":           {0xf8, 0x4c, 0x80, 0x84, 0x01, 0x02, 0x03, 0x04},
+		"commitment like":    {0x01, 0x00, 0x00, 0xde, 0xad, 0xbe, 0xef},
+		"html like":          []byte("<!DOCTYPE html><script>alert(1)</script>"),
+		"gzip magic":         {0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00},
+	}
+}
+
+// RandomValue ... returns size random bytes
+func RandomValue(t *testing.T, size int) []byte {
+	b := make([]byte, size)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
+
+// Payload ... random binary payload for property based tests. Generated payloads splice the patterns of
+// BinaryValues into random bytes, since uniformly random bytes rarely contain line endings or
+// signature-like prefixes at the positions where they get mangled.
+type Payload []byte
+
+// maxPayloadSize ... upper bound on the size of generated payloads
+const maxPayloadSize = 4096
+
+// Generate ... implements quick.Generator
+func (Payload) Generate(r *mrand.Rand, _ int) reflect.Value {
+	patterns := make([][]byte, 0, len(BinaryValues()))
+	for _, v := range BinaryValues() {
+		patterns = append(patterns, v)
+	}
+
+	size := r.Intn(maxPayloadSize) + 1
+	p := make([]byte, 0, size)
+	for len(p) < size {
+		if r.Intn(2) == 0 {
+			p = append(p, patterns[r.Intn(len(patterns))]...)
+			continue
+		}
+		chunk := make([]byte, r.Intn(size-len(p))+1)
+		_, _ = r.Read(chunk)
+		p = append(p, chunk...)
+	}
+	return reflect.ValueOf(Payload(p))
+}
+
+// QuickConfig ... configuration used by the property based round-trip tests
+func QuickConfig() *quick.Config {
+	return &quick.Config{MaxCount: 50}
+}
+
+// requireMissing ... stores are allowed to report missing keys either by returning no data or an error
+func requireMissing(t *testing.T, value []byte, err error) {
+	if err == nil {
+		require.Nil(t, value, "expected no data for a missing key")
+	}
+}
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// RunConformanceTests ... runs the behaviour every precomputed key store (S3, Redis, FS, or a third party
+// backend) must provide for the router to use it as a cache or fallback target.
+func RunConformanceTests(t *testing.T, factory Factory) {
+	putGet := func(t *testing.T, s store.PrecomputedKeyStore, value []byte) {
+		ctx := context.Background()
+		key := crypto.Keccak256(value)
+
+		require.NoError(t, s.Put(ctx, key, value))
+		got, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(value, got), "value was not returned byte for byte")
+	}
+
+	t.Run("PutGet", func(t *testing.T) {
+		putGet(t, factory(t, 0), RandomValue(t, 1024))
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		s := factory(t, 0)
+		value, err := s.Get(context.Background(), crypto.Keccak256(RandomValue(t, 32)))
+		requireMissing(t, value, err)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		s := factory(t, 0)
+		value := RandomValue(t, 1024)
+		putGet(t, s, value)
+		putGet(t, s, value)
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		s := factory(t, 0)
+		value := RandomValue(t, 1024)
+		key := crypto.Keccak256(value)
+
+		require.NoError(t, s.Verify(key, value))
+		if vr, ok := s.(store.VerificationReporter); ok && vr.VerificationMode() == store.VerificationKeccak256 {
+			require.Error(t, s.Verify(key, RandomValue(t, 1024)))
+		}
+	})
+
+	t.Run("LargeValue", func(t *testing.T) {
+		putGet(t, factory(t, 0), RandomValue(t, LargeValueSize))
+	})
+
+	t.Run("BinarySafety", func(t *testing.T) {
+		s := factory(t, 0)
+		for name, value := range BinaryValues() {
+			value := value
+			t.Run(name, func(t *testing.T) {
+				putGet(t, s, value)
+			})
+		}
+	})
+
+	t.Run("RandomRoundTrips", func(t *testing.T) {
+		s := factory(t, 0)
+		roundTrip := func(p Payload) bool {
+			ctx := context.Background()
+			key := crypto.Keccak256(p)
+			if err := s.Put(ctx, key, p); err != nil {
+				t.Logf("put failed: %v", err)
+				return false
+			}
+			got, err := s.Get(ctx, key)
+			if err != nil {
+				t.Logf("get failed: %v", err)
+				return false
+			}
+			return bytes.Equal(p, got)
+		}
+		require.NoError(t, quick.Check(roundTrip, QuickConfig()))
+	})
+
+	t.Run("Cancellation", func(t *testing.T) {
+		s := factory(t, 0)
+		value := RandomValue(t, 1024)
+		key := crypto.Keccak256(value)
+
+		require.Error(t, s.Put(cancelledContext(), key, value))
+		got, err := s.Get(context.Background(), key)
+		requireMissing(t, got, err)
+
+		require.NoError(t, s.Put(context.Background(), key, value))
+		_, err = s.Get(cancelledContext(), key)
+		require.Error(t, err)
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		s := factory(t, ttl)
+		value := RandomValue(t, 1024)
+		putGet(t, s, value)
+
+		key := crypto.Keccak256(value)
+		require.Eventually(t, func() bool {
+			got, err := s.Get(context.Background(), key)
+			return err != nil || got == nil
+		}, expiryTimeout, ttl/10, "value did not expire")
+	})
+}
+
+// RunGeneratedKeyConformanceTests ... runs the behaviour every generated key store (EigenDA, memstore, or a
+// third party backend) must provide for the router to use it as the primary backend.
+func RunGeneratedKeyConformanceTests(t *testing.T, factory GeneratedKeyFactory) {
+	putGet := func(t *testing.T, s store.GeneratedKeyStore, value []byte) []byte {
+		ctx := context.Background()
+
+		key, err := s.Put(ctx, value)
+		require.NoError(t, err)
+		require.NotEmpty(t, key)
+
+		got, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(value, got), "value was not returned byte for byte")
+		return key
+	}
+
+	t.Run("PutGet", func(t *testing.T) {
+		putGet(t, factory(t, 0), RandomValue(t, 1024))
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		s := factory(t, 0)
+		value, err := s.Get(context.Background(), RandomValue(t, 32))
+		requireMissing(t, value, err)
+	})
+
+	t.Run("DistinctKeys", func(t *testing.T) {
+		s := factory(t, 0)
+		value := RandomValue(t, 1024)
+		require.NotEqual(t, putGet(t, s, value), putGet(t, s, RandomValue(t, 1024)))
+	})
+
+	t.Run("Verify", func(t *testing.T) {
+		s := factory(t, 0)
+		value := RandomValue(t, 1024)
+		key := putGet(t, s, value)
+		require.NoError(t, s.Verify(key, value))
+	})
+
+	t.Run("LargeValue", func(t *testing.T) {
+		putGet(t, factory(t, 0), RandomValue(t, LargeValueSize))
+	})
+
+	t.Run("BinarySafety", func(t *testing.T) {
+		s := factory(t, 0)
+		for name, value := range BinaryValues() {
+			value := value
+			t.Run(name, func(t *testing.T) {
+				putGet(t, s, value)
+			})
+		}
+	})
+
+	t.Run("RandomRoundTrips", func(t *testing.T) {
+		s := factory(t, 0)
+		roundTrip := func(p Payload) bool {
+			ctx := context.Background()
+			key, err := s.Put(ctx, p)
+			if err != nil {
+				t.Logf("put failed: %v", err)
+				return false
+			}
+			got, err := s.Get(ctx, key)
+			if err != nil {
+				t.Logf("get failed: %v", err)
+				return false
+			}
+			return bytes.Equal(p, got)
+		}
+		require.NoError(t, quick.Check(roundTrip, QuickConfig()))
+	})
+
+	t.Run("Cancellation", func(t *testing.T) {
+		s := factory(t, 0)
+
+		_, err := s.Put(cancelledContext(), RandomValue(t, 1024))
+		require.Error(t, err)
+
+		key := putGet(t, s, RandomValue(t, 1024))
+		_, err = s.Get(cancelledContext(), key)
+		require.Error(t, err)
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		s := factory(t, ttl)
+		key := putGet(t, s, RandomValue(t, 1024))
+
+		require.Eventually(t, func() bool {
+			got, err := s.Get(context.Background(), key)
+			return err != nil || got == nil
+		}, expiryTimeout, ttl/10, "value did not expire")
+	})
+}
@@ -0,0 +1,35 @@
+package store
+
+import "fmt"
+
+// TargetRole ... whether the router reads from, writes to, or both, a cache or fallback target
+type TargetRole string
+
+const (
+	// TargetRoleReadWrite ... the target is read from and written to, the default
+	TargetRoleReadWrite TargetRole = "read-write"
+	// TargetRoleReadOnly ... the target is only read from, e.g. a shared cache the proxy must not pollute
+	TargetRoleReadOnly TargetRole = "read-only"
+	// TargetRoleWriteOnly ... the target is only written to, e.g. a backup sink that isn't meant to serve reads
+	TargetRoleWriteOnly TargetRole = "write-only"
+)
+
+// ParseTargetRole ... parses a target role
+func ParseTargetRole(s string) (TargetRole, error) {
+	switch r := TargetRole(s); r {
+	case TargetRoleReadWrite, TargetRoleReadOnly, TargetRoleWriteOnly:
+		return r, nil
+	default:
+		return "", fmt.Errorf("unknown target role: %q", s)
+	}
+}
+
+// readable ... returns whether blobs may be read from the target s
+func (r *Router) readable(s PrecomputedKeyStore) bool {
+	return r.roles[s.BackendType()] != TargetRoleWriteOnly
+}
+
+// writable ... returns whether blobs may be written to the target s
+func (r *Router) writable(s PrecomputedKeyStore) bool {
+	return r.roles[s.BackendType()] != TargetRoleReadOnly
+}
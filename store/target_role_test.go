@@ -0,0 +1,55 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// s3MapStore ... mapStore reporting the S3 backend type, to be assigned a role other than the Redis mapStore's
+type s3MapStore struct {
+	mapStore
+}
+
+func (s *s3MapStore) BackendType() BackendType { return S3BackendType }
+
+func TestTargetRoles(t *testing.T) {
+	ctx := context.Background()
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	shared := &mapStore{data: make(map[string][]byte)}
+	backup := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{shared, backup}, nil,
+		RouterOptions{Roles: map[BackendType]TargetRole{
+			RedisBackendType: TargetRoleReadOnly,
+			S3BackendType:    TargetRoleWriteOnly,
+		}})
+	require.NoError(t, err)
+
+	// writes skip the read-only target
+	require.NoError(t, r.(*Router).handleRedundantWrites(ctx, commitment, blob, nil).Err())
+	require.Empty(t, shared.data)
+	require.Equal(t, blob, backup.data[key])
+
+	// reads skip the write-only target
+	backup.data[key] = []byte("corrupt")
+	data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+
+	shared.data[key] = []byte("shared")
+	data, err = r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, []byte("shared"), data)
+
+	// refreshing caches leaves the read-only target untouched as well
+	_, err = r.Get(WithCachePolicy(ctx, CachePolicyRefresh), commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, []byte("shared"), shared.data[key])
+	require.Equal(t, blob, backup.data[key])
+}
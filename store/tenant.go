@@ -0,0 +1,13 @@
+package store
+
+import "context"
+
+// WithTenant ... returns a child context whose writes are indexed as written by tenant
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.Tenant = tenant })
+}
+
+// TenantFromContext ... returns the tenant of the context, empty if none is set
+func TenantFromContext(ctx context.Context) string {
+	return RequestMetaFromContext(ctx).Tenant
+}
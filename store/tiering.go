@@ -0,0 +1,339 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// archiveTier ... tier label of the archived copies held by fallback targets implementing Archiver
+	archiveTier = "archive"
+	// tieringPageSize ... number of index entries examined at a time when looking for blobs to move
+	tieringPageSize = 256
+	// maxTieringErrors ... number of errors kept per report
+	maxTieringErrors = 10
+)
+
+// TieringOptions ... policy moving blobs between the storage tiers of the router based on their age and reads, as
+// recorded by the commitment index. Blobs move from the cache targets to the fallback targets once they weren't
+// read for DemoteAfter, and the copies held by fallback targets that can archive them, e.g. S3, are moved to the
+// archive storage class once they weren't read for ArchiveAfter. Blobs held by fallback targets only that were read
+// at least PromoteReads times since they were last moved are copied back to the cache targets. Zero values disable
+// the respective move, tiering is disabled if Interval is 0.
+type TieringOptions struct {
+	Interval     time.Duration
+	DemoteAfter  time.Duration
+	ArchiveAfter time.Duration
+	PromoteReads int
+	// BatchSize is the maximum number of blobs moved per tier and run
+	BatchSize int
+}
+
+// Enabled ... whether blobs are moved between tiers
+func (o TieringOptions) Enabled() bool {
+	return o.Interval > 0
+}
+
+// Check ... validates the tiering options
+func (o TieringOptions) Check() error {
+	if o.Interval < 0 {
+		return fmt.Errorf("tiering interval must not be negative")
+	}
+	if !o.Enabled() {
+		return nil
+	}
+	if o.DemoteAfter < 0 || o.ArchiveAfter < 0 || o.PromoteReads < 0 {
+		return fmt.Errorf("tiering thresholds must not be negative")
+	}
+	if o.DemoteAfter == 0 && o.ArchiveAfter == 0 && o.PromoteReads == 0 {
+		return fmt.Errorf("tiering requires a demotion or archive age or a promotion read count")
+	}
+	if o.BatchSize < 1 {
+		return fmt.Errorf("tiering batch size must be positive")
+	}
+	return nil
+}
+
+// TieringReport ... outcome of a run of the tiering policy
+type TieringReport struct {
+	At       time.Time `json:"at"`
+	Demoted  int       `json:"demoted"`
+	Archived int       `json:"archived"`
+	Promoted int       `json:"promoted"`
+	Errors   []string  `json:"errors,omitempty"`
+}
+
+func (rep *TieringReport) fail(err error) {
+	if len(rep.Errors) < maxTieringErrors {
+		rep.Errors = append(rep.Errors, err.Error())
+	}
+}
+
+// Tier ... runs the tiering policy once, demoting idle blobs from the cache targets to the fallback targets,
+// archiving idle copies of the fallback targets and promoting frequently read blobs back to the cache targets, then
+// records the number of blobs held by each tier. Read-only targets are neither moved from nor to.
+func (r *Router) Tier(ctx context.Context) TieringReport {
+	r.cacheLock.RLock()
+	caches := append([]PrecomputedKeyStore(nil), r.caches...)
+	r.cacheLock.RUnlock()
+	r.fallbackLock.RLock()
+	fallbacks := append([]PrecomputedKeyStore(nil), r.fallbacks...)
+	r.fallbackLock.RUnlock()
+
+	caches, fallbacks = r.writableTargets(caches), r.writableTargets(fallbacks)
+	rep := TieringReport{At: time.Now()}
+	if r.tiering.DemoteAfter > 0 && len(fallbacks) > 0 {
+		for _, c := range caches {
+			r.demote(ctx, c, fallbacks, &rep)
+		}
+	}
+	if r.tiering.ArchiveAfter > 0 {
+		for _, f := range fallbacks {
+			if a, ok := f.(Archiver); ok {
+				r.archive(ctx, f, a, &rep)
+			}
+		}
+	}
+	if r.tiering.PromoteReads > 0 && len(caches) > 0 {
+		r.promote(ctx, caches, fallbacks, &rep)
+	}
+	r.recordTiers(append(caches, fallbacks...))
+
+	if len(rep.Errors) > 0 {
+		r.log.Warn("Failed to move blobs between tiers", "demoted", rep.Demoted, "archived", rep.Archived,
+			"promoted", rep.Promoted, "errors", len(rep.Errors), "err", rep.Errors[0])
+	} else if rep.Demoted > 0 || rep.Archived > 0 || rep.Promoted > 0 {
+		r.log.Info("Moved blobs between tiers", "demoted", rep.Demoted, "archived", rep.Archived,
+			"promoted", rep.Promoted)
+	}
+	return rep
+}
+
+// RunTiering ... runs the tiering policy of the router every interval until ctx is done
+func RunTiering(ctx context.Context, r IRouter, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Tier(ctx)
+		}
+	}
+}
+
+// demote ... moves the blobs of cache target c that weren't read for the demotion age to the fallback targets,
+// writing them to the first fallback target unless one already holds them. The index may claim copies that were
+// since deleted behind the proxy's back, so the copy of the holding target is checked before the cache copy is
+// deleted, and written again if it is gone.
+func (r *Router) demote(ctx context.Context, c PrecomputedKeyStore, fallbacks []PrecomputedKeyStore,
+	rep *TieringReport) {
+	deleter, ok := c.(Deleter)
+	if !ok || !r.breakers.allow(c) {
+		return
+	}
+	backend := c.BackendType().String()
+	f := index.Filter{Backend: backend, IdleBefore: time.Now().Add(-r.tiering.DemoteAfter)}
+	r.eachTierCandidate(ctx, f, func(ctx context.Context, e index.Entry) bool {
+		key := crypto.Keccak256(e.Key)
+		dst := holder(e, fallbacks)
+		copied := false
+		if dst != nil {
+			if !r.breakers.allow(dst) {
+				rep.fail(fmt.Errorf("failed to demote blob from %s: %w", backend, ErrTargetUnavailable))
+				return false
+			}
+			opCtx, done := r.startOp(ctx, dst, opRead)
+			ok, err := exists(opCtx, dst, key)
+			done(err)
+			if err != nil {
+				rep.fail(fmt.Errorf("failed to check demoted blob in %s: %w", dst.BackendType(), err))
+				return false
+			}
+			if !ok {
+				r.index.RemoveBackend(e.Key, dst.BackendType().String())
+				r.recordQuotaUtilization(dst)
+			}
+			copied = ok
+		} else {
+			dst = fallbacks[0]
+		}
+		if !copied {
+			if err := r.copyBlob(ctx, c, dst, e); err != nil {
+				rep.fail(fmt.Errorf("failed to demote blob from %s: %w", backend, err))
+				return false
+			}
+		}
+		if err := deleter.Delete(ctx, key); err != nil {
+			rep.fail(fmt.Errorf("failed to delete demoted blob from %s: %w", backend, err))
+			return false
+		}
+		r.index.RemoveBackend(e.Key, backend)
+		r.index.ResetReads(e.Key)
+		r.recordQuotaUtilization(c)
+		r.m.RecordTierMove(backend, dst.BackendType().String())
+		rep.Demoted++
+		return true
+	})
+}
+
+// archive ... moves the copies of fallback target f that weren't read for the archive age to its archive storage
+// class
+func (r *Router) archive(ctx context.Context, f PrecomputedKeyStore, a Archiver, rep *TieringReport) {
+	if !r.breakers.allow(f) {
+		return
+	}
+	backend := f.BackendType().String()
+	filter := index.Filter{Backend: backend, IdleBefore: time.Now().Add(-r.tiering.ArchiveAfter)}
+	r.eachTierCandidate(ctx, filter, func(ctx context.Context, e index.Entry) bool {
+		if _, ok := e.Archived[backend]; ok {
+			return false
+		}
+		if err := a.Archive(ctx, crypto.Keccak256(e.Key)); err != nil {
+			rep.fail(fmt.Errorf("failed to archive blob in %s: %w", backend, err))
+			return false
+		}
+		r.index.SetArchived(e.Key, backend, true)
+		r.index.ResetReads(e.Key)
+		r.m.RecordTierMove(backend, archiveTier)
+		rep.Archived++
+		return true
+	})
+}
+
+// promote ... copies the blobs held by fallback targets only that were read at least the promotion read count to
+// every cache target. Archived copies are restored rather than read, and promoted by a later run once readable.
+func (r *Router) promote(ctx context.Context, caches, fallbacks []PrecomputedKeyStore, rep *TieringReport) {
+	r.eachTierCandidate(ctx, index.Filter{MinReads: r.tiering.PromoteReads}, func(ctx context.Context, e index.Entry) bool {
+		if holder(e, caches) != nil {
+			return false
+		}
+		src := holder(e, fallbacks)
+		if src == nil {
+			return false
+		}
+		promoted := false
+		for _, c := range caches {
+			err := r.copyBlob(ctx, src, c, e)
+			if errors.Is(err, ErrArchived) {
+				r.restoreArchived(ctx, src, crypto.Keccak256(e.Key))
+				return false
+			}
+			if err != nil {
+				rep.fail(fmt.Errorf("failed to promote blob to %s: %w", c.BackendType(), err))
+				continue
+			}
+			r.m.RecordTierMove(src.BackendType().String(), c.BackendType().String())
+			promoted = true
+		}
+		if !promoted {
+			return false
+		}
+		r.index.ResetReads(e.Key)
+		rep.Promoted++
+		return true
+	})
+}
+
+// eachTierCandidate ... calls move with the entries matching f, oldest first, until it moved a batch of them. The
+// context move is called with carries the tenant that wrote the entry, whose backends may store it apart.
+func (r *Router) eachTierCandidate(ctx context.Context, f index.Filter,
+	move func(ctx context.Context, e index.Entry) bool) {
+	moved := 0
+	for {
+		entries := r.index.List(f, tieringPageSize)
+		if len(entries) == 0 {
+			return
+		}
+		for _, e := range entries {
+			if move(WithTenant(ctx, e.Tenant), e) {
+				moved++
+			}
+			if moved >= r.tiering.BatchSize {
+				return
+			}
+		}
+		f.Since = entries[len(entries)-1].Seq
+	}
+}
+
+// copyBlob ... copies the stored value of an indexed blob from src to dst as is, and records dst as holding it
+func (r *Router) copyBlob(ctx context.Context, src, dst PrecomputedKeyStore, e index.Entry) error {
+	if !r.breakers.allow(src) || !r.breakers.allow(dst) {
+		return ErrTargetUnavailable
+	}
+	key := crypto.Keccak256(e.Key)
+	opCtx, done := r.startOp(ctx, src, opRead)
+	value, err := src.Get(opCtx, key)
+	done(err)
+	if err != nil {
+		return err
+	}
+	if value == nil {
+		return fmt.Errorf("%s doesn't hold the blob: %w", src.BackendType(), ErrKeyNotFound)
+	}
+	if err := r.reserveQuota(ctx, dst, e.Size); err != nil {
+		return err
+	}
+	opCtx, done = r.startOp(ctx, dst, opWrite)
+	err = dst.Put(opCtx, key, value)
+	done(err)
+	if err != nil {
+		return err
+	}
+	r.index.AddBackend(e.Key, e.Size, dst.BackendType().String())
+	r.recordQuotaUtilization(dst)
+	return nil
+}
+
+// restoreArchived ... starts restoring the archived copy of the blob stored by s under key, so that a later read
+// succeeds
+func (r *Router) restoreArchived(ctx context.Context, s PrecomputedKeyStore, key []byte) {
+	a, ok := s.(Archiver)
+	if !ok {
+		return
+	}
+	if err := a.Restore(ctx, key); err != nil {
+		RequestLogger(ctx, r.log).Warn("Failed to restore archived blob", "backend", s.BackendType(), "err", err)
+		return
+	}
+	RequestLogger(ctx, r.log).Info("Restoring archived blob", "backend", s.BackendType())
+}
+
+// recordTiers ... records the number of indexed blobs held by each of the targets, archived or not
+func (r *Router) recordTiers(targets []PrecomputedKeyStore) {
+	counts := r.index.TierCounts()
+	for _, s := range targets {
+		backend := s.BackendType().String()
+		c := counts[backend]
+		r.m.RecordTierBlobs(backend, false, c.Blobs-c.Archived)
+		r.m.RecordTierBlobs(backend, true, c.Archived)
+	}
+}
+
+// writableTargets ... the targets blobs can be moved to and from
+func (r *Router) writableTargets(targets []PrecomputedKeyStore) []PrecomputedKeyStore {
+	var writable []PrecomputedKeyStore
+	for _, s := range targets {
+		if r.readable(s) && r.writable(s) {
+			writable = append(writable, s)
+		}
+	}
+	return writable
+}
+
+// holder ... the first of the targets the entry is recorded as held by, nil if none
+func holder(e index.Entry, targets []PrecomputedKeyStore) PrecomputedKeyStore {
+	for _, s := range targets {
+		if _, ok := e.Backends[s.BackendType().String()]; ok {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/Layr-Labs/eigenda-proxy/store/index"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// archivingStore ... s3MapStore whose archived keys can't be read until they are restored
+type archivingStore struct {
+	s3MapStore
+	archived map[string]bool
+	restored map[string]bool
+}
+
+func (s *archivingStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	if s.archived[string(key)] && !s.restored[string(key)] {
+		return nil, ErrArchived
+	}
+	return s.s3MapStore.Get(ctx, key)
+}
+
+func (s *archivingStore) Archive(_ context.Context, key []byte) error {
+	s.archived[string(key)] = true
+	return nil
+}
+
+func (s *archivingStore) Restore(_ context.Context, key []byte) error {
+	s.restored[string(key)] = true
+	return nil
+}
+
+func TestTiering(t *testing.T) {
+	ctx := context.Background()
+	m := metrics.NewMetrics("test")
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	cache := &mapStore{data: make(map[string][]byte)}
+	fallback := &archivingStore{
+		s3MapStore: s3MapStore{mapStore{data: make(map[string][]byte)}},
+		archived:   make(map[string]bool),
+		restored:   make(map[string]bool),
+	}
+	idx := index.New(0)
+	opts := TieringOptions{Interval: time.Minute, DemoteAfter: time.Hour, PromoteReads: 2, BatchSize: 10}
+	newRouter := func(opts TieringOptions) *Router {
+		r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache},
+			[]PrecomputedKeyStore{fallback}, RouterOptions{Index: idx, Tiering: opts, Metrics: m})
+		require.NoError(t, err)
+		return r.(*Router)
+	}
+	r := newRouter(opts)
+
+	// blobs read recently stay cached
+	cache.data[key] = blob
+	idx.AddBackend(commitment, len(blob), RedisBackendType.String())
+	rep := r.Tier(ctx)
+	require.Equal(t, TieringReport{At: rep.At}, rep)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.TierBlobs.WithLabelValues("Redis", "false")))
+
+	// idle blobs are moved to the fallback target
+	r.tiering.DemoteAfter = time.Nanosecond
+	rep = r.Tier(ctx)
+	require.Equal(t, 1, rep.Demoted)
+	require.Empty(t, cache.data)
+	require.Equal(t, blob, fallback.data[key])
+	e, _ := idx.Get(commitment)
+	require.Contains(t, e.Backends, S3BackendType.String())
+	require.NotContains(t, e.Backends, RedisBackendType.String())
+	require.Equal(t, 1.0, testutil.ToFloat64(m.TierMoves.WithLabelValues("Redis", "S3")))
+
+	// and then archived
+	r = newRouter(TieringOptions{Interval: time.Minute, ArchiveAfter: time.Nanosecond, BatchSize: 10})
+	require.Equal(t, 1, r.Tier(ctx).Archived)
+	require.True(t, fallback.archived[key])
+	require.Zero(t, r.Tier(ctx).Archived)
+	require.Equal(t, 1.0, testutil.ToFloat64(m.TierBlobs.WithLabelValues("S3", "true")))
+
+	// reads of archived blobs restore them, and are served once restored
+	_, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err) // served by EigenDA
+	r.eigenda = &fakeDAStore{blob: blob, unavailable: true}
+	_, err = r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.ErrorIs(t, err, ErrArchived)
+	require.True(t, fallback.restored[key])
+	data, err := r.Get(ctx, commitment, commitments.SimpleCommitmentMode)
+	require.NoError(t, err)
+	require.Equal(t, blob, data)
+
+	// frequently read blobs are promoted back to the cache target
+	r.tiering = opts
+	require.Equal(t, 1, r.Tier(ctx).Promoted)
+	require.Equal(t, blob, cache.data[key])
+	e, _ = idx.Get(commitment)
+	require.Contains(t, e.Backends, RedisBackendType.String())
+	require.Zero(t, e.Reads)
+}
+
+func TestTieringChecksHolder(t *testing.T) {
+	ctx := context.Background()
+	commitment, blob := []byte("commitment"), []byte("blob")
+	key := string(crypto.Keccak256(commitment))
+
+	cache := &mapStore{data: map[string][]byte{key: blob}}
+	fallback := &s3MapStore{mapStore{data: make(map[string][]byte)}}
+	idx := index.New(0)
+	idx.AddBackend(commitment, len(blob), RedisBackendType.String())
+	// the index claims a fallback copy that was deleted behind the proxy's back
+	idx.AddBackend(commitment, len(blob), S3BackendType.String())
+
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{cache},
+		[]PrecomputedKeyStore{fallback}, RouterOptions{
+			Index:   idx,
+			Tiering: TieringOptions{Interval: time.Minute, DemoteAfter: time.Nanosecond, BatchSize: 10},
+		})
+	require.NoError(t, err)
+
+	// the blob is written to the fallback target again before the cache copy is deleted
+	require.Equal(t, 1, r.Tier(ctx).Demoted)
+	require.Empty(t, cache.data)
+	require.Equal(t, blob, fallback.data[key])
+	e, _ := idx.Get(commitment)
+	require.Contains(t, e.Backends, S3BackendType.String())
+	require.NotContains(t, e.Backends, RedisBackendType.String())
+	require.Equal(t, int64(len(blob)), idx.BackendBytes(S3BackendType.String()))
+}
@@ -0,0 +1,27 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// WithTTL ... returns a child context whose writes to secondary stores carry the retention hint ttl, see
+// RequestMeta.TTL
+func WithTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return updateRequestMeta(ctx, func(m *RequestMeta) { m.TTL = ttl })
+}
+
+// TTLFromContext ... returns the retention hint of the context, 0 if none is set
+func TTLFromContext(ctx context.Context) time.Duration {
+	return RequestMetaFromContext(ctx).TTL
+}
+
+// ShortestTTL ... returns the retention of a blob written with the context's hint to a store configured to keep
+// blobs for retention, where 0 means forever
+func ShortestTTL(ctx context.Context, retention time.Duration) time.Duration {
+	ttl := TTLFromContext(ctx)
+	if ttl <= 0 || (retention > 0 && retention < ttl) {
+		return retention
+	}
+	return ttl
+}
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"github.com/Layr-Labs/eigenda-proxy/commitments"
+	"github.com/Layr-Labs/eigenda-proxy/verify"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// indexCert ... indexes an EigenDA cert along with the EIP-4844 style versioned hash of its blob, so that
+// the blob can also be looked up by versioned hash
+func (r *Router) indexCert(commitment []byte, size int, cm commitments.CommitmentMode) {
+	r.index.Put(commitment, size, string(cm))
+
+	var cert verify.Certificate
+	if err := rlp.DecodeBytes(commitment, &cert); err != nil {
+		r.log.Debug("Failed to decode cert, skipping versioned hash", "err", err)
+		return
+	}
+	vh, err := cert.VersionedHash()
+	if err != nil {
+		r.log.Debug("Failed to compute versioned hash", "err", err)
+		return
+	}
+	r.index.AddAlias(vh[:], commitment)
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// warmTarget ... backend whose connections are kept warm
+type warmTarget struct {
+	backend string
+	pinger  Pinger
+}
+
+// warmTargets ... collects the backends of the router that can be pinged, each physical store once
+func warmTargets(r IRouter) []warmTarget {
+	var targets []warmTarget
+	if p, ok := r.GetEigenDAStore().(Pinger); ok {
+		targets = append(targets, warmTarget{backend: r.GetEigenDAStore().BackendType().String(), pinger: p})
+	}
+
+	seen := make(map[string]struct{})
+	secondaries := append([]PrecomputedKeyStore{r.GetKeccakStore()}, r.Caches()...)
+	for _, s := range append(secondaries, r.Fallbacks()...) {
+		p, ok := s.(Pinger)
+		if !ok {
+			continue
+		}
+		id := identity(s)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		targets = append(targets, warmTarget{backend: s.BackendType().String(), pinger: p})
+	}
+	return targets
+}
+
+// KeepWarm ... pings the router's backends every interval until ctx is done, so that their pooled connections
+// are kept alive through idle periods instead of being dropped by load balancers or NAT gateways. A backend
+// that fails its ping is reconnected if it supports it, so that the next request doesn't hit a dead
+// connection.
+func KeepWarm(ctx context.Context, r IRouter, interval time.Duration, m metrics.Metricer, l log.Logger) {
+	targets := warmTargets(r)
+	if len(targets) == 0 {
+		l.Warn("No backend supports health pings, connections are not kept warm")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, t := range targets {
+				pingBackend(ctx, t, interval, m, l)
+			}
+		}
+	}
+}
+
+// pingBackend ... pings a single backend, reconnecting it on failure. The ping may take at most one interval.
+func pingBackend(ctx context.Context, t warmTarget, timeout time.Duration, m metrics.Metricer, l log.Logger) {
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	err := t.pinger.Ping(pingCtx)
+	cancel()
+	m.RecordBackendPing(t.backend, err == nil)
+	if err == nil {
+		return
+	}
+
+	l.Warn("Backend health ping failed", "backend", t.backend, "err", err)
+	reconnector, ok := t.pinger.(Reconnector)
+	if !ok {
+		return
+	}
+	if err := reconnector.Reconnect(ctx); err != nil {
+		l.Error("Failed to reconnect to backend", "backend", t.backend, "err", err)
+		return
+	}
+	l.Info("Reconnected to backend after failed health ping", "backend", t.backend)
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/metrics"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// pingStore ... mapStore answering health pings with err and counting reconnects
+type pingStore struct {
+	*mapStore
+	err        error
+	pings      int
+	reconnects int
+}
+
+func (p *pingStore) Ping(_ context.Context) error {
+	p.pings++
+	return p.err
+}
+
+func (p *pingStore) Reconnect(_ context.Context) error {
+	p.reconnects++
+	p.err = nil
+	return nil
+}
+
+func TestKeepWarm(t *testing.T) {
+	healthy := &pingStore{mapStore: &mapStore{data: make(map[string][]byte)}}
+	broken := &pingStore{mapStore: &mapStore{data: make(map[string][]byte)}, err: errors.New("connection reset")}
+	unpingable := &mapStore{data: make(map[string][]byte)}
+
+	r, err := NewRouter(nil, nil, log.New(), []PrecomputedKeyStore{healthy, unpingable},
+		[]PrecomputedKeyStore{broken}, RouterOptions{})
+	require.NoError(t, err)
+
+	targets := warmTargets(r)
+	require.Len(t, targets, 2)
+
+	for _, target := range targets {
+		pingBackend(context.Background(), target, time.Second, metrics.NoopMetrics, log.New())
+	}
+	require.Equal(t, 1, healthy.pings)
+	require.Equal(t, 0, healthy.reconnects)
+	require.Equal(t, 1, broken.pings)
+	require.Equal(t, 1, broken.reconnects)
+
+	// the reconnected backend is healthy again
+	pingBackend(context.Background(), targets[1], time.Second, metrics.NoopMetrics, log.New())
+	require.Equal(t, 1, broken.reconnects)
+}
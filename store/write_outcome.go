@@ -0,0 +1,165 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// recentWriteFailures ... number of failed writes kept per target, see TargetWriteSummary
+const recentWriteFailures = 20
+
+// WriteStatus ... outcome of a write to a cache or fallback target
+type WriteStatus string
+
+const (
+	// WriteSucceeded ... the write succeeded on its first attempt
+	WriteSucceeded WriteStatus = "success"
+	// WriteRetried ... the write succeeded when re-driven from the dead-letter queue after failed attempts
+	WriteRetried WriteStatus = "retried"
+	// WriteSkipped ... the write wasn't attempted, e.g. because the target's quota is exhausted
+	WriteSkipped WriteStatus = "skipped"
+	// WriteDeadLettered ... the write failed and was added to the dead-letter queue to be retried
+	WriteDeadLettered WriteStatus = "dead-lettered"
+	// WriteFailed ... the write failed and couldn't be added to the dead-letter queue either
+	WriteFailed WriteStatus = "failed"
+)
+
+// WriteOutcome ... outcome of a write to a single target
+type WriteOutcome struct {
+	Backend  BackendType
+	Status   WriteStatus
+	Attempts int
+	// Err is the error of the last failed attempt, also set for retried writes
+	Err error
+}
+
+// Succeeded ... returns whether the target holds the blob
+func (o WriteOutcome) Succeeded() bool {
+	return o.Status == WriteSucceeded || o.Status == WriteRetried
+}
+
+// WriteOutcomes ... outcomes of a write to several targets
+type WriteOutcomes []WriteOutcome
+
+// Err ... returns a *MultiWriteError if the write was attempted on targets but none of them holds the blob
+func (os WriteOutcomes) Err() error {
+	for _, o := range os {
+		if o.Succeeded() {
+			return nil
+		}
+	}
+	if len(os) == 0 {
+		return nil
+	}
+	return &MultiWriteError{Outcomes: os}
+}
+
+// MultiWriteError ... returned when a write failed on every target, listing why it failed on each of them
+type MultiWriteError struct {
+	Outcomes WriteOutcomes
+}
+
+func (e *MultiWriteError) Error() string {
+	parts := make([]string, len(e.Outcomes))
+	for i, o := range e.Outcomes {
+		parts[i] = fmt.Sprintf("%s: %s: %v", o.Backend, o.Status, o.Err)
+	}
+	return "failed to write blob to any redundant targets: " + strings.Join(parts, "; ")
+}
+
+// Unwrap ... returns the errors of the targets, so that errors.Is matches any of them
+func (e *MultiWriteError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Outcomes))
+	for _, o := range e.Outcomes {
+		if o.Err != nil {
+			errs = append(errs, o.Err)
+		}
+	}
+	return errs
+}
+
+// WriteFailure ... a write to a target that didn't succeed on its first attempt
+type WriteFailure struct {
+	Time       time.Time     `json:"time"`
+	Commitment hexutil.Bytes `json:"commitment"`
+	Status     WriteStatus   `json:"status"`
+	Attempts   int           `json:"attempts"`
+	Error      string        `json:"error"`
+}
+
+// TargetWriteSummary ... outcomes of the writes to a target since the proxy started, and its most recent failures
+type TargetWriteSummary struct {
+	Backend string              `json:"backend"`
+	Counts  map[WriteStatus]int `json:"counts"`
+	// Recent failures, newest first
+	Recent []WriteFailure `json:"recent"`
+}
+
+// writeTracker ... aggregates the outcomes of the writes to each target
+type writeTracker struct {
+	mu      sync.Mutex
+	targets map[BackendType]*TargetWriteSummary
+}
+
+func newWriteTracker() *writeTracker {
+	return &writeTracker{targets: make(map[BackendType]*TargetWriteSummary)}
+}
+
+// record ... accounts the outcome of a write of commitment
+func (t *writeTracker) record(commitment []byte, o WriteOutcome) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.targets[o.Backend]
+	if !ok {
+		s = &TargetWriteSummary{Backend: o.Backend.String(), Counts: make(map[WriteStatus]int)}
+		t.targets[o.Backend] = s
+	}
+	s.Counts[o.Status]++
+	if o.Status == WriteSucceeded {
+		return
+	}
+
+	f := WriteFailure{
+		Time:       time.Now(),
+		Commitment: append([]byte(nil), commitment...),
+		Status:     o.Status,
+		Attempts:   o.Attempts,
+	}
+	if o.Err != nil {
+		f.Error = o.Err.Error()
+	}
+	s.Recent = append([]WriteFailure{f}, s.Recent[:min(len(s.Recent), recentWriteFailures-1)]...)
+}
+
+// summaries ... returns a copy of the summaries, ordered by backend
+func (t *writeTracker) summaries() []TargetWriteSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summaries := make([]TargetWriteSummary, 0, len(t.targets))
+	for _, s := range t.targets {
+		c := TargetWriteSummary{
+			Backend: s.Backend,
+			Counts:  make(map[WriteStatus]int, len(s.Counts)),
+			Recent:  append([]WriteFailure{}, s.Recent...),
+		}
+		for status, n := range s.Counts {
+			c.Counts[status] = n
+		}
+		summaries = append(summaries, c)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Backend < summaries[j].Backend })
+	return summaries
+}
+
+// WriteSummaries ... returns the outcomes of the writes to each cache and fallback target since the proxy
+// started, along with their most recent failures
+func (r *Router) WriteSummaries() []TargetWriteSummary {
+	return r.writes.summaries()
+}
@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda-proxy/store/deadletter"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var errUnavailable = errors.New("service unavailable")
+
+// flakyStore ... mapStore of a configurable backend type whose next writes fail
+type flakyStore struct {
+	mapStore
+	backend  BackendType
+	failures int
+}
+
+func (f *flakyStore) Put(ctx context.Context, key []byte, value []byte) error {
+	if f.failures > 0 {
+		f.failures--
+		return errUnavailable
+	}
+	return f.mapStore.Put(ctx, key, value)
+}
+
+func (f *flakyStore) BackendType() BackendType { return f.backend }
+
+func TestWriteOutcomes(t *testing.T) {
+	ctx := context.Background()
+	blob := []byte("blob")
+
+	redis := &flakyStore{mapStore: mapStore{data: make(map[string][]byte)}, backend: RedisBackendType}
+	s3 := &flakyStore{mapStore: mapStore{data: make(map[string][]byte)}, backend: S3BackendType}
+	r, err := NewRouter(&fakeDAStore{blob: blob}, nil, log.New(), []PrecomputedKeyStore{redis}, []PrecomputedKeyStore{s3},
+		RouterOptions{})
+	require.NoError(t, err)
+	router := r.(*Router)
+
+	outcomes := router.handleRedundantWrites(ctx, []byte("a"), blob, nil)
+	require.NoError(t, outcomes.Err())
+	require.Equal(t, WriteOutcomes{
+		{Backend: RedisBackendType, Status: WriteSucceeded, Attempts: 1},
+		{Backend: S3BackendType, Status: WriteSucceeded, Attempts: 1},
+	}, outcomes)
+
+	// a single target holding the blob is enough, failed writes are dead-lettered without retrying them inline
+	s3.failures = 1
+	outcomes = router.handleRedundantWrites(ctx, []byte("b"), blob, nil)
+	require.NoError(t, outcomes.Err())
+	require.Equal(t, WriteOutcomes{
+		{Backend: RedisBackendType, Status: WriteSucceeded, Attempts: 1},
+		{Backend: S3BackendType, Status: WriteDeadLettered, Attempts: 1, Err: errUnavailable},
+	}, outcomes)
+
+	// the error of a write failing on every target details each of them
+	redis.failures, s3.failures = 1, 1
+	err = router.handleRedundantWrites(ctx, []byte("c"), blob, nil).Err()
+	var merr *MultiWriteError
+	require.ErrorAs(t, err, &merr)
+	require.Len(t, merr.Outcomes, 2)
+	require.ErrorIs(t, err, errUnavailable)
+	require.Contains(t, err.Error(), "S3: dead-lettered: service unavailable")
+
+	// writes re-driven from the dead-letter queue are accounted as retried
+	require.NoError(t, r.Redrive(ctx, deadletter.TaskID(deadletter.KindReplication, RedisBackendType.String(), []byte("c"))))
+
+	summaries := r.WriteSummaries()
+	require.Len(t, summaries, 2)
+	require.Equal(t, "Redis", summaries[0].Backend)
+	require.Equal(t, map[WriteStatus]int{WriteSucceeded: 2, WriteRetried: 1, WriteDeadLettered: 1}, summaries[0].Counts)
+	require.Len(t, summaries[0].Recent, 2)
+	require.Equal(t, []byte("c"), []byte(summaries[0].Recent[0].Commitment))
+	require.Equal(t, WriteRetried, summaries[0].Recent[0].Status)
+	require.Equal(t, errUnavailable.Error(), summaries[0].Recent[0].Error)
+	require.Equal(t, WriteDeadLettered, summaries[0].Recent[1].Status)
+	require.Equal(t, map[WriteStatus]int{WriteSucceeded: 1, WriteDeadLettered: 2}, summaries[1].Counts)
+}
+
+func TestWriteTrackerRecent(t *testing.T) {
+	tr := newWriteTracker()
+	for i := 0; i < recentWriteFailures+5; i++ {
+		tr.record([]byte{byte(i)}, WriteOutcome{Backend: FSBackendType, Status: WriteSkipped, Err: errUnavailable})
+	}
+	summaries := tr.summaries()
+	require.Len(t, summaries[0].Recent, recentWriteFailures)
+	require.Equal(t, []byte{byte(recentWriteFailures + 4)}, []byte(summaries[0].Recent[0].Commitment))
+	require.Equal(t, recentWriteFailures+5, summaries[0].Counts[WriteSkipped])
+}
@@ -0,0 +1,93 @@
+package tenancy
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	NamespacesFlagName       = withFlagPrefix("namespaces")
+	QuorumsFlagName          = withFlagPrefix("quorums")
+	SignerKeyFilesFlagName   = withFlagPrefix("signer-key-files")
+	S3PathsFlagName          = withFlagPrefix("s3-paths")
+	RateLimitsFlagName       = withFlagPrefix("rate-limits")
+	APIKeysFlagName          = withFlagPrefix("api-keys")
+	DefaultRateLimitFlagName = withFlagPrefix("default-rate-limit")
+	BurstFlagName            = withFlagPrefix("burst")
+)
+
+func withFlagPrefix(s string) string {
+	return "tenancy." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_TENANCY_" + s}
+}
+
+// CLIFlags ... used for the namespace configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     NamespacesFlagName,
+			Usage:    "Namespaces sharing the proxy, e.g. one per rollup. Requests select their namespace with the X-EigenDA-Proxy-Tenant header, the /tenants/<namespace>/ path prefix or an API key of the namespace, and must present an API key of the namespace. Requests without a namespace use the proxy-wide configuration.",
+			EnvVars:  withEnvPrefix(envPrefix, "NAMESPACES"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     QuorumsFlagName,
+			Usage:    "Custom quorums the blobs of a namespace are dispersed to instead of --eigenda-custom-quorum-ids, as namespace=quorums entries with quorum IDs joined by '+', e.g. rollup-a=2+3. 'none' disperses to the default quorums only.",
+			EnvVars:  withEnvPrefix(envPrefix, "QUORUMS"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     SignerKeyFilesFlagName,
+			Usage:    "Files holding the hex-encoded signer private key of a namespace's dispersals instead of the proxy's, as namespace=path entries. Files are watched for key rotations.",
+			EnvVars:  withEnvPrefix(envPrefix, "SIGNER_KEY_FILES"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     S3PathsFlagName,
+			Usage:    "Paths the blobs of a namespace are stored under in S3 instead of --s3.path, as namespace=path entries.",
+			EnvVars:  withEnvPrefix(envPrefix, "S3_PATHS"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     RateLimitsFlagName,
+			Usage:    "Sustained number of requests per second allowed for a namespace, as namespace=limit entries. Namespaces without a limit are unlimited.",
+			EnvVars:  withEnvPrefix(envPrefix, "RATE_LIMITS"),
+			Category: category,
+		},
+		&cli.StringSliceFlag{
+			Name:     APIKeysFlagName,
+			Usage:    "API keys of the namespaces, presented as bearer tokens, as namespace=key entries. Every namespace needs at least one key, a namespace may have several.",
+			EnvVars:  withEnvPrefix(envPrefix, "API_KEYS"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     DefaultRateLimitFlagName,
+			Usage:    "Sustained number of requests per second allowed for the requests without a namespace, together. Unlimited if 0.",
+			EnvVars:  withEnvPrefix(envPrefix, "DEFAULT_RATE_LIMIT"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     BurstFlagName,
+			Usage:    "Number of requests a rate limited namespace, or the requests without a namespace, can send at once above the rate limit.",
+			Value:    20,
+			EnvVars:  withEnvPrefix(envPrefix, "BURST"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Namespaces:       ctx.StringSlice(NamespacesFlagName),
+		Quorums:          ctx.StringSlice(QuorumsFlagName),
+		SignerKeyFiles:   ctx.StringSlice(SignerKeyFilesFlagName),
+		S3Paths:          ctx.StringSlice(S3PathsFlagName),
+		RateLimits:       ctx.StringSlice(RateLimitsFlagName),
+		APIKeys:          ctx.StringSlice(APIKeysFlagName),
+		DefaultRateLimit: ctx.Float64(DefaultRateLimitFlagName),
+		Burst:            ctx.Int(BurstFlagName),
+	}
+}
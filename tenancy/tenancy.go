@@ -0,0 +1,233 @@
+// Package tenancy implements namespaces sharing a single proxy, e.g. several rollups. Requests carry their
+// namespace in a header or a path prefix, authenticated by an API key of the namespace, and each namespace can
+// disperse with its own quorums and signer key, store its blobs under its own S3 path and be rate limited on its
+// own.
+package tenancy
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+)
+
+// reserved ... names accounting the requests of unlisted tenants, which can't be namespaces
+var reserved = []string{"default", "other"}
+
+// nameRe ... valid namespace names, which appear in paths of the HTTP API and S3 object keys
+var nameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Tenant ... configuration of a namespace. Unset fields fall back to the proxy-wide configuration.
+type Tenant struct {
+	Name string
+	// CustomQuorums are the custom quorums the blobs of the namespace are dispersed to, instead of
+	// --eigenda-custom-quorum-ids if non-nil. Empty disperses to the default quorums only.
+	CustomQuorums []uint
+	// SignerKeyFile holds the hex encoded private key authenticating the dispersals of the namespace
+	SignerKeyFile string
+	// S3Path is the path the blobs of the namespace are stored under in S3, instead of --s3.path
+	S3Path string
+	// RateLimit is the sustained number of requests per second allowed for the namespace, unlimited if 0
+	RateLimit float64
+	// APIKeys authenticate the requests of the namespace, presented as bearer tokens
+	APIKeys []string
+}
+
+// Config ... the namespaces and their configuration, as <namespace>=<value> entries
+type Config struct {
+	Namespaces     []string
+	Quorums        []string
+	SignerKeyFiles []string
+	S3Paths        []string
+	RateLimits     []string
+	// APIKeys may repeat a namespace, each of its entries is a key of the namespace
+	APIKeys []string
+	// DefaultRateLimit is the sustained number of requests per second allowed for the requests without a
+	// namespace, together, unlimited if 0
+	DefaultRateLimit float64
+	// Burst is the number of requests a namespace can send at once above its rate limit
+	Burst int
+}
+
+// Enabled ... whether any namespace is configured
+func (c Config) Enabled() bool {
+	return len(c.Namespaces) > 0
+}
+
+// Check ... validates the configured namespaces
+func (c Config) Check() error {
+	_, err := c.Tenants()
+	return err
+}
+
+// Tenants ... parses the configuration of the namespaces, keyed by name
+func (c Config) Tenants() (map[string]Tenant, error) {
+	tenants := make(map[string]Tenant, len(c.Namespaces))
+	for _, name := range c.Namespaces {
+		if !nameRe.MatchString(name) {
+			return nil, fmt.Errorf("invalid namespace %q, expected up to 64 lowercase letters, digits, '-' and '_'", name)
+		}
+		for _, r := range reserved {
+			if name == r {
+				return nil, fmt.Errorf("namespace name %s is reserved", name)
+			}
+		}
+		if _, ok := tenants[name]; ok {
+			return nil, fmt.Errorf("duplicate namespace %s", name)
+		}
+		tenants[name] = Tenant{Name: name}
+	}
+	if len(tenants) > 0 && c.Burst <= 0 {
+		return nil, fmt.Errorf("namespace rate limit burst must be positive")
+	}
+	if c.DefaultRateLimit < 0 {
+		return nil, fmt.Errorf("default rate limit must not be negative")
+	}
+	if c.DefaultRateLimit > 0 && len(tenants) == 0 {
+		return nil, fmt.Errorf("default rate limit requires namespaces")
+	}
+
+	err := parseEntries(tenants, "quorums", c.Quorums, func(t *Tenant, v string) error {
+		t.CustomQuorums = []uint{}
+		if v == "" || v == "none" {
+			return nil
+		}
+		for _, raw := range strings.Split(v, "+") {
+			q, err := strconv.ParseUint(raw, 10, 8)
+			if err != nil {
+				return fmt.Errorf("invalid quorum ID %q", raw)
+			}
+			t.CustomQuorums = append(t.CustomQuorums, uint(q))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = parseEntries(tenants, "signer key file", c.SignerKeyFiles, func(t *Tenant, v string) error {
+		if v == "" {
+			return fmt.Errorf("empty path")
+		}
+		t.SignerKeyFile = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = parseEntries(tenants, "S3 path", c.S3Paths, func(t *Tenant, v string) error {
+		if strings.Contains(v, "..") {
+			return fmt.Errorf("path must not contain '..'")
+		}
+		t.S3Path = v
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = parseEntries(tenants, "rate limit", c.RateLimits, func(t *Tenant, v string) error {
+		limit, err := strconv.ParseFloat(v, 64)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("expected a non-negative number of requests per second")
+		}
+		t.RateLimit = limit
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := parseAPIKeys(tenants, c.APIKeys); err != nil {
+		return nil, err
+	}
+	return tenants, nil
+}
+
+// parseAPIKeys ... parses the <namespace>=<key> entries of the API keys into the tenants. Every namespace needs a
+// key, since its header alone would let any caller disperse with its signer key and use up its rate limit.
+func parseAPIKeys(tenants map[string]Tenant, entries []string) error {
+	owners := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, key, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			// the entry isn't printed, it may be a key
+			return fmt.Errorf("invalid namespace API key, expected namespace=key")
+		}
+		t, ok := tenants[name]
+		if !ok {
+			return fmt.Errorf("API key provided for %s, but it is not a namespace", name)
+		}
+		if owner, ok := owners[key]; ok {
+			return fmt.Errorf("API key of namespace %s is also a key of namespace %s", name, owner)
+		}
+		owners[key] = name
+		t.APIKeys = append(t.APIKeys, key)
+		tenants[name] = t
+	}
+	for _, name := range Names(tenants) {
+		if len(tenants[name].APIKeys) == 0 {
+			return fmt.Errorf("namespace %s has no API key", name)
+		}
+	}
+	return nil
+}
+
+// Secrets ... returns the API keys of the namespaces
+func (c Config) Secrets() []string {
+	keys := make([]string, 0, len(c.APIKeys))
+	for _, entry := range c.APIKeys {
+		if _, key, ok := strings.Cut(entry, "="); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Redacted ... returns a copy of the config with the API keys redacted, keeping the namespaces they belong to
+func (c Config) Redacted() Config {
+	if len(c.APIKeys) == 0 {
+		return c
+	}
+	keys := make([]string, len(c.APIKeys))
+	for i, entry := range c.APIKeys {
+		name, key, _ := strings.Cut(entry, "=")
+		keys[i] = name + "=" + secrets.Redact(key)
+	}
+	c.APIKeys = keys
+	return c
+}
+
+// parseEntries ... parses the <namespace>=<value> entries of a setting into the tenants with set
+func parseEntries(tenants map[string]Tenant, setting string, entries []string, set func(t *Tenant, v string) error) error {
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name, v, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid namespace %s %s, expected namespace=value", setting, entry)
+		}
+		t, ok := tenants[name]
+		if !ok {
+			return fmt.Errorf("%s provided for %s, but it is not a namespace", setting, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate %s provided for namespace %s", setting, name)
+		}
+		seen[name] = true
+		if err := set(&t, v); err != nil {
+			return fmt.Errorf("invalid %s for namespace %s: %w", setting, name, err)
+		}
+		tenants[name] = t
+	}
+	return nil
+}
+
+// Names ... sorted names of the tenants
+func Names(tenants map[string]Tenant) []string {
+	names := make([]string, 0, len(tenants))
+	for name := range tenants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
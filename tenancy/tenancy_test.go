@@ -0,0 +1,87 @@
+package tenancy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenants(t *testing.T) {
+	cfg := Config{
+		Namespaces:     []string{"rollup-a", "rollup-b", "rollup_c"},
+		Quorums:        []string{"rollup-a=2+3", "rollup-b=none"},
+		SignerKeyFiles: []string{"rollup-a=/secrets/rollup-a.key"},
+		S3Paths:        []string{"rollup-b=rollups/b"},
+		RateLimits:     []string{"rollup-a=2.5"},
+		APIKeys:        []string{"rollup-a=key-a", "rollup-a=key-a2", "rollup-b=key-b", "rollup_c=key-c"},
+		Burst:          10,
+	}
+	require.True(t, cfg.Enabled())
+	tenants, err := cfg.Tenants()
+	require.NoError(t, err)
+	require.Equal(t, map[string]Tenant{
+		"rollup-a": {
+			Name: "rollup-a", CustomQuorums: []uint{2, 3}, SignerKeyFile: "/secrets/rollup-a.key", RateLimit: 2.5,
+			APIKeys: []string{"key-a", "key-a2"},
+		},
+		"rollup-b": {Name: "rollup-b", CustomQuorums: []uint{}, S3Path: "rollups/b", APIKeys: []string{"key-b"}},
+		"rollup_c": {Name: "rollup_c", APIKeys: []string{"key-c"}},
+	}, tenants)
+	require.Equal(t, []string{"rollup-a", "rollup-b", "rollup_c"}, Names(tenants))
+
+	tenants, err = Config{}.Tenants()
+	require.NoError(t, err)
+	require.Empty(t, tenants)
+	require.False(t, Config{}.Enabled())
+
+	require.Equal(t, []string{"key-a", "key-a2", "key-b", "key-c"}, cfg.Secrets())
+	require.Equal(t, []string{"rollup-a=[redacted]", "rollup-a=[redacted]", "rollup-b=[redacted]", "rollup_c=[redacted]"},
+		cfg.Redacted().APIKeys)
+	require.Equal(t, "rollup-a=key-a", cfg.APIKeys[0])
+}
+
+func TestCheck(t *testing.T) {
+	valid := func() Config {
+		return Config{Namespaces: []string{"rollup-a"}, APIKeys: []string{"rollup-a=key-a"}, Burst: 1}
+	}
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		err    string
+	}{
+		{name: "Valid", modify: func(*Config) {}},
+		{name: "InvalidName", modify: func(c *Config) { c.Namespaces = []string{"Rollup/A"} }, err: "invalid namespace"},
+		{name: "ReservedName", modify: func(c *Config) { c.Namespaces = []string{"other"} }, err: "reserved"},
+		{name: "DuplicateName", modify: func(c *Config) { c.Namespaces = []string{"rollup-a", "rollup-a"} }, err: "duplicate namespace"},
+		{name: "NoBurst", modify: func(c *Config) { c.Burst = 0 }, err: "burst"},
+		{name: "MalformedEntry", modify: func(c *Config) { c.Quorums = []string{"rollup-a"} }, err: "expected namespace=value"},
+		{name: "UnknownNamespace", modify: func(c *Config) { c.S3Paths = []string{"rollup-b=b"} }, err: "not a namespace"},
+		{name: "DuplicateEntry", modify: func(c *Config) { c.RateLimits = []string{"rollup-a=1", "rollup-a=2"} }, err: "duplicate rate limit"},
+		{name: "InvalidQuorum", modify: func(c *Config) { c.Quorums = []string{"rollup-a=2+256"} }, err: "invalid quorum ID"},
+		{name: "EmptySignerKeyFile", modify: func(c *Config) { c.SignerKeyFiles = []string{"rollup-a="} }, err: "empty path"},
+		{name: "EscapingS3Path", modify: func(c *Config) { c.S3Paths = []string{"rollup-a=../b"} }, err: "'..'"},
+		{name: "NoAPIKey", modify: func(c *Config) { c.APIKeys = nil }, err: "has no API key"},
+		{name: "MalformedAPIKey", modify: func(c *Config) { c.APIKeys = []string{"key-a"} }, err: "expected namespace=key"},
+		{name: "SharedAPIKey", modify: func(c *Config) {
+			c.Namespaces = append(c.Namespaces, "rollup-b")
+			c.APIKeys = []string{"rollup-a=key", "rollup-b=key"}
+		}, err: "also a key of namespace"},
+		{name: "NegativeDefaultRateLimit", modify: func(c *Config) { c.DefaultRateLimit = -1 }, err: "must not be negative"},
+		{name: "DefaultRateLimitWithoutNamespaces", modify: func(c *Config) {
+			c.Namespaces, c.APIKeys, c.DefaultRateLimit = nil, nil, 1
+		}, err: "requires namespaces"},
+		{name: "NegativeRateLimit", modify: func(c *Config) { c.RateLimits = []string{"rollup-a=-1"} }, err: "non-negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(&cfg)
+			err := cfg.Check()
+			if tt.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
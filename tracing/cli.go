@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	EndpointFlagName    = withFlagPrefix("endpoint")
+	ProtocolFlagName    = withFlagPrefix("protocol")
+	InsecureFlagName    = withFlagPrefix("insecure")
+	SampleRatioFlagName = withFlagPrefix("sample-ratio")
+	ServiceNameFlagName = withFlagPrefix("service-name")
+)
+
+func withFlagPrefix(s string) string {
+	return "tracing." + s
+}
+
+func withEnvPrefix(envPrefix, s string) []string {
+	return []string{envPrefix + "_TRACING_" + s}
+}
+
+// CLIFlags ... used for the OpenTelemetry tracing configuration
+// category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
+func CLIFlags(envPrefix, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     EndpointFlagName,
+			Usage:    "host:port of the OTLP collector spans are exported to. Tracing is disabled if unset.",
+			EnvVars:  withEnvPrefix(envPrefix, "ENDPOINT"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ProtocolFlagName,
+			Usage:    "OTLP transport spans are exported with, options are [grpc, http].",
+			Value:    string(ProtocolGRPC),
+			EnvVars:  withEnvPrefix(envPrefix, "PROTOCOL"),
+			Category: category,
+		},
+		&cli.BoolFlag{
+			Name:     InsecureFlagName,
+			Usage:    "Disable TLS on the connection to the OTLP collector.",
+			EnvVars:  withEnvPrefix(envPrefix, "INSECURE"),
+			Category: category,
+		},
+		&cli.Float64Flag{
+			Name:     SampleRatioFlagName,
+			Usage:    "Share of the traces started by the proxy that are sampled, between 0 and 1. The sampling decision of traces propagated via the traceparent header is respected.",
+			Value:    1,
+			EnvVars:  withEnvPrefix(envPrefix, "SAMPLE_RATIO"),
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     ServiceNameFlagName,
+			Usage:    "Service name spans are exported under.",
+			Value:    ServiceName,
+			EnvVars:  withEnvPrefix(envPrefix, "SERVICE_NAME"),
+			Category: category,
+		},
+	}
+}
+
+func ReadConfig(ctx *cli.Context) Config {
+	return Config{
+		Endpoint:    ctx.String(EndpointFlagName),
+		Protocol:    Protocol(ctx.String(ProtocolFlagName)),
+		Insecure:    ctx.Bool(InsecureFlagName),
+		SampleRatio: ctx.Float64(SampleRatioFlagName),
+		ServiceName: ctx.String(ServiceNameFlagName),
+	}
+}
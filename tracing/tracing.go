@@ -0,0 +1,140 @@
+// Package tracing exports OpenTelemetry spans of the proxy's request handlers, dispersals, cert verifications and
+// secondary store operations to an OTLP collector. Instrumented packages create their spans via the global tracer
+// provider, which drops them unless Setup installed an exporting one.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName ... default name the proxy's spans are exported under
+const ServiceName = "eigenda-proxy"
+
+// Protocol ... OTLP transport spans are exported with
+type Protocol string
+
+const (
+	ProtocolGRPC Protocol = "grpc"
+	ProtocolHTTP Protocol = "http"
+)
+
+// Config ... OTLP exporter configuration, tracing is disabled unless Endpoint is set
+type Config struct {
+	// Endpoint is the host:port of the OTLP collector
+	Endpoint string
+	Protocol Protocol
+	// Insecure disables TLS on the connection to the collector
+	Insecure bool
+	// SampleRatio is the share of traces started by the proxy that are sampled. The sampling decision of
+	// traces propagated by the client is respected.
+	SampleRatio float64
+	ServiceName string
+}
+
+// Enabled ... returns whether spans are exported
+func (c Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+func (c Config) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Protocol != ProtocolGRPC && c.Protocol != ProtocolHTTP {
+		return fmt.Errorf("unknown tracing protocol %q, must be one of [grpc, http]", c.Protocol)
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", c.SampleRatio)
+	}
+	if strings.Contains(c.Endpoint, "://") {
+		return fmt.Errorf("tracing endpoint must be a host:port, got %s", c.Endpoint)
+	}
+	return nil
+}
+
+// Setup ... installs a global tracer provider exporting to the configured collector, and the W3C trace context
+// propagator. The returned function flushes the pending spans and stops the exporter.
+func Setup(ctx context.Context, cfg Config, version string) (func(context.Context) error, error) {
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var client otlptrace.Client
+	switch cfg.Protocol {
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		client = otlptracehttp.NewClient(opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing protocol %q", cfg.Protocol)
+	}
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	name := cfg.ServiceName
+	if name == "" {
+		name = ServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(name), semconv.ServiceVersion(version)))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{},
+		propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Tracer ... returns the tracer of an instrumented package of the proxy
+func Tracer(pkg string) trace.Tracer {
+	return otel.Tracer("github.com/Layr-Labs/eigenda-proxy/" + pkg)
+}
+
+// Backend ... attribute of the backend an operation was run against
+func Backend(name string) attribute.KeyValue {
+	return attribute.String("backend", name)
+}
+
+// End ... records the error an operation failed with, if any, and ends its span. Cancellations by the client are
+// recorded without flagging the span as failed.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !errors.Is(err, context.Canceled) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
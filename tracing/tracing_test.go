@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestConfigCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectError bool
+	}{
+		{name: "Disabled", cfg: Config{Protocol: "udp"}},
+		{name: "GRPC", cfg: Config{Endpoint: "localhost:4317", Protocol: ProtocolGRPC, SampleRatio: 1}},
+		{name: "HTTP", cfg: Config{Endpoint: "localhost:4318", Protocol: ProtocolHTTP, SampleRatio: 0.1}},
+		{name: "UnknownProtocol", cfg: Config{Endpoint: "localhost:4317", Protocol: "udp"}, expectError: true},
+		{name: "SampleRatio", cfg: Config{Endpoint: "localhost:4317", Protocol: ProtocolGRPC, SampleRatio: 2},
+			expectError: true},
+		{name: "URL", cfg: Config{Endpoint: "http://localhost:4318", Protocol: ProtocolHTTP}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Check()
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{}, "v0.0.0")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	End(span, errors.New("connection refused"))
+	_, span = tracer.Start(context.Background(), "canceled")
+	End(span, context.Canceled)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Len(t, spans[1].Events(), 1)
+	require.Equal(t, codes.Unset, spans[2].Status().Code)
+	require.Len(t, spans[2].Events(), 1)
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDiscoveryInterval ... how often discovered endpoints are resolved again
+const DefaultDiscoveryInterval = 30 * time.Second
+
+// maxDiscoveryResponseSize ... upper bound of a discovery URL's response body
+const maxDiscoveryResponseSize = 1 << 20
+
+// lookupSRV ... resolves SRV records, replaced in tests
+var lookupSRV = net.DefaultResolver.LookupSRV
+
+// CheckDiscoverySource ... validates an endpoint discovery source, i.e. srv://<record name> or the http(s) URL of
+// a JSON array of host:port endpoints
+func CheckDiscoverySource(source string) error {
+	if name, ok := strings.CutPrefix(source, "srv://"); ok {
+		if name == "" {
+			return fmt.Errorf("invalid endpoint discovery source %s: missing SRV record name", source)
+		}
+		return nil
+	}
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return nil
+	}
+	return fmt.Errorf("invalid endpoint discovery source %s: expected srv://<name> or an http(s) URL", source)
+}
+
+// ResolveEndpoints ... returns the host:port endpoints currently advertised by the discovery source. SRV targets
+// are ordered by priority and weight, URL endpoints are kept in the order they are listed in.
+func ResolveEndpoints(ctx context.Context, source string) ([]string, error) {
+	if err := CheckDiscoverySource(source); err != nil {
+		return nil, err
+	}
+
+	var endpoints []string
+	var err error
+	if name, ok := strings.CutPrefix(source, "srv://"); ok {
+		endpoints, err = resolveSRV(ctx, name)
+	} else {
+		endpoints, err = resolveURL(ctx, source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve endpoints of %s: %w", source, err)
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no endpoints discovered at %s", source)
+	}
+	return endpoints, nil
+}
+
+func resolveSRV(ctx context.Context, name string) ([]string, error) {
+	_, records, err := lookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Priority != records[j].Priority {
+			return records[i].Priority < records[j].Priority
+		}
+		return records[i].Weight > records[j].Weight
+	})
+
+	endpoints := make([]string, 0, len(records))
+	for _, r := range records {
+		host := strings.TrimSuffix(r.Target, ".")
+		// a target of "." means the service is decidedly not available at this domain
+		if host == "" {
+			continue
+		}
+		endpoints = append(endpoints, net.JoinHostPort(host, strconv.Itoa(int(r.Port))))
+	}
+	return endpoints, nil
+}
+
+func resolveURL(ctx context.Context, url string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received bad status code %d", resp.StatusCode)
+	}
+
+	var endpoints []string
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDiscoveryResponseSize)).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("failed to decode endpoints: %w", err)
+	}
+	for _, e := range endpoints {
+		if _, _, err := net.SplitHostPort(e); err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", e, err)
+		}
+	}
+	return endpoints, nil
+}
+
+// SelectEndpoint ... returns current if it is still one of the discovered endpoints, so that connections aren't
+// moved on every membership change, or the first discovered endpoint otherwise
+func SelectEndpoint(current string, endpoints []string) string {
+	if slices.Contains(endpoints, current) {
+		return current
+	}
+	return endpoints[0]
+}
+
+// WatchEndpoints ... resolves the endpoints of the discovery source every interval and invokes onChange with the
+// endpoint selected by SelectEndpoint whenever it differs from current, e.g. because the current endpoint was
+// removed from an autoscaled fleet. Resolution errors are passed to onErr and retried on the next tick, current
+// is kept meanwhile. Blocks until ctx is done.
+func WatchEndpoints(ctx context.Context, source, current string, interval time.Duration,
+	onChange func(endpoint string) error, onErr func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		resolveCtx, cancel := context.WithTimeout(ctx, interval)
+		endpoints, err := ResolveEndpoints(resolveCtx, source)
+		cancel()
+		if err != nil {
+			onErr(err)
+			continue
+		}
+
+		endpoint := SelectEndpoint(current, endpoints)
+		if endpoint == current {
+			continue
+		}
+		if err := onChange(endpoint); err != nil {
+			onErr(fmt.Errorf("failed to switch to discovered endpoint %s: %w", endpoint, err))
+			continue
+		}
+		current = endpoint
+	}
+}
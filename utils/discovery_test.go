@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDiscoverySource(t *testing.T) {
+	tests := []struct {
+		source  string
+		wantErr bool
+	}{
+		{"srv://_redis._tcp.cache.svc.cluster.local", false},
+		{"https://discovery.internal/redis", false},
+		{"http://127.0.0.1:8080/endpoints", false},
+		{"srv://", true},
+		{"redis.internal:6379", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		err := CheckDiscoverySource(tt.source)
+		if tt.wantErr {
+			require.Error(t, err, tt.source)
+		} else {
+			require.NoError(t, err, tt.source)
+		}
+	}
+}
+
+func TestResolveEndpointsSRV(t *testing.T) {
+	defer func(f func(context.Context, string, string, string) (string, []*net.SRV, error)) { lookupSRV = f }(lookupSRV)
+	lookupSRV = func(_ context.Context, _, _, name string) (string, []*net.SRV, error) {
+		require.Equal(t, "_redis._tcp.cache", name)
+		return name, []*net.SRV{
+			{Target: "c.cache.", Port: 6379, Priority: 20, Weight: 10},
+			{Target: "a.cache.", Port: 6379, Priority: 10, Weight: 5},
+			{Target: "b.cache.", Port: 6380, Priority: 10, Weight: 50},
+			{Target: ".", Port: 0, Priority: 0, Weight: 0},
+		}, nil
+	}
+
+	endpoints, err := ResolveEndpoints(context.Background(), "srv://_redis._tcp.cache")
+	require.NoError(t, err)
+	require.Equal(t, []string{"b.cache:6380", "a.cache:6379", "c.cache:6379"}, endpoints)
+}
+
+func TestResolveEndpointsURL(t *testing.T) {
+	var mu sync.Mutex
+	body := `["10.0.0.1:6379", "10.0.0.2:6379"]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	endpoints, err := ResolveEndpoints(context.Background(), srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.1:6379", "10.0.0.2:6379"}, endpoints)
+
+	for _, invalid := range []string{`[]`, `["10.0.0.1"]`, `{"endpoints": []}`} {
+		mu.Lock()
+		body = invalid
+		mu.Unlock()
+		_, err = ResolveEndpoints(context.Background(), srv.URL)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestSelectEndpoint(t *testing.T) {
+	endpoints := []string{"a:1", "b:1", "c:1"}
+	require.Equal(t, "b:1", SelectEndpoint("b:1", endpoints))
+	require.Equal(t, "a:1", SelectEndpoint("d:1", endpoints))
+	require.Equal(t, "a:1", SelectEndpoint("", endpoints))
+}
+
+func TestWatchEndpoints(t *testing.T) {
+	var mu sync.Mutex
+	endpoints := []string{"a:1", "b:1"}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_ = json.NewEncoder(w).Encode(endpoints)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string, 1)
+	go WatchEndpoints(ctx, srv.URL, "b:1", 10*time.Millisecond,
+		func(endpoint string) error {
+			changes <- endpoint
+			return nil
+		},
+		func(error) {},
+	)
+
+	// the current endpoint is kept while it is discovered
+	time.Sleep(50 * time.Millisecond)
+	require.Empty(t, changes)
+
+	mu.Lock()
+	endpoints = []string{"c:1", "a:1"}
+	mu.Unlock()
+
+	select {
+	case endpoint := <-changes:
+		require.Equal(t, "c:1", endpoint)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for endpoint change")
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda-proxy/secrets"
+)
+
+// DefaultFileWatchInterval ... how often mounted secret files are checked for changes
+const DefaultFileWatchInterval = 30 * time.Second
+
+// ReadSecretFile ... reads a secret from a mounted file, trimming surrounding whitespace
+// (secrets created with `echo` or `kubectl create secret --from-file` usually end with a newline)
+func ReadSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file %s: %w", path, err)
+	}
+
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return "", fmt.Errorf("secret file %s is empty", path)
+	}
+	secrets.Register(secret)
+	return secret, nil
+}
+
+// WatchSecretFile ... polls the secret file at path every interval and invokes onChange with the new secret
+// whenever the file's contents change. Polling on contents rather than relying on inotify events makes
+// this robust to the atomic symlink swaps Kubernetes uses to update projected volumes.
+// Read errors (e.g. mid-swap) are passed to onErr and retried on the next tick. Blocks until ctx is done.
+func WatchSecretFile(ctx context.Context, path string, interval time.Duration,
+	onChange func(secret string), onErr func(err error)) {
+	last, _ := os.ReadFile(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := os.ReadFile(path)
+		if err != nil {
+			onErr(fmt.Errorf("failed to read secret file %s: %w", path, err))
+			continue
+		}
+		if bytes.Equal(current, last) {
+			continue
+		}
+
+		secret := strings.TrimSpace(string(current))
+		if secret == "" {
+			onErr(fmt.Errorf("secret file %s is empty", path))
+			continue
+		}
+
+		last = current
+		secrets.Register(secret)
+		onChange(secret)
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+
+	require.NoError(t, os.WriteFile(path, []byte("hunter2\n"), 0600))
+	secret, err := ReadSecretFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", secret)
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0600))
+	_, err = ReadSecretFile(path)
+	require.Error(t, err)
+
+	_, err = ReadSecretFile(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestWatchSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string, 1)
+	go WatchSecretFile(ctx, path, 10*time.Millisecond,
+		func(secret string) { changes <- secret },
+		func(error) {},
+	)
+
+	// give the watcher time to read the initial contents
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("new\n"), 0600))
+
+	select {
+	case secret := <-changes:
+		require.Equal(t, "new", secret)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for secret file change")
+	}
+}
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// ByteSizeFlag ... string flag holding a byte size with a unit, e.g. 16MiB, which is parsed and checked against its
+// bounds when the flag is set, so that invalid sizes fail at startup naming the flag
+type ByteSizeFlag struct {
+	cli.StringFlag
+	// Min and Max bound the size, Max is ignored if 0
+	Min, Max uint64
+	// AllowZero accepts 0 regardless of Min, e.g. for sizes where 0 disables a feature
+	AllowZero bool
+}
+
+// RunAction ... validates the value of the flag before running its action
+func (f *ByteSizeFlag) RunAction(ctx *cli.Context) error {
+	if _, err := f.Parse(ctx.String(f.Name)); err != nil {
+		return err
+	}
+	return f.StringFlag.RunAction(ctx)
+}
+
+// Parse ... parses a value of the flag into a number of bytes and checks it against the flag's bounds
+func (f *ByteSizeFlag) Parse(s string) (uint64, error) {
+	n, err := ParseBytesAmount(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for %s: %w", s, f.Name, err)
+	}
+	if n == 0 && f.AllowZero {
+		return 0, nil
+	}
+	if n < f.Min || (f.Max > 0 && n > f.Max) {
+		bounds := describeBounds(FormatBytesAmount(f.Min), FormatBytesAmount(f.Max), f.Min > 0, f.Max > 0, f.AllowZero)
+		return 0, fmt.Errorf("%s must be %s, got %s", f.Name, bounds, s)
+	}
+	return n, nil
+}
+
+// DurationFlag ... duration flag which is checked against its bounds when the flag is set. Durations must not be
+// negative.
+type DurationFlag struct {
+	cli.DurationFlag
+	// Min and Max bound the duration, Max is ignored if 0
+	Min, Max time.Duration
+	// AllowZero accepts 0 regardless of Min, e.g. for intervals where 0 disables a feature
+	AllowZero bool
+}
+
+// RunAction ... validates the value of the flag before running its action
+func (f *DurationFlag) RunAction(ctx *cli.Context) error {
+	if err := f.Check(ctx.Duration(f.Name)); err != nil {
+		return err
+	}
+	return f.DurationFlag.RunAction(ctx)
+}
+
+// Check ... checks a value of the flag against the flag's bounds
+func (f *DurationFlag) Check(d time.Duration) error {
+	if d == 0 && f.AllowZero {
+		return nil
+	}
+	if d < 0 || d < f.Min || (f.Max > 0 && d > f.Max) {
+		bounds := describeBounds(FormatDuration(f.Min), FormatDuration(f.Max), f.Min > 0, f.Max > 0, f.AllowZero)
+		return fmt.Errorf("%s must be %s, got %s", f.Name, bounds, FormatDuration(d))
+	}
+	return nil
+}
+
+// describeBounds ... human readable range of the values accepted by a flag, e.g. "0 or ≥ 1m"
+func describeBounds(lo, hi string, hasMin, hasMax, allowZero bool) string {
+	var bounds string
+	switch {
+	case hasMin && hasMax:
+		bounds = fmt.Sprintf("between %s and %s", lo, hi)
+	case hasMax:
+		bounds = fmt.Sprintf("≤ %s", hi)
+	case hasMin:
+		bounds = fmt.Sprintf("≥ %s", lo)
+	default:
+		bounds = "≥ 0"
+	}
+	if allowZero && hasMin {
+		return "0 or " + bounds
+	}
+	return bounds
+}
+
+// FormatBytesAmount ... formats a number of bytes in the largest binary unit dividing it, e.g. 16MiB, so that
+// formatted sizes parse back to the same number of bytes
+func FormatBytesAmount(n uint64) string {
+	units := []string{"TiB", "GiB", "MiB", "KiB"}
+	for i, unit := range units {
+		size := uint64(1) << (10 * (len(units) - i))
+		if n >= size && n%size == 0 {
+			return fmt.Sprintf("%d%s", n/size, unit)
+		}
+	}
+	return fmt.Sprintf("%dB", n)
+}
+
+// FormatDuration ... formats a duration without trailing zero units, e.g. 1m rather than 1m0s
+func FormatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runFlag(f cli.Flag, args ...string) error {
+	app := &cli.App{
+		Name:      "test",
+		Flags:     []cli.Flag{f},
+		HideHelp:  true,
+		Writer:    io.Discard,
+		ErrWriter: io.Discard,
+		Action:    func(*cli.Context) error { return nil },
+	}
+	return app.Run(append([]string{"test"}, args...))
+}
+
+func TestByteSizeFlag(t *testing.T) {
+	var size string
+	f := &ByteSizeFlag{
+		StringFlag: cli.StringFlag{
+			Name:  "size",
+			Value: "0",
+			Action: func(_ *cli.Context, s string) error {
+				size = s
+				return nil
+			},
+		},
+		Min:       1024,
+		Max:       1 << 20,
+		AllowZero: true,
+	}
+
+	require.NoError(t, runFlag(f))
+	require.NoError(t, runFlag(f, "--size=0"))
+	require.NoError(t, runFlag(f, "--size=512KiB"))
+	require.Equal(t, "512KiB", size)
+
+	require.EqualError(t, runFlag(f, "--size=1000"), "size must be 0 or between 1KiB and 1MiB, got 1000")
+	require.EqualError(t, runFlag(f, "--size=2MB"), "size must be 0 or between 1KiB and 1MiB, got 2MB")
+	require.ErrorContains(t, runFlag(f, "--size=1XB"), "invalid value \"1XB\" for size: unsupported unit: xb")
+}
+
+func TestDurationFlag(t *testing.T) {
+	f := &DurationFlag{DurationFlag: cli.DurationFlag{Name: "interval"}}
+	require.NoError(t, runFlag(f, "--interval=0"))
+	require.EqualError(t, runFlag(f, "--interval=-1s"), "interval must be ≥ 0, got -1s")
+
+	f = &DurationFlag{DurationFlag: cli.DurationFlag{Name: "interval"}, Min: time.Minute, Max: time.Hour}
+	require.NoError(t, runFlag(f, "--interval=90s"))
+	require.EqualError(t, runFlag(f, "--interval=0"), "interval must be between 1m and 1h, got 0s")
+	require.EqualError(t, runFlag(f, "--interval=2h"), "interval must be between 1m and 1h, got 2h")
+}
+
+func TestFormatBytesAmount(t *testing.T) {
+	for n, s := range map[uint64]string{0: "0B", 1000: "1000B", 1024: "1KiB", 16 << 20: "16MiB", 1536 << 20: "1536MiB"} {
+		require.Equal(t, s, FormatBytesAmount(n))
+		parsed, err := ParseBytesAmount(s)
+		require.NoError(t, err)
+		require.Equal(t, n, parsed)
+	}
+}
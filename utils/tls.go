@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/urfave/cli/v2"
+)
+
+// TLSConfig ... TLS settings of the connection to a backend, e.g. one served with certificates of a private PKI
+type TLSConfig struct {
+	// CAFile is a PEM bundle of CAs trusted in addition to the system roots
+	CAFile string
+	// CertFile and KeyFile are the PEM encoded client certificate and key presented for mutual TLS
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the hostname the server certificate is verified against
+	ServerName string
+}
+
+// IsSet ... returns whether any setting differs from the defaults
+func (c TLSConfig) IsSet() bool {
+	return c != TLSConfig{}
+}
+
+// Check ... validates that the client certificate and key are set together
+func (c TLSConfig) Check() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return fmt.Errorf("tls client certificate and key must be set together")
+	}
+	return nil
+}
+
+// Load ... reads the CA bundle and client certificate, returning the resulting client TLS config
+func (c TLSConfig) Load() (*tls.Config, error) {
+	if err := c.Check(); err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", c.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// HTTPClient ... returns an HTTP client using the TLS config, or nil if it isn't set
+func (c TLSConfig) HTTPClient() (*http.Client, error) {
+	if !c.IsSet() {
+		return nil, nil
+	}
+	tlsCfg, err := c.Load()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsCfg
+	return &http.Client{Transport: transport}, nil
+}
+
+// TLSFlags ... flags of a TLSConfig, named <flagPrefix>.ca-file etc. with environment variables
+// <envPrefix>_CA_FILE etc. target names the connection in the flag usages.
+func TLSFlags(flagPrefix, envPrefix, target, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     flagPrefix + ".ca-file",
+			Usage:    fmt.Sprintf("PEM bundle of CAs trusted for the %s connection, in addition to the system roots.", target),
+			EnvVars:  []string{envPrefix + "_CA_FILE"},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + ".cert-file",
+			Usage:    fmt.Sprintf("PEM client certificate presented on the %s connection for mutual TLS.", target),
+			EnvVars:  []string{envPrefix + "_CERT_FILE"},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + ".key-file",
+			Usage:    fmt.Sprintf("PEM private key of the %s client certificate.", target),
+			EnvVars:  []string{envPrefix + "_KEY_FILE"},
+			Category: category,
+		},
+		&cli.StringFlag{
+			Name:     flagPrefix + ".server-name",
+			Usage:    fmt.Sprintf("Hostname the %s server certificate is verified against, if it differs from the endpoint's.", target),
+			EnvVars:  []string{envPrefix + "_SERVER_NAME"},
+			Category: category,
+		},
+	}
+}
+
+// ReadTLSConfig ... parses the TLSConfig of the flags created by TLSFlags with the same flagPrefix
+func ReadTLSConfig(ctx *cli.Context, flagPrefix string) TLSConfig {
+	return TLSConfig{
+		CAFile:     ctx.String(flagPrefix + ".ca-file"),
+		CertFile:   ctx.String(flagPrefix + ".cert-file"),
+		KeyFile:    ctx.String(flagPrefix + ".key-file"),
+		ServerName: ctx.String(flagPrefix + ".server-name"),
+	}
+}
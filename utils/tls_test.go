@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testPKI ... private CA issuing server and client certificates
+type testPKI struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	dir  string
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testPKI{cert: cert, key: key, dir: t.TempDir()}
+}
+
+// issue ... writes a certificate signed by the CA and its key, returning their paths
+func (p *testPKI) issue(t *testing.T, name string, usage x509.ExtKeyUsage) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, p.cert, &key.PublicKey, p.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(p.dir, name+".pem")
+	keyFile = filepath.Join(p.dir, name+"-key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func (p *testPKI) caFile(t *testing.T) string {
+	path := filepath.Join(p.dir, "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: p.cert.Raw}), 0600))
+	return path
+}
+
+func TestTLSConfig(t *testing.T) {
+	pki := newTestPKI(t)
+	serverCert, serverKey := pki.issue(t, "minio.internal", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := pki.issue(t, "proxy", x509.ExtKeyUsageClientAuth)
+
+	// server of the private PKI requiring client certificates
+	cert, err := tls.LoadX509KeyPair(serverCert, serverKey)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(pki.cert)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(cfg TLSConfig) error {
+		client, err := cfg.HTTPClient()
+		if err != nil {
+			return err
+		}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	t.Run("MutualTLS", func(t *testing.T) {
+		require.NoError(t, get(TLSConfig{CAFile: pki.caFile(t), CertFile: clientCert, KeyFile: clientKey}))
+	})
+
+	t.Run("ServerName", func(t *testing.T) {
+		cfg := TLSConfig{CAFile: pki.caFile(t), CertFile: clientCert, KeyFile: clientKey, ServerName: "minio.internal"}
+		require.NoError(t, get(cfg))
+
+		cfg.ServerName = "other.internal"
+		require.Error(t, get(cfg))
+	})
+
+	t.Run("UntrustedServer", func(t *testing.T) {
+		require.Error(t, get(TLSConfig{CertFile: clientCert, KeyFile: clientKey}))
+	})
+
+	t.Run("MissingClientCert", func(t *testing.T) {
+		require.Error(t, get(TLSConfig{CAFile: pki.caFile(t)}))
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := TLSConfig{CertFile: clientCert}.Load()
+		require.Error(t, err)
+
+		_, err = TLSConfig{CAFile: clientKey}.Load()
+		require.ErrorContains(t, err, "no certificates found")
+
+		_, err = TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Load()
+		require.Error(t, err)
+	})
+
+	t.Run("Unset", func(t *testing.T) {
+		require.False(t, TLSConfig{}.IsSet())
+		client, err := TLSConfig{}.HTTPClient()
+		require.NoError(t, err)
+		require.Nil(t, client)
+	})
+}
@@ -6,18 +6,24 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/exp/slices"
 )
 
 var ErrBatchMetadataHashNotFound = errors.New("BatchMetadataHash not found for BatchId")
 
+// svcManagerCheckTimeout ... timeout of the chain ID query checking the svc manager address
+const svcManagerCheckTimeout = 10 * time.Second
+
 // CertVerifier verifies the DA certificate against on-chain EigenDA contracts
 // to ensure disperser returned fields haven't been tampered with
 type CertVerifier struct {
@@ -30,10 +36,13 @@ type CertVerifier struct {
 func NewCertVerifier(cfg *Config, l log.Logger) (*CertVerifier, error) {
 	log.Info("Enabling certificate verification", "confirmation_depth", cfg.EthConfirmationDepth)
 
-	client, err := ethclient.Dial(cfg.RPCURL)
+	client, err := dialEthRPC(context.Background(), cfg.RPCURL, cfg.RPCTLS)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial ETH RPC node: %s", err.Error())
 	}
+	if err := checkSvcManager(client, cfg, l); err != nil {
+		return nil, err
+	}
 
 	// construct caller binding
 	m, err := binding.NewContractEigenDAServiceManagerCaller(common.HexToAddress(cfg.SvcManagerAddr), client)
@@ -49,6 +58,54 @@ func NewCertVerifier(cfg *Config, l log.Logger) (*CertVerifier, error) {
 	}, nil
 }
 
+// checkSvcManager ... checks the service manager address against the canonical deployments of the ETH RPC's
+// chain, as configured by cfg.SvcManagerCheck
+func checkSvcManager(client *ethclient.Client, cfg *Config, l log.Logger) error {
+	if cfg.SvcManagerCheck == SvcManagerCheckOff {
+		return nil
+	}
+	if l == nil {
+		l = log.Root()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), svcManagerCheckTimeout)
+	defer cancel()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		l.Warn("Failed to query the chain ID of the ETH RPC node, svc manager address isn't checked", "err", err)
+		return nil
+	}
+
+	err = CheckSvcManagerAddr(chainID.Uint64(), cfg.SvcManagerAddr)
+	switch {
+	case err == nil:
+		return nil
+	case cfg.SvcManagerCheck == SvcManagerCheckFail:
+		return err
+	default:
+		l.Warn("Svc manager address doesn't match the EigenDA deployment of the ETH RPC's chain, cert verification will likely fail",
+			"err", err)
+		return nil
+	}
+}
+
+// dialEthRPC ... connects to an Ethereum JSON RPC node over HTTP(S), using the TLS config if set
+func dialEthRPC(ctx context.Context, url string, tlsCfg utils.TLSConfig) (*ethclient.Client, error) {
+	if !tlsCfg.IsSet() {
+		return ethclient.DialContext(ctx, url)
+	}
+
+	httpClient, err := tlsCfg.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	client, err := rpc.DialOptions(ctx, url, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(client), nil
+}
+
 // verifies on-chain batch ID for equivalence to certificate batch header fields
 func (cv *CertVerifier) VerifyBatch(
 	header *binding.IEigenDAServiceManagerBatchHeader, id uint32, recordHash [32]byte, confirmationNumber uint32,
@@ -1,11 +1,18 @@
 package verify
 
 import (
+	"crypto/sha256"
+	"errors"
 	"math/big"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// VersionedHashVersionKZG ... version byte of EIP-4844 style versioned hashes of KZG commitments
+const VersionedHashVersionKZG byte = 0x01
+
 // G1Point struct to represent G1Point in Solidity
 type G1Point struct {
 	X *big.Int
@@ -63,3 +70,20 @@ func (c *Certificate) ReadBlobHeader() BlobHeader {
 func (c *Certificate) Proof() *disperser.BlobVerificationProof {
 	return c.BlobVerificationProof
 }
+
+// VersionedHash ... returns an EIP-4844 style versioned hash of the blob's KZG commitment, i.e.
+// VersionedHashVersionKZG || sha256(commitment)[1:], where commitment is the compressed bn254 G1 point.
+func (c *Certificate) VersionedHash() (common.Hash, error) {
+	if c.BlobHeader == nil || c.BlobHeader.Commitment == nil {
+		return common.Hash{}, errors.New("certificate has no blob commitment")
+	}
+
+	var p bn254.G1Affine
+	p.X.SetBytes(c.BlobHeader.Commitment.X)
+	p.Y.SetBytes(c.BlobHeader.Commitment.Y)
+	compressed := p.Bytes()
+
+	h := sha256.Sum256(compressed[:])
+	h[0] = VersionedHashVersionKZG
+	return h, nil
+}
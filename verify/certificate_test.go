@@ -0,0 +1,35 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedHash(t *testing.T) {
+	x, err := hex.DecodeString("1021d699eac68ce312196d480266e8b82fd5fe5c4311e53313837b64db6df178")
+	require.NoError(t, err)
+	y, err := hex.DecodeString("02efa5a7813233ae13f32bae9b8f48252fa45c1b06a5d70bed471a9bea8d98ae")
+	require.NoError(t, err)
+
+	cert := &Certificate{BlobHeader: &disperser.BlobHeader{Commitment: &common.G1Commitment{X: x, Y: y}}}
+	vh, err := cert.VersionedHash()
+	require.NoError(t, err)
+
+	var p bn254.G1Affine
+	p.X.SetBytes(x)
+	p.Y.SetBytes(y)
+	compressed := p.Bytes()
+	expected := sha256.Sum256(compressed[:])
+
+	require.Equal(t, VersionedHashVersionKZG, vh[0])
+	require.Equal(t, expected[1:], vh[1:])
+
+	_, err = (&Certificate{}).VersionedHash()
+	require.Error(t, err)
+}
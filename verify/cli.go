@@ -1,7 +1,6 @@
 package verify
 
 import (
-	"fmt"
 	"runtime"
 
 	"github.com/Layr-Labs/eigenda-proxy/utils"
@@ -27,13 +26,17 @@ var (
 	CertVerificationEnabledFlagName = withFlagPrefix("cert-verification-enabled")
 	EthRPCFlagName                  = withFlagPrefix("eth-rpc")
 	SvcManagerAddrFlagName          = withFlagPrefix("svc-manager-addr")
+	SvcManagerCheckFlagName         = withFlagPrefix("svc-manager-check")
 	EthConfirmationDepthFlagName    = withFlagPrefix("eth-confirmation-depth")
+	EthRPCTLSFlagPrefix             = withFlagPrefix("eth-rpc-tls")
 
 	// kzg flags
-	G1PathFlagName        = withFlagPrefix("g1-path")
-	G2TauFlagName         = withFlagPrefix("g2-tau-path")
-	CachePathFlagName     = withFlagPrefix("cache-path")
-	MaxBlobLengthFlagName = withFlagPrefix("max-blob-length")
+	G1PathFlagName          = withFlagPrefix("g1-path")
+	G2TauFlagName           = withFlagPrefix("g2-tau-path")
+	CachePathFlagName       = withFlagPrefix("cache-path")
+	MaxBlobLengthFlagName   = withFlagPrefix("max-blob-length")
+	AllowMissingSRSFlagName = withFlagPrefix("allow-missing-srs")
+	ReadQuorumsFlagName     = withFlagPrefix("read-quorums")
 )
 
 func withFlagPrefix(s string) string {
@@ -47,7 +50,7 @@ func withEnvPrefix(envPrefix, s string) []string {
 // CLIFlags ... used for Verifier configuration
 // category is used to group the flags in the help output (see https://cli.urfave.org/v2/examples/flags/#grouping)
 func CLIFlags(envPrefix, category string) []cli.Flag {
-	return []cli.Flag{
+	flags := []cli.Flag{
 		&cli.BoolFlag{
 			Name:    CertVerificationEnabledFlagName,
 			Usage:   "Whether to verify certificates received from EigenDA disperser.",
@@ -68,6 +71,13 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			EnvVars:  withEnvPrefix(envPrefix, "SERVICE_MANAGER_ADDR"),
 			Category: category,
 		},
+		&cli.StringFlag{
+			Name:     SvcManagerCheckFlagName,
+			Usage:    "How a svc manager address not matching the canonical EigenDA deployment of the eth rpc's chain is handled: warn, fail or off. Chains without a known deployment aren't checked.",
+			EnvVars:  withEnvPrefix(envPrefix, "SERVICE_MANAGER_CHECK"),
+			Value:    string(SvcManagerCheckWarn),
+			Category: category,
+		},
 		&cli.Uint64Flag{
 			Name:     EthConfirmationDepthFlagName,
 			Usage:    "The number of Ethereum blocks to wait before considering a submitted blob's DA batch submission confirmed. `0` means wait for inclusion only.",
@@ -78,7 +88,7 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 		// kzg flags
 		&cli.StringFlag{
 			Name:    G1PathFlagName,
-			Usage:   "Directory path to g1.point file.",
+			Usage:   "Directory path to g1.point file. Compressed or uncompressed points in monomial or lagrange basis are detected, and converted into the cache path if needed.",
 			EnvVars: withEnvPrefix(envPrefix, "TARGET_KZG_G1_PATH"),
 			// we use a relative path so that the path works for both the binary and the docker container
 			// aka we assume the binary is run from root dir, and that the resources/ dir is copied into the working dir of the container
@@ -103,35 +113,43 @@ func CLIFlags(envPrefix, category string) []cli.Flag {
 			Value:    "resources/SRSTables/",
 			Category: category,
 		},
-		// TODO: can we use a genericFlag for this, and automatically parse the string into a uint64?
-		&cli.StringFlag{
-			Name:    MaxBlobLengthFlagName,
-			Usage:   "Maximum blob length to be written or read from EigenDA. Determines the number of SRS points loaded into memory for KZG commitments. Example units: '30MiB', '4Kb', '30MB'. Maximum size slightly exceeds 1GB.",
-			EnvVars: withEnvPrefix(envPrefix, "MAX_BLOB_LENGTH"),
-			Value:   "16MiB",
-			// set to true to force action to run on the default Value
-			// see https://github.com/urfave/cli/issues/1973
-			HasBeenSet: true,
-			Action: func(_ *cli.Context, maxBlobLengthStr string) error {
-				// parse the string to a uint64 and set the maxBlobLengthBytes var to be used by ReadConfig()
-				numBytes, err := utils.ParseBytesAmount(maxBlobLengthStr)
-				if err != nil {
-					return fmt.Errorf("failed to parse max blob length flag: %w", err)
-				}
-				if numBytes == 0 {
-					return fmt.Errorf("max blob length is 0")
-				}
-				if numBytes > MaxAllowedBlobSize {
-					return fmt.Errorf("excluding disperser constraints on max blob size, SRS points constrain the maxBlobLength configuration parameter to be less than than %d bytes", MaxAllowedBlobSize)
-				}
-				MaxBlobLengthBytes = numBytes
-				return nil
-			},
-			// we also use this flag for memstore.
-			// should we duplicate the flag? Or is there a better way to handle this?
+		&cli.BoolFlag{
+			Name:     AllowMissingSRSFlagName,
+			Usage:    "Start in a degraded cert-only mode instead of failing if the SRS files can't be loaded, skipping local KZG commitment checks. Requires cert verification to be enabled.",
+			EnvVars:  withEnvPrefix(envPrefix, "ALLOW_MISSING_SRS"),
+			Value:    false,
+			Category: category,
+		},
+		&cli.UintSliceFlag{
+			Name:     ReadQuorumsFlagName,
+			Usage:    "Quorums whose confirmation is required when verifying the certs of blobs being read, e.g. 0 to keep serving certs confirmed by quorum 0 only while another quorum is degraded. Checks of other quorums are ignored. If unset, reads require the quorums required by the EigenDA service manager, like writes do.",
+			EnvVars:  withEnvPrefix(envPrefix, "READ_QUORUMS"),
 			Category: category,
 		},
+		&utils.ByteSizeFlag{
+			StringFlag: cli.StringFlag{
+				Name:    MaxBlobLengthFlagName,
+				Usage:   "Maximum blob length to be written or read from EigenDA. Determines the number of SRS points loaded into memory for KZG commitments. Example units: '30MiB', '4Kb', '30MB'. Maximum size slightly exceeds 1GB.",
+				EnvVars: withEnvPrefix(envPrefix, "MAX_BLOB_LENGTH"),
+				Value:   "16MiB",
+				// set to true to force action to run on the default Value
+				// see https://github.com/urfave/cli/issues/1973
+				HasBeenSet: true,
+				Action: func(_ *cli.Context, maxBlobLengthStr string) error {
+					// set the maxBlobLengthBytes var to be used by ReadConfig(), the length has already been
+					// checked against the SRS points constraining it
+					MaxBlobLengthBytes, _ = utils.ParseBytesAmount(maxBlobLengthStr)
+					return nil
+				},
+				// we also use this flag for memstore.
+				// should we duplicate the flag? Or is there a better way to handle this?
+				Category: category,
+			},
+			Min: 1,
+			Max: MaxAllowedBlobSize,
+		},
 	}
+	return append(flags, utils.TLSFlags(EthRPCTLSFlagPrefix, envPrefix+"_EIGENDA_ETH_RPC_TLS", "Ethereum RPC", category)...)
 }
 
 // this var is set by the action in the MaxBlobLengthFlagName flag
@@ -152,7 +170,11 @@ func ReadConfig(ctx *cli.Context) Config {
 		KzgConfig:            kzgCfg,
 		VerifyCerts:          ctx.Bool(CertVerificationEnabledFlagName),
 		RPCURL:               ctx.String(EthRPCFlagName),
+		RPCTLS:               utils.ReadTLSConfig(ctx, EthRPCTLSFlagPrefix),
 		SvcManagerAddr:       ctx.String(SvcManagerAddrFlagName),
+		SvcManagerCheck:      SvcManagerCheck(ctx.String(SvcManagerCheckFlagName)),
 		EthConfirmationDepth: uint64(ctx.Int64(EthConfirmationDepthFlagName)), // #nosec G115
+		AllowMissingSRS:      ctx.Bool(AllowMissingSRSFlagName),
+		ReadQuorums:          ctx.UintSlice(ReadQuorumsFlagName),
 	}
 }
@@ -0,0 +1,46 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+// NetworkParams ... security and retention parameters of the EigenDA network, as configured in the EigenDA
+// service manager
+type NetworkParams struct {
+	// RequiredQuorums are the quorums every blob must be confirmed by
+	RequiredQuorums []uint8
+	// AdversaryThresholdPercentages and ConfirmationThresholdPercentages are indexed by quorum number
+	AdversaryThresholdPercentages    []uint8
+	ConfirmationThresholdPercentages []uint8
+	// StoreDurationBlocks is the number of Ethereum blocks operators store a blob for after its confirmation
+	StoreDurationBlocks uint32
+}
+
+// NetworkParams ... reads the network parameters from the EigenDA service manager. Requires cert verification
+// to be enabled, since the service manager is only dialed then.
+func (v *Verifier) NetworkParams(ctx context.Context) (NetworkParams, error) {
+	if v.cv == nil {
+		return NetworkParams{}, errors.New("cert verification is disabled")
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	var p NetworkParams
+	var err error
+	if p.RequiredQuorums, err = v.cv.manager.QuorumNumbersRequired(opts); err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get required quorum numbers: %w", err)
+	}
+	if p.AdversaryThresholdPercentages, err = v.cv.manager.QuorumAdversaryThresholdPercentages(opts); err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get quorum adversary threshold percentages: %w", err)
+	}
+	if p.ConfirmationThresholdPercentages, err = v.cv.manager.QuorumConfirmationThresholdPercentages(opts); err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get quorum confirmation threshold percentages: %w", err)
+	}
+	if p.StoreDurationBlocks, err = v.cv.manager.STOREDURATIONBLOCKS(opts); err != nil {
+		return NetworkParams{}, fmt.Errorf("failed to get store duration: %w", err)
+	}
+	return p, nil
+}
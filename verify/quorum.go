@@ -0,0 +1,50 @@
+package verify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QuorumPolicyDefault ... name of the read quorum policy requiring the quorums required by the EigenDA service
+// manager, the same as when writing
+const QuorumPolicyDefault = "default"
+
+// QuorumPolicy ... quorums whose confirmation a cert must carry to be accepted when reading. Checks of the cert's
+// other quorums are ignored, e.g. to keep serving certs confirmed by quorum 0 only while another quorum is
+// degraded. An empty policy requires the quorums required by the EigenDA service manager, like writes do.
+type QuorumPolicy []uint8
+
+// NewQuorumPolicy ... returns the policy requiring quorums, all of which must be valid quorum numbers
+func NewQuorumPolicy(quorums []uint) (QuorumPolicy, error) {
+	p := make(QuorumPolicy, 0, len(quorums))
+	for _, q := range quorums {
+		if q > 255 {
+			return nil, fmt.Errorf("invalid quorum number %d", q)
+		}
+		p = append(p, uint8(q)) // #nosec G115
+	}
+	return p, nil
+}
+
+// requires ... returns whether the policy requires the confirmation of quorum
+func (p QuorumPolicy) requires(quorum uint8) bool {
+	for _, q := range p {
+		if q == quorum {
+			return true
+		}
+	}
+	return false
+}
+
+// String ... returns the comma separated quorums of the policy, or QuorumPolicyDefault
+func (p QuorumPolicy) String() string {
+	if len(p) == 0 {
+		return QuorumPolicyDefault
+	}
+	qs := make([]string, len(p))
+	for i, q := range p {
+		qs[i] = strconv.Itoa(int(q))
+	}
+	return strings.Join(qs, ",")
+}
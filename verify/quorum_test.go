@@ -0,0 +1,22 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumPolicy(t *testing.T) {
+	p, err := NewQuorumPolicy(nil)
+	require.NoError(t, err)
+	require.Equal(t, QuorumPolicyDefault, p.String())
+
+	p, err = NewQuorumPolicy([]uint{0, 2})
+	require.NoError(t, err)
+	require.Equal(t, "0,2", p.String())
+	require.True(t, p.requires(0))
+	require.False(t, p.requires(1))
+
+	_, err = NewQuorumPolicy([]uint{256})
+	require.Error(t, err)
+}
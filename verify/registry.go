@@ -0,0 +1,72 @@
+package verify
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SvcManagerCheck ... how a service manager address not matching the canonical deployment of the Ethereum
+// RPC's chain is handled
+type SvcManagerCheck string
+
+const (
+	// SvcManagerCheckWarn ... a mismatch is logged, the proxy starts anyway
+	SvcManagerCheckWarn SvcManagerCheck = "warn"
+	// SvcManagerCheckFail ... a mismatch fails the startup
+	SvcManagerCheckFail SvcManagerCheck = "fail"
+	// SvcManagerCheckOff ... the address isn't checked
+	SvcManagerCheckOff SvcManagerCheck = "off"
+)
+
+// ErrSvcManagerMismatch ... returned when the service manager address isn't the canonical deployment of its chain
+var ErrSvcManagerMismatch = errors.New("svc manager address doesn't match the EigenDA deployment of the chain")
+
+// Deployment ... a canonical EigenDA deployment
+type Deployment struct {
+	Network        string         `json:"network"`
+	ChainID        uint64         `json:"chain_id"`
+	SvcManagerAddr common.Address `json:"svc_manager_addr"`
+}
+
+//go:embed registry.json
+var registryJSON []byte
+
+// deployments ... the embedded registry of canonical deployments
+var deployments = func() []Deployment {
+	var d []Deployment
+	if err := json.Unmarshal(registryJSON, &d); err != nil {
+		panic(fmt.Sprintf("invalid embedded EigenDA deployment registry: %v", err))
+	}
+	return d
+}()
+
+// Deployments ... returns the canonical EigenDA deployments, several networks may share a chain
+func Deployments() []Deployment {
+	return append([]Deployment(nil), deployments...)
+}
+
+// CheckSvcManagerAddr ... returns ErrSvcManagerMismatch if chainID has canonical EigenDA deployments and addr
+// isn't the service manager of any of them. Addresses on chains without a known deployment, e.g. local devnets,
+// can't be checked and pass.
+func CheckSvcManagerAddr(chainID uint64, addr string) error {
+	var expected []string
+	for _, d := range deployments {
+		if d.ChainID != chainID {
+			continue
+		}
+		if common.IsHexAddress(addr) && common.HexToAddress(addr) == d.SvcManagerAddr {
+			return nil
+		}
+		expected = append(expected, fmt.Sprintf("%s (%s)", d.SvcManagerAddr.Hex(), d.Network))
+	}
+	if len(expected) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s is configured for chain %d, expected %s", ErrSvcManagerMismatch, addr, chainID,
+		strings.Join(expected, " or "))
+}
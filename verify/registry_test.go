@@ -0,0 +1,39 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSvcManagerAddr(t *testing.T) {
+	tests := []struct {
+		name        string
+		chainID     uint64
+		addr        string
+		expectError bool
+	}{
+		{name: "Mainnet", chainID: 1, addr: "0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0"},
+		{name: "MainnetLowercase", chainID: 1, addr: strings.ToLower("0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0")},
+		{name: "HoleskyOnMainnet", chainID: 1, addr: "0xD4A7E1Bd8015057293f0D0A557088c286942e84b", expectError: true},
+		{name: "Holesky", chainID: 17000, addr: "0xD4A7E1Bd8015057293f0D0A557088c286942e84b"},
+		{name: "PreprodHolesky", chainID: 17000, addr: "0x54A03db2784E3D0aCC08344D05385d0b62d4F432"},
+		{name: "MainnetOnHolesky", chainID: 17000, addr: "0x870679E138bCdf293b7Ff14dD44b70FC97e12fc0", expectError: true},
+		{name: "Malformed", chainID: 1, addr: "0x1234", expectError: true},
+		{name: "UnknownChain", chainID: 900, addr: "0x1234567890123456789012345678901234567890"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSvcManagerAddr(tt.chainID, tt.addr)
+			if tt.expectError {
+				require.ErrorIs(t, err, ErrSvcManagerMismatch)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	require.Len(t, Deployments(), 3)
+}
@@ -0,0 +1,247 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SRSEncoding ... encoding of the G1 points of an SRS file
+type SRSEncoding string
+
+const (
+	// SRSCompressed ... 32 byte points, the only encoding the kzg library loads
+	SRSCompressed SRSEncoding = "compressed"
+	// SRSUncompressed ... 64 byte points holding both coordinates
+	SRSUncompressed SRSEncoding = "uncompressed"
+)
+
+// SRSBasis ... basis of the G1 points of an SRS file
+type SRSBasis string
+
+const (
+	// SRSMonomial ... [τ^i]G, the basis commitments are computed in
+	SRSMonomial SRSBasis = "monomial"
+	// SRSLagrange ... [L_i(τ)]G over the roots of unity of the file's size, a power of 2
+	SRSLagrange SRSBasis = "lagrange"
+)
+
+// SRSFormat ... format of a G1 SRS file, see DetectSRSFormat
+type SRSFormat struct {
+	Encoding SRSEncoding
+	Basis    SRSBasis
+}
+
+// native ... returns whether the kzg library can load the file as is
+func (f SRSFormat) native() bool {
+	return f.Encoding == SRSCompressed && f.Basis == SRSMonomial
+}
+
+func (f SRSFormat) pointSize() int64 {
+	if f.Encoding == SRSUncompressed {
+		return bn254.SizeOfG1AffineUncompressed
+	}
+	return bn254.SizeOfG1AffineCompressed
+}
+
+// DetectSRSFormat ... detects the format of a G1 SRS file from its first point. The encoding is read from the
+// flag bits of the point's first byte, and the basis from the point itself: the first point of a monomial SRS is
+// always the generator.
+func DetectSRSFormat(path string) (SRSFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return SRSFormat{}, err
+	}
+	defer f.Close()
+
+	var head [bn254.SizeOfG1AffineUncompressed]byte
+	n, err := io.ReadFull(f, head[:])
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return SRSFormat{}, fmt.Errorf("failed to read SRS file header: %w", err)
+	}
+
+	// compressed points set the most significant bit, uncompressed ones leave both flag bits unset
+	format := SRSFormat{Encoding: SRSUncompressed}
+	switch head[0] >> 6 {
+	case 0b10, 0b11:
+		format.Encoding = SRSCompressed
+	case 0b00:
+	default:
+		return SRSFormat{}, fmt.Errorf("SRS file starts with the point at infinity")
+	}
+	if int64(n) < format.pointSize() {
+		return SRSFormat{}, fmt.Errorf("SRS file is shorter than a point")
+	}
+
+	var first bn254.G1Affine
+	if _, err := first.SetBytes(head[:format.pointSize()]); err != nil {
+		return SRSFormat{}, fmt.Errorf("invalid first SRS point: %w", err)
+	}
+	format.Basis = SRSLagrange
+	if first.Equal(&kzg.GenG1) {
+		format.Basis = SRSMonomial
+	}
+	return format, nil
+}
+
+// prepareG1 ... returns the kzg config loading the G1 points of cfg, converted to compressed monomial points if
+// the G1 file is in another format. Converted points are cached in the cache dir, so that they are only
+// converted once.
+func prepareG1(cfg *kzg.KzgConfig, l log.Logger) (*kzg.KzgConfig, error) {
+	format, err := DetectSRSFormat(cfg.G1Path)
+	if err != nil {
+		return nil, err
+	}
+	if format.native() {
+		return cfg, nil
+	}
+
+	src, err := os.Stat(cfg.G1Path)
+	if err != nil {
+		return nil, err
+	}
+	cachePath := filepath.Join(cfg.CacheDir, fmt.Sprintf("%s.%d.point", filepath.Base(cfg.G1Path), cfg.SRSNumberToLoad))
+	if cached, err := os.Stat(cachePath); err == nil && cached.ModTime().After(src.ModTime()) &&
+		cached.Size() == int64(cfg.SRSNumberToLoad)*bn254.SizeOfG1AffineCompressed { // #nosec G115
+		return withG1Path(cfg, cachePath), nil
+	}
+
+	if l != nil {
+		l.Info("Converting SRS file to compressed monomial points", "path", cfg.G1Path,
+			"encoding", format.Encoding, "basis", format.Basis, "points", cfg.SRSNumberToLoad, "cache", cachePath)
+	}
+	points, err := readG1File(cfg.G1Path, format, src.Size(), cfg.SRSNumberToLoad)
+	if err != nil {
+		return nil, err
+	}
+	if format.Basis == SRSLagrange {
+		points = lagrangeToMonomial(points, int(cfg.NumWorker)) // #nosec G115
+		// the conversion is only right if the points were the lagrange basis over the domain of the file's size
+		if !points[0].Equal(&kzg.GenG1) {
+			return nil, fmt.Errorf("SRS file %s isn't an SRS in monomial or lagrange basis", cfg.G1Path)
+		}
+	}
+	if uint64(len(points)) < cfg.SRSNumberToLoad {
+		return nil, fmt.Errorf("SRS file %s holds %d points, %d required", cfg.G1Path, len(points), cfg.SRSNumberToLoad)
+	}
+	if err := writeG1File(cachePath, points[:cfg.SRSNumberToLoad]); err != nil {
+		return nil, fmt.Errorf("failed to cache converted SRS points: %w", err)
+	}
+	return withG1Path(cfg, cachePath), nil
+}
+
+func withG1Path(cfg *kzg.KzgConfig, path string) *kzg.KzgConfig {
+	c := *cfg
+	c.G1Path = path
+	return &c
+}
+
+// readG1File ... reads the points of a G1 SRS file. Monomial files are only read up to the n points loaded, while
+// lagrange files are read entirely since every point of the basis is needed for the conversion.
+func readG1File(path string, format SRSFormat, size int64, n uint64) ([]bn254.G1Affine, error) {
+	count := size / format.pointSize()
+	if format.Basis == SRSMonomial {
+		count = min(count, int64(n)) // #nosec G115
+	} else if count&(count-1) != 0 {
+		return nil, fmt.Errorf("lagrange SRS file %s holds %d points, which isn't a power of 2", path, count)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, count*format.pointSize())
+	if _, err := io.ReadFull(f, buf); err != nil {
+		return nil, fmt.Errorf("failed to read SRS file: %w", err)
+	}
+	points := make([]bn254.G1Affine, count)
+	for i := range points {
+		if _, err := points[i].SetBytes(buf[int64(i)*format.pointSize():]); err != nil {
+			return nil, fmt.Errorf("invalid SRS point %d: %w", i, err)
+		}
+	}
+	return points, nil
+}
+
+// writeG1File ... writes compressed points to path, through a temporary file so that a partially written file is
+// never loaded
+func writeG1File(path string, points []bn254.G1Affine) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	buf := make([]byte, 0, len(points)*bn254.SizeOfG1AffineCompressed)
+	for i := range points {
+		b := points[i].Bytes()
+		buf = append(buf, b[:]...)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o644); err != nil { // #nosec G306
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// lagrangeToMonomial ... converts the lagrange basis [L_j(τ)]G over the domain of size len(points) to the
+// monomial basis [τ^i]G. Since x^i = Σ_j ω^(ij) L_j(x) on the domain, the monomial points are the FFT of the
+// lagrange points, computed with the butterflies spread over workers.
+func lagrangeToMonomial(points []bn254.G1Affine, workers int) []bn254.G1Affine {
+	n := len(points)
+	if n == 1 {
+		return points
+	}
+	workers = max(workers, 1)
+
+	// bit-reversed input order for the in-place iterative FFT
+	logN := bits.TrailingZeros(uint(n))
+	jac := make([]bn254.G1Jac, n)
+	for i := range points {
+		jac[bits.Reverse(uint(i))>>(bits.UintSize-logN)].FromAffine(&points[i])
+	}
+
+	domain := fft.NewDomain(uint64(n))
+	twiddles := make([]big.Int, n/2)
+	var w fr.Element
+	w.SetOne()
+	for k := range twiddles {
+		w.BigInt(&twiddles[k])
+		w.Mul(&w, &domain.Generator)
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		half, stride := size/2, n/size
+		butterflies := n / 2
+		var wg sync.WaitGroup
+		chunk := (butterflies + workers - 1) / workers
+		for start := 0; start < butterflies; start += chunk {
+			end := min(start+chunk, butterflies)
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				var t bn254.G1Jac
+				for b := start; b < end; b++ {
+					// butterfly b pairs the k-th elements of the halves of its block
+					block, k := b/half, b%half
+					i := block*size + k
+					t.ScalarMultiplication(&jac[i+half], &twiddles[k*stride])
+					jac[i+half].Set(&jac[i]).SubAssign(&t)
+					jac[i].AddAssign(&t)
+				}
+			}(start, end)
+		}
+		wg.Wait()
+	}
+	return bn254.BatchJacobianToAffineG1(jac)
+}
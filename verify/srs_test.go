@@ -0,0 +1,147 @@
+package verify
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/stretchr/testify/require"
+)
+
+// toySRS ... monomial and lagrange bases of an SRS of n points with a known τ
+func toySRS(t *testing.T, n int) (monomial, lagrange []bn254.G1Affine) {
+	t.Helper()
+	var tau fr.Element
+	tau.SetUint64(1234567)
+	domain := fft.NewDomain(uint64(n))
+
+	mul := func(s fr.Element) bn254.G1Affine {
+		var b big.Int
+		var p bn254.G1Affine
+		p.ScalarMultiplication(&kzg.GenG1, s.BigInt(&b))
+		return p
+	}
+
+	powers := make([]fr.Element, n)
+	powers[0].SetOne()
+	for i := 1; i < n; i++ {
+		powers[i].Mul(&powers[i-1], &tau)
+	}
+	for i := range powers {
+		monomial = append(monomial, mul(powers[i]))
+	}
+
+	// L_j(τ) = 1/n Σ_i ω^(-ij) τ^i
+	for j := 0; j < n; j++ {
+		var wj, w, l, term fr.Element
+		wj.Exp(domain.GeneratorInv, big.NewInt(int64(j)))
+		w.SetOne()
+		for i := 0; i < n; i++ {
+			term.Mul(&powers[i], &w)
+			l.Add(&l, &term)
+			w.Mul(&w, &wj)
+		}
+		l.Mul(&l, &domain.CardinalityInv)
+		lagrange = append(lagrange, mul(l))
+	}
+	return monomial, lagrange
+}
+
+func writePoints(t *testing.T, path string, points []bn254.G1Affine, compressed bool) {
+	t.Helper()
+	var buf []byte
+	for i := range points {
+		if compressed {
+			b := points[i].Bytes()
+			buf = append(buf, b[:]...)
+		} else {
+			b := points[i].RawBytes()
+			buf = append(buf, b[:]...)
+		}
+	}
+	require.NoError(t, os.WriteFile(path, buf, 0o600))
+}
+
+func TestDetectSRSFormat(t *testing.T) {
+	format, err := DetectSRSFormat("../resources/g1.point")
+	require.NoError(t, err)
+	require.Equal(t, SRSFormat{Encoding: SRSCompressed, Basis: SRSMonomial}, format)
+
+	monomial, lagrange := toySRS(t, 8)
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		points     []bn254.G1Affine
+		compressed bool
+		want       SRSFormat
+	}{
+		{monomial, false, SRSFormat{Encoding: SRSUncompressed, Basis: SRSMonomial}},
+		{lagrange, true, SRSFormat{Encoding: SRSCompressed, Basis: SRSLagrange}},
+		{lagrange, false, SRSFormat{Encoding: SRSUncompressed, Basis: SRSLagrange}},
+	} {
+		path := filepath.Join(dir, "g1.point")
+		writePoints(t, path, tt.points, tt.compressed)
+		format, err := DetectSRSFormat(path)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, format)
+	}
+
+	path := filepath.Join(dir, "short.point")
+	require.NoError(t, os.WriteFile(path, []byte{0x80, 1}, 0o600))
+	_, err = DetectSRSFormat(path)
+	require.Error(t, err)
+}
+
+func TestPrepareG1(t *testing.T) {
+	monomial, lagrange := toySRS(t, 16)
+
+	for name, tt := range map[string]struct {
+		points     []bn254.G1Affine
+		compressed bool
+	}{
+		"compressed monomial":   {monomial, true},
+		"uncompressed monomial": {monomial, false},
+		"compressed lagrange":   {lagrange, true},
+		"uncompressed lagrange": {lagrange, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			cfg := &kzg.KzgConfig{
+				G1Path:          filepath.Join(dir, "g1.point"),
+				CacheDir:        filepath.Join(dir, "SRSTables"),
+				SRSNumberToLoad: 10,
+				NumWorker:       4,
+			}
+			writePoints(t, cfg.G1Path, tt.points, tt.compressed)
+
+			prepared, err := prepareG1(cfg, nil)
+			require.NoError(t, err)
+			if name == "compressed monomial" {
+				require.Same(t, cfg, prepared)
+			} else {
+				require.NotEqual(t, cfg.G1Path, prepared.G1Path)
+			}
+
+			points, err := kzg.ReadG1Points(prepared.G1Path, cfg.SRSNumberToLoad, cfg.NumWorker)
+			require.NoError(t, err)
+			require.Equal(t, monomial[:cfg.SRSNumberToLoad], points)
+
+			// converted points are reused
+			again, err := prepareG1(cfg, nil)
+			require.NoError(t, err)
+			require.Equal(t, prepared.G1Path, again.G1Path)
+		})
+	}
+
+	t.Run("not an SRS", func(t *testing.T) {
+		dir := t.TempDir()
+		cfg := &kzg.KzgConfig{G1Path: filepath.Join(dir, "g1.point"), CacheDir: dir, SRSNumberToLoad: 4, NumWorker: 1}
+		writePoints(t, cfg.G1Path, append([]bn254.G1Affine{monomial[1]}, monomial[:7]...), true)
+		_, err := prepareG1(cfg, nil)
+		require.Error(t, err)
+	})
+}
@@ -1,7 +1,10 @@
 package verify
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
@@ -10,6 +13,7 @@ import (
 
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
 
+	"github.com/Layr-Labs/eigenda-proxy/utils"
 	"github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
@@ -23,42 +27,145 @@ type Config struct {
 	RPCURL               string
 	SvcManagerAddr       string
 	EthConfirmationDepth uint64
+	// SvcManagerCheck is how a SvcManagerAddr not matching the canonical deployment of RPCURL's chain is handled,
+	// warn if empty
+	SvcManagerCheck SvcManagerCheck
+	// RPCTLS is the TLS config of the connection to RPCURL
+	RPCTLS utils.TLSConfig
+	// AllowMissingSRS starts the verifier in cert-only mode if the KZG SRS can't be loaded, skipping local
+	// KZG commitment checks. Only allowed together with VerifyCerts.
+	AllowMissingSRS bool
+	// ReadQuorums are the quorums whose confirmation is required when verifying certs of blobs being read, see
+	// QuorumPolicy. Empty requires the same quorums as writes.
+	ReadQuorums []uint
 }
 
+// ErrCommitmentMismatch ... returned when a blob doesn't match the kzg commitment it is expected to have
+var ErrCommitmentMismatch = errors.New("kzg commitment mismatch")
+
+// ErrKZGUnavailable ... returned by KZG operations of a verifier running in cert-only mode
+var ErrKZGUnavailable = errors.New("kzg verification unavailable: SRS not loaded")
+
 // TODO: right now verification and confirmation depth are tightly coupled. we should decouple them
 type Verifier struct {
-	// kzgVerifier is needed to commit blobs to the memstore
+	// kzgVerifier is needed to commit blobs to the memstore, nil in cert-only mode
 	kzgVerifier *kzgverifier.Verifier
+	kzgErr      error
 	// cert verification is optional, and verifies certs retrieved from eigenDA when turned on
 	verifyCerts bool
 	cv          *CertVerifier
+	readQuorums QuorumPolicy
+
+	// time it took to load the KZG SRS and to set up cert verification, reported as startup phases
+	srsLoadDuration      time.Duration
+	certVerifierDuration time.Duration
 }
 
 func NewVerifier(cfg *Config, l log.Logger) (*Verifier, error) {
 	var cv *CertVerifier
 	var err error
 
+	readQuorums, err := NewQuorumPolicy(cfg.ReadQuorums)
+	if err != nil {
+		return nil, fmt.Errorf("invalid read quorum policy: %w", err)
+	}
+
+	start := time.Now()
 	if cfg.VerifyCerts {
 		cv, err = NewCertVerifier(cfg, l)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create cert verifier: %w", err)
 		}
 	}
+	certVerifierDuration := time.Since(start)
 
-	kzgVerifier, err := kzgverifier.NewVerifier(cfg.KzgConfig, false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create kzg verifier: %w", err)
+	start = time.Now()
+	var kzgVerifier *kzgverifier.Verifier
+	kzgCfg, kzgErr := prepareG1(cfg.KzgConfig, l)
+	if kzgErr == nil {
+		kzgVerifier, kzgErr = kzgverifier.NewVerifier(kzgCfg, false)
+	}
+	srsLoadDuration := time.Since(start)
+	if kzgErr != nil {
+		if !cfg.AllowMissingSRS {
+			return nil, fmt.Errorf("failed to create kzg verifier: %w", kzgErr)
+		}
+		// without local kzg checks, cert checks are the only thing standing between clients and bad data
+		if !cfg.VerifyCerts {
+			return nil, fmt.Errorf("failed to create kzg verifier, and cert-only mode requires cert verification to be enabled: %w", kzgErr)
+		}
+		if l != nil {
+			l.Warn("KZG SRS unavailable, running in degraded cert-only mode: blobs are not checked against their KZG commitments",
+				"err", kzgErr)
+		}
 	}
 
 	return &Verifier{
 		kzgVerifier: kzgVerifier,
+		kzgErr:      kzgErr,
 		verifyCerts: cfg.VerifyCerts,
 		cv:          cv,
+		readQuorums: readQuorums,
+
+		srsLoadDuration:      srsLoadDuration,
+		certVerifierDuration: certVerifierDuration,
 	}, nil
 }
 
+// LoadDurations ... returns the time it took to load the KZG SRS and to set up cert verification, i.e. to dial the
+// Ethereum RPC and check the svc manager, when the verifier was created
+func (v *Verifier) LoadDurations() (srs, certVerifier time.Duration) {
+	return v.srsLoadDuration, v.certVerifierDuration
+}
+
+// KZGEnabled ... returns whether blobs are verified against their KZG commitments, which is only
+// skipped in cert-only mode
+func (v *Verifier) KZGEnabled() bool {
+	return v.kzgVerifier != nil
+}
+
+// Degraded ... returns why the verifier runs with reduced checks, or an empty string if it doesn't
+func (v *Verifier) Degraded() string {
+	if v.kzgVerifier == nil && v.kzgErr != nil {
+		return fmt.Sprintf("cert-only mode, kzg srs unavailable: %v", v.kzgErr)
+	}
+	return ""
+}
+
+// CertVerificationEnabled ... returns whether DA certs are verified against on-chain EigenDA state
+func (v *Verifier) CertVerificationEnabled() bool {
+	return v.verifyCerts
+}
+
 // verifies V0 eigenda certificate type
+// Ping checks the connection to the ETH RPC node, if cert verification is enabled
+func (v *Verifier) Ping(ctx context.Context) error {
+	if v.cv == nil {
+		return nil
+	}
+	if _, err := v.cv.ethClient.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("failed to ping eth rpc: %w", err)
+	}
+	return nil
+}
+
+// ReadQuorumPolicy ... returns the quorum policy applied by VerifyReadCert
+func (v *Verifier) ReadQuorumPolicy() QuorumPolicy {
+	return v.readQuorums
+}
+
+// VerifyCert ... verifies a cert returned by the disperser, requiring the quorums required by the EigenDA service
+// manager
 func (v *Verifier) VerifyCert(cert *Certificate) error {
+	return v.verifyCert(cert, nil)
+}
+
+// VerifyReadCert ... verifies the cert of a blob being read, requiring the quorums of the read quorum policy
+func (v *Verifier) VerifyReadCert(cert *Certificate) error {
+	return v.verifyCert(cert, v.readQuorums)
+}
+
+func (v *Verifier) verifyCert(cert *Certificate, quorums QuorumPolicy) error {
 	if !v.verifyCerts {
 		return nil
 	}
@@ -83,7 +190,7 @@ func (v *Verifier) VerifyCert(cert *Certificate) error {
 	}
 
 	// 3 - verify security parameters
-	err = v.VerifySecurityParams(cert.ReadBlobHeader(), header)
+	err = v.verifySecurityParams(cert.ReadBlobHeader(), header, quorums)
 	if err != nil {
 		return fmt.Errorf("failed to verify security parameters: %w", err)
 	}
@@ -93,6 +200,10 @@ func (v *Verifier) VerifyCert(cert *Certificate) error {
 
 // compute kzg-bn254 commitment of raw blob data using SRS
 func (v *Verifier) Commit(blob []byte) (*bn254.G1Affine, error) {
+	if v.kzgVerifier == nil {
+		return nil, ErrKZGUnavailable
+	}
+
 	inputFr, err := rs.ToFrArray(blob)
 	if err != nil {
 		return nil, fmt.Errorf("cannot convert bytes to field elements, %w", err)
@@ -121,6 +232,11 @@ func (v *Verifier) VerifyCommitment(expectedCommit *common.G1Commitment, blob []
 		return err
 	}
 
+	return CompareCommitment(expectedCommit, actualCommit)
+}
+
+// CompareCommitment asserts that a commitment computed locally equals the commitment in a certificate
+func CompareCommitment(expectedCommit *common.G1Commitment, actualCommit *bn254.G1Affine) error {
 	expectedX := &fp.Element{}
 	expectedX.Unmarshal(expectedCommit.X)
 	expectedY := &fp.Element{}
@@ -130,7 +246,7 @@ func (v *Verifier) VerifyCommitment(expectedCommit *common.G1Commitment, blob []
 	if !actualCommit.X.Equal(expectedX) || !actualCommit.Y.Equal(expectedY) {
 		errMsg += fmt.Sprintf("field elements do not match, x actual commit: %x, x expected commit: %x, ", actualCommit.X.Marshal(), expectedX.Marshal())
 		errMsg += fmt.Sprintf("y actual commit: %x, y expected commit: %x", actualCommit.Y.Marshal(), expectedY.Marshal())
-		return fmt.Errorf("%s", errMsg)
+		return fmt.Errorf("%w: %s", ErrCommitmentMismatch, errMsg)
 	}
 
 	return nil
@@ -138,6 +254,14 @@ func (v *Verifier) VerifyCommitment(expectedCommit *common.G1Commitment, blob []
 
 // VerifySecurityParams ensures that returned security parameters are valid
 func (v *Verifier) VerifySecurityParams(blobHeader BlobHeader, batchHeader binding.IEigenDAServiceManagerBatchHeader) error {
+	return v.verifySecurityParams(blobHeader, batchHeader, nil)
+}
+
+// verifySecurityParams ensures that returned security parameters are valid for the quorums required by the
+// policy. With the default policy, the security params of every quorum must be met, and the quorums required
+// by the service manager must be confirmed.
+func (v *Verifier) verifySecurityParams(blobHeader BlobHeader, batchHeader binding.IEigenDAServiceManagerBatchHeader,
+	policy QuorumPolicy) error {
 	confirmedQuorums := make(map[uint8]bool)
 
 	// require that the security param in each blob is met
@@ -146,38 +270,55 @@ func (v *Verifier) VerifySecurityParams(blobHeader BlobHeader, batchHeader bindi
 			return fmt.Errorf("quorum number mismatch, expected: %d, got: %d", batchHeader.QuorumNumbers[i], blobHeader.QuorumBlobParams[i].QuorumNumber)
 		}
 
-		if blobHeader.QuorumBlobParams[i].AdversaryThresholdPercentage > blobHeader.QuorumBlobParams[i].ConfirmationThresholdPercentage {
-			return fmt.Errorf("adversary threshold percentage must be greater than or equal to confirmation threshold percentage")
+		quorum := blobHeader.QuorumBlobParams[i].QuorumNumber
+		if err := v.verifyQuorumParams(blobHeader.QuorumBlobParams[i], batchHeader.SignedStakeForQuorums[i]); err != nil {
+			if len(policy) == 0 || policy.requires(quorum) {
+				return fmt.Errorf("quorum %d: %w", quorum, err)
+			}
+			log.Debug("Ignoring quorum not required by the read quorum policy", "quorum", quorum, "err", err)
+			continue
 		}
 
-		quorumAdversaryThreshold, err := v.getQuorumAdversaryThreshold(blobHeader.QuorumBlobParams[i].QuorumNumber)
+		confirmedQuorums[quorum] = true
+	}
+
+	requiredQuorums := []uint8(policy)
+	if len(policy) == 0 {
+		var err error
+		requiredQuorums, err = v.cv.manager.QuorumNumbersRequired(nil)
 		if err != nil {
-			log.Warn("failed to get quorum adversary threshold", "err", err)
+			log.Warn("failed to get required quorum numbers", "err", err)
 		}
+	}
 
-		if quorumAdversaryThreshold > 0 && blobHeader.QuorumBlobParams[i].AdversaryThresholdPercentage < quorumAdversaryThreshold {
-			return fmt.Errorf("adversary threshold percentage must be greater than or equal to quorum adversary threshold percentage")
+	// ensure that required quorums are present in the confirmed ones
+	for _, quorum := range requiredQuorums {
+		if !confirmedQuorums[quorum] {
+			return fmt.Errorf("quorum %d is required but not present in confirmed quorums", quorum)
 		}
+	}
 
-		if batchHeader.SignedStakeForQuorums[i] < blobHeader.QuorumBlobParams[i].ConfirmationThresholdPercentage {
-			return fmt.Errorf("signed stake for quorum must be greater than or equal to confirmation threshold percentage")
-		}
+	return nil
+}
 
-		confirmedQuorums[blobHeader.QuorumBlobParams[i].QuorumNumber] = true
+// verifyQuorumParams ensures that the security params of a single quorum are met
+func (v *Verifier) verifyQuorumParams(params QuorumBlobParam, signedStake uint8) error {
+	if params.AdversaryThresholdPercentage > params.ConfirmationThresholdPercentage {
+		return fmt.Errorf("adversary threshold percentage must be greater than or equal to confirmation threshold percentage")
 	}
 
-	requiredQuorums, err := v.cv.manager.QuorumNumbersRequired(nil)
+	quorumAdversaryThreshold, err := v.getQuorumAdversaryThreshold(params.QuorumNumber)
 	if err != nil {
-		log.Warn("failed to get required quorum numbers", "err", err)
+		log.Warn("failed to get quorum adversary threshold", "err", err)
 	}
 
-	// ensure that required quorums are present in the confirmed ones
-	for _, quorum := range requiredQuorums {
-		if !confirmedQuorums[quorum] {
-			return fmt.Errorf("quorum %d is required but not present in confirmed quorums", quorum)
-		}
+	if quorumAdversaryThreshold > 0 && params.AdversaryThresholdPercentage < quorumAdversaryThreshold {
+		return fmt.Errorf("adversary threshold percentage must be greater than or equal to quorum adversary threshold percentage")
 	}
 
+	if signedStake < params.ConfirmationThresholdPercentage {
+		return fmt.Errorf("signed stake for quorum must be greater than or equal to confirmation threshold percentage")
+	}
 	return nil
 }
 
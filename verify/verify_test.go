@@ -58,7 +58,15 @@ func TestCommitmentVerification(t *testing.T) {
 	fakeData, err := codec.EncodeBlob([]byte("I am an imposter!!"))
 	require.NoError(t, err)
 	err = v.VerifyCommitment(c, fakeData)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+	// precomputed commitments are compared the same way
+	commitment, err := v.Commit(blob)
+	require.NoError(t, err)
+	require.NoError(t, CompareCommitment(c, commitment))
+	fakeCommitment, err := v.Commit(fakeData)
+	require.NoError(t, err)
+	require.ErrorIs(t, CompareCommitment(c, fakeCommitment), ErrCommitmentMismatch)
 }
 
 func TestCommitmentWithTooLargeBlob(t *testing.T) {
@@ -110,3 +118,38 @@ func TestCommitmentWithTooLargeBlob(t *testing.T) {
 	require.EqualError(t, err, msg)
 
 }
+
+func TestCertOnlyMode(t *testing.T) {
+	t.Parallel()
+
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          "../resources/missing.point",
+		G2PowerOf2Path:  "../resources/g2.point.powerOf2",
+		CacheDir:        "../resources/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}
+
+	// missing SRS files are fatal by default
+	_, err := NewVerifier(&Config{KzgConfig: kzgConfig}, nil)
+	require.Error(t, err)
+
+	// and cert-only mode requires cert verification
+	_, err = NewVerifier(&Config{KzgConfig: kzgConfig, AllowMissingSRS: true}, nil)
+	require.Error(t, err)
+
+	v, err := NewVerifier(&Config{
+		KzgConfig:       kzgConfig,
+		AllowMissingSRS: true,
+		VerifyCerts:     true,
+		RPCURL:          "http://localhost:8545",
+		SvcManagerAddr:  "0x0000000000000000000000000000000000000000",
+	}, nil)
+	require.NoError(t, err)
+	require.False(t, v.KZGEnabled())
+	require.NotEmpty(t, v.Degraded())
+
+	_, err = v.Commit([]byte("data"))
+	require.ErrorIs(t, err, ErrKZGUnavailable)
+}